@@ -0,0 +1,376 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+const (
+	allowListFetchTimeout = 30 * time.Second
+	allowListMaxSize      = 16 << 20
+)
+
+var errAllowListReadOnly = errors.New("allow list is fetched from a URL and cannot be modified")
+
+// An allowList is the set of device IDs that may use the discovery server.
+// It is read from a file or fetched from an HTTP(S) URL, one device ID per
+// line with "#" starting a comment, and reloaded periodically. A file based
+// list can also be modified through the admin API.
+type allowList struct {
+	source         string
+	isURL          bool
+	reloadInterval time.Duration
+	client         *http.Client
+
+	mut     sync.RWMutex
+	devices map[protocol.DeviceID]struct{}
+	modTime time.Time // of the file, when last loaded
+	etag    string    // of the URL, when last loaded
+}
+
+func newAllowList(source string, reloadInterval time.Duration) (*allowList, error) {
+	a := &allowList{
+		source:         source,
+		isURL:          strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"),
+		reloadInterval: reloadInterval,
+		client:         &http.Client{Timeout: allowListFetchTimeout},
+	}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *allowList) String() string {
+	return fmt.Sprintf("allowList@%s", a.source)
+}
+
+// allowed returns whether the given device is on the list. A nil list
+// allows everything.
+func (a *allowList) allowed(id protocol.DeviceID) bool {
+	if a == nil {
+		return true
+	}
+	a.mut.RLock()
+	_, ok := a.devices[id]
+	a.mut.RUnlock()
+	return ok
+}
+
+func (a *allowList) list() []protocol.DeviceID {
+	a.mut.RLock()
+	defer a.mut.RUnlock()
+	ids := make([]protocol.DeviceID, 0, len(a.devices))
+	for id := range a.devices {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b protocol.DeviceID) int { return a.Compare(b) })
+	return ids
+}
+
+func (a *allowList) Serve(ctx context.Context) error {
+	if a.reloadInterval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	t := time.NewTicker(a.reloadInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := a.reload(); err != nil {
+				// Keep using what we have; a temporarily unreachable URL
+				// or half written file shouldn't lock everyone out.
+				log.Println("Reloading allow list:", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reload reads the list again if it has changed since it was last loaded.
+func (a *allowList) reload() error {
+	if a.isURL {
+		return a.reloadURL()
+	}
+	return a.reloadFile()
+}
+
+func (a *allowList) reloadFile() error {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	info, err := os.Stat(a.source)
+	if err != nil {
+		return err
+	}
+	if a.devices != nil && info.ModTime().Equal(a.modTime) {
+		return nil
+	}
+	bs, err := os.ReadFile(a.source)
+	if err != nil {
+		return err
+	}
+	devices, err := parseAllowList(bytes.NewReader(bs))
+	if err != nil {
+		return err
+	}
+	a.setDevicesLocked(devices)
+	a.modTime = info.ModTime()
+	return nil
+}
+
+func (a *allowList) reloadURL() error {
+	req, err := http.NewRequest(http.MethodGet, a.source, nil)
+	if err != nil {
+		return err
+	}
+	a.mut.RLock()
+	if a.etag != "" {
+		req.Header.Set("If-None-Match", a.etag)
+	}
+	a.mut.RUnlock()
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil
+	default:
+		return fmt.Errorf("fetching %s: %s", a.source, resp.Status)
+	}
+
+	devices, err := parseAllowList(io.LimitReader(resp.Body, allowListMaxSize))
+	if err != nil {
+		return err
+	}
+
+	a.mut.Lock()
+	a.setDevicesLocked(devices)
+	a.etag = resp.Header.Get("ETag")
+	a.mut.Unlock()
+	return nil
+}
+
+func (a *allowList) setDevicesLocked(devices map[protocol.DeviceID]struct{}) {
+	if a.devices != nil && len(devices) != len(a.devices) {
+		log.Printf("Allow list changed, now %d devices", len(devices))
+	}
+	a.devices = devices
+	allowListDevices.Set(float64(len(devices)))
+}
+
+// add puts the device on the list, appending it to the file.
+func (a *allowList) add(id protocol.DeviceID) error {
+	if a.isURL {
+		return errAllowListReadOnly
+	}
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	if _, ok := a.devices[id]; ok {
+		return nil
+	}
+	err := a.editFileLocked(func(lines []string) []string {
+		return append(lines, id.String())
+	})
+	if err != nil {
+		return err
+	}
+	devices := make(map[protocol.DeviceID]struct{}, len(a.devices)+1)
+	for dev := range a.devices {
+		devices[dev] = struct{}{}
+	}
+	devices[id] = struct{}{}
+	a.setDevicesLocked(devices)
+	return nil
+}
+
+// remove takes the device off the list, removing the lines with its ID
+// from the file. Comments and other entries are kept as they are.
+func (a *allowList) remove(id protocol.DeviceID) error {
+	if a.isURL {
+		return errAllowListReadOnly
+	}
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	if _, ok := a.devices[id]; !ok {
+		return nil
+	}
+	err := a.editFileLocked(func(lines []string) []string {
+		return slices.DeleteFunc(lines, func(line string) bool {
+			lineID, ok := parseAllowListLine(line)
+			return ok && lineID == id
+		})
+	})
+	if err != nil {
+		return err
+	}
+	devices := make(map[protocol.DeviceID]struct{}, len(a.devices))
+	for dev := range a.devices {
+		if dev != id {
+			devices[dev] = struct{}{}
+		}
+	}
+	a.setDevicesLocked(devices)
+	return nil
+}
+
+// editFileLocked rewrites the allow list file with the lines returned from
+// fn. The file is replaced atomically so that a concurrent reload, or
+// another reader, never sees a partial list.
+func (a *allowList) editFileLocked(fn func(lines []string) []string) error {
+	bs, err := os.ReadFile(a.source)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var lines []string
+	if len(bs) > 0 {
+		lines = strings.Split(strings.TrimRight(string(bs), "\n"), "\n")
+	}
+	lines = fn(lines)
+
+	tmp, err := os.CreateTemp(filepath.Dir(a.source), filepath.Base(a.source)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(strings.Join(lines, "\n") + "\n"); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), a.source); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(a.source); err == nil {
+		a.modTime = info.ModTime()
+	}
+	return nil
+}
+
+func parseAllowList(r io.Reader) (map[protocol.DeviceID]struct{}, error) {
+	devices := make(map[protocol.DeviceID]struct{})
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		id, ok := parseAllowListLine(sc.Text())
+		if !ok {
+			if strings.TrimSpace(stripComment(sc.Text())) != "" {
+				return nil, fmt.Errorf("line %d: invalid device ID", lineNo)
+			}
+			continue
+		}
+		devices[id] = struct{}{}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return devices, nil
+}
+
+func parseAllowListLine(line string) (protocol.DeviceID, bool) {
+	line = strings.TrimSpace(stripComment(line))
+	if line == "" {
+		return protocol.EmptyDeviceID, false
+	}
+	id, err := protocol.DeviceIDFromString(line)
+	if err != nil {
+		return protocol.EmptyDeviceID, false
+	}
+	return id, true
+}
+
+func stripComment(line string) string {
+	line, _, _ = strings.Cut(line, "#")
+	return line
+}
+
+// A clientLimiter enforces a request rate per client, on top of the
+// Retry-After based pacing the server asks well behaved clients to follow.
+type clientLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mut      sync.Mutex
+	limiters map[string]*rate.Limiter
+	lastGC   time.Time
+}
+
+const clientLimiterGCInterval = 5 * time.Minute
+
+func newClientLimiter(perSecond float64, burst int) *clientLimiter {
+	return &clientLimiter{
+		limit:    rate.Limit(perSecond),
+		burst:    max(burst, 1),
+		limiters: make(map[string]*rate.Limiter),
+		lastGC:   time.Now(),
+	}
+}
+
+// allow returns whether the client may make a request now. If not, it
+// also returns the number of seconds the client should wait.
+func (c *clientLimiter) allow(client string) (bool, int) {
+	if c == nil {
+		return true, 0
+	}
+
+	now := time.Now()
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	if now.Sub(c.lastGC) > clientLimiterGCInterval {
+		// Forget clients that have been idle long enough to have a full
+		// bucket again anyway.
+		for key, lim := range c.limiters {
+			if lim.TokensAt(now) >= float64(c.burst) {
+				delete(c.limiters, key)
+			}
+		}
+		c.lastGC = now
+	}
+
+	lim, ok := c.limiters[client]
+	if !ok {
+		lim = rate.NewLimiter(c.limit, c.burst)
+		c.limiters[client] = lim
+	}
+	if lim.AllowN(now, 1) {
+		return true, 0
+	}
+	wait := time.Duration(float64(time.Second) * (1 - lim.TokensAt(now)) / float64(c.limit))
+	return false, max(int(wait.Round(time.Second)/time.Second), 1)
+}
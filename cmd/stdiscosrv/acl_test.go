@@ -0,0 +1,254 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/tlsutil"
+)
+
+func TestAllowListFile(t *testing.T) {
+	t.Parallel()
+
+	dev1 := protocol.DeviceID{1}
+	dev2 := protocol.DeviceID{2}
+	dev3 := protocol.DeviceID{3}
+
+	name := filepath.Join(t.TempDir(), "allow.txt")
+	initial := "# our fleet\n" + dev1.String() + "\n\n" + dev2.String() + " # laptop\n"
+	if err := os.WriteFile(name, []byte(initial), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	al, err := newAllowList(name, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !al.allowed(dev1) || !al.allowed(dev2) || al.allowed(dev3) {
+		t.Fatal("unexpected initial allow list", al.list())
+	}
+
+	if err := al.add(dev3); err != nil {
+		t.Fatal(err)
+	}
+	if err := al.remove(dev2); err != nil {
+		t.Fatal(err)
+	}
+	if !al.allowed(dev1) || al.allowed(dev2) || !al.allowed(dev3) {
+		t.Fatal("unexpected allow list after edit", al.list())
+	}
+	bs, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "# our fleet\n" + dev1.String() + "\n\n" + dev3.String() + "\n"; string(bs) != exp {
+		t.Errorf("unexpected file contents after edit:\n%s", bs)
+	}
+
+	// Changes made to the file are picked up on reload.
+	if err := os.WriteFile(name, []byte(dev2.String()+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(name, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if err := al.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if al.allowed(dev1) || !al.allowed(dev2) {
+		t.Fatal("unexpected allow list after reload", al.list())
+	}
+
+	// A broken file is an error, and the previous list stays in effect.
+	if err := os.WriteFile(name, []byte("nope\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(name, future.Add(time.Minute), future.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := al.reload(); err == nil {
+		t.Error("expected error for invalid allow list")
+	}
+	if !al.allowed(dev2) {
+		t.Error("expected previous allow list to remain")
+	}
+}
+
+func TestAllowListURL(t *testing.T) {
+	t.Parallel()
+
+	dev1 := protocol.DeviceID{1}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(dev1.String() + "\n"))
+	}))
+	defer srv.Close()
+
+	al, err := newAllowList(srv.URL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !al.allowed(dev1) {
+		t.Error("expected device to be allowed")
+	}
+	if err := al.add(protocol.DeviceID{2}); err != errAllowListReadOnly {
+		t.Errorf("expected read only error, got %v", err)
+	}
+}
+
+func TestAPISrvAllowList(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	crt, err := tlsutil.NewCertificate(filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem"), "localhost", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certHeader := base64.StdEncoding.EncodeToString(crt.Certificate[0])
+	allowedID := protocol.NewDeviceID(crt.Certificate[0])
+	otherID := protocol.DeviceID{42}
+
+	name := filepath.Join(dir, "allow.txt")
+	if err := os.WriteFile(name, []byte(allowedID.String()+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	al, err := newAllowList(name, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := newInMemoryStore(dir, 0, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go db.Serve(ctx)
+	if err := db.merge(&otherID, []DatabaseAddress{{Address: "tcp://10.0.0.1:22000", Expires: time.Now().Add(time.Hour).UnixNano()}}, time.Now().UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+
+	api := newAPISrv("127.0.0.1:0", tls.Certificate{}, db, nil, true, false)
+	api.allowList = al
+
+	do := func(method, device string, withCert bool) *httptest.ResponseRecorder {
+		var body *strings.Reader
+		if method == http.MethodPost {
+			body = strings.NewReader(`{"addresses":["tcp://10.0.0.2:22000"]}`)
+		} else {
+			body = strings.NewReader("")
+		}
+		req := httptest.NewRequest(method, "/v2/?device="+device, body)
+		req.Header.Set("X-Forwarded-For", "192.0.2.1")
+		if withCert {
+			req.Header.Set("X-Forwarded-Tls-Client-Cert", certHeader)
+		}
+		w := httptest.NewRecorder()
+		api.handler(w, req)
+		return w
+	}
+
+	if w := do(http.MethodPost, "", true); w.Code != http.StatusNoContent {
+		t.Errorf("announce from allowed device: got %d", w.Code)
+	}
+	if w := do(http.MethodGet, allowedID.String(), false); w.Code != http.StatusOK {
+		t.Errorf("lookup of allowed device: got %d", w.Code)
+	}
+	if w := do(http.MethodGet, otherID.String(), false); w.Code != http.StatusNotFound {
+		t.Errorf("lookup of other device: got %d", w.Code)
+	}
+
+	must(t, al.remove(allowedID))
+	if w := do(http.MethodPost, "", true); w.Code != http.StatusForbidden {
+		t.Errorf("announce from removed device: got %d", w.Code)
+	}
+
+	must(t, al.add(allowedID))
+	api.lookupNeedsCert = true
+	if w := do(http.MethodGet, allowedID.String(), false); w.Code != http.StatusForbidden {
+		t.Errorf("lookup without certificate: got %d", w.Code)
+	}
+	if w := do(http.MethodGet, allowedID.String(), true); w.Code != http.StatusOK {
+		t.Errorf("lookup with certificate: got %d", w.Code)
+	}
+
+	api.limiter = newClientLimiter(0.001, 2)
+	for i := 0; i < 2; i++ {
+		if w := do(http.MethodGet, allowedID.String(), true); w.Code != http.StatusOK {
+			t.Errorf("lookup within burst: got %d", w.Code)
+		}
+	}
+	w := do(http.MethodGet, allowedID.String(), true)
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("lookup over limit: got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After when rate limited")
+	}
+	if w := do(http.MethodGet, otherID.String(), false); w.Code != http.StatusTooManyRequests {
+		t.Errorf("lookup over limit from the same address without certificate: got %d", w.Code)
+	}
+}
+
+func TestAdminSrv(t *testing.T) {
+	t.Parallel()
+
+	name := filepath.Join(t.TempDir(), "allow.txt")
+	if err := os.WriteFile(name, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	al, err := newAllowList(name, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newAdminSrv("127.0.0.1:0", "secret", al).handler()
+	dev := protocol.DeviceID{7}
+
+	do := func(method, path, token string) int {
+		req := httptest.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	if code := do(http.MethodPut, "/allowlist/"+dev.String(), "wrong"); code != http.StatusUnauthorized {
+		t.Errorf("bad token: got %d", code)
+	}
+	if code := do(http.MethodPut, "/allowlist/nope", "secret"); code != http.StatusBadRequest {
+		t.Errorf("bad device: got %d", code)
+	}
+	if code := do(http.MethodPut, "/allowlist/"+dev.String(), "secret"); code != http.StatusNoContent {
+		t.Errorf("add: got %d", code)
+	}
+	if !al.allowed(dev) {
+		t.Error("expected device to be added")
+	}
+	if code := do(http.MethodDelete, "/allowlist/"+dev.String(), "secret"); code != http.StatusNoContent {
+		t.Errorf("remove: got %d", code)
+	}
+	if al.allowed(dev) {
+		t.Error("expected device to be removed")
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// The adminSrv serves a small API for managing the allow list:
+//
+//	GET    /allowlist           - list the allowed device IDs
+//	PUT    /allowlist/<device>  - add a device
+//	DELETE /allowlist/<device>  - remove a device
+//
+// Requests must carry the configured token as "Authorization: Bearer
+// <token>". The admin API should listen on a private address only.
+type adminSrv struct {
+	addr      string
+	token     string
+	allowList *allowList
+}
+
+func newAdminSrv(addr, token string, allowList *allowList) *adminSrv {
+	return &adminSrv{
+		addr:      addr,
+		token:     token,
+		allowList: allowList,
+	}
+}
+
+func (s *adminSrv) Serve(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		log.Println("Admin listen:", err)
+		return err
+	}
+
+	srv := &http.Server{
+		Handler:        s.handler(),
+		ReadTimeout:    httpReadTimeout,
+		WriteTimeout:   httpWriteTimeout,
+		MaxHeaderBytes: httpMaxHeaderBytes,
+	}
+
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+
+	err = srv.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	log.Println("Admin serve:", err)
+	return err
+}
+
+func (s *adminSrv) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /allowlist", s.handleList)
+	mux.HandleFunc("PUT /allowlist/{device}", s.handleEdit(s.allowList.add))
+	mux.HandleFunc("DELETE /allowlist/{device}", s.handleEdit(s.allowList.remove))
+	return s.authenticated(mux)
+}
+
+func (s *adminSrv) authenticated(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+func (s *adminSrv) handleList(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.allowList.list())
+}
+
+func (*adminSrv) handleEdit(fn func(protocol.DeviceID) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		id, err := protocol.DeviceIDFromString(req.PathValue("device"))
+		if err != nil {
+			http.Error(w, "Bad Request", http.StatusBadRequest)
+			return
+		}
+		if err := fn(id); errors.Is(err, errAllowListReadOnly) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		} else if err != nil {
+			log.Println("Updating allow list:", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		log.Println("Allow list:", req.Method, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
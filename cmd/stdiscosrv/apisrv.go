@@ -49,6 +49,10 @@ type apiSrv struct {
 	gzipWriters    sync.Pool
 	seenTracker    *retryAfterTracker
 	notSeenTracker *retryAfterTracker
+
+	allowList       *allowList     // optional; devices that may announce and be looked up
+	lookupNeedsCert bool           // lookups must present a certificate on the allow list
+	limiter         *clientLimiter // optional
 }
 
 type replicator interface {
@@ -179,6 +183,18 @@ func (s *apiSrv) handler(w http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	if s.limiter != nil {
+		if ok, afterS := s.limiter.allow(clientKey(remoteAddr)); !ok {
+			if debug {
+				log.Println(reqID, "rate limited")
+			}
+			rateLimitedTotal.WithLabelValues(req.Method).Inc()
+			lw.Header().Set("Retry-After", strconv.Itoa(afterS))
+			http.Error(lw, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
+	}
+
 	switch req.Method {
 	case http.MethodGet:
 		s.handleGET(lw, req)
@@ -203,6 +219,28 @@ func (s *apiSrv) handleGET(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if s.lookupNeedsCert {
+		rawCert, err := certificateBytes(req)
+		if err != nil || !s.allowList.allowed(protocol.NewDeviceID(rawCert)) {
+			if debug {
+				log.Println(reqID, "lookup without allowed certificate")
+			}
+			lookupRequestsTotal.WithLabelValues("forbidden").Inc()
+			w.Header().Set("Retry-After", errorRetryAfterString())
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+	}
+
+	if !s.allowList.allowed(deviceID) {
+		// Answer as if we had never heard of the device, so as to not
+		// reveal which devices are on the list.
+		lookupRequestsTotal.WithLabelValues("not_allowed").Inc()
+		w.Header().Set("Retry-After", strconv.Itoa(s.notSeenTracker.retryAfterS()))
+		http.Error(w, "Not Found", http.StatusNotFound)
+		return
+	}
+
 	rec, err := s.db.get(&deviceID)
 	if err != nil {
 		// some sort of internal error
@@ -277,6 +315,15 @@ func (s *apiSrv) handlePOST(remoteAddr *net.TCPAddr, w http.ResponseWriter, req
 	}
 
 	deviceID := protocol.NewDeviceID(rawCert)
+	if !s.allowList.allowed(deviceID) {
+		if debug {
+			log.Println(reqID, "device not on allow list:", deviceID)
+		}
+		announceRequestsTotal.WithLabelValues("not_allowed").Inc()
+		w.Header().Set("Retry-After", errorRetryAfterString())
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
 
 	addresses := fixupAddresses(remoteAddr, ann.Addresses)
 	if len(addresses) == 0 {
@@ -325,6 +372,19 @@ func (s *apiSrv) handleAnnounce(deviceID protocol.DeviceID, addresses []string)
 	return s.db.merge(&deviceID, dbAddrs, seen)
 }
 
+// clientKey identifies the client for rate limiting purposes by its IP
+// address. IPv6 clients usually have a whole /64 to pick addresses from, so
+// they are identified by that prefix.
+func clientKey(remoteAddr *net.TCPAddr) string {
+	if ip4 := remoteAddr.IP.To4(); ip4 != nil {
+		return ip4.String()
+	}
+	if len(remoteAddr.IP) == net.IPv6len {
+		return remoteAddr.IP.Mask(net.CIDRMask(64, 128)).String() + "/64"
+	}
+	return remoteAddr.IP.String()
+}
+
 func handlePing(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(204)
 }
@@ -99,6 +99,26 @@ func TestFixupAddresses(t *testing.T) {
 	}
 }
 
+func TestClientKey(t *testing.T) {
+	cases := []struct {
+		remote *net.TCPAddr
+		key    string
+	}{
+		{addr("192.0.2.42", 22000), "192.0.2.42"},
+		{addr("::ffff:192.0.2.42", 22000), "192.0.2.42"},
+		{addr("2001:db8:1:2:3:4:5:6", 22000), "2001:db8:1:2::/64"},
+		{addr("2001:db8:1:2:ffff::1", 22000), "2001:db8:1:2::/64"},
+		{addr("2001:db8:1:3::1", 22000), "2001:db8:1:3::/64"},
+		{addr("", 0), "<nil>"},
+	}
+
+	for _, tc := range cases {
+		if key := clientKey(tc.remote); key != tc.key {
+			t.Errorf("clientKey(%v) => %q, expected %q", tc.remote, key, tc.key)
+		}
+	}
+}
+
 func addr(host string, port int) *net.TCPAddr {
 	return &net.TCPAddr{
 		IP:   net.ParseIP(host),
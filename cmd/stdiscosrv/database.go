@@ -28,6 +28,7 @@ import (
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/rand"
 	"github.com/syncthing/syncthing/lib/s3"
+	"github.com/thejerf/suture/v4"
 )
 
 type clock interface {
@@ -46,6 +47,13 @@ type database interface {
 	get(key *protocol.DeviceID) (DatabaseRecord, error)
 }
 
+// A store is a database that runs as a service, expiring old records and
+// keeping the statistics up to date.
+type store interface {
+	database
+	suture.Service
+}
+
 type inMemoryStore struct {
 	m             *xsync.MapOf[protocol.DeviceID, DatabaseRecord]
 	dir           string
@@ -170,10 +178,7 @@ loop:
 }
 
 func (s *inMemoryStore) expireAndCalculateStatistics() {
-	now := s.clock.Now()
-	cutoff24h := now.Add(-24 * time.Hour).UnixNano()
-	cutoff1w := now.Add(-7 * 24 * time.Hour).UnixNano()
-	current, currentIPv4, currentIPv6, currentIPv6GUA, last24h, last1w := 0, 0, 0, 0, 0, 0
+	stats := newRecordStatistics(s.clock.Now())
 
 	n := 0
 	s.m.Range(func(key protocol.DeviceID, rec DatabaseRecord) bool {
@@ -182,7 +187,7 @@ func (s *inMemoryStore) expireAndCalculateStatistics() {
 		}
 		n++
 
-		addresses := expire(rec.Addresses, now)
+		addresses := expire(rec.Addresses, stats.now)
 		if len(addresses) == 0 {
 			rec.Addresses = nil
 			s.m.Store(key, rec)
@@ -191,53 +196,82 @@ func (s *inMemoryStore) expireAndCalculateStatistics() {
 			s.m.Store(key, rec)
 		}
 
-		switch {
-		case len(rec.Addresses) > 0:
-			current++
-			seenIPv4, seenIPv6, seenIPv6GUA := false, false, false
-			for _, addr := range rec.Addresses {
-				// We do fast and loose matching on strings here instead of
-				// parsing the address and the IP and doing "proper" checks,
-				// to keep things fast and generate less garbage.
-				if strings.Contains(addr.Address, "[") {
-					seenIPv6 = true
-					if strings.Contains(addr.Address, "[2") {
-						seenIPv6GUA = true
-					}
-				} else {
-					seenIPv4 = true
-				}
-				if seenIPv4 && seenIPv6 && seenIPv6GUA {
-					break
-				}
-			}
-			if seenIPv4 {
-				currentIPv4++
-			}
-			if seenIPv6 {
-				currentIPv6++
-			}
-			if seenIPv6GUA {
-				currentIPv6GUA++
-			}
-		case rec.Seen > cutoff24h:
-			last24h++
-		case rec.Seen > cutoff1w:
-			last1w++
-		default:
+		if !stats.add(rec) {
 			// drop the record if it's older than a week
 			s.m.Delete(key)
 		}
 		return true
 	})
 
-	databaseKeys.WithLabelValues("current").Set(float64(current))
-	databaseKeys.WithLabelValues("currentIPv4").Set(float64(currentIPv4))
-	databaseKeys.WithLabelValues("currentIPv6").Set(float64(currentIPv6))
-	databaseKeys.WithLabelValues("currentIPv6GUA").Set(float64(currentIPv6GUA))
-	databaseKeys.WithLabelValues("last24h").Set(float64(last24h))
-	databaseKeys.WithLabelValues("last1w").Set(float64(last1w))
-	databaseStatisticsSeconds.Set(time.Since(now).Seconds())
+	stats.publish()
+}
+
+// recordStatistics counts the records of a database by how recently they
+// were seen.
+type recordStatistics struct {
+	now                                                                time.Time
+	cutoff24h, cutoff1w                                                int64
+	current, currentIPv4, currentIPv6, currentIPv6GUA, last24h, last1w int
+}
+
+func newRecordStatistics(now time.Time) *recordStatistics {
+	return &recordStatistics{
+		now:       now,
+		cutoff24h: now.Add(-24 * time.Hour).UnixNano(),
+		cutoff1w:  now.Add(-7 * 24 * time.Hour).UnixNano(),
+	}
+}
+
+// add counts the record, with addresses already expired. It returns false
+// if the record is older than a week and should be dropped.
+func (s *recordStatistics) add(rec DatabaseRecord) bool {
+	switch {
+	case len(rec.Addresses) > 0:
+		s.current++
+		seenIPv4, seenIPv6, seenIPv6GUA := false, false, false
+		for _, addr := range rec.Addresses {
+			// We do fast and loose matching on strings here instead of
+			// parsing the address and the IP and doing "proper" checks,
+			// to keep things fast and generate less garbage.
+			if strings.Contains(addr.Address, "[") {
+				seenIPv6 = true
+				if strings.Contains(addr.Address, "[2") {
+					seenIPv6GUA = true
+				}
+			} else {
+				seenIPv4 = true
+			}
+			if seenIPv4 && seenIPv6 && seenIPv6GUA {
+				break
+			}
+		}
+		if seenIPv4 {
+			s.currentIPv4++
+		}
+		if seenIPv6 {
+			s.currentIPv6++
+		}
+		if seenIPv6GUA {
+			s.currentIPv6GUA++
+		}
+	case rec.Seen > s.cutoff24h:
+		s.last24h++
+	case rec.Seen > s.cutoff1w:
+		s.last1w++
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *recordStatistics) publish() {
+	databaseKeys.WithLabelValues("current").Set(float64(s.current))
+	databaseKeys.WithLabelValues("currentIPv4").Set(float64(s.currentIPv4))
+	databaseKeys.WithLabelValues("currentIPv6").Set(float64(s.currentIPv6))
+	databaseKeys.WithLabelValues("currentIPv6GUA").Set(float64(s.currentIPv6GUA))
+	databaseKeys.WithLabelValues("last24h").Set(float64(s.last24h))
+	databaseKeys.WithLabelValues("last1w").Set(float64(s.last1w))
+	databaseStatisticsSeconds.Set(time.Since(s.now).Seconds())
 }
 
 func (s *inMemoryStore) write() (err error) {
@@ -332,7 +366,17 @@ func (s *inMemoryStore) read() (int, error) {
 	}
 	defer fd.Close()
 
-	br := bufio.NewReader(fd)
+	return readRecords(fd, func(key protocol.DeviceID, rec DatabaseRecord) error {
+		rec.Addresses = expire(rec.Addresses, s.clock.Now())
+		s.m.Store(key, rec)
+		return nil
+	})
+}
+
+// readRecords reads a database file in the format written by
+// inMemoryStore.write, calling fn for each valid record.
+func readRecords(r io.Reader, fn func(protocol.DeviceID, DatabaseRecord) error) (int, error) {
+	br := bufio.NewReader(r)
 	var buf []byte
 	nr := 0
 	for {
@@ -364,10 +408,12 @@ func (s *inMemoryStore) read() (int, error) {
 
 		slices.SortFunc(rec.Addresses, DatabaseAddress.Cmp)
 		rec.Addresses = slices.CompactFunc(rec.Addresses, DatabaseAddress.Equal)
-		s.m.Store(key, DatabaseRecord{
-			Addresses: expire(rec.Addresses, s.clock.Now()),
+		if err := fn(key, DatabaseRecord{
+			Addresses: rec.Addresses,
 			Seen:      rec.Seen,
-		})
+		}); err != nil {
+			return nr, err
+		}
 		nr++
 	}
 	return nr, nil
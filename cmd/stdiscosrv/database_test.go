@@ -9,6 +9,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -16,7 +17,67 @@ import (
 )
 
 func TestDatabaseGetSet(t *testing.T) {
-	db := newInMemoryStore(t.TempDir(), 0, nil)
+	t.Run("memory", func(t *testing.T) {
+		db := newInMemoryStore(t.TempDir(), 0, nil)
+		testDatabaseGetSet(t, db, func(c clock) { db.clock = c })
+	})
+	t.Run("leveldb", func(t *testing.T) {
+		db, err := newLevelDBStore(t.TempDir(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testDatabaseGetSet(t, db, func(c clock) { db.clock = c })
+	})
+	t.Run("postgres", func(t *testing.T) {
+		db := newTestPostgresStore(t)
+		testDatabaseGetSet(t, db, func(c clock) { db.clock = c })
+	})
+}
+
+func TestPostgresStoreShared(t *testing.T) {
+	// Servers sharing the database see each other's announcements.
+	a := newTestPostgresStore(t)
+	b := newTestPostgresStore(t)
+	expires := time.Now().Add(time.Hour).UnixNano()
+
+	if err := a.merge(&protocol.EmptyDeviceID, []DatabaseAddress{{Address: "tcp://1.2.3.4:5", Expires: expires}}, time.Now().UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.merge(&protocol.EmptyDeviceID, []DatabaseAddress{{Address: "tcp://6.7.8.9:0", Expires: expires}}, time.Now().UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+	for _, db := range []*postgresStore{a, b} {
+		rec, err := db.get(&protocol.EmptyDeviceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rec.Addresses) != 2 {
+			t.Errorf("Expected both addresses, got %v", rec.Addresses)
+		}
+	}
+}
+
+// newTestPostgresStore returns a store in the database at
+// DISCOVERY_TEST_POSTGRES_URL, emptied, or skips the test if that's not
+// set.
+func newTestPostgresStore(t *testing.T) *postgresStore {
+	t.Helper()
+	url := os.Getenv("DISCOVERY_TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("DISCOVERY_TEST_POSTGRES_URL not set")
+	}
+	db, err := newPostgresStore(url, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.db.Exec(`DELETE FROM discovery_records`); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.db.Close() })
+	return db
+}
+
+func testDatabaseGetSet(t *testing.T, db store, setClock func(clock)) {
 	ctx, cancel := context.WithCancel(context.Background())
 	go db.Serve(ctx)
 	defer cancel()
@@ -35,7 +96,7 @@ func TestDatabaseGetSet(t *testing.T) {
 
 	now := time.Now()
 	tc := &testClock{now}
-	db.clock = tc
+	setClock(tc)
 
 	// Put a record
 
@@ -131,6 +192,53 @@ func TestDatabaseGetSet(t *testing.T) {
 	}
 }
 
+func TestLevelDBStorePersists(t *testing.T) {
+	dir := t.TempDir()
+	expires := time.Now().Add(time.Hour).UnixNano()
+
+	// Records from the in memory store are imported into a new database.
+	mem := newInMemoryStore(dir, 0, nil)
+	if err := mem.put(&protocol.EmptyDeviceID, DatabaseRecord{
+		Addresses: []DatabaseAddress{{Address: "tcp://1.2.3.4:5", Expires: expires}},
+		Seen:      time.Now().UnixNano(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mem.write(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		db, err := newLevelDBStore(dir, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+		go func() { done <- db.Serve(ctx) }()
+
+		rec, err := db.get(&protocol.EmptyDeviceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rec.Addresses) != 1+i {
+			t.Fatalf("%d: expected %d addresses, got %v", i, 1+i, rec.Addresses)
+		}
+
+		// Written immediately, and present after reopening.
+		if i == 0 {
+			if err := db.merge(&protocol.EmptyDeviceID, []DatabaseAddress{{Address: "tcp://6.7.8.9:0", Expires: expires}}, time.Now().UnixNano()); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		cancel()
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
 func TestFilter(t *testing.T) {
 	// all cases are expired with t=10
 	cases := []struct {
@@ -0,0 +1,224 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// levelDBStore keeps the records in a LevelDB database on disk. Every
+// change is written immediately, so nothing is lost on restart.
+type levelDBStore struct {
+	db            *leveldb.DB
+	mut           sync.Mutex // serializes writes, as merge and expiry read before writing
+	statsInterval time.Duration
+	clock         clock
+}
+
+func newLevelDBStore(dir string, statsInterval time.Duration) (*levelDBStore, error) {
+	dbDir := path.Join(dir, "records.leveldb")
+	_, statErr := os.Stat(dbDir)
+	db, err := leveldb.OpenFile(dbDir, nil)
+	if err != nil {
+		return nil, err
+	}
+	s := &levelDBStore{
+		db:            db,
+		statsInterval: statsInterval,
+		clock:         defaultClock{},
+	}
+
+	if os.IsNotExist(statErr) {
+		// New database; carry over the records from the in memory store
+		// if we were using that before.
+		if err := s.importFile(path.Join(dir, "records.db")); err != nil && !os.IsNotExist(err) {
+			log.Println("Error importing records.db:", err)
+		}
+	}
+
+	s.expireAndCalculateStatistics()
+	return s, nil
+}
+
+func (s *levelDBStore) importFile(name string) error {
+	fd, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+
+	batch := new(leveldb.Batch)
+	nr, err := readRecords(fd, func(key protocol.DeviceID, rec DatabaseRecord) error {
+		bs, err := rec.Marshal()
+		if err != nil {
+			return err
+		}
+		batch.Put(key[:], bs)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := s.db.Write(batch, nil); err != nil {
+		return err
+	}
+	log.Printf("Imported %d records from %s", nr, name)
+	return nil
+}
+
+func (s *levelDBStore) put(key *protocol.DeviceID, rec DatabaseRecord) error {
+	t0 := time.Now()
+	defer func() {
+		databaseOperationSeconds.WithLabelValues(dbOpPut).Observe(time.Since(t0).Seconds())
+	}()
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if err := s.write(key, rec); err != nil {
+		databaseOperations.WithLabelValues(dbOpPut, dbResError).Inc()
+		return err
+	}
+	databaseOperations.WithLabelValues(dbOpPut, dbResSuccess).Inc()
+	return nil
+}
+
+func (s *levelDBStore) merge(key *protocol.DeviceID, addrs []DatabaseAddress, seen int64) error {
+	t0 := time.Now()
+	defer func() {
+		databaseOperationSeconds.WithLabelValues(dbOpMerge).Observe(time.Since(t0).Seconds())
+	}()
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	oldRec, err := s.read(key)
+	if err != nil {
+		databaseOperations.WithLabelValues(dbOpMerge, dbResError).Inc()
+		return err
+	}
+	newRec := merge(oldRec, DatabaseRecord{
+		Addresses: addrs,
+		Seen:      seen,
+	})
+	if err := s.write(key, newRec); err != nil {
+		databaseOperations.WithLabelValues(dbOpMerge, dbResError).Inc()
+		return err
+	}
+
+	databaseOperations.WithLabelValues(dbOpMerge, dbResSuccess).Inc()
+	return nil
+}
+
+func (s *levelDBStore) get(key *protocol.DeviceID) (DatabaseRecord, error) {
+	t0 := time.Now()
+	defer func() {
+		databaseOperationSeconds.WithLabelValues(dbOpGet).Observe(time.Since(t0).Seconds())
+	}()
+
+	rec, err := s.read(key)
+	if err != nil {
+		databaseOperations.WithLabelValues(dbOpGet, dbResError).Inc()
+		return DatabaseRecord{}, err
+	}
+	if rec.Seen == 0 && len(rec.Addresses) == 0 {
+		databaseOperations.WithLabelValues(dbOpGet, dbResNotFound).Inc()
+		return DatabaseRecord{}, nil
+	}
+
+	rec.Addresses = expire(rec.Addresses, s.clock.Now())
+	databaseOperations.WithLabelValues(dbOpGet, dbResSuccess).Inc()
+	return rec, nil
+}
+
+// read returns the stored record, or an empty one if there is none.
+func (s *levelDBStore) read(key *protocol.DeviceID) (DatabaseRecord, error) {
+	bs, err := s.db.Get(key[:], nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return DatabaseRecord{}, nil
+	} else if err != nil {
+		return DatabaseRecord{}, err
+	}
+	var rec DatabaseRecord
+	if err := rec.Unmarshal(bs); err != nil {
+		databaseOperations.WithLabelValues(dbOpGet, dbResUnmarshalError).Inc()
+		return DatabaseRecord{}, nil
+	}
+	return rec, nil
+}
+
+func (s *levelDBStore) write(key *protocol.DeviceID, rec DatabaseRecord) error {
+	bs, err := rec.Marshal()
+	if err != nil {
+		return err
+	}
+	return s.db.Put(key[:], bs, nil)
+}
+
+func (s *levelDBStore) Serve(ctx context.Context) error {
+	defer s.db.Close()
+
+	if s.statsInterval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	t := time.NewTicker(s.statsInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			s.expireAndCalculateStatistics()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *levelDBStore) expireAndCalculateStatistics() {
+	stats := newRecordStatistics(s.clock.Now())
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	it := s.db.NewIterator(nil, nil)
+	defer it.Release()
+	batch := new(leveldb.Batch)
+	for it.Next() {
+		var rec DatabaseRecord
+		if err := rec.Unmarshal(it.Value()); err != nil {
+			batch.Delete(it.Key())
+			continue
+		}
+		rec.Addresses = expire(rec.Addresses, stats.now)
+		if !stats.add(rec) {
+			// drop the record if it's older than a week
+			batch.Delete(it.Key())
+		}
+	}
+	if err := it.Error(); err != nil {
+		log.Println("Error iterating database:", err)
+	}
+	if batch.Len() > 0 {
+		if err := s.db.Write(batch, nil); err != nil {
+			log.Println("Error expiring records:", err)
+		}
+		databaseOperations.WithLabelValues(dbOpDelete, dbResSuccess).Add(float64(batch.Len()))
+	}
+
+	stats.publish()
+}
@@ -65,8 +65,10 @@ type CLI struct {
 	Listen        string `group:"Listen" help:"Listen address" default:":8443" env:"DISCOVERY_LISTEN"`
 	MetricsListen string `group:"Listen" help:"Metrics listen address" env:"DISCOVERY_METRICS_LISTEN"`
 
+	DBBackend       string        `group:"Database" help:"Database backend; \"memory\" with periodic flushes to disk, \"leveldb\" writing every change to disk, or \"postgres\" shared between discovery servers" enum:"memory,leveldb,postgres" default:"memory" env:"DISCOVERY_DB_BACKEND"`
 	DBDir           string        `group:"Database" help:"Database directory" default:"." env:"DISCOVERY_DB_DIR"`
-	DBFlushInterval time.Duration `group:"Database" help:"Interval between database flushes" default:"5m" env:"DISCOVERY_DB_FLUSH_INTERVAL"`
+	DBURL           string        `name:"db-url" group:"Database" help:"PostgreSQL connection URL (postgres backend)" env:"DISCOVERY_DB_URL"`
+	DBFlushInterval time.Duration `group:"Database" help:"Interval between database flushes (memory backend)" default:"5m" env:"DISCOVERY_DB_FLUSH_INTERVAL"`
 
 	DBS3Endpoint    string `name:"db-s3-endpoint" group:"Database (S3 backup)" hidden:"true" help:"S3 endpoint for database" env:"DISCOVERY_DB_S3_ENDPOINT"`
 	DBS3Region      string `name:"db-s3-region" group:"Database (S3 backup)" hidden:"true" help:"S3 region for database" env:"DISCOVERY_DB_S3_REGION"`
@@ -74,6 +76,14 @@ type CLI struct {
 	DBS3AccessKeyID string `name:"db-s3-access-key-id" group:"Database (S3 backup)" hidden:"true" help:"S3 access key ID for database" env:"DISCOVERY_DB_S3_ACCESS_KEY_ID"`
 	DBS3SecretKey   string `name:"db-s3-secret-key" group:"Database (S3 backup)" hidden:"true" help:"S3 secret key for database" env:"DISCOVERY_DB_S3_SECRET_KEY"`
 
+	AllowList               string        `group:"Access control" help:"File or HTTP(S) URL with the device IDs allowed to announce and be looked up, one per line" env:"DISCOVERY_ALLOW_LIST"`
+	AllowListReloadInterval time.Duration `group:"Access control" help:"Interval between checks for changes to the allow list" default:"1m" env:"DISCOVERY_ALLOW_LIST_RELOAD_INTERVAL"`
+	AllowListLookupCert     bool          `group:"Access control" help:"Require lookups to present a certificate on the allow list (clients need the \"lookupcert\" option)" env:"DISCOVERY_ALLOW_LIST_LOOKUP_CERT"`
+	ClientRate              float64       `group:"Access control" help:"Requests per second allowed per client IP address, or /64 prefix for IPv6 (0 for unlimited)" env:"DISCOVERY_CLIENT_RATE"`
+	ClientBurst             int           `group:"Access control" help:"Requests allowed in a burst per client" default:"10" env:"DISCOVERY_CLIENT_BURST"`
+	AdminListen             string        `group:"Access control" help:"Admin API listen address, for managing the allow list" env:"DISCOVERY_ADMIN_LISTEN"`
+	AdminToken              string        `group:"Access control" help:"Bearer token required for the admin API" env:"DISCOVERY_ADMIN_TOKEN"`
+
 	AMQPAddress string `group:"AMQP replication" hidden:"true" help:"Address to AMQP broker" env:"DISCOVERY_AMQP_ADDRESS"`
 
 	Debug   bool `short:"d" help:"Print debug output" env:"DISCOVERY_DEBUG"`
@@ -128,7 +138,23 @@ func main() {
 	}
 
 	// Start the database.
-	db := newInMemoryStore(cli.DBDir, cli.DBFlushInterval, s3c)
+	var db store
+	switch cli.DBBackend {
+	case "leveldb":
+		var err error
+		db, err = newLevelDBStore(cli.DBDir, databaseStatisticsInterval)
+		if err != nil {
+			log.Fatalln("Failed to open database:", err)
+		}
+	case "postgres":
+		var err error
+		db, err = newPostgresStore(cli.DBURL, databaseStatisticsInterval)
+		if err != nil {
+			log.Fatalln("Failed to open database:", err)
+		}
+	default:
+		db = newInMemoryStore(cli.DBDir, cli.DBFlushInterval, s3c)
+	}
 	main.Add(db)
 
 	// If we have an AMQP broker for replication, start that
@@ -144,6 +170,30 @@ func main() {
 	qs := newAPISrv(cli.Listen, cert, db, repl, cli.HTTP, cli.Compression)
 	main.Add(qs)
 
+	// If configured, restrict who may use the server.
+	if cli.AllowList != "" {
+		al, err := newAllowList(cli.AllowList, cli.AllowListReloadInterval)
+		if err != nil {
+			log.Fatalln("Failed to load allow list:", err)
+		}
+		log.Printf("Loaded allow list with %d devices from %s", len(al.list()), cli.AllowList)
+		main.Add(al)
+		qs.allowList = al
+		qs.lookupNeedsCert = cli.AllowListLookupCert
+
+		if cli.AdminListen != "" {
+			if cli.AdminToken == "" {
+				log.Fatalln("An admin token is required for the admin API")
+			}
+			main.Add(newAdminSrv(cli.AdminListen, cli.AdminToken, al))
+		}
+	} else if cli.AllowListLookupCert || cli.AdminListen != "" {
+		log.Fatalln("The lookup certificate requirement and admin API need an allow list")
+	}
+	if cli.ClientRate > 0 {
+		qs.limiter = newClientLimiter(cli.ClientRate, cli.ClientBurst)
+	}
+
 	// If we have a metrics port configured, start a metrics handler.
 	if cli.MetricsListen != "" {
 		go func() {
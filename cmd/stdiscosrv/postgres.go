@@ -0,0 +1,222 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	_ "github.com/lib/pq" // PostgreSQL driver
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+const postgresSchema = `CREATE TABLE IF NOT EXISTS discovery_records (
+	device_id BYTEA PRIMARY KEY,
+	seen BIGINT NOT NULL,
+	record BYTEA NOT NULL
+)`
+
+// postgresStore keeps the records in a PostgreSQL database, which several
+// discovery servers can share instead of replicating announcements to each
+// other. Every change is written immediately.
+type postgresStore struct {
+	db            *sql.DB
+	statsInterval time.Duration
+	clock         clock
+}
+
+func newPostgresStore(url string, statsInterval time.Duration) (*postgresStore, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := db.ExecContext(ctx, postgresSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	s := &postgresStore{
+		db:            db,
+		statsInterval: statsInterval,
+		clock:         defaultClock{},
+	}
+	s.expireAndCalculateStatistics()
+	return s, nil
+}
+
+func (s *postgresStore) put(key *protocol.DeviceID, rec DatabaseRecord) error {
+	t0 := time.Now()
+	defer func() {
+		databaseOperationSeconds.WithLabelValues(dbOpPut).Observe(time.Since(t0).Seconds())
+	}()
+
+	bs, err := rec.Marshal()
+	if err != nil {
+		databaseOperations.WithLabelValues(dbOpPut, dbResError).Inc()
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO discovery_records (device_id, seen, record) VALUES ($1, $2, $3)
+		ON CONFLICT (device_id) DO UPDATE SET seen = EXCLUDED.seen, record = EXCLUDED.record`, key[:], rec.Seen, bs)
+	if err != nil {
+		databaseOperations.WithLabelValues(dbOpPut, dbResError).Inc()
+		return err
+	}
+	databaseOperations.WithLabelValues(dbOpPut, dbResSuccess).Inc()
+	return nil
+}
+
+func (s *postgresStore) merge(key *protocol.DeviceID, addrs []DatabaseAddress, seen int64) error {
+	t0 := time.Now()
+	defer func() {
+		databaseOperationSeconds.WithLabelValues(dbOpMerge).Observe(time.Since(t0).Seconds())
+	}()
+
+	if err := s.mergeTx(key, addrs, seen); err != nil {
+		databaseOperations.WithLabelValues(dbOpMerge, dbResError).Inc()
+		return err
+	}
+	databaseOperations.WithLabelValues(dbOpMerge, dbResSuccess).Inc()
+	return nil
+}
+
+// mergeTx merges the addresses into the record in a transaction, with the
+// row locked so that other servers announcing for the same device wait.
+func (s *postgresStore) mergeTx(key *protocol.DeviceID, addrs []DatabaseAddress, seen int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if _, err := tx.Exec(`INSERT INTO discovery_records (device_id, seen, record) VALUES ($1, 0, '')
+		ON CONFLICT (device_id) DO NOTHING`, key[:]); err != nil {
+		return err
+	}
+	var bs []byte
+	if err := tx.QueryRow(`SELECT record FROM discovery_records WHERE device_id = $1 FOR UPDATE`, key[:]).Scan(&bs); err != nil {
+		return err
+	}
+	var oldRec DatabaseRecord
+	if err := oldRec.Unmarshal(bs); err != nil {
+		databaseOperations.WithLabelValues(dbOpMerge, dbResUnmarshalError).Inc()
+		oldRec = DatabaseRecord{}
+	}
+	newRec := merge(oldRec, DatabaseRecord{
+		Addresses: addrs,
+		Seen:      seen,
+	})
+	if bs, err = newRec.Marshal(); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE discovery_records SET seen = $2, record = $3 WHERE device_id = $1`, key[:], newRec.Seen, bs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *postgresStore) get(key *protocol.DeviceID) (DatabaseRecord, error) {
+	t0 := time.Now()
+	defer func() {
+		databaseOperationSeconds.WithLabelValues(dbOpGet).Observe(time.Since(t0).Seconds())
+	}()
+
+	var bs []byte
+	err := s.db.QueryRow(`SELECT record FROM discovery_records WHERE device_id = $1`, key[:]).Scan(&bs)
+	if errors.Is(err, sql.ErrNoRows) {
+		databaseOperations.WithLabelValues(dbOpGet, dbResNotFound).Inc()
+		return DatabaseRecord{}, nil
+	} else if err != nil {
+		databaseOperations.WithLabelValues(dbOpGet, dbResError).Inc()
+		return DatabaseRecord{}, err
+	}
+	var rec DatabaseRecord
+	if err := rec.Unmarshal(bs); err != nil {
+		databaseOperations.WithLabelValues(dbOpGet, dbResUnmarshalError).Inc()
+		return DatabaseRecord{}, nil
+	}
+
+	rec.Addresses = expire(rec.Addresses, s.clock.Now())
+	databaseOperations.WithLabelValues(dbOpGet, dbResSuccess).Inc()
+	return rec, nil
+}
+
+func (s *postgresStore) Serve(ctx context.Context) error {
+	defer s.db.Close()
+
+	if s.statsInterval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+
+	t := time.NewTicker(s.statsInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			s.expireAndCalculateStatistics()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// expireAndCalculateStatistics drops the records older than a week and
+// publishes the statistics. Each server sharing the database does so; a
+// record announced again meanwhile, by any of them, is kept.
+func (s *postgresStore) expireAndCalculateStatistics() {
+	stats := newRecordStatistics(s.clock.Now())
+
+	rows, err := s.db.Query(`SELECT device_id, seen, record FROM discovery_records`)
+	if err != nil {
+		log.Println("Error iterating database:", err)
+		return
+	}
+	type stale struct {
+		key  []byte
+		seen int64
+	}
+	var expired []stale
+	for rows.Next() {
+		var key, bs []byte
+		var seen int64
+		if err := rows.Scan(&key, &seen, &bs); err != nil {
+			log.Println("Error iterating database:", err)
+			break
+		}
+		var rec DatabaseRecord
+		if err := rec.Unmarshal(bs); err != nil {
+			expired = append(expired, stale{key, seen})
+			continue
+		}
+		rec.Addresses = expire(rec.Addresses, stats.now)
+		if !stats.add(rec) {
+			// drop the record if it's older than a week
+			expired = append(expired, stale{key, seen})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating database:", err)
+	}
+	rows.Close()
+
+	for _, rec := range expired {
+		if _, err := s.db.Exec(`DELETE FROM discovery_records WHERE device_id = $1 AND seen = $2`, rec.key, rec.seen); err != nil {
+			log.Println("Error expiring records:", err)
+			break
+		}
+	}
+	if len(expired) > 0 {
+		databaseOperations.WithLabelValues(dbOpDelete, dbResSuccess).Add(float64(len(expired)))
+	}
+
+	stats.publish()
+}
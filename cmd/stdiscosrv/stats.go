@@ -118,6 +118,21 @@ var (
 			Name:      "retry_after_seconds",
 			Help:      "Retry-After header value in seconds.",
 		}, []string{"name"})
+
+	rateLimitedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "syncthing",
+			Subsystem: "discovery",
+			Name:      "rate_limited_total",
+			Help:      "Number of requests refused due to the per client rate limit.",
+		}, []string{"type"})
+	allowListDevices = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "syncthing",
+			Subsystem: "discovery",
+			Name:      "allow_list_devices",
+			Help:      "Number of devices on the allow list.",
+		})
 )
 
 const (
@@ -139,5 +154,5 @@ func init() {
 		databaseKeys, databaseStatisticsSeconds,
 		databaseOperations, databaseOperationSeconds,
 		databaseWriteSeconds, databaseLastWritten,
-		retryAfterLevel)
+		retryAfterLevel, rateLimitedTotal, allowListDevices)
 }
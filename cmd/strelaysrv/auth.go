@@ -0,0 +1,405 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors.
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	syncthingprotocol "github.com/syncthing/syncthing/lib/protocol"
+)
+
+// deviceAuth restricts the relay to the devices listed in the authorization
+// file, when one is given.
+var deviceAuth *authorizer
+
+var (
+	errNotAuthorized = errors.New("not authorized")
+	errQuotaExceeded = errors.New("quota exceeded")
+)
+
+// The authorization file lists the devices allowed to use the relay:
+//
+//	{
+//	    "devices": [
+//	        {
+//	            "id": "EZQOIDM-6DDD4ZI-DJ65NSM-4OQWRAT-EIKSMJO-OZ552BO-WQZEGYY-STS5RQM",
+//	            "name": "build server",
+//	            "group": "engineering",
+//	            "rateBps": 1000000,
+//	            "quotaBytes": 10000000000
+//	        }
+//	    ]
+//	}
+//
+// The rate limit applies to all sessions of the device together, on top of
+// the per session and global limits. The quota is for the traffic relayed
+// within each quota period. Both are optional.
+type authConfig struct {
+	Devices []authorizedDevice `json:"devices"`
+}
+
+type authorizedDevice struct {
+	ID         syncthingprotocol.DeviceID `json:"id"`
+	Name       string                     `json:"name,omitempty"`
+	Group      string                     `json:"group,omitempty"`
+	RateBps    int                        `json:"rateBps,omitempty"`
+	QuotaBytes int64                      `json:"quotaBytes,omitempty"`
+}
+
+type authorizedDeviceState struct {
+	authorizedDevice
+	limiter *rate.Limiter // nil if the device has never had a rate limit
+}
+
+// deviceUsage is the traffic relayed in sessions the device took part in.
+// It's kept for all devices that have used the relay, also after they have
+// been removed from the authorization file, so that it can be accounted
+// for.
+type deviceUsage struct {
+	totalBytes  atomic.Int64
+	periodBytes atomic.Int64
+	periodStart time.Time // protected by authorizer.mut
+}
+
+// deviceUsageFile is the persisted form of the usage.
+type deviceUsageFile struct {
+	TotalBytes  int64     `json:"totalBytes"`
+	PeriodBytes int64     `json:"periodBytes"`
+	PeriodStart time.Time `json:"periodStart"`
+}
+
+type authorizer struct {
+	path        string
+	usagePath   string // optional
+	quotaPeriod time.Duration
+
+	mut     sync.RWMutex
+	devices map[syncthingprotocol.DeviceID]*authorizedDeviceState
+	usage   map[syncthingprotocol.DeviceID]*deviceUsage
+	modTime time.Time
+}
+
+func newAuthorizer(path, usagePath string, quotaPeriod time.Duration) (*authorizer, error) {
+	a := &authorizer{
+		path:        path,
+		usagePath:   usagePath,
+		quotaPeriod: quotaPeriod,
+		usage:       make(map[syncthingprotocol.DeviceID]*deviceUsage),
+	}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	if usagePath != "" {
+		if err := a.loadUsage(); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// serve reloads the authorization file when it changes, rolls over quota
+// periods and saves the usage, until stop is closed.
+func (a *authorizer) serve(interval time.Duration, reload <-chan os.Signal, stop <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+		case <-reload:
+			log.Println("Reloading authorization file")
+		case <-stop:
+			return
+		}
+		if err := a.reload(); err != nil {
+			log.Println("Reloading authorization file:", err)
+		}
+		a.rollOverPeriods(time.Now())
+		if err := a.saveUsage(); err != nil {
+			log.Println("Saving usage:", err)
+		}
+	}
+}
+
+// reload reads the authorization file if it has changed since it was last
+// read. Rate limits of existing devices are updated in place, so that they
+// apply to running sessions as well.
+func (a *authorizer) reload() error {
+	info, err := os.Stat(a.path)
+	if err != nil {
+		return err
+	}
+
+	a.mut.RLock()
+	unchanged := a.devices != nil && info.ModTime().Equal(a.modTime)
+	a.mut.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	bs, err := os.ReadFile(a.path)
+	if err != nil {
+		return err
+	}
+	var f authConfig
+	if err := json.Unmarshal(bs, &f); err != nil {
+		return fmt.Errorf("%s: %w", a.path, err)
+	}
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	devices := make(map[syncthingprotocol.DeviceID]*authorizedDeviceState, len(f.Devices))
+	for _, dev := range f.Devices {
+		if dev.ID == syncthingprotocol.EmptyDeviceID {
+			return fmt.Errorf("%s: device without ID", a.path)
+		}
+		state := &authorizedDeviceState{authorizedDevice: dev}
+		if old, ok := a.devices[dev.ID]; ok {
+			state.limiter = old.limiter
+		}
+		switch {
+		case dev.RateBps > 0 && state.limiter != nil:
+			state.limiter.SetLimit(rate.Limit(dev.RateBps))
+			state.limiter.SetBurst(2 * dev.RateBps)
+		case dev.RateBps > 0:
+			state.limiter = rate.NewLimiter(rate.Limit(dev.RateBps), 2*dev.RateBps)
+		case state.limiter != nil:
+			state.limiter.SetLimit(rate.Inf)
+		}
+		devices[dev.ID] = state
+	}
+
+	if a.devices != nil {
+		log.Printf("Authorization file reloaded, %d devices", len(devices))
+	}
+	a.devices = devices
+	a.modTime = info.ModTime()
+	return nil
+}
+
+// authorize returns nil if the device may use the relay, or
+// errNotAuthorized or errQuotaExceeded.
+func (a *authorizer) authorize(id syncthingprotocol.DeviceID) error {
+	if a == nil {
+		return nil
+	}
+
+	a.mut.RLock()
+	defer a.mut.RUnlock()
+	dev, ok := a.devices[id]
+	if !ok {
+		return errNotAuthorized
+	}
+	if dev.QuotaBytes > 0 {
+		if usage, ok := a.usage[id]; ok && usage.periodBytes.Load() >= dev.QuotaBytes {
+			return errQuotaExceeded
+		}
+	}
+	return nil
+}
+
+// limiters returns the rate limiters of the given devices.
+func (a *authorizer) limiters(ids ...syncthingprotocol.DeviceID) []*rate.Limiter {
+	if a == nil {
+		return nil
+	}
+
+	a.mut.RLock()
+	defer a.mut.RUnlock()
+	var ls []*rate.Limiter
+	for _, id := range ids {
+		if dev, ok := a.devices[id]; ok && dev.RateBps > 0 {
+			ls = append(ls, dev.limiter)
+		}
+	}
+	return ls
+}
+
+// accountFunc returns a function that accounts relayed bytes to the
+// participants of a session. It returns errQuotaExceeded once either of
+// them is over its quota.
+func (a *authorizer) accountFunc(ids ...syncthingprotocol.DeviceID) func(bytes int) error {
+	if a == nil {
+		return nil
+	}
+
+	usages := make([]*deviceUsage, len(ids))
+	a.mut.Lock()
+	for i, id := range ids {
+		usages[i] = a.usageLocked(id)
+	}
+	a.mut.Unlock()
+
+	return func(bytes int) error {
+		var err error
+		for i, usage := range usages {
+			usage.totalBytes.Add(int64(bytes))
+			period := usage.periodBytes.Add(int64(bytes))
+			if quota := a.quota(ids[i]); quota > 0 && period > quota {
+				err = errQuotaExceeded
+			}
+		}
+		return err
+	}
+}
+
+func (a *authorizer) quota(id syncthingprotocol.DeviceID) int64 {
+	a.mut.RLock()
+	defer a.mut.RUnlock()
+	if dev, ok := a.devices[id]; ok {
+		return dev.QuotaBytes
+	}
+	return 0
+}
+
+func (a *authorizer) usageLocked(id syncthingprotocol.DeviceID) *deviceUsage {
+	usage, ok := a.usage[id]
+	if !ok {
+		usage = &deviceUsage{periodStart: time.Now().Truncate(time.Second)}
+		a.usage[id] = usage
+	}
+	return usage
+}
+
+// rollOverPeriods starts a new quota period for the devices whose current
+// one has ended.
+func (a *authorizer) rollOverPeriods(now time.Time) {
+	if a.quotaPeriod <= 0 {
+		return
+	}
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	for _, usage := range a.usage {
+		if elapsed := now.Sub(usage.periodStart); elapsed >= a.quotaPeriod {
+			usage.periodStart = usage.periodStart.Add(elapsed / a.quotaPeriod * a.quotaPeriod)
+			usage.periodBytes.Store(0)
+		}
+	}
+}
+
+// stillAuthorized returns whether the device is still in the authorization
+// file, for dropping devices that have been removed from it.
+func (a *authorizer) stillAuthorized(id syncthingprotocol.DeviceID) bool {
+	if a == nil {
+		return true
+	}
+	a.mut.RLock()
+	_, ok := a.devices[id]
+	a.mut.RUnlock()
+	return ok
+}
+
+func (a *authorizer) loadUsage() error {
+	bs, err := os.ReadFile(a.usagePath)
+	if err != nil {
+		return err
+	}
+	var saved map[syncthingprotocol.DeviceID]deviceUsageFile
+	if err := json.Unmarshal(bs, &saved); err != nil {
+		return fmt.Errorf("%s: %w", a.usagePath, err)
+	}
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	for id, s := range saved {
+		usage := a.usageLocked(id)
+		usage.totalBytes.Store(s.TotalBytes)
+		usage.periodBytes.Store(s.PeriodBytes)
+		usage.periodStart = s.PeriodStart
+	}
+	return nil
+}
+
+func (a *authorizer) saveUsage() error {
+	if a == nil || a.usagePath == "" {
+		return nil
+	}
+
+	a.mut.RLock()
+	saved := make(map[syncthingprotocol.DeviceID]deviceUsageFile, len(a.usage))
+	for id, usage := range a.usage {
+		saved[id] = deviceUsageFile{
+			TotalBytes:  usage.totalBytes.Load(),
+			PeriodBytes: usage.periodBytes.Load(),
+			PeriodStart: usage.periodStart,
+		}
+	}
+	a.mut.RUnlock()
+
+	bs, err := json.MarshalIndent(saved, "", "    ")
+	if err != nil {
+		return err
+	}
+	tmp := a.usagePath + ".tmp"
+	if err := os.WriteFile(tmp, bs, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, a.usagePath)
+}
+
+// status returns the usage per device and per group, for the status
+// endpoint.
+func (a *authorizer) status() (map[string]interface{}, map[string]interface{}) {
+	a.mut.RLock()
+	defer a.mut.RUnlock()
+
+	ids := make([]syncthingprotocol.DeviceID, 0, len(a.usage)+len(a.devices))
+	for id := range a.devices {
+		ids = append(ids, id)
+	}
+	for id := range a.usage {
+		if _, ok := a.devices[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Compare(ids[j]) < 0 })
+
+	outboxesMut.RLock()
+	defer outboxesMut.RUnlock()
+
+	devices := make(map[string]interface{}, len(ids))
+	groups := make(map[string]interface{})
+	for _, id := range ids {
+		entry := map[string]interface{}{
+			"authorized": false,
+		}
+		var group string
+		if dev, ok := a.devices[id]; ok {
+			group = dev.Group
+			entry["authorized"] = true
+			entry["name"] = dev.Name
+			entry["group"] = dev.Group
+			entry["rateBps"] = dev.RateBps
+			entry["quotaBytes"] = dev.QuotaBytes
+		}
+		_, connected := outboxes[id]
+		entry["connected"] = connected
+		if usage, ok := a.usage[id]; ok {
+			total := usage.totalBytes.Load()
+			entry["totalBytes"] = total
+			entry["periodBytes"] = usage.periodBytes.Load()
+			entry["periodStart"] = usage.periodStart
+
+			g, _ := groups[group].(map[string]int64)
+			if g == nil {
+				g = make(map[string]int64)
+				groups[group] = g
+			}
+			g["totalBytes"] += total
+			g["periodBytes"] += usage.periodBytes.Load()
+		}
+		devices[id.String()] = entry
+	}
+	return devices, groups
+}
@@ -0,0 +1,303 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors.
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	syncthingprotocol "github.com/syncthing/syncthing/lib/protocol"
+)
+
+var (
+	authDevice1 = syncthingprotocol.DeviceID{1}
+	authDevice2 = syncthingprotocol.DeviceID{2}
+	authDevice3 = syncthingprotocol.DeviceID{3}
+)
+
+func writeAuthFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the change is noticed even on filesystems with coarse
+	// timestamps.
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAuthorizerLoad(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		content string
+		ok      bool
+	}{
+		{"empty", `{}`, true},
+		{"devices", `{"devices": [{"id": "` + authDevice1.String() + `", "rateBps": 1000, "quotaBytes": 2000}]}`, true},
+		{"invalid JSON", `{"devices": [`, false},
+		{"device without ID", `{"devices": [{"name": "nameless"}]}`, false},
+		{"malformed ID", `{"devices": [{"id": "AAAAAAA"}]}`, false},
+		{"wrong check digits", `{"devices": [{"id": "` + flipCheckDigit(authDevice1.String()) + `"}]}`, false},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "auth.json")
+			writeAuthFile(t, path, tc.content, time.Now())
+			_, err := newAuthorizer(path, "", 0)
+			if tc.ok && err != nil {
+				t.Error("Unexpected error:", err)
+			} else if !tc.ok && err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	if _, err := newAuthorizer(filepath.Join(t.TempDir(), "missing.json"), "", 0); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+// flipCheckDigit changes the check digit of the first group of the device
+// ID string, so that it doesn't validate.
+func flipCheckDigit(id string) string {
+	bs := []byte(id)
+	if bs[6] == 'A' {
+		bs[6] = 'B'
+	} else {
+		bs[6] = 'A'
+	}
+	return string(bs)
+}
+
+func TestAuthorizerAuthorize(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "auth.json")
+	writeAuthFile(t, path, `{"devices": [
+		{"id": "`+authDevice1.String()+`"},
+		{"id": "`+authDevice2.String()+`", "quotaBytes": 100}
+	]}`, time.Now())
+	a, err := newAuthorizer(path, "", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := a.accountFunc(authDevice1, authDevice2)
+
+	cases := []struct {
+		name     string
+		id       syncthingprotocol.DeviceID
+		relayed  int
+		expected error
+	}{
+		{"listed without quota", authDevice1, 0, nil},
+		{"unlisted", authDevice3, 0, errNotAuthorized},
+		{"empty ID", syncthingprotocol.EmptyDeviceID, 0, errNotAuthorized},
+		{"below quota", authDevice2, 99, nil},
+		{"quota reached", authDevice2, 1, errQuotaExceeded},
+		{"no quota after quota reached by a peer", authDevice1, 0, nil},
+	}
+
+	for _, tc := range cases {
+		if tc.relayed > 0 {
+			account(tc.relayed)
+		}
+		if err := a.authorize(tc.id); !errors.Is(err, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, err)
+		}
+	}
+
+	// Without an authorization file everyone is allowed.
+	var none *authorizer
+	if err := none.authorize(authDevice3); err != nil {
+		t.Error("Expected nil authorizer to allow all, got", err)
+	}
+	if !none.stillAuthorized(authDevice3) {
+		t.Error("Expected nil authorizer to keep all")
+	}
+}
+
+func TestAuthorizerAccount(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "auth.json")
+	writeAuthFile(t, path, `{"devices": [
+		{"id": "`+authDevice1.String()+`", "quotaBytes": 100},
+		{"id": "`+authDevice2.String()+`"}
+	]}`, time.Now())
+	a, err := newAuthorizer(path, "", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account := a.accountFunc(authDevice1, authDevice2)
+
+	cases := []struct {
+		bytes    int
+		expected error
+	}{
+		{60, nil},
+		{40, nil}, // exactly at the quota is fine for the session
+		{1, errQuotaExceeded},
+		{1, errQuotaExceeded},
+	}
+	for i, tc := range cases {
+		if err := account(tc.bytes); !errors.Is(err, tc.expected) {
+			t.Errorf("%d: expected %v, got %v", i, tc.expected, err)
+		}
+	}
+
+	// Both participants are accounted for.
+	for _, id := range []syncthingprotocol.DeviceID{authDevice1, authDevice2} {
+		if total := a.usage[id].totalBytes.Load(); total != 102 {
+			t.Errorf("Expected 102 bytes for %v, got %d", id, total)
+		}
+	}
+}
+
+func TestAuthorizerPeriodExpiry(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "auth.json")
+	writeAuthFile(t, path, `{"devices": [{"id": "`+authDevice1.String()+`", "quotaBytes": 10}]}`, time.Now())
+
+	cases := []struct {
+		name     string
+		period   time.Duration
+		elapsed  time.Duration
+		expected error
+	}{
+		{"within period", time.Hour, 59 * time.Minute, errQuotaExceeded},
+		{"period ended", time.Hour, time.Hour, nil},
+		{"several periods ended", time.Hour, 3*time.Hour + time.Minute, nil},
+		{"no period", 0, 100 * time.Hour, errQuotaExceeded},
+	}
+
+	for _, tc := range cases {
+		a, err := newAuthorizer(path, "", tc.period)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a.accountFunc(authDevice1)(10)
+		start := a.usage[authDevice1].periodStart
+
+		a.rollOverPeriods(start.Add(tc.elapsed))
+		if err := a.authorize(authDevice1); !errors.Is(err, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, err)
+		}
+		if tc.expected == nil {
+			// The new period starts on a period boundary, not now.
+			newStart := a.usage[authDevice1].periodStart
+			if newStart.Sub(start)%tc.period != 0 || newStart.After(start.Add(tc.elapsed)) {
+				t.Errorf("%s: unexpected new period start %v, from %v", tc.name, newStart, start)
+			}
+		}
+		if total := a.usage[authDevice1].totalBytes.Load(); total != 10 {
+			t.Errorf("%s: expected total to survive, got %d", tc.name, total)
+		}
+	}
+}
+
+func TestAuthorizerReload(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "auth.json")
+	modTime := time.Now().Add(-time.Hour)
+	writeAuthFile(t, path, `{"devices": [
+		{"id": "`+authDevice1.String()+`", "rateBps": 1000},
+		{"id": "`+authDevice2.String()+`"}
+	]}`, modTime)
+	a, err := newAuthorizer(path, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiters := a.limiters(authDevice1, authDevice2)
+	if len(limiters) != 1 || limiters[0].Limit() != 1000 {
+		t.Fatalf("Expected a single limiter at 1000 B/s, got %v", limiters)
+	}
+	lim := limiters[0]
+
+	// The device's rate changes in place, the other device is removed, and
+	// a broken file leaves the previous state alone.
+	writeAuthFile(t, path, `{"devices": [{"id": "`+authDevice1.String()+`", "rateBps": 500}]}`, modTime.Add(time.Minute))
+	if err := a.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if lim.Limit() != 500 || lim.Burst() != 1000 {
+		t.Errorf("Expected limiter updated in place, got %v / %d", lim.Limit(), lim.Burst())
+	}
+	if a.stillAuthorized(authDevice2) {
+		t.Error("Expected removed device to be dropped")
+	}
+
+	writeAuthFile(t, path, `{"devices": [{"id": "`+authDevice1.String()+`"}]}`, modTime.Add(2*time.Minute))
+	if err := a.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if lim.Limit() != rate.Inf {
+		t.Errorf("Expected limit to be lifted, got %v", lim.Limit())
+	}
+	if ls := a.limiters(authDevice1); len(ls) != 0 {
+		t.Errorf("Expected no limiters without a rate, got %v", ls)
+	}
+
+	writeAuthFile(t, path, `{"devices": [{}]}`, modTime.Add(3*time.Minute))
+	if err := a.reload(); err == nil {
+		t.Error("Expected an error for a device without ID")
+	}
+	if !a.stillAuthorized(authDevice1) {
+		t.Error("Expected failed reload to keep the previous devices")
+	}
+}
+
+func TestAuthorizerUsagePersistence(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "auth.json")
+	usagePath := filepath.Join(dir, "usage.json")
+	writeAuthFile(t, path, `{"devices": [{"id": "`+authDevice1.String()+`", "quotaBytes": 100}]}`, time.Now())
+
+	a, err := newAuthorizer(path, usagePath, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.accountFunc(authDevice1, authDevice2)(150)
+	if err := a.saveUsage(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The quota still applies after a restart, also for usage by devices
+	// that aren't listed anymore.
+	b, err := newAuthorizer(path, usagePath, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := b.authorize(authDevice1); !errors.Is(err, errQuotaExceeded) {
+		t.Errorf("Expected quota to be exceeded after reload, got %v", err)
+	}
+	for _, id := range []syncthingprotocol.DeviceID{authDevice1, authDevice2} {
+		if got := b.usage[id].periodBytes.Load(); got != 150 {
+			t.Errorf("Expected 150 period bytes for %v, got %d", id, got)
+		}
+	}
+	if !b.usage[authDevice1].periodStart.Equal(a.usage[authDevice1].periodStart) {
+		t.Error("Expected the period start to be kept")
+	}
+
+	if err := os.WriteFile(usagePath, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newAuthorizer(path, usagePath, time.Hour); err == nil {
+		t.Error("Expected an error for a broken usage file")
+	}
+}
@@ -81,7 +81,12 @@ func protocolConnectionHandler(tcpConn net.Conn, config *tls.Config, token strin
 		return
 	}
 
-	state := conn.ConnectionState()
+	handleProtocolConnection(conn, conn.ConnectionState(), token)
+}
+
+// handleProtocolConnection serves a protocol connection on which the TLS
+// handshake has completed.
+func handleProtocolConnection(conn net.Conn, state tls.ConnectionState, token string) {
 	if debug && state.NegotiatedProtocol != protocol.ProtocolName {
 		log.Println("Protocol negotiation error")
 	}
@@ -134,6 +139,15 @@ func protocolConnectionHandler(tcpConn net.Conn, config *tls.Config, token strin
 					continue
 				}
 
+				if err := deviceAuth.authorize(id); err != nil {
+					if debug {
+						log.Printf("Refusing join request from %s: %v", id, err)
+					}
+					protocol.WriteMessage(conn, authResponse(err))
+					conn.Close()
+					continue
+				}
+
 				if overLimit.Load() {
 					protocol.WriteMessage(conn, protocol.RelayFull{})
 					if debug {
@@ -173,6 +187,14 @@ func protocolConnectionHandler(tcpConn net.Conn, config *tls.Config, token strin
 					conn.Close()
 					continue
 				}
+				if err := deviceAuth.authorize(id); err != nil {
+					if debug {
+						log.Printf("Refusing connect request from %s: %v", id, err)
+					}
+					protocol.WriteMessage(conn, authResponse(err))
+					conn.Close()
+					continue
+				}
 				outboxesMut.RLock()
 				peerOutbox, ok := outboxes[requestedPeer]
 				outboxesMut.RUnlock()
@@ -273,6 +295,16 @@ func protocolConnectionHandler(tcpConn net.Conn, config *tls.Config, token strin
 				conn.Close()
 			}
 
+			if !deviceAuth.stillAuthorized(id) {
+				if debug {
+					log.Println("Dropping", id, "as it was removed from the authorization file")
+				}
+				protocol.WriteMessage(conn, protocol.ResponseNotAuthorized)
+				conn.Close()
+				dropSessions(id)
+				continue
+			}
+
 			if overLimit.Load() && !hasSessions(id) {
 				if debug {
 					log.Println("Dropping", id, "as it has no sessions and we are over our limits")
@@ -365,6 +397,13 @@ func sessionConnectionHandler(conn net.Conn) {
 	}
 }
 
+func authResponse(err error) protocol.Response {
+	if err == errQuotaExceeded {
+		return protocol.ResponseQuotaExceeded
+	}
+	return protocol.ResponseNotAuthorized
+}
+
 func messageReader(conn net.Conn, messages chan<- interface{}, errors chan<- error) {
 	numConnections.Add(1)
 	defer numConnections.Add(-1)
@@ -0,0 +1,15 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors.
+
+//go:build noquic
+// +build noquic
+
+package main
+
+import (
+	"crypto/tls"
+	"log"
+)
+
+func quicListener(_ string, _ *tls.Config, _ string) {
+	log.Fatalln("QUIC is disabled at build time")
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors.
+
+//go:build !noquic
+// +build !noquic
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/syncthing/syncthing/lib/relay/protocol"
+)
+
+var quicConfig = &quic.Config{
+	MaxIdleTimeout:  30 * time.Second,
+	KeepAlivePeriod: 15 * time.Second,
+}
+
+const quicCloseTimeout = 5 * time.Second
+
+// quicConn is a single stream on its own QUIC connection, which is how
+// clients use QUIC for both protocol and session connections.
+type quicConn struct {
+	quic.Connection
+	quic.Stream
+}
+
+// Close closes the stream and, once the other side has had the chance to
+// read what was written last, the connection. Closing the connection right
+// away would discard anything not yet delivered.
+func (c *quicConn) Close() error {
+	err := c.Stream.Close()
+	c.Stream.CancelRead(0) // unblocks readers, as closing a net.Conn would
+	go func() {
+		select {
+		case <-c.Connection.Context().Done():
+		case <-time.After(quicCloseTimeout):
+		}
+		c.Connection.CloseWithError(0, "closing")
+	}()
+	return err
+}
+
+// quicListener accepts protocol and session connections over QUIC, told
+// apart by the negotiated protocol.
+func quicListener(addr string, config *tls.Config, token string) {
+	listener, err := listenQUIC(addr, config)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	serveQUIC(listener, token)
+}
+
+func listenQUIC(addr string, config *tls.Config) (*quic.Listener, error) {
+	config = config.Clone()
+	config.NextProtos = []string{protocol.ProtocolName, protocol.SessionProtocolName}
+	config.MinVersion = tls.VersionTLS13
+	config.CipherSuites = nil // not configurable for TLS 1.3
+	// quic-go always asks for a session ticket after the handshake, and
+	// panics if crypto/tls doesn't hand one out because they are disabled.
+	config.SessionTicketsDisabled = false
+
+	return quic.ListenAddr(addr, config, quicConfig)
+}
+
+// serveQUIC handles the connections on the listener until it's closed.
+func serveQUIC(listener *quic.Listener, token string) {
+	for {
+		conn, err := listener.Accept(context.Background())
+		if errors.Is(err, quic.ErrServerClosed) {
+			return
+		}
+		if err != nil {
+			if debug {
+				log.Println("QUIC listener failed to accept:", err)
+			}
+			continue
+		}
+
+		if debug {
+			log.Println("QUIC listener accepted connection from", conn.RemoteAddr(), "protocol", conn.ConnectionState().TLS.NegotiatedProtocol)
+		}
+
+		go handleQUICConnection(conn, token)
+	}
+}
+
+func handleQUICConnection(conn quic.Connection, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), messageTimeout)
+	stream, err := conn.AcceptStream(ctx)
+	cancel()
+	if err != nil {
+		if debug {
+			log.Println("QUIC accept stream:", conn.RemoteAddr(), err)
+		}
+		conn.CloseWithError(0, "no stream")
+		return
+	}
+
+	qc := &quicConn{Connection: conn, Stream: stream}
+	state := conn.ConnectionState().TLS
+	switch state.NegotiatedProtocol {
+	case protocol.SessionProtocolName:
+		sessionConnectionHandler(qc)
+	default:
+		handleProtocolConnection(qc, state, token)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors.
+
+//go:build !noquic
+// +build !noquic
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/syncthing/syncthing/lib/relay/protocol"
+)
+
+// startTestQUICRelay serves QUIC connections to the relay and returns the
+// URI to reach it at.
+func startTestQUICRelay(t *testing.T) *url.URL {
+	t.Helper()
+	tlsCfg, relayID := newTestRelayConfig(t)
+	listener, err := listenQUIC("127.0.0.1:0", tlsCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		serveQUIC(listener, "")
+		close(done)
+	}()
+	t.Cleanup(func() {
+		listener.Close()
+		<-done
+	})
+
+	return &url.URL{
+		Scheme:   "relay+quic",
+		Host:     listener.Addr().String(),
+		RawQuery: url.Values{"id": []string{relayID.String()}}.Encode(),
+	}
+}
+
+func TestQUICListenerSession(t *testing.T) {
+	testRelaySession(t, startTestQUICRelay(t))
+}
+
+func TestQUICListenerProtocols(t *testing.T) {
+	uri := startTestQUICRelay(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cases := []struct {
+		proto string
+		ok    bool
+	}{
+		{protocol.ProtocolName, true},
+		{protocol.SessionProtocolName, true},
+		{"h3", false},
+	}
+	for _, tc := range cases {
+		conn, err := quic.DialAddr(ctx, uri.Host, &tls.Config{
+			InsecureSkipVerify: true,
+			NextProtos:         []string{tc.proto},
+			MinVersion:         tls.VersionTLS13,
+		}, quicConfig)
+		if !tc.ok {
+			if err == nil {
+				conn.CloseWithError(0, "")
+				t.Errorf("Expected %q to be refused", tc.proto)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Dialing with %q: %v", tc.proto, err)
+		}
+		if np := conn.ConnectionState().TLS.NegotiatedProtocol; np != tc.proto {
+			t.Errorf("Expected %q to be negotiated, got %q", tc.proto, np)
+		}
+		conn.CloseWithError(0, "")
+	}
+}
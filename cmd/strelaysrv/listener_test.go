@@ -0,0 +1,129 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	syncthingprotocol "github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/relay/client"
+	"github.com/syncthing/syncthing/lib/relay/protocol"
+	"github.com/syncthing/syncthing/lib/tlsutil"
+)
+
+func init() {
+	// Otherwise set from the command line.
+	networkBufferSize = 65536
+}
+
+// newTestRelayConfig returns a TLS config like the one the relay runs with,
+// and the relay's ID.
+func newTestRelayConfig(t *testing.T) (*tls.Config, syncthingprotocol.DeviceID) {
+	t.Helper()
+	cert := newTestCertificate(t)
+	return &tls.Config{
+		Certificates:           []tls.Certificate{cert},
+		NextProtos:             []string{protocol.ProtocolName},
+		ClientAuth:             tls.RequestClientCert,
+		SessionTicketsDisabled: true,
+		InsecureSkipVerify:     true,
+		MinVersion:             tls.VersionTLS12,
+	}, syncthingprotocol.NewDeviceID(cert.Certificate[0])
+}
+
+func newTestCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+	cert, err := tlsutil.NewCertificateInMemory("syncthing", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// testRelaySession has one device join the relay at the URI and another
+// connect to it, and checks that they can talk over the session.
+func testRelaySession(t *testing.T, uri *url.URL) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	serverCerts := []tls.Certificate{newTestCertificate(t)}
+	serverID := syncthingprotocol.NewDeviceID(serverCerts[0].Certificate[0])
+	clientCerts := []tls.Certificate{newTestCertificate(t)}
+
+	rc, err := client.NewClient(uri, serverCerts, 10*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go rc.Serve(ctx)
+
+	// The invitation can only be had once the server device has joined.
+	var clientInv protocol.SessionInvitation
+	for {
+		clientInv, err = client.GetInvitationFromRelay(ctx, uri, serverID, clientCerts, 10*time.Second)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			t.Fatal("Getting invitation:", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	var serverInv protocol.SessionInvitation
+	select {
+	case serverInv = <-rc.Invitations():
+	case <-ctx.Done():
+		t.Fatal("Server device didn't get an invitation")
+	}
+	if !serverInv.ServerSocket || clientInv.ServerSocket {
+		t.Errorf("Unexpected invitations: server %v, client %v", serverInv, clientInv)
+	}
+
+	clientConn, err := client.JoinSession(ctx, uri, clientInv)
+	if err != nil {
+		t.Fatal("Client joining session:", err)
+	}
+	defer clientConn.Close()
+	serverConn, err := client.JoinSession(ctx, uri, serverInv)
+	if err != nil {
+		t.Fatal("Server joining session:", err)
+	}
+	defer serverConn.Close()
+
+	for _, pair := range []struct {
+		from, to io.ReadWriter
+	}{{clientConn, serverConn}, {serverConn, clientConn}} {
+		msg := []byte("hello through the relay")
+		if _, err := pair.from.Write(msg); err != nil {
+			t.Fatal(err)
+		}
+		buf := make([]byte, len(msg))
+		if _, err := io.ReadFull(pair.to, buf); err != nil {
+			t.Fatal(err)
+		}
+		if string(buf) != string(msg) {
+			t.Errorf("Expected %q, got %q", msg, buf)
+		}
+	}
+}
+
+func TestRelaySessionUnknownDevice(t *testing.T) {
+	// Without a device having joined there is no invitation to be had;
+	// exercised over WebSocket, the transport that needs no extra
+	// listener.
+	uri := startTestWSRelay(t, "")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := client.GetInvitationFromRelay(ctx, uri, syncthingprotocol.DeviceID{42}, []tls.Certificate{newTestCertificate(t)}, 5*time.Second)
+	if err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected not found response, got %v", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/syncthing/syncthing/lib/relay/protocol"
+)
+
+// wsListener accepts protocol and session connections over WebSocket, told
+// apart by the negotiated subprotocol. Protocol connections run TLS on top
+// of the WebSocket, as the HTTPS may be terminated by a proxy in front of
+// us. With useHTTP we listen on plain HTTP for such a proxy.
+func wsListener(addr, path string, config *tls.Config, token string, useHTTP bool) {
+	srv := newWSServer(addr, path, config, token)
+
+	var err error
+	if useHTTP {
+		err = srv.ListenAndServe()
+	} else {
+		srv.TLSConfig = &tls.Config{
+			Certificates: config.Certificates,
+			MinVersion:   tls.VersionTLS12,
+		}
+		err = srv.ListenAndServeTLS("", "")
+	}
+	log.Fatalln(err)
+}
+
+// newWSServer returns the HTTP server for WebSocket connections. Its
+// timeouts apply until the connection is upgraded; after that, the relay
+// protocol has its own.
+func newWSServer(addr, path string, config *tls.Config, token string) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, req *http.Request) {
+		handleWebSocket(w, req, config, token)
+	})
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       messageTimeout,
+	}
+}
+
+func handleWebSocket(w http.ResponseWriter, req *http.Request, config *tls.Config, token string) {
+	conn, err := websocket.Accept(w, req, &websocket.AcceptOptions{
+		Subprotocols: []string{protocol.ProtocolName, protocol.SessionProtocolName},
+		// Not a browser API; the origin has no meaning here.
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		if debug {
+			log.Println("WebSocket accept:", req.RemoteAddr, err)
+		}
+		return
+	}
+
+	if debug {
+		log.Println("WebSocket listener accepted connection from", req.RemoteAddr, "subprotocol", conn.Subprotocol())
+	}
+
+	// The connection outlives this handler for sessions, hence not using
+	// the request context.
+	nc := websocket.NetConn(context.Background(), conn, websocket.MessageBinary)
+	switch conn.Subprotocol() {
+	case protocol.ProtocolName:
+		protocolConnectionHandler(nc, config, token)
+	case protocol.SessionProtocolName:
+		sessionConnectionHandler(nc)
+	default:
+		conn.Close(websocket.StatusPolicyViolation, "unsupported subprotocol")
+	}
+}
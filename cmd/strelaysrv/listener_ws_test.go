@@ -0,0 +1,109 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors.
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/syncthing/syncthing/lib/relay/protocol"
+)
+
+// startTestWSRelay serves WebSocket connections to the relay over HTTPS and
+// returns the URI to reach it at.
+func startTestWSRelay(t *testing.T, token string) *url.URL {
+	t.Helper()
+	tlsCfg, relayID := newTestRelayConfig(t)
+	srv := newWSServer("", "/relay", tlsCfg, token)
+	ts := httptest.NewUnstartedServer(srv.Handler)
+	ts.Config = srv
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+
+	q := url.Values{"id": []string{relayID.String()}}
+	if token != "" {
+		q.Set("token", token)
+	}
+	return &url.URL{
+		Scheme:   "relay+wss",
+		Host:     strings.TrimPrefix(ts.URL, "https://"),
+		Path:     "/relay",
+		RawQuery: q.Encode(),
+	}
+}
+
+func TestWSServerTimeouts(t *testing.T) {
+	tlsCfg, _ := newTestRelayConfig(t)
+	srv := newWSServer(":0", "/", tlsCfg, "")
+	if srv.ReadHeaderTimeout <= 0 || srv.IdleTimeout <= 0 {
+		t.Errorf("Expected header and idle timeouts, got %v and %v", srv.ReadHeaderTimeout, srv.IdleTimeout)
+	}
+}
+
+func TestWSListenerSession(t *testing.T) {
+	testRelaySession(t, startTestWSRelay(t, ""))
+}
+
+func TestWSListenerSessionToken(t *testing.T) {
+	testRelaySession(t, startTestWSRelay(t, "secret"))
+}
+
+func TestWSListenerSubprotocol(t *testing.T) {
+	uri := startTestWSRelay(t, "")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Connections without one of our subprotocols don't get one
+	// negotiated, and are closed.
+	conn, _, err := websocket.Dial(ctx, "wss://"+uri.Host+uri.Path, &websocket.DialOptions{
+		HTTPClient:   insecureTestClient(),
+		Subprotocols: []string{"other"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.CloseNow()
+	if conn.Subprotocol() != "" {
+		t.Errorf("Expected no subprotocol, got %q", conn.Subprotocol())
+	}
+	if _, _, err := conn.Read(ctx); websocket.CloseStatus(err) != websocket.StatusPolicyViolation {
+		t.Errorf("Expected policy violation, got %v", err)
+	}
+
+	// A session connection without a valid session is refused.
+	conn, _, err = websocket.Dial(ctx, "wss://"+uri.Host+uri.Path, &websocket.DialOptions{
+		HTTPClient:   insecureTestClient(),
+		Subprotocols: []string{protocol.SessionProtocolName},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nc := websocket.NetConn(ctx, conn, websocket.MessageBinary)
+	defer nc.Close()
+	if err := protocol.WriteMessage(nc, protocol.JoinSessionRequest{Key: []byte("nope")}); err != nil {
+		t.Fatal(err)
+	}
+	msg, err := protocol.ReadMessage(nc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, ok := msg.(protocol.Response); !ok || resp.Code != protocol.ResponseNotFound.Code {
+		t.Errorf("Expected not found response, got %v", msg)
+	}
+}
+
+func insecureTestClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		},
+	}
+}
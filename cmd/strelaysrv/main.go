@@ -55,7 +55,15 @@ var (
 	networkBufferSize int
 
 	statusAddr       string
+	quicListen       string
+	wsListen         string
+	wsPath           string
+	wsHTTP           bool
 	token            string
+	authFile         string
+	usageFile        string
+	quotaPeriod      = 30 * 24 * time.Hour
+	authReload       = time.Minute
 	poolAddrs        string
 	pools            []string
 	providedBy       string
@@ -87,9 +95,17 @@ func main() {
 	flag.DurationVar(&messageTimeout, "message-timeout", messageTimeout, "Maximum amount of time we wait for relevant messages to arrive")
 	flag.IntVar(&sessionLimitBps, "per-session-rate", sessionLimitBps, "Per session rate limit, in bytes/s")
 	flag.IntVar(&globalLimitBps, "global-rate", globalLimitBps, "Global rate limit, in bytes/s")
+	flag.StringVar(&quicListen, "quic-listen", "", "Protocol listen address for QUIC (optional), e.g. \":22067\"")
+	flag.StringVar(&wsListen, "ws-listen", "", "Protocol listen address for WebSocket over HTTPS (optional), e.g. \":443\".\n\tLets devices that can only make HTTPS connections, possibly through a proxy, use the relay.")
+	flag.StringVar(&wsPath, "ws-path", "/", "HTTP path to accept WebSocket connections on")
+	flag.BoolVar(&wsHTTP, "ws-http", false, "Accept WebSocket connections over plain HTTP, behind an HTTPS proxy")
 	flag.BoolVar(&debug, "debug", debug, "Enable debug output")
 	flag.StringVar(&statusAddr, "status-srv", ":22070", "Listen address for status service (blank to disable)")
 	flag.StringVar(&token, "token", "", "Token to restrict access to the relay (optional). Disables joining any pools.")
+	flag.StringVar(&authFile, "auth-file", "", "JSON file listing the devices allowed to use the relay, with optional per device rate limits and quotas (optional). Disables joining any pools.\n\tReloaded when changed, or on SIGHUP.")
+	flag.DurationVar(&authReload, "auth-reload-interval", authReload, "How often to check the authorization file for changes")
+	flag.StringVar(&usageFile, "usage-file", "", "File to keep the per device usage in across restarts (optional, requires -auth-file)")
+	flag.DurationVar(&quotaPeriod, "quota-period", quotaPeriod, "Length of the period the per device quotas apply to")
 	flag.StringVar(&poolAddrs, "pools", defaultPoolAddrs, "Comma separated list of relay pool addresses to join")
 	flag.StringVar(&providedBy, "provided-by", "", "An optional description about who provides the relay")
 	flag.StringVar(&extAddress, "ext-address", "", "An optional address to advertise as being available on.\n\tAllows listening on an unprivileged port with port forwarding from e.g. 443, and be connected to on port 443.")
@@ -234,6 +250,15 @@ func main() {
 		globalLimiter = rate.NewLimiter(rate.Limit(globalLimitBps), 2*globalLimitBps)
 	}
 
+	if authFile != "" {
+		deviceAuth, err = newAuthorizer(authFile, usageFile, quotaPeriod)
+		if err != nil {
+			log.Fatalln("Failed to load authorization file:", err)
+		}
+	} else if usageFile != "" {
+		log.Fatalln("The usage file requires an authorization file")
+	}
+
 	if statusAddr != "" {
 		go statusService(statusAddr)
 	}
@@ -264,8 +289,14 @@ func main() {
 	uri.RawQuery = query.Encode()
 
 	log.Println("URI:", uri.String())
+	if quicListen != "" {
+		log.Println("QUIC URI:", transportURI(uri, "relay+quic", quicListen, "/"))
+	}
+	if wsListen != "" {
+		log.Println("WebSocket URI:", transportURI(uri, "relay+wss", wsListen, wsPath))
+	}
 
-	if token != "" {
+	if token != "" || authFile != "" {
 		poolAddrs = ""
 	}
 
@@ -285,11 +316,29 @@ func main() {
 	}
 
 	go listener(proto, listen, tlsCfg, token)
+	if quicListen != "" {
+		go quicListener(quicListen, tlsCfg, token)
+	}
+	if wsListen != "" {
+		go wsListener(wsListen, wsPath, tlsCfg, token, wsHTTP)
+	}
+
+	stopAuth := make(chan struct{})
+	if deviceAuth != nil {
+		hup := make(chan os.Signal, 1)
+		signal.Notify(hup, syscall.SIGHUP)
+		go deviceAuth.serve(authReload, hup, stopAuth)
+	}
 
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
 	<-sigs
 
+	close(stopAuth)
+	if err := deviceAuth.saveUsage(); err != nil {
+		log.Println("Saving usage:", err)
+	}
+
 	// Gracefully close all connections, hoping that clients will be faster
 	// to realize that the relay is now gone.
 
@@ -325,6 +374,21 @@ func monitorLimits() {
 	}
 }
 
+// transportURI returns the relay URI for the given transport, listening on
+// addr. The host is that of the TCP relay URI, which is the best guess at
+// our external address.
+func transportURI(uri *url.URL, scheme, addr, path string) *url.URL {
+	turi := *uri
+	turi.Scheme = scheme
+	turi.Path = path
+	if host, _, err := net.SplitHostPort(uri.Host); err == nil {
+		if _, port, err := net.SplitHostPort(addr); err == nil {
+			turi.Host = net.JoinHostPort(host, port)
+		}
+	}
+	return &turi
+}
+
 type mapping struct {
 	*nat.Mapping
 }
@@ -45,7 +45,8 @@ func newSession(serverid, clientid syncthingprotocol.DeviceID, sessionRateLimit,
 		serverid:  serverid,
 		clientkey: clientkey,
 		clientid:  clientid,
-		rateLimit: makeRateLimitFunc(sessionRateLimit, globalRateLimit),
+		rateLimit: makeRateLimitFunc(sessionRateLimit, globalRateLimit, deviceAuth.limiters(serverid, clientid)...),
+		account:   deviceAuth.accountFunc(serverid, clientid),
 		connsChan: make(chan net.Conn),
 		conns:     make([]net.Conn, 0, 2),
 	}
@@ -110,6 +111,7 @@ type session struct {
 	clientid  syncthingprotocol.DeviceID
 
 	rateLimit func(bytes int)
+	account   func(bytes int) error
 
 	connsChan chan net.Conn
 	conns     []net.Conn
@@ -268,6 +270,12 @@ func (s *session) proxy(c1, c2 net.Conn) error {
 			log.Printf("%d bytes from %s to %s", n, c1.RemoteAddr(), c2.RemoteAddr())
 		}
 
+		if s.account != nil {
+			if err := s.account(n); err != nil {
+				return err
+			}
+		}
+
 		if s.rateLimit != nil {
 			s.rateLimit(n)
 		}
@@ -284,11 +292,26 @@ func (s *session) String() string {
 	return fmt.Sprintf("<%s/%s>", hex.EncodeToString(s.clientkey)[:5], hex.EncodeToString(s.serverkey)[:5])
 }
 
-func makeRateLimitFunc(sessionRateLimit, globalRateLimit *rate.Limiter) func(int) {
+func makeRateLimitFunc(sessionRateLimit, globalRateLimit *rate.Limiter, deviceRateLimits ...*rate.Limiter) func(int) {
 	// This may be a case of super duper premature optimization... We build an
 	// optimized function to do the rate limiting here based on what we need
 	// to do and then use it in the loop.
 
+	if len(deviceRateLimits) > 0 {
+		// The devices taking part have their own limits, queue the bytes
+		// on all limiters that apply.
+		ls := deviceRateLimits
+		if sessionRateLimit != nil {
+			ls = append(ls, sessionRateLimit)
+		}
+		if globalRateLimit != nil {
+			ls = append(ls, globalRateLimit)
+		}
+		return func(bytes int) {
+			take(bytes, ls...)
+		}
+	}
+
 	if sessionRateLimit == nil && globalRateLimit == nil {
 		// No limiting needed. We could equally well return a func(int64){} and
 		// not do a nil check were we use it, but I think the nil check there
@@ -75,6 +75,10 @@ func getStatus(w http.ResponseWriter, _ *http.Request) {
 		"global-rate":      globalLimitBps,
 		"pools":            pools,
 		"provided-by":      providedBy,
+		"auth":             deviceAuth != nil,
+	}
+	if deviceAuth != nil {
+		status["devices"], status["groups"] = deviceAuth.status()
 	}
 
 	bs, err := json.MarshalIndent(status, "", "    ")
@@ -7,6 +7,7 @@ import (
 	"context"
 	"crypto/tls"
 	"flag"
+	"io"
 	"log"
 	"net"
 	"net/url"
@@ -81,7 +82,7 @@ func main() {
 		}()
 
 		for {
-			conn, err := client.JoinSession(ctx, <-recv)
+			conn, err := client.JoinSession(ctx, uri, <-recv)
 			if err != nil {
 				log.Fatalln("Failed to join", err)
 			}
@@ -101,7 +102,7 @@ func main() {
 		}
 
 		log.Println("Received invitation", invite)
-		conn, err := client.JoinSession(ctx, invite)
+		conn, err := client.JoinSession(ctx, uri, invite)
 		if err != nil {
 			log.Fatalln("Failed to join", err)
 		}
@@ -128,26 +129,24 @@ func stdinReader(c chan<- string) {
 }
 
 func connectToStdio(stdin <-chan string, conn net.Conn) {
-	buf := make([]byte, 1024)
-	for {
-		conn.SetReadDeadline(time.Now().Add(time.Millisecond))
-		n, err := conn.Read(buf[0:])
-		if err != nil {
-			nerr, ok := err.(net.Error)
-			if !ok || !nerr.Timeout() {
-				log.Println(err)
-				return
-			}
+	// Reads happen on their own, as polling with short read deadlines would
+	// close WebSocket connections.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := io.Copy(os.Stdout, conn); err != nil {
+			log.Println(err)
 		}
-		os.Stdout.Write(buf[:n])
+	}()
 
+	for {
 		select {
 		case msg := <-stdin:
-			_, err := conn.Write([]byte(msg))
-			if err != nil {
+			if _, err := conn.Write([]byte(msg)); err != nil {
 				return
 			}
-		default:
+		case <-done:
+			return
 		}
 	}
 }
@@ -25,12 +25,12 @@ const (
 )
 
 // uploadPanicLogs attempts to upload all the panic logs in the named
-// directory to the crash reporting server as urlBase. Uploads are attempted
-// with the newest log first.
+// directory to the crash reporting server as urlBase, using the client.
+// Uploads are attempted with the newest log first.
 //
 // This can can block for a long time. The context can set a final deadline
 // for this.
-func uploadPanicLogs(ctx context.Context, urlBase, dir string) {
+func uploadPanicLogs(ctx context.Context, client *http.Client, urlBase, dir string) {
 	files, err := filepath.Glob(filepath.Join(dir, "panic-*.log"))
 	if err != nil {
 		l.Warnln("Failed to list panic logs:", err)
@@ -45,7 +45,7 @@ func uploadPanicLogs(ctx context.Context, urlBase, dir string) {
 			continue
 		}
 
-		if err := uploadPanicLog(ctx, urlBase, file); err != nil {
+		if err := uploadPanicLog(ctx, client, urlBase, file); err != nil {
 			l.Warnln("Reporting crash:", err)
 		} else {
 			// Rename the log so we don't have to try to report it again. This
@@ -59,7 +59,7 @@ func uploadPanicLogs(ctx context.Context, urlBase, dir string) {
 // reporting server at urlBase. The panic ID is constructed as the sha256 of
 // the log contents. A HEAD request is made to see if the log has already
 // been reported. If not, a PUT is made with the log contents.
-func uploadPanicLog(ctx context.Context, urlBase, file string) error {
+func uploadPanicLog(ctx context.Context, client *http.Client, urlBase, file string) error {
 	data, err := os.ReadFile(file)
 	if err != nil {
 		return err
@@ -82,7 +82,7 @@ func uploadPanicLog(ctx context.Context, urlBase, file string) error {
 	defer headCancel()
 	headReq = headReq.WithContext(headCtx)
 
-	resp, err := http.DefaultClient.Do(headReq)
+	resp, err := client.Do(headReq)
 	if err != nil {
 		return err
 	}
@@ -102,7 +102,7 @@ func uploadPanicLog(ctx context.Context, urlBase, file string) error {
 	defer putCancel()
 	putReq = putReq.WithContext(putCtx)
 
-	resp, err = http.DefaultClient.Do(putReq)
+	resp, err = client.Do(putReq)
 	if err != nil {
 		return err
 	}
@@ -15,9 +15,12 @@ import (
 	"io"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db/backend"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
@@ -25,17 +28,24 @@ import (
 )
 
 type CLI struct {
-	Path       string `arg:"" required:"1" help:"Path to encrypted folder"`
-	To         string `xor:"mode" placeholder:"PATH" help:"Destination directory, when decrypting"`
-	VerifyOnly bool   `xor:"mode" help:"Don't write decrypted files to disk (but verify plaintext hashes)"`
-	Password   string `help:"Folder password for decryption / verification" env:"FOLDER_PASSWORD"`
-	FolderID   string `help:"Folder ID of the encrypted folder, if it cannot be determined automatically"`
-	Continue   bool   `help:"Continue processing next file in case of error, instead of aborting"`
-	Verbose    bool   `help:"Show verbose progress information"`
-	TokenPath  string `placeholder:"PATH" help:"Path to the token file within the folder (used to determine folder ID)"`
+	Path       string   `arg:"" required:"1" help:"Path to encrypted folder"`
+	To         string   `xor:"mode" placeholder:"PATH" help:"Destination directory, when decrypting"`
+	Tar        string   `xor:"mode" placeholder:"PATH" help:"Destination tar archive, when decrypting (use \"-\" for standard output)"`
+	Zip        string   `xor:"mode" placeholder:"PATH" help:"Destination zip archive, when decrypting (use \"-\" for standard output)"`
+	VerifyOnly bool     `xor:"mode" help:"Don't write decrypted files to disk (but verify plaintext hashes)"`
+	Only       []string `placeholder:"PATH" sep:"none" help:"Only process the given plaintext file or directory (may be repeated)"`
+	Password   string   `help:"Folder password for decryption / verification" env:"FOLDER_PASSWORD"`
+	FolderID   string   `help:"Folder ID of the encrypted folder, if it cannot be determined automatically"`
+	Continue   bool     `help:"Continue processing next file in case of error, instead of aborting"`
+	Verbose    bool     `help:"Show verbose progress information"`
+	TokenPath  string   `placeholder:"PATH" help:"Path to the token file within the folder (used to determine folder ID)"`
+	Index      string   `placeholder:"PATH" help:"Database directory of the untrusted device, to report files in its index that are missing from the folder"`
 
 	folderKey *[32]byte
 	keyGen    *protocol.KeyGenerator
+	only      map[string]bool              // selected path -> seen
+	indexed   map[string]protocol.FileInfo // encrypted name -> file, while not seen
+	report    report
 }
 
 type storedEncryptionToken struct {
@@ -46,8 +56,8 @@ type storedEncryptionToken struct {
 func (c *CLI) Run() error {
 	log.SetFlags(0)
 
-	if c.To == "" && !c.VerifyOnly {
-		return errors.New("must set --to or --verify-only")
+	if c.To == "" && c.Tar == "" && c.Zip == "" && !c.VerifyOnly {
+		return errors.New("must set --to, --tar, --zip or --verify-only")
 	}
 
 	if c.TokenPath == "" {
@@ -72,16 +82,93 @@ func (c *CLI) Run() error {
 	c.keyGen = protocol.NewKeyGenerator()
 	c.folderKey = c.keyGen.KeyFromPassword(c.FolderID, c.Password)
 
-	return c.walk()
+	if len(c.Only) > 0 {
+		c.only = make(map[string]bool, len(c.Only))
+		for _, p := range c.Only {
+			c.only[cleanSelection(p)] = false
+		}
+	}
+
+	if c.Index != "" {
+		if err := c.loadIndex(); err != nil {
+			return fmt.Errorf("loading index: %w", err)
+		}
+	}
+
+	dst, err := c.destination()
+	if err != nil {
+		return err
+	}
+	walkErr := c.walk(dst)
+	if err := dst.Close(); err != nil && walkErr == nil {
+		walkErr = err
+	}
+	if walkErr != nil {
+		return walkErr
+	}
+
+	c.indexedMissing()
+	for p, seen := range c.only {
+		if !seen {
+			c.report.missing = append(c.report.missing, p)
+		}
+	}
+	return c.report.print()
+}
+
+func (c *CLI) loadIndex() error {
+	ldb, err := backend.OpenLevelDBRO(c.Index)
+	if err != nil {
+		return err
+	}
+	defer ldb.Close()
+	c.indexed, err = indexedFiles(ldb, c.FolderID)
+	return err
+}
+
+// indexedMissing adds the selected files that are in the index but weren't
+// found in the folder to the missing ones. Those with names we can't
+// decrypt are reported by encrypted name.
+func (c *CLI) indexedMissing() {
+	for name, encFi := range c.indexed {
+		plainFi, err := protocol.DecryptFileInfo(c.keyGen, encFi, c.folderKey)
+		if err != nil {
+			if c.only == nil {
+				c.report.missing = append(c.report.missing, name)
+			}
+			continue
+		}
+		if c.selected(plainFi.Name) {
+			c.report.missing = append(c.report.missing, plainFi.Name)
+		}
+	}
+}
+
+// destination returns where decrypted files go according to the options.
+func (c *CLI) destination() (destination, error) {
+	switch {
+	case c.To != "":
+		return &dirDestination{fs: fs.NewFilesystem(fs.FilesystemTypeBasic, c.To)}, nil
+	case c.Tar != "":
+		w, err := createArchive(c.Tar)
+		if err != nil {
+			return nil, err
+		}
+		return newTarDestination(w), nil
+	case c.Zip != "":
+		w, err := createArchive(c.Zip)
+		if err != nil {
+			return nil, err
+		}
+		return newZipDestination(w), nil
+	default:
+		return verifyDestination{}, nil
+	}
 }
 
 // walk finds and processes every file in the encrypted folder
-func (c *CLI) walk() error {
+func (c *CLI) walk(dst destination) error {
 	srcFs := fs.NewFilesystem(fs.FilesystemTypeBasic, c.Path)
-	var dstFs fs.Filesystem
-	if c.To != "" {
-		dstFs = fs.NewFilesystem(fs.FilesystemTypeBasic, c.To)
-	}
 
 	return srcFs.Walk(".", func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
@@ -93,8 +180,9 @@ func (c *CLI) walk() error {
 		if fs.IsInternal(path) {
 			return nil
 		}
+		delete(c.indexed, osutil.NormalizedFilename(path))
 
-		return c.withContinue(c.process(srcFs, dstFs, path))
+		return c.withContinue(c.process(srcFs, dst, path))
 	})
 }
 
@@ -104,6 +192,7 @@ func (c *CLI) withContinue(err error) error {
 	if err == nil {
 		return nil
 	}
+	c.report.failed = append(c.report.failed, err)
 	if c.Continue {
 		log.Println("Warning:", err)
 		return nil
@@ -111,6 +200,29 @@ func (c *CLI) withContinue(err error) error {
 	return err
 }
 
+// selected returns whether the given plaintext name was selected for
+// processing, and marks the selection as seen.
+func (c *CLI) selected(name string) bool {
+	if c.only == nil {
+		return true
+	}
+	for p := range c.only {
+		if p == "" || name == p || strings.HasPrefix(name, p+"/") {
+			c.only[p] = true
+			return true
+		}
+	}
+	return false
+}
+
+// cleanSelection returns the given path in the format of plaintext names
+// in the index: slash separated and without leading or trailing slashes.
+func cleanSelection(p string) string {
+	p = osutil.NormalizedFilename(filepath.ToSlash(p))
+	p = path.Clean("/" + p)
+	return strings.TrimPrefix(p, "/")
+}
+
 // getFolderID returns the folder ID found in the encrypted token, or an
 // error.
 func (c *CLI) getFolderID() (string, error) {
@@ -128,12 +240,8 @@ func (c *CLI) getFolderID() (string, error) {
 	return tok.FolderID, nil
 }
 
-// process handles the file named path in srcFs, decrypting it into dstFs
-// unless dstFs is nil.
-func (c *CLI) process(srcFs fs.Filesystem, dstFs fs.Filesystem, path string) error {
-	// Which filemode bits to preserve
-	const retainBits = fs.ModePerm | fs.ModeSetgid | fs.ModeSetuid | fs.ModeSticky
-
+// process handles the file named path in srcFs, decrypting it into dst.
+func (c *CLI) process(srcFs fs.Filesystem, dst destination, path string) error {
 	if c.Verbose {
 		log.Printf("Processing %q", path)
 	}
@@ -144,7 +252,7 @@ func (c *CLI) process(srcFs fs.Filesystem, dstFs fs.Filesystem, path string) err
 	}
 	defer encFd.Close()
 
-	encFi, err := loadEncryptedFileInfo(encFd)
+	encFi, dataSize, err := loadEncryptedFileInfo(encFd)
 	if err != nil {
 		return fmt.Errorf("%s: loading metadata trailer: %w", path, err)
 	}
@@ -153,62 +261,61 @@ func (c *CLI) process(srcFs fs.Filesystem, dstFs fs.Filesystem, path string) err
 	// in native format, while protocol expects wire format (slashes).
 	encFi.Name = osutil.NormalizedFilename(encFi.Name)
 
+	// The trailer must describe the file it's attached to, otherwise the
+	// file has been moved or replaced.
+	if encFi.Name != osutil.NormalizedFilename(path) {
+		return fmt.Errorf("%s: metadata trailer is for %q", path, encFi.Name)
+	}
+
 	plainFi, err := protocol.DecryptFileInfo(c.keyGen, *encFi, c.folderKey)
 	if err != nil {
 		return fmt.Errorf("%s: decrypting metadata: %w", path, err)
 	}
 
+	if !c.selected(plainFi.Name) {
+		return nil
+	}
+
 	if c.Verbose {
 		log.Printf("Plaintext filename is %q", plainFi.Name)
 	}
 
-	var plainFd fs.File
-	if dstFs != nil {
-		if err := dstFs.MkdirAll(filepath.Dir(plainFi.Name), 0o700); err != nil {
-			return fmt.Errorf("%s: %w", plainFi.Name, err)
-		}
-
-		plainFd, err = dstFs.Create(plainFi.Name)
-		if err != nil {
-			return fmt.Errorf("%s: %w", plainFi.Name, err)
-		}
-		defer plainFd.Close() // also closed explicitly in the return
-		if err := dstFs.Chmod(plainFi.Name, fs.FileMode(plainFi.Permissions&uint32(retainBits))); err != nil {
-			return fmt.Errorf("%s: %w", plainFi.Name, err)
-		}
+	// Check that all the encrypted data is there before we start writing
+	// anything, to tell truncated files apart from corrupt ones.
+	if dataSize < encFi.Size {
+		return fmt.Errorf("%s: %s: missing data, have %d of %d encrypted bytes", path, plainFi.Name, dataSize, encFi.Size)
 	}
 
-	if err := c.decryptFile(encFi, &plainFi, encFd, plainFd); err != nil {
-		// Decrypting the file failed, leaving it in an inconsistent state.
-		// Delete it. Even --continue currently doesn't mean "leave broken
-		// stuff in place", it just means "try the next file instead of
-		// aborting".
-		if plainFd != nil {
-			_ = dstFs.Remove(plainFd.Name())
-		}
+	var invalid error
+	err = dst.writeFile(&plainFi, func(w io.WriterAt) error {
+		var err error
+		invalid, err = c.decryptFile(encFi, &plainFi, encFd, w)
+		return err
+	})
+	if err != nil {
 		return fmt.Errorf("%s: %s: %w", path, plainFi.Name, err)
-	} else if c.Verbose {
-		log.Printf("Data verified for %q", plainFi.Name)
 	}
-
-	if plainFd != nil {
-		if err := plainFd.Close(); err != nil {
-			return fmt.Errorf("%s: %w", plainFi.Name, err)
-		}
-		if err := dstFs.Chtimes(plainFi.Name, plainFi.ModTime(), plainFi.ModTime()); err != nil {
-			return fmt.Errorf("%s: %w", plainFi.Name, err)
-		}
+	if invalid != nil {
+		// Blocks failed validation but we continued anyway. That's one
+		// failed file, however many blocks.
+		c.report.failed = append(c.report.failed, fmt.Errorf("%s: %s: %w", path, plainFi.Name, invalid))
+		return nil
+	}
+	if c.Verbose {
+		log.Printf("Data verified for %q", plainFi.Name)
 	}
+	c.report.ok++
 	return nil
 }
 
 // decryptFile reads, decrypts and verifies all the blocks in src, writing
 // it to dst if dst is non-nil. (If dst is nil it just becomes a
-// read-and-verify operation.)
-func (c *CLI) decryptFile(encFi *protocol.FileInfo, plainFi *protocol.FileInfo, src io.ReaderAt, dst io.WriterAt) error {
+// read-and-verify operation.) With --continue, blocks failing validation
+// are written anyway and the first such failure is returned as invalid.
+func (c *CLI) decryptFile(encFi *protocol.FileInfo, plainFi *protocol.FileInfo, src io.ReaderAt, dst io.WriterAt) (invalid, err error) {
 	// The encrypted and plaintext files must consist of an equal number of blocks
 	if len(encFi.Blocks) != len(plainFi.Blocks) {
-		return fmt.Errorf("block count mismatch: encrypted %d != plaintext %d", len(encFi.Blocks), len(plainFi.Blocks))
+		return nil, fmt.Errorf("block count mismatch: encrypted %d != plaintext %d", len(encFi.Blocks), len(plainFi.Blocks))
 	}
 
 	fileKey := c.keyGen.FileKey(plainFi.Name, c.folderKey)
@@ -216,13 +323,13 @@ func (c *CLI) decryptFile(encFi *protocol.FileInfo, plainFi *protocol.FileInfo,
 		// Read the encrypted block
 		buf := make([]byte, encBlock.Size)
 		if _, err := src.ReadAt(buf, encBlock.Offset); err != nil {
-			return fmt.Errorf("encrypted block %d (%d bytes): %w", i, encBlock.Size, err)
+			return nil, fmt.Errorf("encrypted block %d (%d bytes): %w", i, encBlock.Size, err)
 		}
 
 		// Decrypt it
 		dec, err := protocol.DecryptBytes(buf, fileKey)
 		if err != nil {
-			return fmt.Errorf("encrypted block %d (%d bytes): %w", i, encBlock.Size, err)
+			return nil, fmt.Errorf("encrypted block %d (%d bytes): %w", i, encBlock.Size, err)
 		}
 
 		// Verify the block size against the expected plaintext
@@ -231,59 +338,62 @@ func (c *CLI) decryptFile(encFi *protocol.FileInfo, plainFi *protocol.FileInfo,
 			// The last block might be padded, which is fine (we skip the padding)
 			dec = dec[:plainBlock.Size]
 		} else if len(dec) != plainBlock.Size {
-			return fmt.Errorf("plaintext block %d size mismatch, actual %d != expected %d", i, len(dec), plainBlock.Size)
+			return nil, fmt.Errorf("plaintext block %d size mismatch, actual %d != expected %d", i, len(dec), plainBlock.Size)
 		}
 
 		// Verify the hash against the plaintext block info
-		if !scanner.Validate(dec, plainBlock.Hash, 0) {
+		if !scanner.Validate(dec, plainBlock.Hash, 0, plainFi.BlockHashAlgorithm) {
 			// The block decrypted correctly but fails the hash check. This
 			// is odd and unexpected, but it it's still a valid block from
 			// the source. The file might have changed while we pulled it?
 			err := fmt.Errorf("plaintext block %d (%d bytes) failed validation after decryption", i, plainBlock.Size)
-			if c.Continue {
-				log.Printf("Warning: %s: %s: %v", encFi.Name, plainFi.Name, err)
-			} else {
-				return err
+			if !c.Continue {
+				return nil, err
+			}
+			log.Printf("Warning: %s: %s: %v", encFi.Name, plainFi.Name, err)
+			if invalid == nil {
+				invalid = err
 			}
 		}
 
 		// Write it to the destination, unless we're just verifying.
 		if dst != nil {
 			if _, err := dst.WriteAt(dec, plainBlock.Offset); err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
 
-	return nil
+	return invalid, nil
 }
 
 // loadEncryptedFileInfo loads the encrypted FileInfo trailer from a file on
-// disk.
-func loadEncryptedFileInfo(fd fs.File) (*protocol.FileInfo, error) {
+// disk, returning it and the size of the data preceding it.
+func loadEncryptedFileInfo(fd fs.File) (*protocol.FileInfo, int64, error) {
 	// Seek to the size of the trailer block
 	if _, err := fd.Seek(-4, io.SeekEnd); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	var bs [4]byte
 	if _, err := io.ReadFull(fd, bs[:]); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	size := int64(binary.BigEndian.Uint32(bs[:]))
 
 	// Seek to the start of the trailer
-	if _, err := fd.Seek(-(4 + size), io.SeekEnd); err != nil {
-		return nil, err
+	dataSize, err := fd.Seek(-(4 + size), io.SeekEnd)
+	if err != nil {
+		return nil, 0, err
 	}
 	trailer := make([]byte, size)
 	if _, err := io.ReadFull(fd, trailer); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	var encFi protocol.FileInfo
 	if err := encFi.Unmarshal(trailer); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return &encFi, nil
+	return &encFi, dataSize, nil
 }
@@ -0,0 +1,118 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package decrypt
+
+import (
+	"encoding/binary"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestProcessTrailerForOtherFile(t *testing.T) {
+	srcFs := fs.NewFilesystem(fs.FilesystemTypeBasic, t.TempDir())
+	if err := srcFs.MkdirAll("a", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// A file with the data and trailer of another one, e.g. after being
+	// moved around on the untrusted device.
+	trailer := protocol.FileInfo{Name: "a/c", Size: 4}
+	bs, err := trailer.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs = append([]byte("data"), bs...)
+	bs = binary.BigEndian.AppendUint32(bs, uint32(len(bs)-4))
+	fd, err := srcFs.Create("a/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fd.Write(bs); err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+
+	c := &CLI{}
+	err = c.process(srcFs, verifyDestination{}, "a/b")
+	if err == nil || !strings.Contains(err.Error(), `metadata trailer is for "a/c"`) {
+		t.Error("Expected trailer name mismatch, got", err)
+	}
+}
+
+func TestIndexedFiles(t *testing.T) {
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ldb.Close()
+	fset, err := db.NewFileSet("folder", ldb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	version := protocol.Vector{}.Update(1)
+	fset.Update(protocol.LocalDeviceID, []protocol.FileInfo{
+		{Name: "AB/CDEF", Version: version, Size: 10, Encrypted: []byte("enc")},
+		{Name: "AB/GHIJ", Version: version, Deleted: true},
+		{Name: "AB", Version: version, Type: protocol.FileInfoTypeDirectory},
+		{Name: "KL/MNOP", Version: version, LocalFlags: protocol.FlagLocalIgnored},
+	})
+	fset.Update(protocol.DeviceID{1}, []protocol.FileInfo{
+		{Name: "QR/STUV", Version: version, Size: 10},
+	})
+
+	files, err := indexedFiles(ldb, "folder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) != 1 || names[0] != "AB/CDEF" {
+		t.Fatalf("Expected only AB/CDEF, got %v", names)
+	}
+	if f := files["AB/CDEF"]; f.Size != 10 || string(f.Encrypted) != "enc" {
+		t.Errorf("Unexpected file %v", f)
+	}
+
+	if _, err := indexedFiles(ldb, "other"); err == nil {
+		t.Error("Expected error for unknown folder")
+	}
+}
+
+func TestIndexedMissing(t *testing.T) {
+	keyGen := protocol.NewKeyGenerator()
+	c := &CLI{
+		keyGen:    keyGen,
+		folderKey: keyGen.KeyFromPassword("folder", "password"),
+		indexed: map[string]protocol.FileInfo{
+			"AB/CDEF": {Name: "AB/CDEF"},
+		},
+	}
+
+	// Names that don't decrypt are reported as they are in full runs...
+	c.indexedMissing()
+	if len(c.report.missing) != 1 || c.report.missing[0] != "AB/CDEF" {
+		t.Errorf("Expected AB/CDEF to be missing, got %v", c.report.missing)
+	}
+
+	// ... but can't be selected.
+	c.report.missing = nil
+	c.only = map[string]bool{"dir": false}
+	c.indexedMissing()
+	if len(c.report.missing) != 0 {
+		t.Errorf("Expected nothing missing, got %v", c.report.missing)
+	}
+}
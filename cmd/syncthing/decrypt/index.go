@@ -0,0 +1,77 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package decrypt
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/osutil"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// indexedFiles returns the files in the index of the untrusted device for
+// the folder, as found in its database, by encrypted name in wire format.
+// Only the metadata needed to tell what they are is loaded.
+func indexedFiles(ldb backend.Backend, folderID string) (map[string]protocol.FileInfo, error) {
+	folderIdx, ok, err := smallIndexID(ldb, db.KeyTypeFolderIdx, []byte(folderID))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("folder %q not found in database", folderID)
+	}
+	deviceIdx, ok, err := smallIndexID(ldb, db.KeyTypeDeviceIdx, protocol.LocalDeviceID[:])
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("no local files for folder %q in database", folderID)
+	}
+
+	prefix := make([]byte, 1+4+4)
+	prefix[0] = db.KeyTypeDevice
+	binary.BigEndian.PutUint32(prefix[1:], folderIdx)
+	binary.BigEndian.PutUint32(prefix[1+4:], deviceIdx)
+	it, err := ldb.NewPrefixIterator(prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	files := make(map[string]protocol.FileInfo)
+	for it.Next() {
+		var f db.FileInfoTruncated
+		if err := f.Unmarshal(it.Value()); err != nil {
+			return nil, fmt.Errorf("reading index entry: %w", err)
+		}
+		if f.Type != protocol.FileInfoTypeFile || f.IsDeleted() || f.IsInvalid() {
+			continue
+		}
+		name := osutil.NormalizedFilename(f.Name)
+		files[name] = protocol.FileInfo{Name: name, Size: f.Size, Encrypted: f.Encrypted}
+	}
+	return files, it.Error()
+}
+
+// smallIndexID returns the number the database uses in keys for the given
+// folder or device ID.
+func smallIndexID(ldb backend.Backend, keyType byte, val []byte) (uint32, bool, error) {
+	it, err := ldb.NewPrefixIterator([]byte{keyType})
+	if err != nil {
+		return 0, false, err
+	}
+	defer it.Release()
+	for it.Next() {
+		if string(it.Value()) == string(val) {
+			return binary.BigEndian.Uint32(it.Key()[1:]), true, nil
+		}
+	}
+	return 0, false, it.Error()
+}
@@ -0,0 +1,212 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package decrypt
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// Which filemode bits to preserve
+const retainBits = fs.ModePerm | fs.ModeSetgid | fs.ModeSetuid | fs.ModeSticky
+
+// A destination receives the decrypted files.
+type destination interface {
+	// writeFile calls fill with a writer for the contents of the given
+	// file. The blocks are written in order. The writer is nil when the
+	// contents are not to be written anywhere.
+	writeFile(fi *protocol.FileInfo, fill func(io.WriterAt) error) error
+	Close() error
+}
+
+// verifyDestination discards everything, for --verify-only.
+type verifyDestination struct{}
+
+func (verifyDestination) writeFile(_ *protocol.FileInfo, fill func(io.WriterAt) error) error {
+	return fill(nil)
+}
+
+func (verifyDestination) Close() error {
+	return nil
+}
+
+// dirDestination writes plain files into a directory.
+type dirDestination struct {
+	fs fs.Filesystem
+}
+
+func (d *dirDestination) writeFile(fi *protocol.FileInfo, fill func(io.WriterAt) error) error {
+	if err := d.fs.MkdirAll(filepath.Dir(fi.Name), 0o700); err != nil {
+		return err
+	}
+
+	fd, err := d.fs.Create(fi.Name)
+	if err != nil {
+		return err
+	}
+	defer fd.Close() // also closed explicitly in the return
+	if err := d.fs.Chmod(fi.Name, fs.FileMode(fi.Permissions&uint32(retainBits))); err != nil {
+		return err
+	}
+
+	if err := fill(fd); err != nil {
+		// Decrypting the file failed, leaving it in an inconsistent state.
+		// Delete it. Even --continue currently doesn't mean "leave broken
+		// stuff in place", it just means "try the next file instead of
+		// aborting".
+		_ = d.fs.Remove(fd.Name())
+		return err
+	}
+
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return d.fs.Chtimes(fi.Name, fi.ModTime(), fi.ModTime())
+}
+
+func (*dirDestination) Close() error {
+	return nil
+}
+
+// tarDestination streams the files as a tar archive.
+type tarDestination struct {
+	w  *bufio.Writer
+	c  io.Closer
+	tw *tar.Writer
+}
+
+func newTarDestination(w io.WriteCloser) *tarDestination {
+	bw := bufio.NewWriter(w)
+	return &tarDestination{w: bw, c: w, tw: tar.NewWriter(bw)}
+}
+
+func (d *tarDestination) writeFile(fi *protocol.FileInfo, fill func(io.WriterAt) error) error {
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     fi.Name,
+		Size:     fi.Size,
+		Mode:     int64(fi.Permissions & uint32(retainBits)),
+		ModTime:  fi.ModTime(),
+		Format:   tar.FormatPAX,
+	}
+	if err := d.tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	sw := &sequentialWriter{w: d.tw}
+	err := fill(sw)
+	if err != nil {
+		// The header promised a certain amount of data, which we need to
+		// provide to keep the archive readable. The file is reported as
+		// failed.
+		log.Printf("Warning: %s: padding incomplete file in archive", fi.Name)
+	}
+	if sw.off < fi.Size {
+		if _, perr := io.CopyN(d.tw, zeroReader{}, fi.Size-sw.off); perr != nil && err == nil {
+			err = perr
+		}
+	}
+	return err
+}
+
+func (d *tarDestination) Close() error {
+	return closeAll(d.tw.Close, d.w.Flush, d.c.Close)
+}
+
+// zipDestination streams the files as a zip archive.
+type zipDestination struct {
+	w  *bufio.Writer
+	c  io.Closer
+	zw *zip.Writer
+}
+
+func newZipDestination(w io.WriteCloser) *zipDestination {
+	bw := bufio.NewWriter(w)
+	return &zipDestination{w: bw, c: w, zw: zip.NewWriter(bw)}
+}
+
+func (d *zipDestination) writeFile(fi *protocol.FileInfo, fill func(io.WriterAt) error) error {
+	hdr := &zip.FileHeader{
+		Name:     fi.Name,
+		Method:   zip.Deflate,
+		Modified: fi.ModTime(),
+	}
+	hdr.SetMode(os.FileMode(fi.Permissions & uint32(retainBits)))
+	w, err := d.zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	// An incomplete file stays incomplete in the archive, and is reported
+	// as failed.
+	return fill(&sequentialWriter{w: w})
+}
+
+func (d *zipDestination) Close() error {
+	return closeAll(d.zw.Close, d.w.Flush, d.c.Close)
+}
+
+// createArchive opens the named archive file for writing, with "-" meaning
+// standard output.
+func createArchive(name string) (io.WriteCloser, error) {
+	if name == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	return os.Create(name)
+}
+
+// closeAll calls all the functions in order, returning the first error.
+func closeAll(fns ...func() error) error {
+	var firstErr error
+	for _, fn := range fns {
+		if err := fn(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+var errNotSequential = errors.New("non-sequential write to archive")
+
+// sequentialWriter adapts a stream to io.WriterAt, for writes that happen
+// in order.
+type sequentialWriter struct {
+	w   io.Writer
+	off int64
+}
+
+func (s *sequentialWriter) WriteAt(p []byte, off int64) (int, error) {
+	if off != s.off {
+		return 0, fmt.Errorf("%w: at %d, expected %d", errNotSequential, off, s.off)
+	}
+	n, err := s.w.Write(p)
+	s.off += int64(n)
+	return n, err
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
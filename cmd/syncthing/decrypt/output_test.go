@@ -0,0 +1,115 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package decrypt
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+type bufCloser struct {
+	bytes.Buffer
+}
+
+func (*bufCloser) Close() error {
+	return nil
+}
+
+func TestTarDestinationPadsFailedFiles(t *testing.T) {
+	var buf bufCloser
+	dst := newTarDestination(&buf)
+
+	good := &protocol.FileInfo{Name: "dir/good", Size: 5, Permissions: 0o644}
+	if err := dst.writeFile(good, func(w io.WriterAt) error {
+		_, err := w.WriteAt([]byte("hello"), 0)
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	bad := &protocol.FileInfo{Name: "bad", Size: 10}
+	failure := errors.New("corrupt")
+	if err := dst.writeFile(bad, func(w io.WriterAt) error {
+		if _, err := w.WriteAt([]byte("abc"), 0); err != nil {
+			return err
+		}
+		return failure
+	}); !errors.Is(err, failure) {
+		t.Fatalf("expected failure, got %v", err)
+	}
+	if err := dst.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(&buf.Buffer)
+	for _, exp := range []struct {
+		name string
+		data string
+	}{
+		{"dir/good", "hello"},
+		{"bad", "abc\x00\x00\x00\x00\x00\x00\x00"},
+	} {
+		hdr, err := tr.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Name != exp.name || string(data) != exp.data {
+			t.Errorf("got %q = %q, expected %q = %q", hdr.Name, data, exp.name, exp.data)
+		}
+	}
+	if _, err := tr.Next(); err != io.EOF {
+		t.Error("expected end of archive, got", err)
+	}
+}
+
+func TestSequentialWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &sequentialWriter{w: &buf}
+	if _, err := w.WriteAt([]byte("ab"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteAt([]byte("cd"), 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteAt([]byte("x"), 10); !errors.Is(err, errNotSequential) {
+		t.Error("expected non-sequential write to fail, got", err)
+	}
+	if buf.String() != "abcd" {
+		t.Errorf("unexpected data %q", buf.String())
+	}
+}
+
+func TestSelection(t *testing.T) {
+	c := &CLI{only: map[string]bool{
+		cleanSelection("dir/sub/"): false,
+		cleanSelection("./file"):   false,
+		cleanSelection("missing"):  false,
+	}}
+	for name, exp := range map[string]bool{
+		"dir/sub/a":  true,
+		"dir/sub":    true,
+		"dir/subway": false,
+		"file":       true,
+		"other":      false,
+	} {
+		if got := c.selected(name); got != exp {
+			t.Errorf("selected(%q) == %v, expected %v", name, got, exp)
+		}
+	}
+	if !c.only["dir/sub"] || !c.only["file"] || c.only["missing"] {
+		t.Errorf("unexpected selection state %v", c.only)
+	}
+}
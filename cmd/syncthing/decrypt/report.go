@@ -0,0 +1,36 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package decrypt
+
+import (
+	"fmt"
+	"log"
+	"sort"
+)
+
+// report summarizes the outcome of processing the folder.
+type report struct {
+	ok      int
+	failed  []error
+	missing []string // selected plaintext paths without a matching file
+}
+
+// print logs the outcome and returns an error if anything went wrong.
+func (r *report) print() error {
+	sort.Strings(r.missing)
+	for _, err := range r.failed {
+		log.Printf("Failed: %v", err)
+	}
+	for _, p := range r.missing {
+		log.Printf("Missing: %s", p)
+	}
+	log.Printf("%d files verified, %d failed, %d missing", r.ok, len(r.failed), len(r.missing))
+	if len(r.failed) > 0 || len(r.missing) > 0 {
+		return fmt.Errorf("%d files failed, %d missing", len(r.failed), len(r.missing))
+	}
+	return nil
+}
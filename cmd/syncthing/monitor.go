@@ -11,6 +11,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -20,6 +21,7 @@ import (
 	"time"
 
 	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/locations"
 	"github.com/syncthing/syncthing/lib/osutil"
@@ -580,7 +582,16 @@ func maybeReportPanics() {
 		}
 	}()
 
-	// Report the panics.
+	// Report the panics, through the configured proxy if any.
+	if err := dialer.SetProxy(opts.ProxyAddress, opts.ProxyFallback); err != nil {
+		l.Warnln("Failed to set proxy:", err)
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: dialer.DialContext,
+			Proxy:       dialer.HTTPProxy,
+		},
+	}
 	dir := locations.GetBaseDir(locations.ConfigBaseDir)
-	uploadPanicLogs(ctx, opts.CRURL, dir)
+	uploadPanicLogs(ctx, client, opts.CRURL, dir)
 }
@@ -0,0 +1,243 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build linux || darwin
+// +build linux darwin
+
+package mount
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// How long the kernel may cache names and attributes. The global state
+// changes underneath us, so this is kept short.
+const attrTimeout = 5 * time.Second
+
+// globalState is the subset of syncthing.Internals the file system is
+// served from.
+type globalState interface {
+	blockSource
+	DBSnapshot(folderID string) (*db.Snapshot, error)
+}
+
+func mountFolder(dir string, st globalState, folder config.FolderConfiguration, debug bool) (mountedFolder, error) {
+	mfs := &mountFS{
+		st:     st,
+		folder: folder.ID,
+		reader: newGlobalReader(st, folder.ID),
+		uid:    uint32(os.Getuid()),
+		gid:    uint32(os.Getgid()),
+	}
+	timeout := attrTimeout
+	return fs.Mount(dir, &node{mfs: mfs}, &fs.Options{
+		MountOptions: fuse.MountOptions{
+			FsName:  "syncthing:" + folder.ID,
+			Name:    "syncthing",
+			Options: []string{"ro"},
+			Debug:   debug,
+			// Mount directly when we're allowed to, falling back to
+			// fusermount otherwise.
+			DirectMount: true,
+		},
+		EntryTimeout: &timeout,
+		AttrTimeout:  &timeout,
+		UID:          mfs.uid,
+		GID:          mfs.gid,
+	})
+}
+
+type mountFS struct {
+	st       globalState
+	folder   string
+	reader   *globalReader
+	uid, gid uint32
+}
+
+// global returns the current global version of the named file, if it
+// exists.
+func (m *mountFS) global(name string) (protocol.FileInfo, bool) {
+	snap, err := m.st.DBSnapshot(m.folder)
+	if err != nil {
+		l.Debugln("Snapshot:", err)
+		return protocol.FileInfo{}, false
+	}
+	defer snap.Release()
+	f, ok := snap.GetGlobal(name)
+	if !ok || f.IsDeleted() || f.IsInvalid() {
+		return protocol.FileInfo{}, false
+	}
+	return f, true
+}
+
+func (m *mountFS) fillAttr(f protocol.FileIntf, out *fuse.Attr) {
+	out.Mode = fileMode(f)
+	out.Size = uint64(f.FileSize())
+	if f.IsDirectory() || f.IsSymlink() {
+		out.Size = 0
+	}
+	out.Blocks = (out.Size + 511) / 512
+	mtime := f.ModTime()
+	out.SetTimes(&mtime, &mtime, &mtime)
+	out.Nlink = 1
+	out.Owner = fuse.Owner{Uid: m.uid, Gid: m.gid}
+}
+
+func fileMode(f protocol.FileIntf) uint32 {
+	var mode uint32
+	switch {
+	case f.IsDirectory():
+		mode = syscall.S_IFDIR | 0o555
+	case f.IsSymlink():
+		return syscall.S_IFLNK | 0o777
+	default:
+		mode = syscall.S_IFREG | 0o444
+	}
+	if f.HasPermissionBits() {
+		// Keep the permissions the file has on the other devices, minus
+		// the write bits as this is a read only file system.
+		mode = mode&syscall.S_IFMT | f.FilePermissions()&0o555
+	}
+	return mode
+}
+
+// node is a file, directory or symlink in the mounted folder, identified
+// by its path.
+type node struct {
+	fs.Inode
+	mfs  *mountFS
+	name string // empty for the root
+}
+
+var (
+	_ = (fs.NodeLookuper)((*node)(nil))
+	_ = (fs.NodeReaddirer)((*node)(nil))
+	_ = (fs.NodeGetattrer)((*node)(nil))
+	_ = (fs.NodeOpener)((*node)(nil))
+	_ = (fs.NodeReader)((*node)(nil))
+	_ = (fs.NodeReadlinker)((*node)(nil))
+)
+
+func (n *node) child(name string) string {
+	if n.name == "" {
+		return name
+	}
+	return path.Join(n.name, name)
+}
+
+func (n *node) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	childName := n.child(name)
+	f, ok := n.mfs.global(childName)
+	if !ok {
+		return nil, syscall.ENOENT
+	}
+	n.mfs.fillAttr(f, &out.Attr)
+	child := &node{mfs: n.mfs, name: childName}
+	return n.NewInode(ctx, child, fs.StableAttr{Mode: out.Attr.Mode & syscall.S_IFMT}), 0
+}
+
+func (n *node) Readdir(_ context.Context) (fs.DirStream, syscall.Errno) {
+	snap, err := n.mfs.st.DBSnapshot(n.mfs.folder)
+	if err != nil {
+		l.Debugln("Snapshot:", err)
+		return nil, syscall.EIO
+	}
+	defer snap.Release()
+
+	prefix := n.name
+	if prefix != "" {
+		prefix += "/"
+	}
+	var entries []fuse.DirEntry
+	snap.WithPrefixedGlobalTruncated(n.name, func(f protocol.FileIntf) bool {
+		rel, ok := strings.CutPrefix(f.FileName(), prefix)
+		if !ok || rel == "" || strings.Contains(rel, "/") {
+			// The directory itself or something further down
+			return true
+		}
+		if f.IsDeleted() || f.IsInvalid() {
+			return true
+		}
+		entries = append(entries, fuse.DirEntry{
+			Name: rel,
+			Mode: fileMode(f),
+		})
+		return true
+	})
+	return fs.NewListDirStream(entries), 0
+}
+
+func (n *node) Getattr(_ context.Context, _ fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	if n.name == "" {
+		out.Mode = syscall.S_IFDIR | 0o555
+		out.Nlink = 1
+		out.Owner = fuse.Owner{Uid: n.mfs.uid, Gid: n.mfs.gid}
+		return 0
+	}
+	f, ok := n.mfs.global(n.name)
+	if !ok {
+		return syscall.ENOENT
+	}
+	n.mfs.fillAttr(f, &out.Attr)
+	return 0
+}
+
+// fileHandle pins the version of the file that was current when it was
+// opened, so that reads are consistent even when the global version
+// changes.
+type fileHandle struct {
+	file protocol.FileInfo
+}
+
+func (n *node) Open(_ context.Context, flags uint32) (fs.FileHandle, uint32, syscall.Errno) {
+	if flags&(syscall.O_WRONLY|syscall.O_RDWR|syscall.O_APPEND|syscall.O_TRUNC) != 0 {
+		return nil, 0, syscall.EROFS
+	}
+	f, ok := n.mfs.global(n.name)
+	if !ok {
+		return nil, 0, syscall.ENOENT
+	}
+	if f.IsDirectory() || f.IsSymlink() {
+		return nil, 0, syscall.EISDIR
+	}
+	return &fileHandle{file: f}, 0, 0
+}
+
+func (n *node) Read(ctx context.Context, fh fs.FileHandle, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	h, ok := fh.(*fileHandle)
+	if !ok {
+		return nil, syscall.EBADF
+	}
+	read, err := n.mfs.reader.ReadAt(ctx, h.file, dest, off)
+	if err != nil {
+		l.Infof("Reading %s: %v", h.file.Name, err)
+		return nil, syscall.EIO
+	}
+	return fuse.ReadResultData(dest[:read]), 0
+}
+
+func (n *node) Readlink(_ context.Context) ([]byte, syscall.Errno) {
+	f, ok := n.mfs.global(n.name)
+	if !ok {
+		return nil, syscall.ENOENT
+	}
+	if !f.IsSymlink() {
+		return nil, syscall.EINVAL
+	}
+	return []byte(f.SymlinkTarget), 0
+}
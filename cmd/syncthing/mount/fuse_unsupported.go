@@ -0,0 +1,21 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package mount
+
+import (
+	"errors"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/syncthing"
+)
+
+func mountFolder(_ string, _ *syncthing.Internals, _ config.FolderConfiguration, _ bool) (mountedFolder, error) {
+	return nil, errors.New("mounting is not supported on this platform")
+}
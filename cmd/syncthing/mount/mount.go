@@ -0,0 +1,148 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package mount implements the `syncthing mount` subcommand.
+package mount
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/syncthing/syncthing/cmd/syncthing/cmdutil"
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/locations"
+	"github.com/syncthing/syncthing/lib/logger"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/svcutil"
+	"github.com/syncthing/syncthing/lib/syncthing"
+)
+
+type CLI struct {
+	cmdutil.CommonOptions
+	DataDir    string `name:"data" placeholder:"PATH" env:"STDATADIR" help:"Set data directory (database and logs)"`
+	Folder     string `arg:"" required:"1" help:"ID of the folder to mount"`
+	Mountpoint string `arg:"" required:"1" type:"existingdir" help:"Empty directory to mount the folder on"`
+	Debug      bool   `help:"Log all FUSE operations"`
+}
+
+// Run starts Syncthing with the configuration and database of the given
+// home directory and mounts the global state of the folder read only. File
+// contents are fetched from connected devices on read. The mounted folder
+// runs as send only for the duration, so that nothing is pulled into it,
+// while Syncthing otherwise runs as usual. Syncthing must not be running
+// already.
+func (c *CLI) Run(l logger.Logger) error {
+	if err := cmdutil.SetConfigDataLocationsFromFlags(c.HomeDir, c.ConfDir, c.DataDir); err != nil {
+		return fmt.Errorf("command line options: %w", err)
+	}
+
+	cert, err := tls.LoadX509KeyPair(locations.Get(locations.CertFile), locations.Get(locations.KeyFile))
+	if err != nil {
+		return fmt.Errorf("loading certificate (has Syncthing been started before?): %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	evLogger := events.NewLogger()
+	go evLogger.Serve(ctx)
+
+	cfg, err := syncthing.LoadConfigAtStartup(locations.Get(locations.ConfigFile), cert, evLogger, false, c.NoDefaultFolder, c.SkipPortProbing)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+
+	folderCfg, ok := cfg.Folder(c.Folder)
+	if !ok {
+		return fmt.Errorf("no folder with ID %q", c.Folder)
+	}
+	if folderCfg.Type == config.FolderTypeReceiveEncrypted {
+		return errors.New("cannot mount a receive encrypted folder")
+	}
+	if folderCfg.Paused {
+		return fmt.Errorf("folder %s is paused", folderCfg.Description())
+	}
+
+	cfg, cleanup, err := withoutPulling(cfg, c.Folder, protocol.NewDeviceID(cert.Certificate[0]), evLogger)
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	defer cleanup()
+	go cfg.Serve(ctx)
+
+	ldb, err := syncthing.OpenDBBackend(locations.Get(locations.Database), cfg.Options().DatabaseTuning)
+	if err != nil {
+		return fmt.Errorf("opening database (is Syncthing already running?): %w", err)
+	}
+
+	app, err := syncthing.New(cfg, ldb, evLogger, cert, syncthing.Options{NoUpgrade: true})
+	if err != nil {
+		return fmt.Errorf("starting Syncthing: %w", err)
+	}
+	if err := app.Start(); err != nil {
+		return fmt.Errorf("starting Syncthing: %w", err)
+	}
+
+	srv, err := mountFolder(c.Mountpoint, app.Internals, folderCfg, c.Debug)
+	if err != nil {
+		app.Stop(svcutil.ExitError)
+		app.Wait()
+		return fmt.Errorf("mounting folder: %w", err)
+	}
+	l.Infof("Mounted folder %s on %s", folderCfg.Description(), c.Mountpoint)
+
+	// Unmount on INT/TERM, which makes the server return. An external
+	// unmount has the same effect.
+	stopSign := make(chan os.Signal, 1)
+	signal.Notify(stopSign, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-stopSign
+		if err := srv.Unmount(); err != nil {
+			l.Warnln("Unmounting:", err)
+		}
+	}()
+	srv.Wait()
+	l.Infof("Unmounted folder %s", folderCfg.Description())
+
+	app.Stop(svcutil.ExitSuccess)
+	if status := app.Wait(); status == svcutil.ExitError {
+		return app.Error()
+	}
+	return nil
+}
+
+// withoutPulling returns a configuration where the folder is send only,
+// so that its data isn't pulled while mounted. As that must not be saved
+// to the actual configuration, the returned one, and any changes made
+// while running, are saved to a temporary file removed by cleanup.
+func withoutPulling(cfg config.Wrapper, folder string, myID protocol.DeviceID, evLogger events.Logger) (config.Wrapper, func(), error) {
+	raw := cfg.RawCopy()
+	for i := range raw.Folders {
+		if raw.Folders[i].ID == folder {
+			raw.Folders[i].Type = config.FolderTypeSendOnly
+		}
+	}
+
+	dir, err := os.MkdirTemp("", "syncthing-mount-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	return config.Wrap(filepath.Join(dir, "config.xml"), raw, myID, evLogger), cleanup, nil
+}
+
+// A mountedFolder is the running file system server of a mount.
+type mountedFolder interface {
+	Unmount() error
+	Wait()
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mount
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+)
+
+func TestWithoutPulling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.xml")
+	raw := config.New(device1)
+	raw.Folders = []config.FolderConfiguration{
+		{ID: "mounted", Type: config.FolderTypeSendReceive},
+		{ID: "other", Type: config.FolderTypeReceiveOnly},
+	}
+	orig := config.Wrap(path, raw, device1, events.NoopLogger)
+
+	cfg, cleanup, err := withoutPulling(orig, "mounted", device1, events.NoopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	if fcfg, _ := cfg.Folder("mounted"); fcfg.Type != config.FolderTypeSendOnly {
+		t.Errorf("Expected mounted folder to be send only, got %v", fcfg.Type)
+	}
+	if fcfg, _ := cfg.Folder("other"); fcfg.Type != config.FolderTypeReceiveOnly {
+		t.Errorf("Expected other folder to be unchanged, got %v", fcfg.Type)
+	}
+	if fcfg, _ := orig.Folder("mounted"); fcfg.Type != config.FolderTypeSendReceive {
+		t.Errorf("Expected original config to be unchanged, got %v", fcfg.Type)
+	}
+	if cfg.ConfigPath() == path {
+		t.Error("Config with the folder send only would be saved over the original")
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/syncthing/syncthing/lib/model"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/scanner"
+)
+
+// The number of recently fetched blocks kept in memory, so that reads
+// smaller than a block don't result in the same block being requested over
+// and over.
+const blockCacheSize = 16
+
+var errNoDevice = errors.New("no connected device has the block")
+
+// blockSource is the subset of syncthing.Internals used to fetch file
+// contents from other devices.
+type blockSource interface {
+	BlockAvailability(folderID string, file protocol.FileInfo, block protocol.BlockInfo) ([]model.Availability, error)
+	DownloadBlock(ctx context.Context, deviceID protocol.DeviceID, folderID string, path string, blockNumber int, blockInfo protocol.BlockInfo, hashAlgorithm protocol.HashAlgorithm, allowFromTemporary bool) ([]byte, error)
+}
+
+// globalReader reads the contents of files in the global state of a
+// folder, by requesting the blocks from connected devices.
+type globalReader struct {
+	src    blockSource
+	folder string
+	cache  *lru.Cache[string, []byte]
+}
+
+func newGlobalReader(src blockSource, folder string) *globalReader {
+	cache, _ := lru.New[string, []byte](blockCacheSize)
+	return &globalReader{
+		src:    src,
+		folder: folder,
+		cache:  cache,
+	}
+}
+
+// ReadAt reads len(buf) bytes of the file starting at the given offset.
+// Fewer bytes are read at the end of the file.
+func (r *globalReader) ReadAt(ctx context.Context, file protocol.FileInfo, buf []byte, off int64) (int, error) {
+	if off >= file.Size {
+		return 0, nil
+	}
+	if rem := file.Size - off; int64(len(buf)) > rem {
+		buf = buf[:rem]
+	}
+
+	blockSize := int64(file.BlockSize())
+	n := 0
+	for n < len(buf) {
+		pos := off + int64(n)
+		blockNo := int(pos / blockSize)
+		if blockNo >= len(file.Blocks) {
+			return n, fmt.Errorf("%s: offset %d beyond last block", file.Name, pos)
+		}
+		block := file.Blocks[blockNo]
+		data, err := r.block(ctx, file, blockNo, block)
+		if err != nil {
+			return n, err
+		}
+		inBlock := pos - block.Offset
+		if inBlock >= int64(len(data)) {
+			return n, fmt.Errorf("%s: short block %d", file.Name, blockNo)
+		}
+		n += copy(buf[n:], data[inBlock:])
+	}
+	return n, nil
+}
+
+func (r *globalReader) block(ctx context.Context, file protocol.FileInfo, blockNo int, block protocol.BlockInfo) ([]byte, error) {
+	if block.IsEmpty() {
+		// No need to ask anyone for a block of all zeroes.
+		return make([]byte, block.Size), nil
+	}
+	key := string(block.Hash)
+	if data, ok := r.cache.Get(key); ok {
+		return data, nil
+	}
+
+	avail, err := r.src.BlockAvailability(r.folder, file, block)
+	if err != nil {
+		return nil, err
+	}
+	lastErr := errNoDevice
+	for _, a := range avail {
+		data, err := r.src.DownloadBlock(ctx, a.ID, r.folder, file.Name, blockNo, block, file.BlockHashAlgorithm, a.FromTemporary)
+		if err != nil {
+			l.Debugf("Request for %s block %d from %s failed: %v", file.Name, blockNo, a.ID.Short(), err)
+			lastErr = err
+			continue
+		}
+		if !scanner.Validate(data, block.Hash, block.WeakHash, file.BlockHashAlgorithm) {
+			l.Debugf("Request for %s block %d from %s returned bad data", file.Name, blockNo, a.ID.Short())
+			lastErr = fmt.Errorf("%s: hash mismatch for block %d", file.Name, blockNo)
+			continue
+		}
+		r.cache.Add(key, data)
+		return data, nil
+	}
+	return nil, fmt.Errorf("%s: %w", file.Name, lastErr)
+}
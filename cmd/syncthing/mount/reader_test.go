@@ -0,0 +1,108 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package mount
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/model"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/scanner"
+)
+
+var (
+	device1, _ = protocol.DeviceIDFromString("AIR6LPZ-7K4PTTV-UXQSMUU-CPQ5YWH-OEDFIIQ-JUG777G-2YQXXR5-YD6AWQR")
+	device2, _ = protocol.DeviceIDFromString("GYRZZQB-IRNPV4Z-T7TC52W-EQYJ3TT-FDQW6MW-DFLMU42-SSSU6EM-FBK2VAY")
+)
+
+type fakeSource struct {
+	data     map[protocol.DeviceID][]byte
+	requests int
+}
+
+func (s *fakeSource) BlockAvailability(_ string, _ protocol.FileInfo, _ protocol.BlockInfo) ([]model.Availability, error) {
+	var avail []model.Availability
+	for _, dev := range []protocol.DeviceID{device1, device2} {
+		if _, ok := s.data[dev]; ok {
+			avail = append(avail, model.Availability{ID: dev})
+		}
+	}
+	return avail, nil
+}
+
+func (s *fakeSource) DownloadBlock(_ context.Context, deviceID protocol.DeviceID, _ string, _ string, _ int, block protocol.BlockInfo, _ protocol.HashAlgorithm, _ bool) ([]byte, error) {
+	s.requests++
+	data, ok := s.data[deviceID]
+	if !ok {
+		return nil, errors.New("not connected")
+	}
+	return data[block.Offset : block.Offset+int64(block.Size)], nil
+}
+
+func TestGlobalReader(t *testing.T) {
+	data := make([]byte, 3*protocol.MinBlockSize+1000)
+	_, _ = rand.Read(data)
+	blocks, err := scanner.Blocks(context.Background(), bytes.NewReader(data), protocol.MinBlockSize, int64(len(data)), protocol.HashAlgorithmSHA256, nil, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := protocol.FileInfo{
+		Name:         "file",
+		Size:         int64(len(data)),
+		RawBlockSize: protocol.MinBlockSize,
+		Blocks:       blocks,
+	}
+
+	// The first device returns garbage, so the data must come from the
+	// second one.
+	garbage := make([]byte, len(data))
+	src := &fakeSource{data: map[protocol.DeviceID][]byte{device1: garbage, device2: data}}
+	r := newGlobalReader(src, "default")
+
+	cases := []struct {
+		off, size int64
+	}{
+		{0, 100},
+		{0, int64(len(data))},
+		{protocol.MinBlockSize - 10, 20},
+		{int64(len(data)) - 50, 100},
+		{int64(len(data)) + 1, 10},
+	}
+	for _, tc := range cases {
+		buf := make([]byte, tc.size)
+		n, err := r.ReadAt(context.Background(), file, buf, tc.off)
+		if err != nil {
+			t.Fatalf("ReadAt(%d, %d): %v", tc.off, tc.size, err)
+		}
+		exp := []byte{}
+		if tc.off < int64(len(data)) {
+			exp = data[tc.off:min(tc.off+tc.size, int64(len(data)))]
+		}
+		if !bytes.Equal(buf[:n], exp) {
+			t.Errorf("ReadAt(%d, %d): unexpected data", tc.off, tc.size)
+		}
+	}
+
+	// Everything is cached by now.
+	before := src.requests
+	if _, err := r.ReadAt(context.Background(), file, make([]byte, 10), 0); err != nil {
+		t.Fatal(err)
+	}
+	if src.requests != before {
+		t.Error("expected cached block to be used")
+	}
+
+	// Without a device that has the correct data we fail.
+	r = newGlobalReader(&fakeSource{data: map[protocol.DeviceID][]byte{device1: garbage}}, "default")
+	if _, err := r.ReadAt(context.Background(), file, make([]byte, 10), 0); err == nil {
+		t.Error("expected error for bad data")
+	}
+}
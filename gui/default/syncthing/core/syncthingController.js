@@ -2044,7 +2044,7 @@ angular.module('syncthing.core')
                         if ($scope.folders[id].devices[i].deviceID === currentID) {
                             found = true;
                             // Update encryption pw
-                            $scope.folders[id].devices[i].encryptionPassword = $scope.currentSharing.encryptionPasswords[id];
+                            setEncryptionPassword($scope.folders[id].devices[i], $scope.currentSharing.encryptionPasswords[id]);
                             break;
                         }
                     }
@@ -2524,7 +2524,7 @@ angular.module('syncthing.core')
             var newDevices = [];
             folderCfg.devices.forEach(function (dev) {
                 if ($scope.currentSharing.selected[dev.deviceID] === true) {
-                    dev.encryptionPassword = $scope.currentSharing.encryptionPasswords[dev.deviceID];
+                    setEncryptionPassword(dev, $scope.currentSharing.encryptionPasswords[dev.deviceID]);
                     newDevices.push(dev);
                     delete $scope.currentSharing.selected[dev.deviceID];
                 };
@@ -2636,6 +2636,15 @@ angular.module('syncthing.core')
             };
         }
 
+        function setEncryptionPassword(folderDevice, password) {
+            if (folderDevice.encryptionPassword && password && folderDevice.encryptionPassword !== password) {
+                // Lets the untrusted device re-sync with the new password
+                // while keeping the data encrypted with the previous one.
+                folderDevice.previousEncryptionPassword = folderDevice.encryptionPassword;
+            }
+            folderDevice.encryptionPassword = password;
+        }
+
         function saveFolderAddIgnores(folderID, useDefault) {
             var ignores = useDefault ? $scope.ignores.defaultLines : ignoresArray();
             return saveIgnores(ignores).then(function () {
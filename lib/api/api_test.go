@@ -231,6 +231,12 @@ func TestAPIServiceRequests(t *testing.T) {
 			Prefix:  "{",
 			Timeout: 15 * time.Second,
 		},
+		{
+			URL:    "/rest/db/throughput?folder=default",
+			Code:   200,
+			Type:   "application/json",
+			Prefix: "{",
+		},
 		{
 			URL:  "/rest/db/file?folder=default&file=something",
 			Code: 404,
@@ -72,6 +72,13 @@ func (c *cast) addWriter(svc func(ctx context.Context) error) {
 	c.Add(c.writer)
 }
 
+// addConn adds a service that both reads and writes, for protocols where
+// we must send from the same socket we receive on.
+func (c *cast) addConn(svc func(ctx context.Context) error) {
+	c.reader = c.createService(svc, "conn")
+	c.Add(c.reader)
+}
+
 func (c *cast) createService(svc func(context.Context) error, suffix string) svcutil.ServiceWithError {
 	return svcutil.AsService(svc, fmt.Sprintf("%s/%s", c, suffix))
 }
@@ -97,8 +104,13 @@ func (c *cast) Recv() ([]byte, net.Addr) {
 }
 
 func (c *cast) Error() error {
-	if err := c.reader.Error(); err != nil {
-		return err
+	if c.reader != nil {
+		if err := c.reader.Error(); err != nil {
+			return err
+		}
+	}
+	if c.writer != nil {
+		return c.writer.Error()
 	}
-	return c.writer.Error()
+	return nil
 }
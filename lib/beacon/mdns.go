@@ -0,0 +1,207 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package beacon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+var (
+	mdnsGroup4 = &net.UDPAddr{IP: net.IPv4(224, 0, 0, 251), Port: 5353}
+	mdnsGroup6 = &net.UDPAddr{IP: net.ParseIP("ff02::fb"), Port: 5353}
+)
+
+// NewMDNS returns a beacon sending to and receiving from the mDNS groups,
+// on IPv4 and IPv6 where available. Unlike the other beacons, packets are
+// sent from the mDNS port, as other responders ignore them otherwise.
+func NewMDNS() Interface {
+	c := newCast("mdnsBeacon")
+	c.addConn(func(ctx context.Context) error {
+		return serveMDNS(ctx, c.inbox, c.outbox)
+	})
+	return c
+}
+
+// mdnsConn is the socket for one address family.
+type mdnsConn interface {
+	writeTo(bs []byte, ifIndex int) error
+	readFrom(bs []byte) (int, net.Addr, error)
+	Close() error
+}
+
+func serveMDNS(ctx context.Context, inbox <-chan []byte, outbox chan<- recv) error {
+	intfs, err := net.Interfaces()
+	if err != nil {
+		l.Debugln(err)
+		return err
+	}
+
+	var conns []mdnsConn
+	if conn, err := listenMDNS4(intfs); err != nil {
+		l.Debugln("mDNS over IPv4:", err)
+	} else {
+		conns = append(conns, conn)
+	}
+	if conn, err := listenMDNS6(intfs); err != nil {
+		l.Debugln("mDNS over IPv6:", err)
+	} else {
+		conns = append(conns, conn)
+	}
+	if len(conns) == 0 {
+		return errors.New("no mDNS sockets available")
+	}
+
+	doneCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-doneCtx.Done()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
+	readErrs := make(chan error, len(conns))
+	for _, conn := range conns {
+		go func(conn mdnsConn) {
+			readErrs <- readMDNS(conn, outbox)
+		}(conn)
+	}
+
+	for {
+		var bs []byte
+		select {
+		case bs = <-inbox:
+		case err := <-readErrs:
+			return err
+		case <-doneCtx.Done():
+			return doneCtx.Err()
+		}
+
+		intfs, err := net.Interfaces()
+		if err != nil {
+			l.Debugln(err)
+			return err
+		}
+
+		for _, intf := range intfs {
+			if intf.Flags&net.FlagRunning == 0 || intf.Flags&net.FlagMulticast == 0 {
+				continue
+			}
+			for _, conn := range conns {
+				if err := conn.writeTo(bs, intf.Index); err != nil {
+					l.Debugln(err, "on write to", intf.Name)
+					continue
+				}
+				l.Debugf("sent %d bytes on %s", len(bs), intf.Name)
+			}
+		}
+	}
+}
+
+func readMDNS(conn mdnsConn, outbox chan<- recv) error {
+	bs := make([]byte, 65536)
+	for {
+		n, addr, err := conn.readFrom(bs)
+		if err != nil {
+			l.Debugln(err)
+			return err
+		}
+		l.Debugf("recv %d bytes from %s", n, addr)
+
+		c := make([]byte, n)
+		copy(c, bs)
+		select {
+		case outbox <- recv{c, addr}:
+		default:
+			l.Debugln("dropping message")
+		}
+	}
+}
+
+type mdnsConn4 struct {
+	*ipv4.PacketConn
+}
+
+func listenMDNS4(intfs []net.Interface) (mdnsConn, error) {
+	// Listening on the group address binds to the wildcard address with
+	// the address reuse options set, so we can coexist with other
+	// responders on the host.
+	conn, err := net.ListenPacket("udp4", mdnsGroup4.String())
+	if err != nil {
+		return nil, err
+	}
+	pconn := ipv4.NewPacketConn(conn)
+	joined := 0
+	for _, intf := range intfs {
+		if err := pconn.JoinGroup(&intf, &net.UDPAddr{IP: mdnsGroup4.IP}); err != nil {
+			l.Debugln("IPv4 mDNS join", intf.Name, "failed:", err)
+			continue
+		}
+		joined++
+	}
+	if joined == 0 {
+		conn.Close()
+		return nil, errors.New("no multicast interfaces available")
+	}
+	_ = pconn.SetMulticastTTL(255)
+	return mdnsConn4{pconn}, nil
+}
+
+func (c mdnsConn4) writeTo(bs []byte, ifIndex int) error {
+	c.SetWriteDeadline(time.Now().Add(time.Second))
+	defer c.SetWriteDeadline(time.Time{})
+	_, err := c.WriteTo(bs, &ipv4.ControlMessage{IfIndex: ifIndex}, mdnsGroup4)
+	return err
+}
+
+func (c mdnsConn4) readFrom(bs []byte) (int, net.Addr, error) {
+	n, _, addr, err := c.ReadFrom(bs)
+	return n, addr, err
+}
+
+type mdnsConn6 struct {
+	*ipv6.PacketConn
+}
+
+func listenMDNS6(intfs []net.Interface) (mdnsConn, error) {
+	conn, err := net.ListenPacket("udp6", mdnsGroup6.String())
+	if err != nil {
+		return nil, err
+	}
+	pconn := ipv6.NewPacketConn(conn)
+	joined := 0
+	for _, intf := range intfs {
+		if err := pconn.JoinGroup(&intf, &net.UDPAddr{IP: mdnsGroup6.IP}); err != nil {
+			l.Debugln("IPv6 mDNS join", intf.Name, "failed:", err)
+			continue
+		}
+		joined++
+	}
+	if joined == 0 {
+		conn.Close()
+		return nil, errors.New("no multicast interfaces available")
+	}
+	return mdnsConn6{pconn}, nil
+}
+
+func (c mdnsConn6) writeTo(bs []byte, ifIndex int) error {
+	c.SetWriteDeadline(time.Now().Add(time.Second))
+	defer c.SetWriteDeadline(time.Time{})
+	_, err := c.WriteTo(bs, &ipv6.ControlMessage{HopLimit: 255, IfIndex: ifIndex}, mdnsGroup6)
+	return err
+}
+
+func (c mdnsConn6) readFrom(bs []byte) (int, net.Addr, error) {
+	n, _, addr, err := c.ReadFrom(bs)
+	return n, addr, err
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package beacon
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// The largest IPv4 subnet we're willing to send to host by host.
+const maxUnicastSubnetBits = 10
+
+// NewUnicast returns a beacon that sends to each of the given targets
+// directly, for networks that filter broadcasts and multicasts. A target is
+// a host, a host:port or an IPv4 subnet in CIDR notation, with the given
+// port as the default. The beacon doesn't receive anything; announcements
+// sent to us this way arrive at the broadcast and multicast beacons
+// listening on the same port.
+func NewUnicast(port int, targets []string) Interface {
+	c := newCast("unicastBeacon")
+	c.addWriter(func(ctx context.Context) error {
+		return writeUnicasts(ctx, c.inbox, port, targets)
+	})
+	return c
+}
+
+func writeUnicasts(ctx context.Context, inbox <-chan []byte, port int, targets []string) error {
+	conn, err := net.ListenPacket("udp", ":0")
+	if err != nil {
+		l.Debugln(err)
+		return err
+	}
+	doneCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-doneCtx.Done()
+		conn.Close()
+	}()
+
+	for {
+		var bs []byte
+		select {
+		case bs = <-inbox:
+		case <-doneCtx.Done():
+			return doneCtx.Err()
+		}
+
+		// Resolve every time, as host names may change what they point at.
+		dsts := unicastAddrs(targets, port)
+		l.Debugln("addresses:", dsts)
+
+		success := 0
+		for _, dst := range dsts {
+			conn.SetWriteDeadline(time.Now().Add(time.Second))
+			_, err = conn.WriteTo(bs, dst)
+			conn.SetWriteDeadline(time.Time{})
+
+			if nerr, ok := err.(net.Error); ok && nerr.Timeout() {
+				// Write timeouts should not happen. We treat it as a fatal
+				// error on the socket.
+				l.Debugln(err)
+				return err
+			}
+
+			if err != nil {
+				// The host may be unreachable for the moment; that's not
+				// a reason to stop sending to the others.
+				l.Debugln(err)
+				continue
+			}
+
+			l.Debugf("sent %d bytes to %s", len(bs), dst)
+			success++
+		}
+
+		if success == 0 && err != nil {
+			l.Debugln("couldn't send any unicasts")
+			return err
+		}
+	}
+}
+
+// unicastAddrs returns the addresses to send to for the given targets,
+// expanding subnets to each host address within them. Targets that can't
+// be resolved or are too large are skipped.
+func unicastAddrs(targets []string, port int) []*net.UDPAddr {
+	var addrs []*net.UDPAddr
+	for _, target := range targets {
+		if ip, ipnet, err := net.ParseCIDR(target); err == nil {
+			hosts, err := subnetHosts(ip, ipnet)
+			if err != nil {
+				l.Infof("Skipping local discovery target %s: %v", target, err)
+				continue
+			}
+			for _, host := range hosts {
+				addrs = append(addrs, &net.UDPAddr{IP: host, Port: port})
+			}
+			continue
+		}
+
+		hostPort := target
+		if ip := net.ParseIP(target); ip != nil {
+			hostPort = net.JoinHostPort(target, strconv.Itoa(port))
+		} else if _, _, err := net.SplitHostPort(target); err != nil {
+			hostPort = net.JoinHostPort(target, strconv.Itoa(port))
+		}
+		addr, err := net.ResolveUDPAddr("udp", hostPort)
+		if err != nil {
+			l.Debugln(err)
+			continue
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+// subnetHosts returns the host addresses in an IPv4 subnet, leaving out
+// the network and broadcast addresses.
+func subnetHosts(ip net.IP, ipnet *net.IPNet) ([]net.IP, error) {
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("IPv6 subnets are not supported")
+	}
+	ones, bits := ipnet.Mask.Size()
+	hostBits := bits - ones
+	if hostBits > maxUnicastSubnetBits {
+		return nil, fmt.Errorf("subnet larger than /%d", bits-maxUnicastSubnetBits)
+	}
+	if hostBits == 0 {
+		return []net.IP{ip.To4()}, nil
+	}
+
+	first := binary.BigEndian.Uint32(ipnet.IP.To4())
+	n := uint32(1) << hostBits
+	var hosts []net.IP
+	for i := uint32(0); i < n; i++ {
+		if hostBits > 1 && (i == 0 || i == n-1) {
+			// Network and broadcast addresses, except for /31s
+			continue
+		}
+		host := make(net.IP, 4)
+		binary.BigEndian.PutUint32(host, first+i)
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package beacon
+
+import (
+	"testing"
+)
+
+func TestUnicastAddrs(t *testing.T) {
+	cases := []struct {
+		target string
+		first  string
+		last   string
+		count  int
+	}{
+		{"192.168.1.10", "192.168.1.10:21027", "192.168.1.10:21027", 1},
+		{"192.168.1.10:1234", "192.168.1.10:1234", "192.168.1.10:1234", 1},
+		{"fd00::10", "[fd00::10]:21027", "[fd00::10]:21027", 1},
+		{"[fd00::10]:1234", "[fd00::10]:1234", "[fd00::10]:1234", 1},
+		{"10.1.2.0/24", "10.1.2.1:21027", "10.1.2.254:21027", 254},
+		{"10.1.2.77/22", "10.1.0.1:21027", "10.1.3.254:21027", 1022},
+		{"10.1.2.4/31", "10.1.2.4:21027", "10.1.2.5:21027", 2},
+		{"10.1.2.4/32", "10.1.2.4:21027", "10.1.2.4:21027", 1},
+		{"10.0.0.0/8", "", "", 0},
+		{"fd00::/120", "", "", 0},
+	}
+
+	for _, tc := range cases {
+		addrs := unicastAddrs([]string{tc.target}, 21027)
+		if len(addrs) != tc.count {
+			t.Errorf("%s: got %d addresses, expected %d", tc.target, len(addrs), tc.count)
+			continue
+		}
+		if tc.count == 0 {
+			continue
+		}
+		if first := addrs[0].String(); first != tc.first {
+			t.Errorf("%s: first address is %s, expected %s", tc.target, first, tc.first)
+		}
+		if last := addrs[len(addrs)-1].String(); last != tc.last {
+			t.Errorf("%s: last address is %s, expected %s", tc.target, last, tc.last)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package blockcache implements a disk backed cache of blocks keyed by
+// their hash, for a device acting as a block cache for others on the LAN.
+// The cache need not share any folders with those devices; blocks are
+// requested from it by hash, and it fills itself by fetching the blocks it
+// missed from the devices that asked for them, once they've had time to
+// pull them from elsewhere. Every block is verified against its hash before
+// it's stored, so blocks of encrypted folders, whose hashes are not those
+// of the data, are never cached.
+package blockcache
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	// The most blocks waiting to be fetched at any time; further misses
+	// don't cause fetches until some have completed.
+	maxPendingFills = 10000
+	// The most fetches in progress at any time.
+	maxConcurrentFills = 4
+	// How long to wait for a single fetch.
+	fillTimeout = time.Minute
+
+	tempPrefix = ".tmp-"
+)
+
+// Fetches of a missed block are attempted after these delays from the
+// miss, giving the device that missed time to pull the block elsewhere.
+var fillDelays = []time.Duration{5 * time.Second, 30 * time.Second, 2 * time.Minute}
+
+// ErrHashMismatch is returned when storing data that doesn't match the hash
+// it's stored by.
+var ErrHashMismatch = errors.New("data does not match hash")
+
+// FetchFunc fetches the data of a block to fill the cache with.
+type FetchFunc func(ctx context.Context) ([]byte, error)
+
+// Cache is a disk backed cache of blocks keyed by hash, which evicts the
+// least recently used blocks when full. It is disabled until given a size
+// with SetMaxSize. It is safe for use from multiple goroutines.
+type Cache struct {
+	dir   string
+	fills chan fill
+
+	mut     sync.Mutex
+	maxSize int64
+	size    int64
+	loaded  bool
+	entries map[string]*list.Element // of *entry
+	lru     *list.List               // most recently used first
+	pending map[string]struct{}
+}
+
+type entry struct {
+	key  string
+	size int64
+}
+
+type fill struct {
+	key   string
+	size  int
+	algo  protocol.HashAlgorithm
+	fetch FetchFunc
+}
+
+// New returns a new Cache keeping its blocks in the given directory.
+// Nothing is read or written until the cache is enabled.
+func New(dir string) *Cache {
+	return &Cache{
+		dir:     dir,
+		fills:   make(chan fill, 64),
+		mut:     sync.NewMutex(),
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		pending: make(map[string]struct{}),
+	}
+}
+
+// Serve runs the fetches filling the cache.
+func (c *Cache) Serve(ctx context.Context) error {
+	sem := make(chan struct{}, maxConcurrentFills)
+	wg := sync.NewWaitGroup()
+	defer wg.Wait()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case f := <-c.fills:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c.fill(ctx, f, sem)
+			}()
+		}
+	}
+}
+
+func (c *Cache) String() string {
+	return fmt.Sprintf("blockcache@%p", c)
+}
+
+// SetMaxSize sets the size the cache may grow to, in bytes, evicting
+// blocks as necessary. Zero disables the cache, leaving what's on disk in
+// place for when it's enabled again.
+func (c *Cache) SetMaxSize(size int64) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.maxSize = size
+	if size <= 0 {
+		return
+	}
+	if !c.loaded {
+		if err := c.loadLocked(); err != nil {
+			l.Warnln("Loading block cache:", err)
+		}
+		c.loaded = true
+	}
+	c.evictLocked()
+}
+
+// Enabled returns whether the cache is in use.
+func (c *Cache) Enabled() bool {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return c.maxSize > 0
+}
+
+// Get returns the block with the given hash, if it's in the cache.
+func (c *Cache) Get(hash []byte) ([]byte, bool) {
+	if len(hash) == 0 {
+		return nil, false
+	}
+	key := hex.EncodeToString(hash)
+
+	c.mut.Lock()
+	el, ok := c.entries[key]
+	if !ok || c.maxSize <= 0 {
+		c.mut.Unlock()
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	c.mut.Unlock()
+
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		l.Debugln("Reading cached block:", err)
+		c.mut.Lock()
+		c.removeLocked(key)
+		c.mut.Unlock()
+		return nil, false
+	}
+
+	// Keep the order of use across restarts.
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return data, true
+}
+
+// Put adds a block to the cache, after verifying that the data has the
+// given hash.
+func (c *Cache) Put(hash, data []byte, algo protocol.HashAlgorithm) error {
+	if len(hash) == 0 {
+		return nil
+	}
+	if sum := algo.Sum(data); !bytes.Equal(sum[:], hash) {
+		return ErrHashMismatch
+	}
+	key := hex.EncodeToString(hash)
+
+	c.mut.Lock()
+	_, exists := c.entries[key]
+	fits := int64(len(data)) <= c.maxSize
+	c.mut.Unlock()
+	if exists || !fits {
+		return nil
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	fd, err := os.CreateTemp(filepath.Dir(path), tempPrefix)
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(data); err != nil {
+		fd.Close()
+		os.Remove(fd.Name())
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		os.Remove(fd.Name())
+		return err
+	}
+	if err := os.Rename(fd.Name(), path); err != nil {
+		os.Remove(fd.Name())
+		return err
+	}
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.addLocked(key, int64(len(data)), false)
+		c.evictLocked()
+	}
+	return nil
+}
+
+// Fill arranges for the block with the given hash and size to be fetched
+// into the cache, unless it's already there or on its way. The fetched data
+// is verified using the given hash algorithm.
+func (c *Cache) Fill(hash []byte, size int, algo protocol.HashAlgorithm, fetch FetchFunc) {
+	if len(hash) == 0 {
+		return
+	}
+	key := hex.EncodeToString(hash)
+
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if c.maxSize <= 0 || len(c.pending) >= maxPendingFills {
+		return
+	}
+	if _, ok := c.entries[key]; ok {
+		return
+	}
+	if _, ok := c.pending[key]; ok {
+		return
+	}
+	select {
+	case c.fills <- fill{key: key, size: size, algo: algo, fetch: fetch}:
+		c.pending[key] = struct{}{}
+	default:
+	}
+}
+
+func (c *Cache) fill(ctx context.Context, f fill, sem chan struct{}) {
+	defer func() {
+		c.mut.Lock()
+		delete(c.pending, f.key)
+		c.mut.Unlock()
+	}()
+
+	for _, delay := range fillDelays {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		fetchCtx, cancel := context.WithTimeout(ctx, fillTimeout)
+		data, err := f.fetch(fetchCtx)
+		cancel()
+		<-sem
+
+		if err != nil {
+			l.Debugf("Fetching block %s: %v", f.key, err)
+			continue
+		}
+		if len(data) != f.size {
+			l.Debugf("Fetching block %s: got %d bytes, expected %d", f.key, len(data), f.size)
+			continue
+		}
+		hash, _ := hex.DecodeString(f.key)
+		if err := c.Put(hash, data, f.algo); err != nil {
+			// Nothing we fetch again is going to be any better.
+			l.Debugf("Storing block %s: %v", f.key, err)
+		}
+		return
+	}
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+func (c *Cache) addLocked(key string, size int64, back bool) {
+	e := &entry{key: key, size: size}
+	if back {
+		c.entries[key] = c.lru.PushBack(e)
+	} else {
+		c.entries[key] = c.lru.PushFront(e)
+	}
+	c.size += size
+}
+
+func (c *Cache) removeLocked(key string) {
+	el, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	c.lru.Remove(el)
+	delete(c.entries, key)
+	c.size -= el.Value.(*entry).size
+}
+
+// evictLocked removes the least recently used blocks until the cache is
+// within its size.
+func (c *Cache) evictLocked() {
+	for c.size > c.maxSize {
+		el := c.lru.Back()
+		if el == nil {
+			return
+		}
+		key := el.Value.(*entry).key
+		c.removeLocked(key)
+		if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+			l.Debugln("Evicting cached block:", err)
+		}
+	}
+}
+
+// loadLocked reads what's in the cache directory, using the modification
+// times for the order of use.
+func (c *Cache) loadLocked() error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+	subdirs, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+
+	type found struct {
+		key   string
+		size  int64
+		mtime time.Time
+	}
+	var blocks []found
+	for _, sub := range subdirs {
+		if !sub.IsDir() || len(sub.Name()) != 2 {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(c.dir, sub.Name()))
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			name := file.Name()
+			if strings.HasPrefix(name, tempPrefix) {
+				// Left over from an interrupted write.
+				os.Remove(filepath.Join(c.dir, sub.Name(), name))
+				continue
+			}
+			if !strings.HasPrefix(name, sub.Name()) {
+				continue
+			}
+			if _, err := hex.DecodeString(name); err != nil {
+				continue
+			}
+			info, err := file.Info()
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			blocks = append(blocks, found{key: name, size: info.Size(), mtime: info.ModTime()})
+		}
+	}
+
+	sort.Slice(blocks, func(a, b int) bool {
+		return blocks[a].mtime.After(blocks[b].mtime)
+	})
+	for _, b := range blocks {
+		if _, ok := c.entries[b.key]; !ok {
+			c.addLocked(b.key, b.size, true)
+		}
+	}
+	l.Debugf("Loaded %d blocks, %d bytes, from %s", len(blocks), c.size, c.dir)
+	return nil
+}
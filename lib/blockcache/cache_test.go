@@ -0,0 +1,191 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package blockcache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func block(i int) ([]byte, []byte) {
+	data := bytes.Repeat([]byte(fmt.Sprint(i)), 100)
+	hash := sha256.Sum256(data)
+	return hash[:], data
+}
+
+func TestCacheDisabled(t *testing.T) {
+	c := New(t.TempDir())
+	hash, data := block(0)
+	if err := c.Put(hash, data, protocol.HashAlgorithmSHA256); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get(hash); ok {
+		t.Error("Disabled cache should not return blocks")
+	}
+}
+
+func TestCachePutVerifies(t *testing.T) {
+	c := New(t.TempDir())
+	c.SetMaxSize(1 << 20)
+	hash, _ := block(0)
+	_, other := block(1)
+	if err := c.Put(hash, other, protocol.HashAlgorithmSHA256); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Expected hash mismatch, got %v", err)
+	}
+	_, data := block(0)
+	if err := c.Put(hash, data, protocol.HashAlgorithmBLAKE3); !errors.Is(err, ErrHashMismatch) {
+		t.Errorf("Expected hash mismatch with another algorithm, got %v", err)
+	}
+	if _, ok := c.Get(hash); ok {
+		t.Error("Expected nothing to be stored")
+	}
+}
+
+func TestCachePutGetEvict(t *testing.T) {
+	dir := t.TempDir()
+	c := New(dir)
+	c.SetMaxSize(300)
+
+	for i := 0; i < 3; i++ {
+		hash, data := block(i)
+		if err := c.Put(hash, data, protocol.HashAlgorithmSHA256); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Use the first one, making the second the least recently used.
+	hash0, data0 := block(0)
+	if got, ok := c.Get(hash0); !ok || !bytes.Equal(got, data0) {
+		t.Fatal("Expected block 0 in cache")
+	}
+
+	hash3, data3 := block(3)
+	if err := c.Put(hash3, data3, protocol.HashAlgorithmSHA256); err != nil {
+		t.Fatal(err)
+	}
+	hash1, _ := block(1)
+	if _, ok := c.Get(hash1); ok {
+		t.Error("Expected block 1 to be evicted")
+	}
+	for _, i := range []int{0, 2, 3} {
+		hash, data := block(i)
+		if got, ok := c.Get(hash); !ok || !bytes.Equal(got, data) {
+			t.Errorf("Expected block %d in cache", i)
+		}
+	}
+
+	// What's on disk is picked up again, within the size.
+	c = New(dir)
+	c.SetMaxSize(200)
+	n := 0
+	for i := 0; i < 4; i++ {
+		hash, _ := block(i)
+		if _, ok := c.Get(hash); ok {
+			n++
+		}
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 blocks after reload, got %d", n)
+	}
+}
+
+func TestCacheFill(t *testing.T) {
+	oldDelays := fillDelays
+	fillDelays = []time.Duration{time.Millisecond, time.Millisecond}
+	defer func() { fillDelays = oldDelays }()
+
+	c := New(t.TempDir())
+	c.SetMaxSize(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Serve(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// The first fetch fails, the second one succeeds.
+	hash, data := block(0)
+	fetched := make(chan struct{}, 2)
+	calls := 0
+	c.Fill(hash, len(data), protocol.HashAlgorithmSHA256, func(context.Context) ([]byte, error) {
+		calls++
+		fetched <- struct{}{}
+		if calls == 1 {
+			return nil, errors.New("not yet")
+		}
+		return data, nil
+	})
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-fetched:
+		case <-time.After(10 * time.Second):
+			t.Fatal("Timed out waiting for fetch")
+		}
+	}
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(time.Millisecond) {
+		if got, ok := c.Get(hash); ok {
+			if !bytes.Equal(got, data) {
+				t.Fatal("Wrong data in cache")
+			}
+			return
+		}
+	}
+	t.Fatal("Block never got into the cache")
+}
+
+func TestCacheFillVerifies(t *testing.T) {
+	oldDelays := fillDelays
+	fillDelays = []time.Duration{time.Millisecond, time.Millisecond}
+	defer func() { fillDelays = oldDelays }()
+
+	c := New(t.TempDir())
+	c.SetMaxSize(1 << 20)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		c.Serve(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// Data not matching the hash is fetched once and never stored.
+	hash, data := block(0)
+	_, other := block(1)
+	fetched := make(chan struct{}, 2)
+	c.Fill(hash, len(data), protocol.HashAlgorithmSHA256, func(context.Context) ([]byte, error) {
+		fetched <- struct{}{}
+		return other, nil
+	})
+	select {
+	case <-fetched:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for fetch")
+	}
+	select {
+	case <-fetched:
+		t.Error("Expected no further fetches")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if _, ok := c.Get(hash); ok {
+		t.Error("Expected the poisoned block not to be stored")
+	}
+}
@@ -97,6 +97,10 @@ func TestDefaultValues(t *testing.T) {
 			ConnectionPriorityTCPWAN:  30,
 			ConnectionPriorityQUICWAN: 40,
 			ConnectionPriorityRelay:   50,
+			ConnectionPriorityWSSLAN:  25,
+			ConnectionPriorityWSSWAN:  45,
+			ProxyFallback:             true,
+			LocalAnnUnicastAddresses:  []string{},
 		},
 		Defaults: Defaults{
 			Folder: FolderConfiguration{
@@ -113,6 +117,9 @@ func TestDefaultValues(t *testing.T) {
 				Versioning: VersioningConfiguration{
 					CleanupIntervalS: 3600,
 					Params:           map[string]string{},
+					Retention: VersioningRetention{
+						Rules: []VersioningRetentionRule{},
+					},
 				},
 				MaxConflicts:         10,
 				WeakHashThresholdPct: 25,
@@ -123,6 +130,7 @@ func TestDefaultValues(t *testing.T) {
 					MaxSingleEntrySize: 1024,
 					MaxTotalSize:       4096,
 				},
+				PullPriorities: []PullPriorityRule{},
 			},
 			Device: DeviceConfiguration{
 				Addresses:       []string{"dynamic"},
@@ -189,6 +197,9 @@ func TestDeviceConfig(t *testing.T) {
 				MaxConflicts:     -1,
 				Versioning: VersioningConfiguration{
 					Params: map[string]string{},
+					Retention: VersioningRetention{
+						Rules: []VersioningRetentionRule{},
+					},
 				},
 				WeakHashThresholdPct: 25,
 				MarkerName:           DefaultMarkerName,
@@ -197,6 +208,7 @@ func TestDeviceConfig(t *testing.T) {
 				XattrFilter: XattrFilter{
 					Entries: []XattrFilterEntry{},
 				},
+				PullPriorities: []PullPriorityRule{},
 			},
 		}
 
@@ -295,6 +307,13 @@ func TestOverriddenValues(t *testing.T) {
 		ConnectionPriorityTCPWAN:  50,
 		ConnectionPriorityQUICWAN: 55,
 		ConnectionPriorityRelay:   9000,
+		ConnectionPriorityWSSLAN:  42,
+		ConnectionPriorityWSSWAN:  52,
+		ProxyAddress:              "socks5://proxy.example.com:1080",
+		ProxyFallback:             false,
+		BlockCacheMaxSizeMiB:      1024,
+		LocalAnnUnicastAddresses:  []string{"192.168.1.10", "10.1.2.0/24"},
+		LocalAnnMDNSEnabled:       true,
 	}
 	expectedPath := "/media/syncthing"
 
@@ -473,6 +492,18 @@ func TestVersioningConfig(t *testing.T) {
 	if diff, equal := messagediff.PrettyDiff(expected, vc.Params); !equal {
 		t.Errorf("vc.Params differ. Diff:\n%s", diff)
 	}
+
+	expectedRetention := VersioningRetention{
+		MaxVersions:     10,
+		MaxTotalSizeMiB: 1024,
+		MinDiskFree:     Size{Value: 5, Unit: "%"},
+		Rules: []VersioningRetentionRule{
+			{Pattern: "*.iso", MaxVersions: 1, MaxAgeDays: 7},
+		},
+	}
+	if diff, equal := messagediff.PrettyDiff(expectedRetention, vc.Retention); !equal {
+		t.Errorf("vc.Retention differs. Diff:\n%s", diff)
+	}
 }
 
 func TestIssue1262(t *testing.T) {
@@ -739,6 +770,39 @@ func TestPullOrder(t *testing.T) {
 	}
 }
 
+func TestScanningOptions(t *testing.T) {
+	wrapper, wrapperCleanup, err := copyAndLoad(testFs, "scanning.xml", device1)
+	defer wrapperCleanup()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(folders map[string]FolderConfiguration) {
+		t.Helper()
+		if f := folders["f1"]; f.ScanCheckpoint || f.FanotifyWatcher {
+			t.Errorf("Expected scan checkpoint and fanotify off by default, got %v and %v", f.ScanCheckpoint, f.FanotifyWatcher)
+		}
+		if f := folders["f2"]; !f.ScanCheckpoint || !f.FanotifyWatcher {
+			t.Errorf("Expected scan checkpoint and fanotify on, got %v and %v", f.ScanCheckpoint, f.FanotifyWatcher)
+		}
+	}
+	check(wrapper.Folders())
+
+	// Serialize and deserialize again to verify it survives the transformation
+
+	buf := new(bytes.Buffer)
+	cfg := wrapper.RawCopy()
+	cfg.WriteXML(buf)
+
+	cfg, _, err = ReadXML(buf, device1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrapper2 := wrap(wrapper.ConfigPath(), cfg, device1)
+	defer wrapper2.stop()
+	check(wrapper2.Folders())
+}
+
 func TestLargeRescanInterval(t *testing.T) {
 	wrapper, wrapperCancel, err := copyAndLoad(testFs, "largeinterval.xml", device1)
 	defer wrapperCancel()
@@ -1506,6 +1570,66 @@ func TestXattrFilter(t *testing.T) {
 	}
 }
 
+func TestPullPriority(t *testing.T) {
+	f := FolderConfiguration{
+		PullPriorities: []PullPriorityRule{
+			{Pattern: "*.docx", Priority: 10},
+			{Pattern: "Projects/current/", Priority: 5},
+			{Pattern: "archive", Priority: -5},
+			{Pattern: "*.iso", Priority: -10},
+		},
+	}
+
+	cases := []struct {
+		name     string
+		priority int
+	}{
+		{"report.docx", 10},
+		{"archive/report.docx", 10},
+		{"Projects/current/notes.txt", 5},
+		{"Projects/current/disk.iso", 5},
+		{"Projects/current", 5},
+		{"Projects/old/notes.txt", 0},
+		{"Other/Projects/current/notes.txt", 0},
+		{"archive/notes.txt", -5},
+		{"photos/archive/2020/img.jpg", -5},
+		{"archive.txt", 0},
+		{"disk.iso", -10},
+		{"notes.txt", 0},
+	}
+
+	for _, tc := range cases {
+		if p := f.PullPriority(filepath.FromSlash(tc.name)); p != tc.priority {
+			t.Errorf("PullPriority(%q) == %d, expected %d", tc.name, p, tc.priority)
+		}
+	}
+
+	// Case is ignored, unless the folder is case sensitive.
+	name := filepath.FromSlash("projects/Current/REPORT.DOCX")
+	if p := f.PullPriority(name); p != 10 {
+		t.Errorf("PullPriority(%q) == %d, expected 10", name, p)
+	}
+	f.CaseSensitiveFS = true
+	if p := f.PullPriority(name); p != 0 {
+		t.Errorf("PullPriority(%q) == %d, expected 0 on a case sensitive folder", name, p)
+	}
+}
+
+func TestPullPriorityInvalidPattern(t *testing.T) {
+	f := FolderConfiguration{
+		ID: "folder",
+		PullPriorities: []PullPriorityRule{
+			{Pattern: "[", Priority: 10},
+			{Pattern: "*.docx", Priority: 5},
+			{Pattern: "dir/a[", Priority: 1},
+		},
+	}
+	f.PullPriorities = ensureValidPullPriorities(&f)
+	if len(f.PullPriorities) != 1 || f.PullPriorities[0].Pattern != "*.docx" {
+		t.Errorf("Expected only the valid rule to remain, got %v", f.PullPriorities)
+	}
+}
+
 func TestUntrustedIntroducer(t *testing.T) {
 	fd, err := os.Open("testdata/untrustedintroducer.xml")
 	if err != nil {
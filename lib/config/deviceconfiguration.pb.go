@@ -45,6 +45,7 @@ type DeviceConfiguration struct {
 	Untrusted                bool                                                 `protobuf:"varint,17,opt,name=untrusted,proto3" json:"untrusted" xml:"untrusted"`
 	RemoteGUIPort            int                                                  `protobuf:"varint,18,opt,name=remote_gui_port,json=remoteGuiPort,proto3,casttype=int" json:"remoteGUIPort" xml:"remoteGUIPort"`
 	RawNumConnections        int                                                  `protobuf:"varint,19,opt,name=num_connections,json=numConnections,proto3,casttype=int" json:"numConnections" xml:"numConnections"`
+	BlockCache               bool                                                 `protobuf:"varint,20,opt,name=block_cache,json=blockCache,proto3" json:"blockCache" xml:"blockCache"`
 }
 
 func (m *DeviceConfiguration) Reset()         { *m = DeviceConfiguration{} }
@@ -89,74 +90,75 @@ func init() {
 }
 
 var fileDescriptor_744b782bd13071dd = []byte{
-	// 1057 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x41, 0x6f, 0xe3, 0x44,
-	0x14, 0x8e, 0xe9, 0x6e, 0xb7, 0x99, 0x6d, 0x9b, 0xc6, 0x65, 0xbb, 0xde, 0x4a, 0x9b, 0x89, 0x42,
-	0x0e, 0x41, 0xec, 0xa6, 0xa8, 0x70, 0xaa, 0x00, 0x89, 0xb4, 0x82, 0xad, 0x2a, 0xba, 0x65, 0x10,
-	0x97, 0xdd, 0x83, 0x71, 0x3c, 0xd3, 0xac, 0xd5, 0x78, 0xc6, 0xd8, 0xe3, 0xb4, 0x95, 0x38, 0x72,
-	0x80, 0x1b, 0xaa, 0xc4, 0x89, 0xcb, 0xc2, 0xdf, 0xe0, 0xc0, 0xb5, 0xb7, 0xe6, 0x08, 0x1c, 0x46,
-	0xda, 0xf4, 0xe6, 0xa3, 0x8f, 0x9c, 0xd0, 0x8c, 0x1d, 0xc7, 0x76, 0x37, 0x2b, 0x24, 0x6e, 0x9e,
-	0xef, 0x7b, 0xf3, 0x7d, 0xf3, 0x9e, 0xdf, 0x9b, 0x01, 0xed, 0xa1, 0xd3, 0xdf, 0xb2, 0x19, 0x3d,
-	0x76, 0x06, 0x5b, 0x98, 0x8c, 0x1c, 0x9b, 0x24, 0x8b, 0xd0, 0xb7, 0xb8, 0xc3, 0x68, 0xd7, 0xf3,
-	0x19, 0x67, 0xfa, 0x62, 0x02, 0x6e, 0x6e, 0xc8, 0x68, 0x05, 0xd9, 0x6c, 0xb8, 0xd5, 0x27, 0x5e,
-	0xc2, 0x6f, 0x3e, 0xc8, 0xa9, 0xb0, 0x7e, 0x40, 0xfc, 0x11, 0xc1, 0x29, 0x55, 0x25, 0x67, 0x3c,
-	0xf9, 0x6c, 0xfd, 0x55, 0x07, 0xeb, 0x7b, 0xca, 0x63, 0x37, 0xef, 0xa1, 0xff, 0xa1, 0x81, 0x6a,
-	0xe2, 0x6d, 0x3a, 0xd8, 0xd0, 0x9a, 0x5a, 0x67, 0xb9, 0xf7, 0xab, 0x76, 0x29, 0x60, 0xe5, 0x6f,
-	0x01, 0x3f, 0x1c, 0x38, 0xfc, 0x45, 0xd8, 0xef, 0xda, 0xcc, 0xdd, 0x0a, 0xce, 0xa9, 0xcd, 0x5f,
-	0x38, 0x74, 0x90, 0xfb, 0xca, 0x9f, 0xa8, 0x9b, 0xa8, 0xef, 0xef, 0x4d, 0x04, 0x5c, 0x9a, 0x7e,
-	0x47, 0x02, 0x2e, 0xe1, 0xf4, 0x3b, 0x16, 0xb0, 0x71, 0xe6, 0x0e, 0x77, 0x5a, 0x0e, 0x7e, 0x64,
-	0x71, 0xee, 0xb7, 0x9a, 0x94, 0x61, 0x72, 0x6c, 0x85, 0x43, 0xbe, 0xd3, 0xe2, 0x7e, 0x48, 0x5a,
-	0xd1, 0x55, 0xfb, 0x4e, 0x4a, 0xc6, 0x57, 0xed, 0x6c, 0xe3, 0x0f, 0xe3, 0xb6, 0x76, 0x31, 0x6e,
-	0x67, 0xa2, 0x2f, 0xc7, 0x6d, 0x0d, 0x4d, 0x59, 0xac, 0x1f, 0x81, 0x5b, 0xd4, 0x72, 0x89, 0xf1,
-	0x56, 0x53, 0xeb, 0x54, 0x7b, 0x1f, 0x45, 0x02, 0xaa, 0x75, 0x2c, 0xe0, 0x03, 0x65, 0x27, 0x17,
-	0x4a, 0xf3, 0x11, 0x73, 0x1d, 0x4e, 0x5c, 0x8f, 0x9f, 0x4b, 0xa7, 0xf5, 0xd7, 0xe0, 0x48, 0xed,
-	0xd4, 0x9f, 0x83, 0xaa, 0x85, 0xb1, 0x4f, 0x82, 0x80, 0x04, 0xc6, 0x42, 0x73, 0xa1, 0x53, 0xed,
-	0x7d, 0x1c, 0x09, 0x38, 0x03, 0x63, 0x01, 0xef, 0x2b, 0xed, 0x14, 0x29, 0x2a, 0xd7, 0x6f, 0xa0,
-	0x68, 0xb6, 0x55, 0x1f, 0x81, 0xbb, 0x36, 0x73, 0x3d, 0xb9, 0x72, 0x18, 0x35, 0x6e, 0x35, 0xb5,
-	0xce, 0xea, 0xf6, 0xbd, 0x6e, 0x56, 0xc6, 0xdd, 0x19, 0xa9, 0x5c, 0xf3, 0xd1, 0xb1, 0x80, 0x1b,
-	0xca, 0x37, 0x87, 0x25, 0xb5, 0x8c, 0xae, 0xda, 0x6b, 0x65, 0x10, 0xe5, 0xb7, 0xea, 0x04, 0x54,
-	0x6d, 0xe2, 0x73, 0x53, 0xd5, 0xea, 0xb6, 0xaa, 0xd5, 0x13, 0xf9, 0x7b, 0x24, 0x78, 0x98, 0xd4,
-	0xeb, 0x61, 0xa2, 0x9d, 0x02, 0xaf, 0xa9, 0xd9, 0xfd, 0x39, 0x1c, 0xca, 0x54, 0xf4, 0x67, 0x00,
-	0x38, 0x94, 0xfb, 0x0c, 0x87, 0x36, 0xf1, 0x8d, 0xc5, 0xa6, 0xd6, 0x59, 0xea, 0xed, 0x44, 0x02,
-	0xe6, 0xd0, 0x58, 0xc0, 0x7b, 0x49, 0x23, 0x64, 0x50, 0x96, 0x44, 0xad, 0x84, 0xa1, 0xdc, 0x3e,
-	0xfd, 0x37, 0x0d, 0x6c, 0x06, 0x27, 0x8e, 0x67, 0x4e, 0x31, 0xd9, 0xc1, 0xa6, 0x4f, 0x5c, 0x36,
-	0xb2, 0x86, 0x81, 0x71, 0x47, 0x99, 0xe1, 0x48, 0x40, 0x43, 0x46, 0xed, 0xe7, 0x82, 0x50, 0x1a,
-	0x13, 0x0b, 0xf8, 0x8e, 0xb2, 0x9e, 0x17, 0x90, 0x1d, 0xe4, 0xe1, 0x1b, 0x23, 0xd0, 0x5c, 0x07,
-	0xfd, 0x77, 0x0d, 0xac, 0x64, 0x67, 0xc6, 0x66, 0xff, 0xdc, 0x58, 0x52, 0x43, 0xf5, 0xf3, 0xff,
-	0x1a, 0xaa, 0x48, 0xc0, 0xe5, 0x99, 0x6a, 0xef, 0x3c, 0x16, 0xb0, 0x53, 0xac, 0x21, 0xee, 0x9d,
-	0xcf, 0x1f, 0xab, 0xfa, 0x8d, 0x30, 0x39, 0x54, 0x6a, 0x90, 0x0a, 0xb2, 0xfa, 0x36, 0x58, 0xf4,
-	0xac, 0x30, 0x20, 0xd8, 0xa8, 0xaa, 0x6a, 0x6e, 0x46, 0x02, 0xa6, 0x48, 0x2c, 0xe0, 0xb2, 0xb2,
-	0x4c, 0x96, 0x2d, 0x94, 0xe2, 0xfa, 0x77, 0x60, 0xcd, 0x1a, 0x0e, 0xd9, 0x29, 0xc1, 0x26, 0x25,
-	0xfc, 0x94, 0xf9, 0x27, 0x81, 0x01, 0xd4, 0xd4, 0x7c, 0x19, 0x09, 0x58, 0x4b, 0xb9, 0xc3, 0x94,
-	0xca, 0xae, 0x81, 0x22, 0x5e, 0x6c, 0x34, 0x63, 0x1e, 0x89, 0xca, 0x72, 0xfa, 0x37, 0x60, 0xdd,
-	0x0a, 0x39, 0x33, 0x2d, 0xdb, 0x26, 0x1e, 0x37, 0x8f, 0xd9, 0x10, 0x13, 0x3f, 0x30, 0xee, 0xaa,
-	0xe3, 0xbf, 0x1f, 0x09, 0x58, 0x97, 0xf4, 0xa7, 0x8a, 0xfd, 0x2c, 0x21, 0x67, 0xe3, 0x5b, 0x66,
-	0x5a, 0xe8, 0x66, 0xb4, 0xfe, 0x14, 0xac, 0xb8, 0xd6, 0x99, 0x19, 0x10, 0x8a, 0xcd, 0x93, 0xbe,
-	0x17, 0x18, 0xcb, 0x4d, 0xad, 0x73, 0xbb, 0xf7, 0x9e, 0x1c, 0x4e, 0xd7, 0x3a, 0xfb, 0x8a, 0x50,
-	0x7c, 0xd0, 0xf7, 0xa4, 0x6a, 0x5d, 0xa9, 0xe6, 0xb0, 0xd6, 0x3f, 0x02, 0x2e, 0x38, 0x94, 0xa3,
-	0x7c, 0xe0, 0x54, 0xd0, 0x27, 0xf6, 0x28, 0x11, 0x5c, 0x29, 0x08, 0x22, 0x62, 0x8f, 0xca, 0x82,
-	0x53, 0xac, 0x20, 0x38, 0x05, 0x75, 0x0a, 0x6a, 0xce, 0x80, 0x32, 0x9f, 0xe0, 0x2c, 0xff, 0xd5,
-	0xe6, 0x42, 0xe7, 0xee, 0xf6, 0x46, 0x37, 0x79, 0x18, 0xba, 0x4f, 0xd3, 0x87, 0x21, 0xc9, 0xa9,
-	0xf7, 0x58, 0xf6, 0x62, 0x24, 0xe0, 0x6a, 0xba, 0x6d, 0x56, 0x98, 0xf5, 0xa4, 0xab, 0xf2, 0x70,
-	0x0b, 0x95, 0xc2, 0xf4, 0x1f, 0x35, 0x50, 0xf3, 0x08, 0xc5, 0x0e, 0x1d, 0x64, 0x86, 0xb5, 0x37,
-	0x1a, 0x3e, 0x91, 0x86, 0x13, 0x01, 0x8d, 0x3d, 0xe2, 0xf9, 0xc4, 0xb6, 0x38, 0xc1, 0x47, 0x89,
-	0x40, 0xaa, 0x19, 0x09, 0xa8, 0x3d, 0xce, 0xee, 0x20, 0x2f, 0xcf, 0xe5, 0x5a, 0xc3, 0xd0, 0xd0,
-	0x6a, 0x81, 0x0b, 0xf4, 0x5f, 0x34, 0x50, 0x4b, 0xaa, 0xf9, 0x6d, 0x48, 0x02, 0x6e, 0x9e, 0x38,
-	0x7d, 0x63, 0x4d, 0xd5, 0x33, 0x98, 0x08, 0xb8, 0xf2, 0x85, 0x2c, 0x93, 0x62, 0x0e, 0x9c, 0x5e,
-	0x24, 0xe0, 0x8a, 0x9b, 0x07, 0xb2, 0x84, 0x0b, 0xe8, 0xb4, 0xc8, 0xd1, 0x55, 0xbb, 0x14, 0x5e,
-	0x06, 0x2e, 0xc6, 0xed, 0xa2, 0x03, 0x2a, 0xf0, 0x7d, 0xfd, 0x13, 0x50, 0x0d, 0x29, 0xf7, 0xc3,
-	0x80, 0x13, 0x6c, 0xd4, 0x55, 0x4f, 0x36, 0xe5, 0x53, 0x92, 0x81, 0xb1, 0x80, 0x35, 0x75, 0x82,
-	0x0c, 0x69, 0xa1, 0x19, 0xab, 0xb2, 0x93, 0x17, 0x1c, 0x27, 0xe6, 0x20, 0x74, 0x4c, 0x8f, 0xf9,
-	0xdc, 0xd0, 0x67, 0xd9, 0x21, 0x45, 0x7d, 0xfe, 0xf5, 0xfe, 0x11, 0xf3, 0xb9, 0xcc, 0xce, 0xcf,
-	0x03, 0x59, 0x76, 0x05, 0x34, 0x9f, 0x5d, 0x31, 0xbc, 0x0c, 0xc8, 0xec, 0x0a, 0x0e, 0x68, 0xca,
-	0x87, 0x8e, 0x5c, 0xea, 0xdf, 0x6b, 0xa0, 0x46, 0x43, 0xd7, 0xb4, 0x19, 0xa5, 0x44, 0x5d, 0x83,
-	0x81, 0xb1, 0xae, 0x4e, 0xf7, 0x7c, 0x22, 0x60, 0x1d, 0x59, 0xa7, 0x87, 0xa1, 0xbb, 0x3b, 0x23,
-	0x65, 0xc7, 0xd1, 0x02, 0x12, 0x0b, 0xf8, 0x76, 0xf2, 0x4a, 0x17, 0xe0, 0xe9, 0x19, 0x2f, 0xc6,
-	0xed, 0x9b, 0x2a, 0xa8, 0xa4, 0xd1, 0x3b, 0xb8, 0x7c, 0xd5, 0xa8, 0x8c, 0x5f, 0x35, 0x2a, 0x97,
-	0x93, 0x86, 0x36, 0x9e, 0x34, 0xb4, 0x9f, 0xae, 0x1b, 0x95, 0x97, 0xd7, 0x0d, 0x6d, 0x7c, 0xdd,
-	0xa8, 0xfc, 0x79, 0xdd, 0xa8, 0x3c, 0x7b, 0xf7, 0x3f, 0xdc, 0xb9, 0x49, 0xe3, 0xf6, 0x17, 0xd5,
-	0xdd, 0xfb, 0xc1, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x75, 0x19, 0xf5, 0x92, 0x9d, 0x09, 0x00,
-	0x00,
+	// 1088 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x95, 0xbd, 0x6f, 0xdb, 0xc6,
+	0x1b, 0xc7, 0xc5, 0x9f, 0x13, 0xc7, 0x3a, 0xbf, 0xc8, 0xa2, 0x13, 0x87, 0x31, 0x10, 0x9d, 0xc0,
+	0x9f, 0x06, 0x15, 0x4d, 0xe4, 0xc2, 0xed, 0x64, 0xb4, 0x05, 0x2a, 0x1b, 0x6d, 0x0c, 0xa3, 0x8e,
+	0x7b, 0x45, 0x97, 0x64, 0x60, 0x29, 0xde, 0x59, 0x26, 0x2c, 0xde, 0xb1, 0xe4, 0x51, 0xb6, 0x81,
+	0x8e, 0x1d, 0xda, 0xad, 0x30, 0xd0, 0xa9, 0x40, 0x91, 0xf6, 0xdf, 0xe8, 0xd0, 0xd5, 0x9b, 0x35,
+	0x16, 0x1d, 0x0e, 0x88, 0xbc, 0x71, 0xe4, 0xd8, 0xa9, 0xb8, 0x23, 0x45, 0x91, 0x72, 0x1c, 0x14,
+	0xe8, 0xc6, 0xfb, 0x7c, 0x9f, 0xfb, 0x3e, 0xf7, 0x3c, 0xbc, 0x17, 0xd0, 0x1a, 0xb8, 0xbd, 0x4d,
+	0x87, 0xd1, 0x23, 0xb7, 0xbf, 0x89, 0xc9, 0xd0, 0x75, 0x48, 0x3a, 0x88, 0x02, 0x9b, 0xbb, 0x8c,
+	0x76, 0xfc, 0x80, 0x71, 0xa6, 0xcf, 0xa7, 0x70, 0x63, 0x5d, 0x46, 0x2b, 0xe4, 0xb0, 0xc1, 0x66,
+	0x8f, 0xf8, 0xa9, 0xbe, 0xf1, 0xa8, 0xe0, 0xc2, 0x7a, 0x21, 0x09, 0x86, 0x04, 0x67, 0x52, 0x95,
+	0x9c, 0xf1, 0xf4, 0xd3, 0xfc, 0x45, 0x07, 0x6b, 0xbb, 0x2a, 0xc7, 0x4e, 0x31, 0x87, 0xfe, 0x87,
+	0x06, 0xaa, 0x69, 0x6e, 0xcb, 0xc5, 0x86, 0xd6, 0xd4, 0xda, 0x4b, 0xdd, 0x5f, 0xb5, 0x4b, 0x01,
+	0x2b, 0x7f, 0x09, 0xf8, 0x41, 0xdf, 0xe5, 0xc7, 0x51, 0xaf, 0xe3, 0x30, 0x6f, 0x33, 0x3c, 0xa7,
+	0x0e, 0x3f, 0x76, 0x69, 0xbf, 0xf0, 0x55, 0x5c, 0x51, 0x27, 0x75, 0xdf, 0xdb, 0x1d, 0x0b, 0xb8,
+	0x30, 0xf9, 0x8e, 0x05, 0x5c, 0xc0, 0xd9, 0x77, 0x22, 0x60, 0xe3, 0xcc, 0x1b, 0x6c, 0x9b, 0x2e,
+	0x7e, 0x62, 0x73, 0x1e, 0x98, 0x4d, 0xca, 0x30, 0x39, 0xb2, 0xa3, 0x01, 0xdf, 0x36, 0x79, 0x10,
+	0x11, 0x33, 0xbe, 0x6a, 0xdd, 0xcb, 0xc4, 0xe4, 0xaa, 0x95, 0x4f, 0xfc, 0x7e, 0xd4, 0xd2, 0x2e,
+	0x46, 0xad, 0xdc, 0xf4, 0xd5, 0xa8, 0xa5, 0xa1, 0x89, 0x8a, 0xf5, 0x43, 0x70, 0x87, 0xda, 0x1e,
+	0x31, 0xfe, 0xd7, 0xd4, 0xda, 0xd5, 0xee, 0x87, 0xb1, 0x80, 0x6a, 0x9c, 0x08, 0xf8, 0x48, 0xa5,
+	0x93, 0x03, 0xe5, 0xf9, 0x84, 0x79, 0x2e, 0x27, 0x9e, 0xcf, 0xcf, 0x65, 0xa6, 0xb5, 0x37, 0x70,
+	0xa4, 0x66, 0xea, 0x2f, 0x41, 0xd5, 0xc6, 0x38, 0x20, 0x61, 0x48, 0x42, 0x63, 0xae, 0x39, 0xd7,
+	0xae, 0x76, 0x3f, 0x8a, 0x05, 0x9c, 0xc2, 0x44, 0xc0, 0x87, 0xca, 0x3b, 0x23, 0x65, 0xe7, 0xfa,
+	0x0d, 0x8a, 0xa6, 0x53, 0xf5, 0x21, 0x58, 0x74, 0x98, 0xe7, 0xcb, 0x91, 0xcb, 0xa8, 0x71, 0xa7,
+	0xa9, 0xb5, 0x57, 0xb6, 0x1e, 0x74, 0xf2, 0x36, 0xee, 0x4c, 0x45, 0x95, 0xb5, 0x18, 0x9d, 0x08,
+	0xb8, 0xae, 0xf2, 0x16, 0x58, 0xda, 0xcb, 0xf8, 0xaa, 0xb5, 0x3a, 0x0b, 0x51, 0x71, 0xaa, 0x4e,
+	0x40, 0xd5, 0x21, 0x01, 0xb7, 0x54, 0xaf, 0xee, 0xaa, 0x5e, 0x3d, 0x93, 0xbf, 0x47, 0xc2, 0x83,
+	0xb4, 0x5f, 0x8f, 0x53, 0xef, 0x0c, 0xbc, 0xa1, 0x67, 0x0f, 0x6f, 0xd1, 0x50, 0xee, 0xa2, 0xbf,
+	0x00, 0xc0, 0xa5, 0x3c, 0x60, 0x38, 0x72, 0x48, 0x60, 0xcc, 0x37, 0xb5, 0xf6, 0x42, 0x77, 0x3b,
+	0x16, 0xb0, 0x40, 0x13, 0x01, 0x1f, 0xa4, 0x1b, 0x21, 0x47, 0x79, 0x11, 0xb5, 0x19, 0x86, 0x0a,
+	0xf3, 0xf4, 0xdf, 0x34, 0xb0, 0x11, 0x9e, 0xb8, 0xbe, 0x35, 0x61, 0x72, 0x07, 0x5b, 0x01, 0xf1,
+	0xd8, 0xd0, 0x1e, 0x84, 0xc6, 0x3d, 0x95, 0x0c, 0xc7, 0x02, 0x1a, 0x32, 0x6a, 0xaf, 0x10, 0x84,
+	0xb2, 0x98, 0x44, 0xc0, 0xff, 0xab, 0xd4, 0xb7, 0x05, 0xe4, 0x0b, 0x79, 0xfc, 0xd6, 0x08, 0x74,
+	0x6b, 0x06, 0xfd, 0x77, 0x0d, 0x2c, 0xe7, 0x6b, 0xc6, 0x56, 0xef, 0xdc, 0x58, 0x50, 0x87, 0xea,
+	0xa7, 0xff, 0x74, 0xa8, 0x62, 0x01, 0x97, 0xa6, 0xae, 0xdd, 0xf3, 0x44, 0xc0, 0x76, 0xb9, 0x87,
+	0xb8, 0x7b, 0x7e, 0xfb, 0xb1, 0xaa, 0xdf, 0x08, 0x93, 0x87, 0x4a, 0x1d, 0xa4, 0x92, 0xad, 0xbe,
+	0x05, 0xe6, 0x7d, 0x3b, 0x0a, 0x09, 0x36, 0xaa, 0xaa, 0x9b, 0x1b, 0xb1, 0x80, 0x19, 0x49, 0x04,
+	0x5c, 0x52, 0x29, 0xd3, 0xa1, 0x89, 0x32, 0xae, 0x7f, 0x0b, 0x56, 0xed, 0xc1, 0x80, 0x9d, 0x12,
+	0x6c, 0x51, 0xc2, 0x4f, 0x59, 0x70, 0x12, 0x1a, 0x40, 0x9d, 0x9a, 0x2f, 0x62, 0x01, 0x6b, 0x99,
+	0x76, 0x90, 0x49, 0xf9, 0x35, 0x50, 0xe6, 0xe5, 0x8d, 0x66, 0xdc, 0x26, 0xa2, 0x59, 0x3b, 0xfd,
+	0x6b, 0xb0, 0x66, 0x47, 0x9c, 0x59, 0xb6, 0xe3, 0x10, 0x9f, 0x5b, 0x47, 0x6c, 0x80, 0x49, 0x10,
+	0x1a, 0x8b, 0x6a, 0xf9, 0xef, 0xc5, 0x02, 0xd6, 0xa5, 0xfc, 0x89, 0x52, 0x3f, 0x4d, 0xc5, 0xe9,
+	0xf1, 0x9d, 0x55, 0x4c, 0x74, 0x33, 0x5a, 0x7f, 0x0e, 0x96, 0x3d, 0xfb, 0xcc, 0x0a, 0x09, 0xc5,
+	0xd6, 0x49, 0xcf, 0x0f, 0x8d, 0xa5, 0xa6, 0xd6, 0xbe, 0xdb, 0x7d, 0x57, 0x1e, 0x4e, 0xcf, 0x3e,
+	0xfb, 0x92, 0x50, 0xbc, 0xdf, 0xf3, 0xa5, 0x6b, 0x5d, 0xb9, 0x16, 0x98, 0xf9, 0xb7, 0x80, 0x73,
+	0x2e, 0xe5, 0xa8, 0x18, 0x38, 0x31, 0x0c, 0x88, 0x33, 0x4c, 0x0d, 0x97, 0x4b, 0x86, 0x88, 0x38,
+	0xc3, 0x59, 0xc3, 0x09, 0x2b, 0x19, 0x4e, 0xa0, 0x4e, 0x41, 0xcd, 0xed, 0x53, 0x16, 0x10, 0x9c,
+	0xd7, 0xbf, 0xd2, 0x9c, 0x6b, 0x2f, 0x6e, 0xad, 0x77, 0xd2, 0x87, 0xa1, 0xf3, 0x3c, 0x7b, 0x18,
+	0xd2, 0x9a, 0xba, 0x4f, 0xe5, 0x5e, 0x8c, 0x05, 0x5c, 0xc9, 0xa6, 0x4d, 0x1b, 0xb3, 0x96, 0xee,
+	0xaa, 0x22, 0x36, 0xd1, 0x4c, 0x98, 0xfe, 0x83, 0x06, 0x6a, 0x3e, 0xa1, 0xd8, 0xa5, 0xfd, 0x3c,
+	0x61, 0xed, 0xad, 0x09, 0x9f, 0xc9, 0x84, 0x63, 0x01, 0x8d, 0x5d, 0xe2, 0x07, 0xc4, 0xb1, 0x39,
+	0xc1, 0x87, 0xa9, 0x41, 0xe6, 0x19, 0x0b, 0xa8, 0x3d, 0xcd, 0xef, 0x20, 0xbf, 0xa8, 0x15, 0xb6,
+	0x86, 0xa1, 0xa1, 0x95, 0x92, 0x16, 0xea, 0x3f, 0x6b, 0xa0, 0x96, 0x76, 0xf3, 0x9b, 0x88, 0x84,
+	0xdc, 0x3a, 0x71, 0x7b, 0xc6, 0xaa, 0xea, 0x67, 0x38, 0x16, 0x70, 0xf9, 0x73, 0xd9, 0x26, 0xa5,
+	0xec, 0xbb, 0xdd, 0x58, 0xc0, 0x65, 0xaf, 0x08, 0xf2, 0x82, 0x4b, 0x74, 0xd2, 0xe4, 0xf8, 0xaa,
+	0x35, 0x13, 0x3e, 0x0b, 0x2e, 0x46, 0xad, 0x72, 0x06, 0x54, 0xd2, 0x7b, 0xfa, 0xc7, 0xa0, 0x1a,
+	0x51, 0x1e, 0x44, 0x21, 0x27, 0xd8, 0xa8, 0xab, 0x3d, 0xd9, 0x94, 0x4f, 0x49, 0x0e, 0x13, 0x01,
+	0x6b, 0x6a, 0x05, 0x39, 0x31, 0xd1, 0x54, 0x55, 0xd5, 0xc9, 0x0b, 0x8e, 0x13, 0xab, 0x1f, 0xb9,
+	0x96, 0xcf, 0x02, 0x6e, 0xe8, 0xd3, 0xea, 0x90, 0x92, 0x3e, 0xfb, 0x6a, 0xef, 0x90, 0x05, 0x5c,
+	0x56, 0x17, 0x14, 0x41, 0x5e, 0x5d, 0x89, 0x16, 0xab, 0x2b, 0x87, 0xcf, 0x02, 0x59, 0x5d, 0x29,
+	0x03, 0x9a, 0xe8, 0x91, 0x2b, 0x87, 0xfa, 0x77, 0x1a, 0xa8, 0xd1, 0xc8, 0xb3, 0x1c, 0x46, 0x29,
+	0x51, 0xd7, 0x60, 0x68, 0xac, 0xa9, 0xd5, 0xbd, 0x1c, 0x0b, 0x58, 0x47, 0xf6, 0xe9, 0x41, 0xe4,
+	0xed, 0x4c, 0x45, 0xb9, 0xe3, 0x68, 0x89, 0x24, 0x02, 0xde, 0x4f, 0x5f, 0xe9, 0x12, 0x9e, 0xac,
+	0xf1, 0x62, 0xd4, 0xba, 0xe9, 0x82, 0x66, 0x3c, 0xf4, 0x1d, 0xb0, 0xd8, 0x1b, 0x30, 0xe7, 0xc4,
+	0x72, 0x6c, 0xe7, 0x98, 0x18, 0xf7, 0x55, 0x9b, 0x4d, 0xf9, 0xe8, 0x28, 0xbc, 0x23, 0x69, 0x22,
+	0xe0, 0xaa, 0x4a, 0x34, 0x45, 0x26, 0x2a, 0xe8, 0xdd, 0xfd, 0xcb, 0xd7, 0x8d, 0xca, 0xe8, 0x75,
+	0xa3, 0x72, 0x39, 0x6e, 0x68, 0xa3, 0x71, 0x43, 0xfb, 0xf1, 0xba, 0x51, 0x79, 0x75, 0xdd, 0xd0,
+	0x46, 0xd7, 0x8d, 0xca, 0x9f, 0xd7, 0x8d, 0xca, 0x8b, 0x77, 0xfe, 0xc5, 0xc5, 0x9d, 0xee, 0xfe,
+	0xde, 0xbc, 0xba, 0xc0, 0xdf, 0xff, 0x67, 0x00, 0x10, 0xc3, 0x3d, 0x51, 0xe2, 0x09, 0x00, 0x00,
 }
 
 func (m *DeviceConfiguration) Marshal() (dAtA []byte, err error) {
@@ -179,6 +181,18 @@ func (m *DeviceConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BlockCache {
+		i--
+		if m.BlockCache {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
 	if m.RawNumConnections != 0 {
 		i = encodeVarintDeviceconfiguration(dAtA, i, uint64(m.RawNumConnections))
 		i--
@@ -436,6 +450,9 @@ func (m *DeviceConfiguration) ProtoSize() (n int) {
 	if m.RawNumConnections != 0 {
 		n += 2 + sovDeviceconfiguration(uint64(m.RawNumConnections))
 	}
+	if m.BlockCache {
+		n += 3
+	}
 	return n
 }
 
@@ -950,6 +967,26 @@ func (m *DeviceConfiguration) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockCache", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeviceconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BlockCache = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeviceconfiguration(dAtA[iNdEx:])
@@ -23,6 +23,7 @@ import (
 	"github.com/syncthing/syncthing/lib/db"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sliceutil"
 )
 
 var (
@@ -43,6 +44,8 @@ func (f FolderConfiguration) Copy() FolderConfiguration {
 	c.Devices = make([]FolderDeviceConfiguration, len(f.Devices))
 	copy(c.Devices, f.Devices)
 	c.Versioning = f.Versioning.Copy()
+	c.PullPriorities = make([]PullPriorityRule, len(f.PullPriorities))
+	copy(c.PullPriorities, f.PullPriorities)
 	return c
 }
 
@@ -52,13 +55,20 @@ func (f FolderConfiguration) Copy() FolderConfiguration {
 func (f FolderConfiguration) Filesystem(fset *db.FileSet) fs.Filesystem {
 	// This is intentionally not a pointer method, because things like
 	// cfg.Folders["default"].Filesystem(nil) should be valid.
-	opts := make([]fs.Option, 0, 3)
+	opts := make([]fs.Option, 0, 5)
 	if f.FilesystemType == fs.FilesystemTypeBasic && f.JunctionsAsDirs {
 		opts = append(opts, new(fs.OptionJunctionsAsDirs))
 	}
+	if f.FilesystemType == fs.FilesystemTypeBasic && f.FanotifyWatcher {
+		opts = append(opts, new(fs.OptionFanotifyWatcher))
+	}
 	if !f.CaseSensitiveFS {
 		opts = append(opts, new(fs.OptionDetectCaseConflicts))
 	}
+	if f.Walkers > 1 {
+		walkers := fs.OptionParallelWalk(f.Walkers)
+		opts = append(opts, &walkers)
+	}
 	if fset != nil {
 		opts = append(opts, fset.MtimeOption())
 	}
@@ -216,6 +226,15 @@ func (f *FolderConfiguration) prepare(myID protocol.DeviceID, existingDevices ma
 	f.Devices = ensureNoDuplicateFolderDevices(f.Devices)
 	f.Devices = ensureDevicePresent(f.Devices, myID)
 	f.Devices = ensureNoUntrustedTrustingSharing(f, f.Devices, existingDevices)
+	f.PullPriorities = ensureValidPullPriorities(f)
+
+	for i := range f.Devices {
+		// The previous password is only meaningful while rotating from it
+		// to a different, current one.
+		if dev := &f.Devices[i]; dev.EncryptionPassword == "" || dev.PreviousEncryptionPassword == dev.EncryptionPassword {
+			dev.PreviousEncryptionPassword = ""
+		}
+	}
 
 	sort.Slice(f.Devices, func(a, b int) bool {
 		return f.Devices[a].DeviceID.Compare(f.Devices[b].DeviceID) == -1
@@ -287,6 +306,13 @@ func (f *FolderConfiguration) Device(device protocol.DeviceID) (FolderDeviceConf
 	return FolderDeviceConfiguration{}, false
 }
 
+// DeviceAccess returns the access mode configured for the given device. It
+// is FolderDeviceAccessSendReceive for devices the folder isn't shared with.
+func (f *FolderConfiguration) DeviceAccess(device protocol.DeviceID) FolderDeviceAccess {
+	dev, _ := f.Device(device)
+	return dev.Access
+}
+
 func (f *FolderConfiguration) SharedWith(device protocol.DeviceID) bool {
 	_, ok := f.Device(device)
 	return ok
@@ -321,6 +347,70 @@ func (f XattrFilter) Permit(s string) bool {
 	return false
 }
 
+// PullPriority returns the priority of the named file according to the
+// pull priority rules, zero if no rule matches. Unless the folder is case
+// sensitive, case is ignored when matching.
+func (f FolderConfiguration) PullPriority(name string) int {
+	for _, rule := range f.PullPriorities {
+		if rule.Matches(name, !f.CaseSensitiveFS) {
+			return rule.Priority
+		}
+	}
+	return 0
+}
+
+// Matches returns true if the pattern matches the named file or any of its
+// parent directories, optionally ignoring case.
+func (r PullPriorityRule) Matches(name string, foldCase bool) bool {
+	pattern, anchored := r.nativePattern()
+	if pattern == "" {
+		return false
+	}
+	if foldCase {
+		pattern = strings.ToLower(pattern)
+		name = strings.ToLower(name)
+	}
+
+	if !anchored {
+		for _, part := range strings.Split(name, string(filepath.Separator)) {
+			if ok, _ := filepath.Match(pattern, part); ok {
+				return true
+			}
+		}
+		return false
+	}
+
+	for ; name != "." && name != string(filepath.Separator); name = filepath.Dir(name) {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// nativePattern returns the pattern with native separators and without
+// leading or trailing ones, and whether it's anchored at the folder root.
+func (r PullPriorityRule) nativePattern() (string, bool) {
+	pattern := filepath.FromSlash(r.Pattern)
+	anchored := strings.ContainsRune(pattern, filepath.Separator)
+	return strings.Trim(pattern, string(filepath.Separator)), anchored
+}
+
+// ensureValidPullPriorities removes the rules with malformed patterns,
+// which would never match.
+func ensureValidPullPriorities(f *FolderConfiguration) []PullPriorityRule {
+	rules := f.PullPriorities
+	for i := 0; i < len(rules); i++ {
+		pattern, _ := rules[i].nativePattern()
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			l.Warnf("Folder %s (%s) has an invalid pull priority pattern %q; removing.", f.ID, f.Label, rules[i].Pattern)
+			rules = sliceutil.RemoveAndZero(rules, i)
+			i--
+		}
+	}
+	return rules
+}
+
 func (f XattrFilter) GetMaxSingleEntrySize() int {
 	return f.MaxSingleEntrySize
 }
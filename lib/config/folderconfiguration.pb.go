@@ -9,6 +9,7 @@ import (
 	proto "github.com/gogo/protobuf/proto"
 	fs "github.com/syncthing/syncthing/lib/fs"
 	github_com_syncthing_syncthing_lib_protocol "github.com/syncthing/syncthing/lib/protocol"
+	protocol "github.com/syncthing/syncthing/lib/protocol"
 	_ "github.com/syncthing/syncthing/proto/ext"
 	io "io"
 	math "math"
@@ -27,9 +28,11 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type FolderDeviceConfiguration struct {
-	DeviceID           github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"deviceID" xml:"id,attr"`
-	IntroducedBy       github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,2,opt,name=introduced_by,json=introducedBy,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"introducedBy" xml:"introducedBy,attr"`
-	EncryptionPassword string                                               `protobuf:"bytes,3,opt,name=encryption_password,json=encryptionPassword,proto3" json:"encryptionPassword" xml:"encryptionPassword"`
+	DeviceID                   github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"deviceID" xml:"id,attr"`
+	IntroducedBy               github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,2,opt,name=introduced_by,json=introducedBy,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"introducedBy" xml:"introducedBy,attr"`
+	EncryptionPassword         string                                               `protobuf:"bytes,3,opt,name=encryption_password,json=encryptionPassword,proto3" json:"encryptionPassword" xml:"encryptionPassword"`
+	Access                     FolderDeviceAccess                                   `protobuf:"varint,4,opt,name=access,proto3,enum=config.FolderDeviceAccess" json:"access" xml:"access,attr"`
+	PreviousEncryptionPassword string                                               `protobuf:"bytes,5,opt,name=previous_encryption_password,json=previousEncryptionPassword,proto3" json:"previousEncryptionPassword" xml:"previousEncryptionPassword"`
 }
 
 func (m *FolderDeviceConfiguration) Reset()         { *m = FolderDeviceConfiguration{} }
@@ -106,6 +109,12 @@ type FolderConfiguration struct {
 	SyncXattrs              bool                        `protobuf:"varint,37,opt,name=sync_xattrs,json=syncXattrs,proto3" json:"syncXattrs" xml:"syncXattrs"`
 	SendXattrs              bool                        `protobuf:"varint,38,opt,name=send_xattrs,json=sendXattrs,proto3" json:"sendXattrs" xml:"sendXattrs"`
 	XattrFilter             XattrFilter                 `protobuf:"bytes,39,opt,name=xattr_filter,json=xattrFilter,proto3" json:"xattrFilter" xml:"xattrFilter"`
+	ScanCheckpoint          bool                        `protobuf:"varint,41,opt,name=scan_checkpoint,json=scanCheckpoint,proto3" json:"scanCheckpoint" xml:"scanCheckpoint"`
+	Walkers                 int                         `protobuf:"varint,42,opt,name=walkers,proto3,casttype=int" json:"walkers" xml:"walkers"`
+	BlockHashAlgorithm      protocol.HashAlgorithm      `protobuf:"varint,43,opt,name=block_hash_algorithm,json=blockHashAlgorithm,proto3,enum=protocol.HashAlgorithm" json:"blockHashAlgorithm" xml:"blockHashAlgorithm" default:"sha256"`
+	VersionLocalChanges     bool                        `protobuf:"varint,44,opt,name=version_local_changes,json=versionLocalChanges,proto3" json:"versionLocalChanges" xml:"versionLocalChanges"`
+	PullPriorities          []PullPriorityRule          `protobuf:"bytes,45,rep,name=pull_priorities,json=pullPriorities,proto3" json:"pullPriorities" xml:"pullPriority"`
+	FanotifyWatcher         bool                        `protobuf:"varint,46,opt,name=fanotify_watcher,json=fanotifyWatcher,proto3" json:"fanotifyWatcher" xml:"fanotifyWatcher"`
 	// Legacy deprecated
 	DeprecatedReadOnly       bool    `protobuf:"varint,9000,opt,name=read_only,json=readOnly,proto3" json:"-" xml:"ro,attr,omitempty"`                       // Deprecated: Do not use.
 	DeprecatedMinDiskFreePct float64 `protobuf:"fixed64,9001,opt,name=min_disk_free_pct,json=minDiskFreePct,proto3" json:"-" xml:"minDiskFreePct,omitempty"` // Deprecated: Do not use.
@@ -229,11 +238,58 @@ func (m *XattrFilterEntry) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_XattrFilterEntry proto.InternalMessageInfo
 
+// Pull priority rule. Files matching the pattern (glob style) are pulled
+// in the order of their priority, highest first, before the configured
+// pull order applies within each priority. The first matching rule is
+// used; files not matching any rule have priority zero. A pattern without
+// a slash matches any path component, e.g. "*.iso" or "Documents", while
+// a pattern with a slash matches from the folder root. Patterns matching a
+// directory apply to everything inside it. Case is ignored unless the
+// folder is case sensitive, and malformed patterns are removed.
+type PullPriorityRule struct {
+	Pattern  string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern" xml:"pattern,attr"`
+	Priority int    `protobuf:"varint,2,opt,name=priority,proto3,casttype=int" json:"priority" xml:"priority,attr"`
+}
+
+func (m *PullPriorityRule) Reset()         { *m = PullPriorityRule{} }
+func (m *PullPriorityRule) String() string { return proto.CompactTextString(m) }
+func (*PullPriorityRule) ProtoMessage()    {}
+func (*PullPriorityRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_44a9785876ed3afa, []int{4}
+}
+func (m *PullPriorityRule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PullPriorityRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PullPriorityRule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PullPriorityRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PullPriorityRule.Merge(m, src)
+}
+func (m *PullPriorityRule) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *PullPriorityRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_PullPriorityRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PullPriorityRule proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*FolderDeviceConfiguration)(nil), "config.FolderDeviceConfiguration")
 	proto.RegisterType((*FolderConfiguration)(nil), "config.FolderConfiguration")
 	proto.RegisterType((*XattrFilter)(nil), "config.XattrFilter")
 	proto.RegisterType((*XattrFilterEntry)(nil), "config.XattrFilterEntry")
+	proto.RegisterType((*PullPriorityRule)(nil), "config.PullPriorityRule")
 }
 
 func init() {
@@ -241,161 +297,185 @@ func init() {
 }
 
 var fileDescriptor_44a9785876ed3afa = []byte{
-	// 2460 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x4d, 0x6c, 0x24, 0x47,
-	0x15, 0x76, 0xdb, 0xfb, 0x63, 0x97, 0xd7, 0x5e, 0xbb, 0x6c, 0xef, 0x76, 0x9c, 0xc4, 0x35, 0xe9,
-	0xcc, 0x26, 0x93, 0x90, 0x78, 0x37, 0x4e, 0x14, 0x29, 0x11, 0x01, 0x32, 0x76, 0x46, 0x2c, 0x8b,
-	0xb3, 0x56, 0x8f, 0x21, 0x90, 0x20, 0x35, 0xed, 0xee, 0x9a, 0x99, 0x8e, 0x7b, 0xba, 0x87, 0xae,
-	0xf6, 0xda, 0xb3, 0x87, 0x28, 0xe4, 0x80, 0x90, 0xc8, 0x01, 0x99, 0x03, 0xe2, 0x80, 0x14, 0x09,
-	0x84, 0x20, 0x5c, 0x38, 0x73, 0xe1, 0xba, 0x17, 0x64, 0x9f, 0x10, 0xe2, 0x50, 0x52, 0xbc, 0xb7,
-	0x39, 0xf6, 0x71, 0x4f, 0xe8, 0xbd, 0xfe, 0x99, 0xea, 0x99, 0x89, 0x84, 0xc4, 0x6d, 0xea, 0xfb,
-	0x5e, 0xbd, 0xf7, 0x75, 0xd5, 0xab, 0x57, 0xaf, 0x86, 0x54, 0x7d, 0xef, 0xe0, 0xb6, 0x13, 0x06,
-	0x2d, 0xaf, 0x7d, 0xbb, 0x15, 0xfa, 0x2e, 0x8f, 0xd2, 0xc1, 0x51, 0x64, 0xc7, 0x5e, 0x18, 0x6c,
-	0xf6, 0xa2, 0x30, 0x0e, 0xe9, 0x95, 0x14, 0x5c, 0x7f, 0x7a, 0xcc, 0x3a, 0xee, 0xf7, 0x78, 0x6a,
-	0xb4, 0xbe, 0xa6, 0x90, 0xc2, 0x7b, 0x98, 0xc3, 0xeb, 0x0a, 0xdc, 0x3b, 0xf2, 0xfd, 0x30, 0x72,
-	0x79, 0x94, 0x71, 0x35, 0x85, 0x7b, 0xc0, 0x23, 0xe1, 0x85, 0x81, 0x17, 0xb4, 0x27, 0x28, 0x58,
-	0x67, 0x8a, 0xe5, 0x81, 0x1f, 0x3a, 0x87, 0xa3, 0xae, 0x28, 0x18, 0xb4, 0xc4, 0x6d, 0x10, 0x24,
-	0x32, 0xec, 0x99, 0x0c, 0x73, 0xc2, 0x5e, 0x3f, 0xb2, 0x83, 0x36, 0xef, 0xf2, 0xb8, 0x13, 0xba,
-	0x19, 0x3b, 0xc7, 0x4f, 0xe2, 0xf4, 0xa7, 0xf1, 0xaf, 0x19, 0xf2, 0x54, 0x03, 0xbf, 0x67, 0x87,
-	0x3f, 0xf0, 0x1c, 0xbe, 0xad, 0x2a, 0xa0, 0x5f, 0x6a, 0x64, 0xce, 0x45, 0xdc, 0xf2, 0x5c, 0x5d,
-	0xab, 0x68, 0xb5, 0x6b, 0xf5, 0xcf, 0xb5, 0x47, 0x92, 0x4d, 0xfd, 0x47, 0xb2, 0x37, 0xda, 0x5e,
-	0xdc, 0x39, 0x3a, 0xd8, 0x74, 0xc2, 0xee, 0x6d, 0xd1, 0x0f, 0x9c, 0xb8, 0xe3, 0x05, 0x6d, 0xe5,
-	0x17, 0x48, 0xc0, 0x20, 0x4e, 0xe8, 0x6f, 0xa6, 0xde, 0xef, 0xee, 0x5c, 0x48, 0x36, 0x9b, 0xff,
-	0x1e, 0x48, 0x36, 0xeb, 0x66, 0xbf, 0x13, 0xc9, 0x16, 0x4e, 0xba, 0xfe, 0xdb, 0x86, 0xe7, 0xbe,
-	0x62, 0xc7, 0x71, 0x64, 0x0c, 0xce, 0xaa, 0x57, 0xb3, 0xdf, 0xc9, 0x59, 0xb5, 0xb0, 0xfb, 0xe5,
-	0x79, 0x55, 0x3b, 0x3d, 0xaf, 0x16, 0x3e, 0xcc, 0x9c, 0x71, 0xe9, 0x9f, 0x34, 0xb2, 0xe0, 0x05,
-	0x71, 0x14, 0xba, 0x47, 0x0e, 0x77, 0xad, 0x83, 0xbe, 0x3e, 0x8d, 0x82, 0x3f, 0xfd, 0xbf, 0x04,
-	0x0f, 0x24, 0xbb, 0x36, 0xf4, 0x5a, 0xef, 0x27, 0x92, 0xdd, 0x4c, 0x85, 0x2a, 0x60, 0x21, 0x79,
-	0x79, 0x0c, 0x05, 0xc1, 0x66, 0xc9, 0x03, 0x75, 0xc8, 0x0a, 0x0f, 0x9c, 0xa8, 0xdf, 0x83, 0x35,
-	0xb6, 0x7a, 0xb6, 0x10, 0xc7, 0x61, 0xe4, 0xea, 0x33, 0x15, 0xad, 0x36, 0x57, 0xdf, 0x1a, 0x48,
-	0x46, 0x87, 0xf4, 0x5e, 0xc6, 0x26, 0x92, 0xe9, 0x18, 0x76, 0x9c, 0x32, 0xcc, 0x09, 0xf6, 0xc6,
-	0x3f, 0x6e, 0x91, 0x95, 0x74, 0x63, 0xcb, 0x5b, 0xda, 0x24, 0xd3, 0xd9, 0x56, 0xce, 0xd5, 0xb7,
-	0x2f, 0x24, 0x9b, 0xc6, 0x4f, 0x9c, 0xf6, 0x20, 0xc2, 0x46, 0x69, 0x07, 0x2a, 0x41, 0xe8, 0xf2,
-	0x96, 0x7d, 0xe4, 0xc7, 0x6f, 0x1b, 0x71, 0x74, 0xc4, 0xd5, 0x2d, 0x39, 0x3d, 0xaf, 0x4e, 0xdf,
-	0xdd, 0xf9, 0x02, 0xbe, 0x6d, 0xda, 0x73, 0xe9, 0x0f, 0xc8, 0x65, 0xdf, 0x3e, 0xe0, 0x3e, 0xae,
-	0xf8, 0x5c, 0xfd, 0xdb, 0x03, 0xc9, 0x52, 0x20, 0x91, 0xac, 0x82, 0x4e, 0x71, 0x94, 0xf9, 0x8d,
-	0xb8, 0x88, 0xed, 0x28, 0x7e, 0xdb, 0x68, 0xd9, 0xbe, 0x40, 0xb7, 0x64, 0x48, 0x7f, 0x7a, 0x5e,
-	0x9d, 0x32, 0xd3, 0xc9, 0xb4, 0x4d, 0xae, 0xb7, 0x3c, 0x9f, 0x8b, 0xbe, 0x88, 0x79, 0xd7, 0x82,
-	0xfc, 0xc6, 0x45, 0x5a, 0xdc, 0xa2, 0x9b, 0x2d, 0xb1, 0xd9, 0x28, 0xa8, 0xfd, 0x7e, 0x8f, 0xd7,
-	0x5f, 0x1e, 0x48, 0xb6, 0xd8, 0x2a, 0x61, 0x89, 0x64, 0xab, 0x18, 0xbd, 0x0c, 0x1b, 0xe6, 0x88,
-	0x1d, 0xdd, 0x25, 0x97, 0x7a, 0x76, 0xdc, 0xd1, 0x2f, 0xa1, 0xfc, 0xb7, 0x06, 0x92, 0xe1, 0x38,
-	0x91, 0xec, 0x69, 0x9c, 0x0f, 0x83, 0x4c, 0x7c, 0xb1, 0x24, 0x9f, 0x80, 0xf0, 0xb9, 0x82, 0x79,
-	0x72, 0x56, 0xd5, 0x3e, 0x31, 0x71, 0x1a, 0xdd, 0x23, 0x97, 0x50, 0xec, 0xe5, 0x4c, 0x6c, 0x7a,
-	0x7a, 0x37, 0xd3, 0xed, 0x40, 0xb1, 0x35, 0x08, 0x11, 0xa7, 0x12, 0xaf, 0x63, 0x08, 0x18, 0x14,
-	0x69, 0x34, 0x57, 0x8c, 0x4c, 0xb4, 0xa2, 0x3f, 0x21, 0x57, 0xd3, 0x3c, 0x17, 0xfa, 0x95, 0xca,
-	0x4c, 0x6d, 0x7e, 0xeb, 0xb9, 0xb2, 0xd3, 0x09, 0x87, 0xb7, 0xce, 0x20, 0xed, 0x07, 0x92, 0xe5,
-	0x33, 0x13, 0xc9, 0xae, 0x61, 0xa8, 0x74, 0x6c, 0x98, 0x39, 0x41, 0x7f, 0xa3, 0x91, 0xe5, 0x88,
-	0x0b, 0xc7, 0x0e, 0x2c, 0x2f, 0x88, 0x79, 0xf4, 0xc0, 0xf6, 0x2d, 0xa1, 0x5f, 0xad, 0x68, 0xb5,
-	0xcb, 0xf5, 0xf6, 0x40, 0xb2, 0xeb, 0x29, 0x79, 0x37, 0xe3, 0x9a, 0x89, 0x64, 0x2f, 0xa1, 0xa7,
-	0x11, 0x7c, 0x74, 0x89, 0x5e, 0x7f, 0xf3, 0xce, 0x1d, 0xe3, 0x89, 0x64, 0x33, 0x5e, 0x10, 0x0f,
-	0xce, 0xaa, 0xab, 0x93, 0xcc, 0x9f, 0x9c, 0x55, 0x2f, 0x81, 0x9d, 0x39, 0x1a, 0x84, 0xfe, 0x5d,
-	0x23, 0xb4, 0x25, 0xac, 0x63, 0x3b, 0x76, 0x3a, 0x3c, 0xb2, 0x78, 0x60, 0x1f, 0xf8, 0xdc, 0xd5,
-	0x67, 0x2b, 0x5a, 0x6d, 0xb6, 0xfe, 0x2b, 0xed, 0x42, 0xb2, 0xa5, 0x46, 0xf3, 0x83, 0x94, 0x7d,
-	0x2f, 0x25, 0x07, 0x92, 0x2d, 0xb5, 0x44, 0x19, 0x4b, 0x24, 0x7b, 0x39, 0x4d, 0x82, 0x11, 0x62,
-	0x54, 0x6d, 0x9e, 0xe3, 0x6b, 0x13, 0x0d, 0x41, 0x27, 0x58, 0x9c, 0x9e, 0x57, 0xc7, 0xc2, 0x9a,
-	0x63, 0x41, 0xe9, 0xdf, 0xca, 0xe2, 0x5d, 0xee, 0xdb, 0x7d, 0x4b, 0xe8, 0x73, 0x15, 0xad, 0xa6,
-	0xd5, 0x3f, 0x03, 0xf1, 0xd7, 0x0b, 0x2f, 0x3b, 0x40, 0x36, 0x61, 0x9d, 0x0b, 0x37, 0x29, 0x94,
-	0x48, 0xf6, 0x62, 0x59, 0x7a, 0x8a, 0x8f, 0x2a, 0x7f, 0xed, 0x0e, 0xe8, 0x5e, 0x9d, 0x64, 0xf5,
-	0xe4, 0xac, 0x3a, 0xfd, 0xda, 0x9d, 0xd3, 0xf3, 0xea, 0x68, 0x38, 0x73, 0x34, 0x18, 0x14, 0xfb,
-	0x55, 0x45, 0x72, 0xec, 0x75, 0x79, 0x78, 0x14, 0x5b, 0x42, 0xaf, 0xa1, 0xe8, 0xfe, 0x85, 0x64,
-	0xcb, 0x85, 0x93, 0xfd, 0x94, 0x05, 0xd5, 0xcb, 0x85, 0xa3, 0x1c, 0x4c, 0x24, 0x7b, 0xa6, 0xac,
-	0x3b, 0x67, 0x8a, 0x0c, 0xbf, 0x31, 0x99, 0x3a, 0x3d, 0xaf, 0x8e, 0xc7, 0x30, 0xc7, 0x23, 0xd0,
-	0x9f, 0x92, 0x6b, 0x5e, 0x3b, 0x08, 0x23, 0x6e, 0xf5, 0x78, 0xd4, 0x15, 0x3a, 0xc1, 0xac, 0x78,
-	0x67, 0x20, 0xd9, 0x7c, 0x8a, 0xef, 0x01, 0x9c, 0x48, 0x76, 0x23, 0xad, 0x69, 0x43, 0xac, 0x90,
-	0xb0, 0x34, 0x0a, 0x9a, 0xea, 0x54, 0xfa, 0x73, 0x8d, 0x2c, 0xda, 0x47, 0x71, 0x68, 0x05, 0x61,
-	0xd4, 0xb5, 0x7d, 0xef, 0x21, 0xd7, 0xe7, 0x31, 0xc8, 0x87, 0x03, 0xc9, 0x16, 0x80, 0x79, 0x3f,
-	0x27, 0x8a, 0x7d, 0x2a, 0xa1, 0x5f, 0x97, 0x5f, 0x74, 0xdc, 0x2a, 0x4f, 0x2e, 0xb3, 0xec, 0x97,
-	0x86, 0x64, 0xa1, 0xeb, 0x05, 0x96, 0xeb, 0x89, 0x43, 0xab, 0x15, 0x71, 0xae, 0x5f, 0xab, 0x68,
-	0xb5, 0xf9, 0xad, 0x6b, 0xf9, 0xe1, 0x6f, 0x7a, 0x0f, 0x79, 0xfd, 0x9d, 0xec, 0x9c, 0xcf, 0x77,
-	0xbd, 0x60, 0xc7, 0x13, 0x87, 0x8d, 0x88, 0x83, 0x22, 0x86, 0x8a, 0x14, 0x4c, 0x4d, 0x98, 0xca,
-	0x2d, 0xe3, 0xc9, 0x59, 0x75, 0xe6, 0xb5, 0xca, 0x2d, 0x53, 0x9d, 0x46, 0xdb, 0x84, 0x0c, 0xbb,
-	0x11, 0x7d, 0x01, 0xa3, 0xb1, 0x3c, 0xda, 0x0f, 0x0b, 0xa6, 0x5c, 0x68, 0x5e, 0xc8, 0x04, 0x28,
-	0x53, 0x13, 0xc9, 0x96, 0x30, 0xfe, 0x10, 0x32, 0x4c, 0x85, 0xa7, 0xef, 0x90, 0xab, 0x4e, 0xd8,
-	0xf3, 0x78, 0x24, 0xf4, 0x45, 0xac, 0x33, 0xcf, 0x43, 0xa5, 0xca, 0xa0, 0xa2, 0x19, 0xc8, 0xc6,
-	0x79, 0x0d, 0x31, 0x73, 0x03, 0xfa, 0x4f, 0x8d, 0xdc, 0x80, 0x3e, 0x88, 0x47, 0x56, 0xd7, 0x3e,
-	0xb1, 0x7a, 0x3c, 0x70, 0xbd, 0xa0, 0x6d, 0x1d, 0x7a, 0x07, 0xfa, 0x75, 0x74, 0xf7, 0x5b, 0x38,
-	0x62, 0x2b, 0x7b, 0x68, 0xb2, 0x6b, 0x9f, 0xec, 0xa5, 0x06, 0xf7, 0xbc, 0xfa, 0x40, 0xb2, 0x95,
-	0xde, 0x38, 0x9c, 0x48, 0xf6, 0x54, 0x5a, 0xea, 0xc7, 0x39, 0xa5, 0x84, 0x4d, 0x9c, 0x3a, 0x19,
-	0x3e, 0x3d, 0xaf, 0x4e, 0x8a, 0x6f, 0x4e, 0xb0, 0x3d, 0x80, 0xe5, 0xe8, 0xd8, 0xa2, 0x03, 0xcb,
-	0xb1, 0x34, 0x5c, 0x8e, 0x0c, 0x2a, 0x96, 0x23, 0x1b, 0x0f, 0x97, 0x23, 0x03, 0xe8, 0xbb, 0xe4,
-	0x32, 0x76, 0x84, 0xfa, 0x32, 0xde, 0x38, 0xcb, 0xf9, 0x8e, 0x41, 0xfc, 0xfb, 0x40, 0xd4, 0x75,
-	0xb8, 0x92, 0xd1, 0x26, 0x91, 0x6c, 0x1e, 0xbd, 0xe1, 0xc8, 0x30, 0x53, 0x94, 0xde, 0x23, 0x0b,
-	0xd9, 0x81, 0x72, 0xb9, 0xcf, 0x63, 0xae, 0x53, 0x4c, 0xf6, 0x17, 0xb0, 0xff, 0x41, 0x62, 0x07,
-	0xf1, 0x44, 0x32, 0xaa, 0x1c, 0xa9, 0x14, 0x34, 0xcc, 0x92, 0x0d, 0x3d, 0x21, 0x3a, 0xde, 0x26,
-	0xbd, 0x28, 0x6c, 0x47, 0x5c, 0x08, 0xf5, 0x5a, 0x59, 0xc1, 0xef, 0x83, 0x16, 0x61, 0x0d, 0x6c,
-	0xf6, 0x32, 0x13, 0xf5, 0x72, 0x49, 0x2f, 0xdd, 0x89, 0x6c, 0xf1, 0xed, 0x93, 0x27, 0xd3, 0x26,
-	0x59, 0xcc, 0xf2, 0xa2, 0x67, 0x1f, 0x09, 0x6e, 0x09, 0x7d, 0x15, 0xe3, 0xbd, 0x0a, 0xdf, 0x91,
-	0x32, 0x7b, 0x40, 0x34, 0x8b, 0xef, 0x50, 0xc1, 0xc2, 0x7b, 0xc9, 0x94, 0x72, 0xb2, 0x00, 0x59,
-	0x06, 0x8b, 0xea, 0x7b, 0x4e, 0x2c, 0xf4, 0x35, 0xf4, 0xf9, 0x1d, 0xf0, 0xd9, 0xb5, 0x4f, 0xb6,
-	0x73, 0x7c, 0x78, 0xea, 0x14, 0xb0, 0x5c, 0xa7, 0xb3, 0x00, 0x69, 0x59, 0x36, 0x4b, 0xb3, 0xa9,
-	0x4b, 0x56, 0x5d, 0x4f, 0xc0, 0xfd, 0x61, 0x89, 0x9e, 0x1d, 0x09, 0x6e, 0x61, 0x9b, 0xa2, 0xdf,
-	0xc0, 0x9d, 0xc0, 0xc6, 0x30, 0xe3, 0x9b, 0x48, 0x63, 0x03, 0x54, 0x34, 0x86, 0xe3, 0x94, 0x61,
-	0x4e, 0xb0, 0x57, 0xa3, 0xc4, 0xbc, 0xdb, 0xb3, 0xbc, 0xc0, 0xe5, 0x27, 0x5c, 0xe8, 0x37, 0xc7,
-	0xa2, 0xec, 0xf3, 0x6e, 0xef, 0x6e, 0xca, 0x8e, 0x46, 0x51, 0xa8, 0x61, 0x14, 0x05, 0xa4, 0x5b,
-	0xe4, 0x0a, 0x6e, 0x80, 0xab, 0xeb, 0xe8, 0x77, 0x7d, 0x20, 0x59, 0x86, 0x14, 0x7d, 0x48, 0x3a,
-	0x34, 0xcc, 0x0c, 0xa7, 0x31, 0xb9, 0x79, 0xcc, 0xed, 0x43, 0x0b, 0xb2, 0xda, 0x8a, 0x3b, 0x11,
-	0x17, 0x9d, 0xd0, 0x77, 0xad, 0x9e, 0x13, 0xeb, 0x4f, 0xe1, 0x82, 0x43, 0x79, 0x5f, 0x05, 0x93,
-	0xef, 0xda, 0xa2, 0xb3, 0x9f, 0x1b, 0xec, 0x39, 0x71, 0x22, 0xd9, 0x3a, 0xba, 0x9c, 0x44, 0x16,
-	0x9b, 0x3a, 0x71, 0x2a, 0xdd, 0x26, 0xf3, 0x5d, 0x3b, 0x3a, 0xe4, 0x91, 0x15, 0xd8, 0x5d, 0xae,
-	0xaf, 0x63, 0x0b, 0x68, 0x40, 0x39, 0x4b, 0xe1, 0xf7, 0xed, 0x2e, 0x2f, 0xca, 0xd9, 0x10, 0x32,
-	0x4c, 0x85, 0xa7, 0x7d, 0xb2, 0x0e, 0x4f, 0x2d, 0x2b, 0x3c, 0x0e, 0x78, 0x24, 0x3a, 0x5e, 0xcf,
-	0x6a, 0x45, 0x61, 0xd7, 0xea, 0xd9, 0x11, 0x0f, 0x62, 0xfd, 0x69, 0x5c, 0x82, 0x6f, 0x0e, 0x24,
-	0xbb, 0x09, 0x56, 0xf7, 0x73, 0xa3, 0x46, 0x14, 0x76, 0xf7, 0xd0, 0x24, 0x91, 0xec, 0xd9, 0xbc,
-	0xe2, 0x4d, 0xe2, 0x0d, 0xf3, 0xeb, 0x66, 0xd2, 0x5f, 0x68, 0x64, 0xb9, 0x1b, 0xba, 0x78, 0x5f,
-	0x5b, 0xc7, 0x5e, 0xe0, 0x86, 0xc7, 0x96, 0xd0, 0x9f, 0xc1, 0x05, 0xfb, 0x08, 0xee, 0x6c, 0xd3,
-	0x3e, 0xde, 0x0d, 0x5d, 0xb8, 0x39, 0x3f, 0x40, 0x16, 0xee, 0xec, 0xc5, 0x6e, 0x09, 0x29, 0x1a,
-	0xe5, 0x32, 0x9c, 0xaf, 0x1c, 0xdc, 0xca, 0x63, 0x5e, 0xcc, 0x11, 0x1f, 0xf4, 0x53, 0x8d, 0xac,
-	0x65, 0xc7, 0xc4, 0x39, 0x8a, 0x40, 0x9b, 0x75, 0x1c, 0x79, 0x31, 0x17, 0xfa, 0xb3, 0x28, 0xe6,
-	0xfb, 0x50, 0x7a, 0xd3, 0x84, 0xcf, 0xf8, 0x0f, 0x90, 0x4e, 0x24, 0xbb, 0xa5, 0x9c, 0x9a, 0x12,
-	0xa7, 0x1c, 0x9e, 0x2d, 0xe5, 0xec, 0x68, 0x5b, 0xe6, 0x24, 0x4f, 0x50, 0xc4, 0xf2, 0xdc, 0x6e,
-	0xc1, 0xbb, 0x4e, 0xdf, 0x18, 0x16, 0xb1, 0x8c, 0x68, 0x00, 0x5e, 0x1c, 0x7e, 0x15, 0x34, 0xcc,
-	0x92, 0x0d, 0xf5, 0xc9, 0x12, 0xbe, 0xb7, 0x2d, 0xa8, 0x05, 0x56, 0x5a, 0x5f, 0x19, 0xd6, 0xd7,
-	0x1b, 0x79, 0x7d, 0xad, 0x03, 0x3f, 0x2c, 0xb2, 0xf8, 0x04, 0x39, 0x28, 0x61, 0xc5, 0xca, 0x96,
-	0x61, 0xc3, 0x1c, 0xb1, 0xa3, 0x9f, 0x6b, 0x64, 0x19, 0x53, 0x08, 0x9f, 0xeb, 0x56, 0xfa, 0x5e,
-	0xd7, 0x2b, 0x18, 0x6f, 0x05, 0x9e, 0x3b, 0xdb, 0x61, 0xaf, 0x6f, 0x02, 0xb7, 0x8b, 0x54, 0xfd,
-	0x1e, 0x34, 0x8c, 0x4e, 0x19, 0x4c, 0x24, 0xab, 0x15, 0x69, 0xa4, 0xe0, 0xca, 0x32, 0x8a, 0xd8,
-	0x0e, 0x5c, 0x3b, 0x72, 0xe1, 0xfe, 0x9f, 0xcd, 0x07, 0xe6, 0xa8, 0x23, 0xfa, 0x47, 0x90, 0x63,
-	0x43, 0x01, 0xe5, 0x81, 0xf0, 0x62, 0xef, 0x01, 0xac, 0xa8, 0xfe, 0x1c, 0x2e, 0xe7, 0x09, 0x74,
-	0xaf, 0xdb, 0xb6, 0xe0, 0xcd, 0x9c, 0x6b, 0x60, 0xf7, 0xea, 0x94, 0xa1, 0x44, 0xb2, 0xb5, 0x54,
-	0x4c, 0x19, 0x87, 0x1e, 0x68, 0xcc, 0x76, 0x1c, 0x82, 0x9e, 0x75, 0x24, 0x88, 0x39, 0x62, 0x23,
-	0xe8, 0x1f, 0x34, 0xb2, 0xd4, 0x0a, 0x7d, 0x3f, 0x3c, 0xb6, 0x3e, 0x3e, 0x0a, 0x1c, 0x68, 0x47,
-	0x84, 0x6e, 0x0c, 0x55, 0x7e, 0x2f, 0x07, 0xdf, 0x15, 0x3b, 0x5e, 0x24, 0x40, 0xe5, 0xc7, 0x65,
-	0xa8, 0x50, 0x39, 0x82, 0xa3, 0xca, 0x51, 0xdb, 0x71, 0x08, 0x54, 0x8e, 0x04, 0x31, 0xaf, 0xa7,
-	0x8a, 0x0a, 0x98, 0xde, 0x27, 0x8b, 0x90, 0x51, 0xc3, 0xea, 0xa0, 0x3f, 0x8f, 0x12, 0xe1, 0x15,
-	0xb8, 0x00, 0x4c, 0x71, 0xae, 0x13, 0xc9, 0x56, 0xd2, 0xcb, 0x4f, 0x45, 0x0d, 0xb3, 0x6c, 0x85,
-	0x0e, 0x79, 0xe0, 0x2a, 0x0e, 0xab, 0x8a, 0x43, 0x1e, 0xb8, 0x13, 0x1c, 0xaa, 0x28, 0x38, 0x54,
-	0xc7, 0x50, 0x04, 0x51, 0xe1, 0x09, 0x74, 0xa3, 0x42, 0xbf, 0x85, 0xde, 0xb0, 0x08, 0x02, 0xfc,
-	0x23, 0x44, 0x8b, 0x22, 0x38, 0x84, 0x0c, 0x53, 0xe1, 0xd1, 0x09, 0xa8, 0xca, 0x9c, 0xbc, 0xa0,
-	0x38, 0xe1, 0x81, 0x3b, 0xea, 0xa4, 0x80, 0xc0, 0x49, 0x31, 0x80, 0xc6, 0x1e, 0xe7, 0xc3, 0xdd,
-	0x17, 0xf3, 0x48, 0x7f, 0x11, 0x7b, 0xd0, 0x95, 0xfc, 0xc4, 0xa1, 0x55, 0x03, 0xa9, 0x7a, 0x2d,
-	0x6f, 0x7c, 0x4f, 0x86, 0x60, 0x22, 0xd9, 0x32, 0xfa, 0x57, 0x30, 0xc3, 0x54, 0x2d, 0xe8, 0x21,
-	0x99, 0x8b, 0xb8, 0xed, 0x5a, 0x61, 0xe0, 0xf7, 0xf5, 0x3f, 0x37, 0x50, 0xe5, 0xee, 0x85, 0x64,
-	0x74, 0x87, 0xf7, 0x22, 0xee, 0xd8, 0x31, 0x77, 0x4d, 0x6e, 0xbb, 0xf7, 0x03, 0xbf, 0x3f, 0x90,
-	0x4c, 0x7b, 0xb5, 0xf8, 0xc7, 0x27, 0x0a, 0xb1, 0x59, 0x7f, 0x25, 0xec, 0x7a, 0x70, 0x73, 0xc6,
-	0x7d, 0xfc, 0xc7, 0x67, 0x0c, 0xd5, 0x35, 0x73, 0x36, 0xca, 0x1c, 0xd0, 0x9f, 0x91, 0xe5, 0x52,
-	0x07, 0x8f, 0xb7, 0xd9, 0x5f, 0x1a, 0xf8, 0xa2, 0x7a, 0xef, 0x42, 0x32, 0x7d, 0x18, 0x74, 0x77,
-	0xd8, 0x87, 0xef, 0x39, 0x71, 0x1e, 0x7a, 0x63, 0xb4, 0x8d, 0xdf, 0x73, 0x62, 0x45, 0x81, 0xae,
-	0x99, 0x8b, 0x65, 0x92, 0xfe, 0x98, 0x5c, 0x4d, 0xbb, 0x17, 0xa1, 0x7f, 0xd9, 0xc0, 0xca, 0xfb,
-	0x2d, 0xb8, 0x06, 0x86, 0x81, 0xd2, 0xae, 0x54, 0x94, 0x3f, 0x2e, 0x9b, 0xa2, 0xb8, 0xce, 0xca,
-	0xad, 0xae, 0x99, 0xb9, 0x3f, 0x7a, 0x48, 0x16, 0xb1, 0xaf, 0x1b, 0xe6, 0xdd, 0x5f, 0xd3, 0xf5,
-	0xdb, 0xbe, 0x90, 0xec, 0xe6, 0x30, 0x42, 0xd3, 0xb1, 0x83, 0x22, 0xb9, 0xf2, 0x38, 0xcf, 0x16,
-	0x5d, 0x5d, 0x41, 0x95, 0x3f, 0x64, 0xa1, 0xc4, 0x19, 0x9f, 0xcd, 0x90, 0x79, 0x65, 0xbb, 0xe9,
-	0x47, 0xe4, 0x2a, 0x0f, 0xe2, 0xc8, 0xe3, 0x42, 0xd7, 0xf0, 0x3f, 0x10, 0x7d, 0x42, 0x52, 0xbc,
-	0x17, 0xc4, 0x51, 0xbf, 0xfe, 0x62, 0xfe, 0xd7, 0x47, 0x36, 0xa1, 0xe8, 0x79, 0x61, 0x8c, 0xdb,
-	0x76, 0x19, 0x7f, 0x99, 0xb9, 0x01, 0xfd, 0x5d, 0x76, 0x79, 0x09, 0x2f, 0x68, 0xfb, 0xdc, 0x42,
-	0xd6, 0x12, 0xf0, 0xe8, 0x9b, 0xc6, 0x25, 0x6c, 0x41, 0x5f, 0xd4, 0xb5, 0x4f, 0x9a, 0xc8, 0x63,
-	0x94, 0xa6, 0xfa, 0xf2, 0x1b, 0xa7, 0x4a, 0x7d, 0xdf, 0xd6, 0x1b, 0xca, 0x23, 0x62, 0x82, 0x1f,
-	0x78, 0x00, 0x82, 0x95, 0x39, 0x81, 0xa3, 0x0f, 0xc9, 0x22, 0x48, 0x8b, 0xc3, 0x18, 0x1a, 0x68,
-	0xd0, 0x34, 0x83, 0x9a, 0xf6, 0xb3, 0xfe, 0x73, 0x1f, 0x88, 0x4c, 0xcd, 0x73, 0xb9, 0x9a, 0x02,
-	0x54, 0x74, 0xbc, 0x71, 0xe7, 0xad, 0x37, 0x15, 0x1d, 0xa5, 0xb9, 0xa0, 0x00, 0x78, 0xb3, 0x84,
-	0x1a, 0xbf, 0xd7, 0xc8, 0xd2, 0xe8, 0xf2, 0xc2, 0x73, 0xa3, 0x0b, 0xef, 0xf1, 0xec, 0x6f, 0xc4,
-	0x6f, 0xc0, 0xdb, 0x02, 0x01, 0xa5, 0x4f, 0x8a, 0x9d, 0x4e, 0xf1, 0xd2, 0x26, 0xc3, 0xa1, 0x99,
-	0x1a, 0xd2, 0x06, 0xb9, 0x02, 0x0f, 0x77, 0x2f, 0xc6, 0xf5, 0x9d, 0xad, 0x6f, 0x62, 0x7f, 0x88,
-	0x48, 0x71, 0x84, 0xd3, 0x61, 0xe1, 0x65, 0x5e, 0x19, 0x9b, 0x99, 0x6d, 0xfd, 0xde, 0xa3, 0xaf,
-	0x36, 0xa6, 0xce, 0xbf, 0xda, 0x98, 0x7a, 0x74, 0xb1, 0xa1, 0x9d, 0x5f, 0x6c, 0x68, 0xbf, 0x7e,
-	0xbc, 0x31, 0xf5, 0xc5, 0xe3, 0x0d, 0xed, 0xfc, 0xf1, 0xc6, 0xd4, 0xbf, 0x1f, 0x6f, 0x4c, 0x7d,
-	0xf8, 0xd2, 0xff, 0xf0, 0xaf, 0x6f, 0x9a, 0x47, 0x07, 0x57, 0xf0, 0xdf, 0xdf, 0xd7, 0xff, 0x1b,
-	0x00, 0x00, 0xff, 0xff, 0x68, 0x19, 0x36, 0x63, 0x1b, 0x18, 0x00, 0x00,
+	// 2833 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x59, 0x4d, 0x6c, 0xdc, 0xc6,
+	0x15, 0x36, 0x6d, 0xcb, 0x96, 0x46, 0xff, 0x23, 0xd9, 0x66, 0x14, 0x47, 0x54, 0x98, 0x75, 0xa2,
+	0x38, 0x8e, 0x6c, 0x2b, 0x6e, 0x80, 0xa4, 0x49, 0x5b, 0xaf, 0x64, 0xa1, 0xae, 0xe3, 0x58, 0x18,
+	0xb9, 0x75, 0x9b, 0x14, 0x60, 0x29, 0x72, 0x56, 0xcb, 0x88, 0x4b, 0xb2, 0x1c, 0xca, 0xd2, 0xfa,
+	0x90, 0xa6, 0x41, 0x51, 0x14, 0x68, 0x0e, 0x85, 0x7b, 0x68, 0x7b, 0x28, 0x10, 0xa0, 0x45, 0xd1,
+	0xa6, 0x97, 0xa2, 0xa7, 0xa2, 0xb7, 0xde, 0x72, 0x29, 0xa4, 0x63, 0xd1, 0x03, 0x81, 0xc8, 0xb7,
+	0x3d, 0xee, 0xd1, 0xa7, 0xe2, 0xbd, 0xe1, 0xcf, 0x90, 0xbb, 0x29, 0x0a, 0xf4, 0xb6, 0xf3, 0x7d,
+	0x6f, 0xde, 0x7b, 0x33, 0x7c, 0xef, 0xcd, 0x9b, 0x59, 0xd2, 0xf0, 0xbd, 0xed, 0xab, 0x4e, 0x18,
+	0xb4, 0xbc, 0x9d, 0xab, 0xad, 0xd0, 0x77, 0x79, 0x2c, 0x07, 0x7b, 0xb1, 0x9d, 0x78, 0x61, 0xb0,
+	0x12, 0xc5, 0x61, 0x12, 0xd2, 0x33, 0x12, 0x5c, 0x78, 0x76, 0x40, 0x3a, 0xe9, 0x46, 0x5c, 0x0a,
+	0x2d, 0x9c, 0x53, 0x48, 0xe1, 0x3d, 0xca, 0xe1, 0x05, 0x05, 0x8e, 0xf6, 0x7c, 0x3f, 0x8c, 0x5d,
+	0x1e, 0x67, 0xdc, 0xb2, 0xc2, 0x3d, 0xe4, 0xb1, 0xf0, 0xc2, 0xc0, 0x0b, 0x76, 0x86, 0x78, 0xb0,
+	0x60, 0x28, 0x92, 0xdb, 0x7e, 0xe8, 0xec, 0xd6, 0x55, 0xbd, 0x30, 0xe0, 0x9a, 0xcb, 0x1f, 0x7a,
+	0x0e, 0xb7, 0x1d, 0x87, 0x0b, 0x91, 0x09, 0x51, 0x10, 0x6a, 0x89, 0xab, 0xe0, 0x75, 0x8e, 0x5d,
+	0xcc, 0x30, 0x27, 0x8c, 0xba, 0xb1, 0x1d, 0xec, 0xf0, 0x0e, 0x4f, 0xda, 0xa1, 0x9b, 0xb1, 0xe7,
+	0x81, 0xc5, 0x9f, 0x4e, 0xe8, 0x5f, 0xdd, 0xe6, 0x51, 0x86, 0x8f, 0xf1, 0x83, 0x44, 0xfe, 0x34,
+	0xff, 0x31, 0x42, 0x9e, 0xd9, 0x40, 0x8b, 0xeb, 0x68, 0x71, 0x4d, 0x75, 0x9f, 0x7e, 0xa6, 0x91,
+	0x31, 0xe9, 0x89, 0xe5, 0xb9, 0xba, 0xb6, 0xa4, 0x2d, 0x4f, 0x34, 0x3f, 0xd1, 0x3e, 0x4f, 0x8d,
+	0x13, 0xff, 0x4e, 0x8d, 0x1b, 0x3b, 0x5e, 0xd2, 0xde, 0xdb, 0x5e, 0x71, 0xc2, 0xce, 0x55, 0xd1,
+	0x0d, 0x9c, 0xa4, 0xed, 0x05, 0x3b, 0xca, 0x2f, 0xd5, 0xf8, 0x8a, 0xd4, 0x7e, 0x7b, 0xfd, 0x38,
+	0x35, 0x46, 0xf3, 0xdf, 0xbd, 0xd4, 0x18, 0x75, 0xb3, 0xdf, 0xfd, 0xd4, 0x98, 0x3c, 0xe8, 0xf8,
+	0x6f, 0x9a, 0x9e, 0x7b, 0xc5, 0x4e, 0x92, 0xd8, 0xec, 0x1d, 0x36, 0xce, 0x66, 0xbf, 0xfb, 0x87,
+	0x8d, 0x42, 0xee, 0x67, 0x47, 0x0d, 0xed, 0xf1, 0x51, 0xa3, 0xd0, 0xc1, 0x72, 0xc6, 0xa5, 0x7f,
+	0xd0, 0xc8, 0xa4, 0x17, 0x24, 0x71, 0xe8, 0xee, 0x39, 0xdc, 0xb5, 0xb6, 0xbb, 0xfa, 0x49, 0x74,
+	0xf8, 0xa3, 0xff, 0xcb, 0xe1, 0x5e, 0x6a, 0x4c, 0x94, 0x5a, 0x9b, 0xdd, 0x7e, 0x6a, 0x5c, 0x90,
+	0x8e, 0x2a, 0x60, 0xe1, 0xf2, 0xec, 0x00, 0x0a, 0x0e, 0xb3, 0x8a, 0x06, 0xea, 0x90, 0x39, 0x1e,
+	0x38, 0x71, 0x37, 0x82, 0x3d, 0xb6, 0x22, 0x5b, 0x88, 0xfd, 0x30, 0x76, 0xf5, 0x53, 0x4b, 0xda,
+	0xf2, 0x58, 0x73, 0xb5, 0x97, 0x1a, 0xb4, 0xa4, 0x37, 0x33, 0xb6, 0x9f, 0x1a, 0x3a, 0x9a, 0x1d,
+	0xa4, 0x4c, 0x36, 0x44, 0x9e, 0x6e, 0x93, 0x33, 0x32, 0x7a, 0xf4, 0xd3, 0x4b, 0xda, 0xf2, 0xd4,
+	0xea, 0xc2, 0x8a, 0x8c, 0xaf, 0x15, 0xf5, 0x6b, 0xdf, 0x44, 0x89, 0xe6, 0x4a, 0x2f, 0x35, 0x32,
+	0xe9, 0x7e, 0x6a, 0xcc, 0xa2, 0x1d, 0x39, 0x2c, 0x16, 0x36, 0xae, 0x8c, 0x59, 0x26, 0x4b, 0x7f,
+	0xa2, 0x91, 0x8b, 0x51, 0xcc, 0x1f, 0x7a, 0xe1, 0x9e, 0xb0, 0x86, 0x2d, 0x69, 0x04, 0x97, 0xd4,
+	0xec, 0xa5, 0xc6, 0x42, 0x2e, 0x77, 0x6b, 0xd8, 0xd2, 0x96, 0xd0, 0xe4, 0x97, 0x8b, 0x98, 0xec,
+	0xbf, 0xcc, 0x37, 0xff, 0x7a, 0x99, 0xcc, 0xc9, 0x55, 0x55, 0xa3, 0x77, 0x8b, 0x9c, 0xcc, 0xa2,
+	0x76, 0xac, 0xb9, 0x76, 0x9c, 0x1a, 0x27, 0xf1, 0x6b, 0x9e, 0xf4, 0xc0, 0xe2, 0x62, 0x25, 0xd8,
+	0x96, 0x82, 0xd0, 0xe5, 0x2d, 0x7b, 0xcf, 0x4f, 0xde, 0x34, 0x93, 0x78, 0x8f, 0xab, 0xd1, 0xf7,
+	0xf8, 0xa8, 0x71, 0xf2, 0xf6, 0xfa, 0xa7, 0xf0, 0x19, 0x4f, 0x7a, 0x2e, 0xfd, 0x36, 0x19, 0xf1,
+	0xed, 0x6d, 0xee, 0x63, 0x70, 0x8d, 0x35, 0xbf, 0xde, 0x4b, 0x0d, 0x09, 0x14, 0xcb, 0xc0, 0x51,
+	0xa6, 0x37, 0xe6, 0x22, 0xb1, 0xe3, 0xe4, 0x4d, 0xb3, 0x65, 0xfb, 0x02, 0xd5, 0x92, 0x92, 0xfe,
+	0xe8, 0xa8, 0x71, 0x82, 0xc9, 0xc9, 0x74, 0x87, 0x4c, 0xb7, 0x3c, 0x9f, 0x8b, 0xae, 0x48, 0x78,
+	0xc7, 0x82, 0x14, 0xc7, 0x78, 0x98, 0x5a, 0xa5, 0x2b, 0x2d, 0xb1, 0xb2, 0x51, 0x50, 0xf7, 0xbb,
+	0x11, 0x6f, 0x5e, 0xee, 0xa5, 0xc6, 0x54, 0xab, 0x82, 0xf5, 0x53, 0x63, 0x1e, 0xad, 0x57, 0x61,
+	0x93, 0xd5, 0xe4, 0xe8, 0x5d, 0x72, 0x3a, 0xb2, 0x93, 0x36, 0x46, 0xc5, 0x58, 0xf3, 0x8d, 0x5e,
+	0x6a, 0xe0, 0xb8, 0x9f, 0x1a, 0xcf, 0xca, 0x8f, 0x60, 0x27, 0xed, 0xcc, 0xf9, 0x62, 0x4b, 0x3e,
+	0x04, 0xc7, 0xc7, 0x0a, 0xe6, 0xe9, 0x61, 0x43, 0xfb, 0x90, 0xe1, 0x34, 0xba, 0x49, 0x4e, 0xa3,
+	0xb3, 0x23, 0x99, 0xb3, 0x95, 0x20, 0x43, 0x67, 0x97, 0xc1, 0x44, 0x22, 0x5d, 0x9c, 0x46, 0x13,
+	0x30, 0x28, 0x02, 0x6b, 0xac, 0x18, 0x31, 0x94, 0xa2, 0xdf, 0x27, 0x67, 0x65, 0x4a, 0x0b, 0xfd,
+	0xcc, 0xd2, 0xa9, 0xe5, 0xf1, 0xd5, 0xe7, 0x87, 0x45, 0x6e, 0xe5, 0x4b, 0x37, 0x0d, 0xc8, 0xf0,
+	0x5e, 0x6a, 0xe4, 0x33, 0xfb, 0xa9, 0x31, 0x81, 0xa6, 0xe4, 0xd8, 0x64, 0x39, 0x41, 0x7f, 0xa9,
+	0x91, 0xd9, 0x98, 0x0b, 0xc7, 0x0e, 0x2c, 0x2f, 0x48, 0x78, 0xfc, 0xd0, 0xf6, 0x2d, 0xa1, 0x9f,
+	0x5d, 0xd2, 0x96, 0x47, 0x9a, 0x3b, 0xbd, 0xd4, 0x98, 0x96, 0xe4, 0xed, 0x8c, 0xdb, 0xea, 0xa7,
+	0xc6, 0xcb, 0xa8, 0xa9, 0x86, 0xd7, 0xb7, 0xe8, 0xb5, 0xd7, 0xaf, 0x5d, 0x33, 0x9f, 0xa6, 0xc6,
+	0x29, 0x2f, 0x48, 0x7a, 0x87, 0x8d, 0xf9, 0x61, 0xe2, 0x4f, 0x0f, 0x1b, 0xa7, 0x41, 0x8e, 0xd5,
+	0x8d, 0xd0, 0xbf, 0x6b, 0x84, 0xb6, 0x84, 0xb5, 0x6f, 0x27, 0x4e, 0x9b, 0xc7, 0x16, 0x0f, 0xec,
+	0x6d, 0x9f, 0xbb, 0xfa, 0xe8, 0x92, 0xb6, 0x3c, 0xda, 0xfc, 0xb9, 0x76, 0x9c, 0x1a, 0x33, 0x1b,
+	0x5b, 0x0f, 0x24, 0x7b, 0x4b, 0x92, 0xbd, 0xd4, 0x98, 0x69, 0x89, 0x2a, 0xd6, 0x4f, 0x8d, 0xcb,
+	0x32, 0x08, 0x6a, 0x44, 0xdd, 0xdb, 0x3c, 0xc6, 0xcf, 0x0d, 0x15, 0x04, 0x3f, 0x41, 0xe2, 0xf1,
+	0x51, 0x63, 0xc0, 0x2c, 0x1b, 0x30, 0x4a, 0xff, 0x52, 0x75, 0xde, 0xe5, 0xbe, 0xdd, 0xb5, 0x84,
+	0x3e, 0xb6, 0xa4, 0x2d, 0x6b, 0xcd, 0x8f, 0xc1, 0xf9, 0xe9, 0x42, 0xcb, 0x3a, 0x90, 0x5b, 0xb0,
+	0xcf, 0x2d, 0x51, 0x81, 0xfa, 0xa9, 0xf1, 0x52, 0xd5, 0x75, 0x89, 0xd7, 0x3d, 0xbf, 0x7e, 0x0d,
+	0xfc, 0x9e, 0x1f, 0x26, 0xf5, 0xf4, 0xb0, 0x71, 0xf2, 0xfa, 0xb5, 0xc7, 0x47, 0x8d, 0xba, 0x39,
+	0x56, 0x37, 0x06, 0xe7, 0xda, 0xbc, 0xe2, 0x72, 0xe2, 0x75, 0x78, 0xb8, 0x97, 0x58, 0x42, 0x5f,
+	0x46, 0xa7, 0xbb, 0xc7, 0xa9, 0x31, 0x5b, 0x28, 0xb9, 0x2f, 0x59, 0xf0, 0x7a, 0xb6, 0x25, 0x6a,
+	0x60, 0x3f, 0x35, 0x2e, 0x56, 0xfd, 0xce, 0x99, 0x22, 0xc2, 0xcf, 0x0f, 0xa7, 0x1e, 0x1f, 0x35,
+	0x06, 0x6d, 0xb0, 0x41, 0x0b, 0xf4, 0x07, 0x64, 0xc2, 0xdb, 0x09, 0xc2, 0x98, 0x5b, 0x11, 0x8f,
+	0x3b, 0x42, 0x27, 0x18, 0x15, 0x6f, 0xf7, 0x52, 0x63, 0x5c, 0xe2, 0x9b, 0x00, 0xf7, 0x53, 0xe3,
+	0xbc, 0xac, 0x69, 0x25, 0x56, 0xb8, 0x30, 0x53, 0x07, 0x99, 0x3a, 0x95, 0xfe, 0x58, 0x23, 0x53,
+	0xf6, 0x5e, 0x12, 0x5a, 0x41, 0x18, 0x77, 0x6c, 0xdf, 0x7b, 0xc4, 0xf5, 0x71, 0x34, 0xf2, 0x5e,
+	0x2f, 0x35, 0x26, 0x81, 0x79, 0x37, 0x27, 0x8a, 0xef, 0x54, 0x41, 0xbf, 0x2c, 0xbe, 0xe8, 0xa0,
+	0x54, 0x1e, 0x5c, 0xac, 0xaa, 0x97, 0x86, 0x64, 0xb2, 0xe3, 0x05, 0x96, 0xeb, 0x89, 0x5d, 0xab,
+	0x15, 0x73, 0xae, 0x4f, 0x2c, 0x69, 0xcb, 0xe3, 0xab, 0x13, 0x79, 0xf2, 0x6f, 0x79, 0x8f, 0x78,
+	0xf3, 0xed, 0x2c, 0xcf, 0xc7, 0x3b, 0x5e, 0xb0, 0xee, 0x89, 0xdd, 0x8d, 0x98, 0x83, 0x47, 0x06,
+	0x7a, 0xa4, 0x60, 0x6a, 0xc0, 0x2c, 0x5d, 0x32, 0x9f, 0x1e, 0x36, 0x4e, 0x5d, 0x5f, 0xba, 0xc4,
+	0xd4, 0x69, 0x74, 0x87, 0x90, 0xb2, 0x6b, 0xd3, 0x27, 0xd1, 0x9a, 0x91, 0x5b, 0xfb, 0x4e, 0xc1,
+	0x54, 0x0b, 0xcd, 0x8b, 0x99, 0x03, 0xca, 0xd4, 0x7e, 0x6a, 0xcc, 0xa0, 0xfd, 0x12, 0x32, 0x99,
+	0xc2, 0xd3, 0xb7, 0xc9, 0x59, 0x27, 0x8c, 0x3c, 0x1e, 0x0b, 0x7d, 0x0a, 0xeb, 0xcc, 0x0b, 0x50,
+	0xa9, 0x32, 0xa8, 0xe8, 0x7b, 0xb2, 0x71, 0x5e, 0x43, 0x58, 0x2e, 0x40, 0xff, 0xa9, 0x91, 0xf3,
+	0xd0, 0x2f, 0xf2, 0xd8, 0xea, 0xd8, 0x07, 0x56, 0xc4, 0x03, 0xd7, 0x0b, 0x76, 0xac, 0x5d, 0x6f,
+	0x5b, 0x9f, 0x46, 0x75, 0xbf, 0x82, 0x14, 0x9b, 0xdb, 0x44, 0x91, 0xbb, 0xf6, 0xc1, 0xa6, 0x14,
+	0xb8, 0xe3, 0xc1, 0xb1, 0x3b, 0x17, 0x0d, 0xc2, 0xfd, 0xd4, 0x78, 0x46, 0x96, 0xfa, 0x41, 0x4e,
+	0x29, 0x61, 0x43, 0xa7, 0x0e, 0x87, 0x1f, 0x1f, 0x35, 0x86, 0xd9, 0x67, 0x43, 0x64, 0xb7, 0x61,
+	0x3b, 0xda, 0xb6, 0x68, 0xc3, 0x76, 0xcc, 0x94, 0xdb, 0x91, 0x41, 0xc5, 0x76, 0x64, 0xe3, 0x72,
+	0x3b, 0x32, 0x80, 0xde, 0x24, 0x23, 0xd8, 0x39, 0xeb, 0xb3, 0x78, 0xe2, 0xcc, 0xe6, 0x5f, 0x0c,
+	0xec, 0xdf, 0x03, 0xa2, 0xa9, 0xc3, 0x91, 0x8c, 0x32, 0xfd, 0xd4, 0x18, 0x47, 0x6d, 0x38, 0x32,
+	0x99, 0x44, 0xe9, 0x1d, 0x32, 0x99, 0x25, 0x94, 0xcb, 0x7d, 0x9e, 0x70, 0x9d, 0x62, 0xb0, 0xbf,
+	0x88, 0xad, 0x1e, 0x12, 0xeb, 0x88, 0xf7, 0x53, 0x83, 0x2a, 0x29, 0x25, 0x41, 0x93, 0x55, 0x64,
+	0xe8, 0x01, 0xd1, 0xf1, 0x34, 0x89, 0xe2, 0x70, 0x27, 0xe6, 0x42, 0xa8, 0xc7, 0xca, 0x1c, 0xae,
+	0x0f, 0x5a, 0x84, 0x73, 0x20, 0xb3, 0x99, 0x89, 0xa8, 0x87, 0x8b, 0x3c, 0x74, 0x87, 0xb2, 0xc5,
+	0xda, 0x87, 0x4f, 0xa6, 0x5b, 0x64, 0x2a, 0x8b, 0x8b, 0xc8, 0xde, 0x13, 0xdc, 0x12, 0xfa, 0x3c,
+	0xda, 0x7b, 0x15, 0xd6, 0x21, 0x99, 0x4d, 0x20, 0xb6, 0x8a, 0x75, 0xa8, 0x60, 0xa1, 0xbd, 0x22,
+	0x4a, 0x39, 0x99, 0x84, 0x28, 0x83, 0x4d, 0xf5, 0x3d, 0x27, 0x11, 0xfa, 0x39, 0xd4, 0xf9, 0x0d,
+	0xd0, 0xd9, 0xb1, 0x0f, 0xd6, 0x72, 0xbc, 0xcc, 0x3a, 0x05, 0xac, 0xd6, 0xe9, 0xcc, 0x80, 0x2c,
+	0xcb, 0xac, 0x32, 0x9b, 0xba, 0x64, 0xde, 0xf5, 0x04, 0x9c, 0x1f, 0x96, 0x88, 0xec, 0x58, 0x70,
+	0x0b, 0xdb, 0x14, 0xfd, 0x3c, 0x7e, 0x09, 0xec, 0x81, 0x33, 0x7e, 0x0b, 0x69, 0x6c, 0x80, 0x8a,
+	0x1e, 0x78, 0x90, 0x32, 0xd9, 0x10, 0x79, 0xd5, 0x4a, 0xc2, 0x3b, 0x91, 0xe5, 0x05, 0x2e, 0x3f,
+	0xe0, 0x42, 0xbf, 0x30, 0x60, 0xe5, 0x3e, 0xef, 0x44, 0xb7, 0x25, 0x5b, 0xb7, 0xa2, 0x50, 0xa5,
+	0x15, 0x05, 0xa4, 0xab, 0xe4, 0x0c, 0x7e, 0x00, 0x57, 0xd7, 0x51, 0xef, 0x02, 0x74, 0xd3, 0x12,
+	0x29, 0xfa, 0x10, 0x39, 0x34, 0x59, 0x86, 0xd3, 0x84, 0x5c, 0xd8, 0xe7, 0xf6, 0xae, 0x05, 0x51,
+	0x6d, 0x25, 0xed, 0x98, 0x8b, 0x76, 0xe8, 0xbb, 0x56, 0xe4, 0x24, 0xfa, 0x33, 0xb8, 0xe1, 0x50,
+	0xde, 0xe7, 0x41, 0xe4, 0x9b, 0xb6, 0x68, 0xdf, 0xcf, 0x05, 0x36, 0x9d, 0xa4, 0x9f, 0x1a, 0x0b,
+	0xa8, 0x72, 0x18, 0x59, 0x7c, 0xd4, 0xa1, 0x53, 0xe9, 0x1a, 0x19, 0xef, 0xd8, 0xf1, 0x2e, 0x8f,
+	0xad, 0xc0, 0xee, 0x70, 0x7d, 0x01, 0x5b, 0x40, 0x13, 0xca, 0x99, 0x84, 0xdf, 0xb5, 0x3b, 0xbc,
+	0x28, 0x67, 0x25, 0x64, 0x32, 0x85, 0xa7, 0x5d, 0xb2, 0x00, 0xb7, 0x4d, 0x2b, 0xdc, 0x0f, 0x78,
+	0x2c, 0xda, 0x5e, 0x64, 0xb5, 0xe2, 0xb0, 0x63, 0x45, 0x76, 0xcc, 0x83, 0x44, 0x7f, 0x16, 0xb7,
+	0xe0, 0xad, 0x5e, 0x6a, 0x5c, 0x00, 0xa9, 0x7b, 0xb9, 0xd0, 0x46, 0x1c, 0x76, 0x36, 0x51, 0xa4,
+	0x9f, 0x1a, 0xcf, 0xe5, 0x15, 0x6f, 0x18, 0x6f, 0xb2, 0x2f, 0x9b, 0x49, 0x7f, 0xaa, 0x91, 0xd9,
+	0x4e, 0xe8, 0xe2, 0x79, 0x6d, 0xed, 0x7b, 0x81, 0x1b, 0xee, 0x5b, 0x42, 0xbf, 0x88, 0x1b, 0xf6,
+	0x3e, 0x9c, 0xd9, 0xcc, 0xde, 0xbf, 0x1b, 0xba, 0x70, 0x72, 0x3e, 0x40, 0x16, 0xce, 0xec, 0xa9,
+	0x4e, 0x05, 0x29, 0x1a, 0xe5, 0x2a, 0x9c, 0xef, 0x1c, 0x9c, 0xca, 0x03, 0x5a, 0x58, 0x4d, 0x07,
+	0xfd, 0x48, 0x23, 0xe7, 0xb2, 0x34, 0x71, 0xf6, 0x62, 0xf0, 0xcd, 0xda, 0x8f, 0xbd, 0x84, 0x0b,
+	0xfd, 0x39, 0x74, 0xe6, 0x1d, 0x28, 0xbd, 0x32, 0xe0, 0x33, 0xfe, 0x01, 0xd2, 0xfd, 0xd4, 0xb8,
+	0xa4, 0x64, 0x4d, 0x85, 0x53, 0x92, 0x67, 0x55, 0xc9, 0x1d, 0x6d, 0x95, 0x0d, 0xd3, 0x04, 0x45,
+	0x2c, 0x8f, 0xed, 0x16, 0x5c, 0x61, 0xf5, 0xc5, 0xb2, 0x88, 0x65, 0xc4, 0x06, 0xe0, 0x45, 0xf2,
+	0xab, 0xa0, 0xc9, 0x2a, 0x32, 0xd4, 0x27, 0x33, 0xf8, 0x2e, 0x61, 0x41, 0x2d, 0xb0, 0x64, 0x7d,
+	0x35, 0xb0, 0xbe, 0x9e, 0xcf, 0xeb, 0x6b, 0x13, 0xf8, 0xb2, 0xc8, 0xe2, 0x15, 0x64, 0xbb, 0x82,
+	0x15, 0x3b, 0x5b, 0x85, 0x4d, 0x56, 0x93, 0xa3, 0x9f, 0x68, 0x64, 0x16, 0x43, 0x08, 0x5f, 0x2c,
+	0x2c, 0xf9, 0x64, 0xa1, 0x2f, 0xa1, 0xbd, 0x39, 0xb8, 0xee, 0xac, 0x85, 0x51, 0x97, 0x01, 0x77,
+	0x17, 0xa9, 0xe6, 0x1d, 0x68, 0x18, 0x9d, 0x2a, 0xd8, 0x4f, 0x8d, 0xe5, 0x22, 0x8c, 0x14, 0x5c,
+	0xd9, 0x46, 0x91, 0xd8, 0x81, 0x6b, 0xc7, 0x2e, 0x9c, 0xff, 0xa3, 0xf9, 0x80, 0xd5, 0x15, 0xd1,
+	0xdf, 0x83, 0x3b, 0x36, 0x14, 0x50, 0x1e, 0x08, 0x2f, 0xf1, 0x1e, 0xc2, 0x8e, 0xea, 0xcf, 0xe3,
+	0x76, 0x1e, 0x40, 0xf7, 0xba, 0x66, 0x0b, 0xbe, 0x95, 0x73, 0x1b, 0xd8, 0xbd, 0x3a, 0x55, 0xa8,
+	0x9f, 0x1a, 0xe7, 0xa4, 0x33, 0x55, 0x1c, 0x7a, 0xa0, 0x01, 0xd9, 0x41, 0x08, 0x7a, 0xd6, 0x9a,
+	0x11, 0x56, 0x93, 0x11, 0xf4, 0x77, 0x1a, 0x99, 0x69, 0x85, 0xbe, 0x1f, 0xee, 0x5b, 0x1f, 0xec,
+	0x05, 0x0e, 0xb4, 0x23, 0x42, 0x37, 0x4b, 0x2f, 0xbf, 0x95, 0x83, 0x37, 0xc5, 0xba, 0x17, 0x0b,
+	0xf0, 0xf2, 0x83, 0x2a, 0x54, 0x78, 0x59, 0xc3, 0xd1, 0xcb, 0xba, 0xec, 0x20, 0x04, 0x5e, 0xd6,
+	0x8c, 0xb0, 0x69, 0xe9, 0x51, 0x01, 0xd3, 0x7b, 0x64, 0x0a, 0x22, 0xaa, 0xac, 0x0e, 0xfa, 0x0b,
+	0xe8, 0x22, 0xdc, 0x02, 0x27, 0x81, 0x29, 0xf2, 0xba, 0x9f, 0x1a, 0x73, 0xf2, 0xf0, 0x53, 0x51,
+	0x93, 0x55, 0xa5, 0x50, 0x21, 0x0f, 0x5c, 0x45, 0x61, 0x43, 0x51, 0xc8, 0x03, 0x77, 0x88, 0x42,
+	0x15, 0x05, 0x85, 0xea, 0x18, 0x8a, 0x20, 0x7a, 0x78, 0x60, 0x27, 0x49, 0x2c, 0xf4, 0x4b, 0xa8,
+	0x0d, 0x8b, 0x20, 0xc0, 0xdf, 0x45, 0xb4, 0x28, 0x82, 0x25, 0x64, 0x32, 0x85, 0x47, 0x25, 0xe0,
+	0x55, 0xa6, 0xe4, 0x45, 0x45, 0x09, 0x0f, 0xdc, 0xba, 0x92, 0x02, 0x02, 0x25, 0xc5, 0x00, 0x1a,
+	0x7b, 0x9c, 0x0f, 0x67, 0x5f, 0xc2, 0x63, 0xfd, 0x25, 0xec, 0x41, 0xe7, 0xf2, 0x8c, 0x43, 0xa9,
+	0x0d, 0xa4, 0x9a, 0xcb, 0x79, 0xe3, 0x7b, 0x50, 0x82, 0xc5, 0x53, 0x8d, 0x82, 0x99, 0x4c, 0x95,
+	0xa0, 0x5b, 0x64, 0x1a, 0x9b, 0x13, 0xa7, 0xcd, 0x9d, 0xdd, 0x28, 0xf4, 0x82, 0x44, 0x7f, 0x19,
+	0x5d, 0xc5, 0xf4, 0x05, 0x6a, 0xad, 0x60, 0x8a, 0xf4, 0xad, 0xc2, 0x26, 0xab, 0xc9, 0x41, 0x03,
+	0xb7, 0x6f, 0xfb, 0xbb, 0xd0, 0xc0, 0x5d, 0x2e, 0x1b, 0xb8, 0x0c, 0x2a, 0x1a, 0xb8, 0x6c, 0x5c,
+	0x36, 0x70, 0x19, 0x40, 0x7f, 0xad, 0x91, 0x79, 0x59, 0x6c, 0xf0, 0xf0, 0xb3, 0xfd, 0x9d, 0x30,
+	0xf6, 0x92, 0x76, 0x47, 0x7f, 0x05, 0x0b, 0xc0, 0x85, 0x95, 0xe2, 0x09, 0x0e, 0xce, 0xaf, 0x9b,
+	0x39, 0xdd, 0xbc, 0x0b, 0xc7, 0x35, 0x4e, 0xac, 0xe0, 0xc5, 0x05, 0x7d, 0x90, 0x52, 0x4b, 0x41,
+	0xdb, 0x5e, 0xfd, 0xca, 0xeb, 0x50, 0x08, 0xce, 0xc8, 0x9f, 0x6c, 0x88, 0x2a, 0xda, 0x26, 0xe7,
+	0xb2, 0xbe, 0xdd, 0xf2, 0x43, 0xc7, 0xf6, 0x2d, 0xa7, 0x0d, 0x75, 0x42, 0xe8, 0x57, 0x70, 0xd3,
+	0x6e, 0x40, 0x55, 0xcf, 0x04, 0xde, 0x01, 0x7e, 0x4d, 0xd2, 0x45, 0x43, 0x3d, 0x84, 0x33, 0xd9,
+	0xb0, 0x19, 0xf4, 0x47, 0x64, 0x1a, 0x4b, 0x6d, 0x14, 0x7b, 0x60, 0xdb, 0xe3, 0x42, 0x7f, 0x15,
+	0x1f, 0x3b, 0x74, 0xb5, 0x9f, 0xdd, 0x94, 0x6c, 0x97, 0xed, 0xf9, 0xbc, 0xf9, 0x56, 0x16, 0x02,
+	0x53, 0x51, 0xc9, 0x78, 0x5c, 0x14, 0xf5, 0x5d, 0x81, 0xbb, 0x90, 0xd1, 0x13, 0x2a, 0xc0, 0x6a,
+	0xb3, 0xe8, 0x03, 0x32, 0xd3, 0xb2, 0x83, 0x30, 0xf1, 0x5a, 0xdd, 0xfc, 0x1a, 0xac, 0xaf, 0xe0,
+	0x2a, 0xaf, 0xe0, 0xed, 0x3c, 0xe3, 0xb2, 0xbb, 0x68, 0x51, 0x39, 0x6a, 0xb8, 0xc9, 0xea, 0x92,
+	0x74, 0x97, 0x8c, 0xc5, 0xdc, 0x76, 0xad, 0x30, 0xf0, 0xbb, 0xfa, 0x1f, 0x37, 0x50, 0xe5, 0xdd,
+	0xe3, 0xd4, 0xa0, 0xeb, 0x3c, 0x8a, 0xb9, 0x63, 0x27, 0xdc, 0x65, 0xdc, 0x76, 0xef, 0x05, 0x7e,
+	0xb7, 0x97, 0x1a, 0xda, 0xab, 0xc5, 0x7b, 0x6a, 0x1c, 0xe2, 0xfd, 0xf0, 0x4a, 0xd8, 0xf1, 0xa0,
+	0x59, 0x93, 0x8b, 0x98, 0x1d, 0x40, 0x75, 0x8d, 0x8d, 0xc6, 0x99, 0x02, 0xfa, 0x43, 0x32, 0x5b,
+	0xb9, 0x34, 0x62, 0x03, 0xf5, 0xa7, 0x0d, 0xbc, 0xc4, 0xdf, 0x3a, 0x4e, 0x0d, 0xbd, 0x34, 0x7a,
+	0xb7, 0xbc, 0xfa, 0x6d, 0x3a, 0x49, 0x6e, 0x7a, 0xb1, 0x7e, 0x73, 0xdc, 0x74, 0x12, 0xc5, 0x03,
+	0x5d, 0x63, 0x53, 0x55, 0x92, 0x7e, 0x8f, 0x9c, 0x95, 0x0d, 0xb3, 0xd0, 0x3f, 0xdb, 0xc0, 0xf0,
+	0xff, 0x1a, 0x74, 0x1e, 0xa5, 0x21, 0x79, 0x11, 0x12, 0xd5, 0xc5, 0x65, 0x53, 0x14, 0xd5, 0x59,
+	0x5e, 0xe8, 0x1a, 0xcb, 0xf5, 0xd1, 0x5d, 0x82, 0xa9, 0xa6, 0x94, 0xba, 0x3f, 0xcb, 0xfd, 0x83,
+	0xc7, 0xcb, 0x0b, 0xa5, 0x85, 0x2d, 0xc7, 0x0e, 0x8a, 0x7a, 0x96, 0xdb, 0x79, 0xae, 0xc8, 0xdd,
+	0x82, 0xaa, 0x2e, 0x64, 0xb2, 0xc2, 0x99, 0x1f, 0x9f, 0x22, 0xe3, 0x4a, 0x85, 0xa1, 0xef, 0x93,
+	0xb3, 0x3c, 0x48, 0x62, 0x88, 0x44, 0xad, 0x1a, 0x89, 0x8a, 0xd4, 0xad, 0x20, 0x89, 0xbb, 0xcd,
+	0x97, 0xf2, 0xd7, 0xb6, 0x6c, 0x42, 0x71, 0xcd, 0x82, 0x31, 0x7e, 0xb6, 0x11, 0xfc, 0xc5, 0x72,
+	0x01, 0xfa, 0x9b, 0xac, 0x5f, 0x12, 0x5e, 0xb0, 0xe3, 0x73, 0x0b, 0x59, 0x0b, 0xfe, 0x66, 0xc1,
+	0x57, 0xd4, 0x91, 0x66, 0x0b, 0x72, 0xbb, 0x63, 0x1f, 0x6c, 0x21, 0x8f, 0x56, 0xb6, 0xd4, 0xc7,
+	0x86, 0x41, 0xaa, 0x72, 0xd5, 0x58, 0xbd, 0xa1, 0xdc, 0x5b, 0x87, 0xe8, 0x81, 0x37, 0x07, 0x90,
+	0x62, 0x43, 0x38, 0xfa, 0x88, 0x4c, 0x81, 0x6b, 0x49, 0x98, 0xd8, 0xbe, 0xf4, 0xe9, 0x14, 0xfa,
+	0x74, 0x3f, 0xbb, 0xf2, 0xdc, 0x07, 0x22, 0xf3, 0xe6, 0xf9, 0xdc, 0x9b, 0x02, 0x54, 0xfc, 0xb8,
+	0x71, 0xed, 0x8d, 0xd7, 0x15, 0x3f, 0x2a, 0x73, 0xc1, 0x03, 0xe0, 0x59, 0x05, 0x35, 0x7f, 0xab,
+	0x91, 0x99, 0xfa, 0xf6, 0xc2, 0x0d, 0xb7, 0x03, 0xc9, 0x94, 0xbd, 0x5c, 0xbf, 0x02, 0xd7, 0x59,
+	0x04, 0x94, 0xd6, 0x3c, 0x71, 0xda, 0xc5, 0xe3, 0x0e, 0x29, 0x87, 0x4c, 0x0a, 0xd2, 0x0d, 0x72,
+	0x06, 0xde, 0x8a, 0xbc, 0x04, 0xf7, 0x77, 0x54, 0x3e, 0xf0, 0x4b, 0xa4, 0x38, 0x35, 0xe4, 0xb0,
+	0x7c, 0xe0, 0x57, 0xc6, 0x2c, 0x93, 0x35, 0xff, 0xa6, 0x91, 0x99, 0x7a, 0x21, 0xa2, 0x77, 0xc8,
+	0xd9, 0xc8, 0x4e, 0x12, 0x1e, 0x07, 0x99, 0x87, 0xd7, 0x21, 0x16, 0x32, 0xa8, 0x2c, 0x47, 0x72,
+	0x5c, 0xe8, 0x9f, 0x50, 0x01, 0x96, 0x8b, 0xd3, 0x07, 0x64, 0x34, 0xab, 0x81, 0xdd, 0x2c, 0x16,
+	0xbe, 0x0a, 0x7f, 0x0b, 0xe5, 0x58, 0x71, 0xa6, 0xe7, 0x80, 0xd4, 0x57, 0xee, 0xf2, 0x64, 0x85,
+	0x60, 0xc5, 0xc4, 0xe6, 0x9d, 0xcf, 0xbf, 0x58, 0x3c, 0x71, 0xf4, 0xc5, 0xe2, 0x89, 0xcf, 0x8f,
+	0x17, 0xb5, 0xa3, 0xe3, 0x45, 0xed, 0x17, 0x4f, 0x16, 0x4f, 0x7c, 0xfa, 0x64, 0x51, 0x3b, 0x7a,
+	0xb2, 0x78, 0xe2, 0x5f, 0x4f, 0x16, 0x4f, 0xbc, 0xf7, 0xf2, 0xff, 0xf0, 0x77, 0x90, 0x4c, 0x81,
+	0xed, 0x33, 0x78, 0x26, 0xbd, 0xf6, 0x9f, 0x01, 0x00, 0x27, 0x8e, 0x6f, 0xbd, 0x71, 0x1c, 0x00,
+	0x00,
 }
 
 func (m *FolderDeviceConfiguration) Marshal() (dAtA []byte, err error) {
@@ -418,6 +498,18 @@ func (m *FolderDeviceConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if len(m.PreviousEncryptionPassword) > 0 {
+		i -= len(m.PreviousEncryptionPassword)
+		copy(dAtA[i:], m.PreviousEncryptionPassword)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.PreviousEncryptionPassword)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.Access != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.Access))
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.EncryptionPassword) > 0 {
 		i -= len(m.EncryptionPassword)
 		copy(dAtA[i:], m.EncryptionPassword)
@@ -515,6 +607,72 @@ func (m *FolderConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if m.FanotifyWatcher {
+		i--
+		if m.FanotifyWatcher {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xf0
+	}
+	if len(m.PullPriorities) > 0 {
+		for iNdEx := len(m.PullPriorities) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PullPriorities[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintFolderconfiguration(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xea
+		}
+	}
+	if m.VersionLocalChanges {
+		i--
+		if m.VersionLocalChanges {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe0
+	}
+	if m.BlockHashAlgorithm != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.BlockHashAlgorithm))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd8
+	}
+	if m.Walkers != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.Walkers))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xd0
+	}
+	if m.ScanCheckpoint {
+		i--
+		if m.ScanCheckpoint {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xc8
+	}
 	if m.FSWatcherTimeoutS != 0 {
 		i -= 8
 		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.FSWatcherTimeoutS))))
@@ -964,6 +1122,41 @@ func (m *XattrFilterEntry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *PullPriorityRule) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PullPriorityRule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PullPriorityRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Priority != 0 {
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Pattern) > 0 {
+		i -= len(m.Pattern)
+		copy(dAtA[i:], m.Pattern)
+		i = encodeVarintFolderconfiguration(dAtA, i, uint64(len(m.Pattern)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintFolderconfiguration(dAtA []byte, offset int, v uint64) int {
 	offset -= sovFolderconfiguration(v)
 	base := offset
@@ -989,6 +1182,13 @@ func (m *FolderDeviceConfiguration) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovFolderconfiguration(uint64(l))
 	}
+	if m.Access != 0 {
+		n += 1 + sovFolderconfiguration(uint64(m.Access))
+	}
+	l = len(m.PreviousEncryptionPassword)
+	if l > 0 {
+		n += 1 + l + sovFolderconfiguration(uint64(l))
+	}
 	return n
 }
 
@@ -1122,6 +1322,27 @@ func (m *FolderConfiguration) ProtoSize() (n int) {
 	if m.FSWatcherTimeoutS != 0 {
 		n += 10
 	}
+	if m.ScanCheckpoint {
+		n += 3
+	}
+	if m.Walkers != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.Walkers))
+	}
+	if m.BlockHashAlgorithm != 0 {
+		n += 2 + sovFolderconfiguration(uint64(m.BlockHashAlgorithm))
+	}
+	if m.VersionLocalChanges {
+		n += 3
+	}
+	if len(m.PullPriorities) > 0 {
+		for _, e := range m.PullPriorities {
+			l = e.ProtoSize()
+			n += 2 + l + sovFolderconfiguration(uint64(l))
+		}
+	}
+	if m.FanotifyWatcher {
+		n += 3
+	}
 	if m.DeprecatedReadOnly {
 		n += 4
 	}
@@ -1174,6 +1395,22 @@ func (m *XattrFilterEntry) ProtoSize() (n int) {
 	return n
 }
 
+func (m *PullPriorityRule) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Pattern)
+	if l > 0 {
+		n += 1 + l + sovFolderconfiguration(uint64(l))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovFolderconfiguration(uint64(m.Priority))
+	}
+	return n
+}
+
 func sovFolderconfiguration(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1307,6 +1544,57 @@ func (m *FolderDeviceConfiguration) Unmarshal(dAtA []byte) error {
 			}
 			m.EncryptionPassword = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Access", wireType)
+			}
+			m.Access = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Access |= FolderDeviceAccess(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousEncryptionPassword", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousEncryptionPassword = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFolderconfiguration(dAtA[iNdEx:])
@@ -2225,6 +2513,138 @@ func (m *FolderConfiguration) Unmarshal(dAtA []byte) error {
 			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
 			m.FSWatcherTimeoutS = float64(math.Float64frombits(v))
+		case 41:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScanCheckpoint", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ScanCheckpoint = bool(v != 0)
+		case 42:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Walkers", wireType)
+			}
+			m.Walkers = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Walkers |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 43:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHashAlgorithm", wireType)
+			}
+			m.BlockHashAlgorithm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHashAlgorithm |= protocol.HashAlgorithm(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 44:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VersionLocalChanges", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.VersionLocalChanges = bool(v != 0)
+		case 45:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PullPriorities", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PullPriorities = append(m.PullPriorities, PullPriorityRule{})
+			if err := m.PullPriorities[len(m.PullPriorities)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 46:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FanotifyWatcher", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FanotifyWatcher = bool(v != 0)
 		case 9000:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedReadOnly", wireType)
@@ -2540,6 +2960,107 @@ func (m *XattrFilterEntry) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PullPriorityRule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowFolderconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PullPriorityRule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PullPriorityRule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pattern", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFolderconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipFolderconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthFolderconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipFolderconfiguration(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -0,0 +1,59 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import "fmt"
+
+// FolderDeviceAccess restricts the direction in which changes flow between
+// us and a given device for a given folder. It is described from the point
+// of view of the remote device: a "receiveonly" device may only receive
+// data from us, while a "sendonly" device may only send data to us.
+func (a FolderDeviceAccess) String() string {
+	switch a {
+	case FolderDeviceAccessSendReceive:
+		return "sendreceive"
+	case FolderDeviceAccessSendOnly:
+		return "sendonly"
+	case FolderDeviceAccessReceiveOnly:
+		return "receiveonly"
+	default:
+		return "unknown"
+	}
+}
+
+func (a FolderDeviceAccess) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText parses the access mode, empty meaning the default. Unknown
+// ones are an error rather than the default, which is full access.
+func (a *FolderDeviceAccess) UnmarshalText(bs []byte) error {
+	switch string(bs) {
+	case "sendreceive", "":
+		*a = FolderDeviceAccessSendReceive
+	case "sendonly":
+		*a = FolderDeviceAccessSendOnly
+	case "receiveonly":
+		*a = FolderDeviceAccessReceiveOnly
+	default:
+		return fmt.Errorf("unknown folder device access %q", bs)
+	}
+	return nil
+}
+
+// AcceptsIndex returns true if index data announced by the device should be
+// taken into account, i.e. if the device is allowed to send us changes.
+func (a FolderDeviceAccess) AcceptsIndex() bool {
+	return a != FolderDeviceAccessReceiveOnly
+}
+
+// ServesRequests returns true if we should announce our index to the device
+// and answer its block requests, i.e. if the device is allowed to receive
+// changes from us.
+func (a FolderDeviceAccess) ServesRequests() bool {
+	return a != FolderDeviceAccessSendOnly
+}
@@ -0,0 +1,75 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: lib/config/folderdeviceaccess.proto
+
+package config
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type FolderDeviceAccess int32
+
+const (
+	FolderDeviceAccessSendReceive FolderDeviceAccess = 0
+	FolderDeviceAccessSendOnly    FolderDeviceAccess = 1
+	FolderDeviceAccessReceiveOnly FolderDeviceAccess = 2
+)
+
+var FolderDeviceAccess_name = map[int32]string{
+	0: "FOLDER_DEVICE_ACCESS_SEND_RECEIVE",
+	1: "FOLDER_DEVICE_ACCESS_SEND_ONLY",
+	2: "FOLDER_DEVICE_ACCESS_RECEIVE_ONLY",
+}
+
+var FolderDeviceAccess_value = map[string]int32{
+	"FOLDER_DEVICE_ACCESS_SEND_RECEIVE": 0,
+	"FOLDER_DEVICE_ACCESS_SEND_ONLY":    1,
+	"FOLDER_DEVICE_ACCESS_RECEIVE_ONLY": 2,
+}
+
+func (FolderDeviceAccess) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b6e79eb16820b050, []int{0}
+}
+
+func init() {
+	proto.RegisterEnum("config.FolderDeviceAccess", FolderDeviceAccess_name, FolderDeviceAccess_value)
+}
+
+func init() {
+	proto.RegisterFile("lib/config/folderdeviceaccess.proto", fileDescriptor_b6e79eb16820b050)
+}
+
+var fileDescriptor_b6e79eb16820b050 = []byte{
+	// 272 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0xce, 0xc9, 0x4c, 0xd2,
+	0x4f, 0xce, 0xcf, 0x4b, 0xcb, 0x4c, 0xd7, 0x4f, 0xcb, 0xcf, 0x49, 0x49, 0x2d, 0x4a, 0x49, 0x2d,
+	0xcb, 0x4c, 0x4e, 0x4d, 0x4c, 0x4e, 0x4e, 0x2d, 0x2e, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17,
+	0x62, 0x83, 0x28, 0x90, 0x52, 0x2e, 0x4a, 0x2d, 0xc8, 0x2f, 0xd6, 0x07, 0x0b, 0x26, 0x95, 0xa6,
+	0xe9, 0xa7, 0xe7, 0xa7, 0xe7, 0x83, 0x39, 0x60, 0x16, 0x44, 0xb1, 0xd6, 0x27, 0x46, 0x2e, 0x21,
+	0x37, 0xb0, 0x49, 0x2e, 0x60, 0x93, 0x1c, 0xc1, 0x26, 0x09, 0x79, 0x70, 0x29, 0xba, 0xf9, 0xfb,
+	0xb8, 0xb8, 0x06, 0xc5, 0xbb, 0xb8, 0x86, 0x79, 0x3a, 0xbb, 0xc6, 0x3b, 0x3a, 0x3b, 0xbb, 0x06,
+	0x07, 0xc7, 0x07, 0xbb, 0xfa, 0xb9, 0xc4, 0x07, 0xb9, 0x3a, 0xbb, 0x7a, 0x86, 0xb9, 0x0a, 0x30,
+	0x48, 0x29, 0x76, 0xcd, 0x55, 0x90, 0xc5, 0xd4, 0x1e, 0x9c, 0x9a, 0x97, 0x12, 0x94, 0x9a, 0x9c,
+	0x9a, 0x59, 0x96, 0x2a, 0xe4, 0xc4, 0x25, 0x87, 0xdb, 0x24, 0x7f, 0x3f, 0x9f, 0x48, 0x01, 0x46,
+	0x29, 0xb9, 0xae, 0xb9, 0x0a, 0x52, 0xd8, 0x8d, 0xf1, 0xcf, 0xcb, 0xa9, 0xc4, 0xe9, 0x1a, 0xa8,
+	0x43, 0x20, 0xc6, 0x30, 0xe1, 0x72, 0x0d, 0xd4, 0x25, 0x20, 0x93, 0xa4, 0x58, 0x56, 0x2c, 0x91,
+	0x63, 0x70, 0xf2, 0x3e, 0xf1, 0x50, 0x8e, 0xe1, 0xc2, 0x43, 0x39, 0x86, 0x13, 0x8f, 0xe4, 0x18,
+	0x2f, 0x3c, 0x92, 0x63, 0x9c, 0xf0, 0x58, 0x8e, 0x61, 0xc1, 0x63, 0x39, 0xc6, 0x0b, 0x8f, 0xe5,
+	0x18, 0x6e, 0x3c, 0x96, 0x63, 0x88, 0xd2, 0x4c, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce,
+	0xcf, 0xd5, 0x2f, 0xae, 0xcc, 0x4b, 0x2e, 0xc9, 0xc8, 0xcc, 0x4b, 0x47, 0x62, 0x21, 0xe2, 0x21,
+	0x89, 0x0d, 0x1c, 0x90, 0xc6, 0x80, 0x01, 0x00, 0xb7, 0x30, 0x77, 0x5f, 0x9c, 0x01, 0x00, 0x00,
+}
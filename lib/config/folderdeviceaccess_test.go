@@ -0,0 +1,39 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package config
+
+import "testing"
+
+func TestFolderDeviceAccessText(t *testing.T) {
+	for _, access := range []FolderDeviceAccess{FolderDeviceAccessSendReceive, FolderDeviceAccessSendOnly, FolderDeviceAccessReceiveOnly} {
+		bs, err := access.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var res FolderDeviceAccess
+		if err := res.UnmarshalText(bs); err != nil {
+			t.Errorf("Unexpected error unmarshalling %q: %v", bs, err)
+		} else if res != access {
+			t.Errorf("Round trip of %v gave %v", access, res)
+		}
+	}
+
+	res := FolderDeviceAccessReceiveOnly
+	if err := res.UnmarshalText(nil); err != nil || res != FolderDeviceAccessSendReceive {
+		t.Errorf("Expected empty access to be sendreceive, got %v, %v", res, err)
+	}
+
+	for _, str := range []string{"sendrecieve", "SendOnly", "readonly"} {
+		res := FolderDeviceAccessReceiveOnly
+		if err := res.UnmarshalText([]byte(str)); err == nil {
+			t.Errorf("Expected an error unmarshalling %q", str)
+		}
+		if res != FolderDeviceAccessReceiveOnly {
+			t.Errorf("Expected %q to leave the value alone, got %v", str, res)
+		}
+	}
+}
@@ -65,6 +65,10 @@ func (opts *OptionsConfiguration) prepare(guiPWIsSet bool) {
 		l.Warnln("Connection priority number for TCP over WAN must be worse (higher) than TCP over LAN. Correcting.")
 		opts.ConnectionPriorityTCPWAN = opts.ConnectionPriorityTCPLAN + 1
 	}
+	if opts.ConnectionPriorityWSSWAN <= opts.ConnectionPriorityWSSLAN {
+		l.Warnln("Connection priority number for WebSocket over WAN must be worse (higher) than WebSocket over LAN. Correcting.")
+		opts.ConnectionPriorityWSSWAN = opts.ConnectionPriorityWSSLAN + 1
+	}
 
 	// If usage reporting is enabled we must have a unique ID.
 	if opts.URAccepted > 0 && opts.URUniqueID == "" {
@@ -89,6 +89,27 @@ type OptionsConfiguration struct {
 	ConnectionPriorityQUICWAN          int  `protobuf:"varint,57,opt,name=connection_priority_quic_wan,json=connectionPriorityQuicWan,proto3,casttype=int" json:"connectionPriorityQuicWan" xml:"connectionPriorityQuicWan" default:"40"`
 	ConnectionPriorityRelay            int  `protobuf:"varint,58,opt,name=connection_priority_relay,json=connectionPriorityRelay,proto3,casttype=int" json:"connectionPriorityRelay" xml:"connectionPriorityRelay" default:"50"`
 	ConnectionPriorityUpgradeThreshold int  `protobuf:"varint,59,opt,name=connection_priority_upgrade_threshold,json=connectionPriorityUpgradeThreshold,proto3,casttype=int" json:"connectionPriorityUpgradeThreshold" xml:"connectionPriorityUpgradeThreshold" default:"0"`
+	ConnectionPriorityWSSLAN           int  `protobuf:"varint,62,opt,name=connection_priority_wss_lan,json=connectionPriorityWssLan,proto3,casttype=int" json:"connectionPriorityWssLan" xml:"connectionPriorityWssLan" default:"25"`
+	ConnectionPriorityWSSWAN           int  `protobuf:"varint,63,opt,name=connection_priority_wss_wan,json=connectionPriorityWssWan,proto3,casttype=int" json:"connectionPriorityWssWan" xml:"connectionPriorityWssWan" default:"45"`
+	// With multiple connections to a device, keep and make connections of
+	// worse priority (like over WAN besides LAN) up to the number wanted,
+	// to stripe requests over all of them, instead of only upgrading.
+	ConnectionMixedPriorities bool `protobuf:"varint,67,opt,name=connection_mixed_priorities,json=connectionMixedPriorities,proto3" json:"connectionMixedPriorities" xml:"connectionMixedPriorities"`
+	// Proxy for all outgoing connections, as a socks5:// or http(s)://
+	// URL, possibly with a username and password. Takes precedence over
+	// ALL_PROXY in the environment. With fallback, direct connections are
+	// attempted alongside those through the proxy.
+	ProxyAddress  string `protobuf:"bytes,60,opt,name=proxy_address,json=proxyAddress,proto3" json:"proxyAddress" xml:"proxyAddress"`
+	ProxyFallback bool   `protobuf:"varint,61,opt,name=proxy_fallback,json=proxyFallback,proto3" json:"proxyFallback" xml:"proxyFallback" default:"true"`
+	// When non-zero, we act as a block cache for devices on the LAN that
+	// have us configured as such, keeping up to this many MiB of blocks.
+	BlockCacheMaxSizeMiB int `protobuf:"varint,64,opt,name=block_cache_max_size_mib,json=blockCacheMaxSizeMib,proto3,casttype=int" json:"blockCacheMaxSizeMiB" xml:"blockCacheMaxSizeMiB"`
+	// Hosts and subnets (like 192.168.1.10, [fd00::10]:21027 or
+	// 10.1.2.0/24) to send local announcements to directly, where
+	// broadcasts and multicasts don't get through.
+	LocalAnnUnicastAddresses []string `protobuf:"bytes,65,rep,name=local_announce_unicast_addresses,json=localAnnounceUnicastAddresses,proto3" json:"localAnnounceUnicastAddresses" xml:"localAnnounceUnicastAddress"`
+	// Advertise and look up devices with mDNS/DNS-SD, as _syncthing._tcp.
+	LocalAnnMDNSEnabled bool `protobuf:"varint,66,opt,name=local_announce_mdns_enabled,json=localAnnounceMdnsEnabled,proto3" json:"localAnnounceMDNSEnabled" xml:"localAnnounceMDNSEnabled"`
 	// Legacy deprecated
 	DeprecatedUPnPEnabled        bool     `protobuf:"varint,9000,opt,name=upnp_enabled,json=upnpEnabled,proto3" json:"-" xml:"upnpEnabled,omitempty"`                                    // Deprecated: Do not use.
 	DeprecatedUPnPLeaseM         int      `protobuf:"varint,9001,opt,name=upnp_lease_m,json=upnpLeaseM,proto3,casttype=int" json:"-" xml:"upnpLeaseMinutes,omitempty"`                   // Deprecated: Do not use.
@@ -141,228 +162,248 @@ func init() {
 }
 
 var fileDescriptor_d09882599506ca03 = []byte{
-	// 3521 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x5a, 0x5d, 0x6c, 0x1d, 0x47,
-	0x15, 0xce, 0x26, 0x4d, 0xda, 0x6c, 0x1c, 0x27, 0x1e, 0x3b, 0xf6, 0x26, 0x4e, 0xbd, 0xee, 0xcd,
-	0x4d, 0xeb, 0xb6, 0x69, 0x62, 0x3b, 0x3f, 0x4d, 0x8d, 0x50, 0xf1, 0x4f, 0x4d, 0xdd, 0xd8, 0x8e,
-	0x3b, 0xb6, 0x6b, 0x54, 0x84, 0x56, 0xe3, 0xbd, 0x73, 0xed, 0xad, 0xf7, 0xee, 0xde, 0xec, 0xce,
-	0xfa, 0xa7, 0x45, 0x50, 0x15, 0x41, 0x79, 0xa3, 0x5c, 0x15, 0x90, 0x40, 0xaa, 0x8a, 0x00, 0x89,
-	0x52, 0x8a, 0x90, 0x90, 0x90, 0x40, 0x42, 0x54, 0x48, 0x48, 0x15, 0x3c, 0xf8, 0x3e, 0x21, 0x24,
-	0xca, 0xa2, 0x3a, 0x7d, 0xba, 0x0f, 0x3c, 0xdc, 0x47, 0xf3, 0x82, 0x66, 0xf6, 0x6f, 0x76, 0x77,
-	0xd6, 0xce, 0xdb, 0xdd, 0xf3, 0x9d, 0x39, 0x73, 0xbe, 0xf9, 0x39, 0x73, 0xce, 0xcc, 0x95, 0x2f,
-	0x9b, 0xc6, 0xea, 0x35, 0xdd, 0xb6, 0xaa, 0xc6, 0xda, 0x35, 0xbb, 0x4e, 0x0c, 0xdb, 0x72, 0x83,
-	0x2f, 0xcf, 0x41, 0xf4, 0xeb, 0x6a, 0xdd, 0xb1, 0x89, 0x0d, 0x4e, 0x04, 0xc2, 0x0b, 0x7d, 0x9c,
-	0x3a, 0xf1, 0x2c, 0xc3, 0x5a, 0x0b, 0x14, 0x2e, 0x9c, 0xe3, 0x00, 0xd7, 0x78, 0x1d, 0x87, 0xe2,
-	0x93, 0x78, 0x9b, 0x04, 0x3f, 0x4b, 0xef, 0x2d, 0xca, 0x3d, 0x77, 0x83, 0x1e, 0x26, 0xf9, 0x1e,
-	0xc0, 0x7b, 0x92, 0x7c, 0xd6, 0x34, 0x5c, 0x82, 0x2d, 0x0d, 0x55, 0x2a, 0x0e, 0x76, 0x5d, 0xec,
-	0x2a, 0xd2, 0xe0, 0xb1, 0xa1, 0x93, 0x13, 0xee, 0x9e, 0xaf, 0x02, 0x88, 0xb6, 0x66, 0x19, 0x3c,
-	0x1e, 0xa1, 0x2d, 0x5f, 0x3d, 0x63, 0xa6, 0x45, 0x6d, 0x5f, 0xbd, 0xbc, 0x5d, 0x33, 0xc7, 0x4a,
-	0x29, 0x79, 0x69, 0xb0, 0x82, 0xab, 0xc8, 0x33, 0xc9, 0x58, 0x29, 0xfc, 0x51, 0xda, 0xdf, 0x2d,
-	0x3f, 0x1c, 0xfe, 0x6e, 0x34, 0xcb, 0x02, 0xe3, 0x30, 0x6b, 0x1a, 0xfc, 0x57, 0x92, 0x95, 0x35,
-	0xd3, 0x5e, 0x45, 0xa6, 0x56, 0x31, 0x5c, 0xdd, 0xde, 0xc4, 0xce, 0x8e, 0xe6, 0x62, 0x67, 0x13,
-	0x3b, 0xae, 0x72, 0x94, 0x39, 0xfa, 0x3b, 0x69, 0xcf, 0x57, 0xbb, 0x21, 0xda, 0xfa, 0x32, 0xd3,
-	0x1b, 0xb7, 0xac, 0xc5, 0x00, 0x6f, 0xf9, 0xea, 0xb9, 0xb5, 0x48, 0x66, 0x7b, 0x96, 0x8e, 0x43,
-	0xa0, 0xed, 0xab, 0x57, 0x98, 0xc3, 0x22, 0x54, 0xe0, 0x77, 0x6b, 0xb7, 0xdc, 0x23, 0x52, 0x6d,
-	0xef, 0x96, 0xc5, 0x1d, 0xa4, 0x89, 0x8a, 0x7c, 0x83, 0xbd, 0x41, 0xc3, 0xa9, 0x88, 0x54, 0x28,
-	0x07, 0x9f, 0x8b, 0x08, 0x63, 0x0b, 0xad, 0x9a, 0xb8, 0xa2, 0x1c, 0x1b, 0x94, 0x86, 0x1e, 0x99,
-	0xf8, 0x80, 0x12, 0x3e, 0x1b, 0x5b, 0x7c, 0x21, 0x00, 0xf3, 0x6c, 0x43, 0xa0, 0xed, 0xab, 0x4f,
-	0x09, 0xd8, 0x86, 0x28, 0x47, 0x97, 0x38, 0x1e, 0xa6, 0x5c, 0x0b, 0xcc, 0x14, 0x01, 0xfb, 0xbb,
-	0xe5, 0x87, 0x68, 0xd3, 0x46, 0xb3, 0x9c, 0x73, 0x2a, 0x47, 0x33, 0x94, 0x83, 0x4f, 0x25, 0xb9,
-	0xcf, 0xb4, 0x75, 0x21, 0xcb, 0x87, 0x18, 0xcb, 0x9f, 0x51, 0x96, 0x67, 0x66, 0xa9, 0x4e, 0x8a,
-	0x64, 0x8f, 0x19, 0x8a, 0x32, 0x1c, 0x9f, 0x0c, 0x96, 0xa0, 0x00, 0x14, 0x50, 0x14, 0x1b, 0x29,
-	0x90, 0x73, 0x04, 0xb3, 0xfe, 0xc0, 0x73, 0xac, 0x41, 0x8e, 0xde, 0xdf, 0x25, 0xb9, 0x3b, 0xa0,
-	0x87, 0x42, 0x5b, 0x5a, 0xdd, 0x76, 0x88, 0x72, 0x7c, 0x50, 0x1a, 0x3a, 0x3e, 0xf1, 0x63, 0x4a,
-	0xad, 0x23, 0x32, 0xb5, 0x60, 0x3b, 0xa4, 0xe5, 0xab, 0x5d, 0xa9, 0xae, 0xa9, 0xb0, 0xed, 0xab,
-	0x4f, 0xe4, 0x49, 0x51, 0x84, 0x63, 0x34, 0x3a, 0x32, 0x3c, 0xfa, 0x6c, 0x69, 0xdf, 0x57, 0x8f,
-	0x19, 0x16, 0x69, 0xed, 0x96, 0x05, 0x66, 0x44, 0xc2, 0xfd, 0xdd, 0xf2, 0x71, 0xd6, 0xb4, 0xd1,
-	0x2c, 0xa7, 0x3c, 0x81, 0x79, 0x5d, 0xf0, 0xad, 0xa3, 0xf2, 0x60, 0x86, 0x4d, 0xcd, 0x33, 0x89,
-	0xa1, 0x23, 0x97, 0x44, 0x71, 0x43, 0x39, 0x31, 0x28, 0x0d, 0x9d, 0x9c, 0xf8, 0x03, 0xa5, 0xd6,
-	0x19, 0x19, 0x9c, 0x9b, 0xa4, 0x3b, 0xb9, 0xe5, 0xab, 0xdd, 0x29, 0xa3, 0x81, 0xb8, 0xed, 0xab,
-	0xb7, 0xf2, 0xf4, 0x02, 0x8c, 0x23, 0xf8, 0xd5, 0x6a, 0x75, 0x64, 0x74, 0x6c, 0xec, 0xf6, 0xf5,
-	0xdb, 0x37, 0xbe, 0x36, 0x16, 0xb0, 0x6d, 0xed, 0x96, 0x85, 0x06, 0xc5, 0xe2, 0xfd, 0xdd, 0x32,
-	0xc8, 0x1b, 0x69, 0x34, 0xcb, 0x19, 0x37, 0xe1, 0xa3, 0xe9, 0xc6, 0x11, 0xc3, 0x30, 0x18, 0x81,
-	0xbb, 0xf2, 0xe9, 0x1a, 0xda, 0xd6, 0x5c, 0x6c, 0x55, 0xb4, 0x8d, 0xd5, 0xba, 0xab, 0x3c, 0xcc,
-	0x26, 0xf3, 0xe9, 0x96, 0xaf, 0x9e, 0xaa, 0xa1, 0xed, 0x45, 0x6c, 0x55, 0xee, 0xac, 0xd6, 0x69,
-	0x70, 0xe9, 0x62, 0xb4, 0x38, 0x59, 0x34, 0x3f, 0x90, 0x57, 0x8c, 0x0c, 0x3a, 0x58, 0xdf, 0x0c,
-	0x0c, 0x3e, 0x92, 0x32, 0x08, 0xb1, 0xbe, 0x99, 0x35, 0x18, 0xc9, 0x52, 0x06, 0x23, 0x21, 0xf8,
-	0xbd, 0x24, 0xf7, 0x39, 0x58, 0xb7, 0x2d, 0x0b, 0xeb, 0x34, 0xbc, 0x6b, 0x86, 0x45, 0xb0, 0xb3,
-	0x89, 0x4c, 0xcd, 0x55, 0x4e, 0x32, 0xdb, 0xdf, 0x60, 0x41, 0x3d, 0x52, 0x99, 0x09, 0xe1, 0x45,
-	0x1a, 0x3b, 0xf8, 0x86, 0x31, 0xd0, 0xf6, 0xd5, 0x21, 0xd6, 0xb7, 0x10, 0xe5, 0x66, 0xe9, 0xd6,
-	0x70, 0xe4, 0xd2, 0xfe, 0x6e, 0xf9, 0xe8, 0xad, 0x61, 0x16, 0xdf, 0x73, 0xfd, 0x40, 0x71, 0x2f,
-	0xa0, 0x2a, 0x77, 0x3a, 0xd8, 0x44, 0x3b, 0x6e, 0x1c, 0x03, 0x64, 0x16, 0x03, 0x9e, 0x6f, 0xf9,
-	0xea, 0xe9, 0x00, 0x49, 0x36, 0x7a, 0x29, 0x74, 0x88, 0x93, 0x66, 0x77, 0x78, 0xb4, 0x63, 0x61,
-	0xba, 0x31, 0x78, 0xeb, 0xa8, 0xdc, 0x1f, 0x76, 0x14, 0x3b, 0x92, 0x0c, 0x52, 0x4d, 0x39, 0xc5,
-	0x06, 0xe9, 0x2f, 0x74, 0x0d, 0xf7, 0x41, 0xaa, 0x97, 0xa3, 0x30, 0xd7, 0xf2, 0xd5, 0x3e, 0x47,
-	0x0c, 0xc5, 0x81, 0xb6, 0x00, 0xe7, 0xbc, 0x1c, 0x19, 0xe6, 0xb6, 0x6c, 0xa1, 0xbd, 0x62, 0x88,
-	0x0e, 0xf2, 0x08, 0x1d, 0xe4, 0x22, 0x37, 0xa1, 0x12, 0xf0, 0xcc, 0x23, 0x60, 0x55, 0x3e, 0xed,
-	0x12, 0xe4, 0x10, 0x6d, 0xd5, 0xb1, 0xb7, 0x5c, 0xec, 0x28, 0x1d, 0x6c, 0xac, 0xbf, 0xd8, 0xf2,
-	0xd5, 0x0e, 0x06, 0x4c, 0x04, 0xf2, 0xb6, 0xaf, 0x3e, 0xc6, 0xe8, 0xf0, 0xc2, 0xc2, 0x91, 0x4e,
-	0x35, 0x05, 0xbf, 0x90, 0xe4, 0x73, 0x16, 0x22, 0x1a, 0x71, 0x10, 0x3d, 0xd5, 0x90, 0x19, 0x4f,
-	0x6c, 0x27, 0xeb, 0xec, 0xde, 0x9e, 0xaf, 0xca, 0xf3, 0xe3, 0x4b, 0x49, 0x58, 0x97, 0x2d, 0x44,
-	0x92, 0x39, 0x56, 0x59, 0xc7, 0x89, 0x48, 0x10, 0xc2, 0xf9, 0x06, 0xa9, 0x2f, 0x2e, 0x5c, 0x73,
-	0x5d, 0xc0, 0x6e, 0x0b, 0x91, 0xa5, 0xc8, 0x9d, 0x68, 0x41, 0xfc, 0x31, 0xe7, 0xa7, 0x89, 0x91,
-	0x8b, 0xb5, 0x9a, 0x72, 0x86, 0x2d, 0x85, 0xef, 0xd0, 0xa5, 0x70, 0x72, 0x7e, 0x7c, 0x69, 0x96,
-	0x8a, 0xe9, 0xe4, 0x9f, 0xb1, 0x10, 0x09, 0x3e, 0x0c, 0xcb, 0x23, 0x2c, 0xf9, 0x29, 0x45, 0xce,
-	0xf2, 0x72, 0xe1, 0xde, 0x68, 0xed, 0x96, 0x73, 0xed, 0xf3, 0xa2, 0x78, 0x07, 0x25, 0x1d, 0x43,
-	0xc0, 0x7b, 0x1f, 0xc8, 0xc0, 0xdf, 0x24, 0xb9, 0x2f, 0xed, 0xbc, 0x83, 0x2d, 0xbc, 0xc5, 0x56,
-	0xf2, 0x59, 0xe6, 0x7e, 0x83, 0xba, 0x7f, 0x6a, 0x7e, 0x7c, 0x09, 0x06, 0x00, 0x25, 0xd0, 0x65,
-	0x21, 0x12, 0x7d, 0xc6, 0x14, 0xca, 0x11, 0x85, 0x34, 0xc2, 0x91, 0xb8, 0xce, 0x93, 0x10, 0xd8,
-	0x10, 0x09, 0x29, 0x91, 0xeb, 0x94, 0x08, 0xef, 0x02, 0xec, 0xe1, 0xa9, 0x44, 0x52, 0x01, 0x19,
-	0x62, 0xd4, 0xb0, 0xed, 0x11, 0xcd, 0x55, 0xba, 0xd2, 0x64, 0x96, 0x02, 0x60, 0x31, 0x24, 0x13,
-	0x7d, 0xd2, 0x95, 0x5e, 0x49, 0x91, 0x49, 0x23, 0x45, 0xdb, 0x4f, 0x60, 0x43, 0x24, 0x8c, 0xb7,
-	0x1c, 0xef, 0x42, 0x9a, 0x4c, 0x24, 0x05, 0x3f, 0x91, 0x64, 0xc5, 0x73, 0xd1, 0x1a, 0xd6, 0x1c,
-	0x4c, 0xcf, 0x7d, 0xc3, 0x5a, 0xd3, 0x90, 0xae, 0xe3, 0x3a, 0xc1, 0x15, 0x05, 0x30, 0x36, 0x88,
-	0xee, 0x80, 0x65, 0x38, 0x1e, 0x4a, 0xe9, 0x0e, 0xf0, 0x9c, 0xe8, 0xab, 0xed, 0xab, 0x67, 0x19,
-	0x89, 0x44, 0xc4, 0x39, 0xcc, 0x2b, 0xa6, 0xbe, 0xe8, 0x8a, 0x4f, 0x4c, 0xc2, 0x5e, 0xe6, 0x02,
-	0x8c, 0x3c, 0x88, 0xe4, 0xe0, 0x0d, 0xb9, 0x27, 0xeb, 0x9c, 0x8b, 0xb1, 0xa5, 0x74, 0x33, 0xc7,
-	0x66, 0xf6, 0x7c, 0xf5, 0xc4, 0x32, 0x5c, 0xc4, 0xd8, 0x6a, 0xf9, 0xea, 0x09, 0xcf, 0xa1, 0xbf,
-	0xda, 0xbe, 0xda, 0x11, 0x3a, 0x44, 0x3f, 0x39, 0x67, 0x22, 0x85, 0xf8, 0x57, 0xa3, 0x59, 0x0e,
-	0x9b, 0x43, 0x90, 0x76, 0x80, 0xca, 0xc0, 0x0f, 0x24, 0xf9, 0x7c, 0xb6, 0x77, 0xcf, 0x32, 0xee,
-	0x79, 0x58, 0x33, 0x2a, 0x4a, 0x0f, 0x4b, 0x22, 0x5e, 0x0d, 0xc6, 0x66, 0x99, 0x89, 0x67, 0xa6,
-	0x82, 0xb1, 0x09, 0xbf, 0xf8, 0xb1, 0x89, 0x14, 0x4a, 0xc1, 0xa0, 0x44, 0x9f, 0x6d, 0xfe, 0x2b,
-	0x1c, 0x94, 0x08, 0xcb, 0x0e, 0x4a, 0xa4, 0x05, 0x3e, 0x96, 0xe4, 0xee, 0x9c, 0x5f, 0x8e, 0xa9,
-	0x9c, 0x63, 0x1e, 0x7d, 0x8f, 0xae, 0xbd, 0xe3, 0xcb, 0x70, 0x19, 0xce, 0xb6, 0x7c, 0xf5, 0xb8,
-	0xe7, 0x2c, 0xc3, 0xd9, 0xb6, 0xaf, 0xde, 0x8e, 0x1c, 0x81, 0xb3, 0xdc, 0xea, 0x5a, 0x27, 0xa4,
-	0xee, 0x8e, 0x5d, 0xbb, 0x56, 0x41, 0x04, 0x5d, 0x75, 0x77, 0x2c, 0x9d, 0xac, 0xd3, 0x62, 0xcd,
-	0xc2, 0xe4, 0x9a, 0x85, 0xb7, 0xa8, 0x94, 0x3a, 0x1c, 0x1a, 0x89, 0x7e, 0xec, 0xef, 0x96, 0x1f,
-	0xa0, 0x61, 0xa3, 0x59, 0x0e, 0xbc, 0x80, 0x5d, 0x19, 0x1e, 0x8e, 0x09, 0xfe, 0x23, 0xc9, 0x6a,
-	0x96, 0x42, 0xdd, 0x76, 0xe9, 0x09, 0xe7, 0x62, 0xdd, 0x73, 0xb0, 0xb9, 0xa3, 0xf4, 0xb2, 0xf0,
-	0xfb, 0x23, 0x56, 0x41, 0x2c, 0xc3, 0x05, 0xdb, 0x25, 0x33, 0x31, 0xd8, 0xf2, 0xd5, 0xb3, 0x9e,
-	0x93, 0x96, 0xb5, 0x7d, 0xf5, 0xf1, 0x90, 0x64, 0x1a, 0xe0, 0xf8, 0x56, 0x91, 0xe9, 0xb2, 0x90,
-	0x9c, 0x6f, 0x2d, 0x90, 0xd1, 0xcc, 0x93, 0xb5, 0xa0, 0xf5, 0x42, 0xd6, 0x05, 0x78, 0x31, 0x4d,
-	0x2b, 0x8d, 0x82, 0x7f, 0x0b, 0x18, 0x1a, 0x96, 0x41, 0x0c, 0x5a, 0x47, 0xd0, 0xf3, 0x4e, 0x73,
-	0x95, 0x3e, 0xb6, 0x8a, 0x7f, 0xc8, 0xaa, 0x87, 0x65, 0x38, 0x13, 0xa0, 0x53, 0x14, 0xa4, 0x01,
-	0xe3, 0x8c, 0xe7, 0xa4, 0x44, 0x71, 0xb8, 0xc8, 0xc8, 0xf9, 0x60, 0x71, 0x7b, 0x38, 0x15, 0xc0,
-	0xb3, 0x16, 0xf2, 0x22, 0x7a, 0x02, 0xd1, 0x56, 0xb4, 0x60, 0xc8, 0xb8, 0x00, 0xfb, 0xd3, 0x04,
-	0x53, 0x20, 0x78, 0x5b, 0x92, 0xfb, 0x90, 0x47, 0x6c, 0xcd, 0xab, 0xaf, 0x39, 0xa8, 0x82, 0x93,
-	0xdc, 0x64, 0x5d, 0x39, 0xcf, 0x78, 0x2d, 0xd0, 0x0a, 0x88, 0xaa, 0x2c, 0x07, 0x1a, 0xd1, 0xb1,
-	0xfe, 0x62, 0x5c, 0x2c, 0x88, 0x40, 0x9e, 0xcd, 0x28, 0x9f, 0xa8, 0x8d, 0x8c, 0x42, 0xa1, 0x35,
-	0x50, 0x93, 0xfb, 0x22, 0x1f, 0x88, 0xad, 0xd5, 0x1d, 0x3a, 0xe2, 0xec, 0x68, 0x74, 0x95, 0x0b,
-	0x6c, 0x09, 0xdd, 0xa2, 0x8e, 0x84, 0x2a, 0x4b, 0xf6, 0x82, 0x83, 0x61, 0x88, 0xb7, 0x7d, 0xf5,
-	0x42, 0x30, 0xa2, 0x02, 0xb0, 0x04, 0x85, 0x6d, 0xc0, 0xa6, 0x0c, 0x36, 0x30, 0xae, 0x6b, 0x04,
-	0xd7, 0xea, 0xb6, 0x83, 0x1c, 0x03, 0xbb, 0xda, 0xba, 0xd2, 0xcf, 0x28, 0xbf, 0x48, 0xd7, 0x25,
-	0x45, 0x97, 0x12, 0x90, 0xd2, 0xbd, 0xc4, 0x7a, 0xc9, 0x02, 0x7c, 0x69, 0x74, 0x83, 0xa7, 0x3a,
-	0x7a, 0x03, 0xe6, 0xac, 0x80, 0x1d, 0xb9, 0x5b, 0x47, 0xfa, 0x3a, 0xd6, 0x8c, 0x35, 0xcb, 0x76,
-	0x70, 0x45, 0xab, 0x1a, 0x26, 0x76, 0x95, 0x8b, 0x8c, 0xe2, 0x0c, 0x3d, 0x60, 0x18, 0x3c, 0x13,
-	0xa0, 0xd3, 0x14, 0x8c, 0x07, 0x3a, 0x87, 0xe4, 0xb6, 0x44, 0xbc, 0xd4, 0x61, 0xde, 0x0c, 0xf8,
-	0xbe, 0x24, 0x5f, 0xa8, 0x3b, 0xf6, 0x1a, 0xad, 0x2d, 0x34, 0xaf, 0x5e, 0x41, 0x04, 0xf3, 0xf9,
-	0xfa, 0xa3, 0x8c, 0xfb, 0x12, 0x4d, 0x37, 0x23, 0xad, 0x65, 0xa6, 0xc4, 0xe7, 0xe6, 0x41, 0xcd,
-	0x5b, 0x80, 0x73, 0xee, 0xdc, 0xe4, 0x06, 0x42, 0xba, 0x09, 0x8b, 0x2c, 0x82, 0xb7, 0x24, 0xb9,
-	0xd7, 0x34, 0x6a, 0x06, 0xd1, 0x56, 0x91, 0x55, 0xd9, 0x32, 0x2a, 0x64, 0x5d, 0x33, 0x2c, 0xcd,
-	0x44, 0x96, 0x32, 0xc0, 0x86, 0x64, 0x8e, 0xd5, 0x72, 0x54, 0x63, 0x22, 0x52, 0x98, 0xb1, 0x66,
-	0x91, 0x95, 0xd4, 0xdf, 0x79, 0xec, 0x80, 0x61, 0x11, 0x99, 0x02, 0x6f, 0x4a, 0x32, 0xa8, 0x19,
-	0x96, 0xb6, 0x6e, 0xd7, 0xb0, 0x56, 0x31, 0xdc, 0x0d, 0xad, 0xea, 0x60, 0xac, 0xa8, 0x83, 0xd2,
-	0xd0, 0xa9, 0xd1, 0x8e, 0xab, 0xc1, 0x45, 0xd7, 0xd5, 0x45, 0xe3, 0x75, 0x3c, 0xf1, 0xc2, 0x27,
-	0xbe, 0x7a, 0x84, 0xee, 0xea, 0x9a, 0x61, 0xbd, 0x68, 0xd7, 0xf0, 0x94, 0xe1, 0x6e, 0x4c, 0x3b,
-	0x18, 0xc7, 0xab, 0x23, 0x23, 0xe7, 0xf7, 0xc1, 0xe0, 0x65, 0xea, 0xc8, 0xb1, 0x91, 0xc1, 0xcb,
-	0x30, 0xdb, 0x1c, 0xdc, 0x97, 0xe4, 0x8e, 0x68, 0xbd, 0xb3, 0x53, 0x60, 0x90, 0x9d, 0x02, 0x7f,
-	0x66, 0x19, 0x48, 0xb4, 0x68, 0x83, 0xb3, 0xe0, 0x94, 0x93, 0x7c, 0xb6, 0x7d, 0x75, 0x2a, 0x2a,
-	0x00, 0x22, 0x99, 0xe0, 0x5c, 0x08, 0x77, 0x80, 0x9b, 0x09, 0xf1, 0x35, 0x4c, 0xd0, 0xd5, 0xd7,
-	0x5c, 0xdb, 0xa2, 0xa1, 0x34, 0x65, 0x36, 0xfd, 0xb9, 0xbf, 0x5b, 0x1e, 0x7a, 0x50, 0x53, 0x34,
-	0x5d, 0xe1, 0xfc, 0x85, 0x89, 0x1d, 0xc7, 0x04, 0x2b, 0x72, 0x17, 0x32, 0xb7, 0x68, 0x31, 0x14,
-	0x14, 0xf7, 0x16, 0x26, 0xae, 0xf2, 0x18, 0xbb, 0x53, 0xa3, 0x35, 0xe8, 0x99, 0x00, 0x64, 0x45,
-	0xf2, 0x3c, 0x26, 0x74, 0xe1, 0xf7, 0x04, 0x11, 0x26, 0x25, 0x2f, 0xc1, 0xac, 0x22, 0xf8, 0x9f,
-	0x24, 0x0f, 0xd9, 0x9b, 0xd8, 0xd9, 0x72, 0x0c, 0x42, 0x03, 0x47, 0xcd, 0x26, 0x58, 0xab, 0xe0,
-	0x4d, 0x43, 0xc7, 0x9a, 0x85, 0x6a, 0xd8, 0xd5, 0x6c, 0x4b, 0x0b, 0xeb, 0x12, 0xa5, 0x94, 0xdc,
-	0xf6, 0xf4, 0xdd, 0x8d, 0x1a, 0x41, 0xd6, 0x66, 0x0a, 0x6f, 0xce, 0x53, 0xf5, 0x96, 0xaf, 0x5e,
-	0xb2, 0x73, 0x90, 0xa1, 0x63, 0x86, 0xde, 0xb5, 0x26, 0x03, 0x53, 0x6d, 0x5f, 0x7d, 0x8e, 0x39,
-	0xf8, 0x00, 0xba, 0xc5, 0x8b, 0x92, 0x16, 0x55, 0x05, 0x7e, 0xc0, 0x07, 0xf1, 0x02, 0x7c, 0x53,
-	0x3e, 0x47, 0xc3, 0x98, 0x66, 0x58, 0x15, 0xbc, 0xad, 0xd1, 0x95, 0xbc, 0x6a, 0xda, 0xfa, 0x86,
-	0xab, 0x5c, 0x62, 0x5b, 0x9a, 0x2e, 0x1a, 0x40, 0x15, 0x66, 0x28, 0x3e, 0x67, 0x58, 0x13, 0x0c,
-	0x8d, 0x2f, 0x51, 0xf3, 0x90, 0x30, 0x71, 0x0d, 0xd2, 0x51, 0x28, 0xb0, 0x04, 0xfe, 0x45, 0xb3,
-	0x4f, 0x0b, 0xe9, 0x1b, 0xb8, 0xa2, 0x59, 0x36, 0x31, 0xaa, 0x86, 0x8e, 0x82, 0xeb, 0x80, 0x8a,
-	0xab, 0x94, 0xd9, 0xfc, 0xbe, 0x4f, 0x87, 0xbb, 0x77, 0x39, 0x50, 0x9a, 0xe7, 0x74, 0x66, 0xa6,
-	0xe8, 0x68, 0xf7, 0x7a, 0x42, 0xa4, 0xed, 0xab, 0xfd, 0x41, 0x68, 0x17, 0xc1, 0xec, 0xea, 0x50,
-	0x88, 0xb4, 0x77, 0xcb, 0x05, 0x16, 0x1b, 0xcd, 0x72, 0x81, 0x17, 0x50, 0xd8, 0xa2, 0xe2, 0x02,
-	0x28, 0x9f, 0x26, 0x0e, 0xaa, 0x56, 0x0d, 0x5d, 0xd3, 0x4d, 0xe4, 0xba, 0xca, 0x65, 0x36, 0xac,
-	0xcf, 0xd0, 0xf2, 0x35, 0x04, 0x26, 0xa9, 0xbc, 0xed, 0xab, 0x20, 0x18, 0x50, 0x4e, 0x18, 0xdf,
-	0x9b, 0xa4, 0x54, 0xc1, 0x1b, 0x72, 0x77, 0x38, 0xc4, 0x5a, 0xd5, 0x36, 0x2b, 0xd8, 0xd1, 0xea,
-	0x88, 0xac, 0x2b, 0x8f, 0xb3, 0x5d, 0x7f, 0x67, 0xcf, 0x57, 0xfb, 0xa7, 0x70, 0xdd, 0xc1, 0x3a,
-	0x22, 0xb8, 0x32, 0x15, 0x28, 0x4e, 0x33, 0xbd, 0x05, 0x44, 0xd6, 0x5b, 0xbe, 0x2a, 0x3d, 0x13,
-	0x17, 0xcb, 0x95, 0x2c, 0x7c, 0xc5, 0xae, 0x19, 0x74, 0x92, 0xc8, 0x4e, 0x49, 0x91, 0x60, 0x57,
-	0x0e, 0x07, 0x1b, 0xf2, 0x59, 0x17, 0x13, 0xcd, 0xb4, 0xb7, 0xb4, 0xba, 0x63, 0xd8, 0x8e, 0x41,
-	0x76, 0x94, 0x27, 0xd8, 0xa6, 0x18, 0x6f, 0xf9, 0x6a, 0xa7, 0x8b, 0xc9, 0xac, 0xbd, 0xb5, 0x10,
-	0x22, 0x71, 0x64, 0x4b, 0x8b, 0x0b, 0xcb, 0xf2, 0x4c, 0x73, 0xf0, 0x81, 0x24, 0xf7, 0xd6, 0xd0,
-	0x76, 0x44, 0x53, 0xb7, 0x2d, 0xdd, 0x73, 0x1c, 0x6c, 0xe9, 0x3b, 0xca, 0x10, 0x1b, 0x47, 0x97,
-	0xdd, 0x7d, 0xa0, 0xad, 0x39, 0xb4, 0x1d, 0xf8, 0x38, 0x99, 0xa8, 0xd0, 0x23, 0xbf, 0x26, 0x90,
-	0xc7, 0x47, 0xbe, 0x08, 0x8c, 0x86, 0x9c, 0x5d, 0x56, 0x88, 0xed, 0x42, 0xa1, 0x55, 0xf0, 0xa9,
-	0x24, 0x77, 0xeb, 0x0e, 0x72, 0xd7, 0x33, 0x29, 0xf9, 0x93, 0x6c, 0x5a, 0x3e, 0x64, 0x29, 0xf9,
-	0x64, 0x94, 0x92, 0xeb, 0x61, 0x4a, 0x3e, 0x1d, 0x9c, 0xcd, 0xb4, 0x59, 0x92, 0x1c, 0x0b, 0xc3,
-	0x30, 0xd3, 0xc9, 0xa7, 0xd9, 0x4c, 0x4c, 0xd7, 0x72, 0x57, 0xce, 0x08, 0x4d, 0xd6, 0xf5, 0x30,
-	0x59, 0x2f, 0x3f, 0x88, 0x19, 0x9a, 0xae, 0x4f, 0x06, 0xe9, 0x7a, 0xc6, 0x98, 0x63, 0x82, 0x9f,
-	0x4a, 0x72, 0x5f, 0x96, 0x5e, 0x74, 0x4b, 0xf2, 0x14, 0x9b, 0x7f, 0x63, 0xcf, 0x57, 0x4f, 0x4e,
-	0x42, 0xee, 0x82, 0x3f, 0x6d, 0x25, 0x7b, 0xc1, 0x2f, 0x44, 0x8b, 0x96, 0x46, 0xa3, 0x59, 0x4e,
-	0x6c, 0x43, 0xb1, 0x65, 0xf0, 0x6d, 0x49, 0xee, 0x75, 0x89, 0x67, 0x69, 0x34, 0x73, 0x42, 0xa6,
-	0xb1, 0x89, 0xb5, 0xe0, 0xee, 0xc8, 0x55, 0x9e, 0x8e, 0xf3, 0xd1, 0x6e, 0xaa, 0x71, 0x27, 0x52,
-	0x58, 0xa4, 0xf8, 0x62, 0x9c, 0x25, 0x09, 0xb0, 0x74, 0x6e, 0xcd, 0x05, 0xb4, 0x63, 0x23, 0xb7,
-	0x87, 0xa1, 0xc8, 0x1a, 0x2d, 0x59, 0x33, 0x6e, 0xd0, 0xb8, 0xea, 0x2a, 0x57, 0x98, 0x13, 0x2f,
-	0xd1, 0x44, 0x2d, 0xd5, 0x6c, 0xce, 0xb0, 0x92, 0xd4, 0x3e, 0x87, 0xf0, 0x39, 0x62, 0x2a, 0xa0,
-	0x8e, 0x0e, 0xc3, 0xbc, 0x1d, 0x9a, 0x95, 0x77, 0xb0, 0xde, 0xa3, 0x77, 0xa7, 0x67, 0x58, 0x0c,
-	0xad, 0xec, 0xf9, 0x6a, 0x27, 0x44, 0x5b, 0x8b, 0xc4, 0xe3, 0x5e, 0x9c, 0x4e, 0xb9, 0xc9, 0x67,
-	0x7c, 0x37, 0x94, 0xc8, 0x0e, 0x7d, 0x15, 0xcb, 0x58, 0x84, 0xbc, 0x3d, 0xb0, 0x29, 0x9f, 0xa1,
-	0x55, 0xe0, 0x2a, 0x72, 0xb1, 0x16, 0x3c, 0x01, 0x2a, 0x57, 0x07, 0xa5, 0xa1, 0xce, 0xd1, 0xce,
-	0x28, 0x2d, 0x5a, 0x62, 0x52, 0x76, 0x99, 0xd7, 0x19, 0xa9, 0x06, 0xb2, 0x38, 0x72, 0xa4, 0xc5,
-	0xa5, 0x41, 0x07, 0xb3, 0x29, 0x0d, 0x97, 0xc7, 0x9b, 0xcd, 0xb2, 0x04, 0x33, 0x4d, 0xc1, 0xbb,
-	0x47, 0xe5, 0x4b, 0x34, 0x6a, 0xc4, 0xe1, 0x82, 0xd6, 0x94, 0xba, 0x5d, 0xa3, 0x4b, 0xd6, 0xc1,
-	0xf7, 0x3c, 0xec, 0x12, 0x6d, 0xc3, 0x58, 0x55, 0xae, 0xb1, 0xe9, 0xf8, 0xab, 0x14, 0x3e, 0x1d,
-	0xce, 0xa1, 0xed, 0xc9, 0x19, 0x18, 0xe0, 0x77, 0x8c, 0x89, 0x96, 0xaf, 0xaa, 0x35, 0xb4, 0x1d,
-	0x6f, 0x71, 0x32, 0x13, 0xda, 0x48, 0x54, 0xe2, 0x53, 0xf0, 0x10, 0x3d, 0xae, 0x1e, 0x3b, 0xd4,
-	0xe4, 0xe1, 0x2a, 0xe1, 0x63, 0x64, 0xc6, 0x5d, 0x78, 0x48, 0xb3, 0x55, 0xf0, 0xb9, 0x24, 0xf7,
-	0xc6, 0x2f, 0x22, 0x26, 0xe2, 0xdf, 0x50, 0x87, 0xd9, 0x06, 0xfe, 0x88, 0x8e, 0x44, 0x4f, 0xf4,
-	0xa2, 0x30, 0x3b, 0x3e, 0xcf, 0x3f, 0xa3, 0xf6, 0x20, 0x81, 0x3c, 0x4e, 0xa4, 0x45, 0xa0, 0xe8,
-	0x21, 0x4b, 0x68, 0xa4, 0x40, 0xce, 0x6d, 0x7d, 0xa1, 0x53, 0x30, 0x69, 0x85, 0xb8, 0x37, 0xd8,
-	0x4d, 0xf9, 0x02, 0x7b, 0xf4, 0xa8, 0x7a, 0xa6, 0x19, 0x66, 0x35, 0xb6, 0x15, 0x95, 0xa8, 0xca,
-	0x08, 0x63, 0x3a, 0x46, 0xb3, 0x06, 0xaa, 0x35, 0xed, 0x99, 0x26, 0xcb, 0x47, 0xee, 0x5a, 0x61,
-	0x51, 0xd9, 0xf6, 0xd5, 0x8b, 0xe1, 0x91, 0x25, 0x82, 0x4b, 0xb0, 0xa0, 0x1d, 0x78, 0x49, 0x3e,
-	0x5d, 0xc5, 0x88, 0x78, 0x0e, 0xd6, 0xaa, 0x26, 0x5a, 0x73, 0x95, 0x51, 0xb6, 0xef, 0x2e, 0xd3,
-	0x93, 0x3e, 0x04, 0xa6, 0xa9, 0x3c, 0x7e, 0x20, 0xe1, 0x84, 0x25, 0x98, 0x52, 0x01, 0x5b, 0x72,
-	0x1f, 0xf7, 0x2e, 0x12, 0xd4, 0x38, 0xd8, 0xb2, 0xbd, 0xb5, 0x75, 0xe5, 0x3a, 0x5b, 0xb4, 0xcf,
-	0xb3, 0xf0, 0x1a, 0xab, 0xcc, 0x52, 0x8d, 0x17, 0x98, 0x42, 0x9c, 0xf5, 0x08, 0xd1, 0x38, 0xa3,
-	0x10, 0x37, 0x06, 0x1b, 0x72, 0x4f, 0xae, 0xe3, 0x1a, 0xda, 0x56, 0x6e, 0xb0, 0x5e, 0x9f, 0xa3,
-	0xc9, 0x60, 0xa6, 0xe1, 0x1c, 0xda, 0x6e, 0xfb, 0xaa, 0x22, 0xea, 0x72, 0x0e, 0x6d, 0xc7, 0xfd,
-	0x09, 0x9a, 0x81, 0xb7, 0x8f, 0xca, 0x6a, 0x74, 0xd9, 0xa3, 0x21, 0x93, 0xa6, 0x14, 0xb6, 0x59,
-	0xd1, 0x88, 0xe9, 0x6a, 0x34, 0x7e, 0x18, 0xb6, 0xe5, 0x2a, 0x37, 0xd9, 0x7c, 0x7d, 0x4c, 0x57,
-	0x66, 0x7f, 0x74, 0xb5, 0x32, 0x4e, 0x55, 0xef, 0x9a, 0x95, 0xa5, 0xd9, 0xc5, 0x57, 0x42, 0xbd,
-	0x96, 0xaf, 0xf6, 0x1b, 0xc5, 0x70, 0x9c, 0xef, 0x1c, 0xa0, 0x43, 0xd7, 0xe7, 0x81, 0x36, 0x0e,
-	0x86, 0x1b, 0xcd, 0xf2, 0x41, 0x0e, 0xc2, 0x7c, 0x5b, 0xd3, 0x8d, 0x40, 0xd0, 0x94, 0xe4, 0x7e,
-	0x6e, 0xdc, 0xa3, 0xc4, 0x4a, 0x23, 0x7a, 0x9d, 0x95, 0xb3, 0xb7, 0xd8, 0xf0, 0xbf, 0x43, 0x47,
-	0x41, 0x99, 0x8c, 0xf5, 0xa2, 0x34, 0x69, 0x69, 0x72, 0x61, 0x76, 0x7c, 0xbe, 0xe5, 0xab, 0x8a,
-	0x9e, 0xc7, 0xf4, 0x7a, 0x50, 0xf0, 0x3e, 0x9d, 0x99, 0xa1, 0xb4, 0xc2, 0x01, 0x49, 0x7b, 0xa3,
-	0x59, 0x2e, 0xec, 0x13, 0x16, 0xf6, 0x08, 0xfe, 0x21, 0xc9, 0x17, 0x45, 0x94, 0xee, 0x79, 0x86,
-	0xce, 0x38, 0x3d, 0xcb, 0x38, 0xbd, 0x4b, 0x39, 0x9d, 0xcf, 0xdb, 0x7f, 0x79, 0x79, 0x66, 0x32,
-	0x20, 0x75, 0x3e, 0xdf, 0xc5, 0xcb, 0x9e, 0xa1, 0x07, 0xac, 0xae, 0x14, 0xb0, 0x0a, 0x35, 0x0e,
-	0x38, 0x3a, 0x1b, 0xcd, 0x72, 0x71, 0xb7, 0xb0, 0xb8, 0xd3, 0x03, 0xe7, 0x6a, 0x0b, 0x59, 0xca,
-	0xed, 0xc3, 0xe6, 0x6a, 0xe5, 0x80, 0xb9, 0x5a, 0x39, 0x6c, 0xae, 0x56, 0x52, 0xa4, 0xae, 0xa7,
-	0x48, 0x5d, 0x2f, 0x9e, 0xab, 0x95, 0xc2, 0xb9, 0x5a, 0x39, 0x6c, 0xae, 0x28, 0xa7, 0xe7, 0x0e,
-	0x9d, 0xab, 0x95, 0x83, 0xe6, 0x6a, 0xe5, 0xd0, 0xb9, 0x4a, 0xd3, 0xba, 0x91, 0xa2, 0x75, 0xe3,
-	0x80, 0xb9, 0x5a, 0x29, 0x9e, 0x2b, 0x4a, 0xac, 0x21, 0xc9, 0xe7, 0x45, 0xc4, 0xd8, 0x6b, 0xa3,
-	0x32, 0xc6, 0x58, 0xbd, 0xd2, 0xf2, 0xd5, 0xbe, 0xbc, 0x09, 0xf6, 0x52, 0x99, 0xe4, 0xaa, 0x62,
-	0x9c, 0xbf, 0xb4, 0x4a, 0xf9, 0x7c, 0x73, 0x18, 0x16, 0xd9, 0x04, 0x7f, 0x92, 0xe4, 0xcb, 0x22,
-	0xa7, 0xe2, 0x1b, 0xcc, 0x75, 0x07, 0xbb, 0xeb, 0xb6, 0x59, 0x51, 0xbe, 0xc0, 0x1c, 0x7c, 0xad,
-	0xe5, 0xab, 0x02, 0x07, 0xc2, 0x73, 0x67, 0x29, 0xd2, 0x6e, 0xfb, 0xea, 0x8d, 0x02, 0x5f, 0xb3,
-	0xaa, 0x9c, 0xdb, 0xbc, 0xd7, 0xd2, 0x30, 0x7c, 0x80, 0xc6, 0xe0, 0xeb, 0x72, 0x87, 0x57, 0xb7,
-	0xea, 0x71, 0xf6, 0xff, 0xcb, 0x69, 0x16, 0xa3, 0xbf, 0xb2, 0xe7, 0xab, 0xe7, 0x92, 0xc2, 0x73,
-	0x79, 0xc1, 0x5a, 0x48, 0x4a, 0x01, 0x56, 0x72, 0x86, 0xd5, 0x78, 0xdd, 0xaa, 0x87, 0x00, 0x57,
-	0x6c, 0x36, 0x9a, 0x65, 0x71, 0x63, 0x45, 0x82, 0xa7, 0xb8, 0x26, 0xe0, 0xe7, 0x52, 0xd8, 0x7d,
-	0xf4, 0xf4, 0xf9, 0xc1, 0x34, 0x1b, 0xa5, 0x37, 0x59, 0xf2, 0x92, 0x36, 0x11, 0x3f, 0x83, 0xb2,
-	0xee, 0x07, 0xe3, 0xee, 0xf9, 0xe7, 0x4b, 0xce, 0x87, 0x24, 0x4b, 0xbb, 0x50, 0xac, 0x45, 0xb3,
-	0x11, 0x51, 0x2f, 0x8a, 0x04, 0xe5, 0xa4, 0x15, 0xf8, 0xad, 0x24, 0x77, 0x32, 0x37, 0x93, 0x47,
-	0xce, 0x5f, 0x05, 0x8e, 0x7e, 0x97, 0x5d, 0x66, 0xa4, 0x4d, 0x70, 0x0f, 0x9e, 0xcc, 0xd5, 0x52,
-	0xec, 0x6a, 0xfa, 0x89, 0x52, 0xe8, 0xec, 0xc5, 0x83, 0xf4, 0x1a, 0xcd, 0x72, 0x41, 0x5f, 0x8a,
-	0x04, 0x3b, 0xf8, 0x96, 0x89, 0xcb, 0xc9, 0x53, 0xe6, 0x87, 0xc5, 0x2e, 0x73, 0xcf, 0x9a, 0x19,
-	0x97, 0xd3, 0x0f, 0x91, 0xc5, 0x2e, 0x17, 0xe9, 0xe5, 0x5d, 0x8e, 0x34, 0x23, 0x97, 0xe3, 0x97,
-	0xcb, 0xaa, 0x1c, 0xfc, 0x65, 0x22, 0xae, 0x75, 0x7e, 0x3d, 0xcd, 0x92, 0xae, 0x2f, 0xa5, 0xfd,
-	0x65, 0xfb, 0x2e, 0x29, 0x7a, 0xb8, 0xc5, 0xe8, 0x24, 0x48, 0xfa, 0xe6, 0xa3, 0x83, 0x43, 0x5c,
-	0x76, 0xd3, 0x9c, 0xbf, 0xe4, 0xd5, 0xea, 0x3a, 0x51, 0x3e, 0xa2, 0x43, 0x24, 0x4d, 0xcc, 0xed,
-	0xf9, 0xea, 0xc5, 0xa4, 0xc7, 0xb9, 0xf4, 0x15, 0xed, 0x82, 0x4e, 0xd2, 0xe3, 0x54, 0xcb, 0xe1,
-	0xe9, 0xee, 0x41, 0x5e, 0x81, 0x16, 0x76, 0x3d, 0x99, 0xb2, 0xc6, 0xd5, 0x91, 0xe5, 0x2a, 0xbf,
-	0x09, 0x66, 0x69, 0x29, 0xe3, 0x02, 0x5f, 0x0e, 0x2c, 0x52, 0xc5, 0x8c, 0x0b, 0x39, 0x3c, 0x3f,
-	0x55, 0xcc, 0x93, 0x9c, 0xde, 0xc4, 0x9d, 0x4f, 0x3e, 0x1b, 0x38, 0xd2, 0xfc, 0x6c, 0xe0, 0xc8,
-	0x27, 0x7b, 0x03, 0x52, 0x73, 0x6f, 0x40, 0x7a, 0xe7, 0xfe, 0xc0, 0x91, 0xf7, 0xef, 0x0f, 0x48,
-	0xcd, 0xfb, 0x03, 0x47, 0xfe, 0x79, 0x7f, 0xe0, 0xc8, 0xab, 0x4f, 0xae, 0x19, 0x64, 0xdd, 0x5b,
-	0xbd, 0xaa, 0xdb, 0xb5, 0x6b, 0xf1, 0x65, 0x03, 0xf7, 0x2b, 0xf9, 0x0f, 0xe8, 0xea, 0x09, 0xf6,
-	0xa7, 0xcf, 0xeb, 0xff, 0x0f, 0x00, 0x00, 0xff, 0xff, 0xc9, 0x24, 0x93, 0x46, 0x60, 0x2a, 0x00,
-	0x00,
+	// 3851 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x5a, 0x5d, 0x6c, 0x25, 0xc9,
+	0x55, 0x9e, 0x9e, 0xc9, 0x4c, 0x32, 0x3d, 0x9e, 0xbf, 0xb6, 0xc7, 0xee, 0x19, 0x4f, 0xdc, 0xce,
+	0xdd, 0x3b, 0x1b, 0x6f, 0x76, 0x67, 0xc6, 0xe3, 0xf9, 0xc9, 0xec, 0x40, 0xd8, 0xf5, 0xcf, 0x9a,
+	0x75, 0xc6, 0xf6, 0x38, 0x65, 0x3b, 0x46, 0x41, 0xd0, 0xaa, 0xdb, 0xb7, 0xae, 0xdd, 0x71, 0xdf,
+	0xea, 0xbb, 0x5d, 0xdd, 0xfe, 0xd9, 0x20, 0x58, 0x05, 0x41, 0xf2, 0x46, 0xb0, 0x02, 0x48, 0x20,
+	0x50, 0x10, 0x41, 0x62, 0x09, 0x41, 0x48, 0x48, 0x48, 0x20, 0x01, 0x11, 0x12, 0x62, 0x05, 0x0f,
+	0xf6, 0x13, 0x42, 0x02, 0x1a, 0xad, 0x87, 0xa7, 0xfb, 0xc0, 0xc3, 0x7d, 0x34, 0x2f, 0xd1, 0xa9,
+	0xfe, 0xab, 0xee, 0xae, 0xbe, 0x9e, 0xb7, 0xee, 0xf3, 0x9d, 0x3a, 0x75, 0xbe, 0xfa, 0x3d, 0x75,
+	0xaa, 0xd4, 0x3b, 0x8e, 0xdd, 0xb8, 0x6f, 0xb9, 0xb4, 0x65, 0x6f, 0xde, 0x77, 0x3b, 0xbe, 0xed,
+	0x52, 0x16, 0xfd, 0x05, 0x1e, 0x86, 0xbf, 0x7b, 0x1d, 0xcf, 0xf5, 0x5d, 0xed, 0x42, 0x24, 0xbc,
+	0x35, 0x22, 0xa8, 0xfb, 0x01, 0xb5, 0xe9, 0x66, 0xa4, 0x70, 0xeb, 0x86, 0x00, 0x30, 0xfb, 0x43,
+	0x12, 0x8b, 0x2f, 0x92, 0x3d, 0x3f, 0xfa, 0xac, 0xfd, 0xcb, 0x2f, 0xab, 0x43, 0x2f, 0xa2, 0x1a,
+	0x66, 0xc5, 0x1a, 0xb4, 0x3f, 0x52, 0xd4, 0x6b, 0x8e, 0xcd, 0x7c, 0x42, 0x4d, 0xdc, 0x6c, 0x7a,
+	0x84, 0x31, 0xc2, 0x74, 0x65, 0xfc, 0xdc, 0xc4, 0xc5, 0x19, 0x76, 0x1c, 0x1a, 0x1a, 0xc2, 0xbb,
+	0x8b, 0x1c, 0x9e, 0x4e, 0xd0, 0x6e, 0x68, 0x5c, 0x75, 0xf2, 0xa2, 0x5e, 0x68, 0xdc, 0xd9, 0x6b,
+	0x3b, 0xcf, 0x6a, 0x39, 0x79, 0x6d, 0xbc, 0x49, 0x5a, 0x38, 0x70, 0xfc, 0x67, 0xb5, 0xf8, 0xa3,
+	0x76, 0x72, 0x58, 0xff, 0x6c, 0xfc, 0x7d, 0x70, 0x54, 0x97, 0x18, 0x47, 0x45, 0xd3, 0xda, 0xff,
+	0x29, 0xaa, 0xbe, 0xe9, 0xb8, 0x0d, 0xec, 0x98, 0x4d, 0x9b, 0x59, 0xee, 0x0e, 0xf1, 0xf6, 0x4d,
+	0x46, 0xbc, 0x1d, 0xe2, 0x31, 0xfd, 0x2c, 0x77, 0xf4, 0xaf, 0x95, 0xe3, 0xd0, 0x18, 0x44, 0x78,
+	0xf7, 0xe7, 0xb9, 0xde, 0x34, 0xa5, 0xab, 0x11, 0xde, 0x0d, 0x8d, 0x1b, 0x9b, 0x89, 0xcc, 0x0d,
+	0xa8, 0x45, 0x62, 0xa0, 0x17, 0x1a, 0x6f, 0x71, 0x87, 0x65, 0xa8, 0xc4, 0xef, 0xee, 0x61, 0x7d,
+	0x48, 0xa6, 0xda, 0x3b, 0xac, 0xcb, 0x2b, 0xc8, 0x13, 0x95, 0xf9, 0x86, 0x86, 0xa3, 0x82, 0x73,
+	0x09, 0xa9, 0x58, 0xae, 0xfd, 0xaf, 0x8c, 0x30, 0xa1, 0xb8, 0xe1, 0x90, 0xa6, 0x7e, 0x6e, 0x5c,
+	0x99, 0xf8, 0xdc, 0xcc, 0xc7, 0x40, 0xf8, 0x5a, 0x6a, 0xf1, 0xbd, 0x08, 0x2c, 0xb3, 0x8d, 0x81,
+	0x5e, 0x68, 0x7c, 0x49, 0xc2, 0x36, 0x46, 0x05, 0xba, 0xbe, 0x17, 0x10, 0xe0, 0x5a, 0x61, 0xa6,
+	0x0a, 0x38, 0x39, 0xac, 0x7f, 0x06, 0x8a, 0x1e, 0x1c, 0xd5, 0x4b, 0x4e, 0x95, 0x68, 0xc6, 0x72,
+	0xed, 0xbf, 0x14, 0x75, 0xc4, 0x71, 0x2d, 0x29, 0xcb, 0xcf, 0x70, 0x96, 0x7f, 0x02, 0x2c, 0xaf,
+	0x2e, 0xba, 0x96, 0x68, 0xaf, 0x1b, 0x1a, 0x43, 0x8e, 0x6b, 0x95, 0x7c, 0xe8, 0x85, 0xc6, 0x1b,
+	0xd1, 0x10, 0x74, 0xad, 0x57, 0xa1, 0x28, 0x37, 0x52, 0x21, 0x17, 0x08, 0x16, 0xfd, 0x41, 0x37,
+	0x78, 0x81, 0x12, 0xbd, 0x7f, 0x53, 0xd4, 0xc1, 0x88, 0x1e, 0x8e, 0x6d, 0x99, 0x1d, 0xd7, 0xf3,
+	0xf5, 0xf3, 0xe3, 0xca, 0xc4, 0xf9, 0x99, 0xdf, 0x07, 0x6a, 0x03, 0x89, 0xa9, 0x15, 0xd7, 0xf3,
+	0xbb, 0xa1, 0x71, 0x3d, 0x57, 0x35, 0x08, 0x7b, 0xa1, 0xf1, 0xc5, 0x32, 0x29, 0x40, 0x04, 0x46,
+	0x53, 0x0f, 0x26, 0xa7, 0xbe, 0x5c, 0x3b, 0x09, 0x8d, 0x73, 0x36, 0xf5, 0xbb, 0x87, 0x75, 0x89,
+	0x19, 0x99, 0xf0, 0xe4, 0xb0, 0x7e, 0x9e, 0x17, 0x3d, 0x38, 0xaa, 0xe7, 0x3c, 0x41, 0x65, 0x5d,
+	0xed, 0xd7, 0xcf, 0xaa, 0xe3, 0x05, 0x36, 0xed, 0xc0, 0xf1, 0x6d, 0x0b, 0x33, 0x3f, 0x59, 0x37,
+	0xf4, 0x0b, 0xe3, 0xca, 0xc4, 0xc5, 0x99, 0xbf, 0x05, 0x6a, 0x57, 0x12, 0x83, 0x4b, 0xb3, 0x30,
+	0x93, 0xbb, 0xa1, 0x31, 0x98, 0x33, 0x1a, 0x89, 0x7b, 0xa1, 0xf1, 0xa4, 0x4c, 0x2f, 0xc2, 0x04,
+	0x82, 0xbf, 0xd8, 0x6a, 0x3d, 0x98, 0x7a, 0xf6, 0xec, 0xe9, 0xc3, 0xa7, 0x8f, 0x7e, 0xe9, 0x59,
+	0xc4, 0xb6, 0x7b, 0x58, 0x97, 0x1a, 0x94, 0x8b, 0x4f, 0x0e, 0xeb, 0x5a, 0xd9, 0xc8, 0xc1, 0x51,
+	0xbd, 0xe0, 0x26, 0xfa, 0x7c, 0xbe, 0x70, 0xc2, 0x30, 0x5e, 0x8c, 0xb4, 0x17, 0xea, 0xe5, 0x36,
+	0xde, 0x33, 0x19, 0xa1, 0x4d, 0x73, 0xbb, 0xd1, 0x61, 0xfa, 0x67, 0x79, 0x67, 0xbe, 0xd9, 0x0d,
+	0x8d, 0x4b, 0x6d, 0xbc, 0xb7, 0x4a, 0x68, 0xf3, 0x79, 0xa3, 0x03, 0x8b, 0xcb, 0x75, 0x4e, 0x4b,
+	0x90, 0x25, 0xfd, 0x83, 0x44, 0xc5, 0xc4, 0xa0, 0x47, 0xac, 0x9d, 0xc8, 0xe0, 0xe7, 0x72, 0x06,
+	0x11, 0xb1, 0x76, 0x8a, 0x06, 0x13, 0x59, 0xce, 0x60, 0x22, 0xd4, 0xfe, 0x46, 0x51, 0x47, 0x3c,
+	0x62, 0xb9, 0x94, 0x12, 0x0b, 0x96, 0x77, 0xd3, 0xa6, 0x3e, 0xf1, 0x76, 0xb0, 0x63, 0x32, 0xfd,
+	0x22, 0xb7, 0xfd, 0xab, 0x7c, 0x51, 0x4f, 0x54, 0x16, 0x62, 0x78, 0x15, 0xd6, 0x0e, 0xb1, 0x60,
+	0x0a, 0xf4, 0x42, 0x63, 0x82, 0xd7, 0x2d, 0x45, 0x85, 0x5e, 0x7a, 0x32, 0x99, 0xb8, 0x74, 0x72,
+	0x58, 0x3f, 0xfb, 0x64, 0x92, 0xaf, 0xef, 0xa5, 0x7a, 0x90, 0xbc, 0x16, 0xad, 0xa5, 0x5e, 0xf1,
+	0x88, 0x83, 0xf7, 0x59, 0xba, 0x06, 0xa8, 0x7c, 0x0d, 0x78, 0xa7, 0x1b, 0x1a, 0x97, 0x23, 0x24,
+	0x9b, 0xe8, 0xb5, 0xd8, 0x21, 0x41, 0x5a, 0x9c, 0xe1, 0xc9, 0x8c, 0x45, 0xf9, 0xc2, 0xda, 0xb7,
+	0xcf, 0xaa, 0xa3, 0x71, 0x45, 0xa9, 0x23, 0x59, 0x23, 0xb5, 0xf5, 0x4b, 0xbc, 0x91, 0xfe, 0x09,
+	0xc6, 0xf0, 0x08, 0x02, 0xbd, 0x12, 0x85, 0xa5, 0x6e, 0x68, 0x8c, 0x78, 0x72, 0x28, 0x5d, 0x68,
+	0x2b, 0x70, 0xc1, 0xcb, 0x07, 0x93, 0xc2, 0x94, 0xad, 0xb4, 0x57, 0x0d, 0x41, 0x23, 0x3f, 0x80,
+	0x46, 0xae, 0x72, 0x13, 0xe9, 0x11, 0xcf, 0x32, 0xa2, 0x35, 0xd4, 0xcb, 0xcc, 0xc7, 0x9e, 0x6f,
+	0x36, 0x3c, 0x77, 0x97, 0x11, 0x4f, 0x1f, 0xe0, 0x6d, 0xfd, 0x95, 0x6e, 0x68, 0x0c, 0x70, 0x60,
+	0x26, 0x92, 0xf7, 0x42, 0xe3, 0x0b, 0x9c, 0x8e, 0x28, 0xac, 0x6c, 0xe9, 0x5c, 0x51, 0xed, 0x4f,
+	0x15, 0xf5, 0x06, 0xc5, 0xbe, 0xe9, 0x7b, 0x18, 0x76, 0x35, 0xec, 0xa4, 0x1d, 0x7b, 0x85, 0x57,
+	0xf6, 0xc1, 0x71, 0x68, 0xa8, 0xcb, 0xd3, 0x6b, 0xd9, 0xb2, 0xae, 0x52, 0xec, 0x67, 0x7d, 0x6c,
+	0xf0, 0x8a, 0x33, 0x91, 0x64, 0x09, 0x17, 0x0b, 0xe4, 0xfe, 0x84, 0xe5, 0x5a, 0xa8, 0x02, 0x0d,
+	0x52, 0xec, 0xaf, 0x25, 0xee, 0x24, 0x03, 0xe2, 0xef, 0x4a, 0x7e, 0x3a, 0x04, 0x33, 0x62, 0xb6,
+	0xf5, 0xab, 0x7c, 0x28, 0xfc, 0x26, 0x0c, 0x85, 0x8b, 0xcb, 0xd3, 0x6b, 0x8b, 0x20, 0x86, 0xce,
+	0xbf, 0x4a, 0xb1, 0x1f, 0xfd, 0xd8, 0x34, 0xf0, 0x09, 0x4b, 0x07, 0x64, 0x41, 0x2e, 0x9d, 0x1b,
+	0xdd, 0xc3, 0x7a, 0xa9, 0x7c, 0x59, 0x94, 0xce, 0xa0, 0xac, 0x62, 0xa4, 0x89, 0xde, 0x47, 0x32,
+	0xed, 0x5f, 0x15, 0x75, 0x24, 0xef, 0xbc, 0x47, 0x28, 0xd9, 0xe5, 0x23, 0xf9, 0x1a, 0x77, 0xff,
+	0x00, 0xdc, 0xbf, 0xb4, 0x3c, 0xbd, 0x86, 0x22, 0x00, 0x08, 0x5c, 0xa7, 0xd8, 0x4f, 0x7e, 0x53,
+	0x0a, 0xf5, 0x84, 0x42, 0x1e, 0x11, 0x48, 0x3c, 0x14, 0x49, 0x48, 0x6c, 0xc8, 0x84, 0x40, 0xe4,
+	0x21, 0x10, 0x11, 0x5d, 0x40, 0x43, 0x22, 0x95, 0x44, 0x2a, 0x21, 0xe3, 0xdb, 0x6d, 0xe2, 0x06,
+	0xbe, 0xc9, 0xf4, 0xeb, 0x79, 0x32, 0x6b, 0x11, 0xb0, 0x1a, 0x93, 0x49, 0x7e, 0x61, 0xa4, 0x37,
+	0x73, 0x64, 0xf2, 0x48, 0xd5, 0xf4, 0x93, 0xd8, 0x90, 0x09, 0xd3, 0x29, 0x27, 0xba, 0x90, 0x27,
+	0x93, 0x48, 0xb5, 0x3f, 0x50, 0x54, 0x3d, 0x60, 0x78, 0x93, 0x98, 0x1e, 0x81, 0x7d, 0xdf, 0xa6,
+	0x9b, 0x26, 0xb6, 0x2c, 0xd2, 0xf1, 0x49, 0x53, 0xd7, 0x38, 0x1b, 0x0c, 0x33, 0x60, 0x1d, 0x4d,
+	0xc7, 0x52, 0x98, 0x01, 0x81, 0x97, 0xfc, 0xf5, 0x42, 0xe3, 0x1a, 0x27, 0x91, 0x89, 0x04, 0x87,
+	0x45, 0xc5, 0xdc, 0x1f, 0x8c, 0xf8, 0xcc, 0x24, 0x1a, 0xe6, 0x2e, 0xa0, 0xc4, 0x83, 0x44, 0xae,
+	0x7d, 0x4b, 0x1d, 0x2a, 0x3a, 0xc7, 0x08, 0xa1, 0xfa, 0x20, 0x77, 0x6c, 0xe1, 0x38, 0x34, 0x2e,
+	0xac, 0xa3, 0x55, 0x42, 0x68, 0x37, 0x34, 0x2e, 0x04, 0x1e, 0x7c, 0xf5, 0x42, 0x63, 0x20, 0x76,
+	0x08, 0x7e, 0x05, 0x67, 0x12, 0x85, 0xf4, 0xeb, 0xe0, 0xa8, 0x1e, 0x17, 0x47, 0x5a, 0xde, 0x01,
+	0x90, 0x69, 0xbf, 0xa3, 0xa8, 0x37, 0x8b, 0xb5, 0x07, 0xd4, 0xfe, 0x20, 0x20, 0xa6, 0xdd, 0xd4,
+	0x87, 0x78, 0x10, 0xf1, 0x8d, 0xa8, 0x6d, 0xd6, 0xb9, 0x78, 0x61, 0x2e, 0x6a, 0x9b, 0xf8, 0x4f,
+	0x6c, 0x9b, 0x44, 0xa1, 0x16, 0x35, 0x4a, 0xf2, 0xdb, 0x13, 0xff, 0xe2, 0x46, 0x49, 0xb0, 0x62,
+	0xa3, 0x24, 0x5a, 0xda, 0x4f, 0x14, 0x75, 0xb0, 0xe4, 0x97, 0xe7, 0xe8, 0x37, 0xb8, 0x47, 0xbf,
+	0x05, 0x63, 0xef, 0xfc, 0x3a, 0x5a, 0x47, 0x8b, 0xdd, 0xd0, 0x38, 0x1f, 0x78, 0xeb, 0x68, 0xb1,
+	0x17, 0x1a, 0x4f, 0x13, 0x47, 0xd0, 0xa2, 0x30, 0xba, 0xb6, 0x7c, 0xbf, 0xc3, 0x9e, 0xdd, 0xbf,
+	0xdf, 0xc4, 0x3e, 0xbe, 0xc7, 0xf6, 0xa9, 0xe5, 0x6f, 0xc1, 0x61, 0x8d, 0x12, 0xff, 0x3e, 0x25,
+	0xbb, 0x20, 0x05, 0x87, 0x63, 0x23, 0xc9, 0xc7, 0xc9, 0x61, 0xfd, 0x15, 0x0a, 0x1e, 0x1c, 0xd5,
+	0x23, 0x2f, 0xd0, 0xf5, 0x02, 0x0f, 0xcf, 0xd1, 0xfe, 0x47, 0x51, 0x8d, 0x22, 0x85, 0x8e, 0xcb,
+	0x60, 0x87, 0x63, 0xc4, 0x0a, 0x3c, 0xe2, 0xec, 0xeb, 0xc3, 0x7c, 0xf9, 0xfd, 0x3d, 0x7e, 0x82,
+	0x58, 0x47, 0x2b, 0x2e, 0xf3, 0x17, 0x52, 0xb0, 0x1b, 0x1a, 0xd7, 0x02, 0x2f, 0x2f, 0xeb, 0x85,
+	0xc6, 0xeb, 0x31, 0xc9, 0x3c, 0x20, 0xf0, 0x6d, 0x61, 0x87, 0xf1, 0x25, 0xb9, 0x5c, 0x5a, 0x22,
+	0x83, 0xc8, 0x93, 0x97, 0x80, 0xf3, 0x42, 0xd1, 0x05, 0x74, 0x3b, 0x4f, 0x2b, 0x8f, 0x6a, 0xff,
+	0x2d, 0x61, 0x68, 0x53, 0xdb, 0xb7, 0xe1, 0x1c, 0x01, 0xfb, 0x9d, 0xc9, 0xf4, 0x11, 0x3e, 0x8a,
+	0x7f, 0x97, 0x9f, 0x1e, 0xd6, 0xd1, 0x42, 0x84, 0xce, 0x01, 0x08, 0x0b, 0xc6, 0xd5, 0xc0, 0xcb,
+	0x89, 0xd2, 0xe5, 0xa2, 0x20, 0x17, 0x17, 0x8b, 0xa7, 0x93, 0xb9, 0x05, 0xbc, 0x68, 0xa1, 0x2c,
+	0x82, 0x1d, 0x08, 0x4a, 0xc1, 0x81, 0xa1, 0xe0, 0x02, 0x1a, 0xcd, 0x13, 0xcc, 0x81, 0xda, 0x77,
+	0x14, 0x75, 0x04, 0x07, 0xbe, 0x6b, 0x06, 0x9d, 0x4d, 0x0f, 0x37, 0x49, 0x16, 0x9b, 0x6c, 0xe9,
+	0x37, 0x39, 0xaf, 0x15, 0x38, 0x01, 0x81, 0xca, 0x7a, 0xa4, 0x91, 0x6c, 0xeb, 0xef, 0xa7, 0x87,
+	0x05, 0x19, 0x28, 0xb2, 0x99, 0x12, 0x03, 0xb5, 0x07, 0x53, 0x48, 0x6a, 0x4d, 0x6b, 0xab, 0x23,
+	0x89, 0x0f, 0xbe, 0x6b, 0x76, 0x3c, 0x68, 0x71, 0xbe, 0x35, 0x32, 0xfd, 0x16, 0x1f, 0x42, 0x4f,
+	0xc0, 0x91, 0x58, 0x65, 0xcd, 0x5d, 0xf1, 0x08, 0x8a, 0xf1, 0x5e, 0x68, 0xdc, 0x8a, 0x5a, 0x54,
+	0x02, 0xd6, 0x90, 0xb4, 0x8c, 0xb6, 0xa3, 0x6a, 0xdb, 0x84, 0x74, 0x4c, 0x9f, 0xb4, 0x3b, 0xae,
+	0x87, 0x3d, 0x9b, 0x30, 0x73, 0x4b, 0x1f, 0xe5, 0x94, 0xdf, 0x87, 0x71, 0x09, 0xe8, 0x5a, 0x06,
+	0x02, 0xdd, 0xd7, 0x78, 0x2d, 0x45, 0x40, 0x3c, 0x1a, 0x3d, 0x12, 0xa9, 0x4e, 0x3d, 0x42, 0x25,
+	0x2b, 0xda, 0xbe, 0x3a, 0x68, 0x61, 0x6b, 0x8b, 0x98, 0xf6, 0x26, 0x75, 0x3d, 0xd2, 0x34, 0x5b,
+	0xb6, 0x43, 0x98, 0x7e, 0x9b, 0x53, 0x5c, 0x80, 0x0d, 0x86, 0xc3, 0x0b, 0x11, 0x3a, 0x0f, 0x60,
+	0xda, 0xd0, 0x25, 0xa4, 0x34, 0x25, 0xd2, 0xa1, 0x8e, 0xca, 0x66, 0xb4, 0xdf, 0x56, 0xd4, 0x5b,
+	0x1d, 0xcf, 0xdd, 0x84, 0xb3, 0x85, 0x19, 0x74, 0x9a, 0xd8, 0x27, 0x62, 0xbc, 0xfe, 0x79, 0xce,
+	0x7d, 0x0d, 0xc2, 0xcd, 0x44, 0x6b, 0x9d, 0x2b, 0x89, 0xb1, 0x79, 0x74, 0xe6, 0xad, 0xc0, 0x05,
+	0x77, 0x1e, 0x0b, 0x0d, 0xa1, 0x3c, 0x46, 0x55, 0x16, 0xb5, 0x6f, 0x2b, 0xea, 0xb0, 0x63, 0xb7,
+	0x6d, 0xdf, 0x6c, 0x60, 0xda, 0xdc, 0xb5, 0x9b, 0xfe, 0x96, 0x69, 0x53, 0xd3, 0xc1, 0x54, 0x1f,
+	0xe3, 0x4d, 0xb2, 0xc4, 0xcf, 0x72, 0xa0, 0x31, 0x93, 0x28, 0x2c, 0xd0, 0x45, 0x4c, 0xb3, 0xf3,
+	0x77, 0x19, 0xeb, 0xd3, 0x2c, 0x32, 0x53, 0xda, 0x47, 0x8a, 0xaa, 0xb5, 0x6d, 0x6a, 0x6e, 0xb9,
+	0x6d, 0x02, 0xd9, 0x81, 0x6d, 0xb3, 0xe5, 0x11, 0xa2, 0x1b, 0xe3, 0xca, 0xc4, 0xa5, 0xa9, 0x81,
+	0x7b, 0x51, 0xa2, 0xeb, 0xde, 0xaa, 0xfd, 0x21, 0x99, 0x79, 0xef, 0x93, 0xd0, 0x38, 0x03, 0xb3,
+	0xba, 0x6d, 0xd3, 0xf7, 0xdd, 0x36, 0x99, 0xb3, 0xd9, 0xf6, 0xbc, 0x47, 0x48, 0x3a, 0x3a, 0x0a,
+	0x72, 0x71, 0x1e, 0x8c, 0xdf, 0x01, 0x47, 0xce, 0x3d, 0x18, 0xbf, 0x83, 0x8a, 0xc5, 0xb5, 0x97,
+	0x8a, 0x3a, 0x90, 0x8c, 0x77, 0xbe, 0x0b, 0x8c, 0xf3, 0x5d, 0xe0, 0x1f, 0x79, 0x04, 0x92, 0x0c,
+	0xda, 0x68, 0x2f, 0xb8, 0xe4, 0x65, 0xbf, 0xbd, 0xd0, 0x98, 0x4b, 0x0e, 0x00, 0x89, 0x4c, 0xb2,
+	0x2f, 0xc4, 0x33, 0x80, 0x15, 0x96, 0xf8, 0x36, 0xf1, 0xf1, 0xbd, 0x6f, 0x32, 0x97, 0xc2, 0x52,
+	0x9a, 0x33, 0x9b, 0xff, 0x3d, 0x39, 0xac, 0x4f, 0xbc, 0xaa, 0x29, 0x08, 0x57, 0x04, 0x7f, 0x51,
+	0x66, 0xc7, 0x73, 0xb4, 0x0d, 0xf5, 0x3a, 0x76, 0x76, 0xe1, 0x30, 0x14, 0x1d, 0xee, 0x29, 0xf1,
+	0x99, 0xfe, 0x05, 0x9e, 0x53, 0x83, 0x33, 0xe8, 0xd5, 0x08, 0xe4, 0x87, 0xe4, 0x65, 0xe2, 0xc3,
+	0xc0, 0x1f, 0x8a, 0x56, 0x98, 0x9c, 0xbc, 0x86, 0x8a, 0x8a, 0xda, 0xff, 0x2b, 0xea, 0x04, 0xa4,
+	0x43, 0x76, 0x3d, 0xdb, 0x87, 0x85, 0xa3, 0xed, 0xfa, 0xc4, 0x6c, 0x92, 0x1d, 0xdb, 0x22, 0x26,
+	0xc5, 0x6d, 0xc2, 0x4c, 0x97, 0x9a, 0xf1, 0xb9, 0x44, 0xaf, 0x65, 0xd9, 0x9e, 0x91, 0x17, 0x49,
+	0x21, 0xc4, 0xcb, 0xcc, 0x91, 0x9d, 0x65, 0x50, 0xef, 0x86, 0xc6, 0x6b, 0x6e, 0x09, 0xb2, 0x2d,
+	0xc2, 0xd1, 0x17, 0x74, 0x36, 0x32, 0xd5, 0x0b, 0x8d, 0xb7, 0xb9, 0x83, 0xaf, 0xa0, 0x5b, 0x3d,
+	0x28, 0xe1, 0x50, 0x55, 0xe1, 0x07, 0x7a, 0x15, 0x2f, 0xb4, 0x5f, 0x53, 0x6f, 0xc0, 0x32, 0x66,
+	0xda, 0xb4, 0x49, 0xf6, 0x4c, 0x18, 0xc9, 0x0d, 0xc7, 0xb5, 0xb6, 0x99, 0xfe, 0x1a, 0x9f, 0xd2,
+	0x30, 0x68, 0x34, 0x50, 0x58, 0x00, 0x7c, 0xc9, 0xa6, 0x33, 0x1c, 0x4d, 0x93, 0xa8, 0x65, 0x48,
+	0x1a, 0xb8, 0x46, 0xe1, 0x28, 0x92, 0x58, 0xd2, 0xfe, 0x13, 0xa2, 0x4f, 0x8a, 0xad, 0x6d, 0xd2,
+	0x34, 0xa9, 0xeb, 0xdb, 0x2d, 0xdb, 0xc2, 0x51, 0x3a, 0xa0, 0xc9, 0xf4, 0x3a, 0xef, 0xdf, 0x1f,
+	0x40, 0x73, 0x0f, 0xaf, 0x47, 0x4a, 0xcb, 0x82, 0xce, 0xc2, 0x1c, 0xb4, 0xf6, 0x70, 0x20, 0x45,
+	0x7a, 0xa1, 0x31, 0x1a, 0x2d, 0xed, 0x32, 0x98, 0xa7, 0x0e, 0xa5, 0x48, 0xef, 0xb0, 0x5e, 0x61,
+	0xf1, 0xe0, 0xa8, 0x5e, 0xe1, 0x05, 0x92, 0x96, 0x68, 0x32, 0x0d, 0xa9, 0x97, 0x7d, 0x0f, 0xb7,
+	0x5a, 0xb6, 0x65, 0x5a, 0x0e, 0x66, 0x4c, 0xbf, 0xc3, 0x9b, 0xf5, 0x2e, 0x1c, 0x5f, 0x63, 0x60,
+	0x16, 0xe4, 0xbd, 0xd0, 0xd0, 0xa2, 0x06, 0x15, 0x84, 0x69, 0xde, 0x24, 0xa7, 0xaa, 0x7d, 0x4b,
+	0x1d, 0x8c, 0x9b, 0xd8, 0x6c, 0xb9, 0x4e, 0x93, 0x78, 0x66, 0x07, 0xfb, 0x5b, 0xfa, 0xeb, 0x7c,
+	0xd6, 0x3f, 0x3f, 0x0e, 0x8d, 0xd1, 0x39, 0xd2, 0xf1, 0x88, 0x85, 0x7d, 0xd2, 0x9c, 0x8b, 0x14,
+	0xe7, 0xb9, 0xde, 0x0a, 0xf6, 0xb7, 0xba, 0xa1, 0xa1, 0xdc, 0x4d, 0x0f, 0xcb, 0xcd, 0x22, 0xfc,
+	0x96, 0xdb, 0xb6, 0xa1, 0x93, 0xfc, 0xfd, 0x9a, 0xae, 0xa0, 0xeb, 0x25, 0x5c, 0xdb, 0x56, 0xaf,
+	0x31, 0xe2, 0x9b, 0x8e, 0xbb, 0x6b, 0x76, 0x3c, 0xdb, 0xf5, 0x6c, 0x7f, 0x5f, 0xff, 0x22, 0x9f,
+	0x14, 0xd3, 0xdd, 0xd0, 0xb8, 0xc2, 0x88, 0xbf, 0xe8, 0xee, 0xae, 0xc4, 0x48, 0xba, 0xb2, 0xe5,
+	0xc5, 0x95, 0xc7, 0xf2, 0x42, 0x71, 0xed, 0x63, 0x45, 0x1d, 0x86, 0xa4, 0x53, 0x4c, 0xd3, 0x72,
+	0xa9, 0x15, 0x78, 0x1e, 0xa1, 0xd6, 0xbe, 0x3e, 0xc1, 0xdb, 0x91, 0xf1, 0xdc, 0x07, 0xde, 0x5d,
+	0xc2, 0x7b, 0x91, 0x8f, 0xb3, 0x99, 0x0a, 0x6c, 0xf9, 0x6d, 0x89, 0x3c, 0xdd, 0xf2, 0x65, 0x60,
+	0xd2, 0xe4, 0x3c, 0x59, 0x21, 0xb7, 0x8b, 0xa4, 0x56, 0x21, 0x47, 0x3c, 0x68, 0x79, 0x98, 0x6d,
+	0x15, 0x42, 0xf2, 0x37, 0x78, 0xb7, 0xfc, 0x88, 0x87, 0xe4, 0xb3, 0x49, 0x48, 0x6e, 0xc5, 0x21,
+	0xf9, 0x7c, 0xb4, 0x37, 0x43, 0xb1, 0x2c, 0x38, 0x96, 0x2e, 0xc3, 0x5c, 0xa7, 0x1c, 0x66, 0x73,
+	0x31, 0x8c, 0xe5, 0xeb, 0x25, 0x23, 0x10, 0xac, 0x5b, 0x71, 0xb0, 0x5e, 0x7f, 0x15, 0x33, 0x10,
+	0xae, 0xcf, 0x46, 0xe1, 0x7a, 0xc1, 0x98, 0xe7, 0x68, 0x7f, 0xac, 0xa8, 0x23, 0x45, 0x7a, 0x49,
+	0x96, 0xe4, 0x4b, 0xbc, 0xff, 0x6d, 0x48, 0x3e, 0xcc, 0x22, 0x21, 0xc1, 0x9f, 0xb7, 0x52, 0x4c,
+	0xf0, 0x4b, 0xd1, 0xaa, 0xa1, 0x01, 0xf9, 0x85, 0xd4, 0x36, 0x92, 0x5b, 0xd6, 0x7e, 0x43, 0x51,
+	0x87, 0x99, 0x1f, 0x50, 0x13, 0x22, 0x27, 0xec, 0xd8, 0x3b, 0xc4, 0x8c, 0x72, 0x47, 0x4c, 0x7f,
+	0x33, 0x8d, 0x47, 0x07, 0x41, 0xe3, 0x79, 0xa2, 0xb0, 0x0a, 0xf8, 0x6a, 0x1a, 0x25, 0x49, 0xb0,
+	0x7c, 0x6c, 0x2d, 0x2c, 0x68, 0xe7, 0x1e, 0x3c, 0x9d, 0x44, 0x32, 0x6b, 0x70, 0x64, 0x2d, 0xb8,
+	0x01, 0xeb, 0x2a, 0xd3, 0xdf, 0xe2, 0x4e, 0x7c, 0x15, 0x02, 0xb5, 0x5c, 0xb1, 0x25, 0x9b, 0x66,
+	0xa1, 0x7d, 0x09, 0x11, 0x63, 0xc4, 0xdc, 0x82, 0x3a, 0x35, 0x89, 0xca, 0x76, 0x20, 0x2a, 0x1f,
+	0xe0, 0xb5, 0x27, 0xf7, 0x4e, 0x77, 0xf9, 0x1a, 0xda, 0x84, 0x4c, 0x37, 0xc2, 0xbb, 0xab, 0x7e,
+	0x20, 0xdc, 0x38, 0x5d, 0x62, 0xd9, 0x6f, 0x9a, 0x1b, 0xca, 0x64, 0xa7, 0xde, 0x8a, 0x15, 0x2c,
+	0x22, 0xd1, 0x9e, 0xb6, 0xa3, 0x5e, 0x6d, 0x62, 0x1f, 0x37, 0x20, 0x45, 0x15, 0x5d, 0x01, 0xea,
+	0xf7, 0xc6, 0x95, 0x89, 0x2b, 0x53, 0x57, 0x92, 0xb0, 0x68, 0x8d, 0x4b, 0x79, 0x32, 0xef, 0x4a,
+	0xa2, 0x1a, 0xc9, 0xd2, 0x95, 0x23, 0x2f, 0xae, 0x8d, 0x7b, 0x84, 0x77, 0x69, 0x3c, 0x3c, 0x3e,
+	0x3a, 0xaa, 0x2b, 0xa8, 0x50, 0x54, 0xfb, 0xfe, 0x59, 0xf5, 0x35, 0x58, 0x35, 0xd2, 0xe5, 0x02,
+	0xce, 0x94, 0x96, 0xdb, 0x86, 0x21, 0xeb, 0x91, 0x0f, 0x02, 0xc2, 0x7c, 0x73, 0xdb, 0x6e, 0xe8,
+	0xf7, 0x79, 0x77, 0xfc, 0xb3, 0x12, 0x5f, 0x1d, 0x2e, 0xe1, 0xbd, 0xd9, 0x05, 0x14, 0xe1, 0xcf,
+	0xed, 0x99, 0x6e, 0x68, 0x18, 0x6d, 0xbc, 0x97, 0x4e, 0x71, 0x7f, 0x21, 0xb6, 0x91, 0xa9, 0xa4,
+	0xbb, 0xe0, 0x29, 0x7a, 0xc2, 0x79, 0xec, 0x54, 0x93, 0xa7, 0xab, 0xc4, 0x97, 0x91, 0x05, 0x77,
+	0xd1, 0x29, 0xc5, 0x1a, 0x70, 0x57, 0x37, 0x9c, 0xde, 0x88, 0x38, 0x58, 0xbc, 0x43, 0x9d, 0xe4,
+	0x13, 0xf8, 0xc7, 0xd0, 0x12, 0x43, 0xc9, 0x8d, 0xc2, 0xe2, 0xf4, 0xb2, 0x78, 0x8d, 0x3a, 0x84,
+	0x25, 0xf2, 0x34, 0x90, 0x96, 0x81, 0xb2, 0x8b, 0x2c, 0xa9, 0x91, 0x0a, 0xb9, 0x30, 0xf5, 0xa5,
+	0x4e, 0xa1, 0xac, 0x14, 0x16, 0xee, 0x60, 0x77, 0xd4, 0x5b, 0xfc, 0xd2, 0xa3, 0x15, 0x38, 0x4e,
+	0x1c, 0xd5, 0xb8, 0x34, 0x39, 0xa2, 0xea, 0x0f, 0x38, 0xd3, 0x67, 0x10, 0x35, 0x80, 0xd6, 0x7c,
+	0xe0, 0x38, 0x3c, 0x1e, 0x79, 0x41, 0xe3, 0x43, 0x65, 0x2f, 0x34, 0x6e, 0xc7, 0x5b, 0x96, 0x0c,
+	0xae, 0xa1, 0x8a, 0x72, 0xda, 0x57, 0xd5, 0xcb, 0x2d, 0x82, 0xfd, 0xc0, 0x23, 0x66, 0xcb, 0xc1,
+	0x9b, 0x4c, 0x9f, 0xe2, 0xf3, 0xee, 0x0e, 0xec, 0xf4, 0x31, 0x30, 0x0f, 0xf2, 0xf4, 0x82, 0x44,
+	0x10, 0xd6, 0x50, 0x4e, 0x45, 0xdb, 0x55, 0x47, 0x84, 0x7b, 0x91, 0xe8, 0x8c, 0x43, 0xa8, 0x1b,
+	0x6c, 0x6e, 0xe9, 0x0f, 0xf9, 0xa0, 0x7d, 0x87, 0x2f, 0xaf, 0xa9, 0xca, 0x22, 0x68, 0xbc, 0xc7,
+	0x15, 0xd2, 0xa8, 0x47, 0x8a, 0xa6, 0x11, 0x85, 0xbc, 0xb0, 0xb6, 0xad, 0x0e, 0x95, 0x2a, 0x6e,
+	0xe3, 0x3d, 0xfd, 0x11, 0xaf, 0xf5, 0x6d, 0x08, 0x06, 0x0b, 0x05, 0x97, 0xf0, 0x5e, 0x2f, 0x34,
+	0x74, 0x59, 0x95, 0x4b, 0x78, 0x2f, 0xad, 0x4f, 0x52, 0x4c, 0xfb, 0xce, 0x59, 0xd5, 0x48, 0x92,
+	0x3d, 0x26, 0x76, 0x20, 0xa4, 0x70, 0x9d, 0xa6, 0xe9, 0x3b, 0xcc, 0x84, 0xf5, 0xc3, 0x76, 0x29,
+	0xd3, 0x1f, 0xf3, 0xfe, 0xfa, 0x09, 0x8c, 0xcc, 0xd1, 0x24, 0xb5, 0x32, 0x0d, 0xaa, 0x2f, 0x9c,
+	0xe6, 0xda, 0xe2, 0xea, 0xd7, 0x63, 0xbd, 0x6e, 0x68, 0x8c, 0xda, 0xd5, 0x70, 0x1a, 0xef, 0xf4,
+	0xd1, 0x81, 0xf1, 0xd9, 0xd7, 0x46, 0x7f, 0xf8, 0xe0, 0xa8, 0xde, 0xcf, 0x41, 0x54, 0x2e, 0xeb,
+	0xb0, 0x04, 0xd4, 0x8e, 0x14, 0x75, 0x54, 0x68, 0xf7, 0x24, 0xb0, 0x32, 0x7d, 0xab, 0xc3, 0x8f,
+	0xb3, 0x4f, 0x78, 0xf3, 0x7f, 0x0f, 0x5a, 0x41, 0x9f, 0x4d, 0xf5, 0x92, 0x30, 0x69, 0x6d, 0x76,
+	0x65, 0x71, 0x7a, 0xb9, 0x1b, 0x1a, 0xba, 0x55, 0xc6, 0xac, 0x4e, 0x74, 0xe0, 0x7d, 0xb3, 0xd0,
+	0x43, 0x79, 0x85, 0x3e, 0x41, 0xfb, 0xc1, 0x51, 0xbd, 0xb2, 0x4e, 0x54, 0x59, 0xa3, 0xf6, 0xef,
+	0x8a, 0x7a, 0x5b, 0x46, 0xe9, 0x83, 0xc0, 0xb6, 0x38, 0xa7, 0x2f, 0x73, 0x4e, 0xdf, 0x07, 0x4e,
+	0x37, 0xcb, 0xf6, 0xbf, 0xb6, 0xbe, 0x30, 0x1b, 0x91, 0xba, 0x59, 0xae, 0xe2, 0x6b, 0x81, 0x6d,
+	0x45, 0xac, 0xde, 0xaa, 0x60, 0x15, 0x6b, 0xf4, 0xd9, 0x3a, 0x0f, 0x8e, 0xea, 0xd5, 0xd5, 0xa2,
+	0xea, 0x4a, 0xfb, 0xf6, 0xd5, 0x2e, 0xa6, 0xfa, 0xd3, 0xd3, 0xfa, 0x6a, 0xa3, 0x4f, 0x5f, 0x6d,
+	0x9c, 0xd6, 0x57, 0x1b, 0x98, 0x4a, 0xaf, 0x39, 0xd2, 0xcb, 0x8b, 0xca, 0x3a, 0x51, 0x65, 0x8d,
+	0xfd, 0xfb, 0x0a, 0x38, 0xbd, 0x7d, 0x6a, 0x5f, 0x6d, 0xf4, 0xeb, 0xab, 0x8d, 0x53, 0xfb, 0x2a,
+	0x4f, 0xeb, 0x51, 0x8e, 0xd6, 0xa3, 0x3e, 0x7d, 0xb5, 0x51, 0xdd, 0x57, 0x40, 0xec, 0x40, 0x51,
+	0x6f, 0xca, 0x88, 0xf1, 0xdb, 0x46, 0xfd, 0x19, 0x67, 0xf5, 0x75, 0x48, 0x5a, 0x95, 0x4d, 0xf0,
+	0x9b, 0xca, 0x2c, 0x56, 0x95, 0xe3, 0x62, 0xd2, 0x2a, 0xe7, 0xf3, 0xe3, 0x49, 0x54, 0x65, 0x53,
+	0xfb, 0x7b, 0x45, 0xbd, 0x23, 0x73, 0x2a, 0xcd, 0x60, 0x6e, 0x79, 0x84, 0x6d, 0xb9, 0x4e, 0x53,
+	0xff, 0x19, 0xee, 0xe0, 0x37, 0xbb, 0xa1, 0x21, 0x71, 0x20, 0xde, 0x77, 0xd6, 0x12, 0xed, 0x5e,
+	0x68, 0x3c, 0xaa, 0xf0, 0xb5, 0xa8, 0x2a, 0xb8, 0x2d, 0x7a, 0xad, 0x4c, 0xa2, 0x57, 0x28, 0x5c,
+	0x39, 0x01, 0x76, 0x19, 0xe3, 0x13, 0xfb, 0xe7, 0xfa, 0x4e, 0x80, 0x8d, 0xd5, 0xd5, 0xca, 0xc5,
+	0x6a, 0x83, 0xb1, 0xfe, 0x8b, 0x55, 0xa4, 0x20, 0xce, 0xea, 0xc7, 0xb9, 0x59, 0xfd, 0x58, 0x3e,
+	0x01, 0xa2, 0x3a, 0x51, 0x65, 0x8d, 0x7d, 0x29, 0xc1, 0xf8, 0x7f, 0xe7, 0x34, 0x4a, 0x1b, 0x7d,
+	0x28, 0x6d, 0x9c, 0x46, 0xa9, 0x30, 0xf8, 0x73, 0x94, 0x1e, 0x55, 0x53, 0xda, 0xa8, 0xa4, 0xb4,
+	0x11, 0xe5, 0x25, 0x45, 0x4a, 0x6d, 0x7b, 0x8f, 0x34, 0x13, 0x62, 0x36, 0x61, 0xfa, 0x2c, 0xdf,
+	0x58, 0xdf, 0xcd, 0x4f, 0xda, 0x25, 0xd0, 0x5a, 0x49, 0x95, 0xd2, 0xab, 0xed, 0x4a, 0x8d, 0x1a,
+	0xaa, 0x2e, 0xad, 0x3d, 0x57, 0x2f, 0x77, 0x3c, 0x77, 0x6f, 0x3f, 0x7d, 0x74, 0xf3, 0xb3, 0xfc,
+	0x28, 0xfc, 0x3a, 0x44, 0x44, 0x1c, 0x88, 0x83, 0xb6, 0x34, 0xf7, 0x21, 0x0a, 0x6b, 0x28, 0xa7,
+	0x03, 0x8f, 0x2e, 0x22, 0x63, 0x2d, 0xec, 0x38, 0x0d, 0x6c, 0x6d, 0xeb, 0x5f, 0xc9, 0x1e, 0x5d,
+	0x70, 0x64, 0x3e, 0x06, 0xd2, 0x73, 0x4c, 0x4e, 0x5a, 0xfd, 0xe8, 0x22, 0xa7, 0x06, 0x6b, 0xa1,
+	0xce, 0x33, 0x60, 0x66, 0x94, 0x6a, 0xe7, 0x8f, 0x68, 0xec, 0x0f, 0xe1, 0x08, 0xd7, 0xd0, 0xdf,
+	0xe5, 0xe3, 0xe0, 0x0f, 0x79, 0x9c, 0xcc, 0xd3, 0x57, 0xb3, 0xa0, 0xb3, 0x84, 0xf7, 0x20, 0xbf,
+	0xbb, 0xc4, 0xcf, 0x0c, 0x43, 0x0d, 0x89, 0x3c, 0x4d, 0x39, 0xc8, 0x40, 0xe1, 0x74, 0x20, 0x2f,
+	0x5c, 0x21, 0x87, 0xb8, 0x58, 0xe6, 0x04, 0x92, 0x69, 0x37, 0xb4, 0xef, 0x96, 0x9f, 0x45, 0x05,
+	0x34, 0xf7, 0x28, 0x8a, 0x30, 0x7d, 0x9a, 0xc7, 0xac, 0xff, 0xc0, 0x07, 0x7a, 0xf2, 0xde, 0x68,
+	0x9d, 0x8a, 0x0f, 0x8b, 0xf8, 0x61, 0x20, 0xff, 0xf6, 0xa8, 0xa8, 0x90, 0x46, 0x5b, 0x7d, 0xb4,
+	0x78, 0xb4, 0xd5, 0x07, 0xef, 0x1d, 0xd6, 0xfb, 0x57, 0x02, 0xd3, 0xa2, 0xca, 0x43, 0xd4, 0xbf,
+	0x28, 0x9c, 0x84, 0x46, 0x8b, 0x2f, 0xc4, 0x9a, 0x34, 0x7b, 0xce, 0x33, 0xc3, 0x47, 0xd6, 0x0f,
+	0xf9, 0x4b, 0xcd, 0xa4, 0x8e, 0xa5, 0xb9, 0xe5, 0xd5, 0x2c, 0xb5, 0xa1, 0xe7, 0x2a, 0x10, 0xb0,
+	0x5e, 0x68, 0x8c, 0x95, 0xb9, 0x0b, 0x0a, 0x40, 0xbc, 0xba, 0x74, 0x1f, 0x0c, 0x9e, 0x66, 0x4a,
+	0x9c, 0x41, 0x85, 0x02, 0x4d, 0x9a, 0xbe, 0x1f, 0xfa, 0x15, 0x75, 0x20, 0xe8, 0xd0, 0x4e, 0x4a,
+	0xeb, 0xcf, 0xe6, 0x39, 0xaf, 0x5f, 0x38, 0x0e, 0x8d, 0x1b, 0x59, 0x86, 0x70, 0x7d, 0x85, 0xae,
+	0x64, 0xc4, 0x94, 0xbb, 0xe9, 0x01, 0x02, 0xca, 0xc6, 0x80, 0x90, 0x15, 0x3c, 0x38, 0xaa, 0xcb,
+	0x0b, 0xeb, 0x0a, 0xba, 0x24, 0x14, 0xd1, 0x7e, 0xa8, 0xc4, 0xd5, 0x27, 0x6f, 0x54, 0x3e, 0x9e,
+	0xe7, 0xb3, 0xe7, 0x23, 0x3e, 0x7b, 0xf2, 0x26, 0xd2, 0xf7, 0x2a, 0xbc, 0xfa, 0xf1, 0xb4, 0x7a,
+	0xf1, 0x9d, 0x89, 0xe0, 0x43, 0x36, 0x61, 0x6e, 0x55, 0x6b, 0xc1, 0xf4, 0x90, 0xd5, 0xa2, 0x2b,
+	0x48, 0xcd, 0x4a, 0x69, 0x7f, 0xa5, 0xa8, 0x57, 0xb8, 0x9b, 0xd9, 0x6b, 0x94, 0x3f, 0x8f, 0x1c,
+	0xfd, 0x2e, 0xcf, 0x3a, 0xe7, 0x4d, 0x08, 0x2f, 0x53, 0x94, 0xbb, 0xe9, 0x42, 0x03, 0xe5, 0xf3,
+	0x6f, 0x49, 0xa4, 0xce, 0xde, 0xee, 0xa7, 0x07, 0xb9, 0x65, 0x79, 0x5d, 0xba, 0x82, 0x06, 0xc4,
+	0x92, 0x99, 0xcb, 0xd9, 0x9b, 0x93, 0x1f, 0x55, 0xbb, 0x2c, 0xbc, 0x3f, 0x29, 0xb8, 0x9c, 0x7f,
+	0x31, 0x52, 0xed, 0x72, 0x95, 0x5e, 0xd9, 0xe5, 0x44, 0x33, 0x71, 0x39, 0xf9, 0xd7, 0x5a, 0x6a,
+	0xf4, 0xb6, 0x2d, 0x4d, 0x4a, 0xfd, 0xc5, 0x3c, 0x5f, 0x69, 0xde, 0xcd, 0xfb, 0xcb, 0x03, 0xa4,
+	0x2c, 0x3b, 0x25, 0x0c, 0x46, 0x2f, 0x43, 0xf2, 0x29, 0xea, 0x01, 0x01, 0x61, 0xfc, 0x4a, 0xb0,
+	0x7c, 0x1b, 0x67, 0x76, 0x2c, 0x5f, 0xff, 0x31, 0x34, 0x91, 0x32, 0xb3, 0x74, 0x1c, 0x1a, 0xb7,
+	0xb3, 0x1a, 0x97, 0xf2, 0x77, 0x69, 0x2b, 0x96, 0x9f, 0x6f, 0xa7, 0x76, 0x09, 0xcf, 0x57, 0xaf,
+	0x95, 0x15, 0x20, 0x03, 0x37, 0x54, 0xc8, 0x3f, 0x31, 0x0b, 0x53, 0xa6, 0xff, 0x65, 0xd4, 0x4b,
+	0x6b, 0x05, 0x17, 0xc4, 0xbc, 0xcd, 0x2a, 0x28, 0x16, 0x5c, 0x28, 0xe1, 0xe5, 0xae, 0xe2, 0x9e,
+	0x94, 0xf4, 0x66, 0x9e, 0x7f, 0xf2, 0xe9, 0xd8, 0x99, 0xa3, 0x4f, 0xc7, 0xce, 0x7c, 0x72, 0x3c,
+	0xa6, 0x1c, 0x1d, 0x8f, 0x29, 0xdf, 0x7b, 0x39, 0x76, 0xe6, 0x07, 0x2f, 0xc7, 0x94, 0xa3, 0x97,
+	0x63, 0x67, 0xfe, 0xe3, 0xe5, 0xd8, 0x99, 0x6f, 0xbc, 0xb1, 0x69, 0xfb, 0x5b, 0x41, 0xe3, 0x9e,
+	0xe5, 0xb6, 0xef, 0xa7, 0x59, 0x61, 0xe1, 0x2b, 0x7b, 0xac, 0xdf, 0xb8, 0xc0, 0x5f, 0xe7, 0x3f,
+	0xfc, 0xe9, 0x00, 0x6e, 0xee, 0x54, 0x5d, 0x09, 0x30, 0x00, 0x00,
 }
 
 func (m *OptionsConfiguration) Marshal() (dAtA []byte, err error) {
@@ -458,6 +499,83 @@ func (m *OptionsConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if m.ConnectionMixedPriorities {
+		i--
+		if m.ConnectionMixedPriorities {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x98
+	}
+	if m.LocalAnnMDNSEnabled {
+		i--
+		if m.LocalAnnMDNSEnabled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x90
+	}
+	if len(m.LocalAnnUnicastAddresses) > 0 {
+		for iNdEx := len(m.LocalAnnUnicastAddresses) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LocalAnnUnicastAddresses[iNdEx])
+			copy(dAtA[i:], m.LocalAnnUnicastAddresses[iNdEx])
+			i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.LocalAnnUnicastAddresses[iNdEx])))
+			i--
+			dAtA[i] = 0x4
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if m.BlockCacheMaxSizeMiB != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.BlockCacheMaxSizeMiB))
+		i--
+		dAtA[i] = 0x4
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.ConnectionPriorityWSSWAN != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.ConnectionPriorityWSSWAN))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xf8
+	}
+	if m.ConnectionPriorityWSSLAN != 0 {
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.ConnectionPriorityWSSLAN))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xf0
+	}
+	if m.ProxyFallback {
+		i--
+		if m.ProxyFallback {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xe8
+	}
+	if len(m.ProxyAddress) > 0 {
+		i -= len(m.ProxyAddress)
+		copy(dAtA[i:], m.ProxyAddress)
+		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(len(m.ProxyAddress)))
+		i--
+		dAtA[i] = 0x3
+		i--
+		dAtA[i] = 0xe2
+	}
 	if m.ConnectionPriorityUpgradeThreshold != 0 {
 		i = encodeVarintOptionsconfiguration(dAtA, i, uint64(m.ConnectionPriorityUpgradeThreshold))
 		i--
@@ -1159,6 +1277,34 @@ func (m *OptionsConfiguration) ProtoSize() (n int) {
 	if m.ConnectionPriorityUpgradeThreshold != 0 {
 		n += 2 + sovOptionsconfiguration(uint64(m.ConnectionPriorityUpgradeThreshold))
 	}
+	l = len(m.ProxyAddress)
+	if l > 0 {
+		n += 2 + l + sovOptionsconfiguration(uint64(l))
+	}
+	if m.ProxyFallback {
+		n += 3
+	}
+	if m.ConnectionPriorityWSSLAN != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.ConnectionPriorityWSSLAN))
+	}
+	if m.ConnectionPriorityWSSWAN != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.ConnectionPriorityWSSWAN))
+	}
+	if m.BlockCacheMaxSizeMiB != 0 {
+		n += 2 + sovOptionsconfiguration(uint64(m.BlockCacheMaxSizeMiB))
+	}
+	if len(m.LocalAnnUnicastAddresses) > 0 {
+		for _, s := range m.LocalAnnUnicastAddresses {
+			l = len(s)
+			n += 2 + l + sovOptionsconfiguration(uint64(l))
+		}
+	}
+	if m.LocalAnnMDNSEnabled {
+		n += 3
+	}
+	if m.ConnectionMixedPriorities {
+		n += 3
+	}
 	if m.DeprecatedUPnPEnabled {
 		n += 4
 	}
@@ -2489,6 +2635,187 @@ func (m *OptionsConfiguration) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 60:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyAddress", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProxyAddress = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 61:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyFallback", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ProxyFallback = bool(v != 0)
+		case 62:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionPriorityWSSLAN", wireType)
+			}
+			m.ConnectionPriorityWSSLAN = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConnectionPriorityWSSLAN |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 63:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionPriorityWSSWAN", wireType)
+			}
+			m.ConnectionPriorityWSSWAN = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConnectionPriorityWSSWAN |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 64:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockCacheMaxSizeMiB", wireType)
+			}
+			m.BlockCacheMaxSizeMiB = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockCacheMaxSizeMiB |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 65:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalAnnUnicastAddresses", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOptionsconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LocalAnnUnicastAddresses = append(m.LocalAnnUnicastAddresses, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 66:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LocalAnnMDNSEnabled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LocalAnnMDNSEnabled = bool(v != 0)
+		case 67:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConnectionMixedPriorities", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOptionsconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ConnectionMixedPriorities = bool(v != 0)
 		case 9000:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field DeprecatedUPnPEnabled", wireType)
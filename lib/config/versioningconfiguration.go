@@ -17,11 +17,12 @@ import (
 
 // internalVersioningConfiguration is used in XML serialization
 type internalVersioningConfiguration struct {
-	Type             string            `xml:"type,attr,omitempty"`
-	Params           []internalParam   `xml:"param"`
-	CleanupIntervalS int               `xml:"cleanupIntervalS" default:"3600"`
-	FSPath           string            `xml:"fsPath"`
-	FSType           fs.FilesystemType `xml:"fsType"`
+	Type             string              `xml:"type,attr,omitempty"`
+	Params           []internalParam     `xml:"param"`
+	CleanupIntervalS int                 `xml:"cleanupIntervalS" default:"3600"`
+	FSPath           string              `xml:"fsPath"`
+	FSType           fs.FilesystemType   `xml:"fsType"`
+	Retention        VersioningRetention `xml:"retention"`
 }
 
 type internalParam struct {
@@ -35,6 +36,7 @@ func (c VersioningConfiguration) Copy() VersioningConfiguration {
 	for k, v := range c.Params {
 		cp.Params[k] = v
 	}
+	cp.Retention.Rules = append([]VersioningRetentionRule(nil), c.Retention.Rules...)
 	return cp
 }
 
@@ -69,6 +71,7 @@ func (c *VersioningConfiguration) toInternal() internalVersioningConfiguration {
 	tmp.CleanupIntervalS = c.CleanupIntervalS
 	tmp.FSPath = c.FSPath
 	tmp.FSType = c.FSType
+	tmp.Retention = c.Retention
 	for k, v := range c.Params {
 		tmp.Params = append(tmp.Params, internalParam{k, v})
 	}
@@ -83,6 +86,7 @@ func (c *VersioningConfiguration) fromInternal(intCfg internalVersioningConfigur
 	c.CleanupIntervalS = intCfg.CleanupIntervalS
 	c.FSPath = intCfg.FSPath
 	c.FSType = intCfg.FSType
+	c.Retention = intCfg.Retention
 	c.Params = make(map[string]string, len(intCfg.Params))
 	for _, p := range intCfg.Params {
 		c.Params[p.Key] = p.Val
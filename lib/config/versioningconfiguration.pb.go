@@ -26,11 +26,12 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 // VersioningConfiguration is used in the code and for JSON serialization
 type VersioningConfiguration struct {
-	Type             string            `protobuf:"bytes,1,opt,name=type,proto3" json:"type" xml:"type,attr"`
-	Params           map[string]string `protobuf:"bytes,2,rep,name=parameters,proto3" json:"params" xml:"parameter" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	CleanupIntervalS int               `protobuf:"varint,3,opt,name=cleanup_interval_s,json=cleanupIntervalS,proto3,casttype=int" json:"cleanupIntervalS" xml:"cleanupIntervalS" default:"3600"`
-	FSPath           string            `protobuf:"bytes,4,opt,name=fs_path,json=fsPath,proto3" json:"fsPath" xml:"fsPath"`
-	FSType           fs.FilesystemType `protobuf:"varint,5,opt,name=fs_type,json=fsType,proto3,enum=fs.FilesystemType" json:"fsType" xml:"fsType"`
+	Type             string              `protobuf:"bytes,1,opt,name=type,proto3" json:"type" xml:"type,attr"`
+	Params           map[string]string   `protobuf:"bytes,2,rep,name=parameters,proto3" json:"params" xml:"parameter" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CleanupIntervalS int                 `protobuf:"varint,3,opt,name=cleanup_interval_s,json=cleanupIntervalS,proto3,casttype=int" json:"cleanupIntervalS" xml:"cleanupIntervalS" default:"3600"`
+	FSPath           string              `protobuf:"bytes,4,opt,name=fs_path,json=fsPath,proto3" json:"fsPath" xml:"fsPath"`
+	FSType           fs.FilesystemType   `protobuf:"varint,5,opt,name=fs_type,json=fsType,proto3,enum=fs.FilesystemType" json:"fsType" xml:"fsType"`
+	Retention        VersioningRetention `protobuf:"bytes,6,opt,name=retention,proto3" json:"retention" xml:"retention"`
 }
 
 func (m *VersioningConfiguration) Reset()         { *m = VersioningConfiguration{} }
@@ -66,9 +67,96 @@ func (m *VersioningConfiguration) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_VersioningConfiguration proto.InternalMessageInfo
 
+// Limits on the versions kept, applied when cleaning out versions in
+// addition to the parameters of the versioning type. Zero means no limit.
+// The first rule matching a file replaces the max versions limit and adds
+// a max age for its versions.
+type VersioningRetention struct {
+	MaxVersions     int                       `protobuf:"varint,1,opt,name=max_versions,json=maxVersions,proto3,casttype=int" json:"maxVersions" xml:"maxVersions"`
+	MaxTotalSizeMiB int64                     `protobuf:"varint,2,opt,name=max_total_size_mib,json=maxTotalSizeMib,proto3" json:"maxTotalSizeMiB" xml:"maxTotalSizeMiB"`
+	MinDiskFree     Size                      `protobuf:"bytes,3,opt,name=min_disk_free,json=minDiskFree,proto3" json:"minDiskFree" xml:"minDiskFree"`
+	Rules           []VersioningRetentionRule `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules" xml:"rule"`
+}
+
+func (m *VersioningRetention) Reset()         { *m = VersioningRetention{} }
+func (m *VersioningRetention) String() string { return proto.CompactTextString(m) }
+func (*VersioningRetention) ProtoMessage()    {}
+func (*VersioningRetention) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95ba6bdb22ffea81, []int{1}
+}
+func (m *VersioningRetention) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VersioningRetention) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VersioningRetention.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VersioningRetention) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersioningRetention.Merge(m, src)
+}
+func (m *VersioningRetention) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *VersioningRetention) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersioningRetention.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersioningRetention proto.InternalMessageInfo
+
+// Patterns without a slash match the file name, others the path within
+// the folder.
+type VersioningRetentionRule struct {
+	Pattern     string `protobuf:"bytes,1,opt,name=pattern,proto3" json:"pattern" xml:"pattern,attr"`
+	MaxVersions int    `protobuf:"varint,2,opt,name=max_versions,json=maxVersions,proto3,casttype=int" json:"maxVersions" xml:"maxVersions,attr"`
+	MaxAgeDays  int    `protobuf:"varint,3,opt,name=max_age_days,json=maxAgeDays,proto3,casttype=int" json:"maxAgeDays" xml:"maxAgeDays,attr"`
+}
+
+func (m *VersioningRetentionRule) Reset()         { *m = VersioningRetentionRule{} }
+func (m *VersioningRetentionRule) String() string { return proto.CompactTextString(m) }
+func (*VersioningRetentionRule) ProtoMessage()    {}
+func (*VersioningRetentionRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95ba6bdb22ffea81, []int{2}
+}
+func (m *VersioningRetentionRule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VersioningRetentionRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VersioningRetentionRule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VersioningRetentionRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersioningRetentionRule.Merge(m, src)
+}
+func (m *VersioningRetentionRule) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *VersioningRetentionRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersioningRetentionRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersioningRetentionRule proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*VersioningConfiguration)(nil), "config.VersioningConfiguration")
 	proto.RegisterMapType((map[string]string)(nil), "config.VersioningConfiguration.ParametersEntry")
+	proto.RegisterType((*VersioningRetention)(nil), "config.VersioningRetention")
+	proto.RegisterType((*VersioningRetentionRule)(nil), "config.VersioningRetentionRule")
 }
 
 func init() {
@@ -76,40 +164,60 @@ func init() {
 }
 
 var fileDescriptor_95ba6bdb22ffea81 = []byte{
-	// 514 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x53, 0x4f, 0x6b, 0xdb, 0x4e,
-	0x10, 0x95, 0xfc, 0xef, 0x87, 0x95, 0x1f, 0x4d, 0x59, 0x0a, 0x15, 0x3e, 0x68, 0x8d, 0x70, 0x8b,
-	0x0a, 0x45, 0x0e, 0x09, 0x94, 0x62, 0x0a, 0x05, 0x97, 0xa6, 0x94, 0xf6, 0x10, 0x94, 0xd0, 0x43,
-	0x7b, 0x30, 0x6b, 0x77, 0x65, 0x2f, 0x91, 0x57, 0x42, 0xbb, 0x36, 0x51, 0x3f, 0x45, 0xe8, 0x27,
-	0xe8, 0xc7, 0xf1, 0xcd, 0x3e, 0xf6, 0xb4, 0x10, 0xfb, 0xa6, 0xa3, 0x8e, 0xe9, 0xa5, 0xec, 0xae,
-	0xa2, 0x9a, 0x94, 0xde, 0xe6, 0xcd, 0x7b, 0xf3, 0x66, 0x46, 0xb3, 0xb2, 0xbc, 0x88, 0x8c, 0xfb,
-	0x93, 0x98, 0x86, 0x64, 0xda, 0x5f, 0xe2, 0x94, 0x91, 0x98, 0x12, 0x3a, 0xd5, 0x89, 0x45, 0x8a,
-	0x38, 0x89, 0xa9, 0x9f, 0xa4, 0x31, 0x8f, 0x41, 0x4b, 0x27, 0x3b, 0x40, 0x56, 0x84, 0xac, 0xcf,
-	0xb3, 0x04, 0x33, 0xcd, 0x75, 0xda, 0xf8, 0x8a, 0xeb, 0xd0, 0xfd, 0xd5, 0xb0, 0x1e, 0x7f, 0xaa,
-	0x8c, 0xde, 0xec, 0x1b, 0x81, 0x57, 0x56, 0x43, 0x56, 0xd9, 0x66, 0xd7, 0xf4, 0xda, 0x43, 0x2f,
-	0x17, 0x50, 0xe1, 0x42, 0xc0, 0xc3, 0xab, 0x79, 0x34, 0x70, 0x25, 0x78, 0x8e, 0x38, 0x4f, 0xdd,
-	0x7c, 0xdd, 0x6b, 0x57, 0x28, 0x50, 0x2a, 0x70, 0x6d, 0x5a, 0x56, 0x82, 0x52, 0x34, 0xc7, 0x1c,
-	0xa7, 0xcc, 0xae, 0x75, 0xeb, 0xde, 0xc1, 0x71, 0xdf, 0xd7, 0x63, 0xf9, 0xff, 0xe8, 0xe9, 0x9f,
-	0x55, 0x15, 0x6f, 0x29, 0x4f, 0xb3, 0xe1, 0xeb, 0x95, 0x80, 0xc6, 0x56, 0xc0, 0x96, 0x22, 0x58,
-	0x2e, 0x60, 0x4b, 0x99, 0xb2, 0x6a, 0x8a, 0xaa, 0x87, 0x5b, 0xac, 0x7b, 0x25, 0xf9, 0x7d, 0xd3,
-	0x2b, 0x0b, 0x82, 0xbd, 0x19, 0xc0, 0x37, 0x0b, 0x4c, 0x22, 0x8c, 0xe8, 0x22, 0x19, 0x11, 0xca,
-	0x71, 0xba, 0x44, 0xd1, 0x88, 0xd9, 0xf5, 0xae, 0xe9, 0x35, 0x87, 0x1f, 0x73, 0x01, 0x1f, 0x96,
-	0xec, 0xfb, 0x92, 0x3c, 0x2f, 0x04, 0x7c, 0xa2, 0x9a, 0xdc, 0x27, 0xdc, 0xee, 0x57, 0x1c, 0xa2,
-	0x45, 0xc4, 0x07, 0xee, 0xc9, 0x8b, 0xa3, 0x23, 0xf7, 0x56, 0xc0, 0x3a, 0xa1, 0xfc, 0x76, 0xdd,
-	0x6b, 0x48, 0x1c, 0xfc, 0xe5, 0x04, 0xde, 0x59, 0xff, 0x85, 0x6c, 0x94, 0x20, 0x3e, 0xb3, 0x1b,
-	0xea, 0x7b, 0xfa, 0x72, 0xab, 0xd3, 0xf3, 0x33, 0xc4, 0x67, 0x72, 0xab, 0x90, 0xc9, 0xa8, 0x10,
-	0xf0, 0x7f, 0xd5, 0x50, 0x43, 0x57, 0x2e, 0xa2, 0x35, 0x41, 0xa9, 0x00, 0x5f, 0x94, 0x91, 0x3a,
-	0x4c, 0xb3, 0x6b, 0x7a, 0x0f, 0x8e, 0x81, 0x1f, 0x32, 0xff, 0x94, 0x44, 0x98, 0x65, 0x8c, 0xe3,
-	0xf9, 0x45, 0x96, 0xe0, 0x3b, 0x73, 0x19, 0x6b, 0xf3, 0x0b, 0x7d, 0xb8, 0x3b, 0x73, 0x09, 0x4b,
-	0x73, 0x19, 0x06, 0xa5, 0xa2, 0x33, 0xb7, 0x0e, 0xef, 0x5d, 0x00, 0x3c, 0xb5, 0xea, 0x97, 0x38,
-	0x2b, 0x1f, 0xc1, 0xa3, 0x5c, 0x40, 0x09, 0x0b, 0x01, 0xdb, 0xca, 0xea, 0x12, 0x67, 0x6e, 0x20,
-	0x33, 0xc0, 0xb7, 0x9a, 0x4b, 0x14, 0x2d, 0xb0, 0x5d, 0x53, 0x4a, 0x3b, 0x17, 0x50, 0x27, 0x0a,
-	0x01, 0x0f, 0x94, 0x56, 0x21, 0x37, 0xd0, 0xd9, 0x41, 0xed, 0xa5, 0x39, 0xfc, 0xb0, 0xba, 0x71,
-	0x8c, 0xcd, 0x8d, 0x63, 0xac, 0xb6, 0x8e, 0xb9, 0xd9, 0x3a, 0xe6, 0xf5, 0xce, 0x31, 0x7e, 0xec,
-	0x1c, 0x73, 0xb3, 0x73, 0x8c, 0x9f, 0x3b, 0xc7, 0xf8, 0xfc, 0x6c, 0x4a, 0xf8, 0x6c, 0x31, 0xf6,
-	0x27, 0xf1, 0xbc, 0xcf, 0x32, 0x3a, 0xe1, 0x33, 0x42, 0xa7, 0x7b, 0xd1, 0x9f, 0xff, 0x61, 0xdc,
-	0x52, 0x2f, 0xfa, 0xe4, 0x77, 0x00, 0x00, 0x00, 0xff, 0xff, 0xd1, 0x04, 0xd0, 0xd5, 0x24, 0x03,
-	0x00, 0x00,
+	// 844 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x55, 0x4f, 0x8f, 0xdb, 0x44,
+	0x14, 0x8f, 0xf3, 0xaf, 0xca, 0x64, 0x21, 0x65, 0xa0, 0x34, 0x5a, 0xa4, 0x4c, 0x64, 0x02, 0x0a,
+	0x02, 0x39, 0x65, 0x2b, 0x21, 0xb4, 0x42, 0x42, 0x35, 0xcb, 0x22, 0x54, 0x8a, 0x2a, 0xef, 0x8a,
+	0xc3, 0x72, 0xb0, 0x26, 0xd9, 0x49, 0x76, 0xb4, 0xb6, 0x13, 0x79, 0x26, 0xab, 0x78, 0x3f, 0x45,
+	0xd5, 0x4f, 0xc0, 0x85, 0x6f, 0xc0, 0x57, 0x40, 0xda, 0xdb, 0xe6, 0xc8, 0x01, 0x8d, 0xd4, 0xcd,
+	0xcd, 0x47, 0x1f, 0x7b, 0x42, 0x33, 0x1e, 0x3b, 0xae, 0x43, 0x7b, 0x7b, 0xef, 0xf7, 0x7e, 0xef,
+	0xf7, 0x66, 0xfc, 0x7e, 0xb6, 0xc1, 0xd0, 0xa3, 0xe3, 0xd1, 0x64, 0x1e, 0x4c, 0xe9, 0x6c, 0x74,
+	0x45, 0x42, 0x46, 0xe7, 0x01, 0x0d, 0x66, 0x29, 0xb0, 0x0c, 0x31, 0xa7, 0xf3, 0xc0, 0x5a, 0x84,
+	0x73, 0x3e, 0x87, 0xcd, 0x14, 0xdc, 0x7f, 0x50, 0xe8, 0x60, 0xf4, 0x9a, 0xa4, 0xe5, 0x7d, 0x28,
+	0xe1, 0x29, 0x1b, 0xf1, 0x68, 0x41, 0x98, 0xc6, 0x5a, 0x64, 0xc5, 0xd3, 0xd0, 0xfc, 0xb7, 0x01,
+	0x1e, 0xfe, 0x96, 0xeb, 0xff, 0x50, 0xd4, 0x87, 0xdf, 0x81, 0xba, 0xec, 0xea, 0x1a, 0x7d, 0x63,
+	0xd8, 0xb2, 0x87, 0xb1, 0x40, 0x2a, 0x4f, 0x04, 0xea, 0xac, 0x7c, 0xef, 0xd0, 0x94, 0xc9, 0x57,
+	0x98, 0xf3, 0xd0, 0x8c, 0x6f, 0x07, 0xad, 0x3c, 0x73, 0x14, 0x0b, 0xbe, 0x30, 0x00, 0x58, 0xe0,
+	0x10, 0xfb, 0x84, 0x93, 0x90, 0x75, 0xab, 0xfd, 0xda, 0xb0, 0x7d, 0x30, 0xb2, 0xd2, 0x13, 0x5a,
+	0x6f, 0x99, 0x69, 0x3d, 0xcf, 0x3b, 0x7e, 0x0c, 0x78, 0x18, 0xd9, 0xdf, 0xdf, 0x08, 0x54, 0xb9,
+	0x13, 0xa8, 0xa9, 0x0a, 0x2c, 0x16, 0xa8, 0xa9, 0x44, 0x59, 0x7e, 0x8a, 0x7c, 0x86, 0x99, 0xdc,
+	0x0e, 0x74, 0xf1, 0xe5, 0x7a, 0xa0, 0x1b, 0x9c, 0xc2, 0x19, 0xe0, 0x35, 0x80, 0x13, 0x8f, 0xe0,
+	0x60, 0xb9, 0x70, 0x69, 0xc0, 0x49, 0x78, 0x85, 0x3d, 0x97, 0x75, 0x6b, 0x7d, 0x63, 0xd8, 0xb0,
+	0x7f, 0x89, 0x05, 0xba, 0xaf, 0xab, 0x3f, 0xeb, 0xe2, 0x49, 0x22, 0xd0, 0x67, 0x6a, 0x48, 0xb9,
+	0x60, 0xf6, 0xcf, 0xc9, 0x14, 0x2f, 0x3d, 0x7e, 0x68, 0x3e, 0xfe, 0xe6, 0xd1, 0x23, 0xf3, 0xb5,
+	0x40, 0x35, 0x1a, 0xf0, 0xd7, 0xb7, 0x83, 0xba, 0xcc, 0x9d, 0x1d, 0x25, 0xf8, 0x13, 0xb8, 0x37,
+	0x65, 0xee, 0x02, 0xf3, 0x8b, 0x6e, 0x5d, 0x3d, 0x4f, 0x4b, 0xde, 0xea, 0xf8, 0xe4, 0x39, 0xe6,
+	0x17, 0xf2, 0x56, 0x53, 0x26, 0xa3, 0x44, 0xa0, 0x3d, 0x35, 0x30, 0x4d, 0x4d, 0x79, 0x91, 0x94,
+	0xe3, 0x68, 0x06, 0xfc, 0x5d, 0x09, 0xa9, 0xc5, 0x34, 0xfa, 0xc6, 0xf0, 0xfd, 0x03, 0x68, 0x4d,
+	0x99, 0x75, 0x4c, 0x3d, 0xc2, 0x22, 0xc6, 0x89, 0x7f, 0x1a, 0x2d, 0x48, 0x26, 0x2e, 0xe3, 0x54,
+	0xfc, 0x34, 0x5d, 0x5c, 0x26, 0x2e, 0x53, 0x2d, 0x2e, 0x43, 0x47, 0x33, 0xa0, 0x0b, 0x5a, 0x21,
+	0xe1, 0x24, 0x90, 0xbb, 0xe8, 0x36, 0xfb, 0xc6, 0xb0, 0x7d, 0xf0, 0xc9, 0xee, 0xca, 0x9c, 0x8c,
+	0x62, 0x0f, 0xe4, 0x7a, 0x62, 0x81, 0xb6, 0x5d, 0xf9, 0x5e, 0x72, 0xc4, 0x74, 0xb6, 0xd5, 0x7d,
+	0x1f, 0x74, 0x4a, 0x2b, 0x86, 0x9f, 0x83, 0xda, 0x25, 0x89, 0xb4, 0xcb, 0x3e, 0x8a, 0x05, 0x92,
+	0x69, 0x22, 0x50, 0x4b, 0xc9, 0x5c, 0x92, 0xc8, 0x74, 0x24, 0x02, 0x2d, 0xd0, 0xb8, 0xc2, 0xde,
+	0x92, 0x74, 0xab, 0x8a, 0xd9, 0x8d, 0x05, 0x4a, 0x81, 0x44, 0xa0, 0xb6, 0xe2, 0xaa, 0xcc, 0x74,
+	0x52, 0xf4, 0xb0, 0xfa, 0xad, 0x61, 0xfe, 0x5d, 0x03, 0x1f, 0xfe, 0xcf, 0xb9, 0xe1, 0xaf, 0x60,
+	0xcf, 0xc7, 0x2b, 0x57, 0xbf, 0x59, 0x4c, 0x0d, 0x6f, 0xd8, 0x5f, 0xc6, 0x02, 0xb5, 0x7d, 0xbc,
+	0xd2, 0x1d, 0xd2, 0x63, 0x1f, 0x28, 0xe1, 0x02, 0x96, 0xad, 0xda, 0x29, 0x12, 0xe1, 0x9f, 0x06,
+	0x80, 0x52, 0x90, 0xcf, 0xb9, 0xf4, 0x14, 0xbd, 0x26, 0xae, 0x4f, 0xc7, 0xea, 0xa4, 0x35, 0x7b,
+	0x75, 0x27, 0x50, 0xe7, 0x19, 0x5e, 0x9d, 0xca, 0xe2, 0x09, 0xbd, 0x26, 0xcf, 0xa8, 0x1d, 0x0b,
+	0xd4, 0xf1, 0xdf, 0x84, 0x12, 0x81, 0x1e, 0x64, 0xd3, 0x8a, 0xb8, 0x7c, 0xbb, 0x76, 0xb8, 0xbb,
+	0xd0, 0xcb, 0xf5, 0xa0, 0x3c, 0xc4, 0x29, 0x71, 0xc6, 0xf0, 0x0c, 0xbc, 0xe7, 0xd3, 0xc0, 0x3d,
+	0xa7, 0xec, 0xd2, 0x9d, 0x86, 0x84, 0x28, 0xf3, 0xb7, 0x0f, 0xf6, 0xb2, 0x1d, 0x4b, 0x9e, 0x3d,
+	0xd4, 0x4b, 0x6d, 0xfb, 0x34, 0x38, 0xa2, 0xec, 0xf2, 0x38, 0x24, 0x64, 0xfb, 0x28, 0xb6, 0x98,
+	0xe9, 0x14, 0x19, 0xf0, 0x0c, 0x34, 0xc2, 0xa5, 0x47, 0x58, 0xb7, 0xae, 0x5e, 0x75, 0xf4, 0x0e,
+	0xdf, 0x38, 0x4b, 0x8f, 0xd8, 0x9f, 0xea, 0x31, 0x69, 0x57, 0x22, 0x10, 0x48, 0x7d, 0xb3, 0xf4,
+	0x88, 0xbc, 0x72, 0x5d, 0x06, 0x4e, 0x5a, 0x34, 0xff, 0xaa, 0x82, 0x87, 0x6f, 0xd1, 0x81, 0x4f,
+	0xc1, 0xbd, 0x05, 0xe6, 0x9c, 0x84, 0x81, 0xf6, 0xd0, 0xd7, 0xb1, 0x40, 0x19, 0x94, 0x08, 0x04,
+	0xf5, 0x67, 0x42, 0xe5, 0xf9, 0xf7, 0x6a, 0xaf, 0x08, 0x38, 0x19, 0x1d, 0xce, 0x4a, 0xc6, 0xa8,
+	0x2a, 0x63, 0x1c, 0xed, 0x1a, 0xe3, 0xe3, 0xb2, 0x31, 0x52, 0x65, 0xed, 0x8e, 0xf8, 0x76, 0x70,
+	0xbf, 0x5c, 0x7b, 0xd3, 0x31, 0x93, 0x74, 0x10, 0x9e, 0x11, 0xf7, 0x1c, 0x47, 0xd9, 0x57, 0xe8,
+	0x49, 0x2c, 0x10, 0xf0, 0xf1, 0xea, 0xc9, 0x8c, 0x1c, 0xe1, 0x88, 0x15, 0x2d, 0xa1, 0xa1, 0xf2,
+	0x98, 0x4e, 0xa9, 0xe4, 0x14, 0xda, 0xed, 0xa7, 0x37, 0xaf, 0x7a, 0x95, 0xf5, 0xab, 0x5e, 0xe5,
+	0xe6, 0xae, 0x67, 0xac, 0xef, 0x7a, 0xc6, 0x8b, 0x4d, 0xaf, 0xf2, 0xc7, 0xa6, 0x67, 0xac, 0x37,
+	0xbd, 0xca, 0x3f, 0x9b, 0x5e, 0xe5, 0xec, 0x8b, 0x19, 0xe5, 0x17, 0xcb, 0xb1, 0x35, 0x99, 0xfb,
+	0x23, 0x16, 0x05, 0x13, 0x7e, 0x41, 0x83, 0x59, 0x21, 0xda, 0xfe, 0x54, 0xc6, 0x4d, 0xf5, 0xc7,
+	0x78, 0xfc, 0xdf, 0x00, 0x4a, 0x52, 0x0a, 0x76, 0x9b, 0x06, 0x00, 0x00,
 }
 
 func (m *VersioningConfiguration) Marshal() (dAtA []byte, err error) {
@@ -132,6 +240,16 @@ func (m *VersioningConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	_ = i
 	var l int
 	_ = l
+	{
+		size, err := m.Retention.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintVersioningconfiguration(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
 	if m.FSType != 0 {
 		i = encodeVarintVersioningconfiguration(dAtA, i, uint64(m.FSType))
 		i--
@@ -178,6 +296,103 @@ func (m *VersioningConfiguration) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *VersioningRetention) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VersioningRetention) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VersioningRetention) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Rules) > 0 {
+		for iNdEx := len(m.Rules) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Rules[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintVersioningconfiguration(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	{
+		size, err := m.MinDiskFree.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintVersioningconfiguration(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.MaxTotalSizeMiB != 0 {
+		i = encodeVarintVersioningconfiguration(dAtA, i, uint64(m.MaxTotalSizeMiB))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MaxVersions != 0 {
+		i = encodeVarintVersioningconfiguration(dAtA, i, uint64(m.MaxVersions))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *VersioningRetentionRule) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VersioningRetentionRule) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VersioningRetentionRule) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxAgeDays != 0 {
+		i = encodeVarintVersioningconfiguration(dAtA, i, uint64(m.MaxAgeDays))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxVersions != 0 {
+		i = encodeVarintVersioningconfiguration(dAtA, i, uint64(m.MaxVersions))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Pattern) > 0 {
+		i -= len(m.Pattern)
+		copy(dAtA[i:], m.Pattern)
+		i = encodeVarintVersioningconfiguration(dAtA, i, uint64(len(m.Pattern)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintVersioningconfiguration(dAtA []byte, offset int, v uint64) int {
 	offset -= sovVersioningconfiguration(v)
 	base := offset
@@ -217,6 +432,50 @@ func (m *VersioningConfiguration) ProtoSize() (n int) {
 	if m.FSType != 0 {
 		n += 1 + sovVersioningconfiguration(uint64(m.FSType))
 	}
+	l = m.Retention.ProtoSize()
+	n += 1 + l + sovVersioningconfiguration(uint64(l))
+	return n
+}
+
+func (m *VersioningRetention) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MaxVersions != 0 {
+		n += 1 + sovVersioningconfiguration(uint64(m.MaxVersions))
+	}
+	if m.MaxTotalSizeMiB != 0 {
+		n += 1 + sovVersioningconfiguration(uint64(m.MaxTotalSizeMiB))
+	}
+	l = m.MinDiskFree.ProtoSize()
+	n += 1 + l + sovVersioningconfiguration(uint64(l))
+	if len(m.Rules) > 0 {
+		for _, e := range m.Rules {
+			l = e.ProtoSize()
+			n += 1 + l + sovVersioningconfiguration(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *VersioningRetentionRule) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Pattern)
+	if l > 0 {
+		n += 1 + l + sovVersioningconfiguration(uint64(l))
+	}
+	if m.MaxVersions != 0 {
+		n += 1 + sovVersioningconfiguration(uint64(m.MaxVersions))
+	}
+	if m.MaxAgeDays != 0 {
+		n += 1 + sovVersioningconfiguration(uint64(m.MaxAgeDays))
+	}
 	return n
 }
 
@@ -484,6 +743,314 @@ func (m *VersioningConfiguration) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Retention", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVersioningconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Retention.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVersioningconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VersioningRetention) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVersioningconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VersioningRetention: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VersioningRetention: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxVersions", wireType)
+			}
+			m.MaxVersions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVersioningconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxVersions |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTotalSizeMiB", wireType)
+			}
+			m.MaxTotalSizeMiB = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVersioningconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxTotalSizeMiB |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinDiskFree", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVersioningconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinDiskFree.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVersioningconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rules = append(m.Rules, VersioningRetentionRule{})
+			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVersioningconfiguration(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *VersioningRetentionRule) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVersioningconfiguration
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VersioningRetentionRule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VersioningRetentionRule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pattern", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVersioningconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthVersioningconfiguration
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxVersions", wireType)
+			}
+			m.MaxVersions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVersioningconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxVersions |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAgeDays", wireType)
+			}
+			m.MaxAgeDays = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVersioningconfiguration
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAgeDays |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipVersioningconfiguration(dAtA[iNdEx:])
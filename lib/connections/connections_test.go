@@ -27,6 +27,7 @@ import (
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/nat"
 	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/protocol/mocks"
 	"github.com/syncthing/syncthing/lib/sync"
 	"github.com/syncthing/syncthing/lib/tlsutil"
 )
@@ -374,6 +375,7 @@ func TestConnectionEstablishment(t *testing.T) {
 	addrs := []string{
 		"tcp://127.0.0.1:0",
 		"quic://127.0.0.1:0",
+		"wss://127.0.0.1:0/bep",
 	}
 
 	send := make([]byte, 128<<10)
@@ -504,3 +506,88 @@ func mustGetCert(b interface{ Fatal(...interface{}) }) tls.Certificate {
 	}
 	return cert
 }
+
+func TestVerifyProxyAddress(t *testing.T) {
+	cases := map[string]bool{
+		"":                           true,
+		"socks5://127.0.0.1:1080":    true,
+		"http://proxy.example.com:8": true,
+		"proxy.example.com:3128":     false,
+		"ftp://proxy.example.com":    false,
+	}
+	for address, ok := range cases {
+		var cfg config.Configuration
+		cfg.Options.ProxyAddress = address
+		if err := (&service{}).VerifyConfiguration(cfg, cfg); (err == nil) != ok {
+			t.Errorf("VerifyConfiguration with proxy address %q: %v, expected ok %v", address, err, ok)
+		}
+	}
+}
+
+func TestMixedPriorityConnections(t *testing.T) {
+	newConn := func(id string, prio int) (*mocks.Connection, chan struct{}) {
+		closed := make(chan struct{}, 1)
+		conn := &mocks.Connection{}
+		conn.DeviceIDReturns(protocol.LocalDeviceID)
+		conn.ConnectionIDReturns(id)
+		conn.PriorityReturns(prio)
+		conn.CloseStub = func(error) { closed <- struct{}{} }
+		return conn, closed
+	}
+	hello := protocol.Hello{NumConnections: 2}
+
+	// Mixing: a LAN connection arriving besides a WAN one keeps both.
+
+	var c deviceConnectionTracker
+	wan, wanClosed := newConn("wan", 30)
+	lan, lanClosed := newConn("lan", 10)
+	c.accountAddedConnection(wan, hello, 0, 2)
+	c.accountAddedConnection(lan, hello, 0, 2)
+	select {
+	case <-wanClosed:
+		t.Fatal("WAN connection should be kept when mixing")
+	case <-lanClosed:
+		t.Fatal("LAN connection should be kept")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A third, better connection replaces the worst one only.
+
+	lan2, lan2Closed := newConn("lan2", 10)
+	c.accountAddedConnection(lan2, hello, 0, 2)
+	select {
+	case <-wanClosed:
+	case <-time.After(time.Second):
+		t.Fatal("WAN connection should be closed beyond the wanted count")
+	}
+	select {
+	case <-lanClosed:
+		t.Fatal("LAN connection should be kept")
+	case <-lan2Closed:
+		t.Fatal("LAN connection should be kept")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Without mixing, the LAN connection replaces the WAN one.
+
+	c = deviceConnectionTracker{}
+	wan, wanClosed = newConn("wan", 30)
+	lan, _ = newConn("lan", 10)
+	c.accountAddedConnection(wan, hello, 0, 0)
+	c.accountAddedConnection(lan, hello, 0, 0)
+	select {
+	case <-wanClosed:
+	case <-time.After(time.Second):
+		t.Fatal("WAN connection should be replaced without mixing")
+	}
+}
+
+func TestPreferUnusedPriorities(t *testing.T) {
+	targets := []dialTarget{{addr: "lan", priority: 10}, {addr: "wan", priority: 30}}
+	if res := preferUnusedPriorities(targets, []int{10}); len(res) != 1 || res[0].addr != "wan" {
+		t.Errorf("expected only the WAN target, got %v", res)
+	}
+	if res := preferUnusedPriorities(targets, []int{10, 30}); len(res) != 2 {
+		t.Errorf("expected all targets when all priorities are used, got %v", res)
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/connections/registry"
+	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/protocol"
 )
 
@@ -123,7 +124,11 @@ func (quicDialerFactory) AlwaysWAN() bool {
 }
 
 func (quicDialerFactory) Valid(_ config.Configuration) error {
-	// Always valid
+	// QUIC can't go through the proxy, so it's only used when we may
+	// connect directly.
+	if !dialer.DirectAllowed() {
+		return errProxyRequired
+	}
 	return nil
 }
 
@@ -21,6 +21,8 @@ import (
 
 func init() {
 	dialers["relay"] = relayDialerFactory{}
+	dialers["relay+quic"] = relayDialerFactory{}
+	dialers["relay+wss"] = relayDialerFactory{}
 }
 
 type relayDialer struct {
@@ -33,15 +35,19 @@ func (d *relayDialer) Dial(ctx context.Context, id protocol.DeviceID, uri *url.U
 		return internalConn{}, err
 	}
 
-	conn, err := client.JoinSession(ctx, inv)
+	conn, err := client.JoinSession(ctx, uri, inv)
 	if err != nil {
 		return internalConn{}, err
 	}
 
-	err = dialer.SetTCPOptions(conn)
-	if err != nil {
-		conn.Close()
-		return internalConn{}, err
+	// Sessions over QUIC or WebSocket aren't TCP connections of our own,
+	// and have nothing to set.
+	if uri.Scheme == "relay" {
+		err = dialer.SetTCPOptions(conn)
+		if err != nil {
+			conn.Close()
+			return internalConn{}, err
+		}
 	}
 
 	err = dialer.SetTrafficClass(conn, d.trafficClass)
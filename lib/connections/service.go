@@ -10,6 +10,7 @@
 package connections
 
 import (
+	"cmp"
 	"context"
 	"crypto/rand"
 	"crypto/tls"
@@ -31,6 +32,7 @@ import (
 	"github.com/syncthing/syncthing/lib/build"
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/connections/registry"
+	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/discover"
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/nat"
@@ -62,8 +64,9 @@ var (
 	errUnsupported = errors.New("unsupported protocol")
 
 	// These are specific explanations for errUnsupported.
-	errDisabled   = fmt.Errorf("%w: disabled by configuration", errUnsupported)
-	errDeprecated = fmt.Errorf("%w: deprecated", errUnsupported)
+	errDisabled      = fmt.Errorf("%w: disabled by configuration", errUnsupported)
+	errDeprecated    = fmt.Errorf("%w: deprecated", errUnsupported)
+	errProxyRequired = fmt.Errorf("%w: can't be used through a proxy", errUnsupported)
 
 	// Various reasons to reject a connection
 	errNetworkNotAllowed      = errors.New("network not allowed")
@@ -218,6 +221,8 @@ func NewService(cfg config.Wrapper, myID protocol.DeviceID, mdl Model, tlsCfg *t
 	cfg.Subscribe(service)
 
 	raw := cfg.RawCopy()
+	// Everything dialing out relies on the proxy being set before it starts.
+	setProxy(raw.Options)
 	// Actually starts the listeners and NAT service
 	// Need to start this before service.connect so that any dials that
 	// try punch through already have a listener to cling on.
@@ -446,7 +451,14 @@ func (s *service) handleHellos(ctx context.Context) error {
 		rd, wr := s.limiter.getLimiters(remoteID, c, c.IsLocal())
 
 		protoConn := protocol.NewConnection(remoteID, rd, wr, c, s.model, c, deviceCfg.Compression, s.cfg.FolderPasswords(remoteID), s.keyGen)
-		s.accountAddedConnection(protoConn, hello, s.cfg.Options().ConnectionPriorityUpgradeThreshold)
+		opts := s.cfg.Options()
+		keep := 0
+		if opts.ConnectionMixedPriorities {
+			// Keep connections of worse priority as long as we don't have
+			// more than we want.
+			keep = desiredConnections(deviceCfg.NumConnections(), int(hello.NumConnections))
+		}
+		s.accountAddedConnection(protoConn, hello, opts.ConnectionPriorityUpgradeThreshold, keep)
 		go func() {
 			<-protoConn.Closed()
 			s.accountRemovedConnection(protoConn)
@@ -577,7 +589,12 @@ func (s *service) dialDevices(ctx context.Context, now time.Time, cfg config.Con
 		// See if we are already connected and, if so, what our cutoff is
 		// for dialer priority.
 		priorityCutoff := worstDialerPriority
-		if currentConns := s.numConnectionsForDevice(deviceCfg.DeviceID); currentConns > 0 {
+		mixing := false
+		if currentConns := s.numConnectionsForDevice(deviceCfg.DeviceID); currentConns > 0 && cfg.Options.ConnectionMixedPriorities && currentConns < s.desiredConnectionsToDevice(deviceCfg.DeviceID) {
+			// We want more connections and are fine with worse ones
+			// besides the ones we have, so any dialer will do.
+			mixing = true
+		} else if currentConns > 0 {
 			// Set the priority cutoff to the current connection's priority,
 			// so that we don't attempt any dialers with worse priority.
 			priorityCutoff = s.worstConnectionPriority(deviceCfg.DeviceID)
@@ -596,6 +613,9 @@ func (s *service) dialDevices(ctx context.Context, now time.Time, cfg config.Con
 		}
 
 		dialTargets := s.resolveDialTargets(ctx, now, cfg, deviceCfg, nextDialAt, initial, priorityCutoff)
+		if mixing {
+			dialTargets = preferUnusedPriorities(dialTargets, s.connectionPriorities(deviceCfg.DeviceID))
+		}
 		if len(dialTargets) > 0 {
 			queue = append(queue, dialQueueEntry{
 				id:         deviceCfg.DeviceID,
@@ -846,6 +866,14 @@ func (s *service) logListenAddressesChangedEvent(l ListenerAddresses) {
 	})
 }
 
+func (*service) VerifyConfiguration(_, to config.Configuration) error {
+	if to.Options.ProxyAddress == "" {
+		return nil
+	}
+	_, err := dialer.ParseProxyURL(to.Options.ProxyAddress)
+	return err
+}
+
 func (s *service) CommitConfiguration(from, to config.Configuration) bool {
 	newDevices := make(map[protocol.DeviceID]bool, len(to.Devices))
 	for _, dev := range to.Devices {
@@ -862,6 +890,10 @@ func (s *service) CommitConfiguration(from, to config.Configuration) bool {
 		}
 	}
 
+	if from.Options.ProxyAddress != to.Options.ProxyAddress || from.Options.ProxyFallback != to.Options.ProxyFallback {
+		setProxy(to.Options)
+	}
+
 	s.checkAndSignalConnectLoopOnUpdatedDevices(from, to)
 
 	s.listenersMut.Lock()
@@ -922,6 +954,12 @@ func (s *service) CommitConfiguration(from, to config.Configuration) bool {
 	return true
 }
 
+func setProxy(opts config.OptionsConfiguration) {
+	if err := dialer.SetProxy(opts.ProxyAddress, opts.ProxyFallback); err != nil {
+		l.Warnln("Failed to set proxy:", err)
+	}
+}
+
 func (s *service) checkAndSignalConnectLoopOnUpdatedDevices(from, to config.Configuration) {
 	oldDevices := from.DeviceMap()
 	dial := false
@@ -1328,15 +1366,35 @@ func (r nextDialRegistry) sleepDurationAndCleanup(now time.Time) time.Duration {
 	return sleep
 }
 
+// preferUnusedPriorities returns the dial targets with a priority we don't
+// already have a connection at, if there are any, so that mixing
+// priorities ends up using different networks instead of more connections
+// over the best one.
+func preferUnusedPriorities(targets []dialTarget, used []int) []dialTarget {
+	var unused []dialTarget
+	for _, tgt := range targets {
+		if !slices.Contains(used, tgt.priority) {
+			unused = append(unused, tgt)
+		}
+	}
+	if len(unused) == 0 {
+		return targets
+	}
+	return unused
+}
+
 func (s *service) desiredConnectionsToDevice(deviceID protocol.DeviceID) int {
 	cfg, ok := s.cfg.Device(deviceID)
 	if !ok {
 		// We want no connections to an unknown device.
 		return 0
 	}
+	return desiredConnections(cfg.NumConnections(), s.wantConnectionsForDevice(deviceID))
+}
 
-	otherSide := s.wantConnectionsForDevice(deviceID)
-	thisSide := cfg.NumConnections()
+// desiredConnections negotiates the number of connections from what we
+// want and what the other side wants.
+func desiredConnections(thisSide, otherSide int) int {
 	switch {
 	case otherSide <= 0:
 		// The other side doesn't support multiple connections, or we
@@ -1369,7 +1427,10 @@ type deviceConnectionTracker struct {
 	wantConnections map[protocol.DeviceID]int                   // number of connections they want
 }
 
-func (c *deviceConnectionTracker) accountAddedConnection(conn protocol.Connection, h protocol.Hello, upgradeThreshold int) {
+// accountAddedConnection adds the connection and closes the ones of worse
+// priority. With keep above zero, only the worst connections beyond that
+// many are closed.
+func (c *deviceConnectionTracker) accountAddedConnection(conn protocol.Connection, h protocol.Hello, upgradeThreshold, keep int) {
 	c.connectionsMut.Lock()
 	defer c.connectionsMut.Unlock()
 	// Lazily initialize the maps
@@ -1388,7 +1449,7 @@ func (c *deviceConnectionTracker) accountAddedConnection(conn protocol.Connectio
 	metricDeviceActiveConnections.WithLabelValues(d.String()).Inc()
 
 	// Close any connections we no longer want to retain.
-	c.closeWorsePriorityConnectionsLocked(d, conn.Priority()-upgradeThreshold)
+	c.closeWorsePriorityConnectionsLocked(d, conn.Priority()-upgradeThreshold, keep)
 }
 
 func (c *deviceConnectionTracker) accountRemovedConnection(conn protocol.Connection) {
@@ -1448,14 +1509,34 @@ func (c *deviceConnectionTracker) worstConnectionPriority(d protocol.DeviceID) i
 	return worstPriority
 }
 
+func (c *deviceConnectionTracker) connectionPriorities(d protocol.DeviceID) []int {
+	c.connectionsMut.Lock()
+	defer c.connectionsMut.Unlock()
+	prios := make([]int, len(c.connections[d]))
+	for i, conn := range c.connections[d] {
+		prios[i] = conn.Priority()
+	}
+	return prios
+}
+
 // closeWorsePriorityConnectionsLocked closes all connections to the given
-// device that are worse than the cutoff priority. Must be called with the
-// lock held.
-func (c *deviceConnectionTracker) closeWorsePriorityConnectionsLocked(d protocol.DeviceID, cutoff int) {
-	for _, conn := range c.connections[d] {
+// device that are worse than the cutoff priority, worst first, until no
+// more than keep remain. A keep of zero closes all of them. Must be called
+// with the lock held.
+func (c *deviceConnectionTracker) closeWorsePriorityConnectionsLocked(d protocol.DeviceID, cutoff, keep int) {
+	conns := slices.Clone(c.connections[d])
+	slices.SortStableFunc(conns, func(a, b protocol.Connection) int {
+		return cmp.Compare(b.Priority(), a.Priority())
+	})
+	remaining := len(conns)
+	for _, conn := range conns {
+		if keep > 0 && remaining <= keep {
+			return
+		}
 		if p := conn.Priority(); p > cutoff {
 			l.Debugf("Closing connection %s to %s with priority %d (cutoff %d)", conn, d.Short(), p, cutoff)
 			go conn.Close(errReplacingConnection)
+			remaining--
 		}
 	}
 }
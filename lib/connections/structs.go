@@ -54,6 +54,8 @@ const (
 	connTypeTCPServer
 	connTypeQUICClient
 	connTypeQUICServer
+	connTypeWSSClient
+	connTypeWSSServer
 )
 
 func (t connType) String() string {
@@ -70,6 +72,10 @@ func (t connType) String() string {
 		return "quic-client"
 	case connTypeQUICServer:
 		return "quic-server"
+	case connTypeWSSClient:
+		return "wss-client"
+	case connTypeWSSServer:
+		return "wss-server"
 	default:
 		return "unknown-type"
 	}
@@ -83,6 +89,8 @@ func (t connType) Transport() string {
 		return "tcp"
 	case connTypeQUICClient, connTypeQUICServer:
 		return "quic"
+	case connTypeWSSClient, connTypeWSSServer:
+		return "wss"
 	default:
 		return "unknown"
 	}
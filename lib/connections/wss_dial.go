@@ -0,0 +1,138 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connections
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/connections/registry"
+	"github.com/syncthing/syncthing/lib/dialer"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func init() {
+	factory := &wssDialerFactory{}
+	for _, scheme := range []string{"wss", "ws"} {
+		dialers[scheme] = factory
+	}
+}
+
+type wssDialer struct {
+	commonDialer
+}
+
+func (d *wssDialer) Dial(ctx context.Context, _ protocol.DeviceID, uri *url.URL) (internalConn, error) {
+	wsURL := wssHTTPURL(uri)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Keep hold of the underlying connection, for its addresses and TCP
+	// options.
+	var netConn net.Conn
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				conn, err := dialer.DialContext(ctx, network, addr)
+				if err == nil {
+					netConn = conn
+				}
+				return conn, err
+			},
+			Proxy: dialer.HTTPProxy,
+			// The HTTPS is often terminated by a proxy in front of the
+			// other device, or uses its self signed certificate. Either
+			// way the device is identified by the TLS connection within.
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: true,
+				MinVersion:         tls.VersionTLS12,
+			},
+		},
+	}
+
+	wc, _, err := websocket.Dial(timeoutCtx, wsURL.String(), &websocket.DialOptions{
+		HTTPClient:   client,
+		Subprotocols: []string{wssSubprotocol},
+	})
+	if err != nil {
+		return internalConn{}, err
+	}
+	if wc.Subprotocol() != wssSubprotocol {
+		wc.Close(websocket.StatusProtocolError, "unexpected subprotocol")
+		return internalConn{}, errors.New("protocol negotiation error")
+	}
+
+	if netConn == nil {
+		// Can't happen, as we just dialed.
+		wc.Close(websocket.StatusInternalError, "")
+		return internalConn{}, errors.New("no underlying connection")
+	}
+	conn := newWSSConn(wc, netConn.LocalAddr(), netConn.RemoteAddr())
+
+	err = dialer.SetTCPOptions(netConn)
+	if err != nil {
+		l.Debugln("Dial (BEP/wss): setting tcp options:", err)
+	}
+
+	err = dialer.SetTrafficClass(netConn, d.trafficClass)
+	if err != nil {
+		l.Debugln("Dial (BEP/wss): setting traffic class:", err)
+	}
+
+	tc := tls.Client(conn, d.tlsCfg)
+	err = tlsTimedHandshake(tc)
+	if err != nil {
+		tc.Close()
+		return internalConn{}, err
+	}
+
+	priority := d.wanPriority
+	isLocal := d.lanChecker.isLAN(conn.RemoteAddr())
+	if isLocal {
+		priority = d.lanPriority
+	}
+
+	return newInternalConn(tc, connTypeWSSClient, isLocal, priority), nil
+}
+
+type wssDialerFactory struct{}
+
+func (wssDialerFactory) New(opts config.OptionsConfiguration, tlsCfg *tls.Config, _ *registry.Registry, lanChecker *lanChecker) genericDialer {
+	return &wssDialer{
+		commonDialer: commonDialer{
+			trafficClass:      opts.TrafficClass,
+			reconnectInterval: time.Duration(opts.ReconnectIntervalS) * time.Second,
+			tlsCfg:            tlsCfg,
+			lanChecker:        lanChecker,
+			lanPriority:       opts.ConnectionPriorityWSSLAN,
+			wanPriority:       opts.ConnectionPriorityWSSWAN,
+			allowsMultiConns:  true,
+		},
+	}
+}
+
+func (wssDialerFactory) AlwaysWAN() bool {
+	return false
+}
+
+func (wssDialerFactory) Valid(_ config.Configuration) error {
+	// Always valid
+	return nil
+}
+
+func (wssDialerFactory) String() string {
+	return "WebSocket Dialer"
+}
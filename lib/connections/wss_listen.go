@@ -0,0 +1,210 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connections
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/connections/registry"
+	"github.com/syncthing/syncthing/lib/nat"
+	"github.com/syncthing/syncthing/lib/svcutil"
+)
+
+func init() {
+	factory := &wssListenerFactory{}
+	for _, scheme := range []string{"wss", "ws"} {
+		listeners[scheme] = factory
+	}
+}
+
+// wssListener accepts BEP over WebSocket. With the wss scheme it serves
+// HTTPS using our certificate; with ws it serves plain HTTP, for use behind
+// a reverse proxy that terminates HTTPS. Connections are TLS secured
+// within the WebSocket either way.
+type wssListener struct {
+	svcutil.ServiceWithError
+	onAddressesChangedNotifier
+
+	uri        *url.URL
+	cfg        config.Wrapper
+	tlsCfg     *tls.Config
+	conns      chan internalConn
+	factory    listenerFactory
+	lanChecker *lanChecker
+
+	laddr net.Addr
+	mut   sync.RWMutex
+}
+
+func (t *wssListener) serve(ctx context.Context) error {
+	listener, err := net.Listen("tcp", t.uri.Host)
+	if err != nil {
+		l.Infoln("Listen (BEP/wss):", err)
+		return err
+	}
+	defer listener.Close()
+
+	// We might bind to :0, so use the port we've been given.
+	laddr := listener.Addr()
+
+	if t.uri.Scheme == "wss" {
+		listener = tls.NewListener(listener, &tls.Config{
+			Certificates: t.tlsCfg.Certificates,
+			NextProtos:   []string{"http/1.1"},
+			MinVersion:   tls.VersionTLS12,
+		})
+	}
+
+	t.mut.Lock()
+	t.laddr = laddr
+	t.mut.Unlock()
+	defer func() {
+		t.mut.Lock()
+		t.laddr = nil
+		t.mut.Unlock()
+	}()
+
+	t.notifyAddressesChanged(t)
+	defer t.clearAddresses(t)
+
+	l.Infof("WebSocket listener (%v) starting", t.uri)
+	defer l.Infof("WebSocket listener (%v) shutting down", t.uri)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(wssPath(t.uri), t.handle)
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		// Failed handshakes from scanners and the like aren't interesting.
+		ErrorLog: log.New(io.Discard, "", 0),
+	}
+
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	err = srv.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	l.Infoln("Listen (BEP/wss):", err)
+	return err
+}
+
+func (t *wssListener) handle(w http.ResponseWriter, req *http.Request) {
+	wc, err := websocket.Accept(w, req, &websocket.AcceptOptions{
+		Subprotocols: []string{wssSubprotocol},
+		// Devices aren't browsers; the origin means nothing here.
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		l.Debugln("Listen (BEP/wss): accept from", req.RemoteAddr, err)
+		return
+	}
+	if wc.Subprotocol() != wssSubprotocol {
+		wc.Close(websocket.StatusPolicyViolation, "unsupported subprotocol")
+		return
+	}
+
+	l.Debugln("Listen (BEP/wss): connect from", req.RemoteAddr)
+
+	var remoteAddr net.Addr
+	if addr, err := net.ResolveTCPAddr("tcp", req.RemoteAddr); err == nil {
+		remoteAddr = addr
+	}
+	localAddr, _ := req.Context().Value(http.LocalAddrContextKey).(net.Addr)
+	conn := newWSSConn(wc, localAddr, remoteAddr)
+
+	tc := tls.Server(conn, t.tlsCfg)
+	if err := tlsTimedHandshake(tc); err != nil {
+		l.Infoln("Listen (BEP/wss): TLS handshake:", err)
+		tc.Close()
+		return
+	}
+
+	// Without HTTPS of our own we're behind a proxy, and the remote
+	// address is that of the proxy. The other device is then not
+	// considered local, whatever the address of the proxy.
+	priority := t.cfg.Options().ConnectionPriorityWSSWAN
+	isLocal := t.uri.Scheme == "wss" && t.lanChecker.isLAN(conn.RemoteAddr())
+	if isLocal {
+		priority = t.cfg.Options().ConnectionPriorityWSSLAN
+	}
+	t.conns <- newInternalConn(tc, connTypeWSSServer, isLocal, priority)
+}
+
+func (t *wssListener) URI() *url.URL {
+	return t.uri
+}
+
+func (t *wssListener) WANAddresses() []*url.URL {
+	if t.uri.Scheme != "wss" {
+		// Behind a proxy; our address isn't the one to connect to.
+		return nil
+	}
+	t.mut.RLock()
+	uri := maybeReplacePort(t.uri, t.laddr)
+	t.mut.RUnlock()
+	return []*url.URL{uri}
+}
+
+func (t *wssListener) LANAddresses() []*url.URL {
+	if t.uri.Scheme != "wss" {
+		return nil
+	}
+	t.mut.RLock()
+	uri := maybeReplacePort(t.uri, t.laddr)
+	t.mut.RUnlock()
+	addrs := []*url.URL{uri}
+	addrs = append(addrs, getURLsForAllAdaptersIfUnspecified("tcp", uri)...)
+	return addrs
+}
+
+func (t *wssListener) String() string {
+	return t.uri.String()
+}
+
+func (t *wssListener) Factory() listenerFactory {
+	return t.factory
+}
+
+func (*wssListener) NATType() string {
+	return "unknown"
+}
+
+type wssListenerFactory struct{}
+
+func (f *wssListenerFactory) New(uri *url.URL, cfg config.Wrapper, tlsCfg *tls.Config, conns chan internalConn, _ *nat.Service, _ *registry.Registry, lanChecker *lanChecker) genericListener {
+	l := &wssListener{
+		uri:        fixupPort(uri, wssDefaultPort(uri)),
+		cfg:        cfg,
+		tlsCfg:     tlsCfg,
+		conns:      conns,
+		factory:    f,
+		lanChecker: lanChecker,
+	}
+	l.ServiceWithError = svcutil.AsService(l.serve, l.String())
+	return l
+}
+
+func (wssListenerFactory) Valid(_ config.Configuration) error {
+	// Always valid
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package connections
+
+import (
+	"context"
+	"net"
+	"net/url"
+
+	"github.com/coder/websocket"
+)
+
+// wssSubprotocol is the WebSocket subprotocol for BEP, which runs in TLS
+// within the WebSocket.
+const wssSubprotocol = "syncthing-bep"
+
+func wssDefaultPort(uri *url.URL) int {
+	if uri.Scheme == "ws" {
+		return 80
+	}
+	return 443
+}
+
+func wssPath(uri *url.URL) string {
+	if uri.Path == "" {
+		return "/"
+	}
+	return uri.Path
+}
+
+// wssHTTPURL returns the HTTP(S) URL to dial for the given wss:// or ws://
+// address.
+func wssHTTPURL(uri *url.URL) *url.URL {
+	scheme := "https"
+	if uri.Scheme == "ws" {
+		scheme = "http"
+	}
+	return &url.URL{
+		Scheme:   scheme,
+		Host:     fixupPort(uri, wssDefaultPort(uri)).Host,
+		Path:     wssPath(uri),
+		RawQuery: uri.RawQuery,
+	}
+}
+
+// wssConn is a WebSocket as a net.Conn, with the addresses of the
+// underlying connection, as the WebSocket doesn't know them.
+type wssConn struct {
+	net.Conn
+	wc         *websocket.Conn
+	localAddr  net.Addr
+	remoteAddr net.Addr
+}
+
+func newWSSConn(wc *websocket.Conn, localAddr, remoteAddr net.Addr) *wssConn {
+	// The connection outlives the handshake, hence not using its context.
+	// NetConn also removes the message size limit.
+	return &wssConn{
+		Conn:       websocket.NetConn(context.Background(), wc, websocket.MessageBinary),
+		wc:         wc,
+		localAddr:  localAddr,
+		remoteAddr: remoteAddr,
+	}
+}
+
+// Close closes the connection without the WebSocket closing handshake,
+// which waits for the other side to respond. The TLS connection within
+// has already said goodbye.
+func (c *wssConn) Close() error {
+	return c.wc.CloseNow()
+}
+
+func (c *wssConn) LocalAddr() net.Addr {
+	if c.localAddr == nil {
+		return c.Conn.LocalAddr()
+	}
+	return c.localAddr
+}
+
+func (c *wssConn) RemoteAddr() net.Addr {
+	if c.remoteAddr == nil {
+		return c.Conn.RemoteAddr()
+	}
+	return c.remoteAddr
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// A hash cache entry is the size, modification and inode change time of a
+// file when it was hashed and the block hash algorithm used, followed by the
+// hash of its block list.
+const hashCacheEntryLen = 3*8 + 1 + 32
+
+// The HashCache remembers the block lists of hashed files by their device
+// and inode numbers, across all folders. A file that still has the same
+// size, modification and inode change time, e.g. because it was moved to
+// another folder along with its directory, isn't hashed again. Renaming a
+// file updates its inode change time on most filesystems, so a file moved
+// by itself is hashed again; the change time is needed to notice files
+// modified in place with their modification time restored.
+//
+// Only a reference to the block list is kept, which is stored with the file
+// in the index. Entries are dropped once the block list has been garbage
+// collected, i.e. when no folder has the file any more. Small files, whose
+// block lists aren't stored separately, are not cached.
+type HashCache struct {
+	db *Lowlevel
+}
+
+func NewHashCache(db *Lowlevel) *HashCache {
+	return &HashCache{
+		db: db,
+	}
+}
+
+func (c *HashCache) String() string {
+	return fmt.Sprintf("HashCache@%p", c)
+}
+
+// Blocks returns the block list of the file, if it has been hashed before
+// with the same algorithm and is unchanged since.
+func (c *HashCache) Blocks(info fs.FileInfo, hashAlgorithm protocol.HashAlgorithm) ([]protocol.BlockInfo, bool) {
+	key, entry, ok := hashCacheKeyEntry(info, hashAlgorithm)
+	if !ok {
+		return nil, false
+	}
+
+	t, err := c.db.newReadOnlyTransaction()
+	if err != nil {
+		return nil, false
+	}
+	defer t.close()
+
+	bs, err := t.Get(key)
+	if err != nil || len(bs) != hashCacheEntryLen || !bytes.Equal(bs[:len(entry)], entry) {
+		return nil, false
+	}
+	bs, err = t.Get(t.keyer.GenerateBlockListKey(nil, bs[len(entry):]))
+	if err != nil {
+		return nil, false
+	}
+	var bl BlockList
+	if err := bl.Unmarshal(bs); err != nil {
+		return nil, false
+	}
+	return bl.Blocks, true
+}
+
+// SetBlocks records the block list of the file as just hashed.
+func (c *HashCache) SetBlocks(info fs.FileInfo, hashAlgorithm protocol.HashAlgorithm, blocks []protocol.BlockInfo) {
+	if len(blocks) <= blocksIndirectionCutoff {
+		return
+	}
+	key, entry, ok := hashCacheKeyEntry(info, hashAlgorithm)
+	if !ok {
+		return
+	}
+	if err := c.db.Put(key, append(entry, protocol.BlocksHash(blocks)...)); err != nil {
+		l.Debugf("%v: failed to record %v: %v", c, info.Name(), err)
+	}
+}
+
+func hashCacheKeyEntry(info fs.FileInfo, hashAlgorithm protocol.HashAlgorithm) ([]byte, []byte, bool) {
+	dev, ino, ok := fs.FileID(info)
+	if !ok || !info.IsRegular() {
+		return nil, nil, false
+	}
+	changeTime := info.InodeChangeTime()
+	if changeTime.IsZero() {
+		// Without it we wouldn't notice files being modified in place
+		// with their modification time restored.
+		return nil, nil, false
+	}
+
+	key := make([]byte, 1+8+8)
+	key[0] = KeyTypeHashCache
+	binary.BigEndian.PutUint64(key[1:], dev)
+	binary.BigEndian.PutUint64(key[9:], ino)
+
+	entry := make([]byte, 3*8+1, hashCacheEntryLen)
+	binary.BigEndian.PutUint64(entry, uint64(info.Size()))
+	binary.BigEndian.PutUint64(entry[8:], uint64(info.ModTime().UnixNano()))
+	binary.BigEndian.PutUint64(entry[16:], uint64(changeTime.UnixNano()))
+	entry[24] = byte(hashAlgorithm)
+	return key, entry, true
+}
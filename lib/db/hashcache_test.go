@@ -0,0 +1,169 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package db
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestHashCache(t *testing.T) {
+	if build.IsWindows {
+		t.Skip("No inode numbers on Windows")
+	}
+
+	db := newLowlevelMemory(t)
+	defer db.Close()
+	meta := newMetadataTracker(db.keyer, events.NoopLogger)
+	cache := NewHashCache(db)
+
+	ffs := fs.NewFilesystem(fs.FilesystemTypeBasic, t.TempDir())
+	fd, err := ffs.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd.Write([]byte("content"))
+	fd.Close()
+	info, err := ffs.Lstat("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blocks := genBlocks(10)
+	cache.SetBlocks(info, protocol.HashAlgorithmSHA256, blocks)
+
+	// The block list isn't in the database until the file is.
+	if _, ok := cache.Blocks(info, protocol.HashAlgorithmSHA256); ok {
+		t.Fatal("unexpected blocks without the block list")
+	}
+	db.updateLocalFiles([]byte("folder"), []protocol.FileInfo{{Name: "file", Blocks: blocks}}, meta)
+	if cached, ok := cache.Blocks(info, protocol.HashAlgorithmSHA256); !ok || !bytes.Equal(protocol.BlocksHash(cached), protocol.BlocksHash(blocks)) {
+		t.Fatal("expected the blocks from the cache")
+	}
+
+	// The file in another folder, e.g. after moving it, is the same.
+	other, err := fs.NewFilesystem(fs.FilesystemTypeBasic, ffs.URI()).Lstat("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Blocks(other, protocol.HashAlgorithmSHA256); !ok {
+		t.Fatal("expected the blocks from the cache")
+	}
+
+	// Blocks hashed with another algorithm aren't.
+	if _, ok := cache.Blocks(info, protocol.HashAlgorithmBLAKE3); ok {
+		t.Fatal("unexpected blocks for another hash algorithm")
+	}
+
+	// A modified file isn't.
+	if err := ffs.Chtimes("file", time.Now(), time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if modified, err := ffs.Lstat("file"); err != nil {
+		t.Fatal(err)
+	} else if _, ok := cache.Blocks(modified, protocol.HashAlgorithmSHA256); ok {
+		t.Fatal("unexpected blocks for a modified file")
+	}
+
+	// The entry is dropped once no folder has the block list any more.
+	if err := db.gcIndirect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := numHashCacheEntries(t, db); n != 1 {
+		t.Fatal("expected the entry to be kept, got", n)
+	}
+	db.updateLocalFiles([]byte("folder"), []protocol.FileInfo{{Name: "file", Deleted: true, Version: protocol.Vector{}.Update(42)}}, meta)
+	if err := db.gcIndirect(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := numHashCacheEntries(t, db); n != 0 {
+		t.Fatal("expected the entry to be dropped, got", n)
+	}
+}
+
+func TestHashCacheMove(t *testing.T) {
+	if build.IsWindows {
+		t.Skip("No inode numbers on Windows")
+	}
+
+	db := newLowlevelMemory(t)
+	defer db.Close()
+	meta := newMetadataTracker(db.keyer, events.NoopLogger)
+	cache := NewHashCache(db)
+
+	// Two folders on the same filesystem.
+	root := fs.NewFilesystem(fs.FilesystemTypeBasic, t.TempDir())
+	for _, dir := range []string{"a/dir", "b"} {
+		if err := root.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	folderA := fs.NewFilesystem(fs.FilesystemTypeBasic, filepath.Join(root.URI(), "a"))
+	folderB := fs.NewFilesystem(fs.FilesystemTypeBasic, filepath.Join(root.URI(), "b"))
+	fd, err := folderA.Create(filepath.Join("dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd.Write([]byte("content"))
+	fd.Close()
+	info, err := folderA.Lstat(filepath.Join("dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := genBlocks(10)
+	cache.SetBlocks(info, protocol.HashAlgorithmSHA256, blocks)
+	db.updateLocalFiles([]byte("a"), []protocol.FileInfo{{Name: filepath.Join("dir", "file"), Blocks: blocks}}, meta)
+
+	// Moved to the other folder along with its directory, the file is
+	// unchanged.
+	if err := root.Rename(filepath.Join("a", "dir"), filepath.Join("b", "dir")); err != nil {
+		t.Fatal(err)
+	}
+	moved, err := folderB.Lstat(filepath.Join("dir", "file"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached, ok := cache.Blocks(moved, protocol.HashAlgorithmSHA256); !ok || !bytes.Equal(protocol.BlocksHash(cached), protocol.BlocksHash(blocks)) {
+		t.Fatal("expected the blocks from the cache after moving the directory")
+	}
+
+	// Moved by itself, the file is hashed again where renaming updates the
+	// inode change time.
+	if err := root.Rename(filepath.Join("b", "dir", "file"), filepath.Join("a", "file")); err != nil {
+		t.Fatal(err)
+	}
+	movedFile, err := folderA.Lstat("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed := !movedFile.InodeChangeTime().Equal(moved.InodeChangeTime())
+	if _, ok := cache.Blocks(movedFile, protocol.HashAlgorithmSHA256); ok == changed {
+		t.Errorf("expected blocks from the cache after moving the file: %v, got %v", !changed, ok)
+	}
+}
+
+func numHashCacheEntries(t *testing.T, db *Lowlevel) int {
+	t.Helper()
+	it, err := db.NewPrefixIterator([]byte{KeyTypeHashCache})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Release()
+	n := 0
+	for it.Next() {
+		n++
+	}
+	return n
+}
@@ -74,6 +74,12 @@ const (
 
 	// KeyTypePendingDevice <device ID in wire format> = ObservedDevice
 	KeyTypePendingDevice byte = 17
+
+	// KeyTypeScanCheckpoint <folder ID as string> = scanner.Checkpoint
+	KeyTypeScanCheckpoint byte = 18
+
+	// KeyTypeHashCache <uint64 device> <uint64 inode> = <size> <mtime> <ctime> <hash algorithm> <block list hash>
+	KeyTypeHashCache byte = 19
 )
 
 type keyer interface {
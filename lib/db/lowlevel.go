@@ -337,6 +337,61 @@ func (db *Lowlevel) removeLocalFiles(folder []byte, nameStrs []string, meta *met
 	return t.Commit()
 }
 
+func (db *Lowlevel) removeRemoteFiles(folder, device []byte, nameStrs []string, meta *metadataTracker) error {
+	db.gcMut.RLock()
+	defer db.gcMut.RUnlock()
+
+	t, err := db.newReadWriteTransaction(meta.CommitHook(folder))
+	if err != nil {
+		return err
+	}
+	defer t.close()
+
+	devID, err := protocol.DeviceIDFromBytes(device)
+	if err != nil {
+		return err
+	}
+	var dk, gk, buf []byte
+	for _, nameStr := range nameStrs {
+		name := []byte(nameStr)
+		dk, err = db.keyer.GenerateDeviceFileKey(dk, folder, device, name)
+		if err != nil {
+			return err
+		}
+
+		ef, ok, err := t.getFileTrunc(dk, true)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			l.Debugf("remove (remote); folder=%q device=%v %v: file doesn't exist", folder, devID, nameStr)
+			continue
+		}
+
+		meta.removeFile(devID, ef)
+
+		gk, err = db.keyer.GenerateGlobalVersionKey(gk, folder, name)
+		if err != nil {
+			return err
+		}
+		buf, err = t.removeFromGlobal(gk, buf, folder, device, name, meta)
+		if err != nil {
+			return err
+		}
+
+		err = t.Delete(dk)
+		if err != nil {
+			return err
+		}
+
+		if err := t.Checkpoint(); err != nil {
+			return err
+		}
+	}
+
+	return t.Commit()
+}
+
 func (db *Lowlevel) removeLocalBlockAndSequenceInfo(keyBuf, folder, name []byte, ef protocol.FileInfo, removeFromBlockListMap bool, t *readWriteTransaction) ([]byte, error) {
 	var err error
 	if len(ef.Blocks) != 0 && !ef.IsInvalid() && ef.Size > 0 {
@@ -675,6 +730,10 @@ func (db *Lowlevel) dropOtherDeviceIndexIDs() error {
 	return t.Commit()
 }
 
+func (db *Lowlevel) dropScanCheckpoint(folder []byte) error {
+	return db.Delete(scanCheckpointKey(folder))
+}
+
 func (db *Lowlevel) dropMtimes(folder []byte) error {
 	key, err := db.keyer.GenerateMtimesKey(nil, folder)
 	if err != nil {
@@ -879,6 +938,32 @@ func (db *Lowlevel) gcIndirect(ctx context.Context) (err error) {
 		return err
 	}
 
+	// Iterate over the hash cache, removing entries referring to block
+	// lists that no longer exist.
+
+	it, err = t.NewPrefixIterator([]byte{KeyTypeHashCache})
+	if err != nil {
+		return err
+	}
+	for it.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if val := it.Value(); len(val) == hashCacheEntryLen && db.blockFilter.has(val[hashCacheEntryLen-32:]) {
+			continue
+		}
+		if err := t.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	it.Release()
+	if err := it.Error(); err != nil {
+		return err
+	}
+
 	// Iterate over version lists, removing keys with hashes that don't match
 	// the filter.
 
@@ -237,6 +237,16 @@ func (m *metadataTracker) Sequence(dev protocol.DeviceID) int64 {
 	return m.countsPtr(dev, 0).Sequence
 }
 
+// setSequence sets the sequence number of a remote device, regardless of
+// the sequence numbers of the files we have for it.
+func (m *metadataTracker) setSequence(dev protocol.DeviceID, seq int64) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	m.dirty = true
+	m.countsPtr(dev, 0).Sequence = seq
+}
+
 func (m *metadataTracker) updateSeqLocked(dev protocol.DeviceID, f protocol.FileIntf) {
 	if dev == protocol.GlobalDeviceID {
 		return
@@ -158,6 +158,27 @@ func (s *FileSet) RemoveLocalItems(items []string) {
 	}
 }
 
+// RemoveRemoteItems removes the given items announced by a remote device,
+// as if it had never announced them.
+func (s *FileSet) RemoveRemoteItems(device protocol.DeviceID, items []string) {
+	if device == protocol.LocalDeviceID {
+		panic("use RemoveLocalItems for the local device")
+	}
+	opStr := fmt.Sprintf("%s RemoveRemoteItems(%v, [%d])", s.folder, device, len(items))
+	l.Debugf(opStr)
+
+	s.updateMutex.Lock()
+	defer s.updateMutex.Unlock()
+
+	for i := range items {
+		items[i] = osutil.NormalizedFilename(items[i])
+	}
+
+	if err := s.db.removeRemoteFiles([]byte(s.folder), device[:], items, s.meta); err != nil && !backend.IsClosed(err) {
+		fatalError(err, opStr, s.db)
+	}
+}
+
 type Snapshot struct {
 	folder     string
 	t          readOnlyTransaction
@@ -412,6 +433,41 @@ func (s *FileSet) SetIndexID(device protocol.DeviceID, id protocol.IndexID) {
 	}
 }
 
+// SetSequence overrides the sequence number we consider to have seen from
+// a remote device. This is used when the index of a remote device has been
+// reconciled without receiving all of it.
+func (s *FileSet) SetSequence(device protocol.DeviceID, seq int64) {
+	if device == protocol.LocalDeviceID {
+		panic("do not explicitly set sequence for local device")
+	}
+	opStr := fmt.Sprintf("%s SetSequence(%v, %d)", s.folder, device, seq)
+	l.Debugf(opStr)
+
+	s.updateMutex.Lock()
+	defer s.updateMutex.Unlock()
+
+	s.meta.setSequence(device, seq)
+
+	t, err := s.db.newReadWriteTransaction()
+	if backend.IsClosed(err) {
+		return
+	} else if err != nil {
+		fatalError(err, opStr, s.db)
+	}
+	defer t.close()
+
+	if err := s.meta.toDB(t, []byte(s.folder)); backend.IsClosed(err) {
+		return
+	} else if err != nil {
+		fatalError(err, opStr, s.db)
+	}
+	if err := t.Commit(); backend.IsClosed(err) {
+		return
+	} else if err != nil {
+		fatalError(err, opStr, s.db)
+	}
+}
+
 func (s *FileSet) MtimeOption() fs.Option {
 	opStr := fmt.Sprintf("%s MtimeOption()", s.folder)
 	l.Debugf(opStr)
@@ -425,6 +481,24 @@ func (s *FileSet) MtimeOption() fs.Option {
 	return fs.NewMtimeOption(kv)
 }
 
+// ScanCheckpoint returns the scan checkpoint stored for the folder, or nil
+// if there is none.
+func (s *FileSet) ScanCheckpoint() []byte {
+	bs, err := s.db.Get(scanCheckpointKey([]byte(s.folder)))
+	if err != nil {
+		return nil
+	}
+	return bs
+}
+
+func (s *FileSet) SetScanCheckpoint(bs []byte) error {
+	return s.db.Put(scanCheckpointKey([]byte(s.folder)), bs)
+}
+
+func scanCheckpointKey(folder []byte) []byte {
+	return append([]byte{KeyTypeScanCheckpoint}, folder...)
+}
+
 func (s *FileSet) ListDevices() []protocol.DeviceID {
 	return s.meta.devices()
 }
@@ -451,6 +525,7 @@ func DropFolder(db *Lowlevel, folder string) {
 		db.dropMtimes,
 		db.dropFolderMeta,
 		db.dropFolderIndexIDs,
+		db.dropScanCheckpoint,
 		db.folderIdx.Delete,
 	}
 	for _, drop := range droppers {
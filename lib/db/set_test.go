@@ -1757,6 +1757,75 @@ func TestNoIndexIDResetOnDrop(t *testing.T) {
 	}
 }
 
+func TestRemoveRemoteItems(t *testing.T) {
+	ldb := newLowlevelMemory(t)
+	defer ldb.Close()
+
+	s := newFileSet(t, "test", ldb)
+
+	local := fileList{
+		protocol.FileInfo{Name: "a", Sequence: 1, Version: protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1000}}}, Blocks: genBlocks(1)},
+		protocol.FileInfo{Name: "b", Sequence: 2, Version: protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1000}}}, Blocks: genBlocks(2)},
+	}
+	remote := fileList{
+		protocol.FileInfo{Name: "a", Sequence: 10, Version: protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1000}}}, Blocks: genBlocks(1)},
+		protocol.FileInfo{Name: "b", Sequence: 11, Version: protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1000}}}, Blocks: genBlocks(2)},
+		protocol.FileInfo{Name: "c", Sequence: 12, Version: protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1000}}}, Blocks: genBlocks(3)},
+	}
+
+	s.Update(protocol.LocalDeviceID, local)
+	s.Update(remoteDevice0, remote)
+
+	s.RemoveRemoteItems(remoteDevice0, []string{"b", "c", "nonexistent"})
+
+	if h := haveList(t, s, remoteDevice0); len(h) != 1 || h[0].Name != "a" {
+		t.Errorf("Expected only a to remain for remote, got %v", h)
+	}
+	if h := haveList(t, s, protocol.LocalDeviceID); len(h) != len(local) {
+		t.Errorf("Local files changed, %d != %d", len(h), len(local))
+	}
+	if g := globalList(t, s); len(g) != len(local) {
+		t.Errorf("Incorrect global files after remove, %d != %d", len(g), len(local))
+	}
+
+	snap := snapshot(t, s)
+	defer snap.Release()
+	if c := snap.GlobalSize(); c.Files != 2 {
+		t.Errorf("Expected 2 global files, got %v", c.Files)
+	}
+	if c := snap.LocalSize(); c.Files != 2 {
+		t.Errorf("Expected 2 local files, got %v", c.Files)
+	}
+	if c := snap.NeedSize(protocol.LocalDeviceID); c.Files != 0 {
+		t.Errorf("Expected nothing needed, got %v", c.Files)
+	}
+}
+
+func TestSetSequence(t *testing.T) {
+	ldb := newLowlevelMemory(t)
+	defer ldb.Close()
+
+	s := newFileSet(t, "test", ldb)
+
+	s.Update(remoteDevice0, fileList{
+		protocol.FileInfo{Name: "a", Sequence: 10, Version: protocol.Vector{Counters: []protocol.Counter{{ID: myID, Value: 1000}}}, Blocks: genBlocks(1)},
+	})
+	if seq := s.Sequence(remoteDevice0); seq != 10 {
+		t.Fatalf("Expected sequence 10, got %d", seq)
+	}
+
+	s.SetSequence(remoteDevice0, 42)
+	if seq := s.Sequence(remoteDevice0); seq != 42 {
+		t.Errorf("Expected sequence 42, got %d", seq)
+	}
+
+	// The sequence is persisted
+	s = newFileSet(t, "test", ldb)
+	if seq := s.Sequence(remoteDevice0); seq != 42 {
+		t.Errorf("Expected persisted sequence 42, got %d", seq)
+	}
+}
+
 func TestConcurrentIndexID(t *testing.T) {
 	done := make(chan struct{})
 	var ids [2]protocol.IndexID
@@ -0,0 +1,157 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package dialer
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+const connectTimeout = 30 * time.Second
+
+// connectDialer tunnels connections through an HTTP proxy using the CONNECT
+// method, as used for HTTPS through proxies.
+type connectDialer struct {
+	proxyAddr string
+	useTLS    bool
+	host      string // for SNI and certificate verification with TLS
+	user      *url.Userinfo
+	forward   proxy.Dialer
+}
+
+// connectDialerFunction is registered for the "http" and "https" proxy URL
+// schemes.
+func connectDialerFunction(u *url.URL, forward proxy.Dialer) (proxy.Dialer, error) {
+	d := &connectDialer{
+		useTLS:  u.Scheme == "https",
+		host:    u.Hostname(),
+		user:    u.User,
+		forward: forward,
+	}
+	port := u.Port()
+	if port == "" {
+		if d.useTLS {
+			port = "443"
+		} else {
+			port = "8080"
+		}
+	}
+	d.proxyAddr = net.JoinHostPort(d.host, port)
+	return d, nil
+}
+
+func (d *connectDialer) Dial(network, addr string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, addr)
+}
+
+func (d *connectDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("HTTP proxy: unsupported network %q", network)
+	}
+
+	var conn net.Conn
+	var err error
+	if cd, ok := d.forward.(proxy.ContextDialer); ok {
+		conn, err = cd.DialContext(ctx, "tcp", d.proxyAddr)
+	} else {
+		conn, err = d.forward.Dial("tcp", d.proxyAddr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The handshake is bounded by the context, or by a timeout of our own
+	// if it has no deadline.
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(connectTimeout)
+	}
+	_ = conn.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Unix(1, 0))
+	})
+	defer stop()
+
+	if d.useTLS {
+		tc := tls.Client(conn, &tls.Config{
+			ServerName: d.host,
+			MinVersion: tls.VersionTLS12,
+		})
+		if err := tc.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("HTTP proxy: %w", err)
+		}
+		conn = tc
+	}
+
+	conn, err = d.connect(conn, addr)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+func (d *connectDialer) connect(conn net.Conn, addr string) (net.Conn, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if d.user != nil {
+		password, _ := d.user.Password()
+		creds := base64.StdEncoding.EncodeToString([]byte(d.user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+creds)
+	}
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("HTTP proxy: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("HTTP proxy: %w", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("HTTP proxy: CONNECT %s: %s", addr, resp.Status)
+	}
+
+	if br.Buffered() > 0 {
+		// The proxy already passed on data from the other side, which the
+		// reader has consumed from the connection.
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
@@ -22,11 +22,13 @@ var (
 
 func init() {
 	proxy.RegisterDialerType("socks", socksDialerFunction)
+	proxy.RegisterDialerType("http", connectDialerFunction)
+	proxy.RegisterDialerType("https", connectDialerFunction)
 
 	if proxyDialer := proxy.FromEnvironment(); proxyDialer != proxy.Direct {
 		http.DefaultTransport = &http.Transport{
 			DialContext:         DialContext,
-			Proxy:               http.ProxyFromEnvironment,
+			Proxy:               HTTPProxy,
 			TLSHandshakeTimeout: 10 * time.Second,
 		}
 
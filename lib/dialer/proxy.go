@@ -0,0 +1,201 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package dialer
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/proxy"
+
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+// configuredProxy is the proxy set with SetProxy, which takes precedence
+// over the one in the environment.
+type configuredProxy struct {
+	uri      *url.URL
+	dialer   proxy.Dialer
+	fallback bool
+}
+
+var (
+	proxyMut       = sync.NewRWMutex()
+	proxyConfig    *configuredProxy
+	proxyLastErr   error
+	proxyLastErrAt time.Time
+	proxyLastOKAt  time.Time
+)
+
+// SetProxy sets the proxy used for all outgoing connections, in place of
+// the one given by ALL_PROXY in the environment. The address is a URL with
+// the scheme socks5 or socks5h for SOCKS5, or http or https for HTTP
+// CONNECT, optionally with a username and password. With fallback, direct
+// connections are attempted alongside those through the proxy. Hosts
+// listed in NO_PROXY in the environment are connected to directly. An
+// empty address reverts to using the environment.
+func SetProxy(address string, fallback bool) error {
+	var cfg *configuredProxy
+	if address != "" {
+		uri, err := ParseProxyURL(address)
+		if err != nil {
+			return err
+		}
+		dialer, err := proxy.FromURL(uri, proxy.Direct)
+		if err != nil {
+			return err
+		}
+		if noProxy := noProxyEnv(); noProxy != "" {
+			perHost := proxy.NewPerHost(dialer, proxy.Direct)
+			perHost.AddFromString(noProxy)
+			dialer = perHost
+		}
+		cfg = &configuredProxy{uri: uri, dialer: dialer, fallback: fallback}
+	}
+
+	proxyMut.Lock()
+	defer proxyMut.Unlock()
+	if cfg == nil && proxyConfig == nil {
+		return nil
+	}
+	if cfg != nil && proxyConfig != nil && cfg.uri.String() == proxyConfig.uri.String() && cfg.fallback == proxyConfig.fallback {
+		return nil
+	}
+	proxyConfig = cfg
+	proxyLastErr = nil
+	proxyLastErrAt = time.Time{}
+	proxyLastOKAt = time.Time{}
+	if cfg != nil {
+		l.Infof("Using proxy %s for outgoing connections", cfg.uri.Redacted())
+		if !cfg.fallback {
+			l.Infoln("Proxy fallback disabled")
+		}
+	}
+	return nil
+}
+
+// ParseProxyURL parses and validates a proxy address as accepted by
+// SetProxy.
+func ParseProxyURL(address string) (*url.URL, error) {
+	uri, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("proxy address: %w", err)
+	}
+	switch uri.Scheme {
+	case "socks", "socks5", "socks5h", "http", "https":
+	default:
+		return nil, fmt.Errorf("proxy address: unsupported scheme %q", uri.Scheme)
+	}
+	if uri.Hostname() == "" {
+		return nil, fmt.Errorf("proxy address: missing host")
+	}
+	return uri, nil
+}
+
+// activeProxy returns the proxy dialer to use, which is proxy.Direct when
+// there is none, and whether falling back to direct connections is allowed.
+func activeProxy() (proxy.Dialer, bool) {
+	proxyMut.RLock()
+	defer proxyMut.RUnlock()
+	if proxyConfig != nil {
+		return proxyConfig.dialer, proxyConfig.fallback
+	}
+	return proxy.FromEnvironment(), !noFallback
+}
+
+// DirectAllowed returns whether connections may be made without the proxy,
+// either because there is none or because falling back is allowed. Things
+// that can't be proxied, like QUIC, should only be used when it's true.
+func DirectAllowed() bool {
+	dialer, fallback := activeProxy()
+	return dialer == proxy.Direct || fallback
+}
+
+// HTTPProxy is to be used as the Proxy of http.Transports that dial with
+// DialContext. It returns the HTTP proxy from the environment, unless a
+// proxy was set with SetProxy, in which case DialContext already goes
+// through it.
+func HTTPProxy(req *http.Request) (*url.URL, error) {
+	proxyMut.RLock()
+	configured := proxyConfig != nil
+	proxyMut.RUnlock()
+	if configured {
+		return nil, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+func recordProxyResult(err error) {
+	proxyMut.Lock()
+	defer proxyMut.Unlock()
+	if err != nil {
+		proxyLastErr = err
+		proxyLastErrAt = time.Now()
+	} else {
+		proxyLastOKAt = time.Now()
+	}
+}
+
+// ProxyStatus describes the proxy in use for outgoing connections.
+type ProxyStatus struct {
+	// Source is "config", "environment", or empty when no proxy is used.
+	Source        string    `json:"source"`
+	Address       string    `json:"address"`
+	Fallback      bool      `json:"fallback"`
+	LastError     string    `json:"lastError"`
+	LastErrorAt   time.Time `json:"lastErrorAt"`
+	LastSuccessAt time.Time `json:"lastSuccessAt"`
+}
+
+// Status returns the current proxy status. Passwords are redacted.
+func Status() ProxyStatus {
+	proxyMut.RLock()
+	defer proxyMut.RUnlock()
+
+	var st ProxyStatus
+	switch {
+	case proxyConfig != nil:
+		st.Source = "config"
+		st.Address = proxyConfig.uri.Redacted()
+		st.Fallback = proxyConfig.fallback
+	case proxy.FromEnvironment() != proxy.Direct:
+		st.Source = "environment"
+		st.Address = environmentProxy()
+		st.Fallback = !noFallback
+	default:
+		return st
+	}
+	if proxyLastErr != nil {
+		st.LastError = proxyLastErr.Error()
+	}
+	st.LastErrorAt = proxyLastErrAt
+	st.LastSuccessAt = proxyLastOKAt
+	return st
+}
+
+func environmentProxy() string {
+	address := os.Getenv("ALL_PROXY")
+	if address == "" {
+		address = os.Getenv("all_proxy")
+	}
+	if uri, err := url.Parse(address); err == nil {
+		return uri.Redacted()
+	}
+	return ""
+}
+
+// noProxyEnv returns the hosts not to use a proxy for, as given in the
+// environment like for proxy.FromEnvironment.
+func noProxyEnv() string {
+	if noProxy := os.Getenv("NO_PROXY"); noProxy != "" {
+		return noProxy
+	}
+	return os.Getenv("no_proxy")
+}
@@ -65,7 +65,8 @@ func SetTrafficClass(conn net.Conn, class int) error {
 }
 
 func dialContextWithFallback(ctx context.Context, fallback proxy.ContextDialer, network, addr string) (net.Conn, error) {
-	dialer, ok := proxy.FromEnvironment().(proxy.ContextDialer)
+	proxyDialer, allowFallback := activeProxy()
+	dialer, ok := proxyDialer.(proxy.ContextDialer)
 	if !ok {
 		return nil, errUnexpectedInterfaceType
 	}
@@ -74,9 +75,10 @@ func dialContextWithFallback(ctx context.Context, fallback proxy.ContextDialer,
 		l.Debugf("Dialing direct result %s %s: %v %v", network, addr, conn, err)
 		return conn, err
 	}
-	if noFallback {
+	if !allowFallback {
 		conn, err := dialer.DialContext(ctx, network, addr)
 		l.Debugf("Dialing no fallback result %s %s: %v %v", network, addr, conn, err)
+		recordProxyResult(err)
 		if err != nil {
 			return nil, err
 		}
@@ -85,6 +87,10 @@ func dialContextWithFallback(ctx context.Context, fallback proxy.ContextDialer,
 
 	proxyDialFudgeAddress := func(ctx context.Context, network, address string) (net.Conn, error) {
 		conn, err := dialer.DialContext(ctx, network, addr)
+		if ctx.Err() == nil {
+			// Not counting dials cancelled as the fallback won.
+			recordProxyResult(err)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -107,7 +113,7 @@ func DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
 func DialContextReusePortFunc(registry *registry.Registry) func(ctx context.Context, network, addr string) (net.Conn, error) {
 	return func(ctx context.Context, network, addr string) (net.Conn, error) {
 		// If proxy is configured, there is no point trying to reuse listen addresses.
-		if proxy.FromEnvironment() != proxy.Direct {
+		if proxyDialer, _ := activeProxy(); proxyDialer != proxy.Direct {
 			return DialContext(ctx, network, addr)
 		}
 
@@ -24,6 +24,7 @@ type Finder interface {
 
 type CacheEntry struct {
 	Addresses  []string  `json:"addresses"`
+	BlockCache bool      `json:"blockCache,omitempty"` // Announced acting as a block cache, for local discovery
 	when       time.Time // When did we get the result
 	found      bool      // Is it a success (cacheTime applies) or a failure (negCacheTime applies)?
 	validUntil time.Time // Validity time, overrides normal calculation
@@ -18,6 +18,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	stdsync "sync"
 	"time"
 
@@ -68,6 +69,7 @@ type serverOptions struct {
 	insecure   bool   // don't check certificate
 	noAnnounce bool   // don't announce
 	noLookup   bool   // don't use for lookups
+	lookupCert bool   // present our certificate on lookups
 	id         string // expected server device ID
 }
 
@@ -110,7 +112,7 @@ func NewGlobal(server string, cert tls.Certificate, addrList AddressLister, evLo
 		Timeout: requestTimeout,
 		Transport: http2EnabledTransport(&http.Transport{
 			DialContext:       dialContext,
-			Proxy:             http.ProxyFromEnvironment,
+			Proxy:             dialer.HTTPProxy,
 			DisableKeepAlives: true, // announcements are few and far between, so don't keep the connection open
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: opts.insecure,
@@ -125,15 +127,21 @@ func NewGlobal(server string, cert tls.Certificate, addrList AddressLister, evLo
 	}
 
 	// The http.Client used for queries. We don't need to present our
-	// certificate here, so lets not include it. May be insecure if requested.
+	// certificate here, so lets not include it, unless the server requires
+	// it to allow lookups. May be insecure if requested.
+	var queryCerts []tls.Certificate
+	if opts.lookupCert {
+		queryCerts = []tls.Certificate{cert}
+	}
 	var queryClient httpClient = &contextClient{&http.Client{
 		Timeout: requestTimeout,
 		Transport: http2EnabledTransport(&http.Transport{
 			DialContext:     dialer.DialContext,
-			Proxy:           http.ProxyFromEnvironment,
+			Proxy:           dialer.HTTPProxy,
 			IdleConnTimeout: time.Second,
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: opts.insecure,
+				Certificates:       queryCerts,
 				MinVersion:         tls.VersionTLS12,
 				ClientSessionCache: tls.NewLRUClientSessionCache(0),
 			},
@@ -337,6 +345,7 @@ func parseOptions(dsn string) (server string, opts serverOptions, err error) {
 	opts.insecure = opts.id != "" || queryBool(q, "insecure")
 	opts.noAnnounce = queryBool(q, "noannounce")
 	opts.noLookup = queryBool(q, "nolookup")
+	opts.lookupCert = queryBool(q, "lookupcert")
 
 	// Check for disallowed combinations
 	if p.Scheme == "http" {
@@ -472,6 +481,14 @@ func ipv6Identity(addr string) string {
 	return fmt.Sprintf("IPv6 local multicast discovery on address %s", addr)
 }
 
+func unicastIdentity(port int, targets []string) string {
+	return fmt.Sprintf("Local unicast discovery on port %d to %s", port, strings.Join(targets, ", "))
+}
+
+func mdnsIdentity() string {
+	return "mDNS local discovery"
+}
+
 func http2EnabledTransport(t *http.Transport) *http.Transport {
 	_ = http2.ConfigureTransport(t)
 	return t
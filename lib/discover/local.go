@@ -15,6 +15,7 @@ import (
 	"net"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/syncthing/syncthing/lib/beacon"
@@ -31,6 +32,9 @@ type localClient struct {
 	addrList AddressLister
 	name     string
 	evLogger events.Logger
+	// blockCache returns whether we announce acting as a block cache for
+	// the other devices on the LAN. May be nil.
+	blockCache func() bool
 
 	beacon          beacon.Interface
 	localBcastStart time.Time
@@ -47,44 +51,55 @@ const (
 	v13Magic          = uint32(0x7D79BC40) // previous version
 )
 
-func NewLocal(id protocol.DeviceID, addr string, addrList AddressLister, evLogger events.Logger) (FinderService, error) {
-	c := &localClient{
-		Supervisor:      suture.New("local", svcutil.SpecWithDebugLogger(l)),
-		myID:            id,
-		addrList:        addrList,
-		evLogger:        evLogger,
-		localBcastTick:  time.NewTicker(BroadcastInterval).C,
-		forcedBcastTick: make(chan time.Time),
-		localBcastStart: time.Now(),
-		cache:           newCache(),
-	}
-
+func NewLocal(id protocol.DeviceID, addr string, addrList AddressLister, blockCache func() bool, evLogger events.Logger) (FinderService, error) {
 	host, port, err := net.SplitHostPort(addr)
 	if err != nil {
 		return nil, err
 	}
 
+	var c *localClient
 	if host == "" {
 		// A broadcast client
-		c.name = "IPv4 local"
 		bcPort, err := strconv.Atoi(port)
 		if err != nil {
 			return nil, err
 		}
-		c.beacon = beacon.NewBroadcast(bcPort)
+		c = newLocalClient(id, "IPv4 local", beacon.NewBroadcast(bcPort), addrList, blockCache, evLogger)
 	} else {
 		// A multicast client
-		c.name = "IPv6 local"
-		c.beacon = beacon.NewMulticast(addr)
+		c = newLocalClient(id, "IPv6 local", beacon.NewMulticast(addr), addrList, blockCache, evLogger)
 	}
-	c.Add(c.beacon)
 	c.Add(svcutil.AsService(c.recvAnnouncements, fmt.Sprintf("%s/recv", c)))
 
-	c.Add(svcutil.AsService(c.sendLocalAnnouncements, fmt.Sprintf("%s/sendLocal", c)))
-
 	return c, nil
 }
 
+// NewLocalUnicast returns a client sending announcements to the given hosts
+// and subnets directly. Announcements sent to us the same way are received
+// by the broadcast and multicast clients listening on the same port.
+func NewLocalUnicast(id protocol.DeviceID, port int, targets []string, addrList AddressLister, blockCache func() bool, evLogger events.Logger) FinderService {
+	return newLocalClient(id, "Unicast local", beacon.NewUnicast(port, targets), addrList, blockCache, evLogger)
+}
+
+func newLocalClient(id protocol.DeviceID, name string, bcn beacon.Interface, addrList AddressLister, blockCache func() bool, evLogger events.Logger) *localClient {
+	c := &localClient{
+		Supervisor:      suture.New("local", svcutil.SpecWithDebugLogger(l)),
+		myID:            id,
+		addrList:        addrList,
+		blockCache:      blockCache,
+		name:            name,
+		evLogger:        evLogger,
+		beacon:          bcn,
+		localBcastTick:  time.NewTicker(BroadcastInterval).C,
+		forcedBcastTick: make(chan time.Time),
+		localBcastStart: time.Now(),
+		cache:           newCache(),
+	}
+	c.Add(c.beacon)
+	c.Add(svcutil.AsService(c.sendLocalAnnouncements, fmt.Sprintf("%s/sendLocal", c)))
+	return c
+}
+
 // Lookup returns a list of addresses the device is available at.
 func (c *localClient) Lookup(_ context.Context, device protocol.DeviceID) (addresses []string, err error) {
 	if cache, ok := c.Get(device); ok {
@@ -125,6 +140,7 @@ func (c *localClient) announcementPkt(instanceID int64, msg []byte) ([]byte, boo
 		ID:         c.myID,
 		Addresses:  addrs,
 		InstanceID: instanceID,
+		BlockCache: c.blockCache != nil && c.blockCache(),
 	}
 	bs, _ := pkt.Marshal()
 
@@ -219,6 +235,16 @@ func (c *localClient) recvAnnouncements(ctx context.Context) error {
 }
 
 func (c *localClient) registerDevice(src net.Addr, device Announce) bool {
+	return registerDevice(c.cache, c.evLogger, src, device, true)
+}
+
+// registerDevice records the addresses of an announced device in the cache,
+// returning whether the device is new to us. When the announcements carry
+// whether the device acts as a block cache, discovery is also logged for
+// devices that started or stopped doing so. That's only a hint for the
+// user; announcements are unauthenticated, so a device is only used as a
+// block cache once configured as one.
+func registerDevice(c *cache, evLogger events.Logger, src net.Addr, device Announce, withBlockCache bool) bool {
 	// Remember whether we already had a valid cache entry for this device.
 	// If the instance ID has changed the remote device has restarted since
 	// we last heard from it, so we should treat it as a new device.
@@ -226,12 +252,37 @@ func (c *localClient) registerDevice(src net.Addr, device Announce) bool {
 	ce, existsAlready := c.Get(device.ID)
 	isNewDevice := !existsAlready || time.Since(ce.when) > CacheLifeTime || ce.instanceID != device.InstanceID
 
-	// Any empty or unspecified addresses should be set to the source address
-	// of the announcement. We also skip any addresses we can't parse.
-
 	l.Debugln("discover: Registering addresses for", device.ID)
+	validAddresses := resolveAnnouncedAddresses(src, device.Addresses)
+
+	c.Set(device.ID, CacheEntry{
+		Addresses:  validAddresses,
+		BlockCache: device.BlockCache,
+		when:       time.Now(),
+		found:      true,
+		instanceID: device.InstanceID,
+	})
+
+	if isNewDevice || (withBlockCache && ce.BlockCache != device.BlockCache) {
+		data := map[string]interface{}{
+			"device": device.ID.String(),
+			"addrs":  validAddresses,
+		}
+		if withBlockCache {
+			data["blockCache"] = device.BlockCache
+		}
+		evLogger.Log(events.DeviceDiscovered, data)
+	}
+
+	return isNewDevice
+}
+
+// resolveAnnouncedAddresses sets any empty or unspecified addresses to the
+// source address of the announcement. We also skip any addresses we can't
+// parse.
+func resolveAnnouncedAddresses(src net.Addr, addrs []string) []string {
 	var validAddresses []string
-	for _, addr := range device.Addresses {
+	for _, addr := range addrs {
 		u, err := url.Parse(addr)
 		if err != nil {
 			continue
@@ -272,21 +323,7 @@ func (c *localClient) registerDevice(src net.Addr, device Announce) bool {
 		}
 	}
 
-	c.Set(device.ID, CacheEntry{
-		Addresses:  validAddresses,
-		when:       time.Now(),
-		found:      true,
-		instanceID: device.InstanceID,
-	})
-
-	if isNewDevice {
-		c.evLogger.Log(events.DeviceDiscovered, map[string]interface{}{
-			"device": device.ID.String(),
-			"addrs":  validAddresses,
-		})
-	}
-
-	return isNewDevice
+	return validAddresses
 }
 
 // filterUndialableLocal returns the list of addresses after removing any
@@ -324,7 +361,7 @@ func sanitizeRelayAddresses(addrs []string) []string {
 			continue
 		}
 
-		if u.Scheme == "relay" {
+		if u.Scheme == "relay" || strings.HasPrefix(u.Scheme, "relay+") {
 			s := url.Values{}
 			q := u.Query()
 
@@ -29,6 +29,7 @@ type Announce struct {
 	ID         github_com_syncthing_syncthing_lib_protocol.DeviceID `protobuf:"bytes,1,opt,name=id,proto3,customtype=github.com/syncthing/syncthing/lib/protocol.DeviceID" json:"id" xml:"id"`
 	Addresses  []string                                             `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses" xml:"address"`
 	InstanceID int64                                                `protobuf:"varint,3,opt,name=instance_id,json=instanceId,proto3" json:"instanceId" xml:"instanceId"`
+	BlockCache bool                                                 `protobuf:"varint,4,opt,name=block_cache,json=blockCache,proto3" json:"blockCache" xml:"blockCache"`
 }
 
 func (m *Announce) Reset()         { *m = Announce{} }
@@ -71,28 +72,30 @@ func init() {
 func init() { proto.RegisterFile("lib/discover/local.proto", fileDescriptor_18afca46562fdaf4) }
 
 var fileDescriptor_18afca46562fdaf4 = []byte{
-	// 334 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0x31, 0x6b, 0xe3, 0x30,
-	0x18, 0x86, 0x2d, 0x05, 0x8e, 0x44, 0x77, 0x07, 0x87, 0x27, 0x93, 0x41, 0x0a, 0xbe, 0x0c, 0x81,
-	0x42, 0x3c, 0xb4, 0x53, 0x29, 0x85, 0x1a, 0x2f, 0x1e, 0xba, 0x64, 0xec, 0xd0, 0x10, 0x4b, 0xaa,
-	0x23, 0x70, 0xa4, 0x60, 0x39, 0x21, 0xfd, 0x07, 0x1d, 0x4b, 0xb6, 0x6e, 0xfd, 0x39, 0x19, 0x3d,
-	0x96, 0x0e, 0x82, 0xd8, 0x5b, 0xc6, 0xfc, 0x82, 0x12, 0x25, 0x69, 0x32, 0x76, 0x7b, 0xf5, 0xe8,
-	0x95, 0x78, 0xf8, 0x3e, 0xe4, 0x65, 0x22, 0x09, 0x98, 0xd0, 0x54, 0xcd, 0x79, 0x1e, 0x64, 0x8a,
-	0x8e, 0xb2, 0xfe, 0x34, 0x57, 0x85, 0x72, 0x9b, 0x47, 0xda, 0xfe, 0x9f, 0xf3, 0xa9, 0xd2, 0x81,
-	0xc5, 0xc9, 0xec, 0x29, 0x48, 0x55, 0xaa, 0xec, 0xc1, 0xa6, 0x7d, 0xbd, 0xdd, 0xe2, 0x8b, 0x62,
-	0x1f, 0xfd, 0x37, 0x88, 0x9a, 0x77, 0x52, 0xaa, 0x99, 0xa4, 0xdc, 0x95, 0x08, 0x0a, 0xe6, 0x81,
-	0x0e, 0xe8, 0xfd, 0x09, 0x1f, 0x57, 0x86, 0x38, 0x9f, 0x86, 0x5c, 0xa5, 0xa2, 0x18, 0xcf, 0x92,
-	0x3e, 0x55, 0x93, 0x40, 0x3f, 0x4b, 0x5a, 0x8c, 0x85, 0x4c, 0xcf, 0xd2, 0xce, 0xc9, 0x7e, 0x45,
-	0x55, 0xd6, 0x8f, 0xf8, 0x5c, 0x50, 0x1e, 0x47, 0x95, 0x21, 0x30, 0x8e, 0x36, 0x86, 0x40, 0xc1,
-	0xb6, 0x86, 0x34, 0x17, 0x93, 0xec, 0xda, 0x17, 0xcc, 0x7f, 0x29, 0xbb, 0x60, 0x59, 0x76, 0x61,
-	0x1c, 0x0d, 0xa0, 0x60, 0xee, 0x0d, 0x6a, 0x8d, 0x18, 0xcb, 0xb9, 0xd6, 0x5c, 0x7b, 0xb0, 0xd3,
-	0xe8, 0xb5, 0x42, 0xbc, 0x31, 0xe4, 0x04, 0xb7, 0x86, 0xfc, 0xb5, 0x6f, 0x0f, 0xc4, 0x1f, 0x9c,
-	0xee, 0xdc, 0x21, 0xfa, 0x2d, 0xa4, 0x2e, 0x46, 0x92, 0xf2, 0xa1, 0x60, 0x5e, 0xa3, 0x03, 0x7a,
-	0x8d, 0xf0, 0xb6, 0x32, 0x04, 0xc5, 0x07, 0x6c, 0x15, 0xd0, 0xb1, 0x14, 0xef, 0x54, 0xfe, 0xed,
-	0x55, 0xbe, 0x91, 0xbf, 0x2c, 0xbb, 0x67, 0xfd, 0xc1, 0x59, 0x3b, 0xbc, 0x5f, 0xad, 0xb1, 0x53,
-	0xae, 0xb1, 0xb3, 0xaa, 0x30, 0x28, 0x2b, 0x0c, 0x5e, 0x6b, 0xec, 0xbc, 0xd7, 0x18, 0x94, 0x35,
-	0x76, 0x3e, 0x6a, 0xec, 0x3c, 0x5c, 0xfc, 0x60, 0x38, 0xc7, 0xd5, 0x24, 0xbf, 0xec, 0x98, 0x2e,
-	0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0xdc, 0xe8, 0x9a, 0xd0, 0xc7, 0x01, 0x00, 0x00,
+	// 360 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x91, 0xbd, 0xee, 0xd3, 0x30,
+	0x14, 0xc5, 0xe3, 0x14, 0xa1, 0xd4, 0x7f, 0x90, 0x50, 0xa6, 0xa8, 0x83, 0x1d, 0x85, 0x0e, 0x91,
+	0x90, 0x9a, 0x01, 0x26, 0x84, 0x90, 0x48, 0xb3, 0x64, 0x60, 0xc9, 0xc8, 0x40, 0x95, 0xd8, 0x26,
+	0xb5, 0x48, 0xed, 0x2a, 0x49, 0xab, 0xf2, 0x06, 0x8c, 0xa8, 0x4f, 0xc0, 0xe3, 0x74, 0xcc, 0x88,
+	0x18, 0x2c, 0x35, 0xd9, 0x3a, 0xf6, 0x09, 0x50, 0xdc, 0xaf, 0x8c, 0x6c, 0xc7, 0xbf, 0x7b, 0x7c,
+	0x74, 0x74, 0x2f, 0x74, 0x0a, 0x9e, 0x05, 0x94, 0x57, 0x44, 0x6e, 0x59, 0x19, 0x14, 0x92, 0xa4,
+	0xc5, 0x6c, 0x5d, 0xca, 0x5a, 0xda, 0xd6, 0x8d, 0x4e, 0x5e, 0x97, 0x6c, 0x2d, 0xab, 0x40, 0xe3,
+	0x6c, 0xf3, 0x2d, 0xc8, 0x65, 0x2e, 0xf5, 0x43, 0xab, 0x8b, 0x7d, 0x32, 0x66, 0xbb, 0xfa, 0x22,
+	0x3d, 0x65, 0x42, 0xeb, 0x93, 0x10, 0x72, 0x23, 0x08, 0xb3, 0x05, 0x34, 0x39, 0x75, 0x80, 0x0b,
+	0xfc, 0x17, 0xe1, 0xd7, 0x83, 0xc2, 0xc6, 0x5f, 0x85, 0xdf, 0xe5, 0xbc, 0x5e, 0x6e, 0xb2, 0x19,
+	0x91, 0xab, 0xa0, 0xfa, 0x21, 0x48, 0xbd, 0xe4, 0x22, 0x1f, 0xa8, 0xbe, 0x93, 0x8e, 0x22, 0xb2,
+	0x98, 0x45, 0x6c, 0xcb, 0x09, 0x8b, 0xa3, 0x56, 0x61, 0x33, 0x8e, 0x4e, 0x0a, 0x9b, 0x9c, 0x9e,
+	0x15, 0xb6, 0x76, 0xab, 0xe2, 0xbd, 0xc7, 0xa9, 0xf7, 0xb3, 0x99, 0x82, 0x7d, 0x33, 0x35, 0xe3,
+	0x28, 0x31, 0x39, 0xb5, 0x3f, 0xc0, 0x71, 0x4a, 0x69, 0xc9, 0xaa, 0x8a, 0x55, 0x8e, 0xe9, 0x8e,
+	0xfc, 0x71, 0x88, 0x4e, 0x0a, 0x3f, 0xe0, 0x59, 0xe1, 0x97, 0xfa, 0xef, 0x95, 0x78, 0xc9, 0x63,
+	0x66, 0x2f, 0xe0, 0x13, 0x17, 0x55, 0x9d, 0x0a, 0xc2, 0x16, 0x9c, 0x3a, 0x23, 0x17, 0xf8, 0xa3,
+	0xf0, 0x63, 0xab, 0x30, 0x8c, 0xaf, 0x58, 0x57, 0x80, 0x37, 0x53, 0xdc, 0x57, 0x79, 0x75, 0xa9,
+	0x72, 0x47, 0xde, 0xbe, 0x99, 0x0e, 0xfc, 0xc9, 0xc0, 0x6d, 0xcf, 0xe1, 0x53, 0x56, 0x48, 0xf2,
+	0x7d, 0x41, 0x52, 0xb2, 0x64, 0xce, 0x33, 0x17, 0xf8, 0x56, 0xe8, 0xf5, 0x91, 0x1a, 0xcf, 0x7b,
+	0x7a, 0x8f, 0x7c, 0x20, 0x2f, 0x19, 0xcc, 0xc3, 0xcf, 0x87, 0x23, 0x32, 0x9a, 0x23, 0x32, 0x0e,
+	0x2d, 0x02, 0x4d, 0x8b, 0xc0, 0xaf, 0x0e, 0x19, 0xbf, 0x3b, 0x04, 0x9a, 0x0e, 0x19, 0x7f, 0x3a,
+	0x64, 0x7c, 0x79, 0xf3, 0x1f, 0x1b, 0xbe, 0xdd, 0x37, 0x7b, 0xae, 0x77, 0xfd, 0xf6, 0xdf, 0x00,
+	0xcb, 0x13, 0x89, 0x52, 0x0c, 0x02, 0x00, 0x00,
 }
 
 func (m *Announce) Marshal() (dAtA []byte, err error) {
@@ -115,6 +118,16 @@ func (m *Announce) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.BlockCache {
+		i--
+		if m.BlockCache {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.InstanceID != 0 {
 		i = encodeVarintLocal(dAtA, i, uint64(m.InstanceID))
 		i--
@@ -170,6 +183,9 @@ func (m *Announce) ProtoSize() (n int) {
 	if m.InstanceID != 0 {
 		n += 1 + sovLocal(uint64(m.InstanceID))
 	}
+	if m.BlockCache {
+		n += 2
+	}
 	return n
 }
 
@@ -292,6 +308,26 @@ func (m *Announce) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockCache", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLocal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BlockCache = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLocal(dAtA[iNdEx:])
@@ -12,13 +12,14 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/protocol"
 )
 
 func TestLocalInstanceID(t *testing.T) {
-	c, err := NewLocal(protocol.LocalDeviceID, ":0", &fakeAddressLister{}, events.NoopLogger)
+	c, err := NewLocal(protocol.LocalDeviceID, ":0", &fakeAddressLister{}, nil, events.NoopLogger)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -42,7 +43,7 @@ func TestLocalInstanceID(t *testing.T) {
 }
 
 func TestLocalInstanceIDShouldTriggerNew(t *testing.T) {
-	c, err := NewLocal(protocol.LocalDeviceID, ":0", &fakeAddressLister{}, events.NoopLogger)
+	c, err := NewLocal(protocol.LocalDeviceID, ":0", &fakeAddressLister{}, nil, events.NoopLogger)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -91,6 +92,84 @@ func TestLocalInstanceIDShouldTriggerNew(t *testing.T) {
 	}
 }
 
+func TestLocalBlockCache(t *testing.T) {
+	blockCache := false
+	c, err := NewLocal(protocol.LocalDeviceID, ":0", &fakeAddressLister{}, func() bool { return blockCache }, events.NoopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lc := c.(*localClient)
+
+	for _, announce := range []bool{false, true} {
+		blockCache = announce
+		msg, ok := lc.announcementPkt(1, nil)
+		if !ok {
+			t.Fatal("unexpectedly not ok")
+		}
+		var pkt Announce
+		if err := pkt.Unmarshal(msg[4:]); err != nil {
+			t.Fatal(err)
+		}
+		if pkt.BlockCache != announce {
+			t.Errorf("Expected block cache %v to be announced, got %v", announce, pkt.BlockCache)
+		}
+	}
+}
+
+func TestLocalBlockCacheDiscovered(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	evLogger := events.NewLogger()
+	go evLogger.Serve(ctx)
+	sub := evLogger.Subscribe(events.DeviceDiscovered)
+	defer sub.Unsubscribe()
+
+	c, err := NewLocal(protocol.LocalDeviceID, ":0", &fakeAddressLister{}, nil, evLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lc := c.(*localClient)
+	src := &net.UDPAddr{IP: []byte{10, 20, 30, 40}, Port: 50}
+	device := protocol.DeviceID{10, 20, 30, 40, 50, 60, 70, 80, 90}
+
+	expectEvent := func(blockCache bool) {
+		t.Helper()
+		ev, err := sub.Poll(time.Second)
+		if err != nil {
+			t.Fatal("Expected a discovery event:", err)
+		}
+		if data := ev.Data.(map[string]interface{}); data["blockCache"] != blockCache {
+			t.Errorf("Expected block cache %v in the event, got %v", blockCache, data["blockCache"])
+		}
+		if ce, _ := lc.Get(device); ce.BlockCache != blockCache {
+			t.Errorf("Expected block cache %v in the cache, got %v", blockCache, ce.BlockCache)
+		}
+	}
+
+	for _, tc := range []struct {
+		blockCache bool
+		event      bool
+	}{
+		{false, true}, // new device
+		{false, false},
+		{true, true}, // started acting as a block cache
+		{true, false},
+		{false, true}, // stopped
+	} {
+		lc.registerDevice(src, Announce{
+			ID:         device,
+			Addresses:  []string{"tcp://0.0.0.0:22000"},
+			InstanceID: 1234567890,
+			BlockCache: tc.blockCache,
+		})
+		if tc.event {
+			expectEvent(tc.blockCache)
+		} else if _, err := sub.Poll(100 * time.Millisecond); err == nil {
+			t.Error("Unexpected discovery event")
+		}
+	}
+}
+
 func TestFilterUndialable(t *testing.T) {
 	addrs := []string{
 		"quic://[2001:db8::1]:22000",             // OK
@@ -216,6 +216,7 @@ func (m *manager) Cache() map[protocol.DeviceID]CacheEntry {
 					cur.when = v.when
 				}
 				cur.Addresses = append(cur.Addresses, v.Addresses...)
+				cur.BlockCache = cur.BlockCache || v.BlockCache
 				res[k] = cur
 			}
 		}
@@ -230,6 +231,12 @@ func (m *manager) Cache() map[protocol.DeviceID]CacheEntry {
 	return res
 }
 
+// announceBlockCache returns whether local discovery announces us as a
+// block cache, which we are whenever the cache is enabled.
+func (m *manager) announceBlockCache() bool {
+	return m.cfg.Options().BlockCacheMaxSizeMiB > 0
+}
+
 func (m *manager) CommitConfiguration(_, to config.Configuration) (handled bool) {
 	m.mut.Lock()
 	defer m.mut.Unlock()
@@ -243,6 +250,12 @@ func (m *manager) CommitConfiguration(_, to config.Configuration) (handled bool)
 	if to.Options.LocalAnnEnabled {
 		toIdentities[ipv4Identity(to.Options.LocalAnnPort)] = struct{}{}
 		toIdentities[ipv6Identity(to.Options.LocalAnnMCAddr)] = struct{}{}
+		if len(to.Options.LocalAnnUnicastAddresses) > 0 {
+			toIdentities[unicastIdentity(to.Options.LocalAnnPort, to.Options.LocalAnnUnicastAddresses)] = struct{}{}
+		}
+		if to.Options.LocalAnnMDNSEnabled {
+			toIdentities[mdnsIdentity()] = struct{}{}
+		}
 	}
 
 	// Remove things that we're not expected to have.
@@ -277,7 +290,7 @@ func (m *manager) CommitConfiguration(_, to config.Configuration) (handled bool)
 		// v4 broadcasts
 		v4Identity := ipv4Identity(to.Options.LocalAnnPort)
 		if _, ok := m.finders[v4Identity]; !ok {
-			bcd, err := NewLocal(m.myID, fmt.Sprintf(":%d", to.Options.LocalAnnPort), m.addressLister, m.evLogger)
+			bcd, err := NewLocal(m.myID, fmt.Sprintf(":%d", to.Options.LocalAnnPort), m.addressLister, m.announceBlockCache, m.evLogger)
 			if err != nil {
 				l.Warnln("IPv4 local discovery:", err)
 			} else {
@@ -288,13 +301,29 @@ func (m *manager) CommitConfiguration(_, to config.Configuration) (handled bool)
 		// v6 multicasts
 		v6Identity := ipv6Identity(to.Options.LocalAnnMCAddr)
 		if _, ok := m.finders[v6Identity]; !ok {
-			mcd, err := NewLocal(m.myID, to.Options.LocalAnnMCAddr, m.addressLister, m.evLogger)
+			mcd, err := NewLocal(m.myID, to.Options.LocalAnnMCAddr, m.addressLister, m.announceBlockCache, m.evLogger)
 			if err != nil {
 				l.Warnln("IPv6 local discovery:", err)
 			} else {
 				m.addLocked(v6Identity, mcd, 0, 0)
 			}
 		}
+
+		// unicasts to configured hosts and subnets
+		if len(to.Options.LocalAnnUnicastAddresses) > 0 {
+			ucIdentity := unicastIdentity(to.Options.LocalAnnPort, to.Options.LocalAnnUnicastAddresses)
+			if _, ok := m.finders[ucIdentity]; !ok {
+				ucd := NewLocalUnicast(m.myID, to.Options.LocalAnnPort, to.Options.LocalAnnUnicastAddresses, m.addressLister, m.announceBlockCache, m.evLogger)
+				m.addLocked(ucIdentity, ucd, 0, 0)
+			}
+		}
+
+		// mDNS
+		if to.Options.LocalAnnMDNSEnabled {
+			if _, ok := m.finders[mdnsIdentity()]; !ok {
+				m.addLocked(mdnsIdentity(), NewMDNS(m.myID, m.addressLister, m.evLogger), 0, 0)
+			}
+		}
 	}
 
 	return true
@@ -0,0 +1,373 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package discover
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thejerf/suture/v4"
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/syncthing/syncthing/lib/beacon"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/svcutil"
+)
+
+const (
+	mdnsService = "_syncthing._tcp.local."
+	mdnsTTL     = uint32(2 * CacheLifeTime / time.Second)
+
+	// We don't answer queries more often than this, however many devices
+	// are asking.
+	mdnsMinAnnounceInterval = time.Second
+
+	// The longest string a TXT record can hold
+	maxTXTLen = 255
+)
+
+// An mdnsClient advertises us as a DNS-SD service instance of
+// _syncthing._tcp and looks up the other instances, named by device ID,
+// with the addresses in their TXT records.
+type mdnsClient struct {
+	*suture.Supervisor
+	myID       protocol.DeviceID
+	addrList   AddressLister
+	evLogger   events.Logger
+	beacon     beacon.Interface
+	instanceID int64
+	forcedTick chan struct{}
+
+	*cache
+}
+
+func NewMDNS(id protocol.DeviceID, addrList AddressLister, evLogger events.Logger) FinderService {
+	c := &mdnsClient{
+		Supervisor: suture.New("mdns", svcutil.SpecWithDebugLogger(l)),
+		myID:       id,
+		addrList:   addrList,
+		evLogger:   evLogger,
+		beacon:     beacon.NewMDNS(),
+		instanceID: rand.Int63(),
+		forcedTick: make(chan struct{}),
+		cache:      newCache(),
+	}
+	c.Add(c.beacon)
+	c.Add(svcutil.AsService(c.recvMessages, fmt.Sprintf("%s/recv", c)))
+	c.Add(svcutil.AsService(c.sendAnnouncements, fmt.Sprintf("%s/send", c)))
+	return c
+}
+
+// Lookup returns a list of addresses the device is available at.
+func (c *mdnsClient) Lookup(_ context.Context, device protocol.DeviceID) (addresses []string, err error) {
+	if cache, ok := c.Get(device); ok {
+		if time.Since(cache.when) < CacheLifeTime {
+			addresses = cache.Addresses
+		}
+	}
+
+	return
+}
+
+func (c *mdnsClient) String() string {
+	return "mDNS local"
+}
+
+func (c *mdnsClient) Error() error {
+	return c.beacon.Error()
+}
+
+func (c *mdnsClient) sendAnnouncements(ctx context.Context) error {
+	// Ask first, so that we learn about the others right away rather than
+	// at their next announcement.
+	if msg, err := mdnsQuery(); err != nil {
+		l.Debugln("discover: Failed to build mDNS query:", err)
+	} else {
+		c.beacon.Send(msg)
+	}
+
+	ticker := time.NewTicker(BroadcastInterval)
+	defer ticker.Stop()
+	for {
+		addrs := sanitizeRelayAddresses(filterUndialableLocal(c.addrList.AllAddresses()))
+		if len(addrs) > 0 {
+			msg, err := mdnsAnnouncement(c.myID, c.instanceID, addrs, localIPs(addrs))
+			if err != nil {
+				l.Debugln("discover: Failed to build mDNS announcement:", err)
+			} else {
+				c.beacon.Send(msg)
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-c.forcedTick:
+			select {
+			case <-time.After(mdnsMinAnnounceInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *mdnsClient) recvMessages(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		buf, addr := c.beacon.Recv()
+		if addr == nil {
+			continue
+		}
+
+		devices, query, err := parseMDNS(buf)
+		if err != nil {
+			l.Debugf("discover: Failed to parse mDNS message from %s: %v", addr, err)
+			continue
+		}
+
+		// Answer queries for our service, and announce ourselves to new
+		// devices right away.
+		announce := query
+		for _, device := range devices {
+			if device.ID == c.myID {
+				continue
+			}
+			l.Debugf("discover: Received mDNS announcement from %s for %s", addr, device.ID)
+			// The TXT records don't say whether the device acts as a block
+			// cache; that's left to the local discovery announcements.
+			if registerDevice(c.cache, c.evLogger, addr, device, false) {
+				announce = true
+			}
+		}
+
+		if announce {
+			select {
+			case c.forcedTick <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// mdnsQuery returns a query for the instances of our service.
+func mdnsQuery() ([]byte, error) {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{})
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	err := b.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(mdnsService),
+		Type:  dnsmessage.TypePTR,
+		Class: dnsmessage.ClassINET,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// mdnsAnnouncement returns an unsolicited response announcing our service
+// instance: the PTR record naming it, and the TXT, SRV and address records
+// describing it. Other devices use the TXT record, which carries the device
+// ID and addresses like the local discovery announcements do; the rest is
+// for generic DNS-SD browsers.
+func mdnsAnnouncement(id protocol.DeviceID, instanceID int64, addrs []string, ips []net.IP) ([]byte, error) {
+	service := dnsmessage.MustNewName(mdnsService)
+	instance, err := dnsmessage.NewName(id.String() + "." + mdnsService)
+	if err != nil {
+		return nil, err
+	}
+	host, err := dnsmessage.NewName("syncthing-" + id.Short().String() + ".local.")
+	if err != nil {
+		return nil, err
+	}
+	header := func(name dnsmessage.Name, typ dnsmessage.Type) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: name, Type: typ, Class: dnsmessage.ClassINET, TTL: mdnsTTL}
+	}
+
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{Response: true, Authoritative: true})
+	b.EnableCompression()
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+	if err := b.PTRResource(header(service, dnsmessage.TypePTR), dnsmessage.PTRResource{PTR: instance}); err != nil {
+		return nil, err
+	}
+
+	if err := b.StartAdditionals(); err != nil {
+		return nil, err
+	}
+	txt := []string{"id=" + id.String(), "instance=" + strconv.FormatInt(instanceID, 10)}
+	for _, addr := range addrs {
+		if entry := "addr=" + addr; len(entry) <= maxTXTLen {
+			txt = append(txt, entry)
+		}
+	}
+	if err := b.TXTResource(header(instance, dnsmessage.TypeTXT), dnsmessage.TXTResource{TXT: txt}); err != nil {
+		return nil, err
+	}
+	if port := listenPort(addrs); port != 0 {
+		if err := b.SRVResource(header(instance, dnsmessage.TypeSRV), dnsmessage.SRVResource{Port: port, Target: host}); err != nil {
+			return nil, err
+		}
+	}
+	for _, ip := range ips {
+		if ip4 := ip.To4(); ip4 != nil {
+			err = b.AResource(header(host, dnsmessage.TypeA), dnsmessage.AResource{A: [4]byte(ip4)})
+		} else {
+			err = b.AAAAResource(header(host, dnsmessage.TypeAAAA), dnsmessage.AAAAResource{AAAA: [16]byte(ip.To16())})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Finish()
+}
+
+// parseMDNS returns the devices announced in an mDNS message, and whether
+// the message is a query for our service.
+func parseMDNS(msg []byte) ([]Announce, bool, error) {
+	var p dnsmessage.Parser
+	hdr, err := p.Start(msg)
+	if err != nil {
+		return nil, false, err
+	}
+	questions, err := p.AllQuestions()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !hdr.Response {
+		for _, q := range questions {
+			if isServiceName(q.Name) {
+				return nil, true, nil
+			}
+		}
+		return nil, false, nil
+	}
+
+	records, err := p.AllAnswers()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := p.SkipAllAuthorities(); err != nil {
+		return nil, false, err
+	}
+	additionals, err := p.AllAdditionals()
+	if err != nil {
+		return nil, false, err
+	}
+	records = append(records, additionals...)
+
+	var devices []Announce
+	for _, r := range records {
+		txt, ok := r.Body.(*dnsmessage.TXTResource)
+		if !ok || !isServiceName(r.Header.Name) {
+			continue
+		}
+
+		var device Announce
+		var hasID bool
+		for _, entry := range txt.TXT {
+			key, val, _ := strings.Cut(entry, "=")
+			switch key {
+			case "id":
+				if id, err := protocol.DeviceIDFromString(val); err == nil {
+					device.ID = id
+					hasID = true
+				}
+			case "instance":
+				device.InstanceID, _ = strconv.ParseInt(val, 10, 64)
+			case "addr":
+				device.Addresses = append(device.Addresses, val)
+			}
+		}
+		if hasID {
+			devices = append(devices, device)
+		}
+	}
+
+	return devices, false, nil
+}
+
+// isServiceName returns whether the name is that of our service or of one
+// of its instances.
+func isServiceName(name dnsmessage.Name) bool {
+	s := strings.ToLower(name.String())
+	return s == mdnsService || strings.HasSuffix(s, "."+mdnsService)
+}
+
+// listenPort returns the port of the first address we accept direct
+// connections on, or zero if there is none.
+func listenPort(addrs []string) uint16 {
+	for _, addr := range addrs {
+		u, err := url.Parse(addr)
+		if err != nil || strings.HasPrefix(u.Scheme, "relay") {
+			continue
+		}
+		if port, err := strconv.ParseUint(u.Port(), 10, 16); err == nil && port != 0 {
+			return uint16(port)
+		}
+	}
+	return 0
+}
+
+// localIPs returns the IP addresses we accept direct connections on, using
+// the interface addresses for listeners on the unspecified address.
+func localIPs(addrs []string) []net.IP {
+	var ips []net.IP
+	seen := make(map[string]struct{})
+	add := func(ip net.IP) {
+		if _, ok := seen[ip.String()]; !ok {
+			seen[ip.String()] = struct{}{}
+			ips = append(ips, ip)
+		}
+	}
+
+	unspecified := false
+	for _, addr := range addrs {
+		u, err := url.Parse(addr)
+		if err != nil || strings.HasPrefix(u.Scheme, "relay") {
+			continue
+		}
+		ip := net.ParseIP(u.Hostname())
+		if ip == nil || ip.IsUnspecified() {
+			unspecified = true
+			continue
+		}
+		add(ip)
+	}
+
+	if unspecified {
+		ifaddrs, err := net.InterfaceAddrs()
+		if err != nil {
+			l.Debugln("discover: Failed to list interface addresses:", err)
+		}
+		for _, ifaddr := range ifaddrs {
+			if ipnet, ok := ifaddr.(*net.IPNet); ok && (ipnet.IP.IsGlobalUnicast() || ipnet.IP.IsLinkLocalUnicast()) {
+				add(ipnet.IP)
+			}
+		}
+	}
+
+	return ips
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package discover
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestMDNSAnnouncementRoundTrip(t *testing.T) {
+	id := protocol.DeviceID{10, 20, 30, 40, 50, 60, 70, 80, 90}
+	addrs := []string{"tcp://0.0.0.0:22000", "quic://192.168.1.10:22000", "relay://192.0.2.42:22067/?id=abc"}
+	ips := []net.IP{net.ParseIP("192.168.1.10"), net.ParseIP("fd00::10")}
+
+	msg, err := mdnsAnnouncement(id, 1234, addrs, ips)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	devices, query, err := parseMDNS(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query {
+		t.Error("announcement should not be a query")
+	}
+	if len(devices) != 1 {
+		t.Fatalf("expected one device, got %d", len(devices))
+	}
+	if devices[0].ID != id || devices[0].InstanceID != 1234 || !slices.Equal(devices[0].Addresses, addrs) {
+		t.Errorf("unexpected announcement %v", devices[0])
+	}
+
+	// Announced unspecified addresses resolve to the source address.
+	c := NewMDNS(protocol.LocalDeviceID, &fakeAddressLister{}, events.NoopLogger).(*mdnsClient)
+	src := &net.UDPAddr{IP: net.ParseIP("192.168.1.20"), Port: 5353}
+	if !registerDevice(c.cache, c.evLogger, src, devices[0], false) {
+		t.Error("first register should be new")
+	}
+	if res, _ := c.Lookup(context.Background(), id); len(res) == 0 || res[0] != "tcp://192.168.1.20:22000" {
+		t.Errorf("unexpected addresses %v", res)
+	}
+}
+
+func TestMDNSQuery(t *testing.T) {
+	msg, err := mdnsQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	devices, query, err := parseMDNS(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !query || len(devices) != 0 {
+		t.Errorf("expected a query without devices, got %v, %v", query, devices)
+	}
+}
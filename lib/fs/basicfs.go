@@ -41,11 +41,29 @@ func (*OptionJunctionsAsDirs) String() string {
 	return "junctionsAsDirs"
 }
 
+// OptionFanotifyWatcher makes Watch use fanotify where available, see
+// watchFanotify.
+type OptionFanotifyWatcher struct{}
+
+func (*OptionFanotifyWatcher) apply(fs Filesystem) Filesystem {
+	if basic, ok := fs.(*BasicFilesystem); !ok {
+		l.Warnln("WithFanotifyWatcher must only be used with FilesystemTypeBasic")
+	} else {
+		basic.fanotifyWatcher = true
+	}
+	return fs
+}
+
+func (*OptionFanotifyWatcher) String() string {
+	return "fanotifyWatcher"
+}
+
 // The BasicFilesystem implements all aspects by delegating to package os.
 // All paths are relative to the root and cannot (should not) escape the root directory.
 type BasicFilesystem struct {
 	root            string
 	junctionsAsDirs bool
+	fanotifyWatcher bool
 	options         []Option
 	userCache       *userCache
 	groupCache      *groupCache
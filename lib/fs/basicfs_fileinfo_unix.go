@@ -37,3 +37,12 @@ func (e basicFileInfo) Group() int {
 func (e *basicFileInfo) osFileInfo() os.FileInfo {
 	return e.FileInfo
 }
+
+// FileID returns the device and inode numbers identifying the file on disk,
+// if known.
+func FileID(info FileInfo) (dev, ino uint64, ok bool) {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return uint64(st.Dev), uint64(st.Ino), true
+	}
+	return 0, 0, false
+}
@@ -32,6 +32,12 @@ func (f *BasicFilesystem) Watch(name string, ignore Matcher, ctx context.Context
 		return nil, nil, err
 	}
 
+	if f.fanotifyWatcher {
+		if outChan, errChan, ok := f.watchFanotify(ctx, name, roots, ignore); ok {
+			return outChan, errChan, nil
+		}
+	}
+
 	outChan := make(chan Event)
 	backendChan := make(chan notify.EventInfo, backendBuffer)
 
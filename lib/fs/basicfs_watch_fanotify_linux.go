@@ -0,0 +1,244 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build linux
+// +build linux
+
+package fs
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	fanotifyEventMask = unix.FAN_CREATE | unix.FAN_DELETE | unix.FAN_MOVED_FROM | unix.FAN_MOVED_TO |
+		unix.FAN_MODIFY | unix.FAN_ATTRIB | unix.FAN_DELETE_SELF | unix.FAN_MOVE_SELF | unix.FAN_ONDIR
+	fanotifyRmEventMask = unix.FAN_DELETE | unix.FAN_MOVED_FROM | unix.FAN_DELETE_SELF | unix.FAN_MOVE_SELF
+
+	// Size of the fixed part of a file handle info record: the record
+	// header, the filesystem ID and the file handle header.
+	fanotifyFIDHeaderLen = 4 + 8 + 8
+)
+
+// watchFanotify watches the whole filesystem the folder is on with a single
+// fanotify mark, instead of one inotify watch per directory. That avoids
+// walking the entire folder to set up the watches on startup and the
+// inotify limits, but requires CAP_SYS_ADMIN and Linux 5.9 or later. Each
+// watched folder has its own fanotify group receiving, and discarding, the
+// events of the entire filesystem, which is why it's only used for folders
+// configured to. It returns false if fanotify isn't available, in which
+// case the caller falls back to the regular watcher.
+func (f *BasicFilesystem) watchFanotify(ctx context.Context, name string, roots []string, ignore Matcher) (<-chan Event, <-chan error, bool) {
+	absName, err := rooted(name, roots[0])
+	if err != nil {
+		return nil, nil, false
+	}
+
+	fd, err := unix.FanotifyInit(unix.FAN_CLASS_NOTIF|unix.FAN_REPORT_DFID_NAME|unix.FAN_CLOEXEC|unix.FAN_NONBLOCK, unix.O_RDONLY|unix.O_LARGEFILE)
+	if err != nil {
+		l.Debugln(f.Type(), f.URI(), "Watch: fanotify unavailable:", err)
+		return nil, nil, false
+	}
+	if err := unix.FanotifyMark(fd, unix.FAN_MARK_ADD|unix.FAN_MARK_FILESYSTEM, fanotifyEventMask, unix.AT_FDCWD, absName); err != nil {
+		l.Debugln(f.Type(), f.URI(), "Watch: fanotify mark failed:", err)
+		unix.Close(fd)
+		return nil, nil, false
+	}
+
+	// File handles are resolved relative to a file on the same filesystem.
+	mountFd, err := unix.Open(absName, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		l.Debugln(f.Type(), f.URI(), "Watch: fanotify:", err)
+		unix.Close(fd)
+		return nil, nil, false
+	}
+
+	// The descriptor is non-blocking, so the file is handled by the runtime
+	// poller and closing it interrupts a pending read.
+	file := os.NewFile(uintptr(fd), "fanotify")
+
+	outChan := make(chan Event)
+	errChan := make(chan error)
+	go func() {
+		<-ctx.Done()
+		file.Close()
+	}()
+	go func() {
+		defer unix.Close(mountFd)
+		f.fanotifyLoop(ctx, absName, roots, file, mountFd, outChan, errChan, ignore)
+	}()
+
+	l.Debugln(f.Type(), f.URI(), "Watch: Using fanotify")
+	return outChan, errChan, true
+}
+
+func (f *BasicFilesystem) fanotifyLoop(ctx context.Context, absName string, roots []string, file *os.File, mountFd int, outChan chan<- Event, errChan chan<- error, ignore Matcher) {
+	buf := make([]byte, 64<<10)
+	for {
+		n, err := file.Read(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				l.Debugln(f.Type(), f.URI(), "Watch: Stopped")
+				return
+			}
+			select {
+			case errChan <- err:
+				l.Debugln(f.Type(), f.URI(), "Watch: Sending error", err)
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		events, overflow, err := parseFanotifyEvents(buf[:n])
+		if err != nil {
+			l.Debugln(f.Type(), f.URI(), "Watch: fanotify:", err)
+		}
+		if overflow {
+			// When next scheduling a scan, do it on the entire folder as
+			// events have been lost.
+			events = append(events[:0], fanotifyEvent{path: absName})
+		}
+
+		for _, ev := range events {
+			evPath := ev.path
+			if evPath == "" {
+				if evPath, err = resolveFanotifyEvent(mountFd, ev); err != nil {
+					// The directory is already gone. Its removal is
+					// reported on its parent as well.
+					continue
+				}
+			}
+
+			if !utf8.ValidString(evPath) {
+				l.Debugln(f.Type(), f.URI(), "Watch: Ignoring invalid UTF-8")
+				continue
+			}
+			if evPath != absName && !strings.HasPrefix(evPath, absName+string(PathSeparator)) {
+				// Somewhere else on the same filesystem
+				continue
+			}
+
+			relPath, errOutside := f.unrootedChecked(evPath, roots)
+			if errOutside != nil {
+				continue
+			}
+			if ignore.Match(relPath).IsIgnored() {
+				l.Debugln(f.Type(), f.URI(), "Watch: Ignoring", relPath)
+				continue
+			}
+
+			evType := NonRemove
+			if ev.mask&fanotifyRmEventMask != 0 {
+				evType = Remove
+			}
+			select {
+			case outChan <- Event{Name: relPath, Type: evType}:
+				l.Debugln(f.Type(), f.URI(), "Watch: Sending", relPath, evType)
+			case <-ctx.Done():
+				l.Debugln(f.Type(), f.URI(), "Watch: Stopped")
+				return
+			}
+		}
+	}
+}
+
+type fanotifyEvent struct {
+	mask       uint64
+	handleType int32
+	handle     []byte
+	name       string
+	path       string // set instead of the handle, if already known
+}
+
+// parseFanotifyEvents parses the events read from a fanotify descriptor
+// initialised with FAN_REPORT_DFID_NAME. The second return value is true if
+// the event queue overflowed.
+func parseFanotifyEvents(buf []byte) ([]fanotifyEvent, bool, error) {
+	var events []fanotifyEvent
+	overflow := false
+	for len(buf) >= unix.FAN_EVENT_METADATA_LEN {
+		var meta unix.FanotifyEventMetadata
+		if err := binary.Read(bytes.NewReader(buf[:unix.FAN_EVENT_METADATA_LEN]), binary.NativeEndian, &meta); err != nil {
+			return events, overflow, err
+		}
+		if meta.Vers != unix.FANOTIFY_METADATA_VERSION {
+			return events, overflow, fmt.Errorf("unexpected metadata version %d", meta.Vers)
+		}
+		if int(meta.Event_len) > len(buf) || meta.Event_len < uint32(meta.Metadata_len) {
+			return events, overflow, errors.New("truncated event")
+		}
+		info := buf[meta.Metadata_len:meta.Event_len]
+		buf = buf[meta.Event_len:]
+
+		if meta.Mask&unix.FAN_Q_OVERFLOW != 0 {
+			overflow = true
+			continue
+		}
+		if meta.Fd != unix.FAN_NOFD {
+			// Not expected with FID reporting, but don't leak it.
+			unix.Close(int(meta.Fd))
+		}
+
+		for len(info) >= 4 {
+			infoType, infoLen := info[0], int(binary.NativeEndian.Uint16(info[2:4]))
+			if infoLen < 4 || infoLen > len(info) {
+				return events, overflow, errors.New("truncated event info")
+			}
+			record := info[:infoLen]
+			info = info[infoLen:]
+			if infoType != unix.FAN_EVENT_INFO_TYPE_DFID_NAME || len(record) < fanotifyFIDHeaderLen {
+				continue
+			}
+
+			handleLen := int(binary.NativeEndian.Uint32(record[12:16]))
+			handleType := int32(binary.NativeEndian.Uint32(record[16:20]))
+			rest := record[fanotifyFIDHeaderLen:]
+			if handleLen > len(rest) {
+				return events, overflow, errors.New("truncated file handle")
+			}
+			name := rest[handleLen:]
+			if i := bytes.IndexByte(name, 0); i >= 0 {
+				name = name[:i]
+			}
+			events = append(events, fanotifyEvent{
+				mask:       meta.Mask,
+				handleType: handleType,
+				handle:     append([]byte(nil), rest[:handleLen]...),
+				name:       string(name),
+			})
+		}
+	}
+	return events, overflow, nil
+}
+
+// resolveFanotifyEvent returns the absolute path of the event, from the
+// handle of its directory and the name within it.
+func resolveFanotifyEvent(mountFd int, ev fanotifyEvent) (string, error) {
+	fd, err := unix.OpenByHandleAt(mountFd, unix.NewFileHandle(ev.handleType, ev.handle), unix.O_PATH|unix.O_CLOEXEC)
+	if err != nil {
+		return "", err
+	}
+	defer unix.Close(fd)
+	dir, err := os.Readlink("/proc/self/fd/" + strconv.Itoa(fd))
+	if err != nil {
+		return "", err
+	}
+	if ev.name == "" || ev.name == "." {
+		return dir, nil
+	}
+	return filepath.Join(dir, ev.name), nil
+}
@@ -0,0 +1,142 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build linux
+// +build linux
+
+package fs
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func fanotifyTestEvent(mask uint64, handle []byte, name string) []byte {
+	var info bytes.Buffer
+	info.Write([]byte{unix.FAN_EVENT_INFO_TYPE_DFID_NAME, 0, 0, 0})
+	info.Write(make([]byte, 8)) // fsid
+	binary.Write(&info, binary.NativeEndian, uint32(len(handle)))
+	binary.Write(&info, binary.NativeEndian, int32(1))
+	info.Write(handle)
+	info.WriteString(name)
+	info.WriteByte(0)
+	for info.Len()%4 != 0 {
+		info.WriteByte(0)
+	}
+	bs := info.Bytes()
+	binary.NativeEndian.PutUint16(bs[2:4], uint16(len(bs)))
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.NativeEndian, unix.FanotifyEventMetadata{
+		Event_len:    uint32(unix.FAN_EVENT_METADATA_LEN + len(bs)),
+		Vers:         unix.FANOTIFY_METADATA_VERSION,
+		Metadata_len: unix.FAN_EVENT_METADATA_LEN,
+		Mask:         mask,
+		Fd:           unix.FAN_NOFD,
+	})
+	buf.Write(bs)
+	return buf.Bytes()
+}
+
+func TestParseFanotifyEvents(t *testing.T) {
+	var buf []byte
+	buf = append(buf, fanotifyTestEvent(unix.FAN_CREATE, []byte{1, 2, 3, 4, 5, 6, 7, 8}, "foo")...)
+	buf = append(buf, fanotifyTestEvent(unix.FAN_DELETE|unix.FAN_ONDIR, []byte{8, 7, 6, 5, 4, 3, 2, 1}, "bar")...)
+
+	events, overflow, err := parseFanotifyEvents(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if overflow {
+		t.Error("unexpected overflow")
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	if events[0].name != "foo" || events[0].mask != unix.FAN_CREATE || !bytes.Equal(events[0].handle, []byte{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Errorf("unexpected first event %+v", events[0])
+	}
+	if events[1].name != "bar" || events[1].mask&fanotifyRmEventMask == 0 {
+		t.Errorf("unexpected second event %+v", events[1])
+	}
+
+	var ov bytes.Buffer
+	binary.Write(&ov, binary.NativeEndian, unix.FanotifyEventMetadata{
+		Event_len:    unix.FAN_EVENT_METADATA_LEN,
+		Vers:         unix.FANOTIFY_METADATA_VERSION,
+		Metadata_len: unix.FAN_EVENT_METADATA_LEN,
+		Mask:         unix.FAN_Q_OVERFLOW,
+		Fd:           unix.FAN_NOFD,
+	})
+	if _, overflow, err := parseFanotifyEvents(ov.Bytes()); err != nil || !overflow {
+		t.Errorf("expected overflow, got %v, %v", overflow, err)
+	}
+}
+
+func TestWatchFanotify(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("fanotify requires CAP_SYS_ADMIN")
+	}
+
+	dir := t.TempDir()
+	fs := newBasicFilesystem(dir)
+	_, roots, err := fs.watchPaths(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventChan, errChan, ok := fs.watchFanotify(ctx, ".", roots, fakeMatcher{})
+	if !ok {
+		t.Skip("fanotify unavailable")
+	}
+
+	// Events for the same file are merged while queued, so wait for each.
+	waitFor := func(expected Event) {
+		t.Helper()
+		timeout := time.After(10 * time.Second)
+		for {
+			select {
+			case ev := <-eventChan:
+				if ev == expected {
+					return
+				}
+				if ev.Name != "sub" && ev.Name != expected.Name {
+					t.Errorf("Unexpected event %v", ev)
+				}
+			case err := <-errChan:
+				t.Fatal(err)
+			case <-timeout:
+				t.Fatalf("Timed out waiting for %v", expected)
+			}
+		}
+	}
+
+	// Changes elsewhere on the same filesystem aren't reported.
+	if err := os.WriteFile(filepath.Join(t.TempDir(), "elsewhere"), []byte("data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(Event{Name: "sub", Type: NonRemove})
+	file := filepath.Join(dir, "sub", "file")
+	if err := os.WriteFile(file, []byte("data"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(Event{Name: filepath.Join("sub", "file"), Type: NonRemove})
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(Event{Name: filepath.Join("sub", "file"), Type: Remove})
+}
@@ -64,6 +64,7 @@ type WalkFunc func(path string, info FileInfo, err error) error
 type walkFilesystem struct {
 	Filesystem
 	checkInfiniteRecursion bool
+	readers                chan struct{} // limits reading ahead, if walking in parallel
 }
 
 func NewWalkFilesystem(next Filesystem) Filesystem {
@@ -71,9 +72,13 @@ func NewWalkFilesystem(next Filesystem) Filesystem {
 		Filesystem: next,
 	}
 	for _, opt := range next.Options() {
-		if _, ok := opt.(*OptionJunctionsAsDirs); ok {
+		switch opt := opt.(type) {
+		case *OptionJunctionsAsDirs:
 			fs.checkInfiniteRecursion = true
-			break
+		case *OptionParallelWalk:
+			if *opt > 1 {
+				fs.readers = make(chan struct{}, *opt)
+			}
 		}
 	}
 	return fs
@@ -147,6 +152,9 @@ func (f *walkFilesystem) Walk(root string, walkFn WalkFunc) error {
 	if f.checkInfiniteRecursion {
 		ancestors = &ancestorDirList{fs: f.Filesystem}
 	}
+	if f.readers != nil {
+		return f.walkParallel(root, info, walkFn, ancestors)
+	}
 	return f.walk(root, info, walkFn, ancestors)
 }
 
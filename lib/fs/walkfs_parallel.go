@@ -0,0 +1,173 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package fs
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// OptionParallelWalk makes Walk list and stat up to the given number of
+// directories concurrently, ahead of where the walk currently is. The walk
+// function is still called from a single routine and in the same order as
+// when walking sequentially. This helps where directory traversal rather
+// than hashing dominates the scan time, e.g. on network filesystems.
+type OptionParallelWalk int
+
+func (*OptionParallelWalk) apply(fs Filesystem) Filesystem {
+	// Handled by the walkFilesystem
+	return fs
+}
+
+func (o *OptionParallelWalk) String() string {
+	return fmt.Sprintf("parallelWalk=%d", int(*o))
+}
+
+// A dirListing holds the contents of a directory, read by whoever claims it
+// first: one of the routines reading ahead, or the walk itself once it gets
+// there.
+type dirListing struct {
+	claimed atomic.Bool
+	done    chan struct{}
+	names   []string
+	infos   []FileInfo
+	errs    []error
+	err     error
+}
+
+type parallelWalker struct {
+	fs        *walkFilesystem
+	walkFn    WalkFunc
+	ancestors *ancestorDirList
+	stop      chan struct{}
+}
+
+func (f *walkFilesystem) walkParallel(root string, info FileInfo, walkFn WalkFunc, ancestors *ancestorDirList) error {
+	w := &parallelWalker{
+		fs:        f,
+		walkFn:    walkFn,
+		ancestors: ancestors,
+		stop:      make(chan struct{}),
+	}
+	// Reads ahead that haven't started yet are abandoned.
+	defer close(w.stop)
+	return w.walk(root, info, nil)
+}
+
+// walk is like walkFilesystem.walk, using the directory listing read ahead,
+// if not nil.
+func (w *parallelWalker) walk(path string, info FileInfo, listing *dirListing) error {
+	l.Debugf("walk: path=%s", path)
+	path, err := Canonicalize(path)
+	if err != nil {
+		return err
+	}
+
+	err = w.walkFn(path, info, nil)
+	if err != nil {
+		if info.IsDir() && err == SkipDir {
+			return nil
+		}
+		return err
+	}
+
+	if !info.IsDir() && path != "." {
+		return nil
+	}
+
+	if w.ancestors != nil {
+		if !w.ancestors.Contains(info) {
+			w.ancestors.Push(info)
+			defer w.ancestors.Pop()
+		} else {
+			return w.walkFn(path, info, ErrInfiniteRecursion)
+		}
+	}
+
+	if listing == nil {
+		listing = &dirListing{done: make(chan struct{})}
+	}
+	w.wait(path, listing)
+	if listing.err != nil {
+		return w.walkFn(path, info, listing.err)
+	}
+
+	// Read the subdirectories ahead of walking them, at most as many at a
+	// time as we have routines for, so that the listings waiting to be
+	// walked don't take up unbounded memory.
+	ahead := make([]*dirListing, len(listing.names))
+	pending, next := 0, 0
+	for i, name := range listing.names {
+		for ; next < len(listing.names) && pending < cap(w.fs.readers); next++ {
+			if listing.errs[next] == nil && listing.infos[next].IsDir() {
+				ahead[next] = w.readAhead(filepath.Join(path, listing.names[next]))
+				pending++
+			}
+		}
+		if ahead[i] != nil {
+			pending--
+		}
+
+		filename := filepath.Join(path, name)
+		fileInfo := listing.infos[i]
+		if err := listing.errs[i]; err != nil {
+			if err := w.walkFn(filename, fileInfo, err); err != nil && err != SkipDir {
+				return err
+			}
+		} else {
+			err = w.walk(filename, fileInfo, ahead[i])
+			ahead[i] = nil
+			if err != nil {
+				if !fileInfo.IsDir() || err != SkipDir {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// readAhead starts reading the directory once a routine is available.
+func (w *parallelWalker) readAhead(path string) *dirListing {
+	listing := &dirListing{done: make(chan struct{})}
+	go func() {
+		select {
+		case w.fs.readers <- struct{}{}:
+		case <-w.stop:
+			return
+		}
+		defer func() { <-w.fs.readers }()
+		if listing.claimed.CompareAndSwap(false, true) {
+			w.read(path, listing)
+		}
+	}()
+	return listing
+}
+
+// wait returns once the listing has been read, reading it right away unless
+// that has already started.
+func (w *parallelWalker) wait(path string, listing *dirListing) {
+	if listing.claimed.CompareAndSwap(false, true) {
+		w.read(path, listing)
+		return
+	}
+	<-listing.done
+}
+
+func (w *parallelWalker) read(path string, listing *dirListing) {
+	defer close(listing.done)
+	listing.names, listing.err = w.fs.DirNames(path)
+	if listing.err != nil {
+		return
+	}
+	listing.infos = make([]FileInfo, len(listing.names))
+	listing.errs = make([]error, len(listing.names))
+	for i, name := range listing.names {
+		listing.infos[i], listing.errs[i] = w.fs.Lstat(filepath.Join(path, name))
+	}
+}
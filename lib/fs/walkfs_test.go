@@ -132,3 +132,48 @@ func testWalkInfiniteRecursion(t *testing.T, fsType FilesystemType, uri string)
 		t.Fatal("Infinite recursion not detected correctly")
 	}
 }
+
+func TestWalkParallel(t *testing.T) {
+	dir := t.TempDir()
+	seq := NewFilesystem(FilesystemTypeBasic, dir)
+	for i := 0; i < 10; i++ {
+		for j := 0; j < 10; j++ {
+			sub := filepath.Join(fmt.Sprintf("dir%d", i), fmt.Sprintf("sub%d", j))
+			if err := seq.MkdirAll(sub, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			fd, err := seq.Create(filepath.Join(sub, "file"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			fd.Close()
+		}
+	}
+
+	walk := func(fs Filesystem) []string {
+		var paths []string
+		if err := fs.Walk(".", func(path string, _ FileInfo, err error) error {
+			if err != nil {
+				t.Fatal(err)
+			}
+			paths = append(paths, path)
+			if path == filepath.Join("dir3", "sub5") {
+				return SkipDir
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return paths
+	}
+
+	walkers := OptionParallelWalk(4)
+	expected := walk(seq)
+	actual := walk(NewFilesystem(FilesystemTypeBasic, dir, &walkers))
+	if len(expected) != 1+10+100+99 {
+		t.Fatalf("walked %d items", len(expected))
+	}
+	if fmt.Sprint(actual) != fmt.Sprint(expected) {
+		t.Errorf("parallel walk order differs:\n%v\n%v", actual, expected)
+	}
+}
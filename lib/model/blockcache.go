@@ -0,0 +1,232 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/osutil"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/scanner"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	// How long a block cache may fetch a block we missed there. The cache
+	// tries for a few minutes after the miss.
+	blockCacheMissLifetime = 10 * time.Minute
+	// The most missed blocks we remember per block cache.
+	maxBlockCacheMisses = 10000
+)
+
+// blockCacheRequest answers a request for a block in a folder we don't
+// have, acting as a block cache for devices on the LAN. The block is looked
+// up by its hash alone. On a miss, the block is fetched from the device
+// that asked once it has had time to pull it elsewhere, so that the next
+// device asking gets it from us.
+func (m *model) blockCacheRequest(conn protocol.Connection, req *protocol.Request) (protocol.RequestResponse, error) {
+	deviceID := conn.DeviceID()
+	if !conn.IsLocal() || len(req.Hash) == 0 {
+		l.Debugf("%v REQ(in) for block cache from non-local device or without hash: %s: %q / %q o=%d s=%d", m, deviceID.Short(), req.Folder, req.Name, req.Offset, req.Size)
+		return nil, protocol.ErrGeneric
+	}
+
+	l.Debugf("%v REQ(in) for block cache: %s: %q / %q o=%d s=%d h=%x", m, deviceID.Short(), req.Folder, req.Name, req.Offset, req.Size, req.Hash)
+
+	if data, ok := m.blockCache.Get(req.Hash); ok && len(data) == req.Size {
+		m.mut.RLock()
+		limiter := m.connRequestLimiters[deviceID]
+		m.mut.RUnlock()
+		res := newLimitedRequestResponse(req.Size, limiter, m.globalRequestLimiter)
+		copy(res.data, data)
+		return res, nil
+	}
+
+	// The device that asked only serves the block back to us by hash,
+	// whatever folder and name we ask for.
+	folder, name, blockNo, offset, size, hash, weakHash, hashAlgorithm := req.Folder, req.Name, req.BlockNo, req.Offset, req.Size, req.Hash, req.WeakHash, req.HashAlgorithm
+	m.blockCache.Fill(hash, size, hashAlgorithm, func(ctx context.Context) ([]byte, error) {
+		return m.RequestGlobal(ctx, deviceID, folder, name, blockNo, offset, size, hash, weakHash, hashAlgorithm, false)
+	})
+	return nil, protocol.ErrNoSuchFile
+}
+
+// blockCacheFillRequest answers a request from a block cache on the LAN
+// filling itself with a block we missed there. Only devices configured as
+// block caches, and not untrusted, get here. The block is looked up by
+// hash among those we missed, and read from the file we were pulling it
+// for; the folder and name in the request are not used, as the cache needn't
+// share the folder with us. Returns false if it's not such a block.
+func (m *model) blockCacheFillRequest(conn protocol.Connection, req *protocol.Request) (protocol.RequestResponse, bool) {
+	deviceID := conn.DeviceID()
+	miss, ok := m.blockCacheMisses.get(deviceID, req.Hash)
+	if !ok || miss.size != req.Size {
+		return nil, false
+	}
+
+	m.mut.RLock()
+	folderCfg, ok := m.folderCfgs[miss.folder]
+	limiter := m.connRequestLimiters[deviceID]
+	m.mut.RUnlock()
+	if !ok || folderCfg.Paused {
+		return nil, false
+	}
+
+	l.Debugf("%v REQ(in) from block cache: %s: %q / %q o=%d s=%d h=%x", m, deviceID.Short(), miss.folder, miss.name, miss.offset, miss.size, req.Hash)
+
+	folderFs := folderCfg.Filesystem(nil)
+	if err := osutil.TraversesSymlink(folderFs, filepath.Dir(miss.name)); err != nil {
+		return nil, false
+	}
+	res := newLimitedRequestResponse(miss.size, limiter, m.globalRequestLimiter)
+	// We're likely still pulling the file, but may be done by now.
+	for _, name := range []string{fs.TempName(miss.name), miss.name} {
+		if info, err := folderFs.Lstat(name); err != nil || !info.IsRegular() {
+			continue
+		}
+		if _, err := readOffsetIntoBuf(folderFs, name, miss.offset, res.data); err == nil && scanner.Validate(res.data, req.Hash, 0, miss.hashAlgorithm) {
+			return res, true
+		}
+	}
+	res.Close()
+	return nil, false
+}
+
+// requestFromBlockCaches asks the block caches we're connected to for the
+// block, all at once and giving up on them together after
+// blockCacheRequestTimeout. The requests carry the hash only, so the caches
+// learn nothing about the folder or file. Returns the first verified block;
+// the caches that didn't have it are passed to missed, if set.
+func (m *model) requestFromBlockCaches(ctx context.Context, block protocol.BlockInfo, hashAlgorithm protocol.HashAlgorithm, missed func(protocol.DeviceID)) ([]byte, bool) {
+	caches := m.blockCachesForRequests()
+	if len(caches) == 0 {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, blockCacheRequestTimeout)
+	defer cancel()
+
+	type result struct {
+		deviceID protocol.DeviceID
+		buf      []byte
+		err      error
+	}
+	// Buffered, so that those still outstanding when we return don't block.
+	results := make(chan result, len(caches))
+	for _, deviceID := range caches {
+		go func(deviceID protocol.DeviceID) {
+			buf, err := m.RequestGlobal(ctx, deviceID, "", "", 0, 0, int(block.Size), block.Hash, 0, hashAlgorithm, false)
+			if err == nil {
+				err = verifyBuffer(buf, block, hashAlgorithm)
+			}
+			results <- result{deviceID, buf, err}
+		}(deviceID)
+	}
+	for range caches {
+		res := <-results
+		if res.err == nil {
+			return res.buf, true
+		}
+		l.Debugf("%v request for %x from block cache %s: %v", m, block.Hash, res.deviceID.Short(), res.err)
+		if missed != nil {
+			missed(res.deviceID)
+		}
+	}
+	return nil, false
+}
+
+// blockCachesForRequests returns the devices configured as block caches for
+// us that we're connected to over the LAN. Devices merely announcing to be
+// block caches aren't asked, nor are untrusted ones, as both would learn
+// which blocks we want and could fetch those they missed from us.
+func (m *model) blockCachesForRequests() []protocol.DeviceID {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	var caches []protocol.DeviceID
+	for deviceID := range m.deviceConnIDs {
+		if !m.isBlockCacheRLocked(deviceID) {
+			continue
+		}
+		for _, connID := range m.deviceConnIDs[deviceID] {
+			if m.connections[connID].IsLocal() {
+				caches = append(caches, deviceID)
+				break
+			}
+		}
+	}
+	return caches
+}
+
+func (m *model) isBlockCacheRLocked(deviceID protocol.DeviceID) bool {
+	_, ok := m.blockCacheDevices[deviceID]
+	return ok
+}
+
+// blockCacheMisses remembers the blocks we missed at the block caches,
+// which are the only blocks they may fetch from us to fill themselves.
+type blockCacheMisses struct {
+	misses map[protocol.DeviceID]map[string]blockCacheMiss // device -> hash -> miss
+	mut    sync.Mutex
+}
+
+type blockCacheMiss struct {
+	folder        string
+	name          string
+	offset        int64
+	size          int
+	hashAlgorithm protocol.HashAlgorithm
+	at            time.Time
+}
+
+func newBlockCacheMisses() *blockCacheMisses {
+	return &blockCacheMisses{
+		misses: make(map[protocol.DeviceID]map[string]blockCacheMiss),
+		mut:    sync.NewMutex(),
+	}
+}
+
+// add records that the block cache didn't have the block, or as many
+// blocks as we remember.
+func (b *blockCacheMisses) add(deviceID protocol.DeviceID, hash []byte, miss blockCacheMiss) {
+	if len(hash) == 0 {
+		return
+	}
+	miss.at = time.Now()
+
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	misses, ok := b.misses[deviceID]
+	if !ok {
+		misses = make(map[string]blockCacheMiss)
+		b.misses[deviceID] = misses
+	}
+	if len(misses) >= maxBlockCacheMisses {
+		for key, old := range misses {
+			if time.Since(old.at) > blockCacheMissLifetime {
+				delete(misses, key)
+			}
+		}
+		if len(misses) >= maxBlockCacheMisses {
+			return
+		}
+	}
+	misses[string(hash)] = miss
+}
+
+// get returns the block we missed at the block cache, if it's recent.
+func (b *blockCacheMisses) get(deviceID protocol.DeviceID, hash []byte) (blockCacheMiss, bool) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	miss, ok := b.misses[deviceID][string(hash)]
+	if !ok || time.Since(miss.at) > blockCacheMissLifetime {
+		return blockCacheMiss{}, false
+	}
+	return miss, true
+}
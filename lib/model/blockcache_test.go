@@ -0,0 +1,262 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/blockcache"
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestBlockCacheServesByHash(t *testing.T) {
+	w, wCancel := newConfigWrapper(defaultCfg)
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+	m.blockCache = blockcache.New(t.TempDir())
+	m.blockCache.SetMaxSize(1 << 20)
+
+	data := []byte("some cached data")
+	hash := sha256.Sum256(data)
+	must(t, m.blockCache.Put(hash[:], data, protocol.HashAlgorithmSHA256))
+
+	local := newFakeConnection(device1, m)
+	local.IsLocalReturns(true)
+	m.AddConnection(local, protocol.Hello{})
+	remote := newFakeConnection(device2, m)
+	m.AddConnection(remote, protocol.Hello{})
+
+	req := &protocol.Request{Folder: "unknown", Name: "foo", Size: len(data), Hash: hash[:]}
+	res, err := m.Request(local, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Data(), data) {
+		t.Errorf("Got %q, expected %q", res.Data(), data)
+	}
+	res.Close()
+
+	// Misses and devices that aren't on the LAN get nothing.
+	other := sha256.Sum256([]byte("other data"))
+	if _, err := m.Request(local, &protocol.Request{Folder: "unknown", Name: "bar", Size: 10, Hash: other[:]}); !errors.Is(err, protocol.ErrNoSuchFile) {
+		t.Errorf("Expected ErrNoSuchFile on miss, got %v", err)
+	}
+	if _, err := m.Request(remote, req); !errors.Is(err, protocol.ErrGeneric) {
+		t.Errorf("Expected ErrGeneric for non-local device, got %v", err)
+	}
+}
+
+func TestBlockCacheClient(t *testing.T) {
+	device3 := protocol.DeviceID{3}
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		for _, id := range []protocol.DeviceID{device2, device3} {
+			dev := newDeviceConfiguration(cfg.Defaults.Device, id, "cache")
+			dev.BlockCache = true
+			cfg.SetDevice(dev)
+		}
+	})
+	must(t, err)
+	waiter.Wait()
+	m := setupModel(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	// device1 has the file, but doesn't answer requests.
+	data := []byte("some data to pull")
+	fc := addFakeConn(m, device1, fcfg.ID)
+	fc.RequestCalls(func(context.Context, *protocol.Request) ([]byte, error) {
+		return nil, protocol.ErrGeneric
+	})
+	fc.addFile("foo", 0o644, protocol.FileInfoTypeFile, data)
+
+	// device2, a block cache on the LAN, has the block. device3, another
+	// one, doesn't answer, which mustn't hold up getting it from device2.
+	reqs := make(chan *protocol.Request, 10)
+	cache := newFakeConnection(device2, m)
+	cache.IsLocalReturns(true)
+	cache.RequestCalls(func(_ context.Context, req *protocol.Request) ([]byte, error) {
+		reqs <- req
+		return data, nil
+	})
+	m.AddConnection(cache, protocol.Hello{})
+	stalled := newFakeConnection(device3, m)
+	stalled.IsLocalReturns(true)
+	stalled.RequestCalls(func(ctx context.Context, _ *protocol.Request) ([]byte, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	m.AddConnection(stalled, protocol.Hello{})
+
+	t0 := time.Now()
+	fc.sendIndexUpdate()
+
+	tfs := fcfg.Filesystem(nil)
+	deadline := time.Now().Add(10 * time.Second)
+	for equalContents(tfs, "foo", data) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the file to be pulled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if d := time.Since(t0); d >= blockCacheRequestTimeout {
+		t.Errorf("Pulling took %v, waiting for the stalled cache", d)
+	}
+	if stalled.RequestCallCount() == 0 {
+		t.Error("Expected the block to be requested from both caches")
+	}
+
+	// The cache learns nothing but the hash.
+	var req *protocol.Request
+	select {
+	case req = <-reqs:
+	default:
+		t.Fatal("Expected the block to be requested from the cache")
+	}
+	if req.Folder != "" || req.Name != "" || len(req.Hash) == 0 {
+		t.Errorf("Expected a request by hash only, got %q / %q %x", req.Folder, req.Name, req.Hash)
+	}
+
+	// The block was there, so the cache may not fetch it from us, and
+	// never by name.
+	byName := &protocol.Request{Folder: fcfg.ID, Name: "foo", Size: req.Size, Hash: req.Hash, HashAlgorithm: req.HashAlgorithm}
+	for _, req := range []*protocol.Request{req, byName} {
+		if _, err := m.Request(cache, req); err == nil {
+			t.Error("Expected the cache not to get a block it didn't miss")
+		}
+	}
+}
+
+func TestBlockCacheFill(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		dev := newDeviceConfiguration(cfg.Defaults.Device, device2, "cache")
+		dev.BlockCache = true
+		cfg.SetDevice(dev)
+	})
+	must(t, err)
+	waiter.Wait()
+	m := setupModel(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	data := []byte("some data to pull")
+	fc := addFakeConn(m, device1, fcfg.ID)
+	fc.addFile("foo", 0o644, protocol.FileInfoTypeFile, data)
+
+	// device2, a block cache on the LAN, has nothing.
+	reqs := make(chan *protocol.Request, 10)
+	cache := newFakeConnection(device2, m)
+	cache.IsLocalReturns(true)
+	cache.RequestCalls(func(_ context.Context, req *protocol.Request) ([]byte, error) {
+		reqs <- req
+		return nil, protocol.ErrNoSuchFile
+	})
+	m.AddConnection(cache, protocol.Hello{})
+
+	fc.sendIndexUpdate()
+
+	tfs := fcfg.Filesystem(nil)
+	deadline := time.Now().Add(10 * time.Second)
+	for equalContents(tfs, "foo", data) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the file to be pulled")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	var missed *protocol.Request
+	select {
+	case missed = <-reqs:
+	default:
+		t.Fatal("Expected the block to be requested from the cache")
+	}
+
+	// The cache may fetch the block it missed from us by hash, although
+	// the folder isn't shared with it, whatever name it asks for.
+	req := &protocol.Request{Folder: "other", Name: "bar", Size: missed.Size, Hash: missed.Hash, HashAlgorithm: missed.HashAlgorithm}
+	res, err := m.Request(cache, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Data(), data) {
+		t.Errorf("Got %q, expected %q", res.Data(), data)
+	}
+	res.Close()
+
+	// Nothing else, by name or by hash.
+	secret := []byte("not for the cache")
+	writeFile(t, tfs, "secret", secret)
+	secretHash := sha256.Sum256(secret)
+	for _, req := range []*protocol.Request{
+		{Folder: fcfg.ID, Name: "secret", Size: len(secret)},
+		{Folder: fcfg.ID, Name: "secret", Size: len(secret), Hash: secretHash[:]},
+		{Folder: fcfg.ID, Name: "foo", Size: len(secret), Hash: secretHash[:]},
+	} {
+		if res, err := m.Request(cache, req); err == nil {
+			res.Close()
+			t.Errorf("Expected request for %q with hash %x to fail", req.Name, req.Hash)
+		}
+	}
+
+	// And only over the LAN.
+	cache.IsLocalReturns(false)
+	if _, err := m.Request(cache, req); !errors.Is(err, protocol.ErrGeneric) {
+		t.Errorf("Expected ErrGeneric over the WAN, got %v", err)
+	}
+}
+
+func TestBlockCacheOnlyConfigured(t *testing.T) {
+	w, wCancel := newConfigWrapper(defaultCfg)
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	cache := newFakeConnection(device1, m)
+	cache.IsLocalReturns(true)
+	m.AddConnection(cache, protocol.Hello{})
+
+	isCache := func() bool {
+		caches := m.blockCachesForRequests()
+		return len(caches) == 1 && caches[0] == device1
+	}
+
+	// Announcing to be a block cache doesn't make it one for us.
+	m.evLogger.Log(events.DeviceDiscovered, map[string]interface{}{"device": device1.String(), "blockCache": true})
+	time.Sleep(100 * time.Millisecond)
+	if isCache() {
+		t.Error("Expected an announced but unconfigured device not to be a block cache")
+	}
+
+	// Neither does configuring an untrusted device as one.
+	setBlockCache := func(untrusted bool) {
+		t.Helper()
+		waiter, err := w.Modify(func(cfg *config.Configuration) {
+			dev, _, _ := cfg.Device(device1)
+			dev.BlockCache = true
+			dev.Untrusted = untrusted
+			cfg.SetDevice(dev)
+		})
+		must(t, err)
+		waiter.Wait()
+	}
+	setBlockCache(true)
+	if isCache() {
+		t.Error("Expected an untrusted device not to be a block cache")
+	}
+	setBlockCache(false)
+	if !isCache() {
+		t.Error("Expected the configured device to be a block cache")
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/rand"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	// Weight of a new sample in the moving averages of throughput and
+	// latency.
+	requestLoadAlpha = 0.25
+	// Assumed throughput when nothing has been measured yet. Only relative
+	// values matter, so this just has to be something.
+	requestLoadDefaultRate = 1 << 20
+)
+
+// connectionActivity tracks the outstanding requests and the measured
+// throughput and latency of each connection, and can answer which of a
+// device's connections is expected to complete a request soonest. Picking
+// that one for every request stripes requests over the connections,
+// weighted by how fast they are. It is safe for use from multiple
+// goroutines.
+type connectionActivity struct {
+	conns map[string]*requestLoad
+	mut   sync.Mutex
+}
+
+// requestLoad is what's in flight over a connection, or to a device, and
+// how fast it has been so far.
+type requestLoad struct {
+	inFlight      int
+	inFlightBytes int64
+	rate          float64 // bytes per second, zero until measured
+	latency       float64 // seconds
+}
+
+// requestStart is what we need to remember about a request in flight to
+// measure the connection once it completes.
+type requestStart struct {
+	at     time.Time
+	queued int64 // bytes requested ahead of this request
+}
+
+func newConnectionActivity() *connectionActivity {
+	return &connectionActivity{
+		conns: make(map[string]*requestLoad),
+		mut:   sync.NewMutex(),
+	}
+}
+
+// pick returns the index of the connection expected to complete a request
+// of the given size first, or -1 if there are no connections. Connections
+// not yet measured are tried as soon as they are idle.
+func (a *connectionActivity) pick(connIDs []string, size int) int {
+	if len(connIDs) == 0 {
+		return -1
+	}
+
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	loads := make([]*requestLoad, len(connIDs))
+	for i, id := range connIDs {
+		loads[i] = a.conns[id]
+	}
+	best, _ := pickLoad(loads, size)
+	return best
+}
+
+// pickLoad returns the index of the load expected to complete a request of
+// the given size first, and the time that is expected to take, or zero if
+// there are no measurements to tell. Nil loads are idle and unmeasured.
+func pickLoad(loads []*requestLoad, size int) (int, time.Duration) {
+	if len(loads) == 0 {
+		return -1, 0
+	}
+
+	// Those without measurements are assumed to be as good as the average
+	// of those with.
+	var rateSum, latencySum float64
+	measured := 0
+	for _, c := range loads {
+		if c != nil && c.rate > 0 {
+			rateSum += c.rate
+			latencySum += c.latency
+			measured++
+		}
+	}
+	defRate, defLatency := float64(requestLoadDefaultRate), 0.0
+	if measured > 0 {
+		defRate = rateSum / float64(measured)
+		defLatency = latencySum / float64(measured)
+	}
+
+	// Start at a random one so that ties are broken randomly.
+	offset := rand.Intn(len(loads))
+	best := -1
+	var bestEstimate float64
+	for i := range loads {
+		idx := (i + offset) % len(loads)
+		c := loads[idx]
+		if c == nil {
+			c = &requestLoad{}
+		}
+		var estimate float64
+		switch {
+		case c.rate > 0:
+			estimate = c.latency + float64(c.inFlightBytes+int64(size))/c.rate
+		case c.inFlight == 0:
+			// Not measured and idle; try it.
+			estimate = 0
+		default:
+			estimate = defLatency + float64(c.inFlightBytes+int64(size))/defRate
+		}
+		if best < 0 || estimate < bestEstimate {
+			best = idx
+			bestEstimate = estimate
+		}
+	}
+	if measured == 0 {
+		return best, 0
+	}
+	return best, time.Duration(bestEstimate * float64(time.Second))
+}
+
+// add starts tracking a new connection.
+func (a *connectionActivity) add(connID string) {
+	a.mut.Lock()
+	if _, ok := a.conns[connID]; !ok {
+		a.conns[connID] = &requestLoad{}
+	}
+	a.mut.Unlock()
+}
+
+// using records a request of the given size being sent on the connection.
+// The returned value is to be passed to done when it completes. Requests on
+// connections that have been removed meanwhile aren't tracked.
+func (a *connectionActivity) using(connID string, size int) requestStart {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	c := a.conns[connID]
+	if c == nil {
+		return requestStart{at: time.Now()}
+	}
+	return c.start(size)
+}
+
+// done records the completion of a request. Successful requests update the
+// throughput and latency of the connection, cancelled ones cap the
+// throughput at what the time spent implies.
+func (a *connectionActivity) done(connID string, start requestStart, size int, err error) {
+	a.doneAfter(connID, start, size, time.Since(start.at), err)
+}
+
+func (a *connectionActivity) doneAfter(connID string, start requestStart, size int, d time.Duration, err error) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	c := a.conns[connID]
+	if c == nil {
+		// Connection closed and removed meanwhile.
+		return
+	}
+	c.finish(start, size, d, err)
+}
+
+// remove forgets about a closed connection.
+func (a *connectionActivity) remove(connID string) {
+	a.mut.Lock()
+	delete(a.conns, connID)
+	a.mut.Unlock()
+}
+
+func (c *requestLoad) start(size int) requestStart {
+	start := requestStart{at: time.Now(), queued: c.inFlightBytes}
+	c.inFlight++
+	c.inFlightBytes += int64(size)
+	return start
+}
+
+func (c *requestLoad) finish(start requestStart, size int, d time.Duration, err error) {
+	c.inFlight--
+	c.inFlightBytes -= int64(size)
+	if d <= 0 {
+		return
+	}
+
+	// The request waited for those ahead of it, so what we got through in
+	// this time is all of them.
+	secs := d.Seconds()
+	rate := float64(start.queued+int64(size)) / secs
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// Given up on, typically because it was also requested elsewhere
+		// and that was faster. It took at least this long, so the rate is
+		// no better than this; otherwise a stalled peer would keep looking
+		// fast and be picked first forever.
+		if c.rate == 0 || rate < c.rate {
+			c.rate = rate
+		}
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if c.rate == 0 {
+		c.rate = rate
+	} else {
+		c.rate += requestLoadAlpha * (rate - c.rate)
+	}
+
+	// The latency is what remains of the time once the transfer is
+	// accounted for, which we can only tell when nothing was ahead.
+	if start.queued == 0 {
+		latency := secs - float64(size)/c.rate
+		if latency < 0 {
+			latency = 0
+		}
+		c.latency += requestLoadAlpha * (latency - c.latency)
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConnectionActivityUnmeasured(t *testing.T) {
+	conns := []string{"a", "b"}
+	ca := newConnectionActivity()
+	ca.add("a")
+	ca.add("b")
+
+	// Idle connections without measurements are tried first.
+	first := ca.pick(conns, 128<<10)
+	ca.using(conns[first], 128<<10)
+	second := ca.pick(conns, 128<<10)
+	if second == first {
+		t.Fatalf("second request should go to the other connection, got %d twice", first)
+	}
+	ca.using(conns[second], 128<<10)
+
+	if idx := ca.pick(nil, 128<<10); idx != -1 {
+		t.Errorf("expected -1 without connections, got %d", idx)
+	}
+}
+
+func TestConnectionActivityWeighted(t *testing.T) {
+	const size = 128 << 10
+	conns := []string{"fast", "slow"}
+	ca := newConnectionActivity()
+	ca.add("fast")
+	ca.add("slow")
+
+	// The fast connection does a block in 10 ms, the slow one in 40 ms.
+	start := ca.using("fast", size)
+	ca.doneAfter("fast", start, size, 10*time.Millisecond, nil)
+	start = ca.using("slow", size)
+	ca.doneAfter("slow", start, size, 40*time.Millisecond, nil)
+
+	// With requests going to whichever is expected to complete first, the
+	// fast connection should get about four times as many.
+	counts := make(map[string]int)
+	for i := 0; i < 50; i++ {
+		idx := ca.pick(conns, size)
+		ca.using(conns[idx], size)
+		counts[conns[idx]]++
+	}
+	if counts["fast"] < 35 || counts["fast"] > 45 {
+		t.Errorf("expected about 40 requests on the fast connection, got %v", counts)
+	}
+}
+
+func TestConnectionActivityFailures(t *testing.T) {
+	const size = 128 << 10
+	ca := newConnectionActivity()
+	ca.add("a")
+
+	start := ca.using("a", size)
+	ca.doneAfter("a", start, size, 10*time.Millisecond, nil)
+	rate := ca.conns["a"].rate
+
+	// Failed requests don't count towards the measurements.
+	start = ca.using("a", size)
+	ca.doneAfter("a", start, size, time.Second, errors.New("failed"))
+	if c := ca.conns["a"]; c.rate != rate || c.inFlight != 0 || c.inFlightBytes != 0 {
+		t.Errorf("unexpected state after failure: %+v", c)
+	}
+
+	// Completing requests on removed connections is harmless.
+	start = ca.using("a", size)
+	ca.remove("a")
+	ca.done("a", start, size, nil)
+	if _, ok := ca.conns["a"]; ok {
+		t.Error("removed connection should stay removed")
+	}
+
+	// As is starting them.
+	start = ca.using("a", size)
+	ca.done("a", start, size, nil)
+	if _, ok := ca.conns["a"]; ok {
+		t.Error("removed connection should not be tracked again")
+	}
+}
+
+func TestConnectionActivityCancelled(t *testing.T) {
+	const size = 128 << 10
+	conns := []string{"stalled", "other"}
+	ca := newConnectionActivity()
+	ca.add("stalled")
+	ca.add("other")
+
+	start := ca.using("stalled", size)
+	ca.doneAfter("stalled", start, size, time.Millisecond, nil)
+	start = ca.using("other", size)
+	ca.doneAfter("other", start, size, 50*time.Millisecond, nil)
+	rate := ca.conns["stalled"].rate
+
+	// Cancelled quickly, for example as it was faster than another
+	// request for the same block, it says nothing new.
+	start = ca.using("stalled", size)
+	ca.doneAfter("stalled", start, size, 10*time.Microsecond, context.Canceled)
+	if c := ca.conns["stalled"]; c.rate != rate || c.inFlight != 0 {
+		t.Errorf("unexpected state after quick cancellation: %+v", c)
+	}
+
+	// Having stalled until given up on, it's no longer the first choice.
+	start = ca.using("stalled", size)
+	ca.doneAfter("stalled", start, size, 2*time.Second, context.Canceled)
+	for i := 0; i < 10; i++ {
+		if idx := ca.pick(conns, size); conns[idx] != "other" {
+			t.Fatal("expected the stalled connection to be avoided")
+		}
+	}
+}
@@ -7,48 +7,67 @@
 package model
 
 import (
+	"time"
+
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/sync"
 )
 
-// deviceActivity tracks the number of outstanding requests per device and can
-// answer which device is least busy. It is safe for use from multiple
+// deviceActivity tracks the outstanding block requests and the measured
+// throughput and latency per device, and can answer which device is
+// expected to deliver a block soonest. It is safe for use from multiple
 // goroutines.
 type deviceActivity struct {
-	act map[protocol.DeviceID]int
+	act map[protocol.DeviceID]*requestLoad
 	mut sync.Mutex
 }
 
 func newDeviceActivity() *deviceActivity {
 	return &deviceActivity{
-		act: make(map[protocol.DeviceID]int),
+		act: make(map[protocol.DeviceID]*requestLoad),
 		mut: sync.NewMutex(),
 	}
 }
 
-// Returns the index of the least busy device, or -1 if all are too busy.
-func (m *deviceActivity) leastBusy(availability []Availability) int {
+// fastest returns the index of the device expected to complete a request
+// of the given size first, or -1 if there are none, along with how long
+// that is expected to take. The expected time is zero when there's nothing
+// measured to base it on. Devices not yet measured are tried as soon as
+// they are idle.
+func (m *deviceActivity) fastest(availability []Availability, size int) (int, time.Duration) {
 	m.mut.Lock()
-	low := 2<<30 - 1
-	best := -1
+	defer m.mut.Unlock()
+	loads := make([]*requestLoad, len(availability))
 	for i := range availability {
-		if usage := m.act[availability[i].ID]; usage < low {
-			low = usage
-			best = i
-		}
+		loads[i] = m.act[availability[i].ID]
 	}
-	m.mut.Unlock()
-	return best
+	return pickLoad(loads, size)
 }
 
-func (m *deviceActivity) using(availability Availability) {
+// using records a request of the given size being sent to the device. The
+// returned value is to be passed to done when it completes.
+func (m *deviceActivity) using(availability Availability, size int) requestStart {
 	m.mut.Lock()
-	m.act[availability.ID]++
-	m.mut.Unlock()
+	defer m.mut.Unlock()
+	c := m.act[availability.ID]
+	if c == nil {
+		c = &requestLoad{}
+		m.act[availability.ID] = c
+	}
+	return c.start(size)
 }
 
-func (m *deviceActivity) done(availability Availability) {
+// done records the completion of a request. Successful requests update the
+// throughput and latency of the device, cancelled ones cap the throughput
+// at what the time spent implies.
+func (m *deviceActivity) done(availability Availability, start requestStart, size int, err error) {
+	m.doneAfter(availability, start, size, time.Since(start.at), err)
+}
+
+func (m *deviceActivity) doneAfter(availability Availability, start requestStart, size int, d time.Duration, err error) {
 	m.mut.Lock()
-	m.act[availability.ID]--
-	m.mut.Unlock()
+	defer m.mut.Unlock()
+	if c := m.act[availability.ID]; c != nil {
+		c.finish(start, size, d, err)
+	}
 }
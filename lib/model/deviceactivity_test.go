@@ -8,53 +8,64 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/syncthing/syncthing/lib/protocol"
 )
 
 func TestDeviceActivity(t *testing.T) {
+	const size = 128 << 10
 	n0 := Availability{protocol.DeviceID([32]byte{1, 2, 3, 4}), false}
 	n1 := Availability{protocol.DeviceID([32]byte{5, 6, 7, 8}), true}
 	n2 := Availability{protocol.DeviceID([32]byte{9, 10, 11, 12}), false}
 	devices := []Availability{n0, n1, n2}
 	na := newDeviceActivity()
 
-	if lb := na.leastBusy(devices); lb != 0 {
-		t.Errorf("Least busy device should be n0 (%v) not %v", n0, lb)
-	}
-	if lb := na.leastBusy(devices); lb != 0 {
-		t.Errorf("Least busy device should still be n0 (%v) not %v", n0, lb)
+	// Without measurements, requests are spread over the idle devices.
+	starts := make(map[protocol.DeviceID]requestStart)
+	for range devices {
+		idx, _ := na.fastest(devices, size)
+		if _, ok := starts[devices[idx].ID]; ok {
+			t.Fatalf("Device %v selected twice while others are idle", devices[idx].ID)
+		}
+		starts[devices[idx].ID] = na.using(devices[idx], size)
 	}
 
-	lb := na.leastBusy(devices)
-	na.using(devices[lb])
-	if lb := na.leastBusy(devices); lb != 1 {
-		t.Errorf("Least busy device should be n1 (%v) not %v", n1, lb)
-	}
-	lb = na.leastBusy(devices)
-	na.using(devices[lb])
-	if lb := na.leastBusy(devices); lb != 2 {
-		t.Errorf("Least busy device should be n2 (%v) not %v", n2, lb)
+	// Once one is done it's the least busy.
+	na.done(n1, starts[n1.ID], size, nil)
+	if idx, _ := na.fastest(devices, size); idx != 1 {
+		t.Errorf("Least busy device should be n1 (%v) not %v", n1, idx)
 	}
 
-	lb = na.leastBusy(devices)
-	na.using(devices[lb])
-	if lb := na.leastBusy(devices); lb != 0 {
-		t.Errorf("Least busy device should be n0 (%v) not %v", n0, lb)
+	if idx, _ := na.fastest(nil, size); idx != -1 {
+		t.Errorf("Expected -1 without devices, got %d", idx)
 	}
+}
 
-	na.done(n1)
-	if lb := na.leastBusy(devices); lb != 1 {
-		t.Errorf("Least busy device should be n1 (%v) not %v", n1, lb)
-	}
+func TestDeviceActivityThroughput(t *testing.T) {
+	const size = 128 << 10
+	fast := Availability{protocol.DeviceID([32]byte{1, 2, 3, 4}), false}
+	slow := Availability{protocol.DeviceID([32]byte{5, 6, 7, 8}), false}
+	devices := []Availability{fast, slow}
+	na := newDeviceActivity()
 
-	na.done(n2)
-	if lb := na.leastBusy(devices); lb != 1 {
-		t.Errorf("Least busy device should still be n1 (%v) not %v", n1, lb)
-	}
+	start := na.using(fast, size)
+	na.doneAfter(fast, start, size, 10*time.Millisecond, nil)
+	start = na.using(slow, size)
+	na.doneAfter(slow, start, size, 100*time.Millisecond, nil)
 
-	na.done(n0)
-	if lb := na.leastBusy(devices); lb != 0 {
-		t.Errorf("Least busy device should be n0 (%v) not %v", n0, lb)
+	// The fast device gets requests until it's expected to be slower than
+	// the slow one.
+	counts := make(map[protocol.DeviceID]int)
+	for i := 0; i < 22; i++ {
+		idx, expected := na.fastest(devices, size)
+		if expected <= 0 {
+			t.Fatal("Expected a measured request time")
+		}
+		na.using(devices[idx], size)
+		counts[devices[idx].ID]++
+	}
+	if counts[fast.ID] < 18 || counts[slow.ID] < 1 {
+		t.Errorf("Expected about ten times as many requests to the fast device, got %d and %d", counts[fast.ID], counts[slow.ID])
 	}
 }
@@ -81,8 +81,11 @@ type folder struct {
 	watchErr         error
 	watchMut         sync.Mutex
 
-	puller    puller
-	versioner versioner.Versioner
+	puller         puller
+	versioner      versioner.Versioner
+	localVersions  chan protocol.FileInfo       // previous versions of locally changed files, nil unless versioning them
+	heldUpdates    map[string]protocol.FileInfo // scanned files not updated until their previous version is archived
+	heldUpdatesMut sync.Mutex
 
 	warnedKqueue bool
 }
@@ -96,6 +99,13 @@ type puller interface {
 	pull() (bool, error) // true when successful and should not be retried
 }
 
+// A syncedPuller is notified when the folder is in sync with the global
+// state, which is also the case when there was nothing to pull.
+type syncedPuller interface {
+	puller
+	inSync()
+}
+
 func newFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg config.FolderConfiguration, evLogger events.Logger, ioLimiter *semaphore.Semaphore, ver versioner.Versioner) folder {
 	f := folder{
 		stateTracker:              newStateTracker(cfg.ID, evLogger),
@@ -139,6 +149,14 @@ func newFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg conf
 	f.pullFailTimer = time.NewTimer(0)
 	<-f.pullFailTimer.C
 
+	// The blocks of encrypted files can't be verified, nor used to
+	// reconstruct the plaintext.
+	if cfg.VersionLocalChanges && ver != nil && cfg.Type != config.FolderTypeReceiveEncrypted {
+		f.localVersions = make(chan protocol.FileInfo, localVersionsQueueSize)
+		f.heldUpdates = make(map[string]protocol.FileInfo)
+		f.heldUpdatesMut = sync.NewMutex()
+	}
+
 	registerFolderMetrics(f.ID)
 
 	return f
@@ -156,6 +174,9 @@ func (f *folder) Serve(ctx context.Context) error {
 	defer func() {
 		f.scanTimer.Stop()
 		f.versionCleanupTimer.Stop()
+		if f.versioner != nil {
+			versioner.Stop(f.versioner)
+		}
 		f.setState(FolderIdle)
 	}()
 
@@ -171,6 +192,10 @@ func (f *folder) Serve(ctx context.Context) error {
 		}
 	}
 
+	if f.localVersions != nil {
+		go f.archiveLocalVersions(ctx)
+	}
+
 	initialCompleted := f.initialScanFinished
 
 	for {
@@ -382,6 +407,9 @@ func (f *folder) pull() (success bool, err error) {
 		f.errorsMut.Lock()
 		f.pullErrors = nil
 		f.errorsMut.Unlock()
+		if sp, ok := f.puller.(syncedPuller); ok {
+			sp.inSync()
+		}
 		return true, nil
 	}
 
@@ -422,6 +450,9 @@ func (f *folder) pull() (success bool, err error) {
 	success, err = f.puller.pull()
 
 	if success && err == nil {
+		if sp, ok := f.puller.(syncedPuller); ok {
+			sp.inSync()
+		}
 		return true, nil
 	}
 
@@ -509,7 +540,14 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 		}
 	}()
 
-	changesHere, err := f.scanSubdirsChangedAndNew(subDirs, batch)
+	// Record the directories seen by full scans, to skip those unchanged
+	// on the initial scan after the next startup.
+	var checkpoint *scanner.Checkpoint
+	if len(subDirs) == 0 && f.ScanCheckpoint {
+		checkpoint = f.newScanCheckpoint()
+	}
+
+	changesHere, err := f.scanSubdirsChangedAndNew(subDirs, checkpoint, batch)
 	changes += changesHere
 	if err != nil {
 		return err
@@ -528,7 +566,7 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 	// Do a scan of the database for each prefix, to check for deleted and
 	// ignored files.
 
-	changesHere, err = f.scanSubdirsDeletedAndIgnored(subDirs, batch)
+	changesHere, err = f.scanSubdirsDeletedAndIgnored(subDirs, checkpoint, batch)
 	changes += changesHere
 	if err != nil {
 		return err
@@ -538,6 +576,12 @@ func (f *folder) scanSubdirs(subDirs []string) error {
 		return err
 	}
 
+	if checkpoint != nil {
+		if err := f.fset.SetScanCheckpoint(checkpoint.Marshal()); err != nil {
+			l.Debugf("%v failed to store scan checkpoint: %v", f, err)
+		}
+	}
+
 	f.ScanCompleted()
 	return nil
 }
@@ -624,11 +668,52 @@ func (b *scanBatch) Update(fi protocol.FileInfo, snap *db.Snapshot) bool {
 		l.Debugf("%v scanning: Merging identical locally changed item with global", b.f, fi)
 		fi = gf
 	}
+	// Keep the previous contents of files changed or deleted locally, if
+	// so configured. The update is held back until they are archived, as
+	// other devices might otherwise act on it and drop them meanwhile.
+	if b.f.localVersions != nil && b.f.holdLocalVersionUpdate(fi, snap) {
+		return true
+	}
 	b.updateBatch.Append(fi)
 	return true
 }
 
-func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *scanBatch) (int, error) {
+// newScanCheckpoint returns a checkpoint to record the directories of a
+// full scan in. The initial scan after startup skips the directories
+// unchanged since the stored checkpoint. Later scans don't, so that changes
+// to the contents of files in those directories while we weren't running
+// are picked up by the next full scan. As changes at runtime are only
+// guaranteed to be picked up by the watcher, it's required for skipping.
+func (f *folder) newScanCheckpoint() *scanner.Checkpoint {
+	tag := fmt.Sprintf("%v %s %s", f.fset.IndexID(protocol.LocalDeviceID), f.mtimefs.URI(), f.ignores.Hash())
+
+	select {
+	case <-f.initialScanFinished:
+		return scanner.NewCheckpoint(tag, nil)
+	default:
+	}
+	if !f.FSWatcherEnabled {
+		return scanner.NewCheckpoint(tag, nil)
+	}
+
+	bs := f.fset.ScanCheckpoint()
+	if bs == nil {
+		return scanner.NewCheckpoint(tag, nil)
+	}
+	prev, err := scanner.UnmarshalCheckpoint(bs)
+	if err != nil {
+		l.Debugf("%v ignoring scan checkpoint: %v", f, err)
+		return scanner.NewCheckpoint(tag, nil)
+	}
+	if prev.Tag != tag {
+		l.Debugf("%v ignoring outdated scan checkpoint", f)
+		return scanner.NewCheckpoint(tag, nil)
+	}
+	l.Debugf("%v using scan checkpoint of %d directories", f, prev.Len())
+	return scanner.NewCheckpoint(tag, prev)
+}
+
+func (f *folder) scanSubdirsChangedAndNew(subDirs []string, checkpoint *scanner.Checkpoint, batch *scanBatch) (int, error) {
 	changes := 0
 	snap, err := f.dbSnapshot()
 	if err != nil {
@@ -641,6 +726,7 @@ func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *scanBatch) (i
 	scanCtx, scanCancel := context.WithCancel(f.ctx)
 	defer scanCancel()
 
+	hashAlgorithm, rehashOtherAlgorithms := f.model.blockHashAlgorithm(f.ID)
 	scanConfig := scanner.Config{
 		Folder:                f.ID,
 		Subs:                  subDirs,
@@ -659,6 +745,10 @@ func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *scanBatch) (i
 		ScanOwnership:         f.SendOwnership || f.SyncOwnership,
 		ScanXattrs:            f.SendXattrs || f.SyncXattrs,
 		XattrFilter:           f.XattrFilter,
+		Checkpoint:            checkpoint,
+		HashCache:             f.model.hashCache,
+		HashAlgorithm:         hashAlgorithm,
+		RehashOtherAlgorithms: rehashOtherAlgorithms,
 	}
 	var fchan chan scanner.ScanResult
 	if f.Type == config.FolderTypeReceiveEncrypted {
@@ -701,7 +791,7 @@ func (f *folder) scanSubdirsChangedAndNew(subDirs []string, batch *scanBatch) (i
 	return changes, nil
 }
 
-func (f *folder) scanSubdirsDeletedAndIgnored(subDirs []string, batch *scanBatch) (int, error) {
+func (f *folder) scanSubdirsDeletedAndIgnored(subDirs []string, checkpoint *scanner.Checkpoint, batch *scanBatch) (int, error) {
 	var toIgnore []db.FileInfoTruncated
 	ignoredParent := ""
 	changes := 0
@@ -774,8 +864,9 @@ func (f *folder) scanSubdirsDeletedAndIgnored(subDirs []string, batch *scanBatch
 				// The file is not ignored, deleted or unsupported. Lets check if
 				// it's still here. Simply stat:ing it won't do as there are
 				// tons of corner cases (e.g. parent dir->symlink, missing
-				// permissions)
-				if !osutil.IsDeleted(f.mtimefs, file.Name) {
+				// permissions). Nothing was removed from directories that
+				// are unchanged since the scan checkpoint.
+				if (checkpoint != nil && checkpoint.Unchanged(filepath.Dir(file.Name))) || !osutil.IsDeleted(f.mtimefs, file.Name) {
 					if ignoredParent != "" {
 						// Don't ignore parents of this not ignored item
 						toIgnore = toIgnore[:0]
@@ -0,0 +1,282 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/versioner"
+)
+
+const (
+	// localVersionsQueueSize is how many locally changed files may wait for
+	// their previous contents to be archived. Further ones are updated
+	// without.
+	localVersionsQueueSize = 1000
+
+	// The previous contents are reconstructed into a temporary file with a
+	// prefix of its own, as the puller might be using the usual one.
+	localVersionTempPrefix = fs.UnixTempPrefix + "version."
+
+	localVersionRequestTimeout = time.Minute
+)
+
+// replacedLocally returns whether the contents of a file we had are gone
+// from disk, given the file as just found by scanning.
+func replacedLocally(prev, cur protocol.FileInfo) bool {
+	switch {
+	case prev.IsDeleted() || prev.IsInvalid() || prev.Type != protocol.FileInfoTypeFile:
+		return false
+	case cur.IsDeleted() || cur.Type != protocol.FileInfoTypeFile:
+		return true
+	case cur.IsInvalid():
+		// Ignored, but still there
+		return false
+	case prev.BlockHashAlgorithm != cur.BlockHashAlgorithm:
+		// Rehashed with another algorithm, so the blocks differ regardless.
+		return prev.Size != cur.Size || !prev.ModTime().Equal(cur.ModTime())
+	default:
+		return !prev.BlocksEqual(cur)
+	}
+}
+
+// holdLocalVersionUpdate queues the file as we had it before it was changed
+// or deleted locally, to archive its contents in the versioner, and holds
+// back the update to the file as just scanned until then. Returns false if
+// the update should go ahead as usual, as the file wasn't replaced or the
+// queue is full.
+func (f *folder) holdLocalVersionUpdate(fi protocol.FileInfo, snap *db.Snapshot) bool {
+	f.heldUpdatesMut.Lock()
+	defer f.heldUpdatesMut.Unlock()
+
+	if _, ok := f.heldUpdates[fi.Name]; ok {
+		// Still archiving, the latest update goes in once done.
+		f.heldUpdates[fi.Name] = fi
+		return true
+	}
+	cf, ok := snap.Get(protocol.LocalDeviceID, fi.Name)
+	if !ok || !replacedLocally(cf, fi) {
+		return false
+	}
+	select {
+	case f.localVersions <- cf:
+		l.Debugf("%v queueing previous version of locally changed %v", f, cf.Name)
+		f.heldUpdates[fi.Name] = fi
+		return true
+	default:
+		l.Infof("Not archiving previous version of locally changed file %q in folder %v: too many files queued", cf.Name, f.Description())
+		return false
+	}
+}
+
+// archiveLocalVersions archives the previous versions of locally changed
+// files as they are queued, and then updates the files as held back, until
+// the context is cancelled.
+func (f *folder) archiveLocalVersions(ctx context.Context) {
+	unsupported := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case file := <-f.localVersions:
+			if !unsupported {
+				err := f.archiveLocalVersion(ctx, file)
+				switch {
+				case err == nil:
+				case errors.Is(err, versioner.ErrLocalVersioningNotSupported):
+					l.Warnf("Not versioning local changes in folder %v: %v", f.Description(), err)
+					unsupported = true
+				case ctx.Err() == nil:
+					l.Infof("Failed to archive previous version of locally changed file %q in folder %v: %v", file.Name, f.Description(), err)
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			f.releaseHeldUpdate(file)
+		}
+	}
+}
+
+// releaseHeldUpdate updates the file as scanned after its previous version
+// was archived, unless it was changed in the database meanwhile, in which
+// case the next scan sorts it out.
+func (f *folder) releaseHeldUpdate(prev protocol.FileInfo) {
+	f.heldUpdatesMut.Lock()
+	fi, ok := f.heldUpdates[prev.Name]
+	delete(f.heldUpdates, prev.Name)
+	f.heldUpdatesMut.Unlock()
+	if !ok {
+		return
+	}
+
+	snap, err := f.dbSnapshot()
+	if err != nil {
+		return
+	}
+	cf, ok := snap.Get(protocol.LocalDeviceID, prev.Name)
+	snap.Release()
+	if !ok || !cf.Version.Equal(prev.Version) {
+		l.Debugf("%v not updating %v changed while archiving its previous version", f, prev.Name)
+		return
+	}
+	f.updateLocalsFromScanning([]protocol.FileInfo{fi})
+}
+
+// archiveLocalVersion reconstructs the contents the file had from its
+// blocks, found elsewhere on disk, in the block cache or on the devices
+// still having that version, and passes them on to the versioner.
+func (f *folder) archiveLocalVersion(ctx context.Context, file protocol.FileInfo) error {
+	snap, err := f.dbSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+
+	tempName := fs.TempNameWithPrefix(file.Name, localVersionTempPrefix)
+	if err := f.reconstructLocalVersion(ctx, snap, file, tempName); err != nil {
+		_ = f.mtimefs.Remove(tempName)
+		return err
+	}
+
+	l.Debugf("%v archiving previous version of locally changed %v", f, file.Name)
+	if err := f.versioner.ArchiveFrom(tempName, file.Name); err != nil {
+		_ = f.mtimefs.Remove(tempName)
+		return err
+	}
+	return nil
+}
+
+func (f *folder) reconstructLocalVersion(ctx context.Context, snap *db.Snapshot, file protocol.FileInfo, tempName string) error {
+	mode := fs.FileMode(0o644)
+	if !f.IgnorePerms && !file.NoPermissions {
+		mode = fs.FileMode(file.Permissions & 0o777)
+	}
+	fd, err := f.mtimefs.OpenFile(tempName, fs.OptReadWrite|fs.OptCreate|fs.OptTruncate, mode)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	if err := fd.Truncate(file.Size); err != nil {
+		return err
+	}
+
+	folders, folderFilesystems := f.blockSourceFolders()
+	devices := f.localVersionDevices(snap, file)
+	for _, block := range file.Blocks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !f.DisableSparseFiles && block.IsEmpty() {
+			continue
+		}
+		buf, ok := f.localVersionBlock(ctx, file, block, folders, folderFilesystems, devices)
+		if !ok {
+			return fmt.Errorf("block at offset %d not available locally or from any device", block.Offset)
+		}
+		_, err := fd.WriteAt(buf, block.Offset)
+		protocol.BufferPool.Put(buf)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	f.mtimefs.Chtimes(tempName, file.ModTime(), file.ModTime()) // never fails
+	return nil
+}
+
+// blockSourceFolders returns the folders to look for blocks on disk in,
+// starting with this one, and their filesystems.
+func (f *folder) blockSourceFolders() ([]string, map[string]fs.Filesystem) {
+	folderFilesystems := make(map[string]fs.Filesystem)
+	folders := []string{f.folderID}
+	for folder, cfg := range f.model.cfg.Folders() {
+		folderFilesystems[folder] = cfg.Filesystem(nil)
+		if folder != f.folderID {
+			folders = append(folders, folder)
+		}
+	}
+	return folders, folderFilesystems
+}
+
+// localVersionDevices returns the devices announcing the given version of
+// the file, i.e. expected to still have it.
+func (f *folder) localVersionDevices(snap *db.Snapshot, file protocol.FileInfo) []protocol.DeviceID {
+	var devices []protocol.DeviceID
+	for _, device := range f.DeviceIDs() {
+		if device == f.model.id {
+			continue
+		}
+		if df, ok := snap.Get(device, file.Name); ok && !df.IsDeleted() && !df.IsInvalid() && df.Version.Equal(file.Version) {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+// localVersionBlock returns the data of the block, read from disk where
+// another file has it, taken from our block cache or requested from the
+// block caches on the LAN or one of the devices.
+func (f *folder) localVersionBlock(ctx context.Context, file protocol.FileInfo, block protocol.BlockInfo, folders []string, folderFilesystems map[string]fs.Filesystem, devices []protocol.DeviceID) ([]byte, bool) {
+	buf := protocol.BufferPool.Get(int(block.Size))
+
+	if f.model.finder.Iterate(folders, block.Hash, func(folder, path string, index int32) bool {
+		fd, err := folderFilesystems[folder].Open(path)
+		if err != nil {
+			return false
+		}
+		defer fd.Close()
+		if _, err := fd.ReadAt(buf, int64(file.BlockSize())*int64(index)); err != nil {
+			return false
+		}
+		return verifyBuffer(buf, block, file.BlockHashAlgorithm) == nil
+	}) {
+		return buf, true
+	}
+
+	if data, ok := f.model.blockCache.Get(block.Hash); ok && verifyBuffer(data, block, file.BlockHashAlgorithm) == nil {
+		copy(buf, data)
+		return buf, true
+	}
+	protocol.BufferPool.Put(buf)
+
+	blockNo := int(block.Offset / int64(file.BlockSize()))
+	request := func(device protocol.DeviceID, timeout time.Duration) ([]byte, bool) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		data, err := f.model.RequestGlobal(ctx, device, f.folderID, file.Name, blockNo, block.Offset, int(block.Size), block.Hash, block.WeakHash, file.BlockHashAlgorithm, false)
+		if err != nil {
+			l.Debugln("request for local version:", f.folderID, file.Name, block.Offset, block.Size, device.Short(), "returned error:", err)
+			return nil, false
+		}
+		if err := verifyBuffer(data, block, file.BlockHashAlgorithm); err != nil {
+			l.Debugln("request for local version:", f.folderID, file.Name, block.Offset, block.Size, device.Short(), err)
+			return nil, false
+		}
+		return data, true
+	}
+	// Misses aren't recorded for the caches to fill themselves, as the
+	// version is archived long before they'd fetch the block from us.
+	if data, ok := f.model.requestFromBlockCaches(ctx, block, file.BlockHashAlgorithm, nil); ok {
+		return data, true
+	}
+	for _, device := range devices {
+		if data, ok := request(device, localVersionRequestTimeout); ok {
+			return data, true
+		}
+	}
+	return nil, false
+}
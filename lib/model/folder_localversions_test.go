@@ -0,0 +1,303 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/versioner"
+)
+
+func TestReplacedLocally(t *testing.T) {
+	prev := protocol.FileInfo{
+		Name:      "file",
+		Size:      8,
+		ModifiedS: 1,
+		Blocks:    []protocol.BlockInfo{{Size: 8, Hash: []byte("previous")}},
+	}
+	changed := prev
+	changed.Blocks = []protocol.BlockInfo{{Size: 8, Hash: []byte("changed!")}}
+	deleted := prev
+	deleted.Deleted = true
+	deleted.Blocks = nil
+	ignored := changed
+	ignored.LocalFlags = protocol.FlagLocalIgnored
+	rehashed := changed
+	rehashed.BlockHashAlgorithm = protocol.HashAlgorithmBLAKE3
+	rewritten := rehashed
+	rewritten.ModifiedS = 2
+	dir := protocol.FileInfo{Name: "file", Type: protocol.FileInfoTypeDirectory}
+
+	cases := []struct {
+		prev, cur protocol.FileInfo
+		expected  bool
+	}{
+		{prev, prev, false},
+		{prev, changed, true},
+		{prev, deleted, true},
+		{prev, dir, true},
+		{prev, ignored, false},
+		{prev, rehashed, false},
+		{prev, rewritten, true},
+		{deleted, prev, false},
+		{dir, prev, false},
+	}
+	for i, tc := range cases {
+		if res := replacedLocally(tc.prev, tc.cur); res != tc.expected {
+			t.Errorf("%d: expected %v, got %v", i, tc.expected, res)
+		}
+	}
+}
+
+func TestVersionLocalChanges(t *testing.T) {
+	dir := t.TempDir()
+	w, cancel := newConfigWrapper(defaultCfg)
+	defer cancel()
+	fcfg := newFolderConfiguration(w, "so", "so", fs.FilesystemTypeBasic, dir)
+	fcfg.Type = config.FolderTypeSendOnly
+	fcfg.FSWatcherEnabled = false
+	fcfg.Versioning.Type = "simple"
+	fcfg.VersionLocalChanges = true
+	setFolder(t, w, fcfg)
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	ffs := fcfg.Filesystem(nil)
+	writeFile(t, ffs, "a", []byte("previous"))
+	writeFile(t, ffs, "b", []byte("previous"))
+	must(t, m.ScanFolder("so"))
+
+	// The previous contents of a are still there in b.
+	writeFile(t, ffs, "a", []byte("changed contents"))
+	must(t, m.ScanFolder("so"))
+
+	var versions map[string][]versioner.FileVersion
+	for timeout := time.After(10 * time.Second); len(versions["a"]) == 0; {
+		select {
+		case <-timeout:
+			t.Fatal("Timed out waiting for a to be versioned")
+		case <-time.After(10 * time.Millisecond):
+		}
+		var err error
+		versions, err = m.GetFolderVersions("so")
+		must(t, err)
+	}
+	if len(versions["a"]) != 1 {
+		t.Fatalf("Expected one version of a, got %v", versions["a"])
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, versioner.DefaultPath, "a~*"))
+	must(t, err)
+	if len(matches) != 1 {
+		t.Fatalf("Expected one version file, got %v", matches)
+	}
+	bs, err := os.ReadFile(matches[0])
+	must(t, err)
+	if string(bs) != "previous" {
+		t.Errorf("Expected previous contents in version, got %q", bs)
+	}
+
+	// Nothing has the contents of b anymore after deleting it.
+	must(t, ffs.Remove("b"))
+	must(t, m.ScanFolder("so"))
+	time.Sleep(100 * time.Millisecond)
+
+	versions, err = m.GetFolderVersions("so")
+	must(t, err)
+	if len(versions["b"]) != 0 {
+		t.Errorf("Expected no version of b, got %v", versions["b"])
+	}
+	if names, err := ffs.DirNames("."); err != nil {
+		t.Fatal(err)
+	} else {
+		for _, name := range names {
+			if fs.IsTemporary(name) {
+				t.Errorf("Temporary file %v left behind", name)
+			}
+		}
+	}
+}
+
+func TestVersionLocalDeleteBeforePeer(t *testing.T) {
+	// The peer drops its copy once it learns about our deletion, so we must
+	// have fetched the previous contents from it before announcing that.
+
+	dir := t.TempDir()
+	w, cancel := newConfigWrapper(defaultCfg)
+	defer cancel()
+	fcfg := newFolderConfiguration(w, "default", "default", fs.FilesystemTypeBasic, dir)
+	fcfg.FSWatcherEnabled = false
+	fcfg.Devices = append(fcfg.Devices, config.FolderDeviceConfiguration{DeviceID: device1})
+	fcfg.Versioning.Type = "simple"
+	fcfg.VersionLocalChanges = true
+	setFolder(t, w, fcfg)
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	contents := []byte("previous contents")
+	var mut sync.Mutex
+	peerHas := true
+	pulled := make(chan struct{})
+	deleted := make(chan struct{})
+	fc := addFakeConn(m, device1, "default")
+	fc.RequestCalls(func(_ context.Context, req *protocol.Request) ([]byte, error) {
+		select {
+		case <-pulled:
+			// Give our index update time to get there first.
+			select {
+			case <-deleted:
+			case <-time.After(time.Second):
+			}
+		default:
+		}
+		mut.Lock()
+		defer mut.Unlock()
+		if req.Name != "a" || !peerHas {
+			return nil, protocol.ErrNoSuchFile
+		}
+		return contents[req.Offset : req.Offset+int64(req.Size)], nil
+	})
+	fc.setIndexFn(func(_ context.Context, _ string, fs []protocol.FileInfo) error {
+		for _, f := range fs {
+			if f.Name != "a" {
+				continue
+			}
+			if !f.IsDeleted() {
+				closeOnce(pulled)
+				continue
+			}
+			mut.Lock()
+			peerHas = false
+			mut.Unlock()
+			closeOnce(deleted)
+		}
+		return nil
+	})
+
+	fc.addFile("a", 0o644, protocol.FileInfoTypeFile, contents)
+	fc.sendIndexUpdate()
+	select {
+	case <-pulled:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for a to be pulled")
+	}
+
+	ffs := fcfg.Filesystem(nil)
+	must(t, ffs.Remove("a"))
+	must(t, m.ScanFolder("default"))
+
+	select {
+	case <-deleted:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the deletion of a to be announced")
+	}
+	if f, ok := m.testCurrentFolderFile("default", "a"); !ok || !f.IsDeleted() {
+		t.Errorf("Expected a to be deleted, got %v", f)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, versioner.DefaultPath, "a~*"))
+	must(t, err)
+	if len(matches) != 1 {
+		t.Fatalf("Expected one version file, got %v", matches)
+	}
+	bs, err := os.ReadFile(matches[0])
+	must(t, err)
+	if string(bs) != string(contents) {
+		t.Errorf("Expected previous contents in version, got %q", bs)
+	}
+}
+
+func TestVersionLocalFromBlockCache(t *testing.T) {
+	dir := t.TempDir()
+	w, cancel := newConfigWrapper(defaultCfg)
+	defer cancel()
+	fcfg := newFolderConfiguration(w, "so", "so", fs.FilesystemTypeBasic, dir)
+	fcfg.Type = config.FolderTypeSendOnly
+	fcfg.FSWatcherEnabled = false
+	fcfg.Versioning.Type = "simple"
+	fcfg.VersionLocalChanges = true
+	setFolder(t, w, fcfg)
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		dev := newDeviceConfiguration(cfg.Defaults.Device, device2, "cache")
+		dev.BlockCache = true
+		cfg.SetDevice(dev)
+	})
+	must(t, err)
+	waiter.Wait()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	// Only the block cache has the previous contents, and is asked for
+	// them by hash alone.
+	previous := []byte("previous")
+	hash := sha256.Sum256(previous)
+	var mut sync.Mutex
+	var reqs []protocol.Request
+	cache := newFakeConnection(device2, m)
+	cache.IsLocalReturns(true)
+	cache.RequestCalls(func(_ context.Context, req *protocol.Request) ([]byte, error) {
+		mut.Lock()
+		reqs = append(reqs, *req)
+		mut.Unlock()
+		if !bytes.Equal(req.Hash, hash[:]) {
+			return nil, protocol.ErrNoSuchFile
+		}
+		return previous, nil
+	})
+	m.AddConnection(cache, protocol.Hello{})
+
+	ffs := fcfg.Filesystem(nil)
+	writeFile(t, ffs, "a", previous)
+	must(t, m.ScanFolder("so"))
+	writeFile(t, ffs, "a", []byte("changed contents"))
+	must(t, m.ScanFolder("so"))
+
+	var matches []string
+	for timeout := time.After(10 * time.Second); len(matches) == 0; {
+		select {
+		case <-timeout:
+			t.Fatal("Timed out waiting for a to be versioned")
+		case <-time.After(10 * time.Millisecond):
+		}
+		matches, err = filepath.Glob(filepath.Join(dir, versioner.DefaultPath, "a~*"))
+		must(t, err)
+	}
+	bs, err := os.ReadFile(matches[0])
+	must(t, err)
+	if !bytes.Equal(bs, previous) {
+		t.Errorf("Expected previous contents in version, got %q", bs)
+	}
+
+	mut.Lock()
+	defer mut.Unlock()
+	if len(reqs) == 0 {
+		t.Fatal("Expected the block to be requested from the cache")
+	}
+	for _, req := range reqs {
+		if req.Folder != "" || req.Name != "" {
+			t.Errorf("Expected a request by hash only, got %q / %q", req.Folder, req.Name)
+		}
+	}
+}
+
+func closeOnce(c chan struct{}) {
+	select {
+	case <-c:
+	default:
+		close(c)
+	}
+}
@@ -8,6 +8,7 @@ package model
 
 import (
 	"fmt"
+	"path/filepath"
 	"sort"
 
 	"github.com/syncthing/syncthing/lib/config"
@@ -31,9 +32,92 @@ type receiveEncryptedFolder struct {
 func newReceiveEncryptedFolder(model *model, fset *db.FileSet, ignores *ignore.Matcher, cfg config.FolderConfiguration, ver versioner.Versioner, evLogger events.Logger, ioLimiter *semaphore.Semaphore) service {
 	f := &receiveEncryptedFolder{newSendReceiveFolder(model, fset, ignores, cfg, ver, evLogger, ioLimiter).(*sendReceiveFolder)}
 	f.localFlags = protocol.FlagLocalReceiveOnly // gets propagated to the scanner, and set on locally changed files
+	f.folder.puller = f
 	return f
 }
 
+// inSync finishes a key rotation in progress once everything encrypted
+// with the current password has been received.
+func (f *receiveEncryptedFolder) inSync() {
+	if !f.model.keyRotationIndexComplete(f.ID) {
+		return
+	}
+	if err := f.removeStaleEncrypted(); err != nil {
+		l.Infof("Removing data encrypted with the previous password in folder %v: %v", f.Description(), err)
+		return
+	}
+	if err := f.model.finishKeyRotation(f.FolderConfiguration); err != nil {
+		l.Warnf("Failed to finish encryption password change for folder %v: %v", f.Description(), err)
+	}
+}
+
+// removeStaleEncrypted removes the items that no other device announces
+// anymore, i.e. those that are encrypted with the previous password.
+func (f *receiveEncryptedFolder) removeStaleEncrypted() error {
+	snap, err := f.dbSnapshot()
+	if err != nil {
+		return err
+	}
+	defer snap.Release()
+	if need := snap.NeedSize(protocol.LocalDeviceID); need.TotalItems() > 0 {
+		return fmt.Errorf("%d items still needed", need.TotalItems())
+	}
+
+	var stale []db.FileInfoTruncated
+	snap.WithHaveTruncated(protocol.LocalDeviceID, func(intf protocol.FileIntf) bool {
+		fit := intf.(db.FileInfoTruncated)
+		// Unexpected items are left for the user to revert.
+		if fit.IsDeleted() || fit.IsReceiveOnlyChanged() {
+			return true
+		}
+		for _, dev := range snap.Availability(fit.Name) {
+			if dev != protocol.LocalDeviceID {
+				return true
+			}
+		}
+		stale = append(stale, fit)
+		return true
+	})
+
+	// Directories are empty as encrypted names are flat, but remove them
+	// after the files all the same.
+	sort.SliceStable(stale, func(a, b int) bool {
+		return !stale[a].IsDirectory() && stale[b].IsDirectory()
+	})
+	failed := 0
+	removed := make([]string, 0, len(stale))
+	for _, fit := range stale {
+		if err := f.inWritableDir(f.mtimefs.Remove, fit.Name); err != nil && !fs.IsNotExist(err) {
+			f.newScanError(fit.Name, fmt.Errorf("deleting previously encrypted item: %w", err))
+			failed++
+			continue
+		}
+		f.removeEmptyParents(fit.Name)
+		removed = append(removed, fit.Name)
+	}
+	// The items are dropped from the database without a deletion entry in
+	// the index: The names are encrypted with a password the other devices
+	// don't use anymore, so they must never see them again.
+	if len(removed) > 0 {
+		f.fset.RemoveLocalItems(removed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d items", failed)
+	}
+	return nil
+}
+
+// removeEmptyParents removes the parent directories of the given item, as
+// far as they are empty. They are implied by the encrypted names and not
+// part of the index.
+func (f *receiveEncryptedFolder) removeEmptyParents(name string) {
+	for dir := filepath.Dir(name); dir != "." && dir != string(filepath.Separator); dir = filepath.Dir(dir) {
+		if err := f.mtimefs.Remove(dir); err != nil {
+			return
+		}
+	}
+}
+
 func (f *receiveEncryptedFolder) Revert() {
 	f.doInSync(f.revert)
 }
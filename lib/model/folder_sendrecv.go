@@ -9,11 +9,11 @@ package model
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -61,6 +61,20 @@ type copyBlocksState struct {
 // Which filemode bits to preserve
 const retainBits = fs.ModeSetgid | fs.ModeSetuid | fs.ModeSticky
 
+const (
+	// A block request taking this many times longer than expected is also
+	// sent to another device, whichever answers first wins.
+	pullHedgeFactor = 4
+	// Requests aren't sent elsewhere before they've taken at least this
+	// long, as short hiccups are normal.
+	pullHedgeMinDelay = 2 * time.Second
+	// Files of at least this many blocks are pulled rarest blocks first.
+	rarestFirstMinBlocks = 16
+	// How long to wait for the block caches before getting the block
+	// elsewhere.
+	blockCacheRequestTimeout = 2 * time.Second
+)
+
 var (
 	activity                  = newDeviceActivity()
 	errNoDevice               = errors.New("peers who had this file went away, or the file has changed while syncing. will retry later")
@@ -450,6 +464,11 @@ func (f *sendReceiveFolder) processNeeded(snap *db.Snapshot, dbUpdateChan chan<-
 		f.queue.SortNewestFirst()
 	}
 
+	// Priority rules take precedence over the order above.
+	if len(f.PullPriorities) > 0 {
+		f.queue.SortByPriority(f.PullPriority)
+	}
+
 	// Process the file queue.
 
 nextFile:
@@ -1118,6 +1137,9 @@ func (f *sendReceiveFolder) handleFile(file protocol.FileInfo, snap *db.Snapshot
 
 	// Reorder blocks
 	blocks = f.blockPullReorderer.Reorder(blocks)
+	if f.BlockPullOrder != config.BlockPullOrderInOrder && len(blocks) >= rarestFirstMinBlocks {
+		blocks = f.rarestFirst(file, blocks)
+	}
 
 	f.evLogger.Log(events.ItemStarted, map[string]string{
 		"folder": f.folderID,
@@ -1138,15 +1160,39 @@ func (f *sendReceiveFolder) handleFile(file protocol.FileInfo, snap *db.Snapshot
 	copyChan <- cs
 }
 
+// rarestFirst moves the blocks that the fewest other devices have in
+// temporary files to the front, keeping the order otherwise. When many
+// devices pull the same large file from each other, this spreads the
+// blocks over them quickly, rather than everyone waiting on the same few
+// devices for the same blocks.
+func (f *sendReceiveFolder) rarestFirst(file protocol.FileInfo, blocks []protocol.BlockInfo) []protocol.BlockInfo {
+	counts := f.model.temporaryAvailability(f.FolderConfiguration, file, blocks)
+	if !slices.ContainsFunc(counts, func(c int) bool { return c > 0 }) {
+		return blocks
+	}
+	idxs := make([]int, len(blocks))
+	for i := range idxs {
+		idxs[i] = i
+	}
+	sort.SliceStable(idxs, func(a, b int) bool {
+		return counts[idxs[a]] < counts[idxs[b]]
+	})
+	sorted := make([]protocol.BlockInfo, len(blocks))
+	for i, idx := range idxs {
+		sorted[i] = blocks[idx]
+	}
+	return sorted
+}
+
 func (f *sendReceiveFolder) reuseBlocks(blocks []protocol.BlockInfo, reused []int, file protocol.FileInfo, tempName string) ([]protocol.BlockInfo, []int) {
 	// Check for an old temporary file which might have some blocks we could
 	// reuse.
-	tempBlocks, err := scanner.HashFile(f.ctx, f.ID, f.mtimefs, tempName, file.BlockSize(), nil, false)
+	tempBlocks, err := scanner.HashFile(f.ctx, f.ID, f.mtimefs, tempName, file.BlockSize(), file.BlockHashAlgorithm, nil, false)
 	if err != nil {
 		var caseErr *fs.ErrCaseConflict
 		if errors.As(err, &caseErr) {
 			if rerr := f.mtimefs.Rename(caseErr.Real, tempName); rerr == nil {
-				tempBlocks, err = scanner.HashFile(f.ctx, f.ID, f.mtimefs, tempName, file.BlockSize(), nil, false)
+				tempBlocks, err = scanner.HashFile(f.ctx, f.ID, f.mtimefs, tempName, file.BlockSize(), file.BlockHashAlgorithm, nil, false)
 			}
 		}
 	}
@@ -1344,7 +1390,7 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 			var found bool
 			if f.Type != config.FolderTypeReceiveEncrypted {
 				found, err = weakHashFinder.Iterate(block.WeakHash, buf, func(offset int64) bool {
-					if f.verifyBuffer(buf, block) != nil {
+					if verifyBuffer(buf, block, state.file.BlockHashAlgorithm) != nil {
 						return true
 					}
 
@@ -1384,7 +1430,7 @@ func (f *sendReceiveFolder) copierRoutine(in <-chan copyBlocksState, pullChan ch
 					// case we can't verify the block integrity so we'll take it on
 					// trust. (The other side can and will verify.)
 					if f.Type != config.FolderTypeReceiveEncrypted {
-						if err := f.verifyBuffer(buf, block); err != nil {
+						if err := verifyBuffer(buf, block, state.file.BlockHashAlgorithm); err != nil {
 							l.Debugln("Finder failed to verify buffer", err)
 							return false
 						}
@@ -1478,12 +1524,12 @@ func (f *sendReceiveFolder) initWeakHashFinder(state copyBlocksState) (*weakhash
 	return weakHashFinder, file
 }
 
-func (*sendReceiveFolder) verifyBuffer(buf []byte, block protocol.BlockInfo) error {
+func verifyBuffer(buf []byte, block protocol.BlockInfo, hashAlgorithm protocol.HashAlgorithm) error {
 	if len(buf) != int(block.Size) {
 		return fmt.Errorf("length mismatch %d != %d", len(buf), block.Size)
 	}
 
-	hash := sha256.Sum256(buf)
+	hash := hashAlgorithm.Sum(buf)
 	if !bytes.Equal(hash[:], block.Hash) {
 		return fmt.Errorf("hash mismatch %x != %x", hash, block.Hash)
 	}
@@ -1546,22 +1592,72 @@ func (f *sendReceiveFolder) pullBlock(state pullBlockState, snap *db.Snapshot, o
 		return
 	}
 
+	// Block caches on the LAN are asked first, as getting blocks from them
+	// is cheap.
+	if buf, ok := f.pullFromBlockCache(state); ok {
+		if err := f.limitedWriteAt(fd, buf, state.block.Offset); err != nil {
+			state.fail(fmt.Errorf("save: %w", err))
+		} else {
+			state.pullDone(state.block)
+		}
+		out <- state.sharedPullerState
+		return
+	}
+
 	var lastError error
 	candidates := f.model.availabilityInSnapshot(f.FolderConfiguration, snap, state.file, state.block)
-loop:
-	for {
-		select {
-		case <-f.ctx.Done():
-			state.fail(fmt.Errorf("folder stopped: %w", f.ctx.Err()))
-			break loop
-		default:
-		}
+	size := int(state.block.Size)
 
-		// Select the least busy device to pull the block from. If we found no
-		// feasible device at all, fail the block (and in the long run, the
-		// file).
-		found := activity.leastBusy(candidates)
+	// Requests still outstanding when we're done are no longer of
+	// interest.
+	ctx, cancel := context.WithCancel(f.ctx)
+	defer cancel()
+
+	// Each candidate is asked at most once, so this never blocks.
+	results := make(chan blockResult, len(candidates))
+	inFlight := 0
+	hedged := false
+	hedgeTimer := time.NewTimer(time.Hour)
+	hedgeTimer.Stop()
+	defer hedgeTimer.Stop()
+
+	// request asks the device expected to deliver the block soonest,
+	// returning false if there are no candidates left.
+	request := func() bool {
+		found, expected := activity.fastest(candidates, size)
 		if found == -1 {
+			return false
+		}
+		selected := candidates[found]
+		candidates[found] = candidates[len(candidates)-1]
+		candidates = candidates[:len(candidates)-1]
+
+		inFlight++
+		go f.requestBlock(ctx, state, selected, results)
+
+		// If it takes much longer than expected, for example because the
+		// device has become slow, ask another one as well rather than
+		// wait for it.
+		delay := pullHedgeFactor * expected
+		if delay < pullHedgeMinDelay {
+			delay = pullHedgeMinDelay
+		}
+		if !hedgeTimer.Stop() {
+			select {
+			case <-hedgeTimer.C:
+			default:
+			}
+		}
+		hedgeTimer.Reset(delay)
+		return true
+	}
+
+loop:
+	for {
+		// Select the device to pull the block from when nothing is in
+		// flight. If we found no feasible device at all, fail the block
+		// (and in the long run, the file).
+		if inFlight == 0 && !request() {
 			if lastError != nil {
 				state.fail(fmt.Errorf("pull: %w", lastError))
 			} else {
@@ -1570,19 +1666,24 @@ loop:
 			break
 		}
 
-		selected := candidates[found]
-		candidates[found] = candidates[len(candidates)-1]
-		candidates = candidates[:len(candidates)-1]
+		var res blockResult
+		select {
+		case <-f.ctx.Done():
+			state.fail(fmt.Errorf("folder stopped: %w", f.ctx.Err()))
+			break loop
+		case <-hedgeTimer.C:
+			if !hedged && request() {
+				l.Debugln("request:", f.folderID, state.file.Name, state.block.Offset, state.block.Size, "is slow, also requesting elsewhere")
+				hedged = true
+			}
+			continue
+		case res = <-results:
+			inFlight--
+		}
 
-		// Fetch the block, while marking the selected device as in use so that
-		// leastBusy can select another device when someone else asks.
-		activity.using(selected)
-		var buf []byte
-		blockNo := int(state.block.Offset / int64(state.file.BlockSize()))
-		buf, lastError = f.model.RequestGlobal(f.ctx, selected.ID, f.folderID, state.file.Name, blockNo, state.block.Offset, int(state.block.Size), state.block.Hash, state.block.WeakHash, selected.FromTemporary)
-		activity.done(selected)
+		lastError = res.err
 		if lastError != nil {
-			l.Debugln("request:", f.folderID, state.file.Name, state.block.Offset, state.block.Size, selected.ID.Short(), "returned error:", lastError)
+			l.Debugln("request:", f.folderID, state.file.Name, state.block.Offset, state.block.Size, res.from.ID.Short(), "returned error:", lastError)
 			continue
 		}
 
@@ -1593,7 +1694,7 @@ loop:
 		// integrity so we'll take it on trust. (The other side can and
 		// will verify.)
 		if f.Type != config.FolderTypeReceiveEncrypted {
-			lastError = f.verifyBuffer(buf, state.block)
+			lastError = verifyBuffer(res.buf, state.block, state.file.BlockHashAlgorithm)
 		}
 		if lastError != nil {
 			l.Debugln("request:", f.folderID, state.file.Name, state.block.Offset, state.block.Size, "hash mismatch")
@@ -1601,7 +1702,7 @@ loop:
 		}
 
 		// Save the block data we got from the cluster
-		err = f.limitedWriteAt(fd, buf, state.block.Offset)
+		err = f.limitedWriteAt(fd, res.buf, state.block.Offset)
 		if err != nil {
 			state.fail(fmt.Errorf("save: %w", err))
 		} else {
@@ -1609,9 +1710,58 @@ loop:
 		}
 		break
 	}
+
+	// Wait for any requests still outstanding to be cancelled, so that
+	// they're accounted for.
+	cancel()
+	for ; inFlight > 0; inFlight-- {
+		<-results
+	}
+
 	out <- state.sharedPullerState
 }
 
+// pullFromBlockCache requests the block from the block caches we're
+// connected to, returning it if one of them had it. Blocks of encrypted
+// files aren't cached, as their hashes can't be verified.
+func (f *sendReceiveFolder) pullFromBlockCache(state pullBlockState) ([]byte, bool) {
+	if f.Type == config.FolderTypeReceiveEncrypted {
+		return nil, false
+	}
+	return f.model.requestFromBlockCaches(f.ctx, state.block, state.file.BlockHashAlgorithm, func(deviceID protocol.DeviceID) {
+		f.model.blockCacheMisses.add(deviceID, state.block.Hash, blockCacheMiss{
+			folder:        f.folderID,
+			name:          state.file.Name,
+			offset:        state.block.Offset,
+			size:          int(state.block.Size),
+			hashAlgorithm: state.file.BlockHashAlgorithm,
+		})
+	})
+}
+
+type blockResult struct {
+	from Availability
+	buf  []byte
+	err  error
+}
+
+// requestBlock requests the block from the given device, marking it as in
+// use meanwhile so that other blocks are preferably requested elsewhere.
+func (f *sendReceiveFolder) requestBlock(ctx context.Context, state pullBlockState, from Availability, results chan<- blockResult) {
+	size := int(state.block.Size)
+	blockNo := int(state.block.Offset / int64(state.file.BlockSize()))
+	start := activity.using(from, size)
+	buf, err := f.model.RequestGlobal(ctx, from.ID, f.folderID, state.file.Name, blockNo, state.block.Offset, size, state.block.Hash, state.block.WeakHash, state.file.BlockHashAlgorithm, from.FromTemporary)
+	if ctx.Err() != nil && err != nil {
+		// Cancelled, as the block came from elsewhere first or the folder
+		// stopped, which only tells us the device wasn't any faster.
+		activity.done(from, start, size, ctx.Err())
+	} else {
+		activity.done(from, start, size, err)
+	}
+	results <- blockResult{from: from, buf: buf, err: err}
+}
+
 func (f *sendReceiveFolder) performFinish(file, curFile protocol.FileInfo, hasCurFile bool, tempName string, snap *db.Snapshot, dbUpdateChan chan<- dbUpdateJob, scanChan chan<- string) error {
 	// Set the correct permission bits on the new file
 	if !f.IgnorePerms && !file.NoPermissions {
@@ -14,6 +14,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -220,6 +221,50 @@ func TestHandleFileWithTemp(t *testing.T) {
 	}
 }
 
+func TestRarestFirst(t *testing.T) {
+	const blockSize = protocol.MinBlockSize
+	file := protocol.FileInfo{
+		Name:    "filex",
+		Size:    rarestFirstMinBlocks * blockSize,
+		Version: protocol.Vector{}.Update(device1.Short()),
+	}
+	for i := 0; i < rarestFirstMinBlocks; i++ {
+		file.Blocks = append(file.Blocks, protocol.BlockInfo{Offset: int64(i * blockSize), Size: blockSize})
+	}
+
+	m, f, wcfgCancel := setupSendReceiveFolder(t)
+	defer wcfgCancel()
+
+	// Without anyone else pulling the file the order is kept.
+	if blocks := f.rarestFirst(file, file.Blocks); !slices.EqualFunc(blocks, file.Blocks, func(a, b protocol.BlockInfo) bool { return a.Offset == b.Offset }) {
+		t.Fatal("Expected unchanged order")
+	}
+
+	// device1 has the first half of the blocks in a temporary file already.
+	downloads := newDeviceDownloadState()
+	var have []int
+	for i := 0; i < rarestFirstMinBlocks/2; i++ {
+		have = append(have, i)
+	}
+	downloads.Update(f.ID, []protocol.FileDownloadProgressUpdate{{
+		UpdateType:   protocol.FileDownloadProgressUpdateTypeAppend,
+		Name:         file.Name,
+		Version:      file.Version,
+		BlockIndexes: have,
+	}})
+	m.mut.Lock()
+	m.deviceDownloads[device1] = downloads
+	m.mut.Unlock()
+
+	blocks := f.rarestFirst(file, file.Blocks)
+	for i, block := range blocks {
+		expected := file.Blocks[(i+rarestFirstMinBlocks/2)%rarestFirstMinBlocks]
+		if block.Offset != expected.Offset {
+			t.Errorf("Block %d has offset %d, expected %d", i, block.Offset, expected.Offset)
+		}
+	}
+}
+
 func TestCopierFinder(t *testing.T) {
 	// After diff between required and existing we should:
 	// Copy: 1, 2, 3, 4, 6, 7, 8
@@ -300,7 +345,7 @@ func TestCopierFinder(t *testing.T) {
 	}
 
 	// Verify that the fetched blocks have actually been written to the temp file
-	blks, err := scanner.HashFile(context.TODO(), f.ID, f.Filesystem(nil), tempFile, protocol.MinBlockSize, nil, false)
+	blks, err := scanner.HashFile(context.TODO(), f.ID, f.Filesystem(nil), tempFile, protocol.MinBlockSize, protocol.HashAlgorithmSHA256, nil, false)
 	if err != nil {
 		t.Log(err)
 	}
@@ -344,7 +389,7 @@ func TestWeakHash(t *testing.T) {
 	// File 1: abcdefgh
 	// File 2: xyabcdef
 	f.Seek(0, io.SeekStart)
-	existing, err := scanner.Blocks(context.TODO(), f, protocol.MinBlockSize, size, nil, true)
+	existing, err := scanner.Blocks(context.TODO(), f, protocol.MinBlockSize, size, protocol.HashAlgorithmSHA256, nil, true)
 	if err != nil {
 		t.Error(err)
 	}
@@ -353,7 +398,7 @@ func TestWeakHash(t *testing.T) {
 	remainder := io.LimitReader(f, size-shift)
 	prefix := io.LimitReader(rand.Reader, shift)
 	nf := io.MultiReader(prefix, remainder)
-	desired, err := scanner.Blocks(context.TODO(), nf, protocol.MinBlockSize, size, nil, true)
+	desired, err := scanner.Blocks(context.TODO(), nf, protocol.MinBlockSize, size, protocol.HashAlgorithmSHA256, nil, true)
 	if err != nil {
 		t.Error(err)
 	}
@@ -709,8 +754,8 @@ func TestIssue3164(t *testing.T) {
 
 func TestDiff(t *testing.T) {
 	for i, test := range diffTestData {
-		a, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.a), test.s, -1, nil, false)
-		b, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.b), test.s, -1, nil, false)
+		a, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.a), test.s, -1, protocol.HashAlgorithmSHA256, nil, false)
+		b, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.b), test.s, -1, protocol.HashAlgorithmSHA256, nil, false)
 		_, d := blockDiff(a, b)
 		if len(d) != len(test.d) {
 			t.Fatalf("Incorrect length for diff %d; %d != %d", i, len(d), len(test.d))
@@ -730,8 +775,8 @@ func TestDiff(t *testing.T) {
 func BenchmarkDiff(b *testing.B) {
 	testCases := make([]struct{ a, b []protocol.BlockInfo }, 0, len(diffTestData))
 	for _, test := range diffTestData {
-		a, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.a), test.s, -1, nil, false)
-		b, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.b), test.s, -1, nil, false)
+		a, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.a), test.s, -1, protocol.HashAlgorithmSHA256, nil, false)
+		b, _ := scanner.Blocks(context.TODO(), bytes.NewBufferString(test.b), test.s, -1, protocol.HashAlgorithmSHA256, nil, false)
 		testCases = append(testCases, struct{ a, b []protocol.BlockInfo }{a, b})
 	}
 	b.ReportAllocs()
@@ -107,6 +107,9 @@ type FolderSummary struct {
 	InSyncFiles int   `json:"inSyncFiles"`
 	InSyncBytes int64 `json:"inSyncBytes"`
 
+	Throughput float64 `json:"throughput"` // bytes per second
+	ETAS       int64   `json:"etaS"`       // seconds, -1 if unknown
+
 	State        string    `json:"state"`
 	StateChanged time.Time `json:"stateChanged"`
 	Error        string    `json:"error"`
@@ -181,6 +184,11 @@ func (c *folderSummaryService) Summary(folder string) (*FolderSummary, error) {
 
 	res.InSyncFiles, res.InSyncBytes = global.Files-need.Files, global.Bytes-need.Bytes
 
+	if throughput, err := c.model.Throughput(protocol.LocalDeviceID, folder); err == nil {
+		res.Throughput = throughput
+	}
+	res.ETAS = etaSeconds(res.NeedBytes, res.Throughput)
+
 	res.State, res.StateChanged, err = c.model.State(folder)
 	if err != nil {
 		res.Error = err.Error()
@@ -7,6 +7,8 @@
 package model
 
 import (
+	"context"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -191,3 +193,65 @@ func TestSetPlatformData(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestScanCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	must(t, os.MkdirAll(filepath.Join(dir, "a"), 0o755))
+	must(t, os.WriteFile(filepath.Join(dir, "a", "f1"), []byte("data"), 0o644))
+	must(t, os.WriteFile(filepath.Join(dir, "f0"), []byte("data"), 0o644))
+
+	w, wCancel := newConfigWrapper(defaultCfgWrapper.RawCopy())
+	defer wCancel()
+	fcfg := newFolderConfiguration(w, "default", "default", fs.FilesystemTypeBasic, dir)
+	fcfg.FSWatcherEnabled = true
+	fcfg.ScanCheckpoint = true
+	waiter, err := w.Modify(func(cfg *config.Configuration) {
+		cfg.SetFolder(fcfg)
+	})
+	must(t, err)
+	waiter.Wait()
+
+	// Initialise model, which does the initial scan, and stop it.
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+	m.cancel()
+	<-m.stopped
+	r, _ := m.folderRunners.Get(fcfg.ID)
+	f := r.(*sendReceiveFolder)
+	f.ctx = context.Background()
+
+	if f.fset.ScanCheckpoint() == nil {
+		t.Fatal("Expected a scan checkpoint to be stored")
+	}
+
+	// Change a/f1 in place, which doesn't change a, and replace f0 with f2.
+	must(t, os.WriteFile(filepath.Join(dir, "a", "f1"), []byte("changed"), 0o644))
+	must(t, os.Remove(filepath.Join(dir, "f0")))
+	must(t, os.WriteFile(filepath.Join(dir, "f2"), []byte("data"), 0o644))
+
+	// Pretend we restarted, so the initial scan uses the checkpoint.
+	f.initialScanFinished = make(chan struct{})
+	must(t, f.scanSubdirs(nil))
+
+	snap := dbSnapshot(t, m, fcfg.ID)
+	if fi, ok := snap.Get(protocol.LocalDeviceID, filepath.Join("a", "f1")); !ok || fi.IsDeleted() || fi.Size != 4 {
+		t.Errorf("Expected a/f1 to be unchanged, got %v", fi)
+	}
+	if fi, ok := snap.Get(protocol.LocalDeviceID, "f0"); !ok || !fi.IsDeleted() {
+		t.Errorf("Expected f0 to be deleted, got %v", fi)
+	}
+	if _, ok := snap.Get(protocol.LocalDeviceID, "f2"); !ok {
+		t.Error("Expected f2 to be scanned")
+	}
+	snap.Release()
+
+	// Later full scans look at everything.
+	close(f.initialScanFinished)
+	must(t, f.scanSubdirs(nil))
+
+	snap = dbSnapshot(t, m, fcfg.ID)
+	defer snap.Release()
+	if fi, ok := snap.Get(protocol.LocalDeviceID, filepath.Join("a", "f1")); !ok || fi.Size != 7 {
+		t.Errorf("Expected a/f1 to be changed, got %v", fi)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/db"
 	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/svcutil"
 	"github.com/syncthing/syncthing/lib/ur"
@@ -25,6 +26,7 @@ type indexHandler struct {
 	downloads                *deviceDownloadState
 	folder                   string
 	folderIsReceiveEncrypted bool
+	access                   config.FolderDeviceAccess
 	evLogger                 events.Logger
 
 	// We track the latest / highest sequence number in two ways for two
@@ -43,6 +45,19 @@ type indexHandler struct {
 	localPrevSequence int64 // the highest sequence number we've seen in our FileInfos
 	sentPrevSequence  int64 // the highest sequence number we've sent to the peer
 
+	// Index reconciliation state. If we have data from a previous index
+	// ID of the other device, we send it a summary of that data and
+	// record the new index ID once it has sent us what differs. If it has
+	// data from a previous index ID of ours, we wait for its summary on
+	// summaryRequests before sending anything.
+	reconcileIndexID protocol.IndexID
+	summaryPartition summaryPartition
+	summaryRequests  chan *protocol.IndexSummary
+
+	// The block hash algorithms the other device supports. Files hashed
+	// otherwise are announced as invalid, as it couldn't pull them.
+	hashAlgorithms []protocol.HashAlgorithm
+
 	cond   *sync.Cond
 	paused bool
 	fset   *db.FileSet
@@ -53,6 +68,9 @@ func newIndexHandler(conn protocol.Connection, downloads *deviceDownloadState, f
 	myIndexID := fset.IndexID(protocol.LocalDeviceID)
 	mySequence := fset.Sequence(protocol.LocalDeviceID)
 	var startSequence int64
+	var reconcileIndexID protocol.IndexID
+	var summaryRequests chan *protocol.IndexSummary
+	useSummaries := startInfo.remote.IndexSummaries && indexSummariesUsable(folder, conn.DeviceID())
 
 	// This is the other side's description of what it knows
 	// about us. Lets check to see if we can start sending index
@@ -82,6 +100,11 @@ func newIndexHandler(conn protocol.Connection, downloads *deviceDownloadState, f
 		// them. We'll start with a full index transfer.
 		l.Infof("Device %v folder %s has mismatching index ID for us (%v != %v)", conn.DeviceID().Short(), folder.Description(), startInfo.local.IndexID, myIndexID)
 		startSequence = 0
+		if useSummaries {
+			// They will send us a summary of what they have, so that we
+			// only need to send what differs.
+			summaryRequests = make(chan *protocol.IndexSummary, 1)
+		}
 	} else {
 		l.Debugf("Device %v folder %s has no index ID for us", conn.DeviceID().Short(), folder.Description())
 	}
@@ -99,6 +122,13 @@ func newIndexHandler(conn protocol.Connection, downloads *deviceDownloadState, f
 		// index, which will presumably be a full index.
 		l.Debugf("Device %v folder %s does not announce an index ID", conn.DeviceID().Short(), folder.Description())
 		fset.Drop(conn.DeviceID())
+	} else if startInfo.remote.IndexID != theirIndexID && theirIndexID != 0 && useSummaries {
+		// The index ID we have on file is not what they're announcing,
+		// but we can reconcile what we have with them instead of
+		// dropping it. We keep the old index ID until that is done, so
+		// that we try again if we get interrupted.
+		l.Infof("Device %v folder %s has a new index ID (%v), reconciling index", conn.DeviceID().Short(), folder.Description(), startInfo.remote.IndexID)
+		reconcileIndexID = startInfo.remote.IndexID
 	} else if startInfo.remote.IndexID != theirIndexID {
 		// The index ID we have on file is not what they're
 		// announcing. They must have reset their database and
@@ -110,13 +140,25 @@ func newIndexHandler(conn protocol.Connection, downloads *deviceDownloadState, f
 		fset.SetIndexID(conn.DeviceID(), startInfo.remote.IndexID)
 	}
 
+	access := folder.DeviceAccess(conn.DeviceID())
+	if !access.AcceptsIndex() {
+		// We don't accept changes from this device, so anything it told us
+		// previously must not linger in the global state either.
+		l.Debugf("Device %v folder %s is receive only, dropping its index data", conn.DeviceID().Short(), folder.Description())
+		fset.Drop(conn.DeviceID())
+	}
+
 	return &indexHandler{
 		conn:                     conn,
 		downloads:                downloads,
 		folder:                   folder.ID,
 		folderIsReceiveEncrypted: folder.Type == config.FolderTypeReceiveEncrypted,
+		access:                   access,
 		localPrevSequence:        startSequence,
 		sentPrevSequence:         startSequence,
+		reconcileIndexID:         reconcileIndexID,
+		summaryRequests:          summaryRequests,
+		hashAlgorithms:           startInfo.remote.HashAlgorithms,
 		evLogger:                 evLogger,
 
 		fset:   fset,
@@ -161,11 +203,27 @@ func (s *indexHandler) Serve(ctx context.Context) (err error) {
 		}
 	}()
 
+	if !s.access.ServesRequests() {
+		// The other side may only send to us, there's no point in telling
+		// it about files it will not be allowed to request.
+		l.Debugf("Not sending index for %s to send only device %s", s.folder, s.conn.DeviceID().Short())
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
 	// We need to send one index, regardless of whether there is something to send or not
 	fset, err := s.waitForFileset(ctx)
 	if err != nil {
 		return err
 	}
+	if err := s.sendSummaryRequest(ctx, fset); err != nil {
+		return err
+	}
+	if s.summaryRequests != nil {
+		if err := s.awaitSummaryRequest(ctx, fset); err != nil {
+			return err
+		}
+	}
 	err = s.sendIndexTo(ctx, fset)
 
 	// Subscribe to LocalIndexUpdated (we have new information to send) and
@@ -338,7 +396,7 @@ func (s *indexHandler) sendIndexTo(ctx context.Context, fset *db.FileSet) error
 			return true
 		}
 
-		f = prepareFileInfoForIndex(f)
+		f = s.invalidateUnsupported(prepareFileInfoForIndex(f))
 
 		previousWasDelete = f.IsDeleted()
 
@@ -377,12 +435,25 @@ func (s *indexHandler) receive(fs []protocol.FileInfo, update bool, op string, p
 		return fmt.Errorf("%v: %w", s.folder, ErrFolderPaused)
 	}
 
+	if !s.access.AcceptsIndex() {
+		l.Debugf("Ignoring %v for %s from receive only device %s (%d files)", op, s.folder, deviceID.Short(), len(fs))
+		return nil
+	}
+
 	defer runner.SchedulePull()
 
 	s.downloads.Update(s.folder, makeForgetUpdate(fs))
 
 	if !update {
 		fset.Drop(deviceID)
+		s.cond.L.Lock()
+		if s.reconcileIndexID != 0 {
+			// They sent a full index instead of reconciling.
+			fset.SetIndexID(deviceID, s.reconcileIndexID)
+			s.reconcileIndexID = 0
+			s.summaryPartition = nil
+		}
+		s.cond.L.Unlock()
 	}
 
 	l.Debugf("Received %d files for %s from %s, prevSeq=%d, lastSeq=%d", len(fs), s.folder, deviceID.Short(), prevSequence, lastSequence)
@@ -469,6 +540,182 @@ func (s *indexHandler) receive(fs []protocol.FileInfo, update bool, op string, p
 	return nil
 }
 
+// sendSummaryRequest sends a summary of the data we have from a previous
+// index ID of the other device, if any.
+func (s *indexHandler) sendSummaryRequest(ctx context.Context, fset *db.FileSet) error {
+	s.cond.L.Lock()
+	pending := s.reconcileIndexID != 0 && s.summaryPartition == nil
+	s.cond.L.Unlock()
+	if !pending {
+		return nil
+	}
+
+	snap, err := fset.Snapshot()
+	if err != nil {
+		return svcutil.AsFatalErr(err, svcutil.ExitError)
+	}
+	partition := choosePartition(snap, s.conn.DeviceID())
+	buckets := summarize(snap, s.conn.DeviceID(), partition)
+	snap.Release()
+
+	s.cond.L.Lock()
+	s.summaryPartition = partition
+	s.cond.L.Unlock()
+
+	l.Debugf("%v: Sending index summary request with %d buckets", s, len(buckets))
+	return s.conn.IndexSummary(ctx, &protocol.IndexSummary{
+		Folder:  s.folder,
+		Type:    protocol.IndexSummaryTypeRequest,
+		Buckets: summaryBucketsToWire(buckets),
+	})
+}
+
+// awaitSummaryRequest waits for the other side to send a summary of the
+// data it has from a previous index ID of ours and sends what differs. If
+// no summary arrives in time we fall back to sending the full index.
+func (s *indexHandler) awaitSummaryRequest(ctx context.Context, fset *db.FileSet) error {
+	timer := time.NewTimer(indexSummaryTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		l.Infof("Device %v folder %s did not send an index summary, sending full index", s.conn.DeviceID().Short(), s.folder)
+		return nil
+	case req := <-s.summaryRequests:
+		return s.sendReconciliation(ctx, fset, req)
+	}
+}
+
+func (s *indexHandler) sendReconciliation(ctx context.Context, fset *db.FileSet, req *protocol.IndexSummary) error {
+	snap, err := fset.Snapshot()
+	if err != nil {
+		return svcutil.AsFatalErr(err, svcutil.ExitError)
+	}
+	defer snap.Release()
+
+	partition := newSummaryPartition(req.Buckets)
+	diff := differingBuckets(req.Buckets, summarize(snap, protocol.LocalDeviceID, partition))
+	l.Infof("Device %v folder %s is reconciling its index, %d of %d buckets differ", s.conn.DeviceID().Short(), s.folder, len(diff), len(req.Buckets))
+	if err := s.conn.IndexSummary(ctx, &protocol.IndexSummary{
+		Folder:  s.folder,
+		Type:    protocol.IndexSummaryTypeDifferences,
+		Buckets: diff,
+	}); err != nil {
+		return err
+	}
+
+	// Send the files in the differing buckets as plain index updates. The
+	// other side has dropped what it had in those buckets. The sequence
+	// checks don't apply as we're not sending a contiguous range.
+	differing := newSummaryPartition(diff)
+	batch := db.NewFileInfoBatch(func(fs []protocol.FileInfo) error {
+		l.Debugf("%v: Sending %d reconciled files", s, len(fs))
+		return s.conn.IndexUpdate(ctx, &protocol.IndexUpdate{
+			Folder: s.folder,
+			Files:  fs,
+		})
+	})
+	snap.WithHaveSequence(1, func(fi protocol.FileIntf) bool {
+		if _, ok := differing[partition.bucketOf(osutil.NormalizedFilename(fi.FileName()))]; !ok {
+			return true
+		}
+		if err = batch.FlushIfFull(); err != nil {
+			return false
+		}
+		batch.Append(s.invalidateUnsupported(prepareFileInfoForIndex(fi.(protocol.FileInfo))))
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if err := batch.Flush(); err != nil {
+		return err
+	}
+
+	seq := snap.Sequence(protocol.LocalDeviceID)
+	if err := s.conn.IndexSummary(ctx, &protocol.IndexSummary{
+		Folder:       s.folder,
+		Type:         protocol.IndexSummaryTypeComplete,
+		LastSequence: seq,
+	}); err != nil {
+		return err
+	}
+	s.localPrevSequence = seq
+	s.sentPrevSequence = seq
+	return nil
+}
+
+// receiveSummary handles an index summary from the other device, as part
+// of reconciling either our or their index.
+func (s *indexHandler) receiveSummary(summary *protocol.IndexSummary) error {
+	deviceID := s.conn.DeviceID()
+
+	s.cond.L.Lock()
+	paused := s.paused
+	fset := s.fset
+	runner := s.runner
+	reconcileIndexID := s.reconcileIndexID
+	partition := s.summaryPartition
+	s.cond.L.Unlock()
+
+	if paused {
+		l.Infof("Index summary for paused folder %q", s.folder)
+		return fmt.Errorf("%v: %w", s.folder, ErrFolderPaused)
+	}
+
+	switch summary.Type {
+	case protocol.IndexSummaryTypeRequest:
+		if s.summaryRequests == nil {
+			l.Debugf("%v: Ignoring unexpected index summary request", s)
+			return nil
+		}
+		select {
+		case s.summaryRequests <- summary:
+		default:
+			l.Debugf("%v: Ignoring duplicate index summary request", s)
+		}
+
+	case protocol.IndexSummaryTypeDifferences:
+		if partition == nil {
+			l.Debugf("%v: Ignoring unexpected index summary differences", s)
+			return nil
+		}
+		differing := newSummaryPartition(summary.Buckets)
+		var names []string
+		snap, err := fset.Snapshot()
+		if err != nil {
+			return svcutil.AsFatalErr(err, svcutil.ExitError)
+		}
+		snap.WithHaveTruncated(deviceID, func(f protocol.FileIntf) bool {
+			if _, ok := differing[partition.bucketOf(osutil.NormalizedFilename(f.FileName()))]; ok {
+				names = append(names, f.FileName())
+			}
+			return true
+		})
+		snap.Release()
+		l.Infof("Reconciling index for device %v folder %s, replacing %d files in %d buckets", deviceID.Short(), s.folder, len(names), len(summary.Buckets))
+		fset.RemoveRemoteItems(deviceID, names)
+		runner.SchedulePull()
+
+	case protocol.IndexSummaryTypeComplete:
+		if reconcileIndexID == 0 {
+			l.Debugf("%v: Ignoring unexpected index summary completion", s)
+			return nil
+		}
+		fset.SetIndexID(deviceID, reconcileIndexID)
+		fset.SetSequence(deviceID, summary.LastSequence)
+		s.cond.L.Lock()
+		s.reconcileIndexID = 0
+		s.summaryPartition = nil
+		s.cond.L.Unlock()
+		l.Infof("Reconciled index for device %v folder %s", deviceID.Short(), s.folder)
+	}
+
+	return nil
+}
+
 func (s *indexHandler) logSequenceAnomaly(msg string, extra map[string]any) {
 	extraStrs := make(map[string]string, len(extra))
 	for k, v := range extra {
@@ -501,6 +748,19 @@ func prepareFileInfoForIndex(f protocol.FileInfo) protocol.FileInfo {
 	return f
 }
 
+// invalidateUnsupported marks the file as invalid and drops its blocks if
+// they are hashed with an algorithm the other device doesn't support.
+func (s *indexHandler) invalidateUnsupported(f protocol.FileInfo) protocol.FileInfo {
+	if protocol.SupportsHashAlgorithm(s.hashAlgorithms, f.BlockHashAlgorithm) {
+		return f
+	}
+	f.RawInvalid = true
+	f.Blocks = nil
+	f.BlocksHash = nil
+	f.BlockHashAlgorithm = protocol.HashAlgorithmSHA256
+	return f
+}
+
 func (s *indexHandler) String() string {
 	return fmt.Sprintf("indexHandler@%p for %s to %s at %s", s, s.folder, s.conn.DeviceID().Short(), s.conn)
 }
@@ -678,6 +938,17 @@ func (r *indexHandlerRegistry) ReceiveIndex(folder string, fs []protocol.FileInf
 	return is.receive(fs, update, op, prevSequence, lastSequence)
 }
 
+func (r *indexHandlerRegistry) ReceiveIndexSummary(folder string, summary *protocol.IndexSummary) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	is, isOk := r.indexHandlers.Get(folder)
+	if !isOk {
+		l.Infof("Index summary for nonexistent or paused folder %q", folder)
+		return fmt.Errorf("%s: %w", folder, ErrFolderMissing)
+	}
+	return is.receiveSummary(summary)
+}
+
 // makeForgetUpdate takes an index update and constructs a download progress update
 // causing to forget any progress for files which we've just been sent.
 func makeForgetUpdate(files []protocol.FileInfo) []protocol.FileDownloadProgressUpdate {
@@ -0,0 +1,225 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/osutil"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+const (
+	// Buckets with more files than this are split into their
+	// subdirectories, down to maxSummaryDepth levels.
+	maxSummaryBucketFiles = 10000
+	maxSummaryDepth       = 8
+
+	// How long we wait for the other side to send its summary request
+	// before falling back to sending a full index.
+	indexSummaryTimeout = 5 * time.Minute
+)
+
+// indexSummariesUsable returns whether index reconciliation can be used
+// for the given folder and device. The summaries contain file names and
+// are thus never used with untrusted devices or encrypted folders.
+func indexSummariesUsable(folder config.FolderConfiguration, device protocol.DeviceID) bool {
+	if folder.Type == config.FolderTypeReceiveEncrypted {
+		return false
+	}
+	dev, ok := folder.Device(device)
+	return ok && dev.EncryptionPassword == "" && dev.Access.ServesRequests() && dev.Access.AcceptsIndex()
+}
+
+// A summaryPartition is the set of prefixes splitting the files of a
+// folder into disjoint buckets. A file belongs to the bucket with the
+// longest prefix that is either its name or one of its parent
+// directories, or to the implicit bucket with the empty prefix.
+type summaryPartition map[string]struct{}
+
+func newSummaryPartition(buckets []protocol.IndexSummaryBucket) summaryPartition {
+	p := make(summaryPartition, len(buckets))
+	for _, b := range buckets {
+		p[b.Prefix] = struct{}{}
+	}
+	return p
+}
+
+// bucketOf returns the prefix of the bucket the given name belongs to. The
+// name is expected in wire format.
+func (p summaryPartition) bucketOf(name string) string {
+	for name != "." && name != "/" && name != "" {
+		if _, ok := p[name]; ok {
+			return name
+		}
+		name = path.Dir(name)
+	}
+	return ""
+}
+
+// summaryBucket accumulates an order independent hash over the files in
+// a bucket.
+type summaryBucket struct {
+	files int64
+	hash  [sha256.Size]byte
+}
+
+func (b *summaryBucket) add(f protocol.FileIntf) {
+	h := summaryFileHash(f)
+	for i := range b.hash {
+		b.hash[i] ^= h[i]
+	}
+	b.files++
+}
+
+// summaryFileHash hashes the attributes of a file that matter for
+// synchronisation, as they are sent over the wire. Both sides must arrive
+// at the same hash for the same announced file.
+func summaryFileHash(f protocol.FileIntf) [sha256.Size]byte {
+	h := sha256.New()
+	var buf [8]byte
+	putInt := func(v int64) {
+		binary.BigEndian.PutUint64(buf[:], uint64(v))
+		h.Write(buf[:])
+	}
+	putBool := func(v bool) {
+		if v {
+			putInt(1)
+		} else {
+			putInt(0)
+		}
+	}
+
+	name := osutil.NormalizedFilename(f.FileName())
+	putInt(int64(len(name)))
+	h.Write([]byte(name))
+	putInt(int64(f.FileType()))
+	putInt(f.FileSize())
+	putInt(f.ModTime().UnixNano())
+	putInt(int64(f.FileModifiedBy()))
+	putInt(int64(f.FilePermissions()))
+	putBool(f.IsDeleted())
+	putBool(f.IsInvalid())
+	putBool(!f.HasPermissionBits())
+	if !f.IsReceiveOnlyChanged() {
+		// Receive only changed files are announced without a version,
+		// c.f. prepareFileInfoForIndex.
+		for _, c := range f.FileVersion().Counters {
+			putInt(int64(c.ID))
+			putInt(int64(c.Value))
+		}
+	}
+	blocksHash := f.FileBlocksHash()
+	putInt(int64(len(blocksHash)))
+	h.Write(blocksHash)
+
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	return sum
+}
+
+// choosePartition looks at the names of the files we have for the given
+// device and splits them into buckets of a reasonable size.
+func choosePartition(snap *db.Snapshot, device protocol.DeviceID) summaryPartition {
+	// Count the files below each directory, down to the maximum depth.
+	counts := make(map[string]int)
+	children := make(map[string][]string)
+	snap.WithHaveTruncated(device, func(f protocol.FileIntf) bool {
+		dirs := strings.Split(osutil.NormalizedFilename(f.FileName()), "/")
+		dirs = dirs[:len(dirs)-1]
+		if len(dirs) > maxSummaryDepth {
+			dirs = dirs[:maxSummaryDepth]
+		}
+		parent := ""
+		for i := range dirs {
+			dir := strings.Join(dirs[:i+1], "/")
+			if counts[dir] == 0 {
+				children[parent] = append(children[parent], dir)
+			}
+			counts[dir]++
+			parent = dir
+		}
+		return true
+	})
+
+	// Split top down until each bucket is small enough or can't be split
+	// any further. Files directly in a directory that was split stay in
+	// the bucket of that directory.
+	partition := make(summaryPartition)
+	queue := children[""]
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+		partition[dir] = struct{}{}
+		if counts[dir] > maxSummaryBucketFiles {
+			queue = append(queue, children[dir]...)
+		}
+	}
+	return partition
+}
+
+// summarize computes the bucket hashes for the files we have for the given
+// device, split according to the partition.
+func summarize(snap *db.Snapshot, device protocol.DeviceID, partition summaryPartition) map[string]*summaryBucket {
+	buckets := make(map[string]*summaryBucket, len(partition)+1)
+	buckets[""] = &summaryBucket{}
+	for prefix := range partition {
+		buckets[prefix] = &summaryBucket{}
+	}
+	snap.WithHaveTruncated(device, func(f protocol.FileIntf) bool {
+		buckets[partition.bucketOf(osutil.NormalizedFilename(f.FileName()))].add(f)
+		return true
+	})
+	return buckets
+}
+
+func summaryBucketsToWire(buckets map[string]*summaryBucket) []protocol.IndexSummaryBucket {
+	res := make([]protocol.IndexSummaryBucket, 0, len(buckets))
+	for prefix, b := range buckets {
+		res = append(res, protocol.IndexSummaryBucket{
+			Prefix: prefix,
+			Files:  b.files,
+			Hash:   b.hash[:],
+		})
+	}
+	sort.Slice(res, func(a, b int) bool {
+		return res[a].Prefix < res[b].Prefix
+	})
+	return res
+}
+
+// differingBuckets returns the buckets from the remote summary whose
+// contents differ from ours.
+func differingBuckets(remote []protocol.IndexSummaryBucket, local map[string]*summaryBucket) []protocol.IndexSummaryBucket {
+	var diff []protocol.IndexSummaryBucket
+	seen := make(map[string]struct{}, len(remote))
+	for _, rb := range remote {
+		seen[rb.Prefix] = struct{}{}
+		lb, ok := local[rb.Prefix]
+		if !ok {
+			lb = &summaryBucket{}
+		}
+		if lb.files != rb.Files || string(lb.hash[:]) != string(rb.Hash) {
+			diff = append(diff, protocol.IndexSummaryBucket{Prefix: rb.Prefix})
+		}
+	}
+	if _, ok := seen[""]; !ok {
+		// The root bucket is always part of the partition, even if the
+		// other side didn't mention it.
+		if lb := local[""]; lb != nil && lb.files > 0 {
+			diff = append(diff, protocol.IndexSummaryBucket{Prefix: ""})
+		}
+	}
+	return diff
+}
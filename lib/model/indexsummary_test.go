@@ -0,0 +1,95 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/db/backend"
+	"github.com/syncthing/syncthing/lib/events"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestSummaryPartitionBucketOf(t *testing.T) {
+	p := summaryPartition{
+		"a":     {},
+		"a/b":   {},
+		"c":     {},
+		"d/e/f": {},
+	}
+	cases := map[string]string{
+		"a":         "a",
+		"a/x":       "a",
+		"a/b":       "a/b",
+		"a/b/c/d":   "a/b",
+		"ab":        "",
+		"c/file":    "c",
+		"d/e/file":  "",
+		"d/e/f/g/h": "d/e/f",
+		"toplevel":  "",
+	}
+	for name, exp := range cases {
+		if got := p.bucketOf(name); got != exp {
+			t.Errorf("bucketOf(%q) == %q, expected %q", name, got, exp)
+		}
+	}
+}
+
+func TestIndexSummaryDifferences(t *testing.T) {
+	ldb, err := db.NewLowlevel(backend.OpenMemory(), events.NoopLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ldb.Close()
+
+	// The sending side has its own files, the receiving side has an older
+	// copy of them from a previous index ID.
+	ours := newFileSet(t, "ours", ldb)
+	theirs := newFileSet(t, "theirs", ldb)
+
+	v1 := protocol.Vector{}.Update(myID.Short())
+	files := []protocol.FileInfo{
+		{Name: "dir1", Type: protocol.FileInfoTypeDirectory, Version: v1},
+		{Name: "dir1/a", Size: 1, Version: v1, Blocks: []protocol.BlockInfo{{Size: 1, Hash: []byte{1}}}},
+		{Name: "dir2", Type: protocol.FileInfoTypeDirectory, Version: v1},
+		{Name: "dir2/b", Size: 2, Version: v1, Blocks: []protocol.BlockInfo{{Size: 2, Hash: []byte{2}}}},
+		{Name: "dir3", Type: protocol.FileInfoTypeDirectory, Version: v1},
+		{Name: "dir3/c", Size: 3, Version: v1, Blocks: []protocol.BlockInfo{{Size: 3, Hash: []byte{3}}}},
+		{Name: "top", Size: 4, Version: v1, Blocks: []protocol.BlockInfo{{Size: 4, Hash: []byte{4}}}},
+	}
+	for i := range files {
+		files[i].Sequence = int64(i + 1)
+		files[i].BlocksHash = protocol.BlocksHash(files[i].Blocks)
+	}
+	theirs.Update(device1, files)
+
+	changed := make([]protocol.FileInfo, len(files))
+	copy(changed, files)
+	changed[3].Version = protocol.Vector{}.Update(myID.Short()).Update(myID.Short())
+	changed[3].Size = 5
+	changed = append(changed, protocol.FileInfo{Name: "dir3/d", Version: v1})
+	ours.Update(protocol.LocalDeviceID, changed)
+
+	theirSnap := fsetSnapshot(t, theirs)
+	defer theirSnap.Release()
+	partition := choosePartition(theirSnap, device1)
+	for _, dir := range []string{"dir1", "dir2", "dir3"} {
+		if _, ok := partition[dir]; !ok {
+			t.Errorf("Expected %v to be a bucket", dir)
+		}
+	}
+	request := summaryBucketsToWire(summarize(theirSnap, device1, partition))
+
+	ourSnap := fsetSnapshot(t, ours)
+	defer ourSnap.Release()
+	diff := differingBuckets(request, summarize(ourSnap, protocol.LocalDeviceID, newSummaryPartition(request)))
+
+	if len(diff) != 2 || diff[0].Prefix != "dir2" || diff[1].Prefix != "dir3" {
+		t.Errorf("Expected dir2 and dir3 to differ, got %v", diff)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// A keyRotation tracks a receive encrypted folder moving from data
+// encrypted with a previous password to data encrypted with the current
+// one. The trusted devices announce both tokens after the password was
+// changed. We then take the current token, request full indexes and pull
+// everything anew, while keeping the data encrypted with the previous
+// password. Once all devices that announced the current token since have
+// sent their complete index and the folder is in sync, the stale data is
+// removed.
+type keyRotation struct {
+	previousToken []byte
+	sequences     map[protocol.DeviceID]int64 // device -> max sequence announced in the last cluster config
+}
+
+func newKeyRotation(previousToken []byte) *keyRotation {
+	return &keyRotation{
+		previousToken: previousToken,
+		sequences:     make(map[protocol.DeviceID]int64),
+	}
+}
+
+func (m *model) startKeyRotation(fcfg config.FolderConfiguration, token, previousToken []byte) error {
+	if err := writeEncryptionToken(storedEncryptionToken{
+		FolderID:      fcfg.ID,
+		Token:         token,
+		PreviousToken: previousToken,
+	}, fcfg); err != nil {
+		return err
+	}
+
+	m.mut.Lock()
+	m.folderEncryptionPasswordTokens[fcfg.ID] = token
+	m.folderKeyRotations[fcfg.ID] = newKeyRotation(previousToken)
+	fset := m.folderFiles[fcfg.ID]
+	m.mut.Unlock()
+
+	l.Infof("Encryption password for folder %s changed on another device, re-syncing; existing data is kept until that is complete", fcfg.Description())
+
+	// Whatever we know about the other devices refers to names encrypted
+	// with the previous password. Forget about it and announce that we
+	// have no index data, so that they send us everything again.
+	if fset != nil {
+		for _, dev := range fcfg.DeviceIDs() {
+			if dev == m.id {
+				continue
+			}
+			fset.Drop(dev)
+			fset.SetIndexID(dev, 0)
+		}
+	}
+	m.sendClusterConfig(fcfg.DeviceIDs())
+
+	return nil
+}
+
+// keyRotationProgress records the sequence the given device announced for
+// the folder, if a key rotation is in progress.
+func (m *model) keyRotationProgress(folder string, device protocol.Device) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	if rot, ok := m.folderKeyRotations[folder]; ok {
+		rot.sequences[device.ID] = device.MaxSequence
+	}
+}
+
+// keyRotationIndexComplete returns true if a key rotation is in progress
+// and we have received everything the devices announced since it started.
+func (m *model) keyRotationIndexComplete(folder string) bool {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	rot, ok := m.folderKeyRotations[folder]
+	if !ok || len(rot.sequences) == 0 {
+		return false
+	}
+	fset, ok := m.folderFiles[folder]
+	if !ok {
+		return false
+	}
+	for dev, seq := range rot.sequences {
+		if fset.Sequence(dev) < seq {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *model) finishKeyRotation(fcfg config.FolderConfiguration) error {
+	m.mut.RLock()
+	token := m.folderEncryptionPasswordTokens[fcfg.ID]
+	m.mut.RUnlock()
+
+	if err := writeEncryptionToken(storedEncryptionToken{
+		FolderID: fcfg.ID,
+		Token:    token,
+	}, fcfg); err != nil {
+		return err
+	}
+
+	m.mut.Lock()
+	delete(m.folderKeyRotations, fcfg.ID)
+	m.mut.Unlock()
+
+	l.Infof("Finished re-syncing folder %s with the changed encryption password", fcfg.Description())
+	return nil
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestCcCheckEncryptionPreviousPassword(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping on short testing - generating encryption tokens is slow")
+	}
+
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	dcfg := config.FolderDeviceConfiguration{
+		DeviceID:                   device1,
+		EncryptionPassword:         "new",
+		PreviousEncryptionPassword: "old",
+	}
+	fcfg.Devices = []config.FolderDeviceConfiguration{{DeviceID: myID}, dcfg}
+	setFolder(t, w, fcfg)
+	m := setupModel(t, w)
+	m.cancel()
+	defer cleanupModel(m)
+
+	check := func(token string, expected error) {
+		t.Helper()
+		deviceInfos := &clusterConfigDeviceInfo{
+			remote: protocol.Device{ID: device1, EncryptionPasswordToken: protocol.PasswordToken(m.keyGen, fcfg.ID, token)},
+			local:  protocol.Device{ID: myID},
+		}
+		if err := m.ccCheckEncryption(fcfg, dcfg, deviceInfos, true); err != expected {
+			t.Errorf("Token for %q: expected error %v, got %v", token, expected, err)
+		}
+	}
+	announcesPrevious := func() bool {
+		cc, _ := m.generateClusterConfig(device1)
+		for _, dev := range cc.Folders[0].Devices {
+			if dev.ID == device1 {
+				return len(dev.PreviousEncryptionPasswordToken) > 0
+			}
+		}
+		return false
+	}
+
+	check("old", nil)
+	check("other", errEncryptionPassword)
+	if !announcesPrevious() {
+		t.Error("Expected previous token to be announced")
+	}
+
+	// Once the other device uses the current password, the previous one
+	// is forgotten.
+	check("new", nil)
+	for _, dev := range w.FolderList()[0].Devices {
+		if dev.DeviceID == device1 && dev.PreviousEncryptionPassword != "" {
+			t.Error("Expected previous password to be cleared")
+		}
+	}
+	if announcesPrevious() {
+		t.Error("Expected previous token not to be announced anymore")
+	}
+}
+
+func TestReceiveEncryptedKeyRotation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping on short testing - generating encryption tokens is slow")
+	}
+
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	tfs := fcfg.Filesystem(nil)
+	fcfg.Type = config.FolderTypeReceiveEncrypted
+	setFolder(t, w, fcfg)
+
+	keyGen := protocol.NewKeyGenerator()
+	oldToken := protocol.PasswordToken(keyGen, fcfg.ID, "old")
+	newToken := protocol.PasswordToken(keyGen, fcfg.ID, "new")
+	must(t, writeEncryptionToken(storedEncryptionToken{FolderID: fcfg.ID, Token: oldToken}, fcfg))
+
+	m := setupModel(t, w)
+	defer cleanupModelAndRemoveDir(m, tfs.URI())
+	m.mut.RLock()
+	fset := m.folderFiles[fcfg.ID]
+	m.mut.RUnlock()
+
+	// What we have, pulled from device1 while it used the old password.
+	version := protocol.Vector{}.Update(device1.Short())
+	stale := protocol.FileInfo{Name: "stale", Version: version, Sequence: 1}
+	writeFile(t, tfs, stale.Name, nil)
+	fset.Update(protocol.LocalDeviceID, []protocol.FileInfo{stale})
+	fset.Update(device1, []protocol.FileInfo{stale})
+
+	// Device1 now announces a new password, with the old one as previous.
+	deviceInfos := &clusterConfigDeviceInfo{
+		remote: protocol.Device{ID: device1, MaxSequence: 1},
+		local:  protocol.Device{ID: myID, EncryptionPasswordToken: newToken, PreviousEncryptionPasswordToken: oldToken},
+	}
+	dcfg, _ := fcfg.Device(device1)
+	must(t, m.ccCheckEncryption(fcfg, dcfg, deviceInfos, false))
+
+	stored, err := readEncryptionToken(fcfg)
+	must(t, err)
+	if !bytes.Equal(stored.Token, newToken) || !bytes.Equal(stored.PreviousToken, oldToken) {
+		t.Fatal("Expected stored token to be rotated")
+	}
+	if id := fset.IndexID(device1); id != 0 {
+		t.Error("Expected index ID to be reset, got", id)
+	}
+	if m.keyRotationIndexComplete(fcfg.ID) {
+		t.Error("Expected rotation to wait for the index")
+	}
+
+	// Devices still using the old password are refused.
+	oldInfos := &clusterConfigDeviceInfo{
+		remote: protocol.Device{ID: device2},
+		local:  protocol.Device{ID: myID, EncryptionPasswordToken: oldToken},
+	}
+	if err := m.ccCheckEncryption(fcfg, dcfg, oldInfos, false); err != errEncryptionPassword {
+		t.Errorf("Expected error %v, got %v", errEncryptionPassword, err)
+	}
+
+	// The new index arrives, and we have pulled everything.
+	current := protocol.FileInfo{Name: "current", Version: version, Sequence: 1}
+	writeFile(t, tfs, current.Name, nil)
+	fset.Update(device1, []protocol.FileInfo{current})
+	fset.Update(protocol.LocalDeviceID, []protocol.FileInfo{current})
+	if !m.keyRotationIndexComplete(fcfg.ID) {
+		t.Fatal("Expected rotation index to be complete")
+	}
+
+	snap, err := fset.Snapshot()
+	must(t, err)
+	seq := snap.Sequence(protocol.LocalDeviceID)
+	snap.Release()
+
+	r, _ := m.folderRunners.Get(fcfg.ID)
+	f := r.(*receiveEncryptedFolder)
+	must(t, f.doInSync(func() error {
+		f.inSync()
+		return nil
+	}))
+
+	if _, err := tfs.Lstat(stale.Name); err == nil {
+		t.Error("Expected stale file to be removed")
+	}
+	if _, err := tfs.Lstat(current.Name); err != nil {
+		t.Error("Expected current file to be kept:", err)
+	}
+	if fi, ok, err := m.CurrentFolderFile(fcfg.ID, stale.Name); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Errorf("Expected stale file to be dropped from the database, got %v", fi)
+	}
+	snap, err = fset.Snapshot()
+	must(t, err)
+	if newSeq := snap.Sequence(protocol.LocalDeviceID); newSeq != seq {
+		t.Errorf("Expected nothing new in the index, sequence went from %v to %v", seq, newSeq)
+	}
+	snap.Release()
+
+	stored, err = readEncryptionToken(fcfg)
+	must(t, err)
+	if !bytes.Equal(stored.Token, newToken) || len(stored.PreviousToken) != 0 {
+		t.Error("Expected rotation to be finished")
+	}
+	m.mut.RLock()
+	_, rotating := m.folderKeyRotations[fcfg.ID]
+	m.mut.RUnlock()
+	if rotating {
+		t.Error("Expected rotation state to be removed")
+	}
+}
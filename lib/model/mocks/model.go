@@ -301,6 +301,18 @@ type Model struct {
 	indexReturnsOnCall map[int]struct {
 		result1 error
 	}
+	IndexSummaryStub        func(protocol.Connection, *protocol.IndexSummary) error
+	indexSummaryMutex       sync.RWMutex
+	indexSummaryArgsForCall []struct {
+		arg1 protocol.Connection
+		arg2 *protocol.IndexSummary
+	}
+	indexSummaryReturns struct {
+		result1 error
+	}
+	indexSummaryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IndexUpdateStub        func(protocol.Connection, *protocol.IndexUpdate) error
 	indexUpdateMutex       sync.RWMutex
 	indexUpdateArgsForCall []struct {
@@ -435,7 +447,7 @@ type Model struct {
 		result1 protocol.RequestResponse
 		result2 error
 	}
-	RequestGlobalStub        func(context.Context, protocol.DeviceID, string, string, int, int64, int, []byte, uint32, bool) ([]byte, error)
+	RequestGlobalStub        func(context.Context, protocol.DeviceID, string, string, int, int64, int, []byte, uint32, protocol.HashAlgorithm, bool) ([]byte, error)
 	requestGlobalMutex       sync.RWMutex
 	requestGlobalArgsForCall []struct {
 		arg1  context.Context
@@ -447,7 +459,8 @@ type Model struct {
 		arg7  int
 		arg8  []byte
 		arg9  uint32
-		arg10 bool
+		arg10 protocol.HashAlgorithm
+		arg11 bool
 	}
 	requestGlobalReturns struct {
 		result1 []byte
@@ -558,6 +571,34 @@ type Model struct {
 		result2 time.Time
 		result3 error
 	}
+	ThroughputStub        func(protocol.DeviceID, string) (float64, error)
+	throughputMutex       sync.RWMutex
+	throughputArgsForCall []struct {
+		arg1 protocol.DeviceID
+		arg2 string
+	}
+	throughputReturns struct {
+		result1 float64
+		result2 error
+	}
+	throughputReturnsOnCall map[int]struct {
+		result1 float64
+		result2 error
+	}
+	ThroughputHistoryStub        func(protocol.DeviceID, string) (model.ThroughputHistory, error)
+	throughputHistoryMutex       sync.RWMutex
+	throughputHistoryArgsForCall []struct {
+		arg1 protocol.DeviceID
+		arg2 string
+	}
+	throughputHistoryReturns struct {
+		result1 model.ThroughputHistory
+		result2 error
+	}
+	throughputHistoryReturnsOnCall map[int]struct {
+		result1 model.ThroughputHistory
+		result2 error
+	}
 	UsageReportingStatsStub        func(*contract.Report, int, bool)
 	usageReportingStatsMutex       sync.RWMutex
 	usageReportingStatsArgsForCall []struct {
@@ -1966,6 +2007,68 @@ func (fake *Model) IndexReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Model) IndexSummary(arg1 protocol.Connection, arg2 *protocol.IndexSummary) error {
+	fake.indexSummaryMutex.Lock()
+	ret, specificReturn := fake.indexSummaryReturnsOnCall[len(fake.indexSummaryArgsForCall)]
+	fake.indexSummaryArgsForCall = append(fake.indexSummaryArgsForCall, struct {
+		arg1 protocol.Connection
+		arg2 *protocol.IndexSummary
+	}{arg1, arg2})
+	stub := fake.IndexSummaryStub
+	fakeReturns := fake.indexSummaryReturns
+	fake.recordInvocation("IndexSummary", []interface{}{arg1, arg2})
+	fake.indexSummaryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Model) IndexSummaryCallCount() int {
+	fake.indexSummaryMutex.RLock()
+	defer fake.indexSummaryMutex.RUnlock()
+	return len(fake.indexSummaryArgsForCall)
+}
+
+func (fake *Model) IndexSummaryCalls(stub func(protocol.Connection, *protocol.IndexSummary) error) {
+	fake.indexSummaryMutex.Lock()
+	defer fake.indexSummaryMutex.Unlock()
+	fake.IndexSummaryStub = stub
+}
+
+func (fake *Model) IndexSummaryArgsForCall(i int) (protocol.Connection, *protocol.IndexSummary) {
+	fake.indexSummaryMutex.RLock()
+	defer fake.indexSummaryMutex.RUnlock()
+	argsForCall := fake.indexSummaryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) IndexSummaryReturns(result1 error) {
+	fake.indexSummaryMutex.Lock()
+	defer fake.indexSummaryMutex.Unlock()
+	fake.IndexSummaryStub = nil
+	fake.indexSummaryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Model) IndexSummaryReturnsOnCall(i int, result1 error) {
+	fake.indexSummaryMutex.Lock()
+	defer fake.indexSummaryMutex.Unlock()
+	fake.IndexSummaryStub = nil
+	if fake.indexSummaryReturnsOnCall == nil {
+		fake.indexSummaryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.indexSummaryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Model) IndexUpdate(arg1 protocol.Connection, arg2 *protocol.IndexUpdate) error {
 	fake.indexUpdateMutex.Lock()
 	ret, specificReturn := fake.indexUpdateReturnsOnCall[len(fake.indexUpdateArgsForCall)]
@@ -2580,7 +2683,7 @@ func (fake *Model) RequestReturnsOnCall(i int, result1 protocol.RequestResponse,
 	}{result1, result2}
 }
 
-func (fake *Model) RequestGlobal(arg1 context.Context, arg2 protocol.DeviceID, arg3 string, arg4 string, arg5 int, arg6 int64, arg7 int, arg8 []byte, arg9 uint32, arg10 bool) ([]byte, error) {
+func (fake *Model) RequestGlobal(arg1 context.Context, arg2 protocol.DeviceID, arg3 string, arg4 string, arg5 int, arg6 int64, arg7 int, arg8 []byte, arg9 uint32, arg10 protocol.HashAlgorithm, arg11 bool) ([]byte, error) {
 	var arg8Copy []byte
 	if arg8 != nil {
 		arg8Copy = make([]byte, len(arg8))
@@ -2598,14 +2701,15 @@ func (fake *Model) RequestGlobal(arg1 context.Context, arg2 protocol.DeviceID, a
 		arg7  int
 		arg8  []byte
 		arg9  uint32
-		arg10 bool
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8Copy, arg9, arg10})
+		arg10 protocol.HashAlgorithm
+		arg11 bool
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8Copy, arg9, arg10, arg11})
 	stub := fake.RequestGlobalStub
 	fakeReturns := fake.requestGlobalReturns
-	fake.recordInvocation("RequestGlobal", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8Copy, arg9, arg10})
+	fake.recordInvocation("RequestGlobal", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8Copy, arg9, arg10, arg11})
 	fake.requestGlobalMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10, arg11)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -2619,17 +2723,17 @@ func (fake *Model) RequestGlobalCallCount() int {
 	return len(fake.requestGlobalArgsForCall)
 }
 
-func (fake *Model) RequestGlobalCalls(stub func(context.Context, protocol.DeviceID, string, string, int, int64, int, []byte, uint32, bool) ([]byte, error)) {
+func (fake *Model) RequestGlobalCalls(stub func(context.Context, protocol.DeviceID, string, string, int, int64, int, []byte, uint32, protocol.HashAlgorithm, bool) ([]byte, error)) {
 	fake.requestGlobalMutex.Lock()
 	defer fake.requestGlobalMutex.Unlock()
 	fake.RequestGlobalStub = stub
 }
 
-func (fake *Model) RequestGlobalArgsForCall(i int) (context.Context, protocol.DeviceID, string, string, int, int64, int, []byte, uint32, bool) {
+func (fake *Model) RequestGlobalArgsForCall(i int) (context.Context, protocol.DeviceID, string, string, int, int64, int, []byte, uint32, protocol.HashAlgorithm, bool) {
 	fake.requestGlobalMutex.RLock()
 	defer fake.requestGlobalMutex.RUnlock()
 	argsForCall := fake.requestGlobalArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8, argsForCall.arg9, argsForCall.arg10
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8, argsForCall.arg9, argsForCall.arg10, argsForCall.arg11
 }
 
 func (fake *Model) RequestGlobalReturns(result1 []byte, result2 error) {
@@ -3192,6 +3296,136 @@ func (fake *Model) StateReturnsOnCall(i int, result1 string, result2 time.Time,
 	}{result1, result2, result3}
 }
 
+func (fake *Model) Throughput(arg1 protocol.DeviceID, arg2 string) (float64, error) {
+	fake.throughputMutex.Lock()
+	ret, specificReturn := fake.throughputReturnsOnCall[len(fake.throughputArgsForCall)]
+	fake.throughputArgsForCall = append(fake.throughputArgsForCall, struct {
+		arg1 protocol.DeviceID
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ThroughputStub
+	fakeReturns := fake.throughputReturns
+	fake.recordInvocation("Throughput", []interface{}{arg1, arg2})
+	fake.throughputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) ThroughputCallCount() int {
+	fake.throughputMutex.RLock()
+	defer fake.throughputMutex.RUnlock()
+	return len(fake.throughputArgsForCall)
+}
+
+func (fake *Model) ThroughputCalls(stub func(protocol.DeviceID, string) (float64, error)) {
+	fake.throughputMutex.Lock()
+	defer fake.throughputMutex.Unlock()
+	fake.ThroughputStub = stub
+}
+
+func (fake *Model) ThroughputArgsForCall(i int) (protocol.DeviceID, string) {
+	fake.throughputMutex.RLock()
+	defer fake.throughputMutex.RUnlock()
+	argsForCall := fake.throughputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) ThroughputReturns(result1 float64, result2 error) {
+	fake.throughputMutex.Lock()
+	defer fake.throughputMutex.Unlock()
+	fake.ThroughputStub = nil
+	fake.throughputReturns = struct {
+		result1 float64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) ThroughputReturnsOnCall(i int, result1 float64, result2 error) {
+	fake.throughputMutex.Lock()
+	defer fake.throughputMutex.Unlock()
+	fake.ThroughputStub = nil
+	if fake.throughputReturnsOnCall == nil {
+		fake.throughputReturnsOnCall = make(map[int]struct {
+			result1 float64
+			result2 error
+		})
+	}
+	fake.throughputReturnsOnCall[i] = struct {
+		result1 float64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) ThroughputHistory(arg1 protocol.DeviceID, arg2 string) (model.ThroughputHistory, error) {
+	fake.throughputHistoryMutex.Lock()
+	ret, specificReturn := fake.throughputHistoryReturnsOnCall[len(fake.throughputHistoryArgsForCall)]
+	fake.throughputHistoryArgsForCall = append(fake.throughputHistoryArgsForCall, struct {
+		arg1 protocol.DeviceID
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ThroughputHistoryStub
+	fakeReturns := fake.throughputHistoryReturns
+	fake.recordInvocation("ThroughputHistory", []interface{}{arg1, arg2})
+	fake.throughputHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Model) ThroughputHistoryCallCount() int {
+	fake.throughputHistoryMutex.RLock()
+	defer fake.throughputHistoryMutex.RUnlock()
+	return len(fake.throughputHistoryArgsForCall)
+}
+
+func (fake *Model) ThroughputHistoryCalls(stub func(protocol.DeviceID, string) (model.ThroughputHistory, error)) {
+	fake.throughputHistoryMutex.Lock()
+	defer fake.throughputHistoryMutex.Unlock()
+	fake.ThroughputHistoryStub = stub
+}
+
+func (fake *Model) ThroughputHistoryArgsForCall(i int) (protocol.DeviceID, string) {
+	fake.throughputHistoryMutex.RLock()
+	defer fake.throughputHistoryMutex.RUnlock()
+	argsForCall := fake.throughputHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Model) ThroughputHistoryReturns(result1 model.ThroughputHistory, result2 error) {
+	fake.throughputHistoryMutex.Lock()
+	defer fake.throughputHistoryMutex.Unlock()
+	fake.ThroughputHistoryStub = nil
+	fake.throughputHistoryReturns = struct {
+		result1 model.ThroughputHistory
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Model) ThroughputHistoryReturnsOnCall(i int, result1 model.ThroughputHistory, result2 error) {
+	fake.throughputHistoryMutex.Lock()
+	defer fake.throughputHistoryMutex.Unlock()
+	fake.ThroughputHistoryStub = nil
+	if fake.throughputHistoryReturnsOnCall == nil {
+		fake.throughputHistoryReturnsOnCall = make(map[int]struct {
+			result1 model.ThroughputHistory
+			result2 error
+		})
+	}
+	fake.throughputHistoryReturnsOnCall[i] = struct {
+		result1 model.ThroughputHistory
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Model) UsageReportingStats(arg1 *contract.Report, arg2 int, arg3 bool) {
 	fake.usageReportingStatsMutex.Lock()
 	fake.usageReportingStatsArgsForCall = append(fake.usageReportingStatsArgsForCall, struct {
@@ -3338,6 +3572,8 @@ func (fake *Model) Invocations() map[string][][]interface{} {
 	defer fake.globalDirectoryTreeMutex.RUnlock()
 	fake.indexMutex.RLock()
 	defer fake.indexMutex.RUnlock()
+	fake.indexSummaryMutex.RLock()
+	defer fake.indexSummaryMutex.RUnlock()
 	fake.indexUpdateMutex.RLock()
 	defer fake.indexUpdateMutex.RUnlock()
 	fake.loadIgnoresMutex.RLock()
@@ -3378,6 +3614,10 @@ func (fake *Model) Invocations() map[string][][]interface{} {
 	defer fake.setIgnoresMutex.RUnlock()
 	fake.stateMutex.RLock()
 	defer fake.stateMutex.RUnlock()
+	fake.throughputMutex.RLock()
+	defer fake.throughputMutex.RUnlock()
+	fake.throughputHistoryMutex.RLock()
+	defer fake.throughputHistoryMutex.RUnlock()
 	fake.usageReportingStatsMutex.RLock()
 	defer fake.usageReportingStatsMutex.RUnlock()
 	fake.watchErrorMutex.RLock()
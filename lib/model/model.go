@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"slices"
 	"strings"
 	stdsync "sync"
 	"sync/atomic"
@@ -28,16 +29,18 @@ import (
 
 	"github.com/thejerf/suture/v4"
 
+	"github.com/syncthing/syncthing/lib/blockcache"
 	"github.com/syncthing/syncthing/lib/build"
 	"github.com/syncthing/syncthing/lib/config"
 	"github.com/syncthing/syncthing/lib/connections"
 	"github.com/syncthing/syncthing/lib/db"
+	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/events"
 	"github.com/syncthing/syncthing/lib/fs"
 	"github.com/syncthing/syncthing/lib/ignore"
+	"github.com/syncthing/syncthing/lib/locations"
 	"github.com/syncthing/syncthing/lib/osutil"
 	"github.com/syncthing/syncthing/lib/protocol"
-	"github.com/syncthing/syncthing/lib/rand"
 	"github.com/syncthing/syncthing/lib/scanner"
 	"github.com/syncthing/syncthing/lib/semaphore"
 	"github.com/syncthing/syncthing/lib/stats"
@@ -105,6 +108,8 @@ type Model interface {
 	Availability(folder string, file protocol.FileInfo, block protocol.BlockInfo) ([]Availability, error)
 
 	Completion(device protocol.DeviceID, folder string) (FolderCompletion, error)
+	Throughput(device protocol.DeviceID, folder string) (float64, error)
+	ThroughputHistory(device protocol.DeviceID, folder string) (ThroughputHistory, error)
 	ConnectionStats() map[string]interface{}
 	DeviceStatistics() (map[protocol.DeviceID]stats.DeviceStatistics, error)
 	FolderStatistics() (map[string]stats.FolderStatistics, error)
@@ -118,7 +123,7 @@ type Model interface {
 
 	GlobalDirectoryTree(folder, prefix string, levels int, dirsOnly bool) ([]*TreeEntry, error)
 
-	RequestGlobal(ctx context.Context, deviceID protocol.DeviceID, folder, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error)
+	RequestGlobal(ctx context.Context, deviceID protocol.DeviceID, folder, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, hashAlgorithm protocol.HashAlgorithm, fromTemporary bool) ([]byte, error)
 }
 
 type model struct {
@@ -133,7 +138,9 @@ type model struct {
 
 	// constant or concurrency safe fields
 	finder          *db.BlockFinder
+	hashCache       *db.HashCache
 	progressEmitter *ProgressEmitter
+	throughput      *throughputTracker
 	shortID         protocol.ShortID
 	// globalRequestLimiter limits the amount of data in concurrent incoming
 	// requests
@@ -145,6 +152,11 @@ type model struct {
 	started         chan struct{}
 	keyGen          *protocol.KeyGenerator
 	promotionTimer  *time.Timer
+	// blockCache holds blocks for other devices on the LAN, when we act
+	// as a block cache for them.
+	blockCache *blockcache.Cache
+	// blockCacheMisses are the blocks we missed at the block caches.
+	blockCacheMisses *blockCacheMisses
 
 	// fields protected by mut
 	mut                            sync.RWMutex
@@ -157,14 +169,18 @@ type model struct {
 	folderVersioners               map[string]versioner.Versioner                         // folder -> versioner (may be nil)
 	folderEncryptionPasswordTokens map[string][]byte                                      // folder -> encryption token (may be missing, and only for encryption type folders)
 	folderEncryptionFailures       map[string]map[protocol.DeviceID]error                 // folder -> device -> error regarding encryption consistency (may be missing)
+	folderKeyRotations             map[string]*keyRotation                                // folder -> key rotation in progress (only for receive encrypted folders)
 	connections                    map[string]protocol.Connection                         // connection ID -> connection
 	deviceConnIDs                  map[protocol.DeviceID][]string                         // device -> connection IDs (invariant: if the key exists, the value is len >= 1, with the primary connection at the start of the slice)
 	promotedConnID                 map[protocol.DeviceID]string                           // device -> latest promoted connection ID
 	connRequestLimiters            map[protocol.DeviceID]*semaphore.Semaphore
+	connActivity                   *connectionActivity
+	blockCacheDevices              deviceIDSet              // devices that are block caches for us
 	closed                         map[string]chan struct{} // connection ID -> closed channel
 	helloMessages                  map[protocol.DeviceID]protocol.Hello
 	deviceDownloads                map[protocol.DeviceID]*deviceDownloadState
 	remoteFolderStates             map[protocol.DeviceID]map[string]remoteFolderState // deviceID -> folders
+	deviceHashAlgorithms           map[protocol.DeviceID][]protocol.HashAlgorithm     // deviceID -> supported block hash algorithms, as last announced
 	indexHandlers                  *serviceMap[protocol.DeviceID, *indexHandlerRegistry]
 
 	// for testing only
@@ -216,7 +232,9 @@ func NewModel(cfg config.Wrapper, id protocol.DeviceID, ldb *db.Lowlevel, protec
 
 		// constant or concurrency safe fields
 		finder:               db.NewBlockFinder(ldb),
+		hashCache:            db.NewHashCache(ldb),
 		progressEmitter:      NewProgressEmitter(cfg, evLogger),
+		throughput:           newThroughputTracker(),
 		shortID:              id.Short(),
 		globalRequestLimiter: semaphore.New(1024 * cfg.Options().MaxConcurrentIncomingRequestKiB()),
 		folderIOLimiter:      semaphore.New(cfg.Options().MaxFolderConcurrency()),
@@ -224,6 +242,8 @@ func NewModel(cfg config.Wrapper, id protocol.DeviceID, ldb *db.Lowlevel, protec
 		started:              make(chan struct{}),
 		keyGen:               keyGen,
 		promotionTimer:       time.NewTimer(0),
+		blockCache:           blockcache.New(locations.Get(locations.BlockCache)),
+		blockCacheMisses:     newBlockCacheMisses(),
 
 		// fields protected by mut
 		mut:                            sync.NewRWMutex(),
@@ -235,24 +255,34 @@ func NewModel(cfg config.Wrapper, id protocol.DeviceID, ldb *db.Lowlevel, protec
 		folderVersioners:               make(map[string]versioner.Versioner),
 		folderEncryptionPasswordTokens: make(map[string][]byte),
 		folderEncryptionFailures:       make(map[string]map[protocol.DeviceID]error),
+		folderKeyRotations:             make(map[string]*keyRotation),
 		connections:                    make(map[string]protocol.Connection),
 		deviceConnIDs:                  make(map[protocol.DeviceID][]string),
 		promotedConnID:                 make(map[protocol.DeviceID]string),
 		connRequestLimiters:            make(map[protocol.DeviceID]*semaphore.Semaphore),
+		connActivity:                   newConnectionActivity(),
+		blockCacheDevices:              make(deviceIDSet),
 		closed:                         make(map[string]chan struct{}),
 		helloMessages:                  make(map[protocol.DeviceID]protocol.Hello),
 		deviceDownloads:                make(map[protocol.DeviceID]*deviceDownloadState),
 		remoteFolderStates:             make(map[protocol.DeviceID]map[string]remoteFolderState),
+		deviceHashAlgorithms:           make(map[protocol.DeviceID][]protocol.HashAlgorithm),
 		indexHandlers:                  newServiceMap[protocol.DeviceID, *indexHandlerRegistry](evLogger),
 	}
 	for devID, cfg := range cfg.Devices() {
 		m.deviceStatRefs[devID] = stats.NewDeviceStatisticsReference(m.db, devID)
 		m.setConnRequestLimitersLocked(cfg)
+		if cfg.BlockCache && !cfg.Untrusted {
+			m.blockCacheDevices[devID] = struct{}{}
+		}
 	}
+	m.blockCache.SetMaxSize(int64(cfg.Options().BlockCacheMaxSizeMiB) << 20)
 	m.Add(m.folderRunners)
+	m.Add(m.blockCache)
 	m.Add(m.progressEmitter)
 	m.Add(m.indexHandlers)
 	m.Add(svcutil.AsService(m.serve, m.String()))
+	m.Add(svcutil.AsService(m.sampleThroughput, fmt.Sprintf("%s/sampleThroughput", m)))
 
 	return m
 }
@@ -384,8 +414,11 @@ func (m *model) addAndStartFolderLockedWithIgnores(cfg config.FolderConfiguratio
 	}
 
 	if cfg.Type == config.FolderTypeReceiveEncrypted {
-		if encryptionToken, err := readEncryptionToken(cfg); err == nil {
-			m.folderEncryptionPasswordTokens[folder] = encryptionToken
+		if stored, err := readEncryptionToken(cfg); err == nil {
+			m.folderEncryptionPasswordTokens[folder] = stored.Token
+			if len(stored.PreviousToken) > 0 {
+				m.folderKeyRotations[folder] = newKeyRotation(stored.PreviousToken)
+			}
 		} else if !fs.IsNotExist(err) {
 			l.Warnf("Failed to read encryption token: %v", err)
 		}
@@ -400,7 +433,14 @@ func (m *model) addAndStartFolderLockedWithIgnores(cfg config.FolderConfiguratio
 	var ver versioner.Versioner
 	if cfg.Versioning.Type != "" {
 		var err error
-		ver, err = versioner.New(cfg)
+		ver, err = versioner.New(cfg, func(name string) (protocol.FileInfo, bool) {
+			snap, err := fset.Snapshot()
+			if err != nil {
+				return protocol.FileInfo{}, false
+			}
+			defer snap.Release()
+			return snap.Get(protocol.LocalDeviceID, name)
+		})
 		if err != nil {
 			panic(fmt.Errorf("creating versioner: %w", err))
 		}
@@ -495,6 +535,7 @@ func (m *model) cleanupFolderLocked(cfg config.FolderConfiguration) {
 	delete(m.folderVersioners, cfg.ID)
 	delete(m.folderEncryptionPasswordTokens, cfg.ID)
 	delete(m.folderEncryptionFailures, cfg.ID)
+	delete(m.folderKeyRotations, cfg.ID)
 }
 
 func (m *model) restartFolder(from, to config.FolderConfiguration, cacheIgnoredFiles bool) error {
@@ -770,6 +811,7 @@ func (m *model) ConnectionStats() map[string]interface{} {
 	}
 
 	res["connections"] = conns
+	res["proxy"] = dialer.Status()
 
 	in, out := protocol.TotalInOut()
 	res["total"] = map[string]interface{}{
@@ -829,6 +871,8 @@ type FolderCompletion struct {
 	NeedDeletes   int
 	Sequence      int64
 	RemoteState   remoteFolderState
+	Throughput    float64 // bytes per second
+	ETAS          int64   // seconds, -1 if unknown
 }
 
 func newFolderCompletion(global, need db.Counts, sequence int64, state remoteFolderState) FolderCompletion {
@@ -851,7 +895,9 @@ func (comp *FolderCompletion) add(other FolderCompletion) {
 	comp.GlobalItems += other.GlobalItems
 	comp.NeedItems += other.NeedItems
 	comp.NeedDeletes += other.NeedDeletes
+	comp.Throughput += other.Throughput
 	comp.setCompletionPct()
+	comp.ETAS = etaSeconds(comp.NeedBytes, comp.Throughput)
 }
 
 func (comp *FolderCompletion) setCompletionPct() {
@@ -882,6 +928,8 @@ func (comp *FolderCompletion) Map() map[string]interface{} {
 		"needDeletes": comp.NeedDeletes,
 		"sequence":    comp.Sequence,
 		"remoteState": comp.RemoteState,
+		"throughput":  comp.Throughput,
+		"etaS":        comp.ETAS,
 	}
 }
 
@@ -949,11 +997,51 @@ func (m *model) folderCompletion(device protocol.DeviceID, folder string) (Folde
 	}
 
 	comp := newFolderCompletion(snap.GlobalSize(), need, snap.Sequence(device), state)
+	comp.Throughput = m.throughput.throughput(folder, device, time.Now())
+	comp.ETAS = etaSeconds(comp.NeedBytes, comp.Throughput)
 
 	l.Debugf("%v Completion(%s, %q): %v", m, device, folder, comp.Map())
 	return comp, nil
 }
 
+// Throughput returns the current throughput of the given folder on the
+// given device, which can be the local device like for Completion.
+func (m *model) Throughput(device protocol.DeviceID, folder string) (float64, error) {
+	device, err := m.throughputDevice(device, folder)
+	if err != nil {
+		return 0, err
+	}
+	return m.throughput.throughput(folder, device, time.Now()), nil
+}
+
+// ThroughputHistory returns the current throughput and the throughput
+// history of the given folder on the given device, which can be the local
+// device like for Completion.
+func (m *model) ThroughputHistory(device protocol.DeviceID, folder string) (ThroughputHistory, error) {
+	device, err := m.throughputDevice(device, folder)
+	if err != nil {
+		return ThroughputHistory{}, err
+	}
+	return m.throughput.history(folder, device, time.Now()), nil
+}
+
+// throughputDevice returns the device the throughput of the folder is
+// tracked for, given the device asked for, or an error if the folder
+// doesn't exist or isn't shared with it.
+func (m *model) throughputDevice(device protocol.DeviceID, folder string) (protocol.DeviceID, error) {
+	if device == m.id {
+		device = protocol.LocalDeviceID
+	}
+	fcfg, ok := m.cfg.Folder(folder)
+	if !ok {
+		return device, ErrFolderMissing
+	}
+	if device != protocol.LocalDeviceID && !fcfg.SharedWith(device) {
+		return device, fmt.Errorf("%s: not shared with device %s: %w", folder, device.Short(), ErrFolderMissing)
+	}
+	return device, nil
+}
+
 // DBSnapshot returns a snapshot of the database content relevant to the given folder.
 func (m *model) DBSnapshot(folder string) (*db.Snapshot, error) {
 	m.mut.RLock()
@@ -1177,6 +1265,33 @@ func (m *model) handleIndex(conn protocol.Connection, folder string, fs []protoc
 	return indexHandler.ReceiveIndex(folder, fs, update, op, prevSequence, lastSequence)
 }
 
+// IndexSummary is called when the other side sends an index summary as part
+// of reconciling an index after an index ID change.
+// Implements the protocol.Model interface.
+func (m *model) IndexSummary(conn protocol.Connection, summary *protocol.IndexSummary) error {
+	deviceID := conn.DeviceID()
+	l.Debugf("Index summary (in): %s / %q: %v with %d buckets", deviceID, summary.Folder, summary.Type, len(summary.Buckets))
+
+	if cfg, ok := m.cfg.Folder(summary.Folder); !ok || !cfg.SharedWith(deviceID) {
+		l.Warnf("Index summary for unexpected folder ID %q sent from device %q; ensure that the folder exists and that this device is selected under \"Share With\" in the folder configuration.", summary.Folder, deviceID)
+		return fmt.Errorf("%s: %w", summary.Folder, ErrFolderMissing)
+	} else if cfg.Paused {
+		l.Debugf("Index summary for paused folder (ID %q) sent from device %q.", summary.Folder, deviceID)
+		return fmt.Errorf("%s: %w", summary.Folder, ErrFolderPaused)
+	}
+
+	m.mut.RLock()
+	indexHandler, ok := m.getIndexHandlerRLocked(conn)
+	m.mut.RUnlock()
+	if !ok {
+		m.evLogger.Log(events.Failure, "index handler does not exist for connection on which an index summary was received")
+		l.Debugf("Index summary for folder (ID %q) sent from device %q: missing index handler", summary.Folder, deviceID)
+		return fmt.Errorf("%s: %w", summary.Folder, ErrFolderNotRunning)
+	}
+
+	return indexHandler.ReceiveIndexSummary(summary.Folder, summary)
+}
+
 type clusterConfigDeviceInfo struct {
 	local, remote protocol.Device
 }
@@ -1280,6 +1395,11 @@ func (m *model) ClusterConfig(conn protocol.Connection, cm *protocol.ClusterConf
 
 	m.mut.Lock()
 	m.remoteFolderStates[deviceID] = states
+	for _, info := range ccDeviceInfos {
+		// The same for all folders
+		m.deviceHashAlgorithms[deviceID] = info.remote.HashAlgorithms
+		break
+	}
 	m.mut.Unlock()
 
 	m.evLogger.Log(events.ClusterConfigReceived, ClusterConfigReceivedEventData{
@@ -1546,35 +1666,47 @@ func (m *model) ccCheckEncryption(fcfg config.FolderConfiguration, folderDevice
 	}
 
 	if isEncryptedRemote {
-		passwordToken := protocol.PasswordToken(m.keyGen, fcfg.ID, folderDevice.EncryptionPassword)
-		match := false
+		var ccToken []byte
 		if hasTokenLocal {
-			match = bytes.Equal(passwordToken, ccDeviceInfos.local.EncryptionPasswordToken)
+			ccToken = ccDeviceInfos.local.EncryptionPasswordToken
 		} else {
 			// hasTokenRemote == true
-			match = bytes.Equal(passwordToken, ccDeviceInfos.remote.EncryptionPasswordToken)
+			ccToken = ccDeviceInfos.remote.EncryptionPasswordToken
+		}
+		if bytes.Equal(protocol.PasswordToken(m.keyGen, fcfg.ID, folderDevice.EncryptionPassword), ccToken) {
+			if folderDevice.PreviousEncryptionPassword != "" {
+				// The other device switched to the current password, the
+				// previous one isn't needed anymore.
+				m.clearPreviousEncryptionPassword(fcfg.ID, folderDevice)
+			}
+			return nil
 		}
-		if !match {
-			return errEncryptionPassword
+		if folderDevice.PreviousEncryptionPassword != "" && bytes.Equal(protocol.PasswordToken(m.keyGen, fcfg.ID, folderDevice.PreviousEncryptionPassword), ccToken) {
+			// The other device has data encrypted with the previous
+			// password. It will switch to the current one when it sees
+			// both tokens in our cluster config.
+			l.Debugf("Device %v has folder %s encrypted with the previous password", folderDevice.DeviceID.Short(), fcfg.Description())
+			return nil
 		}
-		return nil
+		return errEncryptionPassword
 	}
 
 	// isEncryptedLocal == true
 
-	var ccToken []byte
+	var ccToken, ccPreviousToken []byte
 	if hasTokenLocal {
 		ccToken = ccDeviceInfos.local.EncryptionPasswordToken
+		ccPreviousToken = ccDeviceInfos.local.PreviousEncryptionPasswordToken
 	} else {
 		// hasTokenRemote == true
 		ccToken = ccDeviceInfos.remote.EncryptionPasswordToken
+		ccPreviousToken = ccDeviceInfos.remote.PreviousEncryptionPasswordToken
 	}
 	m.mut.RLock()
 	token, ok := m.folderEncryptionPasswordTokens[fcfg.ID]
 	m.mut.RUnlock()
 	if !ok {
-		var err error
-		token, err = readEncryptionToken(fcfg)
+		stored, err := readEncryptionToken(fcfg)
 		if err != nil && !fs.IsNotExist(err) {
 			if rerr, ok := redactPathError(err); ok {
 				return rerr
@@ -1585,11 +1717,15 @@ func (m *model) ccCheckEncryption(fcfg config.FolderConfiguration, folderDevice
 			}
 		}
 		if err == nil {
+			token = stored.Token
 			m.mut.Lock()
 			m.folderEncryptionPasswordTokens[fcfg.ID] = token
+			if len(stored.PreviousToken) > 0 {
+				m.folderKeyRotations[fcfg.ID] = newKeyRotation(stored.PreviousToken)
+			}
 			m.mut.Unlock()
 		} else {
-			if err := writeEncryptionToken(ccToken, fcfg); err != nil {
+			if err := writeEncryptionToken(storedEncryptionToken{FolderID: fcfg.ID, Token: ccToken}, fcfg); err != nil {
 				if rerr, ok := redactPathError(err); ok {
 					return rerr
 				} else {
@@ -1608,10 +1744,46 @@ func (m *model) ccCheckEncryption(fcfg config.FolderConfiguration, folderDevice
 			return nil
 		}
 	}
-	if !bytes.Equal(token, ccToken) {
-		return errEncryptionPassword
+	if bytes.Equal(token, ccToken) {
+		m.keyRotationProgress(fcfg.ID, ccDeviceInfos.remote)
+		return nil
 	}
-	return nil
+	if len(ccPreviousToken) > 0 && bytes.Equal(token, ccPreviousToken) {
+		// The password was changed on the other side and our data is
+		// encrypted with the previous one.
+		if err := m.startKeyRotation(fcfg, ccToken, token); err != nil {
+			if rerr, ok := redactPathError(err); ok {
+				return rerr
+			}
+			return &redactedError{
+				error:    err,
+				redacted: errEncryptionTokenWrite,
+			}
+		}
+		m.keyRotationProgress(fcfg.ID, ccDeviceInfos.remote)
+		return nil
+	}
+	return errEncryptionPassword
+}
+
+// clearPreviousEncryptionPassword removes the previous password of the device
+// from the folder config, unless it was changed meanwhile.
+func (m *model) clearPreviousEncryptionPassword(folder string, folderDevice config.FolderDeviceConfiguration) {
+	l.Debugf("Device %v switched to the current password for folder %s", folderDevice.DeviceID.Short(), folder)
+	m.cfg.Modify(func(cfg *config.Configuration) {
+		fcfg, i, ok := cfg.Folder(folder)
+		if !ok {
+			return
+		}
+		for j := range fcfg.Devices {
+			dev := &fcfg.Devices[j]
+			if dev.DeviceID == folderDevice.DeviceID && dev.PreviousEncryptionPassword == folderDevice.PreviousEncryptionPassword {
+				dev.PreviousEncryptionPassword = ""
+				cfg.Folders[i] = fcfg
+				return
+			}
+		}
+	})
 }
 
 func (m *model) sendClusterConfig(ids []protocol.DeviceID) {
@@ -1872,6 +2044,7 @@ func (m *model) Closed(conn protocol.Connection, err error) {
 	closed := m.closed[connID]
 	delete(m.closed, connID)
 	delete(m.connections, connID)
+	m.connActivity.remove(connID)
 
 	removedIsPrimary := m.promotedConnID[deviceID] == connID
 	remainingConns := without(m.deviceConnIDs[deviceID], connID)
@@ -1959,7 +2132,25 @@ func (m *model) Request(conn protocol.Connection, req *protocol.Request) (out pr
 	m.mut.RLock()
 	folderCfg, ok := m.folderCfgs[req.Folder]
 	folderIgnores := m.folderIgnores[req.Folder]
+	isBlockCache := m.isBlockCacheRLocked(deviceID)
 	m.mut.RUnlock()
+	if !ok || !folderCfg.SharedWith(deviceID) {
+		if isBlockCache && conn.IsLocal() {
+			// A block cache filling itself with a block we missed there,
+			// which it gets by hash only.
+			if res, ok := m.blockCacheFillRequest(conn, req); ok {
+				return res, nil
+			}
+		}
+		if m.blockCache.Enabled() {
+			// Not something we share, but we might have the block cached.
+			return m.blockCacheRequest(conn, req)
+		}
+		if isBlockCache && conn.IsLocal() {
+			l.Debugf("Request from block cache %s for block %x we didn't miss there", deviceID.Short(), req.Hash)
+			return nil, protocol.ErrNoSuchFile
+		}
+	}
 	if !ok {
 		// The folder might be already unpaused in the config, but not yet
 		// in the model.
@@ -1975,6 +2166,10 @@ func (m *model) Request(conn protocol.Connection, req *protocol.Request) (out pr
 		l.Debugf("Request from %s for file %s in paused folder %q", deviceID.Short(), req.Name, req.Folder)
 		return nil, protocol.ErrGeneric
 	}
+	if !folderCfg.DeviceAccess(deviceID).ServesRequests() {
+		l.Debugf("Request from %s for file %s in folder %q denied, device may only send", deviceID.Short(), req.Name, req.Folder)
+		return nil, protocol.ErrGeneric
+	}
 
 	// Make sure the path is valid and in canonical form
 	if name, err := fs.Canonicalize(req.Name); err != nil {
@@ -2037,7 +2232,7 @@ func (m *model) Request(conn protocol.Connection, req *protocol.Request) (out pr
 			return nil, protocol.ErrNoSuchFile
 		}
 		_, err := readOffsetIntoBuf(folderFs, tempFn, req.Offset, res.data)
-		if err == nil && scanner.Validate(res.data, req.Hash, req.WeakHash) {
+		if err == nil && scanner.Validate(res.data, req.Hash, req.WeakHash, req.HashAlgorithm) {
 			return res, nil
 		}
 		// Fall through to reading from a non-temp file, just in case the temp
@@ -2066,7 +2261,7 @@ func (m *model) Request(conn protocol.Connection, req *protocol.Request) (out pr
 		return nil, protocol.ErrGeneric
 	}
 
-	if folderCfg.Type != config.FolderTypeReceiveEncrypted && len(req.Hash) > 0 && !scanner.Validate(res.data[:n], req.Hash, req.WeakHash) {
+	if folderCfg.Type != config.FolderTypeReceiveEncrypted && len(req.Hash) > 0 && !scanner.Validate(res.data[:n], req.Hash, req.WeakHash, req.HashAlgorithm) {
 		m.recheckFile(deviceID, req.Folder, req.Name, req.Offset, req.Hash, req.WeakHash)
 		l.Debugf("%v REQ(in) failed validating data: %s: %q / %q o=%d s=%d", m, deviceID.Short(), req.Folder, req.Name, req.Offset, req.Size)
 		return nil, protocol.ErrNoSuchFile
@@ -2330,6 +2525,7 @@ func (m *model) AddConnection(conn protocol.Connection, hello protocol.Hello) {
 	m.closed[connID] = closed
 	m.helloMessages[deviceID] = hello
 	m.deviceConnIDs[deviceID] = append(m.deviceConnIDs[deviceID], connID)
+	m.connActivity.add(connID)
 	if m.deviceDownloads[deviceID] == nil {
 		m.deviceDownloads[deviceID] = newDeviceDownloadState()
 	}
@@ -2463,22 +2659,48 @@ func (m *model) deviceDidCloseRLocked(deviceID protocol.DeviceID, duration time.
 	}
 }
 
-func (m *model) RequestGlobal(ctx context.Context, deviceID protocol.DeviceID, folder, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, fromTemporary bool) ([]byte, error) {
-	conn, connOK := m.requestConnectionForDevice(deviceID)
-	if !connOK {
-		return nil, fmt.Errorf("requestGlobal: no connection to device: %s", deviceID.Short())
-	}
+func (m *model) RequestGlobal(ctx context.Context, deviceID protocol.DeviceID, folder, name string, blockNo int, offset int64, size int, hash []byte, weakHash uint32, hashAlgorithm protocol.HashAlgorithm, fromTemporary bool) ([]byte, error) {
+	req := &protocol.Request{Folder: folder, Name: name, BlockNo: blockNo, Offset: offset, Size: size, Hash: hash, WeakHash: weakHash, HashAlgorithm: hashAlgorithm, FromTemporary: fromTemporary}
+
+	var tried []string
+	var lastErr error
+	for {
+		conn, connOK := m.requestConnectionForDevice(deviceID, size, tried)
+		if !connOK {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, fmt.Errorf("requestGlobal: no connection to device: %s", deviceID.Short())
+		}
 
-	l.Debugf("%v REQ(out): %s (%s): %q / %q b=%d o=%d s=%d h=%x wh=%x ft=%t", m, deviceID.Short(), conn, folder, name, blockNo, offset, size, hash, weakHash, fromTemporary)
-	return conn.Request(ctx, &protocol.Request{Folder: folder, Name: name, BlockNo: blockNo, Offset: offset, Size: size, Hash: hash, WeakHash: weakHash, FromTemporary: fromTemporary})
+		l.Debugf("%v REQ(out): %s (%s): %q / %q b=%d o=%d s=%d h=%x wh=%x ft=%t", m, deviceID.Short(), conn, folder, name, blockNo, offset, size, hash, weakHash, fromTemporary)
+		start := m.connActivity.using(conn.ConnectionID(), size)
+		data, err := conn.Request(ctx, req)
+		if err != nil && ctx.Err() != nil {
+			// Given up on, which caps the connection's measured rate.
+			m.connActivity.done(conn.ConnectionID(), start, size, ctx.Err())
+		} else {
+			m.connActivity.done(conn.ConnectionID(), start, size, err)
+		}
+		if err == nil || ctx.Err() != nil || !errors.Is(err, protocol.ErrClosed) {
+			return data, err
+		}
+
+		// The connection went away with the request in flight; the device
+		// may well be reachable over another one.
+		l.Debugf("%v REQ(out): %s (%s): %v, retrying on another connection", m, deviceID.Short(), conn, err)
+		tried = append(tried, conn.ConnectionID())
+		lastErr = err
+	}
 }
 
 // requestConnectionForDevice returns a connection to the given device, to
-// be used for sending a request. If there is only one device connection,
-// this is the one to use. If there are multiple then we avoid the first
-// ("primary") connection, which is dedicated to index data, and pick a
-// random one of the others.
-func (m *model) requestConnectionForDevice(deviceID protocol.DeviceID) (protocol.Connection, bool) {
+// be used for sending a request of the given size, skipping those in
+// exclude. The primary connection is kept for index traffic when there are
+// others. Requests are striped over those, picking the one expected to
+// complete the request first based on what it has in flight and its
+// measured throughput and latency.
+func (m *model) requestConnectionForDevice(deviceID protocol.DeviceID, size int, exclude []string) (protocol.Connection, bool) {
 	m.mut.RLock()
 	defer m.mut.RUnlock()
 
@@ -2488,15 +2710,22 @@ func (m *model) requestConnectionForDevice(deviceID protocol.DeviceID) (protocol
 	}
 
 	// If there is an entry in deviceConns, it always contains at least one
-	// connection.
-	connID := connIDs[0]
-	if len(connIDs) > 1 {
-		// Pick a random connection of the non-primary ones
-		idx := rand.Intn(len(connIDs)-1) + 1
-		connID = connIDs[idx]
+	// connection, the first being the primary one.
+	candidates := make([]string, 0, len(connIDs))
+	for _, connID := range connIDs[1:] {
+		if !slices.Contains(exclude, connID) {
+			candidates = append(candidates, connID)
+		}
+	}
+	if len(candidates) == 0 && !slices.Contains(exclude, connIDs[0]) {
+		candidates = append(candidates, connIDs[0])
 	}
 
-	conn, connOK := m.connections[connID]
+	idx := m.connActivity.pick(candidates, size)
+	if idx < 0 {
+		return nil, false
+	}
+	conn, connOK := m.connections[candidates[idx]]
 	return conn, connOK
 }
 
@@ -2585,6 +2814,39 @@ func (m *model) numHashers(folder string) int {
 	return 1
 }
 
+// blockHashAlgorithm returns the algorithm to hash blocks of the folder with
+// and whether files hashed with another algorithm should be rehashed. The
+// configured algorithm is only used once all devices sharing the folder
+// have announced support for it; files are rehashed when a device is known
+// not to support it, as it couldn't sync them otherwise.
+func (m *model) blockHashAlgorithm(folder string) (protocol.HashAlgorithm, bool) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	folderCfg := m.folderCfgs[folder]
+
+	if folderCfg.BlockHashAlgorithm == protocol.HashAlgorithmSHA256 {
+		return protocol.HashAlgorithmSHA256, false
+	}
+
+	usable, rehash := true, false
+	for _, device := range folderCfg.DeviceIDs() {
+		if device == m.id {
+			continue
+		}
+		supported, ok := m.deviceHashAlgorithms[device]
+		if !ok {
+			// Not yet known, keep files as they are.
+			usable = false
+		} else if !protocol.SupportsHashAlgorithm(supported, folderCfg.BlockHashAlgorithm) {
+			usable, rehash = false, true
+		}
+	}
+	if !usable {
+		return protocol.HashAlgorithmSHA256, rehash
+	}
+	return folderCfg.BlockHashAlgorithm, false
+}
+
 // generateClusterConfig returns a ClusterConfigMessage that is correct and the
 // set of folder passwords for the given peer device
 func (m *model) generateClusterConfig(device protocol.DeviceID) (*protocol.ClusterConfig, map[string]string) {
@@ -2638,10 +2900,18 @@ func (m *model) generateClusterConfigRLocked(device protocol.DeviceID) (*protoco
 				Introducer:  deviceCfg.Introducer,
 			}
 
+			if deviceCfg.DeviceID == m.id {
+				protocolDevice.IndexSummaries = indexSummariesUsable(folderCfg, device)
+				protocolDevice.HashAlgorithms = protocol.SupportedHashAlgorithms
+			}
+
 			if deviceCfg.DeviceID == m.id && hasEncryptionToken {
 				protocolDevice.EncryptionPasswordToken = encryptionToken
 			} else if folderDevice.EncryptionPassword != "" {
 				protocolDevice.EncryptionPasswordToken = protocol.PasswordToken(m.keyGen, folderCfg.ID, folderDevice.EncryptionPassword)
+				if folderDevice.PreviousEncryptionPassword != "" {
+					protocolDevice.PreviousEncryptionPasswordToken = protocol.PasswordToken(m.keyGen, folderCfg.ID, folderDevice.PreviousEncryptionPassword)
+				}
 				if folderDevice.DeviceID == device {
 					passwords[folderCfg.ID] = folderDevice.EncryptionPassword
 				}
@@ -2915,6 +3185,27 @@ func (m *model) availabilityInSnapshotRLocked(cfg config.FolderConfiguration, sn
 	return availabilities
 }
 
+// temporaryAvailability returns, for each of the given blocks of the file,
+// the number of devices that have it in a temporary file, i.e. that are
+// themselves in the process of pulling the file.
+func (m *model) temporaryAvailability(cfg config.FolderConfiguration, file protocol.FileInfo, blocks []protocol.BlockInfo) []int {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	counts := make([]int, len(blocks))
+	for _, device := range cfg.Devices {
+		downloads := m.deviceDownloads[device.DeviceID]
+		if downloads == nil {
+			continue
+		}
+		for i, block := range blocks {
+			if downloads.Has(cfg.ID, file.Name, file.Version, int(block.Offset/int64(file.BlockSize()))) {
+				counts[i]++
+			}
+		}
+	}
+	return counts
+}
+
 // BringToFront bumps the given files priority in the job queue.
 func (m *model) BringToFront(folder, file string) {
 	m.mut.RLock()
@@ -2955,7 +3246,7 @@ func (*model) VerifyConfiguration(from, to config.Configuration) error {
 	// will panic later when starting the folder.
 	for _, to := range to.Folders {
 		if to.Versioning.Type != "" {
-			if _, err := versioner.New(to); err != nil {
+			if _, err := versioner.New(to, nil); err != nil {
 				return err
 			}
 		}
@@ -3033,6 +3324,15 @@ func (m *model) CommitConfiguration(from, to config.Configuration) bool {
 			}
 		}
 
+		// Changing the access mode of a device invalidates what we have
+		// exchanged with it so far. Dropping the connection makes both
+		// sides start over with fresh index handlers.
+		for _, dev := range toCfg.Devices {
+			if fromCfg.DeviceAccess(dev.DeviceID) != dev.Access {
+				closeDevices = append(closeDevices, dev.DeviceID)
+			}
+		}
+
 		// Emit the folder pause/resume event
 		if fromCfg.Paused != toCfg.Paused {
 			eventType := events.FolderResumed
@@ -3115,8 +3415,18 @@ func (m *model) CommitConfiguration(from, to config.Configuration) bool {
 	ignoredDevices := observedDeviceSet(to.IgnoredDevices)
 	m.cleanPending(toDevices, toFolders, ignoredDevices, removedFolders)
 
+	m.mut.Lock()
+	m.blockCacheDevices = make(deviceIDSet)
+	for _, dev := range to.Devices {
+		if dev.BlockCache && !dev.Untrusted {
+			m.blockCacheDevices[dev.DeviceID] = struct{}{}
+		}
+	}
+	m.mut.Unlock()
+
 	m.globalRequestLimiter.SetCapacity(1024 * to.Options.MaxConcurrentIncomingRequestKiB())
 	m.folderIOLimiter.SetCapacity(to.Options.MaxFolderConcurrency())
+	m.blockCache.SetMaxSize(int64(to.Options.BlockCacheMaxSizeMiB) << 20)
 
 	// Some options don't require restart as those components handle it fine
 	// by themselves. Compare the options structs containing only the
@@ -3424,32 +3734,32 @@ func encryptionTokenPath(cfg config.FolderConfiguration) string {
 type storedEncryptionToken struct {
 	FolderID string
 	Token    []byte
+	// PreviousToken is set while the data is being moved from the
+	// previous to the current password.
+	PreviousToken []byte `json:",omitempty"`
 }
 
-func readEncryptionToken(cfg config.FolderConfiguration) ([]byte, error) {
+func readEncryptionToken(cfg config.FolderConfiguration) (storedEncryptionToken, error) {
 	fd, err := cfg.Filesystem(nil).Open(encryptionTokenPath(cfg))
 	if err != nil {
-		return nil, err
+		return storedEncryptionToken{}, err
 	}
 	defer fd.Close()
 	var stored storedEncryptionToken
 	if err := json.NewDecoder(fd).Decode(&stored); err != nil {
-		return nil, err
+		return storedEncryptionToken{}, err
 	}
-	return stored.Token, nil
+	return stored, nil
 }
 
-func writeEncryptionToken(token []byte, cfg config.FolderConfiguration) error {
+func writeEncryptionToken(stored storedEncryptionToken, cfg config.FolderConfiguration) error {
 	tokenName := encryptionTokenPath(cfg)
-	fd, err := cfg.Filesystem(nil).OpenFile(tokenName, fs.OptReadWrite|fs.OptCreate, 0o666)
+	fd, err := cfg.Filesystem(nil).OpenFile(tokenName, fs.OptReadWrite|fs.OptCreate|fs.OptTruncate, 0o666)
 	if err != nil {
 		return err
 	}
 	defer fd.Close()
-	return json.NewEncoder(fd).Encode(storedEncryptionToken{
-		FolderID: cfg.ID,
-		Token:    token,
-	})
+	return json.NewEncoder(fd).Encode(stored)
 }
 
 func newFolderConfiguration(w config.Wrapper, id, label string, fsType fs.FilesystemType, path string) config.FolderConfiguration {
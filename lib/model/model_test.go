@@ -222,7 +222,7 @@ func BenchmarkRequestOut(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		data, err := m.RequestGlobal(context.Background(), device1, "default", files[i%n].Name, 0, 0, 32, nil, 0, false)
+		data, err := m.RequestGlobal(context.Background(), device1, "default", files[i%n].Name, 0, 0, 32, nil, 0, protocol.HashAlgorithmSHA256, false)
 		if err != nil {
 			b.Error(err)
 		}
@@ -255,6 +255,62 @@ func BenchmarkRequestInSingleFile(b *testing.B) {
 	b.SetBytes(128 << 10)
 }
 
+func TestFolderDeviceAccess(t *testing.T) {
+	cases := []struct {
+		access        config.FolderDeviceAccess
+		acceptsIndex  bool
+		servesRequest bool
+	}{
+		{config.FolderDeviceAccessSendReceive, true, true},
+		{config.FolderDeviceAccessSendOnly, true, false},
+		{config.FolderDeviceAccessReceiveOnly, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.access.String(), func(t *testing.T) {
+			w, fcfg, wCancel := newDefaultCfgWrapper()
+			defer wCancel()
+			for i := range fcfg.Devices {
+				if fcfg.Devices[i].DeviceID == device1 {
+					fcfg.Devices[i].Access = tc.access
+				}
+			}
+			setFolder(t, w, fcfg)
+			m, fc := setupModelWithConnectionFromWrapper(t, w)
+			defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+			writeFile(t, fcfg.Filesystem(nil), "foo", []byte("foobar"))
+			must(t, m.ScanFolder("default"))
+
+			remote := protocol.FileInfo{
+				Name:    "bar",
+				Size:    6,
+				Version: protocol.Vector{}.Update(device1.Short()),
+				Blocks:  []protocol.BlockInfo{{Size: 6, Hash: []byte("hash")}},
+			}
+			must(t, m.Index(fc, &protocol.Index{Folder: "default", Files: []protocol.FileInfo{remote}}))
+
+			snap := dbSnapshot(t, m, "default")
+			_, ok := snap.GetGlobal("bar")
+			snap.Release()
+			if ok != tc.acceptsIndex {
+				t.Errorf("file from remote in global state: %v, expected %v", ok, tc.acceptsIndex)
+			}
+
+			res, err := m.Request(fc, &protocol.Request{Folder: "default", Name: "foo", Size: 6})
+			if tc.servesRequest {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+				res.Close()
+			} else if err == nil {
+				res.Close()
+				t.Error("expected request to be denied")
+			}
+		})
+	}
+}
+
 func TestDeviceRename(t *testing.T) {
 	hello := protocol.Hello{
 		ClientName:    "syncthing",
@@ -4141,3 +4197,49 @@ type modtimeTruncatingFileInfo struct {
 func (fi modtimeTruncatingFileInfo) ModTime() time.Time {
 	return fi.FileInfo.ModTime().Truncate(fi.trunc)
 }
+
+func TestBlockHashAlgorithm(t *testing.T) {
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	fcfg.BlockHashAlgorithm = protocol.HashAlgorithmBLAKE3
+	addDevice2(t, w, fcfg)
+	m := setupModel(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Path)
+
+	check := func(expAlgorithm protocol.HashAlgorithm, expRehash bool) {
+		t.Helper()
+		algorithm, rehash := m.blockHashAlgorithm(fcfg.ID)
+		if algorithm != expAlgorithm || rehash != expRehash {
+			t.Errorf("Got %v (rehash %v), expected %v (rehash %v)", algorithm, rehash, expAlgorithm, expRehash)
+		}
+	}
+	supportingClusterConfig := func(dev protocol.DeviceID) *protocol.ClusterConfig {
+		cc := basicClusterConfig(myID, dev, fcfg.ID)
+		cc.Folders[0].Devices[1].HashAlgorithms = []protocol.HashAlgorithm{protocol.HashAlgorithmBLAKE3}
+		return cc
+	}
+
+	// Nothing known about the other devices yet
+	check(protocol.HashAlgorithmSHA256, false)
+
+	// Device 1 doesn't announce support
+	conn1 := addFakeConn(m, device1, fcfg.ID)
+	check(protocol.HashAlgorithmSHA256, true)
+
+	// Device 1 supports it, device 2 is still unknown
+	must(t, m.ClusterConfig(conn1, supportingClusterConfig(device1)))
+	check(protocol.HashAlgorithmSHA256, false)
+
+	conn2 := addFakeConn(m, device2, fcfg.ID)
+	check(protocol.HashAlgorithmSHA256, true)
+	must(t, m.ClusterConfig(conn2, supportingClusterConfig(device2)))
+	check(protocol.HashAlgorithmBLAKE3, false)
+
+	// We announce our support in turn
+	cc, _ := m.generateClusterConfig(device1)
+	for _, dev := range cc.Folders[0].Devices {
+		if dev.ID == myID && !protocol.SupportsHashAlgorithm(dev.HashAlgorithms, protocol.HashAlgorithmBLAKE3) {
+			t.Error("Expected support for BLAKE3 to be announced, got", dev.HashAlgorithms)
+		}
+	}
+}
@@ -24,6 +24,7 @@ type jobQueueEntry struct {
 	name     string
 	size     int64
 	modified int64
+	priority int
 }
 
 func newJobQueue() *jobQueue {
@@ -35,7 +36,7 @@ func newJobQueue() *jobQueue {
 func (q *jobQueue) Push(file string, size int64, modified time.Time) {
 	q.mut.Lock()
 	// The range of UnixNano covers a range of reasonable timestamps.
-	q.queued = append(q.queued, jobQueueEntry{file, size, modified.UnixNano()})
+	q.queued = append(q.queued, jobQueueEntry{file, size, modified.UnixNano(), 0})
 	q.mut.Unlock()
 }
 
@@ -181,6 +182,18 @@ func (q *jobQueue) SortNewestFirst() {
 	sort.Sort(sort.Reverse(oldestFirst(q.queued)))
 }
 
+// SortByPriority orders the queue by the priorities given by the function,
+// highest first, keeping the current order within each priority.
+func (q *jobQueue) SortByPriority(priority func(string) int) {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+
+	for i := range q.queued {
+		q.queued[i].priority = priority(q.queued[i].name)
+	}
+	sort.Stable(highestPriorityFirst(q.queued))
+}
+
 // The usual sort.Interface boilerplate
 
 type smallestFirst []jobQueueEntry
@@ -194,3 +207,9 @@ type oldestFirst []jobQueueEntry
 func (q oldestFirst) Len() int           { return len(q) }
 func (q oldestFirst) Less(a, b int) bool { return q[a].modified < q[b].modified }
 func (q oldestFirst) Swap(a, b int)      { q[a], q[b] = q[b], q[a] }
+
+type highestPriorityFirst []jobQueueEntry
+
+func (q highestPriorityFirst) Len() int           { return len(q) }
+func (q highestPriorityFirst) Less(a, b int) bool { return q[a].priority > q[b].priority }
+func (q highestPriorityFirst) Swap(a, b int)      { q[a], q[b] = q[b], q[a] }
@@ -9,6 +9,7 @@ package model
 import (
 	"fmt"
 	"math/rand"
+	"path/filepath"
 	"slices"
 	"testing"
 	"time"
@@ -252,6 +253,35 @@ func TestSortByAge(t *testing.T) {
 	}
 }
 
+func TestSortByPriority(t *testing.T) {
+	q := newJobQueue()
+	q.Push("f1.iso", 0, time.Time{})
+	q.Push("f2.docx", 0, time.Time{})
+	q.Push("f3", 0, time.Time{})
+	q.Push("f4.docx", 0, time.Time{})
+	q.Push("f5.iso", 0, time.Time{})
+
+	q.SortByPriority(func(name string) int {
+		switch filepath.Ext(name) {
+		case ".docx":
+			return 10
+		case ".iso":
+			return -10
+		}
+		return 0
+	})
+
+	_, actual, _ := q.Jobs(1, 100)
+	if l := len(actual); l != 5 {
+		t.Fatalf("Weird length %d returned from jobs(1, 100)", l)
+	}
+	expected := []string{"f2.docx", "f4.docx", "f3", "f1.iso", "f5.iso"}
+
+	if diff, equal := messagediff.PrettyDiff(expected, actual); !equal {
+		t.Errorf("SortByPriority() diff:\n%s", diff)
+	}
+}
+
 func BenchmarkJobQueueBump(b *testing.B) {
 	files := genFiles(10000)
 
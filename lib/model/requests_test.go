@@ -15,6 +15,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -1280,7 +1281,7 @@ func TestRequestReceiveEncrypted(t *testing.T) {
 	setFolder(t, w, fcfg)
 
 	encToken := protocol.PasswordToken(protocol.NewKeyGenerator(), fcfg.ID, "pw")
-	must(t, writeEncryptionToken(encToken, fcfg))
+	must(t, writeEncryptionToken(storedEncryptionToken{FolderID: fcfg.ID, Token: encToken}, fcfg))
 
 	m := setupModel(t, w)
 	defer cleanupModelAndRemoveDir(m, tfs.URI())
@@ -1462,3 +1463,206 @@ func TestRequestGlobalInvalidToValid(t *testing.T) {
 		}
 	}
 }
+
+func TestRequestGlobalFailover(t *testing.T) {
+	w, wCancel := newConfigWrapper(defaultCfg)
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	data := []byte("some data to return")
+
+	// One connection is closed under the requests, the other works.
+	var closedCalls atomic.Int32
+	closedConn := newFakeConnection(device1, m)
+	closedConn.RequestCalls(func(context.Context, *protocol.Request) ([]byte, error) {
+		closedCalls.Add(1)
+		return nil, protocol.ErrClosed
+	})
+	workingConn := newFakeConnection(device1, m)
+	workingConn.addFile("foo", 0o644, protocol.FileInfoTypeFile, data)
+	// The primary connection is only used for requests as a last resort.
+	m.AddConnection(workingConn, protocol.Hello{})
+	m.AddConnection(closedConn, protocol.Hello{})
+
+	for i := 0; i < 10; i++ {
+		got, err := m.RequestGlobal(context.Background(), device1, "default", "foo", 0, 0, len(data), nil, 0, protocol.HashAlgorithmSHA256, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("got %q, expected %q", got, data)
+		}
+	}
+	if n := closedCalls.Load(); n != 10 {
+		t.Errorf("expected all requests on the closed non-primary connection first, got %d", n)
+	}
+
+	// Without a working connection the error is passed on.
+	m.Closed(workingConn, errors.New("gone"))
+	if _, err := m.RequestGlobal(context.Background(), device1, "default", "foo", 0, 0, len(data), nil, 0, protocol.HashAlgorithmSHA256, false); !errors.Is(err, protocol.ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+}
+
+func TestRequestConnectionForDevicePrimary(t *testing.T) {
+	w, wCancel := newConfigWrapper(defaultCfg)
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	primary := newFakeConnection(device1, m)
+	m.AddConnection(primary, protocol.Hello{})
+	if conn, ok := m.requestConnectionForDevice(device1, 128<<10, nil); !ok || conn.ConnectionID() != primary.ConnectionID() {
+		t.Fatal("expected the only connection to be used")
+	}
+
+	others := []*fakeConnection{newFakeConnection(device1, m), newFakeConnection(device1, m)}
+	for _, conn := range others {
+		m.AddConnection(conn, protocol.Hello{})
+	}
+	for i := 0; i < 20; i++ {
+		conn, ok := m.requestConnectionForDevice(device1, 128<<10, nil)
+		if !ok {
+			t.Fatal("expected a connection")
+		}
+		if conn.ConnectionID() == primary.ConnectionID() {
+			t.Fatal("the primary connection should be kept for index traffic")
+		}
+		m.connActivity.using(conn.ConnectionID(), 128<<10)
+	}
+
+	exclude := []string{others[0].ConnectionID(), others[1].ConnectionID()}
+	if conn, ok := m.requestConnectionForDevice(device1, 128<<10, exclude); !ok || conn.ConnectionID() != primary.ConnectionID() {
+		t.Error("expected the primary connection once the others are excluded")
+	}
+	exclude = append(exclude, primary.ConnectionID())
+	if _, ok := m.requestConnectionForDevice(device1, 128<<10, exclude); ok {
+		t.Error("expected no connection with all excluded")
+	}
+}
+
+func TestRequestGlobalMixedPriorities(t *testing.T) {
+	// A LAN and a WAN connection besides the primary one carry blocks at
+	// the same time.
+	w, wCancel := newConfigWrapper(defaultCfg)
+	defer wCancel()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	primary := newFakeConnection(device1, m)
+	primary.IsLocalReturns(true)
+	primary.PriorityReturns(10)
+	m.AddConnection(primary, protocol.Hello{})
+
+	data := []byte("some data")
+	var inFlight sync.WaitGroup
+	inFlight.Add(2)
+	newConn := func(local bool, prio int) *fakeConnection {
+		conn := newFakeConnection(device1, m)
+		conn.IsLocalReturns(local)
+		conn.PriorityReturns(prio)
+		var once sync.Once
+		conn.RequestCalls(func(context.Context, *protocol.Request) ([]byte, error) {
+			once.Do(inFlight.Done)
+			inFlight.Wait()
+			return data, nil
+		})
+		m.AddConnection(conn, protocol.Hello{})
+		return conn
+	}
+	lan := newConn(true, 10)
+	wan := newConn(false, 30)
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := m.RequestGlobal(context.Background(), device1, "default", "foo", 0, 0, len(data), nil, 0, protocol.HashAlgorithmSHA256, false)
+			errs <- err
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatal("requests not in flight on both connections at the same time")
+		}
+	}
+	if lan.RequestCallCount() != 1 || wan.RequestCallCount() != 1 {
+		t.Errorf("expected one request each on LAN and WAN, got %d and %d", lan.RequestCallCount(), wan.RequestCallCount())
+	}
+	if primary.RequestCallCount() != 0 {
+		t.Error("the primary connection should be kept for index traffic")
+	}
+}
+
+func TestPullSlowDeviceHedged(t *testing.T) {
+	// device1 looks much faster than device2, but has stopped answering.
+	oldActivity := activity
+	activity = newDeviceActivity()
+	defer func() { activity = oldActivity }()
+	start := activity.using(Availability{ID: device1}, 1<<10)
+	activity.doneAfter(Availability{ID: device1}, start, 1<<10, time.Millisecond, nil)
+	start = activity.using(Availability{ID: device2}, 1<<10)
+	activity.doneAfter(Availability{ID: device2}, start, 1<<10, 100*time.Millisecond, nil)
+
+	w, fcfg, wCancel := newDefaultCfgWrapper()
+	defer wCancel()
+	addDevice2(t, w, fcfg)
+	m := setupModel(t, w)
+	defer cleanupModelAndRemoveDir(m, fcfg.Filesystem(nil).URI())
+
+	var slowCalls atomic.Int32
+	slow := addFakeConn(m, device1, fcfg.ID)
+	slow.RequestCalls(func(ctx context.Context, _ *protocol.Request) ([]byte, error) {
+		slowCalls.Add(1)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	fast := addFakeConn(m, device2, fcfg.ID)
+
+	data := []byte("some data to pull")
+	fast.addFile("foo", 0o644, protocol.FileInfoTypeFile, data)
+	slow.files = fast.files
+	slow.sendIndexUpdate()
+	fast.sendIndexUpdate()
+
+	// The block is requested from device2 as well once device1 is slow to
+	// answer, so the file gets pulled regardless.
+	tfs := fcfg.Filesystem(nil)
+	deadline := time.Now().Add(pullHedgeMinDelay + 10*time.Second)
+	for {
+		if equalContents(tfs, "foo", data) == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the file to be pulled")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	calls := slowCalls.Load()
+	if calls == 0 {
+		t.Fatal("Expected a request to the slow device")
+	}
+
+	// Having stalled, device1 no longer looks fast, so the next file is
+	// pulled from device2 straight away instead of waiting for the hedge.
+	data = []byte("some more data to pull")
+	fast.addFile("bar", 0o644, protocol.FileInfoTypeFile, data)
+	slow.files = fast.files
+	slow.sendIndexUpdate()
+	fast.sendIndexUpdate()
+	deadline = time.Now().Add(10 * time.Second)
+	for equalContents(tfs, "bar", data) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the file to be pulled")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := slowCalls.Load(); n != calls {
+		t.Errorf("Expected no more requests to the stalled device, got %d", n-calls)
+	}
+}
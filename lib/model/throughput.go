@@ -0,0 +1,192 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/sync"
+)
+
+const (
+	// How often the need of each folder and device is sampled, and for how
+	// many samples the history goes back (24 hours).
+	throughputResolution    = time.Minute
+	throughputHistoryLength = 24 * 60
+	// The period over which the current throughput is averaged.
+	throughputWindow = 10 * time.Minute
+)
+
+// ThroughputSample is the need of a folder on a device at a point in time,
+// and the rate at which it went down since the previous sample.
+type ThroughputSample struct {
+	Time           time.Time `json:"time"`
+	NeedBytes      int64     `json:"needBytes"`
+	BytesPerSecond float64   `json:"bytesPerSecond"`
+}
+
+// ThroughputHistory is the current throughput of a folder on a device,
+// averaged over the last few minutes, and the samples it's based on.
+type ThroughputHistory struct {
+	Throughput float64            `json:"throughput"`
+	Samples    []ThroughputSample `json:"samples"`
+}
+
+type throughputKey struct {
+	folder string
+	device protocol.DeviceID
+}
+
+// throughputTracker keeps the throughput history of each folder and device.
+// Throughput is how fast the need goes down, so it includes data copied
+// locally as well as transferred, and doesn't count what's transferred
+// while even more becomes needed. It is safe for use from multiple
+// goroutines.
+type throughputTracker struct {
+	samples map[throughputKey][]ThroughputSample
+	mut     sync.Mutex
+}
+
+func newThroughputTracker() *throughputTracker {
+	return &throughputTracker{
+		samples: make(map[throughputKey][]ThroughputSample),
+		mut:     sync.NewMutex(),
+	}
+}
+
+// record adds a sample of the need of the folder on the device, unless the
+// previous one is too recent to be a sample of its own.
+func (t *throughputTracker) record(folder string, device protocol.DeviceID, needBytes int64, now time.Time) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	key := throughputKey{folder, device}
+	samples := t.samples[key]
+	sample := ThroughputSample{Time: now, NeedBytes: needBytes}
+	if len(samples) > 0 {
+		prev := samples[len(samples)-1]
+		elapsed := now.Sub(prev.Time)
+		if elapsed < throughputResolution/2 {
+			return
+		}
+		if done := prev.NeedBytes - needBytes; done > 0 {
+			sample.BytesPerSecond = float64(done) / elapsed.Seconds()
+		}
+	}
+	if len(samples) >= throughputHistoryLength {
+		samples = append(samples[:0], samples[len(samples)-throughputHistoryLength+1:]...)
+	}
+	t.samples[key] = append(samples, sample)
+}
+
+// retain forgets about all folders and devices but the given ones.
+func (t *throughputTracker) retain(keep map[throughputKey]struct{}) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	for key := range t.samples {
+		if _, ok := keep[key]; !ok {
+			delete(t.samples, key)
+		}
+	}
+}
+
+// history returns the throughput history of the folder on the device.
+func (t *throughputTracker) history(folder string, device protocol.DeviceID, now time.Time) ThroughputHistory {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	samples := t.samples[throughputKey{folder, device}]
+	return ThroughputHistory{
+		Throughput: currentThroughput(samples, now),
+		Samples:    append([]ThroughputSample{}, samples...),
+	}
+}
+
+// throughput returns the current throughput of the folder on the device, in
+// bytes per second.
+func (t *throughputTracker) throughput(folder string, device protocol.DeviceID, now time.Time) float64 {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	return currentThroughput(t.samples[throughputKey{folder, device}], now)
+}
+
+// currentThroughput returns the average throughput over the samples within
+// the window, weighted by the time each covers. That's zero when there are
+// no recent samples.
+func currentThroughput(samples []ThroughputSample, now time.Time) float64 {
+	var done, secs float64
+	for i := len(samples) - 1; i > 0 && now.Sub(samples[i].Time) <= throughputWindow; i-- {
+		elapsed := samples[i].Time.Sub(samples[i-1].Time).Seconds()
+		done += samples[i].BytesPerSecond * elapsed
+		secs += elapsed
+	}
+	if secs == 0 {
+		return 0
+	}
+	return done / secs
+}
+
+// etaSeconds returns the estimated number of seconds until the needed bytes
+// are done at the given throughput, or -1 if it can't tell.
+func etaSeconds(needBytes int64, throughput float64) int64 {
+	if needBytes <= 0 {
+		return 0
+	}
+	if throughput <= 0 {
+		return -1
+	}
+	return int64(math.Ceil(float64(needBytes) / throughput))
+}
+
+// sampleThroughput records the need of each folder on each device sharing
+// it at the throughput resolution.
+func (m *model) sampleThroughput(ctx context.Context) error {
+	ticker := time.NewTicker(throughputResolution)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			m.recordThroughput(now)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *model) recordThroughput(now time.Time) {
+	keep := make(map[throughputKey]struct{})
+	for _, fcfg := range m.cfg.FolderList() {
+		for _, dev := range fcfg.Devices {
+			device := dev.DeviceID
+			if device == m.id {
+				device = protocol.LocalDeviceID
+			}
+			keep[throughputKey{fcfg.ID, device}] = struct{}{}
+			if fcfg.Paused {
+				continue
+			}
+
+			comp, err := m.folderCompletion(device, fcfg.ID)
+			if err != nil {
+				continue
+			}
+			need := comp.NeedBytes
+			if device == protocol.LocalDeviceID {
+				// Also count the progress on the files being pulled
+				// currently, for finer grained samples.
+				need -= m.progressEmitter.BytesCompleted(fcfg.ID)
+				if need < 0 {
+					need = 0
+				}
+			}
+			m.throughput.record(fcfg.ID, device, need, now)
+		}
+	}
+	m.throughput.retain(keep)
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestThroughputTracker(t *testing.T) {
+	tr := newThroughputTracker()
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	minute := throughputResolution
+
+	// Nothing known yet
+	if res := tr.throughput("f", protocol.LocalDeviceID, start); res != 0 {
+		t.Errorf("Expected no throughput, got %v", res)
+	}
+
+	// 60 MB per minute for a while, then a pause where even more becomes
+	// needed, and something too soon after to be recorded.
+	needs := []int64{600e6, 540e6, 480e6, 420e6, 420e6, 500e6}
+	for i, need := range needs {
+		tr.record("f", protocol.LocalDeviceID, need, start.Add(time.Duration(i)*minute))
+	}
+	tr.record("f", protocol.LocalDeviceID, 0, start.Add(time.Duration(len(needs)-1)*minute+time.Second))
+	now := start.Add(time.Duration(len(needs)-1) * minute)
+
+	hist := tr.history("f", protocol.LocalDeviceID, now)
+	if len(hist.Samples) != len(needs) {
+		t.Fatalf("Expected %d samples, got %d", len(needs), len(hist.Samples))
+	}
+	expectedRates := []float64{0, 1e6, 1e6, 1e6, 0, 0}
+	for i, s := range hist.Samples {
+		if s.NeedBytes != needs[i] || s.BytesPerSecond != expectedRates[i] {
+			t.Errorf("Unexpected sample %d: %+v", i, s)
+		}
+	}
+	if hist.Throughput != 0.6e6 {
+		t.Errorf("Expected throughput 0.6 MB/s, got %v", hist.Throughput)
+	}
+
+	// Older samples are out of the window
+	later := now.Add(throughputWindow - 3*minute)
+	if res := tr.throughput("f", protocol.LocalDeviceID, later); res != 0.5e6 {
+		t.Errorf("Expected throughput 0.5 MB/s, got %v", res)
+	}
+	if res := tr.throughput("f", protocol.LocalDeviceID, now.Add(throughputWindow+minute)); res != 0 {
+		t.Errorf("Expected no throughput without recent samples, got %v", res)
+	}
+
+	// Other folders and devices are separate, and forgotten unless retained
+	tr.record("g", protocol.LocalDeviceID, 1, start)
+	tr.record("f", device1, 1, start)
+	if res := tr.history("f", device1, now); len(res.Samples) != 1 {
+		t.Errorf("Expected one sample for device1, got %v", res.Samples)
+	}
+	tr.retain(map[throughputKey]struct{}{{"f", protocol.LocalDeviceID}: {}})
+	if res := tr.history("g", protocol.LocalDeviceID, now); len(res.Samples) != 0 {
+		t.Errorf("Expected g to be forgotten, got %v", res.Samples)
+	}
+	if res := tr.history("f", protocol.LocalDeviceID, now); len(res.Samples) != len(needs) {
+		t.Errorf("Expected f to be retained, got %v", res.Samples)
+	}
+}
+
+func TestThroughputHistoryLength(t *testing.T) {
+	tr := newThroughputTracker()
+	start := time.Now()
+	for i := 0; i < throughputHistoryLength+10; i++ {
+		tr.record("f", device1, int64(i), start.Add(time.Duration(i)*throughputResolution))
+	}
+	samples := tr.history("f", device1, start).Samples
+	if len(samples) != throughputHistoryLength {
+		t.Fatalf("Expected %d samples, got %d", throughputHistoryLength, len(samples))
+	}
+	if samples[0].NeedBytes != 10 || samples[len(samples)-1].NeedBytes != throughputHistoryLength+9 {
+		t.Errorf("Expected the latest samples to be kept, got %v ... %v", samples[0], samples[len(samples)-1])
+	}
+}
+
+func TestETASeconds(t *testing.T) {
+	cases := []struct {
+		need       int64
+		throughput float64
+		expected   int64
+	}{
+		{0, 0, 0},
+		{0, 100, 0},
+		{100, 0, -1},
+		{100, 100, 1},
+		{150, 100, 2},
+		{600e6, 1e6, 600},
+	}
+	for _, tc := range cases {
+		if res := etaSeconds(tc.need, tc.throughput); res != tc.expected {
+			t.Errorf("etaSeconds(%d, %v) == %d, expected %d", tc.need, tc.throughput, res, tc.expected)
+		}
+	}
+}
+
+func TestThroughputNotShared(t *testing.T) {
+	w, fcfg, cancel := newDefaultCfgWrapper()
+	defer cancel()
+	m := setupModel(t, w)
+	defer cleanupModel(m)
+
+	for _, device := range []protocol.DeviceID{myID, protocol.LocalDeviceID, device1} {
+		if _, err := m.Throughput(device, fcfg.ID); err != nil {
+			t.Errorf("Throughput(%v): unexpected error: %v", device, err)
+		}
+		if _, err := m.ThroughputHistory(device, fcfg.ID); err != nil {
+			t.Errorf("ThroughputHistory(%v): unexpected error: %v", device, err)
+		}
+	}
+
+	// The folder isn't shared with device2, nor is there such a folder.
+	for _, tc := range []struct {
+		device protocol.DeviceID
+		folder string
+	}{{device2, fcfg.ID}, {device1, "nonexistent"}} {
+		if _, err := m.Throughput(tc.device, tc.folder); !errors.Is(err, ErrFolderMissing) {
+			t.Errorf("Throughput(%v, %q): expected missing folder, got %v", tc.device, tc.folder, err)
+		}
+		if _, err := m.ThroughputHistory(tc.device, tc.folder); !errors.Is(err, ErrFolderMissing) {
+			t.Errorf("ThroughputHistory(%v, %q): expected missing folder, got %v", tc.device, tc.folder, err)
+		}
+	}
+}
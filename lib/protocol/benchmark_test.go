@@ -194,3 +194,7 @@ func (*fakeModel) Closed(Connection, error) {
 func (*fakeModel) DownloadProgress(Connection, *DownloadProgress) error {
 	return nil
 }
+
+func (*fakeModel) IndexSummary(Connection, *IndexSummary) error {
+	return nil
+}
@@ -35,6 +35,7 @@ const (
 	MessageTypeDownloadProgress MessageType = 5
 	MessageTypePing             MessageType = 6
 	MessageTypeClose            MessageType = 7
+	MessageTypeIndexSummary     MessageType = 8
 )
 
 var MessageType_name = map[int32]string{
@@ -46,6 +47,7 @@ var MessageType_name = map[int32]string{
 	5: "MESSAGE_TYPE_DOWNLOAD_PROGRESS",
 	6: "MESSAGE_TYPE_PING",
 	7: "MESSAGE_TYPE_CLOSE",
+	8: "MESSAGE_TYPE_INDEX_SUMMARY",
 }
 
 var MessageType_value = map[string]int32{
@@ -57,6 +59,7 @@ var MessageType_value = map[string]int32{
 	"MESSAGE_TYPE_DOWNLOAD_PROGRESS": 5,
 	"MESSAGE_TYPE_PING":              6,
 	"MESSAGE_TYPE_CLOSE":             7,
+	"MESSAGE_TYPE_INDEX_SUMMARY":     8,
 }
 
 func (x MessageType) String() string {
@@ -120,6 +123,34 @@ func (Compression) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_311ef540e10d9705, []int{2}
 }
 
+type IndexSummaryType int32
+
+const (
+	IndexSummaryTypeRequest     IndexSummaryType = 0
+	IndexSummaryTypeDifferences IndexSummaryType = 1
+	IndexSummaryTypeComplete    IndexSummaryType = 2
+)
+
+var IndexSummaryType_name = map[int32]string{
+	0: "INDEX_SUMMARY_TYPE_REQUEST",
+	1: "INDEX_SUMMARY_TYPE_DIFFERENCES",
+	2: "INDEX_SUMMARY_TYPE_COMPLETE",
+}
+
+var IndexSummaryType_value = map[string]int32{
+	"INDEX_SUMMARY_TYPE_REQUEST":     0,
+	"INDEX_SUMMARY_TYPE_DIFFERENCES": 1,
+	"INDEX_SUMMARY_TYPE_COMPLETE":    2,
+}
+
+func (x IndexSummaryType) String() string {
+	return proto.EnumName(IndexSummaryType_name, int32(x))
+}
+
+func (IndexSummaryType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{3}
+}
+
 type FileInfoType int32
 
 const (
@@ -151,7 +182,32 @@ func (x FileInfoType) String() string {
 }
 
 func (FileInfoType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{3}
+	return fileDescriptor_311ef540e10d9705, []int{4}
+}
+
+type HashAlgorithm int32
+
+const (
+	HashAlgorithmSHA256 HashAlgorithm = 0
+	HashAlgorithmBLAKE3 HashAlgorithm = 1
+)
+
+var HashAlgorithm_name = map[int32]string{
+	0: "HASH_ALGORITHM_SHA256",
+	1: "HASH_ALGORITHM_BLAKE3",
+}
+
+var HashAlgorithm_value = map[string]int32{
+	"HASH_ALGORITHM_SHA256": 0,
+	"HASH_ALGORITHM_BLAKE3": 1,
+}
+
+func (x HashAlgorithm) String() string {
+	return proto.EnumName(HashAlgorithm_name, int32(x))
+}
+
+func (HashAlgorithm) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{5}
 }
 
 type ErrorCode int32
@@ -182,7 +238,7 @@ func (x ErrorCode) String() string {
 }
 
 func (ErrorCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{4}
+	return fileDescriptor_311ef540e10d9705, []int{6}
 }
 
 type FileDownloadProgressUpdateType int32
@@ -207,7 +263,7 @@ func (x FileDownloadProgressUpdateType) String() string {
 }
 
 func (FileDownloadProgressUpdateType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{5}
+	return fileDescriptor_311ef540e10d9705, []int{7}
 }
 
 type Hello struct {
@@ -372,16 +428,19 @@ func (m *Folder) XXX_DiscardUnknown() {
 var xxx_messageInfo_Folder proto.InternalMessageInfo
 
 type Device struct {
-	ID                       DeviceID    `protobuf:"bytes,1,opt,name=id,proto3,customtype=DeviceID" json:"id" xml:"id"`
-	Name                     string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name" xml:"name"`
-	Addresses                []string    `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses" xml:"address"`
-	Compression              Compression `protobuf:"varint,4,opt,name=compression,proto3,enum=protocol.Compression" json:"compression" xml:"compression"`
-	CertName                 string      `protobuf:"bytes,5,opt,name=cert_name,json=certName,proto3" json:"certName" xml:"certName"`
-	MaxSequence              int64       `protobuf:"varint,6,opt,name=max_sequence,json=maxSequence,proto3" json:"maxSequence" xml:"maxSequence"`
-	Introducer               bool        `protobuf:"varint,7,opt,name=introducer,proto3" json:"introducer" xml:"introducer"`
-	IndexID                  IndexID     `protobuf:"varint,8,opt,name=index_id,json=indexId,proto3,customtype=IndexID" json:"indexId" xml:"indexId"`
-	SkipIntroductionRemovals bool        `protobuf:"varint,9,opt,name=skip_introduction_removals,json=skipIntroductionRemovals,proto3" json:"skipIntroductionRemovals" xml:"skipIntroductionRemovals"`
-	EncryptionPasswordToken  []byte      `protobuf:"bytes,10,opt,name=encryption_password_token,json=encryptionPasswordToken,proto3" json:"encryptionPasswordToken" xml:"encryptionPasswordToken"`
+	ID                              DeviceID        `protobuf:"bytes,1,opt,name=id,proto3,customtype=DeviceID" json:"id" xml:"id"`
+	Name                            string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name" xml:"name"`
+	Addresses                       []string        `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses" xml:"address"`
+	Compression                     Compression     `protobuf:"varint,4,opt,name=compression,proto3,enum=protocol.Compression" json:"compression" xml:"compression"`
+	CertName                        string          `protobuf:"bytes,5,opt,name=cert_name,json=certName,proto3" json:"certName" xml:"certName"`
+	MaxSequence                     int64           `protobuf:"varint,6,opt,name=max_sequence,json=maxSequence,proto3" json:"maxSequence" xml:"maxSequence"`
+	Introducer                      bool            `protobuf:"varint,7,opt,name=introducer,proto3" json:"introducer" xml:"introducer"`
+	IndexID                         IndexID         `protobuf:"varint,8,opt,name=index_id,json=indexId,proto3,customtype=IndexID" json:"indexId" xml:"indexId"`
+	SkipIntroductionRemovals        bool            `protobuf:"varint,9,opt,name=skip_introduction_removals,json=skipIntroductionRemovals,proto3" json:"skipIntroductionRemovals" xml:"skipIntroductionRemovals"`
+	EncryptionPasswordToken         []byte          `protobuf:"bytes,10,opt,name=encryption_password_token,json=encryptionPasswordToken,proto3" json:"encryptionPasswordToken" xml:"encryptionPasswordToken"`
+	IndexSummaries                  bool            `protobuf:"varint,11,opt,name=index_summaries,json=indexSummaries,proto3" json:"indexSummaries" xml:"indexSummaries"`
+	PreviousEncryptionPasswordToken []byte          `protobuf:"bytes,12,opt,name=previous_encryption_password_token,json=previousEncryptionPasswordToken,proto3" json:"previousEncryptionPasswordToken" xml:"previousEncryptionPasswordToken"`
+	HashAlgorithms                  []HashAlgorithm `protobuf:"varint,13,rep,packed,name=hash_algorithms,json=hashAlgorithms,proto3,enum=protocol.HashAlgorithm" json:"hashAlgorithms" xml:"hashAlgorithm"`
 }
 
 func (m *Device) Reset()         { *m = Device{} }
@@ -496,22 +555,110 @@ func (m *IndexUpdate) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_IndexUpdate proto.InternalMessageInfo
 
+// An IndexSummary is used to reconcile the index of a device whose index ID
+// has changed, instead of transferring the entire index again. The receiver
+// of the index sends a REQUEST with hashes over what it currently knows,
+// the sender replies with the DIFFERENCES, sends index updates for the
+// files in the differing buckets and finishes with COMPLETE.
+type IndexSummary struct {
+	Folder       string               `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder" xml:"folder"`
+	Type         IndexSummaryType     `protobuf:"varint,2,opt,name=type,proto3,enum=protocol.IndexSummaryType" json:"type" xml:"type"`
+	Buckets      []IndexSummaryBucket `protobuf:"bytes,3,rep,name=buckets,proto3" json:"buckets" xml:"bucket"`
+	LastSequence int64                `protobuf:"varint,4,opt,name=last_sequence,json=lastSequence,proto3" json:"lastSequence" xml:"lastSequence"`
+}
+
+func (m *IndexSummary) Reset()         { *m = IndexSummary{} }
+func (m *IndexSummary) String() string { return proto.CompactTextString(m) }
+func (*IndexSummary) ProtoMessage()    {}
+func (*IndexSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{7}
+}
+func (m *IndexSummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IndexSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IndexSummary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IndexSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexSummary.Merge(m, src)
+}
+func (m *IndexSummary) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *IndexSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IndexSummary proto.InternalMessageInfo
+
+// A bucket covers the entry with the name given by the prefix and everything
+// below it, except what is covered by a bucket with a longer prefix. The
+// bucket with the empty prefix covers everything else.
+type IndexSummaryBucket struct {
+	Prefix string `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix" xml:"prefix"`
+	Files  int64  `protobuf:"varint,2,opt,name=files,proto3" json:"files" xml:"files"`
+	Hash   []byte `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash" xml:"hash"`
+}
+
+func (m *IndexSummaryBucket) Reset()         { *m = IndexSummaryBucket{} }
+func (m *IndexSummaryBucket) String() string { return proto.CompactTextString(m) }
+func (*IndexSummaryBucket) ProtoMessage()    {}
+func (*IndexSummaryBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_311ef540e10d9705, []int{8}
+}
+func (m *IndexSummaryBucket) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IndexSummaryBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IndexSummaryBucket.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IndexSummaryBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IndexSummaryBucket.Merge(m, src)
+}
+func (m *IndexSummaryBucket) XXX_Size() int {
+	return m.ProtoSize()
+}
+func (m *IndexSummaryBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_IndexSummaryBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IndexSummaryBucket proto.InternalMessageInfo
+
 type FileInfo struct {
-	Name          string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name" xml:"name"`
-	Size          int64        `protobuf:"varint,3,opt,name=size,proto3" json:"size" xml:"size"`
-	ModifiedS     int64        `protobuf:"varint,5,opt,name=modified_s,json=modifiedS,proto3" json:"modifiedS" xml:"modifiedS"`
-	ModifiedBy    ShortID      `protobuf:"varint,12,opt,name=modified_by,json=modifiedBy,proto3,customtype=ShortID" json:"modifiedBy" xml:"modifiedBy"`
-	Version       Vector       `protobuf:"bytes,9,opt,name=version,proto3" json:"version" xml:"version"`
-	Sequence      int64        `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence" xml:"sequence"`
-	Blocks        []BlockInfo  `protobuf:"bytes,16,rep,name=blocks,proto3" json:"blocks" xml:"block"`
-	SymlinkTarget string       `protobuf:"bytes,17,opt,name=symlink_target,json=symlinkTarget,proto3" json:"symlinkTarget" xml:"symlinkTarget"`
-	BlocksHash    []byte       `protobuf:"bytes,18,opt,name=blocks_hash,json=blocksHash,proto3" json:"blocksHash" xml:"blocksHash"`
-	Encrypted     []byte       `protobuf:"bytes,19,opt,name=encrypted,proto3" json:"encrypted" xml:"encrypted"`
-	Type          FileInfoType `protobuf:"varint,2,opt,name=type,proto3,enum=protocol.FileInfoType" json:"type" xml:"type"`
-	Permissions   uint32       `protobuf:"varint,4,opt,name=permissions,proto3" json:"permissions" xml:"permissions"`
-	ModifiedNs    int          `protobuf:"varint,11,opt,name=modified_ns,json=modifiedNs,proto3,casttype=int" json:"modifiedNs" xml:"modifiedNs"`
-	RawBlockSize  int          `protobuf:"varint,13,opt,name=block_size,json=blockSize,proto3,casttype=int" json:"blockSize" xml:"blockSize"`
-	Platform      PlatformData `protobuf:"bytes,14,opt,name=platform,proto3" json:"platform" xml:"platform"`
+	Name               string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name" xml:"name"`
+	Size               int64         `protobuf:"varint,3,opt,name=size,proto3" json:"size" xml:"size"`
+	ModifiedS          int64         `protobuf:"varint,5,opt,name=modified_s,json=modifiedS,proto3" json:"modifiedS" xml:"modifiedS"`
+	ModifiedBy         ShortID       `protobuf:"varint,12,opt,name=modified_by,json=modifiedBy,proto3,customtype=ShortID" json:"modifiedBy" xml:"modifiedBy"`
+	Version            Vector        `protobuf:"bytes,9,opt,name=version,proto3" json:"version" xml:"version"`
+	Sequence           int64         `protobuf:"varint,10,opt,name=sequence,proto3" json:"sequence" xml:"sequence"`
+	Blocks             []BlockInfo   `protobuf:"bytes,16,rep,name=blocks,proto3" json:"blocks" xml:"block"`
+	SymlinkTarget      string        `protobuf:"bytes,17,opt,name=symlink_target,json=symlinkTarget,proto3" json:"symlinkTarget" xml:"symlinkTarget"`
+	BlocksHash         []byte        `protobuf:"bytes,18,opt,name=blocks_hash,json=blocksHash,proto3" json:"blocksHash" xml:"blocksHash"`
+	Encrypted          []byte        `protobuf:"bytes,19,opt,name=encrypted,proto3" json:"encrypted" xml:"encrypted"`
+	Type               FileInfoType  `protobuf:"varint,2,opt,name=type,proto3,enum=protocol.FileInfoType" json:"type" xml:"type"`
+	Permissions        uint32        `protobuf:"varint,4,opt,name=permissions,proto3" json:"permissions" xml:"permissions"`
+	ModifiedNs         int           `protobuf:"varint,11,opt,name=modified_ns,json=modifiedNs,proto3,casttype=int" json:"modifiedNs" xml:"modifiedNs"`
+	RawBlockSize       int           `protobuf:"varint,13,opt,name=block_size,json=blockSize,proto3,casttype=int" json:"blockSize" xml:"blockSize"`
+	BlockHashAlgorithm HashAlgorithm `protobuf:"varint,20,opt,name=block_hash_algorithm,json=blockHashAlgorithm,proto3,enum=protocol.HashAlgorithm" json:"blockHashAlgorithm" xml:"blockHashAlgorithm"`
+	Platform           PlatformData  `protobuf:"bytes,14,opt,name=platform,proto3" json:"platform" xml:"platform"`
 	// The local_flags fields stores flags that are relevant to the local
 	// host only. It is not part of the protocol, doesn't get sent or
 	// received (we make sure to zero it), nonetheless we need it on our
@@ -534,7 +681,7 @@ type FileInfo struct {
 func (m *FileInfo) Reset()      { *m = FileInfo{} }
 func (*FileInfo) ProtoMessage() {}
 func (*FileInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{7}
+	return fileDescriptor_311ef540e10d9705, []int{9}
 }
 func (m *FileInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -573,7 +720,7 @@ type BlockInfo struct {
 func (m *BlockInfo) Reset()      { *m = BlockInfo{} }
 func (*BlockInfo) ProtoMessage() {}
 func (*BlockInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{8}
+	return fileDescriptor_311ef540e10d9705, []int{10}
 }
 func (m *BlockInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -610,7 +757,7 @@ func (m *Vector) Reset()         { *m = Vector{} }
 func (m *Vector) String() string { return proto.CompactTextString(m) }
 func (*Vector) ProtoMessage()    {}
 func (*Vector) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{9}
+	return fileDescriptor_311ef540e10d9705, []int{11}
 }
 func (m *Vector) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -648,7 +795,7 @@ func (m *Counter) Reset()         { *m = Counter{} }
 func (m *Counter) String() string { return proto.CompactTextString(m) }
 func (*Counter) ProtoMessage()    {}
 func (*Counter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{10}
+	return fileDescriptor_311ef540e10d9705, []int{12}
 }
 func (m *Counter) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -690,7 +837,7 @@ func (m *PlatformData) Reset()         { *m = PlatformData{} }
 func (m *PlatformData) String() string { return proto.CompactTextString(m) }
 func (*PlatformData) ProtoMessage()    {}
 func (*PlatformData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{11}
+	return fileDescriptor_311ef540e10d9705, []int{13}
 }
 func (m *PlatformData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -733,7 +880,7 @@ func (m *UnixData) Reset()         { *m = UnixData{} }
 func (m *UnixData) String() string { return proto.CompactTextString(m) }
 func (*UnixData) ProtoMessage()    {}
 func (*UnixData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{12}
+	return fileDescriptor_311ef540e10d9705, []int{14}
 }
 func (m *UnixData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -774,7 +921,7 @@ func (m *WindowsData) Reset()         { *m = WindowsData{} }
 func (m *WindowsData) String() string { return proto.CompactTextString(m) }
 func (*WindowsData) ProtoMessage()    {}
 func (*WindowsData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{13}
+	return fileDescriptor_311ef540e10d9705, []int{15}
 }
 func (m *WindowsData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -811,7 +958,7 @@ func (m *XattrData) Reset()         { *m = XattrData{} }
 func (m *XattrData) String() string { return proto.CompactTextString(m) }
 func (*XattrData) ProtoMessage()    {}
 func (*XattrData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{14}
+	return fileDescriptor_311ef540e10d9705, []int{16}
 }
 func (m *XattrData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -849,7 +996,7 @@ func (m *Xattr) Reset()         { *m = Xattr{} }
 func (m *Xattr) String() string { return proto.CompactTextString(m) }
 func (*Xattr) ProtoMessage()    {}
 func (*Xattr) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{15}
+	return fileDescriptor_311ef540e10d9705, []int{17}
 }
 func (m *Xattr) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -879,22 +1026,23 @@ func (m *Xattr) XXX_DiscardUnknown() {
 var xxx_messageInfo_Xattr proto.InternalMessageInfo
 
 type Request struct {
-	ID            int    `protobuf:"varint,1,opt,name=id,proto3,casttype=int" json:"id" xml:"id"`
-	Folder        string `protobuf:"bytes,2,opt,name=folder,proto3" json:"folder" xml:"folder"`
-	Name          string `protobuf:"bytes,3,opt,name=name,proto3" json:"name" xml:"name"`
-	Offset        int64  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset" xml:"offset"`
-	Size          int    `protobuf:"varint,5,opt,name=size,proto3,casttype=int" json:"size" xml:"size"`
-	Hash          []byte `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash" xml:"hash"`
-	FromTemporary bool   `protobuf:"varint,7,opt,name=from_temporary,json=fromTemporary,proto3" json:"fromTemporary" xml:"fromTemporary"`
-	WeakHash      uint32 `protobuf:"varint,8,opt,name=weak_hash,json=weakHash,proto3" json:"weakHash" xml:"weakHash"`
-	BlockNo       int    `protobuf:"varint,9,opt,name=block_no,json=blockNo,proto3,casttype=int" json:"blockNo" xml:"blockNo"`
+	ID            int           `protobuf:"varint,1,opt,name=id,proto3,casttype=int" json:"id" xml:"id"`
+	Folder        string        `protobuf:"bytes,2,opt,name=folder,proto3" json:"folder" xml:"folder"`
+	Name          string        `protobuf:"bytes,3,opt,name=name,proto3" json:"name" xml:"name"`
+	Offset        int64         `protobuf:"varint,4,opt,name=offset,proto3" json:"offset" xml:"offset"`
+	Size          int           `protobuf:"varint,5,opt,name=size,proto3,casttype=int" json:"size" xml:"size"`
+	Hash          []byte        `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash" xml:"hash"`
+	FromTemporary bool          `protobuf:"varint,7,opt,name=from_temporary,json=fromTemporary,proto3" json:"fromTemporary" xml:"fromTemporary"`
+	WeakHash      uint32        `protobuf:"varint,8,opt,name=weak_hash,json=weakHash,proto3" json:"weakHash" xml:"weakHash"`
+	BlockNo       int           `protobuf:"varint,9,opt,name=block_no,json=blockNo,proto3,casttype=int" json:"blockNo" xml:"blockNo"`
+	HashAlgorithm HashAlgorithm `protobuf:"varint,10,opt,name=hash_algorithm,json=hashAlgorithm,proto3,enum=protocol.HashAlgorithm" json:"hashAlgorithm" xml:"hashAlgorithm"`
 }
 
 func (m *Request) Reset()         { *m = Request{} }
 func (m *Request) String() string { return proto.CompactTextString(m) }
 func (*Request) ProtoMessage()    {}
 func (*Request) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{16}
+	return fileDescriptor_311ef540e10d9705, []int{18}
 }
 func (m *Request) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -933,7 +1081,7 @@ func (m *Response) Reset()         { *m = Response{} }
 func (m *Response) String() string { return proto.CompactTextString(m) }
 func (*Response) ProtoMessage()    {}
 func (*Response) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{17}
+	return fileDescriptor_311ef540e10d9705, []int{19}
 }
 func (m *Response) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -971,7 +1119,7 @@ func (m *DownloadProgress) Reset()         { *m = DownloadProgress{} }
 func (m *DownloadProgress) String() string { return proto.CompactTextString(m) }
 func (*DownloadProgress) ProtoMessage()    {}
 func (*DownloadProgress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{18}
+	return fileDescriptor_311ef540e10d9705, []int{20}
 }
 func (m *DownloadProgress) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1012,7 +1160,7 @@ func (m *FileDownloadProgressUpdate) Reset()         { *m = FileDownloadProgress
 func (m *FileDownloadProgressUpdate) String() string { return proto.CompactTextString(m) }
 func (*FileDownloadProgressUpdate) ProtoMessage()    {}
 func (*FileDownloadProgressUpdate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{19}
+	return fileDescriptor_311ef540e10d9705, []int{21}
 }
 func (m *FileDownloadProgressUpdate) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1048,7 +1196,7 @@ func (m *Ping) Reset()         { *m = Ping{} }
 func (m *Ping) String() string { return proto.CompactTextString(m) }
 func (*Ping) ProtoMessage()    {}
 func (*Ping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{20}
+	return fileDescriptor_311ef540e10d9705, []int{22}
 }
 func (m *Ping) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1085,7 +1233,7 @@ func (m *Close) Reset()         { *m = Close{} }
 func (m *Close) String() string { return proto.CompactTextString(m) }
 func (*Close) ProtoMessage()    {}
 func (*Close) Descriptor() ([]byte, []int) {
-	return fileDescriptor_311ef540e10d9705, []int{21}
+	return fileDescriptor_311ef540e10d9705, []int{23}
 }
 func (m *Close) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1118,7 +1266,9 @@ func init() {
 	proto.RegisterEnum("protocol.MessageType", MessageType_name, MessageType_value)
 	proto.RegisterEnum("protocol.MessageCompression", MessageCompression_name, MessageCompression_value)
 	proto.RegisterEnum("protocol.Compression", Compression_name, Compression_value)
+	proto.RegisterEnum("protocol.IndexSummaryType", IndexSummaryType_name, IndexSummaryType_value)
 	proto.RegisterEnum("protocol.FileInfoType", FileInfoType_name, FileInfoType_value)
+	proto.RegisterEnum("protocol.HashAlgorithm", HashAlgorithm_name, HashAlgorithm_value)
 	proto.RegisterEnum("protocol.ErrorCode", ErrorCode_name, ErrorCode_value)
 	proto.RegisterEnum("protocol.FileDownloadProgressUpdateType", FileDownloadProgressUpdateType_name, FileDownloadProgressUpdateType_value)
 	proto.RegisterType((*Hello)(nil), "protocol.Hello")
@@ -1128,6 +1278,8 @@ func init() {
 	proto.RegisterType((*Device)(nil), "protocol.Device")
 	proto.RegisterType((*Index)(nil), "protocol.Index")
 	proto.RegisterType((*IndexUpdate)(nil), "protocol.IndexUpdate")
+	proto.RegisterType((*IndexSummary)(nil), "protocol.IndexSummary")
+	proto.RegisterType((*IndexSummaryBucket)(nil), "protocol.IndexSummaryBucket")
 	proto.RegisterType((*FileInfo)(nil), "protocol.FileInfo")
 	proto.RegisterType((*BlockInfo)(nil), "protocol.BlockInfo")
 	proto.RegisterType((*Vector)(nil), "protocol.Vector")
@@ -1148,213 +1300,239 @@ func init() {
 func init() { proto.RegisterFile("lib/protocol/bep.proto", fileDescriptor_311ef540e10d9705) }
 
 var fileDescriptor_311ef540e10d9705 = []byte{
-	// 3292 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0x4d, 0x6c, 0x23, 0x47,
-	0x76, 0x16, 0x7f, 0x45, 0x95, 0x7e, 0x86, 0xaa, 0xf9, 0xa3, 0x39, 0x63, 0x35, 0x53, 0x3b, 0x9b,
-	0xc8, 0xda, 0xec, 0x78, 0xad, 0xf5, 0x6e, 0x1c, 0xdb, 0xb1, 0x21, 0xfe, 0x48, 0xc3, 0xb5, 0x86,
-	0x94, 0x8b, 0x9a, 0xf1, 0x7a, 0x80, 0x80, 0x68, 0xb1, 0x4b, 0x54, 0x63, 0xc8, 0x6e, 0xa6, 0xbb,
-	0xa9, 0x9f, 0x45, 0x2e, 0xc1, 0x02, 0x8b, 0x40, 0x87, 0x20, 0xd8, 0x53, 0x10, 0xac, 0x90, 0xc5,
-	0x5e, 0x72, 0x0b, 0x90, 0x43, 0x2e, 0x7b, 0xca, 0x71, 0x6e, 0x19, 0x2c, 0x10, 0x20, 0xc8, 0xa1,
-	0x01, 0xcf, 0x5c, 0x12, 0xe6, 0xc6, 0x63, 0x0e, 0x41, 0x50, 0xaf, 0xaa, 0xab, 0xab, 0xf5, 0xe3,
-	0x68, 0xec, 0x5b, 0x6e, 0xac, 0xef, 0x7d, 0xef, 0x55, 0x75, 0xbd, 0x57, 0xef, 0xbd, 0x2a, 0x09,
-	0xdd, 0x19, 0xd8, 0x7b, 0xef, 0x8e, 0x3c, 0x37, 0x70, 0x7b, 0xee, 0xe0, 0xdd, 0x3d, 0x36, 0x7a,
-	0x08, 0x03, 0x5c, 0x88, 0xb0, 0xf2, 0x1c, 0x3b, 0x0e, 0x04, 0x58, 0xfe, 0x8e, 0xc7, 0x46, 0xae,
-	0x2f, 0xe8, 0x7b, 0xe3, 0xfd, 0x77, 0xfb, 0x6e, 0xdf, 0x85, 0x01, 0xfc, 0x12, 0x24, 0xf2, 0x3f,
-	0x69, 0x94, 0x7b, 0xc4, 0x06, 0x03, 0x17, 0xd7, 0xd0, 0xbc, 0xc5, 0x0e, 0xed, 0x1e, 0xeb, 0x3a,
-	0xe6, 0x90, 0x95, 0x52, 0x95, 0xd4, 0xea, 0x5c, 0x95, 0x4c, 0x42, 0x03, 0x09, 0xb8, 0x65, 0x0e,
-	0xd9, 0x34, 0x34, 0x8a, 0xc7, 0xc3, 0xc1, 0x87, 0x24, 0x86, 0x08, 0xd5, 0xe4, 0xdc, 0x48, 0x6f,
-	0x60, 0x33, 0x27, 0x10, 0x46, 0xd2, 0xb1, 0x11, 0x01, 0x27, 0x8c, 0xc4, 0x10, 0xa1, 0x9a, 0x1c,
-	0xb7, 0xd1, 0x92, 0x34, 0x72, 0xc8, 0x3c, 0xdf, 0x76, 0x9d, 0x52, 0x06, 0xec, 0xac, 0x4e, 0x42,
-	0x63, 0x51, 0x48, 0x9e, 0x0a, 0xc1, 0x34, 0x34, 0x6e, 0x6a, 0xa6, 0x24, 0x4a, 0x68, 0x92, 0x85,
-	0x9f, 0xa1, 0x1b, 0xce, 0x78, 0xd8, 0xed, 0xb9, 0x8e, 0xc3, 0x7a, 0x81, 0xed, 0x3a, 0x7e, 0x29,
-	0x5b, 0x49, 0xad, 0xe6, 0xaa, 0xef, 0x4d, 0x42, 0x63, 0xc9, 0x19, 0x0f, 0x6b, 0xb1, 0x64, 0x1a,
-	0x1a, 0xb7, 0xc0, 0x64, 0x12, 0x26, 0xff, 0x1d, 0x1a, 0x19, 0xdb, 0x09, 0xe8, 0x39, 0x3a, 0xfe,
-	0x04, 0xcd, 0x05, 0xf6, 0x90, 0xf9, 0x81, 0x39, 0x1c, 0x95, 0x72, 0x95, 0xd4, 0x6a, 0xa6, 0x5a,
-	0x99, 0x84, 0x46, 0x0c, 0x4e, 0x43, 0xe3, 0x06, 0x18, 0x54, 0x08, 0xa1, 0xb1, 0x94, 0xfc, 0x63,
-	0x0a, 0xe5, 0x1f, 0x31, 0xd3, 0x62, 0x1e, 0xde, 0x40, 0xd9, 0xe0, 0x64, 0x24, 0xb6, 0x7e, 0x69,
-	0xfd, 0xf6, 0xc3, 0xc8, 0xa9, 0x0f, 0x1f, 0x33, 0xdf, 0x37, 0xfb, 0x6c, 0xf7, 0x64, 0xc4, 0xaa,
-	0x77, 0x26, 0xa1, 0x01, 0xb4, 0x69, 0x68, 0x20, 0x61, 0xf7, 0x64, 0xc4, 0x08, 0x05, 0x0c, 0x5b,
-	0x68, 0xbe, 0xe7, 0x0e, 0x47, 0x1e, 0xf3, 0x61, 0xdf, 0xd2, 0x60, 0xe9, 0xfe, 0x05, 0x4b, 0xb5,
-	0x98, 0x53, 0x7d, 0x30, 0x09, 0x0d, 0x5d, 0x69, 0x1a, 0x1a, 0xcb, 0x62, 0x4f, 0x63, 0x8c, 0x50,
-	0x9d, 0x41, 0x7e, 0x95, 0x42, 0x8b, 0xb5, 0xc1, 0xd8, 0x0f, 0x98, 0x57, 0x73, 0x9d, 0x7d, 0xbb,
-	0x8f, 0x3f, 0x43, 0xb3, 0xfb, 0xee, 0xc0, 0x62, 0x9e, 0x5f, 0x4a, 0x55, 0x32, 0xab, 0xf3, 0xeb,
-	0xc5, 0x78, 0xce, 0x4d, 0x10, 0x54, 0x8d, 0x17, 0xa1, 0x31, 0x33, 0x09, 0x8d, 0x88, 0x38, 0x0d,
-	0x8d, 0x05, 0x98, 0x47, 0x8c, 0x09, 0x8d, 0x04, 0x7c, 0x4b, 0x7d, 0xd6, 0x73, 0x1d, 0xcb, 0xf4,
-	0x4e, 0xe0, 0x13, 0x0a, 0x62, 0x4b, 0x15, 0xa8, 0xb6, 0x54, 0x21, 0x84, 0xc6, 0x52, 0xf2, 0xdb,
-	0x2c, 0xca, 0x8b, 0x49, 0xf1, 0x43, 0x94, 0xb6, 0x2d, 0x19, 0xcb, 0x2b, 0xaf, 0x42, 0x23, 0xdd,
-	0xac, 0x4f, 0x42, 0x23, 0x6d, 0x5b, 0xd3, 0xd0, 0x28, 0x80, 0x09, 0xdb, 0x22, 0xbf, 0x7c, 0xf9,
-	0x20, 0xdd, 0xac, 0xd3, 0xb4, 0x6d, 0xe1, 0x87, 0x28, 0x37, 0x30, 0xf7, 0xd8, 0x40, 0x46, 0x6e,
-	0x69, 0x12, 0x1a, 0x02, 0x98, 0x86, 0xc6, 0x3c, 0xf0, 0x61, 0x44, 0xa8, 0x40, 0xf1, 0x47, 0x68,
-	0xce, 0x63, 0xa6, 0xd5, 0x75, 0x9d, 0xc1, 0x09, 0x44, 0x69, 0xa1, 0xba, 0x32, 0x09, 0x8d, 0x02,
-	0x07, 0xdb, 0xce, 0x80, 0xaf, 0x74, 0x09, 0xd4, 0x22, 0x80, 0x50, 0x25, 0xc3, 0x5d, 0x84, 0xed,
-	0xbe, 0xe3, 0x7a, 0xac, 0x3b, 0x62, 0xde, 0xd0, 0x86, 0xbd, 0x15, 0x91, 0x59, 0xa8, 0xfe, 0x60,
-	0x12, 0x1a, 0xcb, 0x42, 0xba, 0x13, 0x0b, 0xa7, 0xa1, 0x71, 0x57, 0xac, 0xfa, 0xbc, 0x84, 0xd0,
-	0x8b, 0x6c, 0xfc, 0x19, 0x5a, 0x94, 0x13, 0x58, 0x6c, 0xc0, 0x02, 0x06, 0xf1, 0x59, 0xa8, 0xfe,
-	0xfe, 0x24, 0x34, 0x16, 0x84, 0xa0, 0x0e, 0xf8, 0x34, 0x34, 0xb0, 0x66, 0x56, 0x80, 0x84, 0x26,
-	0x38, 0xd8, 0x42, 0xb7, 0x2c, 0xdb, 0x37, 0xf7, 0x06, 0xac, 0x1b, 0xb0, 0xe1, 0xa8, 0x6b, 0x3b,
-	0x16, 0x3b, 0x66, 0x7e, 0x29, 0x0f, 0x36, 0xd7, 0x27, 0xa1, 0x81, 0xa5, 0x7c, 0x97, 0x0d, 0x47,
-	0x4d, 0x21, 0x9d, 0x86, 0x46, 0x49, 0x24, 0x8c, 0x0b, 0x22, 0x42, 0x2f, 0xe1, 0xe3, 0x75, 0x94,
-	0x1f, 0x99, 0x63, 0x9f, 0x59, 0xa5, 0x59, 0xb0, 0x5b, 0x9e, 0x84, 0x86, 0x44, 0x54, 0xc0, 0x88,
-	0x21, 0xa1, 0x12, 0xe7, 0xc1, 0x27, 0x52, 0x90, 0x5f, 0x2a, 0x9e, 0x0f, 0xbe, 0x3a, 0x08, 0xe2,
-	0xe0, 0x93, 0x44, 0x65, 0x4b, 0x8c, 0x09, 0x8d, 0x04, 0xe4, 0x9f, 0xf3, 0x28, 0x2f, 0x94, 0x70,
-	0x55, 0x05, 0xcf, 0x42, 0x75, 0x9d, 0x1b, 0xf8, 0xf7, 0xd0, 0x28, 0x08, 0x59, 0xb3, 0x7e, 0x55,
-	0x30, 0xfd, 0xe5, 0xcb, 0x07, 0x29, 0x2d, 0xa0, 0xd6, 0x50, 0x56, 0xcb, 0x84, 0x70, 0x78, 0x1d,
-	0x91, 0x03, 0xc5, 0xe1, 0x75, 0x20, 0xfb, 0x01, 0x86, 0x3f, 0x46, 0x73, 0xa6, 0x65, 0xf1, 0x43,
-	0xc6, 0xfc, 0x52, 0xa6, 0x92, 0xe1, 0x31, 0xcb, 0xe3, 0x5e, 0x81, 0xd3, 0xd0, 0x58, 0x04, 0x2d,
-	0x89, 0x10, 0x1a, 0xcb, 0xf0, 0x9f, 0x26, 0x8f, 0x7e, 0xf6, 0x7c, 0x12, 0xf9, 0x76, 0x67, 0x9e,
-	0x47, 0x7a, 0x8f, 0x79, 0x32, 0xaf, 0xe7, 0xc4, 0x81, 0xe2, 0x91, 0xce, 0x41, 0x99, 0xd5, 0x45,
-	0xa4, 0x47, 0x00, 0xa1, 0x4a, 0x86, 0xb7, 0xd0, 0xc2, 0xd0, 0x3c, 0xee, 0xfa, 0xec, 0xcf, 0xc6,
-	0xcc, 0xe9, 0x31, 0x88, 0x99, 0x8c, 0x58, 0xc5, 0xd0, 0x3c, 0xee, 0x48, 0x58, 0xad, 0x42, 0xc3,
-	0x08, 0xd5, 0x19, 0xb8, 0x8a, 0x90, 0xed, 0x04, 0x9e, 0x6b, 0x8d, 0x7b, 0xcc, 0x93, 0x21, 0x02,
-	0xe5, 0x25, 0x46, 0x55, 0x79, 0x89, 0x21, 0x42, 0x35, 0x39, 0xee, 0xa3, 0x02, 0xc4, 0x6e, 0xd7,
-	0xb6, 0x4a, 0x85, 0x4a, 0x6a, 0x35, 0x5b, 0xdd, 0x96, 0xce, 0x9d, 0x85, 0x28, 0x04, 0xdf, 0x46,
-	0x3f, 0x79, 0xcc, 0x00, 0xbb, 0x69, 0xa9, 0xdd, 0x97, 0x63, 0x9e, 0x37, 0x22, 0xda, 0xdf, 0xc6,
-	0x3f, 0x69, 0xc4, 0xc7, 0x7f, 0x8e, 0xca, 0xfe, 0x73, 0x9b, 0x9f, 0x14, 0x31, 0x37, 0x2f, 0x18,
-	0x5d, 0x8f, 0x0d, 0xdd, 0x43, 0x73, 0xe0, 0x97, 0xe6, 0x60, 0xf1, 0x9f, 0x4c, 0x42, 0xa3, 0xc4,
-	0x59, 0x4d, 0x8d, 0x44, 0x25, 0x67, 0x1a, 0x1a, 0x2b, 0x22, 0xcf, 0x5d, 0x41, 0x20, 0xf4, 0x4a,
-	0x5d, 0x7c, 0x8c, 0xde, 0x62, 0x4e, 0xcf, 0x3b, 0x19, 0xc1, 0xb4, 0x23, 0xd3, 0xf7, 0x8f, 0x5c,
-	0xcf, 0xea, 0x06, 0xee, 0x73, 0xe6, 0x94, 0x10, 0x04, 0xf5, 0xc7, 0x93, 0xd0, 0xb8, 0x1b, 0x93,
-	0x76, 0x24, 0x67, 0x97, 0x53, 0xa6, 0xa1, 0xf1, 0x36, 0xcc, 0x7d, 0x85, 0x9c, 0xd0, 0xab, 0x34,
-	0xc9, 0xbf, 0xa4, 0x50, 0x0e, 0x36, 0x83, 0x9f, 0x66, 0x91, 0xd4, 0x65, 0x0a, 0x86, 0xd3, 0x2c,
-	0x90, 0x0b, 0xe9, 0x5f, 0xe2, 0xb8, 0x81, 0x72, 0xfb, 0xf6, 0x80, 0xf9, 0xa5, 0x34, 0x9c, 0x65,
-	0xac, 0x15, 0x12, 0x7b, 0xc0, 0x9a, 0xce, 0xbe, 0x5b, 0xbd, 0x27, 0x4f, 0xb3, 0x20, 0xaa, 0xb3,
-	0xc4, 0x47, 0x84, 0x0a, 0x90, 0xe7, 0xbe, 0x81, 0xe9, 0x07, 0x71, 0xcc, 0x65, 0x20, 0xe6, 0x20,
-	0xf7, 0x71, 0x81, 0x16, 0x74, 0x58, 0x26, 0xf6, 0x18, 0x24, 0x34, 0xc1, 0x21, 0xbf, 0x49, 0xa3,
-	0x79, 0xf8, 0xa2, 0x27, 0x23, 0xcb, 0x0c, 0xd8, 0xff, 0x97, 0xef, 0xe2, 0xc6, 0x46, 0x1e, 0x3b,
-	0x8c, 0x8d, 0x65, 0x63, 0x63, 0x5c, 0x70, 0xc1, 0x98, 0x0e, 0x12, 0x9a, 0xe0, 0x90, 0x5f, 0x2c,
-	0xa2, 0x42, 0xf4, 0x29, 0x2a, 0xef, 0xa5, 0xae, 0x91, 0xf7, 0xd6, 0x50, 0xd6, 0xb7, 0x7f, 0x16,
-	0x7d, 0x09, 0x70, 0xf9, 0x58, 0x71, 0xf9, 0x80, 0x50, 0xc0, 0xf0, 0xa7, 0x08, 0x0d, 0x5d, 0xcb,
-	0xde, 0xb7, 0x99, 0xd5, 0xf5, 0xf5, 0x7e, 0x2b, 0x42, 0x3b, 0xaa, 0x39, 0x50, 0x08, 0xa1, 0xb1,
-	0x94, 0xa7, 0x49, 0x65, 0x60, 0xef, 0xa4, 0xb4, 0x00, 0x09, 0xe0, 0xe3, 0x28, 0x01, 0x74, 0x0e,
-	0x5c, 0x2f, 0x80, 0x53, 0xaf, 0xa6, 0xa9, 0x9e, 0xa8, 0x8c, 0x12, 0x43, 0x84, 0x1f, 0x78, 0x49,
-	0xa6, 0x1a, 0x15, 0x6f, 0xa3, 0xd9, 0xa8, 0x69, 0xe5, 0x07, 0x3c, 0x51, 0x8b, 0x9e, 0xb2, 0x5e,
-	0xe0, 0x7a, 0xd5, 0x4a, 0x54, 0x8b, 0x0e, 0x55, 0x13, 0x2b, 0xf2, 0xca, 0x61, 0xd4, 0xbe, 0x46,
-	0x12, 0xfc, 0x21, 0x2a, 0x28, 0xd7, 0x20, 0xf8, 0x56, 0xc8, 0xb9, 0x7e, 0xec, 0x96, 0x25, 0xd9,
-	0x07, 0x45, 0x2e, 0x51, 0x32, 0xfc, 0x13, 0x94, 0xdf, 0x1b, 0xb8, 0xbd, 0xe7, 0x51, 0x51, 0xbc,
-	0x19, 0x2f, 0xa4, 0xca, 0x71, 0x88, 0xb8, 0xb7, 0xe5, 0x5a, 0x24, 0x55, 0x75, 0x39, 0x30, 0x24,
-	0x54, 0xc2, 0xbc, 0x23, 0xf7, 0x4f, 0x86, 0x03, 0xdb, 0x79, 0xde, 0x0d, 0x4c, 0xaf, 0xcf, 0x82,
-	0xd2, 0x72, 0xdc, 0x91, 0x4b, 0xc9, 0x2e, 0x08, 0x54, 0x47, 0x9e, 0x40, 0x09, 0x4d, 0xb2, 0xf8,
-	0x3d, 0x41, 0x98, 0xee, 0x1e, 0x98, 0xfe, 0x41, 0x09, 0x43, 0x3a, 0x82, 0x44, 0x2e, 0xe0, 0x47,
-	0xa6, 0x7f, 0xa0, 0xb6, 0x3d, 0x86, 0x08, 0xd5, 0xe4, 0xbc, 0x4f, 0x94, 0x29, 0x88, 0x59, 0xa5,
-	0x9b, 0x60, 0x02, 0x42, 0x41, 0x81, 0x2a, 0x14, 0x14, 0x42, 0x68, 0x2c, 0xc5, 0x55, 0xd9, 0x6f,
-	0x8b, 0x2e, 0xf9, 0xce, 0xc5, 0x03, 0x79, 0x8d, 0x86, 0x7b, 0x13, 0xcd, 0x9f, 0x6f, 0xde, 0x16,
-	0x45, 0x61, 0x1b, 0x25, 0xda, 0x36, 0x51, 0xd8, 0x46, 0x7a, 0xc3, 0xa6, 0x33, 0xf0, 0x4f, 0xb4,
-	0xb0, 0x74, 0xfc, 0xd2, 0x3c, 0x5c, 0x4f, 0xde, 0xd1, 0xe3, 0xb0, 0xe5, 0x5f, 0x88, 0xc3, 0x56,
-	0x7c, 0x2d, 0xd1, 0x68, 0x78, 0x1f, 0x89, 0x5d, 0xea, 0xc2, 0xa9, 0x5a, 0x04, 0x53, 0x5b, 0xaf,
-	0x42, 0x63, 0x81, 0x9a, 0x47, 0xe0, 0xfa, 0x8e, 0xfd, 0x33, 0xc6, 0x37, 0x6a, 0x2f, 0x1a, 0xa8,
-	0x8d, 0x52, 0x48, 0x64, 0xf8, 0x97, 0x2f, 0x1f, 0x24, 0xd4, 0x68, 0xac, 0x84, 0x9f, 0xa2, 0xc2,
-	0x68, 0x60, 0x06, 0xfb, 0xae, 0x37, 0x2c, 0x2d, 0x41, 0xb0, 0x6b, 0x7b, 0xb8, 0x23, 0x25, 0x75,
-	0x33, 0x30, 0xab, 0x44, 0x86, 0x99, 0xe2, 0xab, 0xc8, 0x8d, 0x00, 0x42, 0x95, 0x0c, 0xd7, 0xd1,
-	0xfc, 0xc0, 0xed, 0x99, 0x83, 0xee, 0xfe, 0xc0, 0xec, 0xfb, 0xa5, 0xff, 0x98, 0x85, 0x4d, 0x85,
-	0xe8, 0x00, 0x7c, 0x93, 0xc3, 0x6a, 0x33, 0x62, 0x88, 0x50, 0x4d, 0x8e, 0x1f, 0xa1, 0x05, 0x79,
-	0x8c, 0x44, 0x8c, 0xfd, 0xe7, 0x2c, 0x44, 0x08, 0xf8, 0x46, 0x0a, 0x64, 0x94, 0x2d, 0xeb, 0xa7,
-	0x4f, 0x84, 0x99, 0xce, 0xc0, 0x9f, 0xa3, 0x1b, 0xb6, 0xe3, 0x5a, 0xac, 0xdb, 0x3b, 0x30, 0x9d,
-	0x3e, 0xe3, 0xfe, 0x99, 0xcc, 0xc2, 0x69, 0x84, 0xf8, 0x07, 0x59, 0x0d, 0x44, 0xe0, 0xa3, 0x9b,
-	0xb2, 0x49, 0xd0, 0x50, 0x42, 0x93, 0x2c, 0x7c, 0x8c, 0xb4, 0xea, 0xd9, 0x0d, 0x3c, 0xd3, 0x1e,
-	0x30, 0x4f, 0xf8, 0xeb, 0xbf, 0x66, 0xc1, 0x61, 0x9f, 0x4e, 0x42, 0xe3, 0x76, 0xcc, 0xd9, 0x15,
-	0x14, 0xe9, 0xac, 0x7b, 0xe7, 0x2a, 0xb3, 0x26, 0x55, 0x11, 0x71, 0xb9, 0x32, 0xfe, 0x31, 0x6f,
-	0x96, 0x79, 0x43, 0x6f, 0xc9, 0xce, 0xfd, 0xbe, 0x68, 0x8b, 0x01, 0x52, 0xa9, 0x48, 0x8e, 0xa1,
-	0x2f, 0x86, 0x5f, 0x98, 0xa2, 0x59, 0xdb, 0x39, 0x34, 0x07, 0x76, 0xd4, 0x99, 0x7f, 0xf0, 0x2a,
-	0x34, 0x10, 0x35, 0x8f, 0x9a, 0x02, 0x15, 0x8d, 0x12, 0xfc, 0xd4, 0x1a, 0x25, 0x18, 0xf3, 0x46,
-	0x49, 0x63, 0xd2, 0x88, 0xc7, 0xd3, 0x8a, 0xe3, 0x26, 0x2e, 0x3f, 0x05, 0x30, 0x0d, 0xdb, 0xea,
-	0xb8, 0xc9, 0x8b, 0x8f, 0xd8, 0xd6, 0x04, 0x4a, 0x68, 0x92, 0xf5, 0x61, 0xf6, 0x6f, 0x7e, 0x6d,
-	0xcc, 0x90, 0xaf, 0x52, 0x68, 0x4e, 0xa5, 0x38, 0x5e, 0x5d, 0xc0, 0xff, 0x19, 0x70, 0x3f, 0x9c,
-	0xe6, 0x03, 0xe1, 0x77, 0x71, 0x9a, 0x0f, 0xc0, 0xe1, 0x80, 0xf1, 0xba, 0xee, 0xee, 0xef, 0xfb,
-	0x2c, 0x80, 0xba, 0x95, 0x11, 0x75, 0x5d, 0x20, 0xaa, 0xae, 0x8b, 0x21, 0xa1, 0x12, 0xc7, 0xef,
-	0xc9, 0xea, 0x95, 0x06, 0xb7, 0xbd, 0x7d, 0x79, 0xf5, 0x8a, 0x9c, 0x22, 0x8a, 0xd8, 0x47, 0x68,
-	0xee, 0x88, 0x99, 0xcf, 0x45, 0x5c, 0x8a, 0x94, 0x01, 0x79, 0x9d, 0x83, 0x32, 0x26, 0xc5, 0xe9,
-	0x88, 0x00, 0x42, 0x95, 0x4c, 0x7e, 0xe3, 0x33, 0x94, 0x17, 0xe5, 0x04, 0xef, 0xa0, 0x42, 0xcf,
-	0x1d, 0x3b, 0x41, 0x7c, 0xf7, 0x5e, 0xd6, 0x9b, 0x7e, 0x90, 0x54, 0x7f, 0x2f, 0x3a, 0x80, 0x11,
-	0x55, 0xf9, 0x48, 0x02, 0xbc, 0x5b, 0x97, 0x22, 0xf2, 0xf3, 0x14, 0x9a, 0x95, 0x8a, 0xf8, 0x91,
-	0xba, 0x03, 0x65, 0xab, 0x1f, 0x9c, 0xab, 0x92, 0x5f, 0x7f, 0x9f, 0xd6, 0x2b, 0xa4, 0xbc, 0x5a,
-	0x1f, 0x9a, 0x83, 0xb1, 0xd8, 0xa8, 0xac, 0xb8, 0x5a, 0x03, 0xa0, 0x8a, 0x0e, 0x8c, 0x08, 0x15,
-	0x28, 0xf9, 0x79, 0x16, 0x2d, 0xe8, 0x49, 0x84, 0xa7, 0xeb, 0xb1, 0x63, 0x1f, 0xc3, 0x62, 0x12,
-	0xfd, 0xd3, 0x13, 0xc7, 0x3e, 0x86, 0x34, 0x53, 0x7e, 0x11, 0x1a, 0x29, 0xee, 0x00, 0xce, 0x53,
-	0x0e, 0xe0, 0x03, 0x42, 0x01, 0xc3, 0x9f, 0xa3, 0xd9, 0x23, 0xdb, 0xb1, 0xdc, 0x23, 0x1f, 0x96,
-	0x31, 0xaf, 0x5f, 0x90, 0xbe, 0x10, 0x02, 0xb0, 0x54, 0x91, 0x96, 0x22, 0xb6, 0xda, 0x2e, 0x39,
-	0x26, 0x34, 0x92, 0xe0, 0x2d, 0x94, 0x1b, 0xd8, 0xce, 0xf8, 0x18, 0x02, 0x2c, 0x51, 0x66, 0x7f,
-	0x6a, 0x06, 0x81, 0x07, 0xe6, 0xee, 0x4b, 0x73, 0x82, 0x19, 0xbf, 0x25, 0xf0, 0x11, 0xa1, 0x02,
-	0xc5, 0x9f, 0xa1, 0xbc, 0x65, 0x7a, 0x47, 0xb6, 0xb8, 0xbb, 0x5d, 0x61, 0x69, 0x45, 0x5a, 0x92,
-	0xd4, 0xf8, 0x1e, 0x0b, 0x43, 0x42, 0x25, 0x8e, 0x19, 0x9a, 0xdd, 0xf7, 0x18, 0xdb, 0xf3, 0x2d,
-	0x68, 0x92, 0xae, 0xb0, 0xf6, 0x63, 0x6e, 0x8d, 0xdf, 0x76, 0x36, 0x3d, 0xc6, 0xaa, 0x1d, 0xb8,
-	0xed, 0x48, 0x35, 0xf5, 0xc5, 0x72, 0x0c, 0xb7, 0x1d, 0x49, 0xa3, 0x11, 0x09, 0x77, 0x51, 0xde,
-	0x61, 0x01, 0x9f, 0x25, 0x7f, 0xf5, 0x2c, 0xeb, 0x72, 0x96, 0x7c, 0x8b, 0x05, 0x62, 0x12, 0xa9,
-	0xa4, 0x56, 0x2f, 0x86, 0x7c, 0x0a, 0xc9, 0xa1, 0x92, 0x41, 0x7e, 0x91, 0x46, 0x85, 0xc8, 0xbf,
-	0xbc, 0xf9, 0x73, 0x8f, 0x1c, 0xe6, 0xe9, 0x2f, 0x94, 0x50, 0xf1, 0x01, 0x95, 0xb7, 0x50, 0x51,
-	0xc8, 0x14, 0x42, 0x68, 0x2c, 0xe5, 0x06, 0xfa, 0x9e, 0x3b, 0x1e, 0xe9, 0xaf, 0x93, 0x60, 0x00,
-	0xd0, 0x84, 0x01, 0x85, 0x10, 0x1a, 0x4b, 0xf1, 0x47, 0x28, 0x33, 0xb6, 0x2d, 0x70, 0x75, 0xae,
-	0xfa, 0xce, 0xab, 0xd0, 0xc8, 0x3c, 0x81, 0x13, 0xc0, 0xd1, 0x69, 0x68, 0xcc, 0x89, 0x80, 0xb3,
-	0x2d, 0xad, 0x7c, 0x72, 0x06, 0xe5, 0x72, 0xae, 0xdc, 0xb7, 0x2d, 0xf9, 0xf4, 0x08, 0xca, 0x5b,
-	0x42, 0xb9, 0xaf, 0x29, 0xf7, 0x93, 0xca, 0x5b, 0x5c, 0x99, 0x63, 0xbf, 0x4a, 0xa1, 0x79, 0x2d,
-	0x42, 0xbf, 0xfd, 0x5e, 0x6c, 0xa3, 0x25, 0x61, 0xc0, 0xf6, 0xbb, 0xf0, 0x81, 0xf2, 0xa9, 0x0d,
-	0x9a, 0x7f, 0x90, 0x34, 0xfd, 0x2d, 0x8e, 0xab, 0xe6, 0x5f, 0x07, 0x09, 0x4d, 0x70, 0x48, 0x07,
-	0xcd, 0x29, 0x87, 0xe3, 0x4d, 0x94, 0x3f, 0xe6, 0x83, 0x28, 0x21, 0xdd, 0x38, 0x17, 0x15, 0x71,
-	0xdb, 0x29, 0x68, 0xea, 0x40, 0xc0, 0x90, 0x50, 0x09, 0x93, 0x1e, 0xca, 0x01, 0xff, 0x8d, 0x6e,
-	0x13, 0x89, 0x3c, 0xb3, 0xf0, 0x7f, 0xe7, 0x99, 0xbf, 0xc8, 0xa2, 0x59, 0xca, 0x9b, 0x66, 0x3f,
-	0xc0, 0x3f, 0x52, 0xd9, 0x2e, 0x57, 0xfd, 0xee, 0x55, 0xe9, 0x2d, 0xf6, 0x4e, 0xf4, 0xc8, 0x13,
-	0x5f, 0x07, 0xd3, 0xd7, 0xbe, 0x0e, 0x46, 0x9f, 0x94, 0xb9, 0xc6, 0x27, 0xc5, 0x65, 0x29, 0xfb,
-	0xc6, 0x65, 0x29, 0x77, 0xfd, 0xb2, 0x14, 0x55, 0xca, 0xfc, 0x35, 0x2a, 0x65, 0x1b, 0x2d, 0xed,
-	0x7b, 0xee, 0x10, 0x9e, 0x02, 0x5d, 0xcf, 0xf4, 0x4e, 0x64, 0x57, 0x00, 0xa5, 0x9b, 0x4b, 0x76,
-	0x23, 0x81, 0x2a, 0xdd, 0x09, 0x94, 0xd0, 0x24, 0x2b, 0x59, 0x13, 0x0b, 0x6f, 0x56, 0x13, 0xf1,
-	0x27, 0xa8, 0x20, 0x3a, 0x5e, 0xc7, 0x85, 0x6b, 0x57, 0xae, 0xfa, 0x1d, 0x9e, 0xca, 0x00, 0x6b,
-	0xb9, 0x2a, 0x95, 0xc9, 0xb1, 0xfa, 0xec, 0x88, 0x40, 0xfe, 0x21, 0x85, 0x0a, 0x94, 0xf9, 0x23,
-	0xd7, 0xf1, 0xd9, 0x37, 0x0d, 0x82, 0x35, 0x94, 0xb5, 0xcc, 0xc0, 0x94, 0x61, 0x07, 0xbb, 0xc7,
-	0xc7, 0x6a, 0xf7, 0xf8, 0x80, 0x50, 0xc0, 0xf0, 0xa7, 0x28, 0xdb, 0x73, 0x2d, 0xe1, 0xfc, 0x25,
-	0x3d, 0x69, 0x36, 0x3c, 0xcf, 0xf5, 0x6a, 0xae, 0x25, 0xaf, 0x1d, 0x9c, 0xa4, 0x0c, 0xf0, 0x01,
-	0xa1, 0x80, 0x91, 0xbf, 0x4f, 0xa1, 0x62, 0xdd, 0x3d, 0x72, 0x06, 0xae, 0x69, 0xed, 0x78, 0x6e,
-	0xdf, 0x63, 0xbe, 0xff, 0x8d, 0x5e, 0x25, 0xba, 0x68, 0x76, 0x0c, 0x6f, 0x1a, 0xd1, 0xbb, 0xc4,
-	0x83, 0xe4, 0x35, 0xe8, 0xfc, 0x24, 0xe2, 0x01, 0x24, 0x7e, 0x4f, 0x95, 0xca, 0xca, 0xbe, 0x18,
-	0x13, 0x1a, 0x09, 0xc8, 0x6f, 0x32, 0xa8, 0x7c, 0xb5, 0x21, 0x3c, 0x44, 0xf3, 0x82, 0xd9, 0xd5,
-	0xfe, 0xf4, 0xb1, 0x7a, 0x9d, 0x35, 0xc0, 0xe5, 0x0c, 0x2e, 0x05, 0x63, 0x35, 0x56, 0x97, 0x82,
-	0x18, 0x22, 0x54, 0x93, 0xbf, 0xd1, 0x73, 0xac, 0x76, 0x95, 0xcf, 0x7c, 0xfb, 0xab, 0x7c, 0x07,
-	0x2d, 0x8a, 0x10, 0x8d, 0xde, 0xcd, 0xb3, 0x95, 0xcc, 0x6a, 0xae, 0xfa, 0x90, 0x67, 0xdb, 0x3d,
-	0xd1, 0xac, 0x46, 0x2f, 0xe6, 0xcb, 0x71, 0xb0, 0x0a, 0x30, 0x8a, 0xb6, 0xe2, 0x0c, 0x4d, 0x70,
-	0xf1, 0x66, 0xe2, 0xa6, 0x27, 0x8e, 0xfa, 0x1f, 0x5c, 0xf3, 0x66, 0xa7, 0xdd, 0xe4, 0x48, 0x1e,
-	0x65, 0x77, 0x6c, 0xa7, 0x4f, 0x3e, 0x42, 0xb9, 0xda, 0xc0, 0xf5, 0x21, 0xe3, 0x78, 0xcc, 0xf4,
-	0x5d, 0x47, 0x0f, 0x25, 0x81, 0x28, 0x57, 0x8b, 0x21, 0xa1, 0x12, 0x5f, 0xfb, 0x6d, 0x06, 0xcd,
-	0x6b, 0x7f, 0xa9, 0xc2, 0x7f, 0x82, 0xee, 0x3d, 0x6e, 0x74, 0x3a, 0x1b, 0x5b, 0x8d, 0xee, 0xee,
-	0x97, 0x3b, 0x8d, 0x6e, 0x6d, 0xfb, 0x49, 0x67, 0xb7, 0x41, 0xbb, 0xb5, 0x76, 0x6b, 0xb3, 0xb9,
-	0x55, 0x9c, 0x29, 0xdf, 0x3f, 0x3d, 0xab, 0x94, 0x34, 0x8d, 0xe4, 0x9f, 0x94, 0xfe, 0x10, 0xe1,
-	0x84, 0x7a, 0xb3, 0x55, 0x6f, 0xfc, 0xb4, 0x98, 0x2a, 0xdf, 0x3a, 0x3d, 0xab, 0x14, 0x35, 0x2d,
-	0xf1, 0xd2, 0xf8, 0xc7, 0xe8, 0xad, 0x8b, 0xec, 0xee, 0x93, 0x9d, 0xfa, 0xc6, 0x6e, 0xa3, 0x98,
-	0x2e, 0x97, 0x4f, 0xcf, 0x2a, 0x77, 0xce, 0x2b, 0xc9, 0x10, 0xfc, 0x01, 0xba, 0x95, 0x50, 0xa5,
-	0x8d, 0xcf, 0x9f, 0x34, 0x3a, 0xbb, 0xc5, 0x4c, 0xf9, 0xce, 0xe9, 0x59, 0x05, 0x6b, 0x5a, 0x51,
-	0x99, 0x58, 0x47, 0xb7, 0xcf, 0x69, 0x74, 0x76, 0xda, 0xad, 0x4e, 0xa3, 0x98, 0x2d, 0xdf, 0x3d,
-	0x3d, 0xab, 0xdc, 0x4c, 0xa8, 0xc8, 0xac, 0x52, 0x43, 0x2b, 0x09, 0x9d, 0x7a, 0xfb, 0x8b, 0xd6,
-	0x76, 0x7b, 0xa3, 0xde, 0xdd, 0xa1, 0xed, 0x2d, 0xda, 0xe8, 0x74, 0x8a, 0xb9, 0xb2, 0x71, 0x7a,
-	0x56, 0xb9, 0xa7, 0x29, 0x5f, 0x38, 0xe1, 0x6b, 0x68, 0x39, 0x61, 0x64, 0xa7, 0xd9, 0xda, 0x2a,
-	0xe6, 0xcb, 0x37, 0x4f, 0xcf, 0x2a, 0x37, 0x34, 0x3d, 0xee, 0xcb, 0x0b, 0xfb, 0x57, 0xdb, 0x6e,
-	0x77, 0x1a, 0xc5, 0xd9, 0x0b, 0xfb, 0x07, 0x0e, 0x5f, 0xfb, 0xbb, 0x14, 0xc2, 0x17, 0xff, 0x38,
-	0x88, 0x3f, 0x40, 0xa5, 0xc8, 0x48, 0xad, 0xfd, 0x78, 0x87, 0xaf, 0xb3, 0xd9, 0x6e, 0x75, 0x5b,
-	0xed, 0x56, 0xa3, 0x38, 0x93, 0xd8, 0x55, 0x4d, 0xab, 0xe5, 0x3a, 0x0c, 0xb7, 0xd1, 0xdd, 0xcb,
-	0x34, 0xb7, 0x9f, 0xbd, 0x5f, 0x4c, 0x95, 0xd7, 0x4f, 0xcf, 0x2a, 0xb7, 0x2f, 0x2a, 0x6e, 0x3f,
-	0x7b, 0xff, 0x77, 0x7f, 0xf5, 0xdd, 0xcb, 0x05, 0x6b, 0xbc, 0x01, 0xd2, 0x97, 0xf6, 0x1e, 0xba,
-	0xa5, 0x1b, 0x7e, 0xdc, 0xd8, 0xdd, 0xa8, 0x6f, 0xec, 0x6e, 0x14, 0x67, 0x84, 0x0f, 0x34, 0xea,
-	0x63, 0x16, 0x98, 0x90, 0x76, 0xbf, 0x87, 0x96, 0x13, 0x5f, 0xd1, 0x78, 0xda, 0xa0, 0x51, 0x44,
-	0xe9, 0xeb, 0x67, 0x87, 0xcc, 0xc3, 0xdf, 0x47, 0x58, 0x27, 0x6f, 0x6c, 0x7f, 0xb1, 0xf1, 0x65,
-	0xa7, 0x98, 0x2e, 0xdf, 0x3e, 0x3d, 0xab, 0x2c, 0x6b, 0xec, 0x8d, 0xc1, 0x91, 0x79, 0xe2, 0xaf,
-	0xfd, 0x53, 0x1a, 0x2d, 0xe8, 0xef, 0x46, 0xf8, 0xfb, 0xe8, 0xe6, 0x66, 0x73, 0x9b, 0x47, 0xe2,
-	0x66, 0x5b, 0x78, 0x80, 0x0f, 0x8b, 0x33, 0x62, 0x3a, 0x9d, 0xca, 0x7f, 0xe3, 0x3f, 0x42, 0xa5,
-	0x73, 0xf4, 0x7a, 0x93, 0x36, 0x6a, 0xbb, 0x6d, 0xfa, 0x65, 0x31, 0x55, 0x7e, 0x8b, 0x6f, 0x98,
-	0xae, 0x53, 0xb7, 0x3d, 0x48, 0x41, 0x27, 0xf8, 0x13, 0x74, 0xef, 0x9c, 0x62, 0xe7, 0xcb, 0xc7,
-	0xdb, 0xcd, 0xd6, 0x67, 0x62, 0xbe, 0x74, 0xf9, 0xed, 0xd3, 0xb3, 0xca, 0x5d, 0x5d, 0xb7, 0x23,
-	0x9e, 0xe2, 0x38, 0x54, 0x48, 0xe1, 0x47, 0xa8, 0x72, 0x85, 0x7e, 0xbc, 0x80, 0x4c, 0x99, 0x9c,
-	0x9e, 0x55, 0xee, 0x5f, 0x62, 0x44, 0xad, 0xa3, 0x90, 0xc2, 0x3f, 0x44, 0x77, 0x2e, 0xb7, 0x14,
-	0x9d, 0x8b, 0x4b, 0xf4, 0xd7, 0xfe, 0x35, 0x85, 0xe6, 0x54, 0xd5, 0xe3, 0x9b, 0xd6, 0xa0, 0xb4,
-	0xcd, 0x93, 0x44, 0xbd, 0xd1, 0x6d, 0xb5, 0xbb, 0x30, 0x8a, 0x36, 0x4d, 0xf1, 0x5a, 0x2e, 0xfc,
-	0xe4, 0x31, 0xae, 0xd1, 0xb7, 0x1a, 0xad, 0x06, 0x6d, 0xd6, 0x22, 0x8f, 0x2a, 0xf6, 0x16, 0x73,
-	0x98, 0x67, 0xf7, 0xf0, 0xfb, 0xe8, 0x6e, 0xd2, 0x78, 0xe7, 0x49, 0xed, 0x51, 0xb4, 0x4b, 0xb0,
-	0x40, 0x6d, 0x82, 0xce, 0xb8, 0x77, 0x00, 0x8e, 0xf9, 0x51, 0x42, 0xab, 0xd9, 0x7a, 0xba, 0xb1,
-	0xdd, 0xac, 0x0b, 0xad, 0x4c, 0xb9, 0x74, 0x7a, 0x56, 0xb9, 0xa5, 0xb4, 0xe4, 0x03, 0x07, 0x57,
-	0x5b, 0xfb, 0x5d, 0x0a, 0xad, 0x7c, 0x7d, 0xf1, 0xc2, 0x5f, 0xa0, 0x77, 0x60, 0xbf, 0x2e, 0xa4,
-	0x02, 0x99, 0xb7, 0xc4, 0x1e, 0x6e, 0xec, 0xec, 0x34, 0x5a, 0xf5, 0xe2, 0x4c, 0x79, 0xf5, 0xf4,
-	0xac, 0xf2, 0xe0, 0xeb, 0x4d, 0x6e, 0x8c, 0x46, 0xcc, 0xb1, 0xae, 0x69, 0x78, 0xb3, 0x4d, 0xb7,
-	0x1a, 0xbb, 0xc5, 0xd4, 0x75, 0x0c, 0x6f, 0xba, 0x5e, 0x9f, 0x05, 0xd5, 0xc7, 0x2f, 0xbe, 0x5a,
-	0x99, 0x79, 0xf9, 0xd5, 0xca, 0xcc, 0x8b, 0x57, 0x2b, 0xa9, 0x97, 0xaf, 0x56, 0x52, 0x7f, 0xfd,
-	0x7a, 0x65, 0xe6, 0xd7, 0xaf, 0x57, 0x52, 0x2f, 0x5f, 0xaf, 0xcc, 0xfc, 0xdb, 0xeb, 0x95, 0x99,
-	0x67, 0xdf, 0xeb, 0xdb, 0xc1, 0xc1, 0x78, 0xef, 0x61, 0xcf, 0x1d, 0xbe, 0xeb, 0x9f, 0x38, 0xbd,
-	0xe0, 0xc0, 0x76, 0xfa, 0xda, 0x2f, 0xfd, 0x1f, 0x58, 0xf6, 0xf2, 0xf0, 0xeb, 0x87, 0xff, 0x1b,
-	0x00, 0x00, 0xff, 0xff, 0xa6, 0x37, 0xc7, 0xbf, 0xd7, 0x22, 0x00, 0x00,
+	// 3707 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0xcb, 0x6f, 0x23, 0x47,
+	0x7a, 0x57, 0xf3, 0x21, 0x51, 0xa5, 0xc7, 0x50, 0x35, 0x2f, 0x9a, 0x33, 0x56, 0x33, 0xb5, 0xe3,
+	0x44, 0xd6, 0x66, 0xc7, 0x6b, 0xf9, 0x11, 0xc7, 0x76, 0x6c, 0xf0, 0x25, 0x89, 0x3b, 0x12, 0x29,
+	0x17, 0x35, 0x33, 0x3b, 0x03, 0x04, 0x8d, 0x16, 0xbb, 0x44, 0x35, 0x86, 0xec, 0x66, 0xba, 0x9b,
+	0x7a, 0x2c, 0x92, 0xd3, 0x22, 0x41, 0xa0, 0xc3, 0x22, 0xd8, 0x53, 0x12, 0xac, 0x92, 0xc5, 0x22,
+	0x41, 0x6e, 0x41, 0x72, 0xc8, 0x25, 0x7f, 0x81, 0x6f, 0x19, 0x38, 0x08, 0x10, 0xe4, 0xd0, 0x80,
+	0xc7, 0x97, 0x84, 0x7b, 0xd3, 0x25, 0x40, 0x0e, 0x41, 0x50, 0x8f, 0xae, 0xae, 0x26, 0xa5, 0xb1,
+	0xc6, 0x73, 0xcb, 0x49, 0xac, 0xdf, 0xf7, 0xfb, 0xbe, 0xee, 0xaa, 0xfa, 0xea, 0x7b, 0x54, 0x0b,
+	0xdc, 0xea, 0xd9, 0x7b, 0xef, 0x0c, 0x3c, 0x37, 0x70, 0x3b, 0x6e, 0xef, 0x9d, 0x3d, 0x32, 0xb8,
+	0xcf, 0x06, 0x30, 0x17, 0x61, 0xc5, 0x59, 0x72, 0x1c, 0x70, 0xb0, 0xf8, 0x3d, 0x8f, 0x0c, 0x5c,
+	0x9f, 0xd3, 0xf7, 0x86, 0xfb, 0xef, 0x74, 0xdd, 0xae, 0xcb, 0x06, 0xec, 0x17, 0x27, 0xa1, 0xff,
+	0x4d, 0x81, 0xec, 0x26, 0xe9, 0xf5, 0x5c, 0x58, 0x05, 0x73, 0x16, 0x39, 0xb4, 0x3b, 0xc4, 0x70,
+	0xcc, 0x3e, 0x29, 0x68, 0x25, 0x6d, 0x65, 0xb6, 0x82, 0x46, 0xa1, 0x0e, 0x38, 0xdc, 0x34, 0xfb,
+	0xe4, 0x3c, 0xd4, 0xf3, 0xc7, 0xfd, 0xde, 0xc7, 0x28, 0x86, 0x10, 0x56, 0xe4, 0xd4, 0x48, 0xa7,
+	0x67, 0x13, 0x27, 0xe0, 0x46, 0x52, 0xb1, 0x11, 0x0e, 0x27, 0x8c, 0xc4, 0x10, 0xc2, 0x8a, 0x1c,
+	0xb6, 0xc0, 0xa2, 0x30, 0x72, 0x48, 0x3c, 0xdf, 0x76, 0x9d, 0x42, 0x9a, 0xd9, 0x59, 0x19, 0x85,
+	0xfa, 0x02, 0x97, 0x3c, 0xe2, 0x82, 0xf3, 0x50, 0xbf, 0xae, 0x98, 0x12, 0x28, 0xc2, 0x49, 0x16,
+	0x7c, 0x0a, 0xae, 0x39, 0xc3, 0xbe, 0xd1, 0x71, 0x1d, 0x87, 0x74, 0x02, 0xdb, 0x75, 0xfc, 0x42,
+	0xa6, 0xa4, 0xad, 0x64, 0x2b, 0xef, 0x8e, 0x42, 0x7d, 0xd1, 0x19, 0xf6, 0xab, 0xb1, 0xe4, 0x3c,
+	0xd4, 0x6f, 0x30, 0x93, 0x49, 0x18, 0xfd, 0x4f, 0xa8, 0xa7, 0x6d, 0x27, 0xc0, 0x63, 0x74, 0xf8,
+	0x19, 0x98, 0x0d, 0xec, 0x3e, 0xf1, 0x03, 0xb3, 0x3f, 0x28, 0x64, 0x4b, 0xda, 0x4a, 0xba, 0x52,
+	0x1a, 0x85, 0x7a, 0x0c, 0x9e, 0x87, 0xfa, 0x35, 0x66, 0x50, 0x22, 0x08, 0xc7, 0x52, 0xf4, 0x8f,
+	0x1a, 0x98, 0xde, 0x24, 0xa6, 0x45, 0x3c, 0x58, 0x06, 0x99, 0xe0, 0x64, 0xc0, 0x97, 0x7e, 0x71,
+	0xed, 0xe6, 0xfd, 0x68, 0x53, 0xef, 0x6f, 0x13, 0xdf, 0x37, 0xbb, 0x64, 0xf7, 0x64, 0x40, 0x2a,
+	0xb7, 0x46, 0xa1, 0xce, 0x68, 0xe7, 0xa1, 0x0e, 0xb8, 0xdd, 0x93, 0x01, 0x41, 0x98, 0x61, 0xd0,
+	0x02, 0x73, 0x1d, 0xb7, 0x3f, 0xf0, 0x88, 0xcf, 0xd6, 0x2d, 0xc5, 0x2c, 0xdd, 0x9d, 0xb0, 0x54,
+	0x8d, 0x39, 0x95, 0x7b, 0xa3, 0x50, 0x57, 0x95, 0xce, 0x43, 0x7d, 0x89, 0xaf, 0x69, 0x8c, 0x21,
+	0xac, 0x32, 0xd0, 0x2f, 0x34, 0xb0, 0x50, 0xed, 0x0d, 0xfd, 0x80, 0x78, 0x55, 0xd7, 0xd9, 0xb7,
+	0xbb, 0xf0, 0x01, 0x98, 0xd9, 0x77, 0x7b, 0x16, 0xf1, 0xfc, 0x82, 0x56, 0x4a, 0xaf, 0xcc, 0xad,
+	0xe5, 0xe3, 0x67, 0xae, 0x33, 0x41, 0x45, 0xff, 0x32, 0xd4, 0xa7, 0x46, 0xa1, 0x1e, 0x11, 0xcf,
+	0x43, 0x7d, 0x9e, 0x3d, 0x87, 0x8f, 0x11, 0x8e, 0x04, 0x74, 0x49, 0x7d, 0xd2, 0x71, 0x1d, 0xcb,
+	0xf4, 0x4e, 0xd8, 0x14, 0x72, 0x7c, 0x49, 0x25, 0x28, 0x97, 0x54, 0x22, 0x08, 0xc7, 0x52, 0xf4,
+	0xcf, 0x19, 0x30, 0xcd, 0x1f, 0x0a, 0xef, 0x83, 0x94, 0x6d, 0x09, 0x5f, 0x5e, 0x7e, 0x11, 0xea,
+	0xa9, 0x46, 0x6d, 0x14, 0xea, 0x29, 0xdb, 0x3a, 0x0f, 0xf5, 0x1c, 0x33, 0x61, 0x5b, 0xe8, 0xe7,
+	0xcf, 0xef, 0xa5, 0x1a, 0x35, 0x9c, 0xb2, 0x2d, 0x78, 0x1f, 0x64, 0x7b, 0xe6, 0x1e, 0xe9, 0x09,
+	0xcf, 0x2d, 0x8c, 0x42, 0x9d, 0x03, 0xe7, 0xa1, 0x3e, 0xc7, 0xf8, 0x6c, 0x84, 0x30, 0x47, 0xe1,
+	0x27, 0x60, 0xd6, 0x23, 0xa6, 0x65, 0xb8, 0x4e, 0xef, 0x84, 0x79, 0x69, 0xae, 0xb2, 0x3c, 0x0a,
+	0xf5, 0x1c, 0x05, 0x5b, 0x4e, 0x8f, 0xbe, 0xe9, 0x22, 0x53, 0x8b, 0x00, 0x84, 0xa5, 0x0c, 0x1a,
+	0x00, 0xda, 0x5d, 0xc7, 0xf5, 0x88, 0x31, 0x20, 0x5e, 0xdf, 0xf6, 0x7d, 0xe9, 0x99, 0xb9, 0xca,
+	0x0f, 0x47, 0xa1, 0xbe, 0xc4, 0xa5, 0x3b, 0xb1, 0xf0, 0x3c, 0xd4, 0x6f, 0xf3, 0xb7, 0x1e, 0x97,
+	0x20, 0x3c, 0xc9, 0x86, 0x0f, 0xc0, 0x82, 0x78, 0x80, 0x45, 0x7a, 0x24, 0x20, 0xcc, 0x3f, 0x73,
+	0x95, 0xdf, 0x1c, 0x85, 0xfa, 0x3c, 0x17, 0xd4, 0x18, 0x7e, 0x1e, 0xea, 0x50, 0x31, 0xcb, 0x41,
+	0x84, 0x13, 0x1c, 0x68, 0x81, 0x1b, 0x96, 0xed, 0x9b, 0x7b, 0x3d, 0x62, 0x04, 0xa4, 0x3f, 0x30,
+	0x6c, 0xc7, 0x22, 0xc7, 0xc4, 0x2f, 0x4c, 0x33, 0x9b, 0x6b, 0xa3, 0x50, 0x87, 0x42, 0xbe, 0x4b,
+	0xfa, 0x83, 0x06, 0x97, 0x9e, 0x87, 0x7a, 0x81, 0x07, 0x8c, 0x09, 0x11, 0xc2, 0x17, 0xf0, 0xe1,
+	0x1a, 0x98, 0x1e, 0x98, 0x43, 0x9f, 0x58, 0x85, 0x19, 0x66, 0xb7, 0x38, 0x0a, 0x75, 0x81, 0x48,
+	0x87, 0xe1, 0x43, 0x84, 0x05, 0x4e, 0x9d, 0x8f, 0x87, 0x20, 0xbf, 0x90, 0x1f, 0x77, 0xbe, 0x1a,
+	0x13, 0xc4, 0xce, 0x27, 0x88, 0xd2, 0x16, 0x1f, 0x23, 0x1c, 0x09, 0xd0, 0x5f, 0xcd, 0x82, 0x69,
+	0xae, 0x04, 0x2b, 0xd2, 0x79, 0xe6, 0x2b, 0x6b, 0xd4, 0xc0, 0x7f, 0x84, 0x7a, 0x8e, 0xcb, 0x1a,
+	0xb5, 0xcb, 0x9c, 0xe9, 0x4f, 0x9f, 0xdf, 0xd3, 0x14, 0x87, 0x5a, 0x05, 0x19, 0x25, 0x12, 0xb2,
+	0xc3, 0xeb, 0x98, 0xfd, 0xf8, 0xf0, 0x3a, 0x2c, 0xfa, 0x31, 0x0c, 0x7e, 0x0a, 0x66, 0x4d, 0xcb,
+	0xa2, 0x87, 0x8c, 0xf8, 0x85, 0x74, 0x29, 0x4d, 0x7d, 0x96, 0xfa, 0xbd, 0x04, 0xcf, 0x43, 0x7d,
+	0x81, 0x69, 0x09, 0x04, 0xe1, 0x58, 0x06, 0x7f, 0x3f, 0x79, 0xf4, 0x33, 0xe3, 0x41, 0xe4, 0xf5,
+	0xce, 0x3c, 0xf5, 0xf4, 0x0e, 0xf1, 0x44, 0x5c, 0xcf, 0xf2, 0x03, 0x45, 0x3d, 0x9d, 0x82, 0x22,
+	0xaa, 0x73, 0x4f, 0x8f, 0x00, 0x84, 0xa5, 0x0c, 0x6e, 0x80, 0xf9, 0xbe, 0x79, 0x6c, 0xf8, 0xe4,
+	0x0f, 0x86, 0xc4, 0xe9, 0x10, 0xe6, 0x33, 0x69, 0xfe, 0x16, 0x7d, 0xf3, 0xb8, 0x2d, 0x60, 0xf9,
+	0x16, 0x0a, 0x86, 0xb0, 0xca, 0x80, 0x15, 0x00, 0x6c, 0x27, 0xf0, 0x5c, 0x6b, 0xd8, 0x21, 0x9e,
+	0x70, 0x11, 0x96, 0x5e, 0x62, 0x54, 0xa6, 0x97, 0x18, 0x42, 0x58, 0x91, 0xc3, 0x2e, 0xc8, 0x31,
+	0xdf, 0x35, 0x6c, 0xab, 0x90, 0x2b, 0x69, 0x2b, 0x99, 0xca, 0x96, 0xd8, 0xdc, 0x19, 0xe6, 0x85,
+	0x6c, 0x6f, 0xa3, 0x9f, 0xd4, 0x67, 0x18, 0xbb, 0x61, 0xc9, 0xd5, 0x17, 0x63, 0x1a, 0x37, 0x22,
+	0xda, 0x5f, 0xc6, 0x3f, 0x71, 0xc4, 0x87, 0x7f, 0x08, 0x8a, 0xfe, 0x33, 0x7b, 0x60, 0x44, 0xcf,
+	0xa6, 0x09, 0xc3, 0xf0, 0x48, 0xdf, 0x3d, 0x34, 0x7b, 0x7e, 0x61, 0x96, 0xbd, 0xfc, 0x67, 0xa3,
+	0x50, 0x2f, 0x50, 0x56, 0x43, 0x21, 0x61, 0xc1, 0x39, 0x0f, 0xf5, 0x65, 0x1e, 0xe7, 0x2e, 0x21,
+	0x20, 0x7c, 0xa9, 0x2e, 0x3c, 0x06, 0x6f, 0x10, 0xa7, 0xe3, 0x9d, 0x0c, 0xd8, 0x63, 0x07, 0xa6,
+	0xef, 0x1f, 0xb9, 0x9e, 0x65, 0x04, 0xee, 0x33, 0xe2, 0x14, 0x00, 0x73, 0xea, 0x4f, 0x47, 0xa1,
+	0x7e, 0x3b, 0x26, 0xed, 0x08, 0xce, 0x2e, 0xa5, 0x9c, 0x87, 0xfa, 0x9b, 0xec, 0xd9, 0x97, 0xc8,
+	0x11, 0xbe, 0x4c, 0x13, 0xb6, 0xc1, 0x35, 0xbe, 0xc0, 0xfe, 0xb0, 0xdf, 0x37, 0x3d, 0x9b, 0xf8,
+	0x85, 0x39, 0x36, 0xd9, 0x55, 0x9a, 0x6e, 0x99, 0xa8, 0x1d, 0x49, 0x64, 0xba, 0x4d, 0xc2, 0x08,
+	0x8f, 0xf1, 0xe0, 0x5f, 0x68, 0x00, 0x0d, 0x3c, 0x72, 0x68, 0xbb, 0x43, 0xdf, 0xb8, 0x7c, 0x62,
+	0xf3, 0x6c, 0x62, 0x5b, 0xa3, 0x50, 0xd7, 0x23, 0x76, 0xfd, 0xd2, 0x09, 0xbe, 0xc5, 0xc3, 0xc9,
+	0xcb, 0x79, 0x08, 0x7f, 0x9b, 0x25, 0xf8, 0x0c, 0x5c, 0x3b, 0x30, 0xfd, 0x03, 0xc3, 0xec, 0x75,
+	0x5d, 0xcf, 0x0e, 0x0e, 0xfa, 0x7e, 0x61, 0xa1, 0x94, 0x5e, 0x59, 0x5c, 0xbb, 0x1d, 0x1f, 0xbf,
+	0x4d, 0xd3, 0x3f, 0x28, 0x47, 0xf2, 0xca, 0xdb, 0x74, 0x25, 0x0e, 0x54, 0xc8, 0x97, 0xb5, 0x4c,
+	0x02, 0x46, 0x78, 0x8c, 0x86, 0xfe, 0x45, 0x03, 0x59, 0xe6, 0x6a, 0x34, 0x56, 0xf2, 0x94, 0x29,
+	0x12, 0x1c, 0x8b, 0x95, 0x1c, 0x99, 0x48, 0xae, 0x02, 0x87, 0x75, 0x90, 0xdd, 0xb7, 0x7b, 0xc4,
+	0x2f, 0xa4, 0x58, 0xa4, 0x84, 0x4a, 0x9a, 0xb6, 0x7b, 0xa4, 0xe1, 0xec, 0xbb, 0x95, 0x3b, 0x22,
+	0x56, 0x72, 0xa2, 0x8c, 0x54, 0x74, 0x84, 0x30, 0x07, 0x69, 0x66, 0xe9, 0x99, 0x7e, 0x10, 0x9f,
+	0xe8, 0x34, 0x3b, 0xd1, 0x2c, 0xb3, 0x50, 0x81, 0x72, 0xa4, 0xa1, 0x48, 0x9b, 0x31, 0x88, 0x70,
+	0x82, 0x83, 0x7e, 0x95, 0x02, 0x73, 0x6c, 0x46, 0x0f, 0x07, 0x96, 0x19, 0x90, 0xff, 0x2f, 0xf3,
+	0xa2, 0xc6, 0xa8, 0xe7, 0xc4, 0xc6, 0x32, 0xb1, 0x31, 0x2a, 0x98, 0x30, 0xa6, 0x82, 0x08, 0x27,
+	0x38, 0xe8, 0x1f, 0x52, 0x60, 0xbe, 0x11, 0x1f, 0x89, 0x93, 0xef, 0xb4, 0x4a, 0xeb, 0xa2, 0xc2,
+	0xe4, 0x75, 0x61, 0x31, 0x5e, 0x24, 0xd5, 0xf2, 0x15, 0xca, 0xcc, 0xc7, 0x60, 0x66, 0x6f, 0xd8,
+	0x79, 0x46, 0x02, 0x9e, 0xa7, 0xe6, 0xd6, 0xee, 0x5e, 0x6c, 0xaa, 0xc2, 0x48, 0x71, 0xf6, 0x15,
+	0x4a, 0xf2, 0xfd, 0xf8, 0x18, 0xe1, 0x48, 0x30, 0xb9, 0xfe, 0x99, 0xd7, 0xf0, 0xab, 0xbf, 0xd1,
+	0x00, 0x9c, 0x7c, 0x1b, 0x56, 0x62, 0x78, 0x64, 0xdf, 0x3e, 0x56, 0x17, 0x8e, 0x23, 0x71, 0x89,
+	0xc1, 0x86, 0xb4, 0xc4, 0x60, 0x3f, 0x68, 0x5d, 0x18, 0xb9, 0x17, 0x7d, 0x9f, 0x82, 0xea, 0x46,
+	0x73, 0xd2, 0x8d, 0x7c, 0xe9, 0x47, 0xab, 0x20, 0x43, 0x8f, 0x2d, 0x73, 0x9f, 0x79, 0xbe, 0x98,
+	0x74, 0x2c, 0x17, 0x93, 0x0e, 0x10, 0x66, 0x18, 0xfa, 0xe3, 0x45, 0x90, 0x8b, 0x9c, 0x54, 0xd6,
+	0x0b, 0xda, 0x15, 0xea, 0x85, 0x55, 0x90, 0xf1, 0xed, 0x9f, 0x44, 0x3e, 0xca, 0xb8, 0x74, 0x2c,
+	0xb9, 0x74, 0x80, 0x30, 0xc3, 0xe0, 0xe7, 0x00, 0xf4, 0x5d, 0xcb, 0xde, 0xb7, 0x89, 0x65, 0xf8,
+	0x6a, 0x9f, 0x12, 0xa1, 0x6d, 0x59, 0x54, 0x4b, 0x04, 0xe1, 0x58, 0x4a, 0xcb, 0x0b, 0x69, 0x60,
+	0xef, 0x84, 0xc5, 0xd9, 0x4c, 0xe5, 0xd3, 0x28, 0x71, 0xb6, 0x0f, 0x5c, 0x2f, 0x60, 0xd9, 0x52,
+	0x3e, 0xa6, 0x72, 0x22, 0x33, 0x71, 0x0c, 0x21, 0x9a, 0x28, 0x05, 0x19, 0x2b, 0x54, 0xb8, 0x05,
+	0x66, 0xa2, 0x66, 0x8f, 0x26, 0xc6, 0x44, 0x0d, 0xf7, 0x88, 0x74, 0x02, 0xd7, 0xab, 0x94, 0x22,
+	0x2f, 0x3a, 0x94, 0xcd, 0x1f, 0xcf, 0xc7, 0x87, 0x51, 0xdb, 0x17, 0x49, 0xe0, 0xc7, 0x20, 0x27,
+	0x3d, 0x08, 0xb0, 0xb9, 0xb2, 0x5a, 0xc5, 0x8f, 0xbd, 0x67, 0x51, 0xf4, 0x0f, 0x91, 0xe7, 0x48,
+	0x19, 0xfc, 0x11, 0x98, 0xde, 0xeb, 0xb9, 0x9d, 0x67, 0x51, 0x31, 0x79, 0x3d, 0x7e, 0x91, 0x0a,
+	0xc5, 0x59, 0x2c, 0x79, 0x53, 0xbc, 0x8b, 0xa0, 0x4a, 0x2f, 0x60, 0x43, 0x84, 0x05, 0x4c, 0x3b,
+	0x59, 0xff, 0xa4, 0xdf, 0xb3, 0x9d, 0x67, 0x46, 0x60, 0x7a, 0x5d, 0x12, 0x14, 0x96, 0xe2, 0x4e,
+	0x56, 0x48, 0x76, 0x99, 0x40, 0x46, 0xff, 0x04, 0x8a, 0x70, 0x92, 0x45, 0xfb, 0x6b, 0x6e, 0xda,
+	0x60, 0xee, 0x05, 0x99, 0x7b, 0xb1, 0x02, 0x88, 0xc3, 0x9b, 0xdc, 0xc9, 0xf2, 0xf1, 0xcb, 0x30,
+	0x08, 0x61, 0x45, 0x4e, 0xfb, 0x2b, 0x91, 0x40, 0x89, 0x55, 0xb8, 0xce, 0x4c, 0x30, 0x57, 0x90,
+	0xa0, 0x74, 0x05, 0x89, 0x20, 0x1c, 0x4b, 0x61, 0x25, 0x11, 0x45, 0x6e, 0x4d, 0x86, 0xda, 0x2b,
+	0x44, 0x90, 0x75, 0x30, 0x37, 0xde, 0xf4, 0x2c, 0xf0, 0x82, 0x70, 0x90, 0x68, 0x77, 0x78, 0x41,
+	0x38, 0x50, 0x1b, 0x1d, 0x95, 0x01, 0x7f, 0xa4, 0xb8, 0xa5, 0xc3, 0xeb, 0x8c, 0x2c, 0xcb, 0xae,
+	0xd2, 0xb9, 0x9a, 0xfe, 0x84, 0x1f, 0x36, 0xe3, 0x76, 0x5e, 0xa1, 0xc1, 0x7d, 0xc0, 0x57, 0xc9,
+	0x60, 0xa7, 0x6a, 0x81, 0x99, 0xda, 0x78, 0x11, 0xea, 0xf3, 0xd8, 0x3c, 0x62, 0x5b, 0xdf, 0xb6,
+	0x7f, 0x42, 0xe8, 0x42, 0xed, 0x45, 0x03, 0xb9, 0x50, 0x12, 0x89, 0x0c, 0xff, 0xfc, 0xf9, 0xbd,
+	0x84, 0x1a, 0x8e, 0x95, 0xe0, 0x1f, 0x81, 0x1b, 0xfc, 0x39, 0xc9, 0xa2, 0xa1, 0x70, 0xa3, 0xa4,
+	0xbd, 0xac, 0x66, 0x60, 0x2d, 0x16, 0x53, 0x4c, 0xe0, 0xb2, 0xc5, 0x9a, 0x14, 0x21, 0x7c, 0x01,
+	0x1f, 0x3e, 0x02, 0xb9, 0x41, 0xcf, 0x0c, 0xf6, 0x5d, 0xaf, 0x5f, 0x58, 0x64, 0x67, 0x4d, 0xd9,
+	0xc2, 0x1d, 0x21, 0xa9, 0x99, 0x81, 0x59, 0x41, 0xc2, 0xcb, 0x25, 0x5f, 0x1e, 0x9c, 0x08, 0x40,
+	0x58, 0xca, 0x60, 0x0d, 0xcc, 0xf5, 0xdc, 0x8e, 0xd9, 0x33, 0xf6, 0x7b, 0x66, 0xd7, 0x2f, 0xfc,
+	0xe7, 0x0c, 0xdb, 0x53, 0xe6, 0x9c, 0x0c, 0x5f, 0xa7, 0xb0, 0xdc, 0x8b, 0x18, 0x42, 0x58, 0x91,
+	0xc3, 0x4d, 0x30, 0x2f, 0x4e, 0x31, 0x77, 0xf1, 0xff, 0x9a, 0x61, 0x0e, 0xca, 0x5c, 0x43, 0x08,
+	0x84, 0x93, 0x2f, 0xa9, 0x87, 0x9f, 0x7b, 0xb9, 0xca, 0x80, 0x5f, 0xd0, 0x32, 0xd4, 0xb5, 0x88,
+	0xd1, 0x39, 0x30, 0x9d, 0x2e, 0xa1, 0xee, 0x31, 0x9a, 0x61, 0xc1, 0x80, 0x1d, 0x3f, 0x26, 0xab,
+	0x32, 0x51, 0x33, 0x2e, 0xbe, 0x12, 0x28, 0xc2, 0x49, 0x16, 0x3c, 0x06, 0x4a, 0xd1, 0x6b, 0x04,
+	0x9e, 0x69, 0xf7, 0x88, 0xc7, 0xdd, 0xe5, 0xd7, 0x33, 0xcc, 0x5f, 0x3e, 0x1f, 0x85, 0xfa, 0xcd,
+	0x98, 0xb3, 0xcb, 0x29, 0xc2, 0x57, 0xee, 0x8c, 0x15, 0xd4, 0x8a, 0x54, 0x3a, 0xe4, 0xc5, 0xca,
+	0xf0, 0x43, 0xda, 0xe3, 0xd2, 0x3e, 0xdc, 0x12, 0x0d, 0xf7, 0x5d, 0xde, 0xcd, 0x32, 0x48, 0x46,
+	0x42, 0x31, 0x66, 0xed, 0x2c, 0xfb, 0x05, 0x31, 0x98, 0xb1, 0x9d, 0x43, 0xb3, 0x67, 0x47, 0x0d,
+	0xf5, 0x47, 0x2f, 0x42, 0x1d, 0x60, 0xf3, 0xa8, 0xc1, 0x51, 0xde, 0xdf, 0xb0, 0x9f, 0x4a, 0x7f,
+	0xc3, 0xc6, 0xb4, 0xbf, 0x51, 0x98, 0x38, 0xe2, 0xd1, 0xa8, 0xe6, 0xb8, 0x89, 0x3b, 0x8b, 0x1c,
+	0x33, 0xcd, 0x96, 0xd5, 0x71, 0x93, 0xf7, 0x15, 0x7c, 0x59, 0x13, 0x28, 0xc2, 0x49, 0xd6, 0xc7,
+	0x99, 0x3f, 0xff, 0xa5, 0x3e, 0x85, 0xbe, 0xd6, 0xc0, 0xac, 0x8c, 0xb0, 0xaf, 0x92, 0x41, 0x69,
+	0x46, 0x77, 0xf7, 0xf7, 0x7d, 0x12, 0xb0, 0xb4, 0x99, 0xe6, 0x19, 0x9d, 0x23, 0x32, 0xa3, 0xf3,
+	0x21, 0xc2, 0x02, 0x87, 0xef, 0x8a, 0xe4, 0x99, 0x62, 0xdb, 0xf6, 0xe6, 0xc5, 0xc9, 0x33, 0xda,
+	0x14, 0x26, 0xa2, 0x2d, 0xf0, 0x11, 0x31, 0xf9, 0xb1, 0x15, 0x11, 0x8b, 0xa5, 0x15, 0x0a, 0x0a,
+	0x9f, 0xe4, 0xa7, 0x23, 0x02, 0x10, 0x96, 0x32, 0x31, 0xc7, 0xa7, 0x60, 0x9a, 0x67, 0x33, 0xb8,
+	0x03, 0x72, 0x1d, 0x77, 0xe8, 0x04, 0xf1, 0x95, 0xd9, 0x92, 0xda, 0xab, 0x33, 0x49, 0xe5, 0x37,
+	0xa2, 0x03, 0x18, 0x51, 0xe5, 0x1e, 0x09, 0x80, 0x36, 0xd9, 0x42, 0x84, 0x7e, 0xaa, 0x81, 0x19,
+	0xa1, 0x08, 0x37, 0xe5, 0xd5, 0x45, 0xa6, 0xf2, 0xd1, 0x58, 0x92, 0x7e, 0xf9, 0x35, 0x98, 0x9a,
+	0xa0, 0xc5, 0x8d, 0xd8, 0xa1, 0xd9, 0x1b, 0xf2, 0x85, 0xca, 0xf0, 0xca, 0x87, 0x01, 0x32, 0xe7,
+	0xb1, 0x11, 0xc2, 0x1c, 0x45, 0x3f, 0xcd, 0x80, 0x79, 0x35, 0x88, 0xd0, 0x6c, 0x31, 0x74, 0x44,
+	0xb1, 0x95, 0x28, 0xcc, 0x1f, 0x3a, 0xf6, 0x31, 0x0b, 0x33, 0xc5, 0x2f, 0x43, 0x5d, 0xa3, 0x1b,
+	0x40, 0x79, 0x72, 0x03, 0xe8, 0x00, 0x61, 0x86, 0xc1, 0x2f, 0xc0, 0xcc, 0x91, 0xed, 0x58, 0xee,
+	0x11, 0x2f, 0xc0, 0xe6, 0xd4, 0x7b, 0x8d, 0xc7, 0x5c, 0xc0, 0x2c, 0x95, 0x84, 0xa5, 0x88, 0x2d,
+	0x97, 0x4b, 0x8c, 0x11, 0x8e, 0x24, 0x70, 0x03, 0x64, 0x7b, 0xb6, 0x33, 0x3c, 0x66, 0x0e, 0x96,
+	0xc8, 0xf2, 0x3f, 0x36, 0x83, 0xc0, 0x63, 0xe6, 0xee, 0x0a, 0x73, 0x9c, 0x29, 0x27, 0xcc, 0x46,
+	0xf4, 0x0a, 0x90, 0xfe, 0x85, 0x0f, 0xc0, 0xb4, 0x65, 0x7a, 0x47, 0x36, 0xbf, 0x72, 0xb9, 0xc4,
+	0xd2, 0xb2, 0xb0, 0x24, 0xa8, 0xf1, 0xf5, 0x13, 0x1b, 0x22, 0x2c, 0x70, 0x48, 0xc0, 0xcc, 0xbe,
+	0x47, 0xc8, 0x9e, 0x6f, 0x15, 0xb2, 0x97, 0x5b, 0xfb, 0x90, 0x5a, 0xa3, 0x97, 0x14, 0xeb, 0x1e,
+	0x21, 0x95, 0x36, 0xbb, 0xa4, 0x10, 0x6a, 0x72, 0xc6, 0x62, 0xcc, 0x2e, 0x29, 0x04, 0x0d, 0x47,
+	0x24, 0x68, 0x80, 0x69, 0x87, 0x04, 0x7b, 0x3e, 0x0f, 0x26, 0x97, 0x3c, 0x65, 0x4d, 0x3c, 0x65,
+	0xba, 0x49, 0x02, 0xfe, 0x10, 0xa1, 0x24, 0xdf, 0x9e, 0x0f, 0xe9, 0x23, 0x04, 0x07, 0x0b, 0x06,
+	0xfa, 0x93, 0x14, 0xc8, 0x45, 0xfb, 0x4b, 0x6b, 0x4f, 0xf7, 0xc8, 0x21, 0x9e, 0xfa, 0x61, 0x81,
+	0x15, 0x1c, 0x0c, 0x15, 0x97, 0x47, 0x3c, 0x8f, 0x4a, 0x04, 0xe1, 0x58, 0x4a, 0x0d, 0x74, 0x3d,
+	0x77, 0x38, 0x50, 0x3f, 0x2a, 0x30, 0x03, 0x0c, 0x4d, 0x18, 0x90, 0x08, 0xc2, 0xb1, 0x14, 0x7e,
+	0x02, 0xd2, 0x43, 0xdb, 0x62, 0x5b, 0x9d, 0xad, 0xbc, 0xfd, 0x22, 0xd4, 0xd3, 0x0f, 0xd9, 0x09,
+	0xa0, 0xe8, 0x79, 0xa8, 0xcf, 0x72, 0x87, 0xb3, 0x2d, 0x25, 0x7b, 0x53, 0x06, 0xa6, 0x72, 0xaa,
+	0xdc, 0xb5, 0xad, 0x42, 0x26, 0x56, 0xde, 0xe0, 0xca, 0x5d, 0x45, 0xb9, 0x9b, 0x54, 0xde, 0xa0,
+	0xca, 0x14, 0xfb, 0x85, 0x06, 0xe6, 0x14, 0x0f, 0x7d, 0xfd, 0xb5, 0xd8, 0x02, 0x8b, 0xdc, 0x80,
+	0xed, 0x1b, 0x6c, 0x82, 0xe2, 0x86, 0x9c, 0xb5, 0x48, 0x4c, 0xd2, 0xf0, 0x37, 0x28, 0x2e, 0x5b,
+	0x24, 0x15, 0x44, 0x38, 0xc1, 0x41, 0x6d, 0x30, 0x2b, 0x37, 0x1c, 0xae, 0x83, 0xe9, 0x63, 0x3a,
+	0x88, 0x02, 0xd2, 0xb5, 0x31, 0xaf, 0x88, 0xab, 0x5e, 0x4e, 0x93, 0x07, 0x82, 0x0d, 0x11, 0x16,
+	0x30, 0xea, 0x80, 0x2c, 0xe3, 0xbf, 0x52, 0x33, 0x93, 0x88, 0x33, 0xf3, 0xdf, 0x1e, 0x67, 0x7e,
+	0x9d, 0x01, 0x33, 0x98, 0xd6, 0xec, 0x7e, 0x00, 0x3f, 0x90, 0xd1, 0x2e, 0x5b, 0x79, 0xeb, 0xb2,
+	0xf0, 0x16, 0xef, 0x4e, 0x74, 0x37, 0x1b, 0x77, 0xd0, 0xa9, 0x2b, 0x77, 0xd0, 0xd1, 0x94, 0xd2,
+	0x57, 0x98, 0x52, 0x9c, 0x96, 0x32, 0xaf, 0x9c, 0x96, 0xb2, 0x57, 0x4f, 0x4b, 0x51, 0xa6, 0x9c,
+	0xbe, 0x42, 0xa6, 0x6c, 0x81, 0xc5, 0x7d, 0xcf, 0xed, 0xb3, 0x1b, 0x7c, 0xd7, 0xa3, 0xdf, 0x57,
+	0x66, 0xe2, 0xd4, 0x4d, 0x25, 0xbb, 0x91, 0x40, 0xa6, 0xee, 0x04, 0x8a, 0x70, 0x92, 0x95, 0xcc,
+	0x89, 0xb9, 0x57, 0xcb, 0x89, 0xf0, 0x33, 0x90, 0xe3, 0x85, 0xb0, 0xe3, 0xb2, 0xae, 0x2f, 0x5b,
+	0xf9, 0x1e, 0xbb, 0x25, 0xa0, 0x58, 0xd3, 0x95, 0xa1, 0x4c, 0x8c, 0xe5, 0xb4, 0x23, 0x02, 0x3c,
+	0x00, 0x8b, 0x63, 0x25, 0x34, 0x78, 0x79, 0x09, 0xcd, 0xa6, 0x79, 0x30, 0x56, 0x3d, 0x5f, 0x78,
+	0xeb, 0x96, 0x64, 0xa1, 0xbf, 0xd7, 0x40, 0x0e, 0x13, 0x7f, 0xe0, 0x3a, 0x3e, 0xf9, 0xae, 0xee,
+	0xb6, 0x0a, 0x32, 0x96, 0x19, 0x98, 0x85, 0x54, 0xbc, 0x4f, 0x74, 0x2c, 0xf7, 0x89, 0x0e, 0x10,
+	0x66, 0x18, 0xfc, 0x1c, 0x64, 0x3a, 0xae, 0xc5, 0xdd, 0x6c, 0x51, 0x0d, 0xcf, 0x75, 0xcf, 0x73,
+	0xbd, 0xaa, 0x6b, 0x89, 0xfe, 0x8a, 0x92, 0xa4, 0x01, 0x3a, 0x40, 0x98, 0x61, 0xe8, 0xef, 0x34,
+	0x90, 0xaf, 0xb9, 0x47, 0x4e, 0xcf, 0x35, 0xad, 0x1d, 0xcf, 0xed, 0xd2, 0x6b, 0xfc, 0xef, 0x74,
+	0x65, 0x64, 0x80, 0x99, 0x21, 0xbb, 0x96, 0x8b, 0xae, 0xd6, 0xee, 0x25, 0xfb, 0xbd, 0xf1, 0x87,
+	0xf0, 0x3b, 0xbc, 0xf8, 0xca, 0x47, 0x28, 0x4b, 0xfb, 0x7c, 0x8c, 0x70, 0x24, 0x40, 0xbf, 0x4a,
+	0x83, 0xe2, 0xe5, 0x86, 0x60, 0x1f, 0xcc, 0x71, 0xa6, 0xa1, 0x7c, 0x1b, 0x5d, 0xb9, 0xca, 0x3b,
+	0xb0, 0x2e, 0x94, 0xb5, 0x1f, 0x43, 0x39, 0x96, 0xed, 0x47, 0x0c, 0x21, 0xac, 0xc8, 0x5f, 0xe9,
+	0x7b, 0x8d, 0x72, 0x67, 0x91, 0x7e, 0xfd, 0x3b, 0x8b, 0x36, 0x58, 0xe0, 0x87, 0x21, 0xfa, 0xb0,
+	0x96, 0x29, 0xa5, 0x57, 0xb2, 0x95, 0xfb, 0x34, 0xae, 0xef, 0xf1, 0xb2, 0x38, 0xfa, 0xa4, 0xb6,
+	0x14, 0x1f, 0x0b, 0x0e, 0x46, 0xde, 0x96, 0x9f, 0xc2, 0x09, 0x2e, 0x5c, 0x4f, 0xb4, 0xb4, 0x3c,
+	0xa8, 0xfc, 0xd6, 0x15, 0x5b, 0x58, 0xa5, 0x65, 0x45, 0xd3, 0x20, 0xb3, 0x63, 0x3b, 0x5d, 0xf4,
+	0x09, 0xc8, 0x56, 0x7b, 0xae, 0xcf, 0x62, 0x9b, 0x47, 0x4c, 0xdf, 0x75, 0x54, 0x57, 0xe2, 0x88,
+	0xdc, 0x6a, 0x3e, 0x44, 0x58, 0xe0, 0xab, 0xff, 0x9d, 0x06, 0x73, 0xca, 0xa7, 0x6c, 0xf8, 0x7b,
+	0xe0, 0xce, 0x76, 0xbd, 0xdd, 0x2e, 0x6f, 0xd4, 0x8d, 0xdd, 0x27, 0x3b, 0x75, 0xa3, 0xba, 0xf5,
+	0xb0, 0xbd, 0x5b, 0xc7, 0x46, 0xb5, 0xd5, 0x5c, 0x6f, 0x6c, 0xe4, 0xa7, 0x8a, 0x77, 0x4f, 0xcf,
+	0x4a, 0x05, 0x45, 0x23, 0xf9, 0xcd, 0xf9, 0xb7, 0x01, 0x4c, 0xa8, 0x37, 0x9a, 0xb5, 0xfa, 0x8f,
+	0xf3, 0x5a, 0xf1, 0xc6, 0xe9, 0x59, 0x29, 0xaf, 0x68, 0xf1, 0xcb, 0xf2, 0xdf, 0x05, 0x6f, 0x4c,
+	0xb2, 0x8d, 0x87, 0x3b, 0xb5, 0xf2, 0x6e, 0x3d, 0x9f, 0x2a, 0x16, 0x4f, 0xcf, 0x4a, 0xb7, 0xc6,
+	0x95, 0x84, 0x0b, 0xfe, 0x10, 0xdc, 0x48, 0xa8, 0xe2, 0xfa, 0x17, 0x0f, 0xeb, 0xed, 0xdd, 0x7c,
+	0xba, 0x78, 0xeb, 0xf4, 0xac, 0x04, 0x15, 0xad, 0x28, 0x21, 0xad, 0x81, 0x9b, 0x63, 0x1a, 0xed,
+	0x9d, 0x56, 0xb3, 0x5d, 0xcf, 0x67, 0x8a, 0xb7, 0x4f, 0xcf, 0x4a, 0xd7, 0x13, 0x2a, 0x22, 0xaa,
+	0x54, 0xc1, 0x72, 0x42, 0xa7, 0xd6, 0x7a, 0xdc, 0xdc, 0x6a, 0x95, 0x6b, 0xc6, 0x0e, 0x6e, 0x6d,
+	0xe0, 0x7a, 0xbb, 0x9d, 0xcf, 0x16, 0xf5, 0xd3, 0xb3, 0xd2, 0x1d, 0x45, 0x79, 0xe2, 0x84, 0xaf,
+	0x82, 0xa5, 0x84, 0x91, 0x9d, 0x46, 0x73, 0x23, 0x3f, 0x5d, 0xbc, 0x7e, 0x7a, 0x56, 0xba, 0xa6,
+	0xe8, 0xd1, 0xbd, 0x9c, 0x58, 0xbf, 0xea, 0x56, 0xab, 0x5d, 0xcf, 0xcf, 0x4c, 0xac, 0x1f, 0xdf,
+	0xf0, 0x4f, 0x40, 0xf1, 0x82, 0xf5, 0x6b, 0x3f, 0xdc, 0xde, 0x2e, 0xe3, 0x27, 0xf9, 0x5c, 0xf1,
+	0xce, 0xe9, 0x59, 0xe9, 0xf6, 0xf8, 0x02, 0x8a, 0x8b, 0xd7, 0xd5, 0xbf, 0xd6, 0x00, 0x9c, 0xfc,
+	0xd7, 0x03, 0xf8, 0x11, 0x28, 0x44, 0x36, 0xab, 0xad, 0xed, 0x1d, 0x3a, 0xc9, 0x46, 0xab, 0x69,
+	0x34, 0x5b, 0xcd, 0x7a, 0x7e, 0x2a, 0xb1, 0x25, 0x8a, 0x56, 0xd3, 0x75, 0xe8, 0xbf, 0x88, 0xdc,
+	0xbe, 0x48, 0x73, 0xeb, 0xe9, 0xfb, 0x79, 0xad, 0xb8, 0x76, 0x7a, 0x56, 0xba, 0x39, 0xa9, 0xb8,
+	0xf5, 0xf4, 0xfd, 0xaf, 0x7e, 0xf6, 0xd6, 0xc5, 0x82, 0x55, 0x5a, 0xa7, 0xa9, 0xaf, 0xf6, 0x2e,
+	0xb8, 0xa1, 0x1a, 0xde, 0xae, 0xef, 0x96, 0x6b, 0xe5, 0xdd, 0x72, 0x7e, 0x8a, 0x6f, 0xa0, 0x42,
+	0xdd, 0x26, 0x81, 0xc9, 0x62, 0xf6, 0xf7, 0xc1, 0x52, 0x62, 0x16, 0xf5, 0x47, 0x75, 0x1c, 0xb9,
+	0xa3, 0xfa, 0xfe, 0xe4, 0x90, 0x78, 0xf0, 0x07, 0x00, 0xaa, 0xe4, 0xf2, 0xd6, 0xe3, 0xf2, 0x93,
+	0x76, 0x3e, 0x55, 0xbc, 0x79, 0x7a, 0x56, 0x5a, 0x52, 0xd8, 0xe5, 0xde, 0x91, 0x79, 0xe2, 0xaf,
+	0xfe, 0xab, 0x06, 0xf2, 0xe3, 0x77, 0xf4, 0x74, 0x4b, 0x12, 0xbb, 0x90, 0xf4, 0xce, 0x29, 0xbe,
+	0x25, 0xe3, 0x5a, 0x91, 0x8b, 0x56, 0xc1, 0xf2, 0x05, 0xca, 0xb5, 0xc6, 0xfa, 0x7a, 0x1d, 0xd7,
+	0x9b, 0xd5, 0x7a, 0x3b, 0xaf, 0x71, 0x77, 0x1b, 0x37, 0x50, 0xb3, 0xf7, 0xf7, 0x89, 0x47, 0xaf,
+	0x4a, 0x7d, 0x7a, 0x82, 0x2f, 0x30, 0x42, 0x27, 0xb6, 0x55, 0x67, 0xc7, 0x8a, 0x9d, 0xe0, 0x71,
+	0x0b, 0x74, 0x7a, 0x3d, 0x12, 0x90, 0xd5, 0x7f, 0x4a, 0x81, 0x79, 0xf5, 0xce, 0x10, 0xfe, 0x00,
+	0x5c, 0x5f, 0x6f, 0x6c, 0x51, 0xe7, 0x5a, 0x6f, 0x71, 0x5b, 0x74, 0x98, 0x9f, 0xe2, 0x8b, 0xa8,
+	0x52, 0xe9, 0x6f, 0xf8, 0x3b, 0xa0, 0x30, 0x46, 0xaf, 0x35, 0x70, 0xbd, 0xba, 0xdb, 0xc2, 0x4f,
+	0xf2, 0x5a, 0xf1, 0x0d, 0xea, 0x06, 0xaa, 0x4e, 0xcd, 0xf6, 0x58, 0x54, 0x3e, 0x81, 0x9f, 0x81,
+	0x3b, 0x63, 0x8a, 0xed, 0x27, 0xdb, 0x5b, 0x8d, 0xe6, 0x03, 0xfe, 0xbc, 0x54, 0xf1, 0x4d, 0xba,
+	0x74, 0xaa, 0x6e, 0x9b, 0x5f, 0xc3, 0x52, 0x28, 0xa7, 0xc1, 0x4d, 0x50, 0xba, 0x44, 0x3f, 0x7e,
+	0x81, 0x74, 0x11, 0x9d, 0x9e, 0x95, 0xee, 0x5e, 0x60, 0x44, 0xbe, 0x47, 0x4e, 0x83, 0xef, 0x81,
+	0x5b, 0x17, 0x5b, 0x8a, 0x42, 0xc5, 0x05, 0xfa, 0xab, 0x7f, 0xab, 0x81, 0x85, 0xe4, 0x9d, 0x5e,
+	0x03, 0xdc, 0xdc, 0x2c, 0xb7, 0x37, 0x8d, 0xf2, 0xd6, 0x46, 0x0b, 0x37, 0x76, 0x37, 0xb7, 0x8d,
+	0xf6, 0x66, 0x79, 0xed, 0x83, 0x0f, 0xf3, 0x53, 0xc5, 0xfb, 0xd4, 0x4a, 0x82, 0xcd, 0x45, 0x5f,
+	0xfd, 0xec, 0xad, 0x8b, 0xe0, 0x0b, 0x4c, 0x55, 0xb6, 0xca, 0x0f, 0xea, 0xef, 0xe5, 0xb5, 0x0b,
+	0x4c, 0x71, 0xd1, 0x84, 0x29, 0x0e, 0xaf, 0xfe, 0x9b, 0x06, 0x66, 0x65, 0xc1, 0x42, 0x37, 0xb7,
+	0x8e, 0x71, 0x8b, 0xc6, 0xf7, 0x5a, 0xdd, 0x68, 0xb6, 0x0c, 0x36, 0x8a, 0x36, 0x57, 0xf2, 0x9a,
+	0x2e, 0xfb, 0x49, 0xc3, 0x93, 0x42, 0xdf, 0xa8, 0x37, 0xeb, 0xb8, 0x51, 0x8d, 0xce, 0x93, 0x64,
+	0x6f, 0x10, 0x87, 0x78, 0x76, 0x07, 0xbe, 0x0f, 0x6e, 0x27, 0x8d, 0xb7, 0x1f, 0x56, 0x37, 0xa3,
+	0xdd, 0x64, 0x0b, 0xa9, 0x3c, 0xa0, 0x3d, 0xec, 0x1c, 0x30, 0x07, 0xfa, 0x20, 0xa1, 0xd5, 0x68,
+	0x3e, 0x2a, 0x6f, 0x35, 0x6a, 0x5c, 0x2b, 0x5d, 0x2c, 0x9c, 0x9e, 0x95, 0x6e, 0x48, 0x2d, 0x71,
+	0x0b, 0x46, 0xd5, 0x56, 0xbf, 0xd2, 0xc0, 0xf2, 0xcb, 0xeb, 0x0e, 0xf8, 0x18, 0xbc, 0xcd, 0xf6,
+	0x75, 0x22, 0x8a, 0x8b, 0x94, 0xc3, 0xf7, 0xba, 0xbc, 0xb3, 0x53, 0x6f, 0xd6, 0xf2, 0x53, 0xc5,
+	0x95, 0xd3, 0xb3, 0xd2, 0xbd, 0x97, 0x9b, 0x2c, 0x0f, 0x06, 0xc4, 0xb1, 0xae, 0x68, 0x78, 0xbd,
+	0x85, 0x37, 0xea, 0xbb, 0x79, 0xed, 0x2a, 0x86, 0xd7, 0x5d, 0xfa, 0x69, 0xa1, 0xb2, 0xfd, 0xe5,
+	0xd7, 0xcb, 0x53, 0xcf, 0xbf, 0x5e, 0x9e, 0xfa, 0xf2, 0xc5, 0xb2, 0xf6, 0xfc, 0xc5, 0xb2, 0xf6,
+	0x67, 0xdf, 0x2c, 0x4f, 0xfd, 0xf2, 0x9b, 0x65, 0xed, 0xf9, 0x37, 0xcb, 0x53, 0xff, 0xfe, 0xcd,
+	0xf2, 0xd4, 0xd3, 0xef, 0x77, 0xed, 0xe0, 0x60, 0xb8, 0x77, 0xbf, 0xe3, 0xf6, 0xdf, 0xf1, 0x4f,
+	0x9c, 0x4e, 0x70, 0x60, 0x3b, 0x5d, 0xe5, 0x97, 0xfa, 0xcf, 0x89, 0x7b, 0xd3, 0xec, 0xd7, 0x7b,
+	0xff, 0x37, 0x00, 0x3b, 0xff, 0xf1, 0x67, 0xb3, 0x28, 0x00, 0x00,
 }
 
 func (m *Hello) Marshal() (dAtA []byte, err error) {
@@ -1614,6 +1792,41 @@ func (m *Device) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.HashAlgorithms) > 0 {
+		dAtA2 := make([]byte, len(m.HashAlgorithms)*10)
+		var j1 int
+		for _, num := range m.HashAlgorithms {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintBep(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if len(m.PreviousEncryptionPasswordToken) > 0 {
+		i -= len(m.PreviousEncryptionPasswordToken)
+		copy(dAtA[i:], m.PreviousEncryptionPasswordToken)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.PreviousEncryptionPasswordToken)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.IndexSummaries {
+		i--
+		if m.IndexSummaries {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
 	if len(m.EncryptionPasswordToken) > 0 {
 		i -= len(m.EncryptionPasswordToken)
 		copy(dAtA[i:], m.EncryptionPasswordToken)
@@ -1795,6 +2008,102 @@ func (m *IndexUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *IndexSummary) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IndexSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.LastSequence != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.LastSequence))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Buckets) > 0 {
+		for iNdEx := len(m.Buckets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Buckets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintBep(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Type != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Folder) > 0 {
+		i -= len(m.Folder)
+		copy(dAtA[i:], m.Folder)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Folder)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *IndexSummaryBucket) Marshal() (dAtA []byte, err error) {
+	size := m.ProtoSize()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexSummaryBucket) MarshalTo(dAtA []byte) (int, error) {
+	size := m.ProtoSize()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IndexSummaryBucket) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Files != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.Files))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintBep(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *FileInfo) Marshal() (dAtA []byte, err error) {
 	size := m.ProtoSize()
 	dAtA = make([]byte, size)
@@ -1845,6 +2154,13 @@ func (m *FileInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xc0
 	}
+	if m.BlockHashAlgorithm != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.BlockHashAlgorithm))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
+	}
 	if len(m.Encrypted) > 0 {
 		i -= len(m.Encrypted)
 		copy(dAtA[i:], m.Encrypted)
@@ -2379,6 +2695,11 @@ func (m *Request) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.HashAlgorithm != 0 {
+		i = encodeVarintBep(dAtA, i, uint64(m.HashAlgorithm))
+		i--
+		dAtA[i] = 0x50
+	}
 	if m.BlockNo != 0 {
 		i = encodeVarintBep(dAtA, i, uint64(m.BlockNo))
 		i--
@@ -2782,6 +3103,20 @@ func (m *Device) ProtoSize() (n int) {
 	if l > 0 {
 		n += 1 + l + sovBep(uint64(l))
 	}
+	if m.IndexSummaries {
+		n += 2
+	}
+	l = len(m.PreviousEncryptionPasswordToken)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	if len(m.HashAlgorithms) > 0 {
+		l = 0
+		for _, e := range m.HashAlgorithms {
+			l += sovBep(uint64(e))
+		}
+		n += 1 + sovBep(uint64(l)) + l
+	}
 	return n
 }
 
@@ -2832,6 +3167,51 @@ func (m *IndexUpdate) ProtoSize() (n int) {
 	return n
 }
 
+func (m *IndexSummary) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Folder)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	if m.Type != 0 {
+		n += 1 + sovBep(uint64(m.Type))
+	}
+	if len(m.Buckets) > 0 {
+		for _, e := range m.Buckets {
+			l = e.ProtoSize()
+			n += 1 + l + sovBep(uint64(l))
+		}
+	}
+	if m.LastSequence != 0 {
+		n += 1 + sovBep(uint64(m.LastSequence))
+	}
+	return n
+}
+
+func (m *IndexSummaryBucket) ProtoSize() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	if m.Files != 0 {
+		n += 1 + sovBep(uint64(m.Files))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovBep(uint64(l))
+	}
+	return n
+}
+
 func (m *FileInfo) ProtoSize() (n int) {
 	if m == nil {
 		return 0
@@ -2897,6 +3277,9 @@ func (m *FileInfo) ProtoSize() (n int) {
 	if l > 0 {
 		n += 2 + l + sovBep(uint64(l))
 	}
+	if m.BlockHashAlgorithm != 0 {
+		n += 2 + sovBep(uint64(m.BlockHashAlgorithm))
+	}
 	if m.LocalFlags != 0 {
 		n += 2 + sovBep(uint64(m.LocalFlags))
 	}
@@ -3105,6 +3488,9 @@ func (m *Request) ProtoSize() (n int) {
 	if m.BlockNo != 0 {
 		n += 1 + sovBep(uint64(m.BlockNo))
 	}
+	if m.HashAlgorithm != 0 {
+		n += 1 + sovBep(uint64(m.HashAlgorithm))
+	}
 	return n
 }
 
@@ -4113,11 +4499,134 @@ func (m *Device) Unmarshal(dAtA []byte) error {
 				m.EncryptionPasswordToken = []byte{}
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipBep(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexSummaries", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IndexSummaries = bool(v != 0)
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PreviousEncryptionPasswordToken", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PreviousEncryptionPasswordToken = append(m.PreviousEncryptionPasswordToken[:0], dAtA[iNdEx:postIndex]...)
+			if m.PreviousEncryptionPasswordToken == nil {
+				m.PreviousEncryptionPasswordToken = []byte{}
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType == 0 {
+				var v HashAlgorithm
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowBep
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= HashAlgorithm(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.HashAlgorithms = append(m.HashAlgorithms, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowBep
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthBep
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthBep
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.HashAlgorithms) == 0 {
+					m.HashAlgorithms = make([]HashAlgorithm, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v HashAlgorithm
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowBep
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= HashAlgorithm(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.HashAlgorithms = append(m.HashAlgorithms, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashAlgorithms", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
 			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthBep
@@ -4423,6 +4932,295 @@ func (m *IndexUpdate) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *IndexSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IndexSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IndexSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Folder", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Folder = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= IndexSummaryType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Buckets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Buckets = append(m.Buckets, IndexSummaryBucket{})
+			if err := m.Buckets[len(m.Buckets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastSequence", wireType)
+			}
+			m.LastSequence = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastSequence |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IndexSummaryBucket) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBep
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IndexSummaryBucket: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IndexSummaryBucket: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Files", wireType)
+			}
+			m.Files = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Files |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBep
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBep
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBep(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBep
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *FileInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -4896,6 +5694,25 @@ func (m *FileInfo) Unmarshal(dAtA []byte) error {
 				m.Encrypted = []byte{}
 			}
 			iNdEx = postIndex
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHashAlgorithm", wireType)
+			}
+			m.BlockHashAlgorithm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockHashAlgorithm |= HashAlgorithm(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 1000:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field LocalFlags", wireType)
@@ -6283,6 +7100,25 @@ func (m *Request) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashAlgorithm", wireType)
+			}
+			m.HashAlgorithm = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBep
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HashAlgorithm |= HashAlgorithm(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBep(dAtA[iNdEx:])
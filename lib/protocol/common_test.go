@@ -65,6 +65,10 @@ func (*TestModel) DownloadProgress(Connection, *DownloadProgress) error {
 	return nil
 }
 
+func (*TestModel) IndexSummary(Connection, *IndexSummary) error {
+	return nil
+}
+
 func (t *TestModel) closedError() error {
 	select {
 	case <-t.closedCh:
@@ -155,6 +155,15 @@ func (e encryptedModel) DownloadProgress(p *DownloadProgress) error {
 	return nil
 }
 
+func (e encryptedModel) IndexSummary(s *IndexSummary) error {
+	if _, ok := e.folderKeys.get(s.Folder); !ok {
+		return e.model.IndexSummary(s)
+	}
+
+	// Index reconciliation is not supported with encrypted devices - ignore.
+	return nil
+}
+
 func (e encryptedModel) ClusterConfig(config *ClusterConfig) error {
 	return e.model.ClusterConfig(config)
 }
@@ -254,6 +263,15 @@ func (e encryptedConnection) DownloadProgress(ctx context.Context, dp *DownloadP
 	// No need to send these
 }
 
+func (e encryptedConnection) IndexSummary(ctx context.Context, s *IndexSummary) error {
+	if _, ok := e.folderKeys.get(s.Folder); ok {
+		// The summary would leak plaintext names and we never announce
+		// support for it to encrypted devices anyway.
+		return nil
+	}
+	return e.conn.IndexSummary(ctx, s)
+}
+
 func (e encryptedConnection) ClusterConfig(config *ClusterConfig) {
 	e.conn.ClusterConfig(config)
 }
@@ -369,6 +387,8 @@ func encryptFileInfo(keyGen *KeyGenerator, fi FileInfo, folderKey *[keySize]byte
 		enc.Size = offset // new total file size
 		enc.Blocks = blocks
 		enc.RawBlockSize = fi.BlockSize() + blockOverhead
+		// Sent back to us in requests, to verify the decrypted hash with.
+		enc.BlockHashAlgorithm = fi.BlockHashAlgorithm
 	}
 
 	return enc
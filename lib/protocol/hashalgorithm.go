@@ -0,0 +1,83 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package protocol
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+
+	"lukechampine.com/blake3"
+)
+
+// BlockHashSize is the size of a block hash, whatever the algorithm.
+const BlockHashSize = sha256.Size
+
+// SupportedHashAlgorithms are the block hash algorithms we support besides
+// SHA-256, which every device does.
+var SupportedHashAlgorithms = []HashAlgorithm{HashAlgorithmBLAKE3}
+
+var hashAlgorithmMarshal = map[HashAlgorithm]string{
+	HashAlgorithmSHA256: "sha256",
+	HashAlgorithmBLAKE3: "blake3",
+}
+
+var hashAlgorithmUnmarshal = map[string]HashAlgorithm{
+	"sha256": HashAlgorithmSHA256,
+	"blake3": HashAlgorithmBLAKE3,
+}
+
+func (a HashAlgorithm) GoString() string {
+	return fmt.Sprintf("%q", a.String())
+}
+
+func (a HashAlgorithm) MarshalText() ([]byte, error) {
+	return []byte(hashAlgorithmMarshal[a]), nil
+}
+
+func (a *HashAlgorithm) UnmarshalText(bs []byte) error {
+	algo, ok := hashAlgorithmUnmarshal[string(bs)]
+	if !ok {
+		return fmt.Errorf("unknown block hash algorithm %q", bs)
+	}
+	*a = algo
+	return nil
+}
+
+func (a *HashAlgorithm) ParseDefault(str string) error {
+	return a.UnmarshalText([]byte(str))
+}
+
+// NewHash returns a hash computing block hashes with the algorithm.
+func (a HashAlgorithm) NewHash() hash.Hash {
+	if a == HashAlgorithmBLAKE3 {
+		return blake3.New(BlockHashSize, nil)
+	}
+	return sha256.New()
+}
+
+// Sum returns the block hash of the data.
+func (a HashAlgorithm) Sum(data []byte) [BlockHashSize]byte {
+	if a == HashAlgorithmBLAKE3 {
+		return blake3.Sum256(data)
+	}
+	return sha256.Sum256(data)
+}
+
+// SupportsHashAlgorithm returns whether the algorithm is SHA-256 or among
+// the supported ones.
+func SupportsHashAlgorithm(supported []HashAlgorithm, a HashAlgorithm) bool {
+	if a == HashAlgorithmSHA256 {
+		return true
+	}
+	for _, s := range supported {
+		if s == a {
+			return true
+		}
+	}
+	return false
+}
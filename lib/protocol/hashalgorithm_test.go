@@ -0,0 +1,34 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package protocol
+
+import "testing"
+
+func TestHashAlgorithmText(t *testing.T) {
+	for _, algo := range []HashAlgorithm{HashAlgorithmSHA256, HashAlgorithmBLAKE3} {
+		bs, err := algo.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var res HashAlgorithm
+		if err := res.UnmarshalText(bs); err != nil {
+			t.Errorf("Unexpected error unmarshalling %q: %v", bs, err)
+		} else if res != algo {
+			t.Errorf("Round trip of %v gave %v", algo, res)
+		}
+	}
+
+	for _, str := range []string{"", "SHA256", "md5"} {
+		res := HashAlgorithmBLAKE3
+		if err := res.UnmarshalText([]byte(str)); err == nil {
+			t.Errorf("Expected an error unmarshalling %q", str)
+		}
+		if res != HashAlgorithmBLAKE3 {
+			t.Errorf("Expected %q to leave the value alone, got %v", str, res)
+		}
+	}
+}
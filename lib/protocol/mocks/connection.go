@@ -89,6 +89,18 @@ type Connection struct {
 	indexReturnsOnCall map[int]struct {
 		result1 error
 	}
+	IndexSummaryStub        func(context.Context, *protocol.IndexSummary) error
+	indexSummaryMutex       sync.RWMutex
+	indexSummaryArgsForCall []struct {
+		arg1 context.Context
+		arg2 *protocol.IndexSummary
+	}
+	indexSummaryReturns struct {
+		result1 error
+	}
+	indexSummaryReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IndexUpdateStub        func(context.Context, *protocol.IndexUpdate) error
 	indexUpdateMutex       sync.RWMutex
 	indexUpdateArgsForCall []struct {
@@ -622,6 +634,68 @@ func (fake *Connection) IndexReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *Connection) IndexSummary(arg1 context.Context, arg2 *protocol.IndexSummary) error {
+	fake.indexSummaryMutex.Lock()
+	ret, specificReturn := fake.indexSummaryReturnsOnCall[len(fake.indexSummaryArgsForCall)]
+	fake.indexSummaryArgsForCall = append(fake.indexSummaryArgsForCall, struct {
+		arg1 context.Context
+		arg2 *protocol.IndexSummary
+	}{arg1, arg2})
+	stub := fake.IndexSummaryStub
+	fakeReturns := fake.indexSummaryReturns
+	fake.recordInvocation("IndexSummary", []interface{}{arg1, arg2})
+	fake.indexSummaryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Connection) IndexSummaryCallCount() int {
+	fake.indexSummaryMutex.RLock()
+	defer fake.indexSummaryMutex.RUnlock()
+	return len(fake.indexSummaryArgsForCall)
+}
+
+func (fake *Connection) IndexSummaryCalls(stub func(context.Context, *protocol.IndexSummary) error) {
+	fake.indexSummaryMutex.Lock()
+	defer fake.indexSummaryMutex.Unlock()
+	fake.IndexSummaryStub = stub
+}
+
+func (fake *Connection) IndexSummaryArgsForCall(i int) (context.Context, *protocol.IndexSummary) {
+	fake.indexSummaryMutex.RLock()
+	defer fake.indexSummaryMutex.RUnlock()
+	argsForCall := fake.indexSummaryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Connection) IndexSummaryReturns(result1 error) {
+	fake.indexSummaryMutex.Lock()
+	defer fake.indexSummaryMutex.Unlock()
+	fake.IndexSummaryStub = nil
+	fake.indexSummaryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Connection) IndexSummaryReturnsOnCall(i int, result1 error) {
+	fake.indexSummaryMutex.Lock()
+	defer fake.indexSummaryMutex.Unlock()
+	fake.IndexSummaryStub = nil
+	if fake.indexSummaryReturnsOnCall == nil {
+		fake.indexSummaryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.indexSummaryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *Connection) IndexUpdate(arg1 context.Context, arg2 *protocol.IndexUpdate) error {
 	fake.indexUpdateMutex.Lock()
 	ret, specificReturn := fake.indexUpdateReturnsOnCall[len(fake.indexUpdateArgsForCall)]
@@ -1197,6 +1271,8 @@ func (fake *Connection) Invocations() map[string][][]interface{} {
 	defer fake.establishedAtMutex.RUnlock()
 	fake.indexMutex.RLock()
 	defer fake.indexMutex.RUnlock()
+	fake.indexSummaryMutex.RLock()
+	defer fake.indexSummaryMutex.RUnlock()
 	fake.indexUpdateMutex.RLock()
 	defer fake.indexUpdateMutex.RUnlock()
 	fake.isLocalMutex.RLock()
@@ -134,6 +134,8 @@ type Model interface {
 	Closed(conn Connection, err error)
 	// The peer device sent progress updates for the files it is currently downloading
 	DownloadProgress(conn Connection, p *DownloadProgress) error
+	// The peer device sent an index summary as part of index reconciliation
+	IndexSummary(conn Connection, s *IndexSummary) error
 }
 
 // rawModel is the Model interface, but without the initial Connection
@@ -145,6 +147,7 @@ type rawModel interface {
 	ClusterConfig(*ClusterConfig) error
 	Closed(err error)
 	DownloadProgress(*DownloadProgress) error
+	IndexSummary(*IndexSummary) error
 }
 
 type RequestResponse interface {
@@ -179,6 +182,11 @@ type Connection interface {
 	// further by the caller.
 	DownloadProgress(ctx context.Context, dp *DownloadProgress)
 
+	// Send an Index Summary message to the peer device. The message in the
+	// parameter may be altered by the connection and should not be used
+	// further by the caller.
+	IndexSummary(ctx context.Context, s *IndexSummary) error
+
 	Start()
 	SetFolderPasswords(passwords map[string]string)
 	Close(err error)
@@ -379,6 +387,22 @@ func (c *rawConnection) IndexUpdate(ctx context.Context, idxUp *IndexUpdate) err
 	return nil
 }
 
+// IndexSummary sends an index summary to the connected peer device. It is
+// sent in order with Index and IndexUpdate messages.
+func (c *rawConnection) IndexSummary(ctx context.Context, s *IndexSummary) error {
+	select {
+	case <-c.closed:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	c.idxMut.Lock()
+	c.send(ctx, s, nil)
+	c.idxMut.Unlock()
+	return nil
+}
+
 // Request returns the bytes for the specified block after fetching them from the connected peer.
 func (c *rawConnection) Request(ctx context.Context, req *Request) ([]byte, error) {
 	select {
@@ -529,6 +553,9 @@ func (c *rawConnection) dispatcherLoop() (err error) {
 
 		case *DownloadProgress:
 			err = c.model.DownloadProgress(msg)
+
+		case *IndexSummary:
+			err = c.model.IndexSummary(msg)
 		}
 		if err != nil {
 			return newHandleError(err, msgContext)
@@ -918,6 +945,8 @@ func typeOf(msg message) MessageType {
 		return MessageTypeResponse
 	case *DownloadProgress:
 		return MessageTypeDownloadProgress
+	case *IndexSummary:
+		return MessageTypeIndexSummary
 	case *Ping:
 		return MessageTypePing
 	case *Close:
@@ -941,6 +970,8 @@ func newMessage(t MessageType) (message, error) {
 		return new(Response), nil
 	case MessageTypeDownloadProgress:
 		return new(DownloadProgress), nil
+	case MessageTypeIndexSummary:
+		return new(IndexSummary), nil
 	case MessageTypePing:
 		return new(Ping), nil
 	case MessageTypeClose:
@@ -1141,6 +1172,8 @@ func messageContext(msg message) (string, error) {
 		return "response", nil
 	case *DownloadProgress:
 		return fmt.Sprintf("download-progress for %v", msg.Folder), nil
+	case *IndexSummary:
+		return fmt.Sprintf("index-summary for %v", msg.Folder), nil
 	case *Ping:
 		return "ping", nil
 	case *Close:
@@ -1181,3 +1214,7 @@ func (c *connectionWrappingModel) Closed(err error) {
 func (c *connectionWrappingModel) DownloadProgress(p *DownloadProgress) error {
 	return c.model.DownloadProgress(c.conn, p)
 }
+
+func (c *connectionWrappingModel) IndexSummary(s *IndexSummary) error {
+	return c.model.IndexSummary(c.conn, s)
+}
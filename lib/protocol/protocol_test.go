@@ -359,6 +359,12 @@ func TestMarshalClusterConfigMessage(t *testing.T) {
 				if len(m1.Folders[i].Devices[j].EncryptionPasswordToken) == 0 {
 					m1.Folders[i].Devices[j].EncryptionPasswordToken = nil
 				}
+				if len(m1.Folders[i].Devices[j].PreviousEncryptionPasswordToken) == 0 {
+					m1.Folders[i].Devices[j].PreviousEncryptionPasswordToken = nil
+				}
+				if len(m1.Folders[i].Devices[j].HashAlgorithms) == 0 {
+					m1.Folders[i].Devices[j].HashAlgorithms = nil
+				}
 			}
 		}
 
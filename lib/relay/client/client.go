@@ -27,7 +27,7 @@ func NewClient(uri *url.URL, certs []tls.Certificate, timeout time.Duration) (Re
 	invitations := make(chan protocol.SessionInvitation)
 
 	switch uri.Scheme {
-	case "relay":
+	case schemeTCP, schemeQUIC, schemeWebSocket:
 		return newStaticClient(uri, certs, invitations, timeout), nil
 	case "dynamic+http", "dynamic+https":
 		return newDynamicClient(uri, certs, invitations, timeout), nil
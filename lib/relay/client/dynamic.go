@@ -53,7 +53,7 @@ func (c *dynamicClient) serve(ctx context.Context) error {
 		l.Debugln(c, "failed to lookup dynamic relays", err)
 		return err
 	}
-	data, err := http.DefaultClient.Do(req)
+	data, err := poolHTTPClient.Do(req)
 	if err != nil {
 		l.Debugln(c, "failed to lookup dynamic relays", err)
 		return err
@@ -8,10 +8,8 @@ import (
 	"fmt"
 	"net"
 	"net/url"
-	"strconv"
 	"time"
 
-	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/osutil"
 	syncthingprotocol "github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/relay/protocol"
@@ -27,25 +25,18 @@ func (e *incorrectResponseCodeErr) Error() string {
 }
 
 func GetInvitationFromRelay(ctx context.Context, uri *url.URL, id syncthingprotocol.DeviceID, certs []tls.Certificate, timeout time.Duration) (protocol.SessionInvitation, error) {
-	if uri.Scheme != "relay" {
+	if !supportedScheme(uri.Scheme) {
 		return protocol.SessionInvitation{}, fmt.Errorf("unsupported relay scheme: %v", uri.Scheme)
 	}
 
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
-	rconn, err := dialer.DialContext(ctx, "tcp", uri.Host)
+	conn, err := dialProtocol(ctx, uri, configForCerts(certs))
 	if err != nil {
 		return protocol.SessionInvitation{}, err
 	}
-
-	conn := tls.Client(rconn, configForCerts(certs))
-	conn.SetDeadline(time.Now().Add(timeout))
-
-	if err := performHandshakeAndValidation(conn, uri); err != nil {
-		return protocol.SessionInvitation{}, err
-	}
-
 	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
 
 	request := protocol.ConnectRequest{
 		ID: id[:],
@@ -75,12 +66,12 @@ func GetInvitationFromRelay(ctx context.Context, uri *url.URL, id syncthingproto
 	}
 }
 
-func JoinSession(ctx context.Context, invitation protocol.SessionInvitation) (net.Conn, error) {
-	addr := net.JoinHostPort(net.IP(invitation.Address).String(), strconv.Itoa(int(invitation.Port)))
-
+// JoinSession connects to the session described by the invitation, which
+// was received from the relay at the given URI.
+func JoinSession(ctx context.Context, uri *url.URL, invitation protocol.SessionInvitation) (net.Conn, error) {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
-	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	conn, err := dialSession(ctx, uri, invitation)
 	if err != nil {
 		return nil, err
 	}
@@ -92,11 +83,13 @@ func JoinSession(ctx context.Context, invitation protocol.SessionInvitation) (ne
 	conn.SetDeadline(time.Now().Add(10 * time.Second))
 	err = protocol.WriteMessage(conn, request)
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
 
 	message, err := protocol.ReadMessage(conn)
 	if err != nil {
+		conn.Close()
 		return nil, err
 	}
 
@@ -105,10 +98,12 @@ func JoinSession(ctx context.Context, invitation protocol.SessionInvitation) (ne
 	switch msg := message.(type) {
 	case protocol.Response:
 		if msg.Code != 0 {
+			conn.Close()
 			return nil, fmt.Errorf("incorrect response code %d: %s", msg.Code, msg.Message)
 		}
 		return conn, nil
 	default:
+		conn.Close()
 		return nil, fmt.Errorf("protocol error: expecting response got %v", msg)
 	}
 }
@@ -11,9 +11,7 @@ import (
 	"net/url"
 	"time"
 
-	"github.com/syncthing/syncthing/lib/dialer"
 	"github.com/syncthing/syncthing/lib/osutil"
-	syncthingprotocol "github.com/syncthing/syncthing/lib/protocol"
 	"github.com/syncthing/syncthing/lib/relay/protocol"
 )
 
@@ -27,7 +25,7 @@ type staticClient struct {
 	messageTimeout time.Duration
 	connectTimeout time.Duration
 
-	conn  *tls.Conn
+	conn  net.Conn
 	token string
 }
 
@@ -133,39 +131,22 @@ func (c *staticClient) URI() *url.URL {
 }
 
 func (c *staticClient) connect(ctx context.Context) error {
-	if c.uri.Scheme != "relay" {
+	if !supportedScheme(c.uri.Scheme) {
 		return fmt.Errorf("unsupported relay scheme: %v", c.uri.Scheme)
 	}
 
 	timeoutCtx, cancel := context.WithTimeout(ctx, c.connectTimeout)
 	defer cancel()
-	tcpConn, err := dialer.DialContext(timeoutCtx, "tcp", c.uri.Host)
+	conn, err := dialProtocol(timeoutCtx, c.uri, c.config)
 	if err != nil {
 		return err
 	}
 
-	// Copy the TLS config and set the server name we're connecting to. In
-	// many cases this will be an IP address, in which case it's a no-op. In
-	// other cases it will be a hostname, which will cause the TLS stack to
-	// send SNI.
-	cfg := c.config
-	if host, _, err := net.SplitHostPort(c.uri.Host); err == nil {
-		cfg = cfg.Clone()
-		cfg.ServerName = host
-	}
-
-	conn := tls.Client(tcpConn, cfg)
-
 	if err := conn.SetDeadline(time.Now().Add(c.connectTimeout)); err != nil {
 		conn.Close()
 		return err
 	}
 
-	if err := performHandshakeAndValidation(conn, c.uri); err != nil {
-		conn.Close()
-		return err
-	}
-
 	c.conn = conn
 	return nil
 }
@@ -201,38 +182,6 @@ func (c *staticClient) join() error {
 	return nil
 }
 
-func performHandshakeAndValidation(conn *tls.Conn, uri *url.URL) error {
-	if err := conn.Handshake(); err != nil {
-		return err
-	}
-
-	cs := conn.ConnectionState()
-	if cs.NegotiatedProtocol != protocol.ProtocolName {
-		return errors.New("protocol negotiation error")
-	}
-
-	q := uri.Query()
-	relayIDs := q.Get("id")
-	if relayIDs != "" {
-		relayID, err := syncthingprotocol.DeviceIDFromString(relayIDs)
-		if err != nil {
-			return fmt.Errorf("relay address contains invalid verification id: %w", err)
-		}
-
-		certs := cs.PeerCertificates
-		if cl := len(certs); cl != 1 {
-			return fmt.Errorf("unexpected certificate count: %d", cl)
-		}
-
-		remoteID := syncthingprotocol.NewDeviceID(certs[0].Raw)
-		if remoteID != relayID {
-			return fmt.Errorf("relay id does not match. Expected %v got %v", relayID, remoteID)
-		}
-	}
-
-	return nil
-}
-
 func messageReader(ctx context.Context, conn net.Conn, messages chan<- interface{}, errors chan<- error) {
 	for {
 		msg, err := protocol.ReadMessage(conn)
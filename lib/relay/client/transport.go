@@ -0,0 +1,211 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors (see the CONTRIBUTORS file).
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/coder/websocket"
+
+	"github.com/syncthing/syncthing/lib/dialer"
+	syncthingprotocol "github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/relay/protocol"
+)
+
+// The relay protocol is normally spoken over TLS on TCP, with sessions on
+// plain TCP connections to the address given in the invitation. It can also
+// be carried over QUIC, or over WebSocket on HTTPS for networks that only
+// let HTTPS through. Sessions then use the same transport, to the same
+// address as the relay itself.
+const (
+	schemeTCP       = "relay"
+	schemeQUIC      = "relay+quic"
+	schemeWebSocket = "relay+wss"
+)
+
+// QUIC can't go through a proxy, and is not used when one is required.
+var errProxyRequired = errors.New("QUIC can't be used through a proxy")
+
+func supportedScheme(scheme string) bool {
+	switch scheme {
+	case schemeTCP, schemeQUIC, schemeWebSocket:
+		return true
+	default:
+		return false
+	}
+}
+
+// poolHTTPClient is used to look up relays in pools.
+var poolHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext:         dialer.DialContext,
+		Proxy:               dialer.HTTPProxy,
+		TLSHandshakeTimeout: 10 * time.Second,
+	},
+}
+
+// wsHTTPClient is used for WebSocket connections. It goes through the
+// proxy given in the environment, which is often the only way out of the
+// networks that need WebSocket. The HTTPS certificate isn't verified, as
+// relays use self signed certificates; the relay is identified by the TLS
+// connection within instead, as on TCP.
+var wsHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: dialer.DialContext,
+		Proxy:       dialer.HTTPProxy,
+		TLSClientConfig: &tls.Config{
+			InsecureSkipVerify: true,
+			MinVersion:         tls.VersionTLS12,
+		},
+	},
+}
+
+// dialProtocol connects to the relay for the protocol connection, performs
+// the TLS handshake and verifies the identity of the relay.
+func dialProtocol(ctx context.Context, uri *url.URL, cfg *tls.Config) (net.Conn, error) {
+	// Copy the TLS config and set the server name we're connecting to. In
+	// many cases this will be an IP address, in which case it's a no-op. In
+	// other cases it will be a hostname, which will cause the TLS stack to
+	// send SNI.
+	if host, _, err := net.SplitHostPort(uri.Host); err == nil {
+		cfg = cfg.Clone()
+		cfg.ServerName = host
+	}
+
+	switch uri.Scheme {
+	case schemeTCP:
+		conn, err := dialer.DialContext(ctx, "tcp", uri.Host)
+		if err != nil {
+			return nil, err
+		}
+		return tlsHandshake(ctx, conn, cfg, uri)
+
+	case schemeQUIC:
+		if !dialer.DirectAllowed() {
+			return nil, errProxyRequired
+		}
+		conn, err := dialQUIC(ctx, uri.Host, cfg, protocol.ProtocolName)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateConnectionState(conn.ConnectionState(), uri); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		return conn, nil
+
+	case schemeWebSocket:
+		// The WebSocket connection may be terminated by a proxy in front of
+		// the relay, so we run our own TLS on top of it, as on TCP.
+		conn, err := dialWebSocket(ctx, uri, protocol.ProtocolName)
+		if err != nil {
+			return nil, err
+		}
+		return tlsHandshake(ctx, conn, cfg, uri)
+
+	default:
+		return nil, fmt.Errorf("unsupported relay scheme: %v", uri.Scheme)
+	}
+}
+
+// dialSession connects to the relay for the given session invitation.
+func dialSession(ctx context.Context, uri *url.URL, invitation protocol.SessionInvitation) (net.Conn, error) {
+	switch uri.Scheme {
+	case schemeTCP:
+		addr := net.JoinHostPort(net.IP(invitation.Address).String(), strconv.Itoa(int(invitation.Port)))
+		return dialer.DialContext(ctx, "tcp", addr)
+
+	case schemeQUIC:
+		// What goes over the session is protected by the devices' own TLS,
+		// so there is nothing to verify about the relay here.
+		cfg := &tls.Config{
+			InsecureSkipVerify: true,
+			MinVersion:         tls.VersionTLS13,
+		}
+		conn, err := dialQUIC(ctx, uri.Host, cfg, protocol.SessionProtocolName)
+		if err != nil {
+			return nil, err
+		}
+		return conn, nil
+
+	case schemeWebSocket:
+		return dialWebSocket(ctx, uri, protocol.SessionProtocolName)
+
+	default:
+		return nil, fmt.Errorf("unsupported relay scheme: %v", uri.Scheme)
+	}
+}
+
+func dialWebSocket(ctx context.Context, uri *url.URL, subprotocol string) (net.Conn, error) {
+	wsURL := url.URL{
+		Scheme: "https",
+		Host:   uri.Host,
+		Path:   uri.Path,
+	}
+	if wsURL.Path == "" {
+		wsURL.Path = "/"
+	}
+
+	conn, _, err := websocket.Dial(ctx, wsURL.String(), &websocket.DialOptions{
+		HTTPClient:   wsHTTPClient,
+		Subprotocols: []string{subprotocol},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if conn.Subprotocol() != subprotocol {
+		conn.Close(websocket.StatusProtocolError, "unexpected subprotocol")
+		return nil, errors.New("protocol negotiation error")
+	}
+
+	// The connection lives on after the dial, hence not using ctx.
+	return websocket.NetConn(context.Background(), conn, websocket.MessageBinary), nil
+}
+
+func tlsHandshake(ctx context.Context, conn net.Conn, cfg *tls.Config, uri *url.URL) (net.Conn, error) {
+	tc := tls.Client(conn, cfg)
+	if err := tc.HandshakeContext(ctx); err != nil {
+		tc.Close()
+		return nil, err
+	}
+	if err := validateConnectionState(tc.ConnectionState(), uri); err != nil {
+		tc.Close()
+		return nil, err
+	}
+	return tc, nil
+}
+
+func validateConnectionState(cs tls.ConnectionState, uri *url.URL) error {
+	if cs.NegotiatedProtocol != protocol.ProtocolName {
+		return errors.New("protocol negotiation error")
+	}
+
+	q := uri.Query()
+	relayIDs := q.Get("id")
+	if relayIDs != "" {
+		relayID, err := syncthingprotocol.DeviceIDFromString(relayIDs)
+		if err != nil {
+			return fmt.Errorf("relay address contains invalid verification id: %w", err)
+		}
+
+		certs := cs.PeerCertificates
+		if cl := len(certs); cl != 1 {
+			return fmt.Errorf("unexpected certificate count: %d", cl)
+		}
+
+		remoteID := syncthingprotocol.NewDeviceID(certs[0].Raw)
+		if remoteID != relayID {
+			return fmt.Errorf("relay id does not match. Expected %v got %v", relayID, remoteID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors (see the CONTRIBUTORS file).
+
+//go:build noquic
+// +build noquic
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+)
+
+type quicConn struct {
+	net.Conn
+}
+
+func (*quicConn) ConnectionState() tls.ConnectionState {
+	return tls.ConnectionState{}
+}
+
+func dialQUIC(context.Context, string, *tls.Config, string) (*quicConn, error) {
+	return nil, errors.New("relay over QUIC: disabled at build time")
+}
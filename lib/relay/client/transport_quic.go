@@ -0,0 +1,65 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors (see the CONTRIBUTORS file).
+
+//go:build !noquic
+// +build !noquic
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/quic-go/quic-go"
+)
+
+var quicConfig = &quic.Config{
+	MaxIdleTimeout:  30 * time.Second,
+	KeepAlivePeriod: 15 * time.Second,
+}
+
+const quicCloseTimeout = 5 * time.Second
+
+// quicConn is a single stream on its own QUIC connection.
+type quicConn struct {
+	quic.Connection
+	quic.Stream
+}
+
+// Close closes the stream and, once the other side has had the chance to
+// read what was written last, the connection. Closing the connection right
+// away would discard anything not yet delivered.
+func (c *quicConn) Close() error {
+	err := c.Stream.Close()
+	c.Stream.CancelRead(0) // unblocks readers, as closing a net.Conn would
+	go func() {
+		select {
+		case <-c.Connection.Context().Done():
+		case <-time.After(quicCloseTimeout):
+		}
+		c.Connection.CloseWithError(0, "closing")
+	}()
+	return err
+}
+
+func (c *quicConn) ConnectionState() tls.ConnectionState {
+	return c.Connection.ConnectionState().TLS
+}
+
+func dialQUIC(ctx context.Context, addr string, cfg *tls.Config, nextProto string) (*quicConn, error) {
+	cfg = cfg.Clone()
+	cfg.NextProtos = []string{nextProto}
+	cfg.MinVersion = tls.VersionTLS13
+	cfg.CipherSuites = nil // not configurable for TLS 1.3
+
+	conn, err := quic.DialAddr(ctx, addr, cfg, quicConfig)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		conn.CloseWithError(0, "error")
+		return nil, err
+	}
+	return &quicConn{Connection: conn, Stream: stream}, nil
+}
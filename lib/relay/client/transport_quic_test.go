@@ -0,0 +1,86 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors (see the CONTRIBUTORS file).
+
+//go:build !noquic
+// +build !noquic
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	syncthingprotocol "github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/relay/protocol"
+)
+
+func TestQUICTransport(t *testing.T) {
+	relayCert := newTestCertificate(t)
+	relayID := syncthingprotocol.NewDeviceID(relayCert.Certificate[0])
+
+	// A relay that echoes everything back on the stream of each
+	// connection, whatever the negotiated protocol.
+	listener, err := quic.ListenAddr("127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{relayCert},
+		NextProtos:   []string{protocol.ProtocolName, protocol.SessionProtocolName},
+		MinVersion:   tls.VersionTLS13,
+	}, quicConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	protocols := make(chan string, 3)
+	go func() {
+		for {
+			conn, err := listener.Accept(context.Background())
+			if err != nil {
+				return
+			}
+			protocols <- conn.ConnectionState().TLS.NegotiatedProtocol
+			go func() {
+				stream, err := conn.AcceptStream(context.Background())
+				if err != nil {
+					return
+				}
+				io.Copy(stream, stream)
+				stream.Close()
+			}()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	clientCfg := configForCerts([]tls.Certificate{newTestCertificate(t)})
+	host := listener.Addr().String()
+
+	uri := &url.URL{Scheme: schemeQUIC, Host: host, RawQuery: "id=" + relayID.String()}
+	conn, err := dialProtocol(ctx, uri, clientCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo(t, conn)
+	if proto := <-protocols; proto != protocol.ProtocolName {
+		t.Errorf("Expected protocol connection to negotiate %q, got %q", protocol.ProtocolName, proto)
+	}
+
+	wrongID := &url.URL{Scheme: schemeQUIC, Host: host, RawQuery: "id=" + syncthingprotocol.DeviceID{42}.String()}
+	if conn, err := dialProtocol(ctx, wrongID, clientCfg); err == nil {
+		conn.Close()
+		t.Error("Expected protocol connection to a relay with another ID to fail")
+	}
+	<-protocols
+
+	conn, err = dialSession(ctx, uri, protocol.SessionInvitation{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo(t, conn)
+	if proto := <-protocols; proto != protocol.SessionProtocolName {
+		t.Errorf("Expected session connection to negotiate %q, got %q", protocol.SessionProtocolName, proto)
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2024 Audrius Butkevicius and Contributors (see the CONTRIBUTORS file).
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+
+	syncthingprotocol "github.com/syncthing/syncthing/lib/protocol"
+	"github.com/syncthing/syncthing/lib/relay/protocol"
+	"github.com/syncthing/syncthing/lib/tlsutil"
+)
+
+func TestSupportedScheme(t *testing.T) {
+	cases := map[string]bool{
+		"relay":      true,
+		"relay+quic": true,
+		"relay+wss":  true,
+		"relay+ws":   false,
+		"dynamic":    false,
+		"":           false,
+	}
+	for scheme, expected := range cases {
+		if res := supportedScheme(scheme); res != expected {
+			t.Errorf("supportedScheme(%q) == %v, expected %v", scheme, res, expected)
+		}
+	}
+}
+
+func TestValidateConnectionState(t *testing.T) {
+	relayCert := newTestCertificate(t)
+	relayID := syncthingprotocol.NewDeviceID(relayCert.Certificate[0])
+	otherID := syncthingprotocol.DeviceID{42}
+	parsed := relayCert.Leaf
+
+	cases := []struct {
+		name  string
+		cs    tls.ConnectionState
+		query string
+		ok    bool
+	}{
+		{"no id", tls.ConnectionState{NegotiatedProtocol: protocol.ProtocolName}, "", true},
+		{"matching id", tls.ConnectionState{NegotiatedProtocol: protocol.ProtocolName, PeerCertificates: []*x509.Certificate{parsed}}, "id=" + relayID.String(), true},
+		{"wrong protocol", tls.ConnectionState{NegotiatedProtocol: "h2"}, "", false},
+		{"no protocol", tls.ConnectionState{}, "", false},
+		{"other id", tls.ConnectionState{NegotiatedProtocol: protocol.ProtocolName, PeerCertificates: []*x509.Certificate{parsed}}, "id=" + otherID.String(), false},
+		{"invalid id", tls.ConnectionState{NegotiatedProtocol: protocol.ProtocolName, PeerCertificates: []*x509.Certificate{parsed}}, "id=nope", false},
+		{"no certificate", tls.ConnectionState{NegotiatedProtocol: protocol.ProtocolName}, "id=" + relayID.String(), false},
+		{"too many certificates", tls.ConnectionState{NegotiatedProtocol: protocol.ProtocolName, PeerCertificates: []*x509.Certificate{parsed, parsed}}, "id=" + relayID.String(), false},
+	}
+
+	for _, tc := range cases {
+		uri := &url.URL{Scheme: schemeTCP, Host: "192.0.2.42:22067", RawQuery: tc.query}
+		err := validateConnectionState(tc.cs, uri)
+		if tc.ok && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		} else if !tc.ok && err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
+
+func TestWebSocketTransport(t *testing.T) {
+	relayCert := newTestCertificate(t)
+	relayID := syncthingprotocol.NewDeviceID(relayCert.Certificate[0])
+
+	// A relay that runs TLS within the WebSocket for protocol connections
+	// and echoes everything back on both kinds of connection.
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := websocket.Accept(w, req, &websocket.AcceptOptions{
+			Subprotocols: []string{protocol.ProtocolName, protocol.SessionProtocolName},
+		})
+		if err != nil {
+			return
+		}
+		nc := websocket.NetConn(context.Background(), conn, websocket.MessageBinary)
+		defer nc.Close()
+		switch conn.Subprotocol() {
+		case protocol.ProtocolName:
+			tc := tls.Server(nc, &tls.Config{
+				Certificates: []tls.Certificate{relayCert},
+				NextProtos:   []string{protocol.ProtocolName},
+			})
+			if tc.Handshake() != nil {
+				return
+			}
+			io.Copy(tc, tc)
+		case protocol.SessionProtocolName:
+			io.Copy(nc, nc)
+		}
+	}))
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "https://")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	clientCfg := configForCerts([]tls.Certificate{newTestCertificate(t)})
+
+	uri := &url.URL{Scheme: schemeWebSocket, Host: host, RawQuery: "id=" + relayID.String()}
+	conn, err := dialProtocol(ctx, uri, clientCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo(t, conn)
+
+	wrongID := &url.URL{Scheme: schemeWebSocket, Host: host, RawQuery: "id=" + syncthingprotocol.DeviceID{42}.String()}
+	if conn, err := dialProtocol(ctx, wrongID, clientCfg); err == nil {
+		conn.Close()
+		t.Error("Expected protocol connection to a relay with another ID to fail")
+	}
+
+	conn, err = dialSession(ctx, uri, protocol.SessionInvitation{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	echo(t, conn)
+}
+
+func TestWebSocketTransportSubprotocol(t *testing.T) {
+	// A server that doesn't speak the relay protocol.
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		conn, err := websocket.Accept(w, req, nil)
+		if err != nil {
+			return
+		}
+		conn.Close(websocket.StatusNormalClosure, "")
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	uri := &url.URL{Scheme: schemeWebSocket, Host: strings.TrimPrefix(srv.URL, "https://")}
+	if conn, err := dialSession(ctx, uri, protocol.SessionInvitation{}); err == nil {
+		conn.Close()
+		t.Error("Expected session connection without the subprotocol to fail")
+	}
+}
+
+func TestUnsupportedScheme(t *testing.T) {
+	ctx := context.Background()
+	uri := &url.URL{Scheme: "relay+ws", Host: "192.0.2.42:443"}
+	if _, err := dialProtocol(ctx, uri, &tls.Config{}); err == nil {
+		t.Error("Expected protocol connection with an unsupported scheme to fail")
+	}
+	if _, err := dialSession(ctx, uri, protocol.SessionInvitation{}); err == nil {
+		t.Error("Expected session connection with an unsupported scheme to fail")
+	}
+}
+
+func newTestCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+	cert, err := tlsutil.NewCertificateInMemory("syncthing", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cert
+}
+
+// echo writes to the connection, expects to read the same back and closes
+// it.
+func echo(t *testing.T, conn net.Conn) {
+	t.Helper()
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	msg := []byte("hello relay")
+	if _, err := conn.Write(msg); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len(msg))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != string(msg) {
+		t.Errorf("Expected %q back, got %q", msg, buf)
+	}
+}
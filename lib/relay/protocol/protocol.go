@@ -11,6 +11,10 @@ import (
 const (
 	magic        = 0x9E79BC40
 	ProtocolName = "bep-relay"
+
+	// SessionProtocolName tells session connections apart from protocol
+	// connections on transports where both arrive at the same address.
+	SessionProtocolName = "bep-relay-session"
 )
 
 var (
@@ -18,6 +22,8 @@ var (
 	ResponseNotFound          = Response{1, "not found"}
 	ResponseAlreadyConnected  = Response{2, "already connected"}
 	ResponseWrongToken        = Response{3, "wrong token"}
+	ResponseNotAuthorized     = Response{4, "not authorized"}
+	ResponseQuotaExceeded     = Response{5, "quota exceeded"}
 	ResponseUnexpectedMessage = Response{100, "unexpected message"}
 )
 
@@ -16,7 +16,7 @@ import (
 )
 
 // HashFile hashes the files and returns a list of blocks representing the file.
-func HashFile(ctx context.Context, folderID string, fs fs.Filesystem, path string, blockSize int, counter Counter, useWeakHashes bool) ([]protocol.BlockInfo, error) {
+func HashFile(ctx context.Context, folderID string, fs fs.Filesystem, path string, blockSize int, hashAlgorithm protocol.HashAlgorithm, counter Counter, useWeakHashes bool) ([]protocol.BlockInfo, error) {
 	fd, err := fs.Open(path)
 	if err != nil {
 		l.Debugln("open:", err)
@@ -36,7 +36,7 @@ func HashFile(ctx context.Context, folderID string, fs fs.Filesystem, path strin
 
 	// Hash the file. This may take a while for large files.
 
-	blocks, err := Blocks(ctx, fd, blockSize, size, counter, useWeakHashes)
+	blocks, err := Blocks(ctx, fd, blockSize, size, hashAlgorithm, counter, useWeakHashes)
 	if err != nil {
 		l.Debugln("blocks:", err)
 		return nil, err
@@ -66,6 +66,7 @@ func HashFile(ctx context.Context, folderID string, fs fs.Filesystem, path strin
 type parallelHasher struct {
 	folderID string
 	fs       fs.Filesystem
+	cache    HashCache
 	outbox   chan<- ScanResult
 	inbox    <-chan protocol.FileInfo
 	counter  Counter
@@ -73,10 +74,11 @@ type parallelHasher struct {
 	wg       sync.WaitGroup
 }
 
-func newParallelHasher(ctx context.Context, folderID string, fs fs.Filesystem, workers int, outbox chan<- ScanResult, inbox <-chan protocol.FileInfo, counter Counter, done chan<- struct{}) {
+func newParallelHasher(ctx context.Context, folderID string, fs fs.Filesystem, cache HashCache, workers int, outbox chan<- ScanResult, inbox <-chan protocol.FileInfo, counter Counter, done chan<- struct{}) {
 	ph := &parallelHasher{
 		folderID: folderID,
 		fs:       fs,
+		cache:    cache,
 		outbox:   outbox,
 		inbox:    inbox,
 		counter:  counter,
@@ -108,7 +110,7 @@ func (ph *parallelHasher) hashFiles(ctx context.Context) {
 				panic("Bug. Asked to hash a directory or a deleted file.")
 			}
 
-			blocks, err := HashFile(ctx, ph.folderID, ph.fs, f.Name, f.BlockSize(), ph.counter, true)
+			blocks, err := ph.hashFile(ctx, f)
 			if err != nil {
 				handleError(ctx, "hashing", f.Name, err, ph.outbox)
 				continue
@@ -139,6 +141,36 @@ func (ph *parallelHasher) hashFiles(ctx context.Context) {
 	}
 }
 
+// hashFile returns the blocks of the file, taken from the hash cache if it
+// has them for the file as it is now and with the same block size.
+func (ph *parallelHasher) hashFile(ctx context.Context, f protocol.FileInfo) ([]protocol.BlockInfo, error) {
+	if ph.cache == nil {
+		return HashFile(ctx, ph.folderID, ph.fs, f.Name, f.BlockSize(), f.BlockHashAlgorithm, ph.counter, true)
+	}
+
+	info, err := ph.fs.Lstat(f.Name)
+	if err != nil {
+		return nil, err
+	}
+	if blocks, ok := ph.cache.Blocks(info, f.BlockHashAlgorithm); ok && (len(blocks) < 2 || int(blocks[0].Size) == f.BlockSize()) {
+		l.Debugln("blocks from hash cache:", f)
+		if ph.counter != nil {
+			ph.counter.Update(info.Size())
+		}
+		return blocks, nil
+	}
+
+	// The file is hashed as it is now, or later. In the latter case the
+	// inode change time recorded is older than the file's and the entry
+	// won't match again.
+	blocks, err := HashFile(ctx, ph.folderID, ph.fs, f.Name, f.BlockSize(), f.BlockHashAlgorithm, ph.counter, true)
+	if err != nil {
+		return nil, err
+	}
+	ph.cache.SetBlocks(info, f.BlockHashAlgorithm, blocks)
+	return blocks, nil
+}
+
 func (ph *parallelHasher) closeWhenDone() {
 	ph.wg.Wait()
 	// In case the hasher aborted on context, wait for filesystem
@@ -9,7 +9,6 @@ package scanner
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"hash"
 	"hash/adler32"
 	"io"
@@ -23,14 +22,15 @@ type Counter interface {
 	Update(bytes int64)
 }
 
-// Blocks returns the blockwise hash of the reader.
-func Blocks(ctx context.Context, r io.Reader, blocksize int, sizehint int64, counter Counter, useWeakHashes bool) ([]protocol.BlockInfo, error) {
+// Blocks returns the blockwise hash of the reader, using the given hash
+// algorithm.
+func Blocks(ctx context.Context, r io.Reader, blocksize int, sizehint int64, hashAlgorithm protocol.HashAlgorithm, counter Counter, useWeakHashes bool) ([]protocol.BlockInfo, error) {
 	if counter == nil {
 		counter = &noopCounter{}
 	}
 
-	hf := sha256.New()
-	const hashLength = sha256.Size
+	hf := hashAlgorithm.NewHash()
+	const hashLength = protocol.BlockHashSize
 
 	var weakHf hash.Hash32 = noopHash{}
 	var multiHf io.Writer = hf
@@ -102,10 +102,15 @@ func Blocks(ctx context.Context, r io.Reader, blocksize int, sizehint int64, cou
 
 	if len(blocks) == 0 {
 		// Empty file
+		hash := SHA256OfNothing
+		if hashAlgorithm != protocol.HashAlgorithmSHA256 {
+			sum := hashAlgorithm.Sum(nil)
+			hash = sum[:]
+		}
 		blocks = append(blocks, protocol.BlockInfo{
 			Offset: 0,
 			Size:   0,
-			Hash:   SHA256OfNothing,
+			Hash:   hash,
 		})
 	}
 
@@ -113,15 +118,16 @@ func Blocks(ctx context.Context, r io.Reader, blocksize int, sizehint int64, cou
 }
 
 // Validate quickly validates buf against the 32-bit weakHash, if not zero,
-// else against the cryptohash hash, if len(hash)>0. It is satisfied if
-// either hash matches or neither hash is given.
-func Validate(buf, hash []byte, weakHash uint32) bool {
+// else against the cryptohash hash computed with hashAlgorithm, if
+// len(hash)>0. It is satisfied if either hash matches or neither hash is
+// given.
+func Validate(buf, hash []byte, weakHash uint32, hashAlgorithm protocol.HashAlgorithm) bool {
 	if weakHash != 0 && adler32.Checksum(buf) == weakHash {
 		return true
 	}
 
 	if len(hash) > 0 {
-		hbuf := sha256.Sum256(buf)
+		hbuf := hashAlgorithm.Sum(buf)
 		return bytes.Equal(hbuf[:], hash)
 	}
 
@@ -71,7 +71,7 @@ var blocksTestData = []struct {
 func TestBlocks(t *testing.T) {
 	for testNo, test := range blocksTestData {
 		buf := bytes.NewBuffer(test.data)
-		blocks, err := Blocks(context.TODO(), buf, test.blocksize, -1, nil, true)
+		blocks, err := Blocks(context.TODO(), buf, test.blocksize, -1, protocol.HashAlgorithmSHA256, nil, true)
 
 		if err != nil {
 			t.Fatal(err)
@@ -106,6 +106,45 @@ func TestBlocks(t *testing.T) {
 	}
 }
 
+func TestBlocksBLAKE3(t *testing.T) {
+	tests := []struct {
+		data      string
+		blocksize int
+		hash      []string
+	}{
+		{"", 1024, []string{
+			"af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"}},
+		{"contents", 1024, []string{
+			"9a93003c179d7929c7b55c86ae35b8de95eac5690012cdefb7b063d95a625782"}},
+		{"contents", 3, []string{
+			"5011fd09eae952ba6bc8fb69e4ae38969ac31735e74a52da425d827bdd790e93",
+			"ca59e27f719c9be55fc7ed4fc4dd984b7713005bd07699feb17e39b7ebbbf4e6",
+			"9af9aba2dd5a191e00fc8857b13100ff86f3cb616583ec0d6723360cc8afcb43"}},
+	}
+
+	for testNo, test := range tests {
+		blocks, err := Blocks(context.TODO(), bytes.NewBufferString(test.data), test.blocksize, -1, protocol.HashAlgorithmBLAKE3, nil, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l := len(blocks); l != len(test.hash) {
+			t.Fatalf("%d: Incorrect number of blocks %d != %d", testNo, l, len(test.hash))
+		}
+		for i, block := range blocks {
+			if h := fmt.Sprintf("%x", block.Hash); h != test.hash[i] {
+				t.Errorf("%d/%d: Incorrect block hash %q != %q", testNo, i, h, test.hash[i])
+			}
+			data := []byte(test.data)[block.Offset : block.Offset+int64(block.Size)]
+			if !Validate(data, block.Hash, 0, protocol.HashAlgorithmBLAKE3) {
+				t.Errorf("%d/%d: Block doesn't validate", testNo, i)
+			}
+			if len(data) > 0 && Validate(data, block.Hash, 0, protocol.HashAlgorithmSHA256) {
+				t.Errorf("%d/%d: Block validates with the wrong algorithm", testNo, i)
+			}
+		}
+	}
+}
+
 func TestAdler32Variants(t *testing.T) {
 	// Verify that the two adler32 functions give matching results for a few
 	// different blocks of data.
@@ -125,7 +164,7 @@ func TestAdler32Variants(t *testing.T) {
 
 		// Make sure whatever we use in Validate matches too resp. this
 		// tests gets adjusted if we ever switch the weak hash algo.
-		return sum1 == sum2 && Validate(data, nil, sum1)
+		return sum1 == sum2 && Validate(data, nil, sum1, protocol.HashAlgorithmSHA256)
 	}
 
 	// protocol block sized data
@@ -172,7 +211,7 @@ func TestAdler32Variants(t *testing.T) {
 				t.Errorf("Mismatch after roll; i=%d, sum1=%08x, sum3=%08x", i, sum1, sum3)
 				break
 			}
-			if !Validate(window, nil, sum1) {
+			if !Validate(window, nil, sum1, protocol.HashAlgorithmSHA256) {
 				t.Errorf("Validation failure after roll; i=%d", i)
 			}
 		}
@@ -215,7 +254,7 @@ func BenchmarkValidate(b *testing.B) {
 
 	for i := 0; i < b.N; i++ {
 		for _, b := range blocks {
-			Validate(b.data, b.hash[:], b.weakhash)
+			Validate(b.data, b.hash[:], b.weakhash, protocol.HashAlgorithmSHA256)
 		}
 	}
 }
@@ -0,0 +1,180 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package scanner
+
+import (
+	"encoding/binary"
+	"errors"
+	"path/filepath"
+	"sort"
+
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+const checkpointVersion = 1
+
+var errCheckpointCorrupt = errors.New("corrupt scan checkpoint")
+
+// A Checkpoint records the directories seen by a complete scan, with their
+// modification and inode change times. A directory with the same times is
+// assumed to have the same entries, so a later scan based on the checkpoint
+// doesn't read it, but only walks the subdirectories recorded for it.
+// Changes to the contents of existing files in such directories are not
+// detected that way.
+//
+// A Checkpoint is used by one walker at a time.
+type Checkpoint struct {
+	// Identifies what the checkpoint is valid for, e.g. the folder's index
+	// ID and ignore patterns.
+	Tag string
+
+	prev      map[string]checkpointDir
+	subdirs   map[string][]string
+	next      map[string]checkpointDir
+	unchanged map[string]struct{}
+}
+
+type checkpointDir struct {
+	modTime    int64
+	changeTime int64
+}
+
+// NewCheckpoint returns a checkpoint recording the directories of the next
+// scan, based on the previous one if not nil.
+func NewCheckpoint(tag string, prev *Checkpoint) *Checkpoint {
+	c := &Checkpoint{
+		Tag:       tag,
+		next:      make(map[string]checkpointDir),
+		unchanged: make(map[string]struct{}),
+	}
+	if prev != nil {
+		c.prev = prev.next
+		c.subdirs = make(map[string][]string)
+		for dir := range c.prev {
+			if dir != "." {
+				parent := filepath.Dir(dir)
+				c.subdirs[parent] = append(c.subdirs[parent], dir)
+			}
+		}
+		// Walked in the same order as when reading the directories.
+		for _, subdirs := range c.subdirs {
+			sort.Strings(subdirs)
+		}
+	}
+	return c
+}
+
+// visit records the directory and returns whether it is unchanged since
+// the previous checkpoint, and if so, its subdirectories.
+func (c *Checkpoint) visit(path string, info fs.FileInfo) ([]string, bool) {
+	cur := checkpointDir{modTime: info.ModTime().UnixNano()}
+	if ct := info.InodeChangeTime(); !ct.IsZero() {
+		cur.changeTime = ct.UnixNano()
+	}
+	c.next[path] = cur
+
+	if prev, ok := c.prev[path]; !ok || prev != cur {
+		return nil, false
+	}
+	c.unchanged[path] = struct{}{}
+	return c.subdirs[path], true
+}
+
+// forget removes the directory from the record, e.g. because it couldn't
+// be read.
+func (c *Checkpoint) forget(path string) {
+	delete(c.next, path)
+	delete(c.unchanged, path)
+}
+
+// Unchanged returns whether the directory was found unchanged since the
+// previous checkpoint during the scan, i.e. whether the files in it weren't
+// looked at.
+func (c *Checkpoint) Unchanged(dir string) bool {
+	_, ok := c.unchanged[dir]
+	return ok
+}
+
+// Len returns the number of directories recorded.
+func (c *Checkpoint) Len() int {
+	return len(c.next)
+}
+
+// Marshal returns the directories recorded, for loading with
+// UnmarshalCheckpoint.
+func (c *Checkpoint) Marshal() []byte {
+	bs := []byte{checkpointVersion}
+	bs = appendString(bs, c.Tag)
+	bs = binary.AppendUvarint(bs, uint64(len(c.next)))
+	for path, dir := range c.next {
+		bs = appendString(bs, path)
+		bs = binary.AppendVarint(bs, dir.modTime)
+		bs = binary.AppendVarint(bs, dir.changeTime)
+	}
+	return bs
+}
+
+// UnmarshalCheckpoint returns a checkpoint as marshalled, to be passed to
+// NewCheckpoint as the previous one.
+func UnmarshalCheckpoint(bs []byte) (*Checkpoint, error) {
+	if len(bs) == 0 || bs[0] != checkpointVersion {
+		return nil, errCheckpointCorrupt
+	}
+	r := checkpointReader{bs: bs[1:]}
+	c := &Checkpoint{Tag: r.string()}
+	n := r.uvarint()
+	c.next = make(map[string]checkpointDir, min(n, uint64(len(bs))))
+	for i := uint64(0); i < n && r.err == nil; i++ {
+		path := r.string()
+		c.next[path] = checkpointDir{modTime: r.varint(), changeTime: r.varint()}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return c, nil
+}
+
+func appendString(bs []byte, s string) []byte {
+	bs = binary.AppendUvarint(bs, uint64(len(s)))
+	return append(bs, s...)
+}
+
+type checkpointReader struct {
+	bs  []byte
+	err error
+}
+
+func (r *checkpointReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.bs)
+	if n <= 0 {
+		r.err = errCheckpointCorrupt
+		return 0
+	}
+	r.bs = r.bs[n:]
+	return v
+}
+
+func (r *checkpointReader) varint() int64 {
+	v, n := binary.Varint(r.bs)
+	if n <= 0 {
+		r.err = errCheckpointCorrupt
+		return 0
+	}
+	r.bs = r.bs[n:]
+	return v
+}
+
+func (r *checkpointReader) string() string {
+	n := r.uvarint()
+	if r.err != nil || n > uint64(len(r.bs)) {
+		r.err = errCheckpointCorrupt
+		return ""
+	}
+	s := string(r.bs[:n])
+	r.bs = r.bs[n:]
+	return s
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+func TestWalkCheckpoint(t *testing.T) {
+	testFs := fs.NewFilesystem(fs.FilesystemTypeBasic, t.TempDir())
+	for _, dir := range []string{"a/sub", "b"} {
+		if err := testFs.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"a/f1", "a/sub/f2", "b/f3"} {
+		writeFile(t, testFs, name, "data")
+	}
+
+	walk := func(cfiler CurrentFiler, cp *Checkpoint) []protocol.FileInfo {
+		cfg, cancel := testConfig()
+		defer cancel()
+		cfg.Filesystem = testFs
+		cfg.CurrentFiler = cfiler
+		cfg.Checkpoint = cp
+		var files []protocol.FileInfo
+		for res := range Walk(context.Background(), cfg) {
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			files = append(files, res.File)
+		}
+		return files
+	}
+
+	cp1 := NewCheckpoint("tag", nil)
+	current := make(fakeCurrentFiler)
+	for _, f := range walk(current, cp1) {
+		current[f.Name] = f
+	}
+	if cp1.Len() != 4 {
+		t.Fatalf("Expected 4 directories recorded, got %d", cp1.Len())
+	}
+
+	// The checkpoint survives a round trip.
+	prev, err := UnmarshalCheckpoint(cp1.Marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev.Tag != "tag" || prev.Len() != 4 {
+		t.Fatalf("Unexpected checkpoint after round trip: %q, %d directories", prev.Tag, prev.Len())
+	}
+
+	// Change a file in place in a, and add files in b and a/sub. Only the
+	// latter are noticed, as a is unchanged.
+	writeFile(t, testFs, "a/f1", "changed")
+	writeFile(t, testFs, "a/sub/f4", "data")
+	writeFile(t, testFs, "b/f5", "data")
+
+	cp2 := NewCheckpoint("tag", prev)
+	var names []string
+	for _, f := range walk(current, cp2) {
+		if !f.IsDirectory() {
+			names = append(names, f.Name)
+		}
+	}
+	slices.Sort(names)
+	if exp := []string{filepath.FromSlash("a/sub/f4"), filepath.FromSlash("b/f5")}; !slices.Equal(names, exp) {
+		t.Errorf("Expected %v to be scanned, got %v", exp, names)
+	}
+	if !cp2.Unchanged("a") || !cp2.Unchanged(".") {
+		t.Error("Expected a and the root to be unchanged")
+	}
+	if cp2.Unchanged("b") || cp2.Unchanged(filepath.FromSlash("a/sub")) {
+		t.Error("Expected b and a/sub to be changed")
+	}
+	if cp2.Len() != 4 {
+		t.Errorf("Expected 4 directories recorded, got %d", cp2.Len())
+	}
+
+	// Without a previous checkpoint, everything is looked at.
+	var changed bool
+	for _, f := range walk(current, NewCheckpoint("tag", nil)) {
+		if f.Name == filepath.FromSlash("a/f1") {
+			changed = true
+		}
+	}
+	if !changed {
+		t.Error("Expected a/f1 to be scanned")
+	}
+}
+
+func TestCheckpointSubdirsSorted(t *testing.T) {
+	// Unchanged directories' subdirectories are walked in order, however
+	// they were recorded.
+	prev := &Checkpoint{next: map[string]checkpointDir{".": {}}}
+	for _, dir := range []string{"c", "a", "e", "b", "d", filepath.Join("a", "z"), filepath.Join("a", "y")} {
+		prev.next[dir] = checkpointDir{}
+	}
+	c := NewCheckpoint("tag", prev)
+	if exp := []string{"a", "b", "c", "d", "e"}; !slices.Equal(c.subdirs["."], exp) {
+		t.Errorf("Expected subdirectories %v, got %v", exp, c.subdirs["."])
+	}
+	if exp := []string{filepath.Join("a", "y"), filepath.Join("a", "z")}; !slices.Equal(c.subdirs["a"], exp) {
+		t.Errorf("Expected subdirectories %v, got %v", exp, c.subdirs["a"])
+	}
+}
+
+func writeFile(t *testing.T, testFs fs.Filesystem, name, data string) {
+	t.Helper()
+	fd, err := testFs.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fd.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := fd.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
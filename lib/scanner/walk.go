@@ -47,6 +47,12 @@ type Config struct {
 	AutoNormalize bool
 	// Number of routines to use for hashing
 	Hashers int
+	// Algorithm to hash blocks with
+	HashAlgorithm protocol.HashAlgorithm
+	// If RehashOtherAlgorithms is true, unchanged files with blocks hashed
+	// by another algorithm than HashAlgorithm are hashed again, e.g.
+	// because devices we share the folder with don't support it.
+	RehashOtherAlgorithms bool
 	// Our vector clock id
 	ShortID protocol.ShortID
 	// Optional progress tick interval which defines how often FolderScanProgress
@@ -64,6 +70,12 @@ type Config struct {
 	ScanXattrs bool
 	// Filter for extended attributes
 	XattrFilter XattrFilter
+	// If Checkpoint is not nil, the directories walked are recorded in it,
+	// and those unchanged since its previous checkpoint are not read.
+	Checkpoint *Checkpoint
+	// If HashCache is not nil, it is consulted for the blocks of files
+	// before hashing them, and told about the files hashed.
+	HashCache HashCache
 }
 
 type CurrentFiler interface {
@@ -71,6 +83,16 @@ type CurrentFiler interface {
 	CurrentFile(name string) (protocol.FileInfo, bool)
 }
 
+// A HashCache knows the blocks of files hashed before, by their identity
+// on disk, potentially from other folders.
+type HashCache interface {
+	// Blocks returns the blocks of the file, if it has been hashed before
+	// with the same algorithm and is unchanged since.
+	Blocks(info fs.FileInfo, hashAlgorithm protocol.HashAlgorithm) ([]protocol.BlockInfo, bool)
+	// SetBlocks records the blocks of the file as just hashed.
+	SetBlocks(info fs.FileInfo, hashAlgorithm protocol.HashAlgorithm, blocks []protocol.BlockInfo)
+}
+
 type XattrFilter interface {
 	Permit(string) bool
 	GetMaxSingleEntrySize() int
@@ -133,7 +155,7 @@ func (w *walker) walk(ctx context.Context) chan ScanResult {
 	// We're not required to emit scan progress events, just kick off hashers,
 	// and feed inputs directly from the walker.
 	if w.ProgressTickIntervalS < 0 {
-		newParallelHasher(ctx, w.Folder, w.Filesystem, w.Hashers, finishedChan, toHashChan, nil, nil)
+		newParallelHasher(ctx, w.Folder, w.Filesystem, w.HashCache, w.Hashers, finishedChan, toHashChan, nil, nil)
 		return finishedChan
 	}
 
@@ -167,7 +189,7 @@ func (w *walker) walk(ctx context.Context) chan ScanResult {
 		done := make(chan struct{})
 		progress := newByteCounter()
 
-		newParallelHasher(ctx, w.Folder, w.Filesystem, w.Hashers, finishedChan, realToHashChan, progress, done)
+		newParallelHasher(ctx, w.Folder, w.Filesystem, w.HashCache, w.Hashers, finishedChan, realToHashChan, progress, done)
 
 		// A routine which actually emits the FolderScanProgress events
 		// every w.ProgressTicker ticks, until the hasher routines terminate.
@@ -257,7 +279,8 @@ func (w *walker) walkAndHashFiles(ctx context.Context, toHashChan chan<- protoco
 	now := time.Now()
 	ignoredParent := ""
 
-	return func(path string, info fs.FileInfo, err error) error {
+	var walkFn fs.WalkFunc
+	walkFn = func(path string, info fs.FileInfo, err error) error {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -317,11 +340,15 @@ func (w *walker) walkAndHashFiles(ctx context.Context, toHashChan chan<- protoco
 			if !fs.IsNotExist(err) {
 				handleError(ctx, "scan", path, err, finishedChan)
 			}
+			if w.Checkpoint != nil {
+				// Make sure we try reading the directory again next time.
+				w.Checkpoint.forget(path)
+			}
 			return skip
 		}
 
 		if path == "." {
-			return nil
+			return w.walkCheckpointed(path, info, walkFn)
 		}
 
 		if path != nonNormPath {
@@ -340,7 +367,10 @@ func (w *walker) walkAndHashFiles(ctx context.Context, toHashChan chan<- protoco
 
 		if ignoredParent == "" {
 			// parent isn't ignored, nothing special
-			return w.handleItem(ctx, path, info, toHashChan, finishedChan)
+			if err := w.handleItem(ctx, path, info, toHashChan, finishedChan); err != nil {
+				return err
+			}
+			return w.walkCheckpointed(path, info, walkFn)
 		}
 
 		// Part of current path below the ignored (potential) parent
@@ -349,7 +379,10 @@ func (w *walker) walkAndHashFiles(ctx context.Context, toHashChan chan<- protoco
 		// ignored path isn't actually a parent of the current path
 		if rel == path {
 			ignoredParent = ""
-			return w.handleItem(ctx, path, info, toHashChan, finishedChan)
+			if err := w.handleItem(ctx, path, info, toHashChan, finishedChan); err != nil {
+				return err
+			}
+			return w.walkCheckpointed(path, info, walkFn)
 		}
 
 		// The previously ignored parent directories of the current, not
@@ -372,6 +405,27 @@ func (w *walker) walkAndHashFiles(ctx context.Context, toHashChan chan<- protoco
 
 		return nil
 	}
+	return walkFn
+}
+
+// walkCheckpointed records the directory in the checkpoint, if any. If the
+// directory is unchanged since the previous checkpoint, its known
+// subdirectories are walked instead of reading it, and it is skipped.
+func (w *walker) walkCheckpointed(path string, info fs.FileInfo, walkFn fs.WalkFunc) error {
+	if w.Checkpoint == nil || !info.IsDir() || info.IsSymlink() {
+		return nil
+	}
+	subdirs, unchanged := w.Checkpoint.visit(path, info)
+	if !unchanged {
+		return nil
+	}
+	l.Debugln(w, "unchanged since checkpoint:", path)
+	for _, sub := range subdirs {
+		if err := w.Filesystem.Walk(sub, walkFn); err != nil {
+			return err
+		}
+	}
+	return fs.SkipDir
 }
 
 func (w *walker) handleItem(ctx context.Context, path string, info fs.FileInfo, toHashChan chan<- protocol.FileInfo, finishedChan chan<- ScanResult) error {
@@ -422,10 +476,13 @@ func (w *walker) walkRegular(ctx context.Context, relPath string, info fs.FileIn
 	f = w.updateFileInfo(f, curFile)
 	f.NoPermissions = w.IgnorePerms
 	f.RawBlockSize = blockSize
+	f.BlockHashAlgorithm = w.HashAlgorithm
 	l.Debugln(w, "checking:", f)
 
 	if hasCurFile {
-		if curFile.IsEquivalentOptional(f, protocol.FileInfoComparison{
+		if w.RehashOtherAlgorithms && curFile.BlockHashAlgorithm != w.HashAlgorithm {
+			l.Debugln(w, "rehash with", w.HashAlgorithm, "instead of", curFile.BlockHashAlgorithm, ":", curFile)
+		} else if curFile.IsEquivalentOptional(f, protocol.FileInfoComparison{
 			ModTimeWindow:   w.ModTimeWindow,
 			IgnorePerms:     w.IgnorePerms,
 			IgnoreBlocks:    true,
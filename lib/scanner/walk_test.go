@@ -161,7 +161,7 @@ func TestVerify(t *testing.T) {
 	progress := newByteCounter()
 	defer progress.Close()
 
-	blocks, err := Blocks(context.TODO(), buf, blocksize, -1, progress, false)
+	blocks, err := Blocks(context.TODO(), buf, blocksize, -1, protocol.HashAlgorithmSHA256, progress, false)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -635,7 +635,7 @@ func BenchmarkHashFile(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		if _, err := HashFile(context.TODO(), "", testFs, testdataName, protocol.MinBlockSize, nil, true); err != nil {
+		if _, err := HashFile(context.TODO(), "", testFs, testdataName, protocol.MinBlockSize, protocol.HashAlgorithmSHA256, nil, true); err != nil {
 			b.Fatal(err)
 		}
 	}
@@ -1001,3 +1001,70 @@ func BenchmarkWalk(b *testing.B) {
 		walkDir(testFs, "/", nil, nil, 0)
 	}
 }
+
+type fakeHashCache map[string][]protocol.BlockInfo
+
+func (c fakeHashCache) Blocks(info fs.FileInfo, _ protocol.HashAlgorithm) ([]protocol.BlockInfo, bool) {
+	blocks, ok := c[info.Name()]
+	return blocks, ok
+}
+
+func (c fakeHashCache) SetBlocks(info fs.FileInfo, _ protocol.HashAlgorithm, blocks []protocol.BlockInfo) {
+	c[info.Name()] = blocks
+}
+
+func TestWalkHashCache(t *testing.T) {
+	testFs := fs.NewFilesystem(fs.FilesystemTypeFake, rand.String(32)+"?content=true")
+	fd, err := testFs.Create("file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fd.Write(make([]byte, 3*protocol.MinBlockSize)); err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+
+	walk := func(cache HashCache) protocol.FileInfo {
+		t.Helper()
+		cfg, cancel := testConfig()
+		defer cancel()
+		cfg.Filesystem = testFs
+		cfg.HashCache = cache
+		var files []protocol.FileInfo
+		for res := range Walk(context.Background(), cfg) {
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			files = append(files, res.File)
+		}
+		if len(files) != 1 {
+			t.Fatalf("expected one file, got %d", len(files))
+		}
+		return files[0]
+	}
+
+	// Files hashed are recorded.
+	cache := make(fakeHashCache)
+	hashed := walk(cache)
+	if len(hashed.Blocks) != 3 || !bytes.Equal(protocol.BlocksHash(cache["file"]), hashed.BlocksHash) {
+		t.Fatal("expected the hashed blocks to be cached")
+	}
+
+	// Cached blocks are used instead of hashing.
+	cached := make([]protocol.BlockInfo, 3)
+	for i := range cached {
+		cached[i] = protocol.BlockInfo{Offset: int64(i * protocol.MinBlockSize), Size: protocol.MinBlockSize, Hash: make([]byte, 32)}
+	}
+	cache["file"] = cached
+	if f := walk(cache); !bytes.Equal(f.BlocksHash, protocol.BlocksHash(cached)) {
+		t.Fatal("expected the cached blocks")
+	}
+
+	// Unless they have a different block size.
+	cache["file"] = []protocol.BlockInfo{cached[0], cached[1]}
+	cache["file"][0].Size *= 2
+	cache["file"][1].Size = protocol.MinBlockSize
+	if f := walk(cache); !bytes.Equal(f.BlocksHash, hashed.BlocksHash) {
+		t.Fatal("expected the file to be hashed again")
+	}
+}
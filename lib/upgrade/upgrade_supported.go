@@ -65,7 +65,7 @@ var upgradeClient = &http.Client{
 	Timeout: readTimeout,
 	Transport: &http.Transport{
 		DialContext:     dialer.DialContext,
-		Proxy:           http.ProxyFromEnvironment,
+		Proxy:           dialer.HTTPProxy,
 		TLSClientConfig: tlsutil.SecureDefaultWithTLS12(),
 	},
 }
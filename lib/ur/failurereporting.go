@@ -210,7 +210,7 @@ func sendFailureReports(ctx context.Context, reports []FailureReport, url string
 	client := &http.Client{
 		Transport: &http.Transport{
 			DialContext:     dialer.DialContext,
-			Proxy:           http.ProxyFromEnvironment,
+			Proxy:           dialer.HTTPProxy,
 			TLSClientConfig: tlsutil.SecureDefaultWithTLS12(),
 		},
 	}
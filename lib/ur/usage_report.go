@@ -144,7 +144,8 @@ func (s *Service) reportData(ctx context.Context, urVersion int, preview bool) (
 			// None
 		case "simple":
 			report.FolderUses.SimpleVersioning++
-		case "staggered":
+		case "staggered", "snapshot":
+			// Snapshots expire like staggered versions
 			report.FolderUses.StaggeredVersioning++
 		case "external":
 			report.FolderUses.ExternalVersioning++
@@ -198,7 +199,7 @@ func (s *Service) reportData(ctx context.Context, urVersion int, preview bool) (
 		switch {
 		case addr == "dynamic+https://relays.syncthing.net/endpoint":
 			report.Relays.DefaultServers++
-		case strings.HasPrefix(addr, "relay://") || strings.HasPrefix(addr, "dynamic+http"):
+		case strings.HasPrefix(addr, "relay://") || strings.HasPrefix(addr, "relay+") || strings.HasPrefix(addr, "dynamic+http"):
 			report.Relays.OtherServers++
 
 		}
@@ -367,7 +368,7 @@ func (s *Service) sendUsageReport(ctx context.Context) error {
 	client := &http.Client{
 		Transport: &http.Transport{
 			DialContext: dialer.DialContext,
-			Proxy:       http.ProxyFromEnvironment,
+			Proxy:       dialer.HTTPProxy,
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: s.cfg.Options().URPostInsecurely,
 				MinVersion:         tls.VersionTLS12,
@@ -465,7 +466,7 @@ func cpuBenchOnce(ctx context.Context, duration time.Duration, useWeakHash bool,
 	var err error
 	for time.Since(t0) < duration {
 		r := bytes.NewReader(bs)
-		blocksResult, err = scanner.Blocks(ctx, r, protocol.MinBlockSize, int64(len(bs)), nil, useWeakHash)
+		blocksResult, err = scanner.Blocks(ctx, r, protocol.MinBlockSize, int64(len(bs)), protocol.HashAlgorithmSHA256, nil, useWeakHash)
 		if err != nil {
 			return 0 // Context done
 		}
@@ -39,6 +39,10 @@ func newExternal(cfg config.FolderConfiguration) Versioner {
 		command = strings.ReplaceAll(command, `\`, `\\`)
 	}
 
+	if cfg.Versioning.Params["protocol"] == "jsonrpc" {
+		return newExternalPlugin(cfg, command)
+	}
+
 	s := external{
 		command:    command,
 		filesystem: cfg.Filesystem(nil),
@@ -64,19 +68,44 @@ func (v external) Archive(filePath string) error {
 
 	l.Debugln("archiving", filePath)
 
-	if v.command == "" {
-		return errors.New("command is empty, please enter a valid command")
+	cmd, err := externalCommand(v.command, map[string]string{
+		"%FOLDER_FILESYSTEM%": v.filesystem.Type().String(),
+		"%FOLDER_PATH%":       v.filesystem.URI(),
+		"%FILE_PATH%":         filePath,
+	})
+	if err != nil {
+		return err
 	}
-
-	words, err := shellquote.Split(v.command)
+	combinedOutput, err := cmd.CombinedOutput()
+	l.Debugln("external command output:", string(combinedOutput))
 	if err != nil {
-		return fmt.Errorf("command is invalid: %w", err)
+		if eerr, ok := err.(*exec.ExitError); ok && len(eerr.Stderr) > 0 {
+			return fmt.Errorf("%v: %v", err, string(eerr.Stderr))
+		}
+		return err
 	}
 
-	context := map[string]string{
-		"%FOLDER_FILESYSTEM%": v.filesystem.Type().String(),
-		"%FOLDER_PATH%":       v.filesystem.URI(),
-		"%FILE_PATH%":         filePath,
+	// return error if the file was not removed
+	if _, err = v.filesystem.Lstat(filePath); fs.IsNotExist(err) {
+		return nil
+	}
+	return errors.New("file was not removed by external script")
+}
+
+func (external) ArchiveFrom(_, _ string) error {
+	return ErrLocalVersioningNotSupported
+}
+
+// externalCommand returns the command to run, with the placeholders in the
+// context replaced by their values.
+func externalCommand(command string, context map[string]string) (*exec.Cmd, error) {
+	if command == "" {
+		return nil, errors.New("command is empty, please enter a valid command")
+	}
+
+	words, err := shellquote.Split(command)
+	if err != nil {
+		return nil, fmt.Errorf("command is invalid: %w", err)
 	}
 
 	for i, word := range words {
@@ -98,20 +127,7 @@ func (v external) Archive(filePath string) error {
 		}
 	}
 	cmd.Env = filteredEnv
-	combinedOutput, err := cmd.CombinedOutput()
-	l.Debugln("external command output:", string(combinedOutput))
-	if err != nil {
-		if eerr, ok := err.(*exec.ExitError); ok && len(eerr.Stderr) > 0 {
-			return fmt.Errorf("%v: %v", err, string(eerr.Stderr))
-		}
-		return err
-	}
-
-	// return error if the file was not removed
-	if _, err = v.filesystem.Lstat(filePath); fs.IsNotExist(err) {
-		return nil
-	}
-	return errors.New("file was not removed by external script")
+	return cmd, nil
 }
 
 func (external) GetVersions() (map[string][]FileVersion, error) {
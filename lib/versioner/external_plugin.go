@@ -0,0 +1,471 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package versioner
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/osutil"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+const (
+	// A plugin not used for this long is stopped, to be started again on
+	// the next request.
+	pluginIdleTimeout = time.Minute
+
+	pluginDefaultTimeoutS = 600
+
+	jsonRPCMethodNotFound = -32601
+)
+
+var errPluginTimeout = errors.New("timed out waiting for response")
+
+// externalPlugin is an external versioner running as a long lived process.
+// It is sent JSON-RPC 2.0 requests on its standard input and answers them
+// on its standard output, one message per line, one request at a time.
+// The requests are "archive", "getVersions", "restore" and "clean".
+type externalPlugin struct {
+	command    string
+	folderID   string
+	filesystem fs.Filesystem
+	timeout    time.Duration
+	lookup     FileLookup
+
+	mut    sync.Mutex
+	proc   *pluginProcess // nil when not running
+	nextID int64
+	idle   *time.Timer
+}
+
+type pluginProcess struct {
+	stdin     io.WriteCloser
+	responses chan pluginResponse // closed when the process exits
+	kill      func()
+}
+
+type pluginRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int64       `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type pluginResponse struct {
+	ID     int64           `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *pluginError    `json:"error"`
+}
+
+type pluginError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *pluginError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+type pluginFolder struct {
+	ID         string `json:"id"`
+	Path       string `json:"path"`
+	Filesystem string `json:"filesystem"`
+}
+
+type pluginArchiveParams struct {
+	Folder pluginFolder    `json:"folder"`
+	Path   string          `json:"path"`
+	Source string          `json:"source,omitempty"` // holding the contents to archive, when not at path
+	File   *pluginFileInfo `json:"file,omitempty"`
+}
+
+// pluginFileInfo is the metadata of the file being archived, as last
+// recorded in the database.
+type pluginFileInfo struct {
+	Size          int64                  `json:"size"`
+	ModTime       time.Time              `json:"modTime"`
+	Permissions   uint32                 `json:"permissions"`
+	ModifiedBy    string                 `json:"modifiedBy"`
+	Version       []pluginCounter        `json:"version"`
+	HashAlgorithm protocol.HashAlgorithm `json:"hashAlgorithm"`
+	BlocksHash    string                 `json:"blocksHash"`
+	Blocks        []pluginBlock          `json:"blocks"`
+}
+
+type pluginCounter struct {
+	ID    string `json:"id"`
+	Value uint64 `json:"value"`
+}
+
+type pluginBlock struct {
+	Offset int64  `json:"offset"`
+	Size   int    `json:"size"`
+	Hash   string `json:"hash"`
+}
+
+type pluginRestoreParams struct {
+	Folder      pluginFolder `json:"folder"`
+	Path        string       `json:"path"`
+	VersionTime time.Time    `json:"versionTime"`
+}
+
+type pluginFolderParams struct {
+	Folder pluginFolder `json:"folder"`
+}
+
+func newExternalPlugin(cfg config.FolderConfiguration, command string) Versioner {
+	timeoutS, err := strconv.Atoi(cfg.Versioning.Params["timeoutS"])
+	if err != nil || timeoutS <= 0 {
+		timeoutS = pluginDefaultTimeoutS
+	}
+
+	p := &externalPlugin{
+		command:    command,
+		folderID:   cfg.ID,
+		filesystem: cfg.Filesystem(nil),
+		timeout:    time.Duration(timeoutS) * time.Second,
+	}
+
+	l.Debugf("instantiated %#v", p)
+	return p
+}
+
+func (p *externalPlugin) setFileLookup(lookup FileLookup) {
+	p.lookup = lookup
+}
+
+func (p *externalPlugin) folder() pluginFolder {
+	return pluginFolder{
+		ID:         p.folderID,
+		Path:       p.filesystem.URI(),
+		Filesystem: p.filesystem.Type().String(),
+	}
+}
+
+// Archive moves the named file away to a version archive. If this function
+// returns nil, the named file does not exist any more (has been archived).
+func (p *externalPlugin) Archive(filePath string) error {
+	return p.archive(filePath, filePath)
+}
+
+// ArchiveFrom has the plugin archive the file at srcPath as a version of the
+// named file.
+func (p *externalPlugin) ArchiveFrom(srcPath, filePath string) error {
+	err := p.archive(srcPath, filePath)
+	var perr *pluginError
+	if errors.As(err, &perr) && perr.Code == jsonRPCMethodNotFound {
+		return ErrLocalVersioningNotSupported
+	}
+	return err
+}
+
+func (p *externalPlugin) archive(srcPath, filePath string) error {
+	info, err := p.filesystem.Lstat(srcPath)
+	if fs.IsNotExist(err) {
+		l.Debugln("not archiving nonexistent file", srcPath)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if info.IsSymlink() {
+		panic("bug: attempting to version a symlink")
+	}
+
+	params := pluginArchiveParams{
+		Folder: p.folder(),
+		Path:   filePath,
+	}
+	if srcPath != filePath {
+		params.Source = srcPath
+	}
+	if p.lookup != nil {
+		if file, ok := p.lookup(osutil.NormalizedFilename(filePath)); ok && !file.IsDeleted() {
+			params.File = newPluginFileInfo(file)
+		}
+	}
+
+	l.Debugln("archiving", srcPath, "with plugin")
+	if err := p.call(context.Background(), "archive", params, nil); err != nil {
+		return err
+	}
+
+	// return error if the file was not removed
+	if _, err = p.filesystem.Lstat(srcPath); fs.IsNotExist(err) {
+		return nil
+	}
+	return errors.New("file was not removed by external plugin")
+}
+
+func newPluginFileInfo(file protocol.FileInfo) *pluginFileInfo {
+	info := &pluginFileInfo{
+		Size:          file.Size,
+		ModTime:       file.ModTime(),
+		Permissions:   file.Permissions,
+		ModifiedBy:    file.ModifiedBy.String(),
+		HashAlgorithm: file.BlockHashAlgorithm,
+		BlocksHash:    fmt.Sprintf("%x", file.BlocksHash),
+		Version:       make([]pluginCounter, 0, len(file.Version.Counters)),
+		Blocks:        make([]pluginBlock, 0, len(file.Blocks)),
+	}
+	for _, c := range file.Version.Counters {
+		info.Version = append(info.Version, pluginCounter{ID: c.ID.String(), Value: c.Value})
+	}
+	for _, b := range file.Blocks {
+		info.Blocks = append(info.Blocks, pluginBlock{Offset: b.Offset, Size: b.Size, Hash: fmt.Sprintf("%x", b.Hash)})
+	}
+	return info
+}
+
+func (p *externalPlugin) GetVersions() (map[string][]FileVersion, error) {
+	var versions map[string][]FileVersion
+	err := p.call(context.Background(), "getVersions", pluginFolderParams{Folder: p.folder()}, &versions)
+	var perr *pluginError
+	if errors.As(err, &perr) && perr.Code == jsonRPCMethodNotFound {
+		return nil, ErrRestorationNotSupported
+	} else if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]FileVersion, len(versions))
+	for name, fileVersions := range versions {
+		name = osutil.NormalizedFilename(name)
+		files[name] = append(files[name], fileVersions...)
+	}
+	return files, nil
+}
+
+func (p *externalPlugin) Restore(filePath string, versionTime time.Time) error {
+	filePath = osutil.NativeFilename(filePath)
+
+	// If something already exists where we are restoring to, archive
+	// existing file for versioning, remove if it's a symlink, or fail if
+	// it's a directory.
+	if info, err := p.filesystem.Lstat(filePath); err == nil {
+		switch {
+		case info.IsDir():
+			return ErrDirectory
+		case info.IsSymlink():
+			if err := p.filesystem.Remove(filePath); err != nil {
+				return fmt.Errorf("removing existing symlink: %w", err)
+			}
+		case info.IsRegular():
+			if err := p.Archive(filePath); err != nil {
+				return fmt.Errorf("archiving existing file: %w", err)
+			}
+		default:
+			panic("bug: unknown item type")
+		}
+	} else if !fs.IsNotExist(err) {
+		return err
+	}
+
+	_ = p.filesystem.MkdirAll(filepath.Dir(filePath), 0o755)
+	err := p.call(context.Background(), "restore", pluginRestoreParams{
+		Folder:      p.folder(),
+		Path:        filePath,
+		VersionTime: versionTime,
+	}, nil)
+	var perr *pluginError
+	if errors.As(err, &perr) && perr.Code == jsonRPCMethodNotFound {
+		return ErrRestorationNotSupported
+	}
+	return err
+}
+
+func (p *externalPlugin) Clean(ctx context.Context) error {
+	err := p.call(ctx, "clean", pluginFolderParams{Folder: p.folder()}, nil)
+	var perr *pluginError
+	if errors.As(err, &perr) && perr.Code == jsonRPCMethodNotFound {
+		// Nothing to clean, as far as the plugin is concerned
+		return nil
+	}
+	return err
+}
+
+func (p *externalPlugin) String() string {
+	return fmt.Sprintf("ExternalPlugin/@%p", p)
+}
+
+// call sends the request to the plugin, starting it if it isn't running,
+// and decodes the result of the response into result, unless nil.
+func (p *externalPlugin) call(ctx context.Context, method string, params, result interface{}) error {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.idle != nil {
+		p.idle.Stop()
+	}
+	defer func() {
+		p.idle = time.AfterFunc(pluginIdleTimeout, p.stopIfIdle)
+	}()
+
+	if p.proc == nil {
+		proc, err := p.start()
+		if err != nil {
+			return err
+		}
+		p.proc = proc
+	}
+
+	p.nextID++
+	req := pluginRequest{JSONRPC: "2.0", ID: p.nextID, Method: method, Params: params}
+	bs, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	if _, err := p.proc.stdin.Write(append(bs, '\n')); err != nil {
+		p.stopLocked()
+		return fmt.Errorf("plugin: %w", err)
+	}
+
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case resp, ok := <-p.proc.responses:
+			if !ok {
+				p.stopLocked()
+				return errors.New("plugin exited")
+			}
+			if resp.ID != req.ID {
+				l.Debugln("plugin: discarding response to request", resp.ID)
+				continue
+			}
+			if resp.Error != nil {
+				return resp.Error
+			}
+			if result == nil || len(resp.Result) == 0 {
+				return nil
+			}
+			return json.Unmarshal(resp.Result, result)
+		case <-timer.C:
+			p.stopLocked()
+			return fmt.Errorf("plugin: %s: %w", method, errPluginTimeout)
+		case <-ctx.Done():
+			// The response to come will be discarded by the next call.
+			return ctx.Err()
+		}
+	}
+}
+
+func (p *externalPlugin) start() (*pluginProcess, error) {
+	cmd, err := externalCommand(p.command, map[string]string{
+		"%FOLDER_FILESYSTEM%": p.filesystem.Type().String(),
+		"%FOLDER_PATH%":       p.filesystem.URI(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting plugin: %w", err)
+	}
+	l.Debugln("started plugin", cmd.Args)
+
+	proc := &pluginProcess{
+		stdin:     stdin,
+		responses: make(chan pluginResponse),
+		kill: func() {
+			_ = cmd.Process.Kill()
+		},
+	}
+	// Wait closes the pipes, so it must wait for the output to be read.
+	stderrDone := make(chan struct{})
+	go func() {
+		defer close(stderrDone)
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			l.Debugln("plugin output:", scanner.Text())
+		}
+	}()
+	go func() {
+		defer close(proc.responses)
+		dec := json.NewDecoder(stdout)
+		for {
+			var resp pluginResponse
+			if err := dec.Decode(&resp); err != nil {
+				l.Debugln("plugin: reading response:", err)
+				<-stderrDone
+				_ = cmd.Wait()
+				return
+			}
+			proc.responses <- resp
+		}
+	}()
+	return proc, nil
+}
+
+// stop stops the plugin when the folder stops, once the request in
+// progress, if any, is done. It's started again if used afterwards.
+func (p *externalPlugin) stop() {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	if p.idle != nil {
+		p.idle.Stop()
+		p.idle = nil
+	}
+	p.stopLocked()
+}
+
+func (p *externalPlugin) stopIfIdle() {
+	if !p.mut.TryLock() {
+		// In use, and will be stopped when idle again.
+		return
+	}
+	defer p.mut.Unlock()
+	p.stopLocked()
+}
+
+// stopLocked stops the plugin, giving it a chance to exit by itself on
+// end of input first.
+func (p *externalPlugin) stopLocked() {
+	if p.proc == nil {
+		return
+	}
+	proc := p.proc
+	p.proc = nil
+	l.Debugln("stopping plugin")
+
+	_ = proc.stdin.Close()
+	timer := time.NewTimer(10 * time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case _, ok := <-proc.responses:
+			if !ok {
+				return
+			}
+		case <-timer.C:
+			proc.kill()
+			for range proc.responses {
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package versioner
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kballard/go-shellquote"
+
+	"github.com/syncthing/syncthing/lib/build"
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/protocol"
+)
+
+// TestExternalPluginHelper isn't a test, but the plugin used by
+// TestExternalPlugin when running the test binary as one. It keeps the
+// versions in the directory given in the environment, and the params of
+// the last archive request next to it.
+func TestExternalPluginHelper(_ *testing.T) {
+	versionsDir := os.Getenv("STVERSIONERPLUGINDIR")
+	if versionsDir == "" {
+		return
+	}
+	defer os.Exit(0)
+
+	enc := json.NewEncoder(os.Stdout)
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var req struct {
+			ID     int64           `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			os.Exit(1)
+		}
+
+		var result interface{}
+		var err error
+		switch req.Method {
+		case "archive":
+			var params pluginArchiveParams
+			_ = json.Unmarshal(req.Params, &params)
+			_ = os.WriteFile(versionsDir+".json", req.Params, 0o644)
+			src := params.Path
+			if params.Source != "" {
+				src = params.Source
+			}
+			versionTime := time.Now()
+			dst := filepath.Join(versionsDir, TagFilename(params.Path, versionTime.Format(TimeFormat)))
+			for _, statErr := os.Lstat(dst); statErr == nil; _, statErr = os.Lstat(dst) {
+				versionTime = versionTime.Add(time.Second)
+				dst = filepath.Join(versionsDir, TagFilename(params.Path, versionTime.Format(TimeFormat)))
+			}
+			if err = os.MkdirAll(filepath.Dir(dst), 0o755); err == nil {
+				err = os.Rename(filepath.Join(params.Folder.Path, src), dst)
+			}
+		case "getVersions":
+			result, err = retrieveVersions(fs.NewFilesystem(fs.FilesystemTypeBasic, versionsDir))
+		case "restore":
+			var params pluginRestoreParams
+			_ = json.Unmarshal(req.Params, &params)
+			var bs []byte
+			bs, err = os.ReadFile(filepath.Join(versionsDir, TagFilename(params.Path, params.VersionTime.Format(TimeFormat))))
+			if err == nil {
+				err = os.WriteFile(filepath.Join(params.Folder.Path, params.Path), bs, 0o644)
+			}
+		default:
+			_ = enc.Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": pluginError{Code: jsonRPCMethodNotFound, Message: "method not found"}})
+			continue
+		}
+		if err != nil {
+			_ = enc.Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": pluginError{Code: 1, Message: err.Error()}})
+			continue
+		}
+		_ = enc.Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+	}
+}
+
+func TestExternalPlugin(t *testing.T) {
+	if build.IsWindows {
+		t.Skip("the test binary path isn't quoted for Windows")
+	}
+
+	dir := t.TempDir()
+	folderDir := filepath.Join(dir, "folder")
+	versionsDir := filepath.Join(dir, "versions")
+	t.Setenv("STVERSIONERPLUGINDIR", versionsDir)
+
+	cfg := config.FolderConfiguration{
+		ID:             "folder",
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           folderDir,
+		Versioning: config.VersioningConfiguration{
+			Type: "external",
+			Params: map[string]string{
+				"command":  shellquote.Join(os.Args[0], "-test.run=^TestExternalPluginHelper$"),
+				"protocol": "jsonrpc",
+				"timeoutS": "10",
+			},
+		},
+	}
+	ffs := cfg.Filesystem(nil)
+	if err := ffs.MkdirAll("dir", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join("dir", "file")
+
+	lookup := func(lookupName string) (protocol.FileInfo, bool) {
+		if lookupName != "dir/file" {
+			return protocol.FileInfo{}, false
+		}
+		return protocol.FileInfo{
+			Name:       "dir/file",
+			Size:       9,
+			ModifiedBy: protocol.LocalDeviceID.Short(),
+			Version:    protocol.Vector{}.Update(protocol.LocalDeviceID.Short()),
+			Blocks:     []protocol.BlockInfo{{Size: 9, Hash: []byte{0xde, 0xad}}},
+		}, true
+	}
+	v, err := New(cfg, lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := v.(*versionerWithErrorContext).Versioner.(*externalPlugin)
+	defer p.stopIfIdle()
+
+	// Archiving, passing the file's metadata on
+
+	writeFile(t, ffs, name, "versioned")
+	if err := v.Archive(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ffs.Lstat(name); !fs.IsNotExist(err) {
+		t.Error("File should no longer exist")
+	}
+	bs, err := os.ReadFile(versionsDir + ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var params pluginArchiveParams
+	if err := json.Unmarshal(bs, &params); err != nil {
+		t.Fatal(err)
+	}
+	if params.Folder.ID != "folder" || params.Path != name || params.File == nil {
+		t.Fatalf("Unexpected archive params %s", bs)
+	}
+	if params.File.ModifiedBy != protocol.LocalDeviceID.Short().String() || len(params.File.Version) != 1 || params.File.Blocks[0].Hash != "dead" || params.File.HashAlgorithm != protocol.HashAlgorithmSHA256 {
+		t.Errorf("Unexpected file metadata %s", bs)
+	}
+
+	// Listing versions
+
+	versions, err := v.GetVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileVersions := versions["dir/file"]
+	if len(versions) != 1 || len(fileVersions) != 1 {
+		t.Fatalf("Expected one version of dir/file, got %v", versions)
+	}
+
+	// Restoring, archiving what's there first
+
+	writeFile(t, ffs, name, "current")
+	if err := v.Restore(name, fileVersions[0].VersionTime); err != nil {
+		t.Fatal(err)
+	}
+	if bs, err := os.ReadFile(filepath.Join(folderDir, name)); err != nil || string(bs) != "versioned" {
+		t.Errorf("Expected restored contents, got %q, %v", bs, err)
+	}
+	if err := json.Unmarshal(mustReadFile(t, versionsDir+".json"), &params); err != nil || params.Path != name {
+		t.Errorf("Expected the existing file to be archived, got %v, %v", params, err)
+	}
+
+	// Archiving from another file
+
+	writeFile(t, ffs, "temp", "previous")
+	if err := v.ArchiveFrom("temp", name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ffs.Lstat("temp"); !fs.IsNotExist(err) {
+		t.Error("Source file should no longer exist")
+	}
+	if bs, err := os.ReadFile(filepath.Join(folderDir, name)); err != nil || string(bs) != "versioned" {
+		t.Errorf("Expected the file to be untouched, got %q, %v", bs, err)
+	}
+
+	// Unknown to the plugin
+
+	if err := v.Clean(context.Background()); err != nil {
+		t.Error("Clean should succeed, got", err)
+	}
+
+	// Failing, and running again after exiting
+
+	if err := v.Restore("nonexistent", time.Now()); err == nil {
+		t.Error("Restoring nonexistent version should fail")
+	}
+	p.stopIfIdle()
+	if _, err := v.GetVersions(); err != nil {
+		t.Error("Expected plugin to be started again, got", err)
+	}
+
+	// Exiting when the folder stops
+
+	p.mut.Lock()
+	proc := p.proc
+	p.mut.Unlock()
+	if proc == nil {
+		t.Fatal("Expected the plugin to be running")
+	}
+	Stop(v)
+	if _, ok := <-proc.responses; ok {
+		t.Error("Expected the plugin to have exited")
+	}
+	p.mut.Lock()
+	running, idle := p.proc != nil, p.idle != nil
+	p.mut.Unlock()
+	if running || idle {
+		t.Error("Expected the plugin to be stopped")
+	}
+}
+
+func TestExternalPluginNotRunning(t *testing.T) {
+	cfg := config.FolderConfiguration{
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           t.TempDir(),
+		Versioning: config.VersioningConfiguration{
+			Params: map[string]string{
+				"command":  "nonexistent command",
+				"protocol": "jsonrpc",
+			},
+		},
+	}
+	v := newExternal(cfg)
+	if _, err := v.GetVersions(); err == nil || errors.Is(err, ErrRestorationNotSupported) {
+		t.Error("Expected failure to start plugin, got", err)
+	}
+}
+
+func mustReadFile(t *testing.T, name string) []byte {
+	t.Helper()
+	bs, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bs
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package versioner
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+// retention is the retention policy common to the versioners, limiting the
+// versions kept beyond what the parameters of each allow. It's applied when
+// cleaning out versions.
+type retention struct {
+	maxVersions  int
+	maxTotalSize int64
+	minDiskFree  config.Size
+	rules        []config.VersioningRetentionRule
+}
+
+// A version is an archived version of a file, as seen by the retention
+// policy.
+type version struct {
+	path    string    // in the versions filesystem
+	name    string    // of the versioned file
+	created time.Time // when it was archived
+	size    int64
+}
+
+func newRetention(cfg config.VersioningConfiguration) retention {
+	return retention{
+		maxVersions:  cfg.Retention.MaxVersions,
+		maxTotalSize: cfg.Retention.MaxTotalSizeMiB << 20,
+		minDiskFree:  cfg.Retention.MinDiskFree,
+		rules:        cfg.Retention.Rules,
+	}
+}
+
+// newVersion returns the version at the path in the versions filesystem,
+// archived at the time in its tag or, lacking one, its modification time.
+func newVersion(path, name string, info fs.FileInfo) version {
+	created, err := time.ParseInLocation(TimeFormat, extractTag(path), time.Local)
+	if err != nil {
+		created = info.ModTime()
+	}
+	return version{path: path, name: name, created: created, size: info.Size()}
+}
+
+func (r retention) enabled() bool {
+	return r.maxVersions > 0 || r.maxTotalSize > 0 || r.minDiskFree.BaseValue() > 0 || len(r.rules) > 0
+}
+
+// limits returns the max number of versions and max age for the versions
+// of the named file, zero meaning no limit.
+func (r retention) limits(name string) (int, time.Duration) {
+	for _, rule := range r.rules {
+		if matchRetentionPattern(rule.Pattern, name) {
+			return rule.MaxVersions, time.Duration(rule.MaxAgeDays) * 24 * time.Hour
+		}
+	}
+	return r.maxVersions, 0
+}
+
+func matchRetentionPattern(pattern, name string) bool {
+	pattern = filepath.FromSlash(pattern)
+	if !strings.ContainsRune(pattern, filepath.Separator) {
+		name = filepath.Base(name)
+	}
+	ok, err := filepath.Match(pattern, name)
+	return err == nil && ok
+}
+
+// toRemove returns the versions to remove, given all versions there are.
+// First the per file limits are applied, then the oldest versions are
+// removed until the total size is within the limit and the given number of
+// bytes has been freed.
+func (r retention) toRemove(versions []version, toFree int64, now time.Time) []version {
+	sort.Slice(versions, func(a, b int) bool {
+		return versions[a].created.Before(versions[b].created)
+	})
+
+	var remove, keep []version
+	perFile := make(map[string]int)
+	for _, v := range versions {
+		perFile[v.name]++
+	}
+	for _, v := range versions {
+		maxVersions, maxAge := r.limits(v.name)
+		switch {
+		case maxVersions > 0 && perFile[v.name] > maxVersions:
+			l.Debugln("Versioner: too many versions of", v.name, "-> delete", v.path)
+		case maxAge > 0 && now.Sub(v.created) > maxAge:
+			l.Debugln("Versioner: version over maximum age -> delete", v.path)
+		default:
+			keep = append(keep, v)
+			continue
+		}
+		perFile[v.name]--
+		remove = append(remove, v)
+	}
+
+	var total int64
+	for _, v := range keep {
+		total += v.size
+	}
+	for _, v := range keep {
+		if (r.maxTotalSize <= 0 || total <= r.maxTotalSize) && toFree <= 0 {
+			break
+		}
+		l.Debugln("Versioner: over size limit or short of free space -> delete", v.path)
+		remove = append(remove, v)
+		total -= v.size
+		toFree -= v.size
+	}
+
+	return remove
+}
+
+// bytesToFree returns how many bytes need to be freed to have the required
+// free space, if any.
+func (r retention) bytesToFree(usage fs.Usage) int64 {
+	val := r.minDiskFree.BaseValue()
+	if val <= 0 {
+		return 0
+	}
+	if r.minDiskFree.Percentage() {
+		val = float64(usage.Total) * val / 100
+	}
+	return int64(val) - int64(usage.Free)
+}
+
+// apply removes the versions to remove per the policy from the versions
+// filesystem.
+func (r retention) apply(versionsFs fs.Filesystem, versions []version) {
+	if !r.enabled() || len(versions) == 0 {
+		return
+	}
+
+	var toFree int64
+	if r.minDiskFree.BaseValue() > 0 {
+		if usage, err := versionsFs.Usage("."); err != nil {
+			l.Warnln("Versioner: checking free space:", err)
+		} else {
+			toFree = r.bytesToFree(usage)
+		}
+	}
+
+	for _, v := range r.toRemove(versions, toFree, time.Now()) {
+		if err := versionsFs.Remove(v.path); err != nil {
+			l.Warnf("Versioner: can't remove %q: %v", v.path, err)
+		}
+	}
+}
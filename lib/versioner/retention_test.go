@@ -0,0 +1,135 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package versioner
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/d4l3k/messagediff"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+func TestRetentionToRemove(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.Local)
+	day := 24 * time.Hour
+	versions := func() []version {
+		return []version{
+			{path: "a~3", name: "a", created: now.Add(-1 * day), size: 10},
+			{path: "a~1", name: "a", created: now.Add(-3 * day), size: 10},
+			{path: "a~2", name: "a", created: now.Add(-2 * day), size: 10},
+			{path: "dir/b.log~1", name: "dir/b.log", created: now.Add(-5 * day), size: 100},
+			{path: "dir/b.log~2", name: "dir/b.log", created: now.Add(-4 * day), size: 100},
+			{path: "dir/c~1", name: "dir/c", created: now.Add(-6 * day), size: 1000},
+		}
+	}
+
+	cases := []struct {
+		name      string
+		retention retention
+		toFree    int64
+		expected  []string
+	}{
+		{"none", retention{}, 0, nil},
+		{"max versions", retention{maxVersions: 1}, 0, []string{"dir/b.log~1", "a~1", "a~2"}},
+		{"rule by name", retention{rules: []config.VersioningRetentionRule{{Pattern: "*.log", MaxAgeDays: 4}}}, 0, []string{"dir/b.log~1"}},
+		{"rule by path", retention{rules: []config.VersioningRetentionRule{{Pattern: "dir/*", MaxVersions: 1}}}, 0, []string{"dir/b.log~1"}},
+		{"first rule matching", retention{maxVersions: 1, rules: []config.VersioningRetentionRule{{Pattern: "a"}, {Pattern: "*", MaxVersions: 2}}}, 0, nil},
+		{"max total size", retention{maxTotalSize: 150}, 0, []string{"dir/c~1", "dir/b.log~1"}},
+		{"free space", retention{}, 1050, []string{"dir/c~1", "dir/b.log~1"}},
+		{"both", retention{maxVersions: 2, maxTotalSize: 1000}, 0, []string{"a~1", "dir/c~1"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var removed []string
+			for _, v := range tc.retention.toRemove(versions(), tc.toFree, now) {
+				removed = append(removed, filepath.ToSlash(v.path))
+			}
+			if diff, equal := messagediff.PrettyDiff(tc.expected, removed); !equal {
+				t.Errorf("Unexpected versions removed. Diff:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRetentionBytesToFree(t *testing.T) {
+	usage := fs.Usage{Free: 100 << 20, Total: 1000 << 20}
+	cases := []struct {
+		minDiskFree config.Size
+		expected    int64
+	}{
+		{config.Size{}, 0},
+		{config.Size{Value: 50, Unit: "MB"}, 50*1000*1000 - 100<<20},
+		{config.Size{Value: 20, Unit: "%"}, 100 << 20},
+	}
+	for _, tc := range cases {
+		r := retention{minDiskFree: tc.minDiskFree}
+		if res := r.bytesToFree(usage); res != tc.expected {
+			t.Errorf("%v: expected %d, got %d", tc.minDiskFree, tc.expected, res)
+		}
+	}
+}
+
+func TestRetentionClean(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.FolderConfiguration{
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           dir,
+		Versioning: config.VersioningConfiguration{
+			Params: map[string]string{
+				"keep": "10",
+			},
+			Retention: config.VersioningRetention{
+				MaxVersions:     2,
+				MaxTotalSizeMiB: 1,
+				Rules: []config.VersioningRetentionRule{
+					{Pattern: "*.iso", MaxVersions: 1},
+				},
+			},
+		},
+	}
+	versionsFs := versionerFsFromFolderCfg(cfg)
+	if err := versionsFs.MkdirAll("dir", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	for i, name := range []string{"a", "a", "a", filepath.Join("dir", "b.txt"), "image.iso", "image.iso"} {
+		tag := now.Add(time.Duration(i-10) * time.Minute).Format(TimeFormat)
+		writeFile(t, versionsFs, TagFilename(name, tag), "data")
+	}
+	// Too big to keep along with the others
+	writeFile(t, versionsFs, TagFilename("big", now.Add(-time.Hour).Format(TimeFormat)), string(make([]byte, 1<<20)))
+
+	for _, v := range []Versioner{newSimple(cfg), newTrashcan(cfg)} {
+		if err := v.Clean(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err := retrieveVersions(versionsFs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int)
+	for name, fileVersions := range versions {
+		counts[name] = len(fileVersions)
+	}
+	expected := map[string]int{
+		"a":         2,
+		"dir/b.txt": 1,
+		"image.iso": 1,
+	}
+	if diff, equal := messagediff.PrettyDiff(expected, counts); !equal {
+		t.Errorf("Unexpected versions kept. Diff:\n%s", diff)
+	}
+}
@@ -27,6 +27,7 @@ type simple struct {
 	folderFs        fs.Filesystem
 	versionsFs      fs.Filesystem
 	copyRangeMethod fs.CopyRangeMethod
+	retention       retention
 }
 
 func newSimple(cfg config.FolderConfiguration) Versioner {
@@ -44,6 +45,7 @@ func newSimple(cfg config.FolderConfiguration) Versioner {
 		folderFs:        cfg.Filesystem(nil),
 		versionsFs:      versionerFsFromFolderCfg(cfg),
 		copyRangeMethod: cfg.CopyRangeMethod,
+		retention:       newRetention(cfg.Versioning),
 	}
 
 	l.Debugf("instantiated %#v", s)
@@ -63,6 +65,20 @@ func (v simple) Archive(filePath string) error {
 	return nil
 }
 
+// ArchiveFrom moves the file at srcPath to the version archive as a version
+// of the named file, e.g. previous contents reconstructed after the named
+// file was changed locally.
+func (v simple) ArchiveFrom(srcPath, filePath string) error {
+	err := archiveFileFrom(v.copyRangeMethod, v.folderFs, v.versionsFs, srcPath, filePath, TagFilename)
+	if err != nil {
+		return err
+	}
+
+	cleanVersions(v.versionsFs, findAllVersions(v.versionsFs, filePath), v.toRemove)
+
+	return nil
+}
+
 func (v simple) GetVersions() (map[string][]FileVersion, error) {
 	return retrieveVersions(v.versionsFs)
 }
@@ -72,7 +88,7 @@ func (v simple) Restore(filepath string, versionTime time.Time) error {
 }
 
 func (v simple) Clean(ctx context.Context) error {
-	return clean(ctx, v.versionsFs, v.toRemove)
+	return clean(ctx, v.versionsFs, v.toRemove, v.retention)
 }
 
 func (v simple) toRemove(versions []string, now time.Time) []string {
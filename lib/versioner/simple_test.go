@@ -158,3 +158,56 @@ func TestPathTildes(t *testing.T) {
 		t.Fatalf("found versioned file %q, want one that begins with %q", got, testPath)
 	}
 }
+
+func TestSimpleArchiveFrom(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := config.FolderConfiguration{
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           dir,
+		Versioning: config.VersioningConfiguration{
+			Params: map[string]string{
+				"keep": "2",
+			},
+		},
+	}
+	fs := cfg.Filesystem(nil)
+	v := newSimple(cfg)
+
+	// The file itself has other contents by now, the previous ones are
+	// in a temporary file.
+	if err := fs.MkdirAll("dir", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, fs, filepath.Join("dir", "test"), "current")
+	writeFile(t, fs, filepath.Join("dir", ".syncthing.test.tmp"), "previous")
+
+	if err := v.ArchiveFrom(filepath.Join("dir", ".syncthing.test.tmp"), filepath.Join("dir", "test")); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fs.Lstat(filepath.Join("dir", ".syncthing.test.tmp")); !os.IsNotExist(err) {
+		t.Error("Expected the temporary file to be gone, got", err)
+	}
+	if bs, err := os.ReadFile(filepath.Join(dir, "dir", "test")); err != nil || string(bs) != "current" {
+		t.Errorf("Expected the file to be untouched, got %q, %v", bs, err)
+	}
+
+	versions, err := v.GetVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 || len(versions[filepath.ToSlash(filepath.Join("dir", "test"))]) != 1 {
+		t.Fatalf("Expected one version of dir/test, got %v", versions)
+	}
+	matches, err := filepath.Glob(filepath.Join(dir, DefaultPath, "dir", "test~*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("Expected one version file, got %v", matches)
+	}
+	if bs, err := os.ReadFile(matches[0]); err != nil || string(bs) != "previous" {
+		t.Errorf("Expected the previous contents in the version, got %q, %v", bs, err)
+	}
+}
@@ -0,0 +1,395 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package versioner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+	"github.com/syncthing/syncthing/lib/osutil"
+)
+
+func init() {
+	// Register the constructor for this type of versioner with the name "snapshot"
+	factories["snapshot"] = newSnapshot
+}
+
+const (
+	snapshotPrefix   = "snapshot"
+	snapshotFilesExt = ".files"
+)
+
+// snapshot keeps versions in copy-on-write snapshots of the folder, which
+// take next to no space or IO. Before the first file replaced or deleted by
+// a pull is archived, a read-only Btrfs snapshot of the folder is taken
+// into the versions directory, so archiving a file is merely removing it.
+// The files archived are recorded next to the snapshot as the versions it
+// holds. A snapshot expires once the staggered versioner's rules and the
+// per file limits of the retention policy would remove all of them. Beyond
+// that, the oldest snapshots expire while over the total size or short of
+// free space, their size being that of the files archived in them as they
+// share their data with the folder and each other otherwise.
+type snapshot struct {
+	folderFs   fs.Filesystem
+	versionsFs fs.Filesystem
+	stagger    staggered
+	retention  retention
+
+	mut    sync.Mutex
+	latest string // the snapshot taken last, if any
+}
+
+func newSnapshot(cfg config.FolderConfiguration) Versioner {
+	params := cfg.Versioning.Params
+	folderFs := cfg.Filesystem(nil)
+	versionsFs := versionerFsFromFolderCfg(cfg)
+	intervals := staggeredIntervals(params)
+
+	// Without snapshots, reflinking the old files into the versions
+	// directory still takes next to no space where supported.
+	fallback := &staggered{
+		folderFs:        folderFs,
+		versionsFs:      versionsFs,
+		interval:        intervals,
+		copyRangeMethod: fs.CopyRangeMethodAllWithFallback,
+		retention:       newRetention(cfg.Versioning),
+	}
+
+	mode := params["mode"]
+	if mode == "reflink" {
+		l.Debugf("instantiated %#v", fallback)
+		return fallback
+	}
+
+	err := errors.New("not a local folder")
+	if folderFs.Type() == fs.FilesystemTypeBasic && versionsFs.Type() == fs.FilesystemTypeBasic {
+		err = btrfsSnapshotsUsable(folderFs.URI(), versionsFs.URI())
+	}
+	if err != nil {
+		if mode == "btrfs" {
+			l.Warnf("Versioning folder %s by reflinking files instead of snapshots: %v", cfg.Description(), err)
+		}
+		l.Debugf("instantiated %#v (%v)", fallback, err)
+		return fallback
+	}
+
+	s := &snapshot{
+		folderFs:   folderFs,
+		versionsFs: versionsFs,
+		stagger:    staggered{interval: intervals},
+		retention:  newRetention(cfg.Versioning),
+	}
+
+	l.Debugf("instantiated %#v", s)
+	return s
+}
+
+// Archive moves the named file away to a version archive. If this function
+// returns nil, the named file does not exist any more (has been archived).
+func (v *snapshot) Archive(filePath string) error {
+	filePath = osutil.NativeFilename(filePath)
+	info, err := v.folderFs.Lstat(filePath)
+	if fs.IsNotExist(err) {
+		l.Debugln("not archiving nonexistent file", filePath)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if info.IsSymlink() {
+		panic("bug: attempting to version a symlink")
+	}
+
+	v.mut.Lock()
+	defer v.mut.Unlock()
+
+	if !v.holds(v.latest, filePath, info) {
+		if err := v.takeSnapshotLocked(); err != nil {
+			return err
+		}
+	}
+	if err := v.recordLocked(filePath); err != nil {
+		return err
+	}
+
+	l.Debugln("archiving", filePath, "in", v.latest)
+	return v.folderFs.Remove(filePath)
+}
+
+// ArchiveFrom is not supported, as snapshots can only hold what is in the
+// folder when they are taken. Versions of local changes are only kept when
+// falling back to reflinks.
+func (*snapshot) ArchiveFrom(_, _ string) error {
+	return ErrLocalVersioningNotSupported
+}
+
+// holds returns whether the snapshot has the file as it currently is, i.e.
+// was taken after the file was last changed.
+func (v *snapshot) holds(name, filePath string, info fs.FileInfo) bool {
+	if name == "" {
+		return false
+	}
+	snapInfo, err := v.versionsFs.Lstat(filepath.Join(name, filePath))
+	return err == nil && snapInfo.Size() == info.Size() && snapInfo.ModTime().Equal(info.ModTime())
+}
+
+func (v *snapshot) takeSnapshotLocked() error {
+	if _, err := v.versionsFs.Stat("."); fs.IsNotExist(err) {
+		l.Debugln("creating versions dir")
+		if err := v.versionsFs.MkdirAll(".", 0o755); err != nil {
+			return err
+		}
+		_ = v.versionsFs.Hide(".")
+	} else if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	name := snapshotName(now)
+	if name == v.latest {
+		// Snapshots are named by the second they were taken in.
+		time.Sleep(now.Truncate(time.Second).Add(time.Second).Sub(now))
+		now = time.Now()
+		name = snapshotName(now)
+	}
+
+	l.Debugln("taking snapshot", name)
+	if err := createBtrfsSnapshot(v.folderFs.URI(), filepath.Join(v.versionsFs.URI(), name)); err != nil {
+		return fmt.Errorf("taking snapshot: %w", err)
+	}
+	v.latest = name
+
+	v.expireLocked(now)
+	return nil
+}
+
+// recordLocked adds the file to the versions held by the latest snapshot.
+func (v *snapshot) recordLocked(filePath string) error {
+	fd, err := v.versionsFs.OpenFile(v.latest+snapshotFilesExt, fs.OptWriteOnly|fs.OptCreate|fs.OptAppend, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(append([]byte(filePath), 0)); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
+// archivedLocked returns the files archived in the snapshot.
+func (v *snapshot) archivedLocked(name string) []string {
+	fd, err := v.versionsFs.Open(name + snapshotFilesExt)
+	if err != nil {
+		return nil
+	}
+	defer fd.Close()
+	bs, err := io.ReadAll(fd)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var files []string
+	for _, file := range bytes.Split(bs, []byte{0}) {
+		if _, ok := seen[string(file)]; len(file) == 0 || ok {
+			continue
+		}
+		seen[string(file)] = struct{}{}
+		files = append(files, string(file))
+	}
+	return files
+}
+
+// snapshotsLocked returns the names of the existing snapshots, oldest
+// first.
+func (v *snapshot) snapshotsLocked() []string {
+	names, err := v.versionsFs.Glob(TagFilename(snapshotPrefix, timeGlob))
+	if err != nil {
+		l.Warnln("Versioner: listing snapshots:", err)
+		return nil
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (v *snapshot) expireLocked(now time.Time) {
+	var toFree int64
+	if v.retention.minDiskFree.BaseValue() > 0 {
+		if usage, err := v.versionsFs.Usage("."); err != nil {
+			l.Warnln("Versioner: checking free space:", err)
+		} else {
+			toFree = v.retention.bytesToFree(usage)
+		}
+	}
+
+	for _, name := range v.expiredLocked(toFree, now) {
+		l.Debugln("removing snapshot", name)
+		if err := deleteBtrfsSnapshot(filepath.Join(v.versionsFs.URI(), name)); err != nil {
+			l.Warnf("Versioner: can't remove snapshot %q: %v", name, err)
+			continue
+		}
+		_ = v.versionsFs.Remove(name + snapshotFilesExt)
+	}
+}
+
+// expiredLocked returns the snapshots that hold no versions to keep, as
+// the staggered versioner and the per file limits would for each of the
+// files archived in them, and then the oldest ones while over the total
+// size or until the given number of bytes has been freed.
+func (v *snapshot) expiredLocked(toFree int64, now time.Time) []string {
+	snapshots := v.snapshotsLocked()
+	versionsPerFile := make(map[string][]string)
+	sizes := make(map[string]int64)
+	for _, name := range snapshots {
+		for _, file := range v.archivedLocked(name) {
+			versionsPerFile[file] = append(versionsPerFile[file], name)
+			if info, err := v.versionsFs.Lstat(filepath.Join(name, file)); err == nil {
+				sizes[name] += info.Size()
+			}
+		}
+	}
+
+	// The total size and free space are down to whole snapshots.
+	perFile := v.retention
+	perFile.maxTotalSize = 0
+	needed := make(map[string]struct{})
+	for file, names := range versionsPerFile {
+		remove := v.stagger.toRemove(names, now)
+		var versions []version
+		for _, name := range names {
+			if slices.Contains(remove, name) {
+				continue
+			}
+			created, err := time.ParseInLocation(TimeFormat, extractTag(name), time.Local)
+			if err != nil {
+				continue
+			}
+			versions = append(versions, version{path: name, name: file, created: created})
+		}
+		removed := perFile.toRemove(versions, 0, now)
+		for _, ver := range versions {
+			if !slices.ContainsFunc(removed, func(r version) bool { return r.path == ver.path }) {
+				needed[ver.path] = struct{}{}
+			}
+		}
+	}
+
+	var total int64
+	for name := range needed {
+		total += sizes[name]
+	}
+	for _, name := range snapshots {
+		if _, ok := needed[name]; !ok || name == v.latest {
+			continue
+		}
+		if (v.retention.maxTotalSize <= 0 || total <= v.retention.maxTotalSize) && toFree <= 0 {
+			break
+		}
+		l.Debugln("Versioner: over size limit or short of free space -> delete", name)
+		delete(needed, name)
+		total -= sizes[name]
+		toFree -= sizes[name]
+	}
+
+	var expired []string
+	for _, name := range snapshots {
+		if _, ok := needed[name]; !ok && name != v.latest {
+			expired = append(expired, name)
+		}
+	}
+	return expired
+}
+
+func (v *snapshot) GetVersions() (map[string][]FileVersion, error) {
+	v.mut.Lock()
+	defer v.mut.Unlock()
+
+	files := make(map[string][]FileVersion)
+	for _, name := range v.snapshotsLocked() {
+		versionTime, err := time.ParseInLocation(TimeFormat, extractTag(name), time.Local)
+		if err != nil {
+			continue
+		}
+		for _, filePath := range v.archivedLocked(name) {
+			info, err := v.versionsFs.Lstat(filepath.Join(name, filePath))
+			if err != nil || !info.IsRegular() {
+				continue
+			}
+			filePath = osutil.NormalizedFilename(filePath)
+			files[filePath] = append(files[filePath], FileVersion{
+				VersionTime: versionTime,
+				ModTime:     info.ModTime().Truncate(time.Second),
+				Size:        info.Size(),
+			})
+		}
+	}
+	return files, nil
+}
+
+func (v *snapshot) Restore(filePath string, versionTime time.Time) error {
+	filePath = osutil.NativeFilename(filePath)
+	src := filepath.Join(snapshotName(versionTime), filePath)
+	srcInfo, err := v.versionsFs.Lstat(src)
+	if err != nil || !srcInfo.IsRegular() {
+		return errNotFound
+	}
+
+	// If something already exists where we are restoring to, archive
+	// existing file for versioning, remove if it's a symlink, or fail if
+	// it's a directory.
+	if info, err := v.folderFs.Lstat(filePath); err == nil {
+		switch {
+		case info.IsDir():
+			return ErrDirectory
+		case info.IsSymlink():
+			if err := v.folderFs.Remove(filePath); err != nil {
+				return fmt.Errorf("removing existing symlink: %w", err)
+			}
+		case info.IsRegular():
+			if err := v.Archive(filePath); err != nil {
+				return fmt.Errorf("archiving existing file: %w", err)
+			}
+		default:
+			panic("bug: unknown item type")
+		}
+	} else if !fs.IsNotExist(err) {
+		return err
+	}
+
+	_ = v.folderFs.MkdirAll(filepath.Dir(filePath), 0o755)
+	err = osutil.Copy(fs.CopyRangeMethodAllWithFallback, v.versionsFs, v.folderFs, src, filePath)
+	_ = v.folderFs.Chtimes(filePath, srcInfo.ModTime(), srcInfo.ModTime())
+	return err
+}
+
+func (v *snapshot) Clean(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	v.mut.Lock()
+	defer v.mut.Unlock()
+	v.expireLocked(time.Now())
+	return nil
+}
+
+func (v *snapshot) String() string {
+	return fmt.Sprintf("Snapshot/@%p", v)
+}
+
+func snapshotName(t time.Time) string {
+	return TagFilename(snapshotPrefix, t.In(time.Local).Format(TimeFormat))
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build linux
+// +build linux
+
+package versioner
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	btrfsSuperMagic = 0x9123683e
+	// The inode number of the root directory of a subvolume
+	btrfsFirstFreeObjectID = 256
+	btrfsSubvolRdonly      = 1 << 1
+
+	// _IOW(0x94, 23, struct btrfs_ioctl_vol_args_v2),
+	// _IOW(0x94, 15, struct btrfs_ioctl_vol_args) and
+	// _IOR(0x94, 31, struct btrfs_ioctl_fs_info_args)
+	btrfsIocSnapCreateV2 = 0x50009417
+	btrfsIocSnapDestroy  = 0x5000940f
+	btrfsIocFsInfo       = 0x8400941f
+
+	// Lets users other than root delete the snapshots they can take
+	userSubvolRmAllowed = "user_subvol_rm_allowed"
+)
+
+// struct btrfs_ioctl_vol_args_v2, with the unions flattened
+type btrfsVolArgsV2 struct {
+	fd      int64
+	transid uint64
+	flags   uint64
+	unused  [4]uint64
+	name    [4040]byte
+}
+
+// struct btrfs_ioctl_vol_args
+type btrfsVolArgs struct {
+	fd   int64
+	name [4088]byte
+}
+
+// struct btrfs_ioctl_fs_info_args, up to the filesystem ID
+type btrfsFsInfoArgs struct {
+	maxID      uint64
+	numDevices uint64
+	fsid       [16]byte
+	_          [992]byte
+}
+
+// btrfsSnapshotsUsable returns nil if the path is the root of a Btrfs
+// subvolume, and we can take snapshots of it into the versions directory
+// and delete them again. That requires the versions directory to be on the
+// same Btrfs filesystem, and deleting snapshots requires root or the
+// user_subvol_rm_allowed mount option.
+func btrfsSnapshotsUsable(path, versions string) error {
+	if err := btrfsSubvolume(path); err != nil {
+		return err
+	}
+
+	// The versions directory is created when needed.
+	for {
+		if _, err := os.Stat(versions); err == nil || filepath.Dir(versions) == versions {
+			break
+		}
+		versions = filepath.Dir(versions)
+	}
+	fsid, err := btrfsFilesystemID(path)
+	if err != nil {
+		return err
+	}
+	if versionsFsid, err := btrfsFilesystemID(versions); err != nil || versionsFsid != fsid {
+		return errors.New("versions directory not on the same Btrfs filesystem")
+	}
+
+	if os.Geteuid() != 0 {
+		opts, err := mountOptions(versions)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(opts, userSubvolRmAllowed) {
+			return errors.New("snapshots can't be deleted without running as root or the " + userSubvolRmAllowed + " mount option")
+		}
+	}
+	return nil
+}
+
+// btrfsFilesystemID returns the ID of the Btrfs filesystem the path is on,
+// the same for all its subvolumes.
+func btrfsFilesystemID(path string) ([16]byte, error) {
+	var args btrfsFsInfoArgs
+	if err := btrfsIoctl(path, btrfsIocFsInfo, unsafe.Pointer(&args), path); err != nil {
+		return [16]byte{}, err
+	}
+	return args.fsid, nil
+}
+
+// mountOptions returns the options of the mount the path is on.
+func mountOptions(path string) ([]string, error) {
+	path, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := os.ReadFile("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	return parseMountOptions(bs, path), nil
+}
+
+// parseMountOptions returns the per mount and filesystem options of the
+// innermost mount of the path, as listed in /proc/self/mountinfo.
+func parseMountOptions(mountinfo []byte, path string) []string {
+	var opts []string
+	longest := -1
+	for _, line := range bytes.Split(mountinfo, []byte("\n")) {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+		before, after, ok := strings.Cut(string(line), " - ")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(before)
+		superFields := strings.Fields(after)
+		if len(fields) < 6 || len(superFields) < 3 {
+			continue
+		}
+		mountPoint := unescapeMountPath(fields[4])
+		if mountPoint != path && !strings.HasPrefix(path, strings.TrimSuffix(mountPoint, "/")+"/") {
+			continue
+		}
+		// Later mounts of the same point hide earlier ones.
+		if len(mountPoint) >= longest {
+			longest = len(mountPoint)
+			opts = append(strings.Split(fields[5], ","), strings.Split(superFields[2], ",")...)
+		}
+	}
+	return opts
+}
+
+// unescapeMountPath undoes the octal escaping of spaces and the like in
+// paths in /proc/self/mountinfo.
+func unescapeMountPath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if c, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(path[i])
+	}
+	return b.String()
+}
+
+// btrfsSubvolume returns nil if the path is the root of a Btrfs subvolume,
+// which can be snapshotted.
+func btrfsSubvolume(path string) error {
+	var statfs unix.Statfs_t
+	if err := unix.Statfs(path, &statfs); err != nil {
+		return err
+	}
+	if uint32(statfs.Type) != btrfsSuperMagic {
+		return errors.New("not on Btrfs")
+	}
+	var stat unix.Stat_t
+	if err := unix.Stat(path, &stat); err != nil {
+		return err
+	}
+	if stat.Ino != btrfsFirstFreeObjectID {
+		return errors.New("not a Btrfs subvolume")
+	}
+	return nil
+}
+
+// createBtrfsSnapshot takes a read-only snapshot of the subvolume at src,
+// as dst.
+func createBtrfsSnapshot(src, dst string) error {
+	srcFd, err := unix.Open(src, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return &os.PathError{Op: "open", Path: src, Err: err}
+	}
+	defer unix.Close(srcFd)
+
+	args := btrfsVolArgsV2{fd: int64(srcFd), flags: btrfsSubvolRdonly}
+	if err := setBtrfsName(args.name[:], filepath.Base(dst)); err != nil {
+		return err
+	}
+	return btrfsIoctl(filepath.Dir(dst), btrfsIocSnapCreateV2, unsafe.Pointer(&args), dst)
+}
+
+// deleteBtrfsSnapshot deletes the snapshot at path.
+func deleteBtrfsSnapshot(path string) error {
+	var args btrfsVolArgs
+	if err := setBtrfsName(args.name[:], filepath.Base(path)); err != nil {
+		return err
+	}
+	return btrfsIoctl(filepath.Dir(path), btrfsIocSnapDestroy, unsafe.Pointer(&args), path)
+}
+
+func setBtrfsName(dst []byte, name string) error {
+	if len(name) >= len(dst) {
+		return errors.New("name too long")
+	}
+	copy(dst, name)
+	return nil
+}
+
+// btrfsIoctl runs the ioctl on the directory, with the arguments naming
+// the subvolume at path within it.
+func btrfsIoctl(dir string, req uintptr, args unsafe.Pointer, path string) error {
+	dirFd, err := unix.Open(dir, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return &os.PathError{Op: "open", Path: dir, Err: err}
+	}
+	defer unix.Close(dirFd)
+
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(dirFd), req, uintptr(args)); errno != 0 {
+		return &os.PathError{Op: "ioctl", Path: path, Err: errno}
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build linux
+// +build linux
+
+package versioner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+// btrfsTestFolder returns a subvolume on a Btrfs filesystem mounted from a
+// loopback image, or skips the test if that isn't possible.
+func btrfsTestFolder(t *testing.T) string {
+	t.Helper()
+	if os.Getuid() != 0 {
+		t.Skip("mounting a Btrfs image requires root")
+	}
+	for _, cmd := range []string{"mkfs.btrfs", "btrfs", "mount", "umount"} {
+		if _, err := exec.LookPath(cmd); err != nil {
+			t.Skip(cmd, "not available")
+		}
+	}
+
+	dir := t.TempDir()
+	img := filepath.Join(dir, "btrfs.img")
+	mnt := filepath.Join(dir, "mnt")
+	run := func(args ...string) error {
+		out, err := exec.Command(args[0], args[1:]...).CombinedOutput()
+		if err != nil {
+			t.Logf("%v: %v: %s", args, err, out)
+		}
+		return err
+	}
+
+	fd, err := os.Create(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fd.Truncate(256 << 20); err != nil {
+		t.Fatal(err)
+	}
+	fd.Close()
+	if err := os.Mkdir(mnt, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if run("mkfs.btrfs", "-q", img) != nil || run("mount", "-o", "loop", img, mnt) != nil {
+		t.Skip("can't mount a Btrfs image")
+	}
+	t.Cleanup(func() {
+		_ = run("umount", mnt)
+	})
+
+	folder := filepath.Join(mnt, "folder")
+	if err := run("btrfs", "subvolume", "create", folder); err != nil {
+		t.Fatal(err)
+	}
+	return folder
+}
+
+func TestSnapshotBtrfs(t *testing.T) {
+	cfg := config.FolderConfiguration{
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           btrfsTestFolder(t),
+		Versioning: config.VersioningConfiguration{
+			Type:   "snapshot",
+			Params: map[string]string{"mode": "btrfs"},
+		},
+	}
+	folderFs := cfg.Filesystem(nil)
+
+	v, ok := newSnapshot(cfg).(*snapshot)
+	if !ok {
+		t.Fatal("expected a snapshot versioner")
+	}
+	// Keep all versions
+	v.stagger.interval = [4]interval{}
+
+	// Files archived together end up in the same snapshot.
+	writeFile(t, folderFs, "a", "A1")
+	writeFile(t, folderFs, "b", "B1")
+	for _, file := range []string{"a", "b"} {
+		if err := v.Archive(file); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := folderFs.Lstat(file); !fs.IsNotExist(err) {
+			t.Fatal("expected file to be archived, got", err)
+		}
+	}
+	if snaps := v.snapshotsLocked(); len(snaps) != 1 {
+		t.Fatal("expected one snapshot, got", snaps)
+	}
+
+	// A changed file needs another one.
+	writeFile(t, folderFs, "a", "A2")
+	later := time.Now().Add(time.Minute)
+	if err := folderFs.Chtimes("a", later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Archive("a"); err != nil {
+		t.Fatal(err)
+	}
+	snaps := v.snapshotsLocked()
+	if len(snaps) != 2 {
+		t.Fatal("expected two snapshots, got", snaps)
+	}
+
+	versions, err := v.GetVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions["a"]) != 2 || len(versions["b"]) != 1 {
+		t.Fatal("unexpected versions", versions)
+	}
+
+	// Restoring archives the current file in turn.
+	writeFile(t, folderFs, "a", "A3")
+	first := versions["a"][0].VersionTime
+	if err := v.Restore("a", first); err != nil {
+		t.Fatal(err)
+	}
+	if content := readFile(t, folderFs, "a"); content != "A1" {
+		t.Errorf("expected A1, got %s", content)
+	}
+	versions, err = v.GetVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions["a"]) != 3 {
+		t.Error("expected the replaced file to be archived, got", versions["a"])
+	}
+
+	// Snapshots beyond the maximum age expire.
+	v.stagger.interval = [4]interval{3: {0, 1}}
+	v.latest = ""
+	time.Sleep(2 * time.Second)
+	if err := v.Clean(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if snaps := v.snapshotsLocked(); len(snaps) != 0 {
+		t.Error("expected snapshots to expire, got", snaps)
+	}
+}
+
+func TestSnapshotBtrfsOtherFilesystem(t *testing.T) {
+	// Snapshots can't be taken into a versions directory on another
+	// filesystem, so files are reflinked there instead.
+	cfg := config.FolderConfiguration{
+		FilesystemType: fs.FilesystemTypeBasic,
+		Path:           btrfsTestFolder(t),
+		Versioning: config.VersioningConfiguration{
+			Type:   "snapshot",
+			Params: map[string]string{"mode": "btrfs"},
+			FSPath: t.TempDir(),
+		},
+	}
+	if _, ok := newSnapshot(cfg).(*staggered); !ok {
+		t.Fatal("expected fallback to staggered versioner")
+	}
+}
+
+func TestParseMountOptions(t *testing.T) {
+	mountinfo := []byte(`22 1 0:21 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+36 22 0:32 / /mnt/data rw,noatime shared:2 - btrfs /dev/sdb1 rw,space_cache=v2,subvolid=5,subvol=/
+37 36 0:32 /home /mnt/data/my\040files rw,noatime shared:3 - btrfs /dev/sdb1 rw,user_subvol_rm_allowed,subvolid=256,subvol=/home
+`)
+
+	cases := []struct {
+		path    string
+		allowed bool
+		fstype  string
+	}{
+		{"/mnt/data/my files/folder", true, "btrfs"},
+		{"/mnt/data/my files", true, "btrfs"},
+		{"/mnt/data/folder", false, "btrfs"},
+		{"/mnt/database", false, "ext4"},
+		{"/home", false, "ext4"},
+	}
+	for _, tc := range cases {
+		opts := parseMountOptions(mountinfo, tc.path)
+		if allowed := slices.Contains(opts, userSubvolRmAllowed); allowed != tc.allowed {
+			t.Errorf("%s: expected %s %v, got options %v", tc.path, userSubvolRmAllowed, tc.allowed, opts)
+		}
+		if btrfs := slices.Contains(opts, "space_cache=v2") || slices.Contains(opts, "subvolid=256"); btrfs != (tc.fstype == "btrfs") {
+			t.Errorf("%s: options of the wrong mount: %v", tc.path, opts)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !linux
+// +build !linux
+
+package versioner
+
+import "errors"
+
+var errBtrfsUnsupported = errors.New("Btrfs snapshots are not supported on this platform")
+
+func btrfsSnapshotsUsable(_, _ string) error {
+	return errBtrfsUnsupported
+}
+
+func createBtrfsSnapshot(_, _ string) error {
+	return errBtrfsUnsupported
+}
+
+func deleteBtrfsSnapshot(string) error {
+	return errBtrfsUnsupported
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2024 The Syncthing Authors.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this file,
+// You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package versioner
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/fs"
+)
+
+func TestSnapshotFallback(t *testing.T) {
+	// A folder that isn't a Btrfs subvolume is versioned by reflinking
+	// files into the versions directory, as far as possible.
+
+	for _, mode := range []string{"", "btrfs", "reflink"} {
+		cfg := config.FolderConfiguration{
+			FilesystemType: fs.FilesystemTypeBasic,
+			Path:           t.TempDir(),
+			Versioning: config.VersioningConfiguration{
+				Params: map[string]string{"mode": mode},
+			},
+		}
+		folderFs := cfg.Filesystem(nil)
+
+		v, ok := newSnapshot(cfg).(*staggered)
+		if !ok {
+			t.Fatalf("%q: expected fallback to staggered versioner", mode)
+		}
+		if v.copyRangeMethod != fs.CopyRangeMethodAllWithFallback {
+			t.Errorf("%q: unexpected copy range method %v", mode, v.copyRangeMethod)
+		}
+
+		writeFile(t, folderFs, "file", "A")
+		if err := v.Archive("file"); err != nil {
+			t.Fatal(err)
+		}
+		versions, err := v.GetVersions()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(versions["file"]) != 1 {
+			t.Errorf("%q: expected one version, got %v", mode, versions)
+		}
+	}
+}
+
+func TestSnapshotExpiry(t *testing.T) {
+	// A snapshot is kept as long as it holds any version of a file that
+	// the staggered rules would keep.
+
+	v := newTestSnapshots(t, map[string][]string{
+		"snapshot~20160415-120000": {"a", "b"},
+		"snapshot~20160415-135930": {"a"},
+		"snapshot~20160415-135940": {"a", "c"},
+		"snapshot~20160415-135950": {"a"},
+		"snapshot~20160415-135955": nil,
+	})
+
+	// "a" is kept in the first and second snapshots only, "c" keeps the
+	// third one, the last one is empty.
+	expired := v.expiredLocked(0, parseTime("20160415-140000"))
+	expected := []string{"snapshot~20160415-135950", "snapshot~20160415-135955"}
+	if !slices.Equal(expired, expected) {
+		t.Errorf("expected %v to expire, got %v", expected, expired)
+	}
+
+	// The latest snapshot is kept regardless.
+	v.latest = "snapshot~20160415-135955"
+	expired = v.expiredLocked(0, parseTime("20160415-140000"))
+	if !slices.Equal(expired, expected[:1]) {
+		t.Errorf("expected %v to expire, got %v", expected[:1], expired)
+	}
+}
+
+func TestSnapshotExpiryRetention(t *testing.T) {
+	// The retention policy expires snapshots the staggered rules keep.
+
+	v := newTestSnapshots(t, map[string][]string{
+		"snapshot~20160415-120000": {"a"},
+		"snapshot~20160415-135930": {"a"},
+		"snapshot~20160415-135940": {"c"},
+	})
+	now := parseTime("20160415-140000")
+	first := []string{"snapshot~20160415-120000"}
+	firstTwo := []string{"snapshot~20160415-120000", "snapshot~20160415-135930"}
+
+	if expired := v.expiredLocked(0, now); len(expired) != 0 {
+		t.Errorf("expected no snapshots to expire, got %v", expired)
+	}
+
+	// Only the latest version of "a" is kept.
+	v.retention.maxVersions = 1
+	if expired := v.expiredLocked(0, now); !slices.Equal(expired, first) {
+		t.Errorf("max versions: expected %v to expire, got %v", first, expired)
+	}
+	v.retention.maxVersions = 0
+
+	// The oldest snapshots go until enough of the files in them are gone.
+	if expired := v.expiredLocked(5, now); !slices.Equal(expired, first) {
+		t.Errorf("free space: expected %v to expire, got %v", first, expired)
+	}
+	if expired := v.expiredLocked(15, now); !slices.Equal(expired, firstTwo) {
+		t.Errorf("free space: expected %v to expire, got %v", firstTwo, expired)
+	}
+	v.retention.maxTotalSize = 15
+	if expired := v.expiredLocked(0, now); !slices.Equal(expired, firstTwo) {
+		t.Errorf("total size: expected %v to expire, got %v", firstTwo, expired)
+	}
+
+	// The latest snapshot is kept regardless.
+	v.latest = "snapshot~20160415-135940"
+	if expired := v.expiredLocked(100, now); !slices.Equal(expired, firstTwo) {
+		t.Errorf("expected %v to expire, got %v", firstTwo, expired)
+	}
+}
+
+// newTestSnapshots returns a snapshot versioner with the given snapshots,
+// named after when they were taken, holding ten byte versions of the
+// files.
+func newTestSnapshots(t *testing.T, snapshots map[string][]string) *snapshot {
+	t.Helper()
+	versionsFs := fs.NewFilesystem(fs.FilesystemTypeFake, t.Name()+"?content=true")
+	v := &snapshot{
+		versionsFs: versionsFs,
+		stagger:    staggered{interval: staggeredIntervals(nil)},
+	}
+	for name, files := range snapshots {
+		if err := versionsFs.MkdirAll(name, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		v.latest = name
+		for _, file := range files {
+			writeFile(t, versionsFs, filepath.Join(name, file), "0123456789")
+			if err := v.recordLocked(file); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	v.latest = ""
+	return v
+}
@@ -32,35 +32,40 @@ type staggered struct {
 	versionsFs      fs.Filesystem
 	interval        [4]interval
 	copyRangeMethod fs.CopyRangeMethod
+	retention       retention
 }
 
 func newStaggered(cfg config.FolderConfiguration) Versioner {
-	params := cfg.Versioning.Params
-	maxAge, err := strconv.ParseInt(params["maxAge"], 10, 0)
-	if err != nil {
-		maxAge = 31536000 // Default: ~1 year
-	}
-
 	versionsFs := versionerFsFromFolderCfg(cfg)
 
 	s := &staggered{
-		folderFs:   cfg.Filesystem(nil),
-		versionsFs: versionsFs,
-		interval: [4]interval{
-			{30, 60 * 60},                     // first hour -> 30 sec between versions
-			{60 * 60, 24 * 60 * 60},           // next day -> 1 h between versions
-			{24 * 60 * 60, 30 * 24 * 60 * 60}, // next 30 days -> 1 day between versions
-			{7 * 24 * 60 * 60, maxAge},        // next year -> 1 week between versions
-		},
+		folderFs:        cfg.Filesystem(nil),
+		versionsFs:      versionsFs,
+		interval:        staggeredIntervals(cfg.Versioning.Params),
 		copyRangeMethod: cfg.CopyRangeMethod,
+		retention:       newRetention(cfg.Versioning),
 	}
 
 	l.Debugf("instantiated %#v", s)
 	return s
 }
 
+func staggeredIntervals(params map[string]string) [4]interval {
+	maxAge, err := strconv.ParseInt(params["maxAge"], 10, 0)
+	if err != nil {
+		maxAge = 31536000 // Default: ~1 year
+	}
+
+	return [4]interval{
+		{30, 60 * 60},                     // first hour -> 30 sec between versions
+		{60 * 60, 24 * 60 * 60},           // next day -> 1 h between versions
+		{24 * 60 * 60, 30 * 24 * 60 * 60}, // next 30 days -> 1 day between versions
+		{7 * 24 * 60 * 60, maxAge},        // next year -> 1 week between versions
+	}
+}
+
 func (v *staggered) Clean(ctx context.Context) error {
-	return clean(ctx, v.versionsFs, v.toRemove)
+	return clean(ctx, v.versionsFs, v.toRemove, v.retention)
 }
 
 func (v *staggered) toRemove(versions []string, now time.Time) []string {
@@ -125,6 +130,18 @@ func (v *staggered) Archive(filePath string) error {
 	return nil
 }
 
+// ArchiveFrom moves the file at srcPath to the version archive as a version
+// of the named file.
+func (v *staggered) ArchiveFrom(srcPath, filePath string) error {
+	if err := archiveFileFrom(v.copyRangeMethod, v.folderFs, v.versionsFs, srcPath, filePath, TagFilename); err != nil {
+		return err
+	}
+
+	cleanVersions(v.versionsFs, findAllVersions(v.versionsFs, filePath), v.toRemove)
+
+	return nil
+}
+
 func (v *staggered) GetVersions() (map[string][]FileVersion, error) {
 	return retrieveVersions(v.versionsFs)
 }
@@ -26,6 +26,7 @@ type trashcan struct {
 	versionsFs      fs.Filesystem
 	cleanoutDays    int
 	copyRangeMethod fs.CopyRangeMethod
+	retention       retention
 }
 
 func newTrashcan(cfg config.FolderConfiguration) Versioner {
@@ -37,6 +38,7 @@ func newTrashcan(cfg config.FolderConfiguration) Versioner {
 		versionsFs:      versionerFsFromFolderCfg(cfg),
 		cleanoutDays:    cleanoutDays,
 		copyRangeMethod: cfg.CopyRangeMethod,
+		retention:       newRetention(cfg.Versioning),
 	}
 
 	l.Debugf("instantiated %#v", s)
@@ -51,12 +53,20 @@ func (t *trashcan) Archive(filePath string) error {
 	})
 }
 
+// ArchiveFrom moves the file at srcPath to the trash can in place of the
+// named file.
+func (t *trashcan) ArchiveFrom(srcPath, filePath string) error {
+	return archiveFileFrom(t.copyRangeMethod, t.folderFs, t.versionsFs, srcPath, filePath, func(name, tag string) string {
+		return name
+	})
+}
+
 func (t *trashcan) String() string {
 	return fmt.Sprintf("trashcan@%p", t)
 }
 
 func (t *trashcan) Clean(ctx context.Context) error {
-	if t.cleanoutDays <= 0 {
+	if t.cleanoutDays <= 0 && !t.retention.enabled() {
 		return nil
 	}
 
@@ -66,6 +76,7 @@ func (t *trashcan) Clean(ctx context.Context) error {
 
 	cutoff := time.Now().Add(time.Duration(-24*t.cleanoutDays) * time.Hour)
 	dirTracker := make(emptyDirTracker)
+	var remaining []version
 
 	walkFn := func(path string, info fs.FileInfo, err error) error {
 		if err != nil {
@@ -83,13 +94,19 @@ func (t *trashcan) Clean(ctx context.Context) error {
 			return nil
 		}
 
-		if info.ModTime().Before(cutoff) {
+		if t.cleanoutDays > 0 && info.ModTime().Before(cutoff) {
 			// The file is too old; remove it.
 			err = t.versionsFs.Remove(path)
 		} else {
 			// Keep this file, and remember it so we don't unnecessarily try
 			// to remove this directory.
 			dirTracker.addFile(path)
+			// Versions are untagged, except those archived when restoring.
+			name, tag := UntagFilename(path)
+			if _, err := time.Parse(TimeFormat, tag); err != nil {
+				name = path
+			}
+			remaining = append(remaining, newVersion(path, name, info))
 		}
 		return err
 	}
@@ -98,6 +115,8 @@ func (t *trashcan) Clean(ctx context.Context) error {
 		return err
 	}
 
+	t.retention.apply(t.versionsFs, remaining)
+
 	dirTracker.deleteEmptyDirs(t.versionsFs)
 
 	return nil
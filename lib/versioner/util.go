@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -137,9 +138,16 @@ type fileTagger func(string, string) string
 
 func archiveFile(method fs.CopyRangeMethod, srcFs, dstFs fs.Filesystem, filePath string, tagger fileTagger) error {
 	filePath = osutil.NativeFilename(filePath)
-	info, err := srcFs.Lstat(filePath)
+	return archiveFileFrom(method, srcFs, dstFs, filePath, filePath, tagger)
+}
+
+// archiveFileFrom archives the file at srcPath as a version of filePath.
+func archiveFileFrom(method fs.CopyRangeMethod, srcFs, dstFs fs.Filesystem, srcPath, filePath string, tagger fileTagger) error {
+	srcPath = osutil.NativeFilename(srcPath)
+	filePath = osutil.NativeFilename(filePath)
+	info, err := srcFs.Lstat(srcPath)
 	if fs.IsNotExist(err) {
-		l.Debugln("not archiving nonexistent file", filePath)
+		l.Debugln("not archiving nonexistent file", srcPath)
 		return nil
 	} else if err != nil {
 		return err
@@ -175,8 +183,8 @@ func archiveFile(method fs.CopyRangeMethod, srcFs, dstFs fs.Filesystem, filePath
 
 	ver := tagger(file, now.Format(TimeFormat))
 	dst := filepath.Join(inFolderPath, ver)
-	l.Debugln("archiving", filePath, "moving to", dst)
-	err = osutil.RenameOrCopy(method, srcFs, dstFs, filePath, dst)
+	l.Debugln("archiving", srcPath, "moving to", dst)
+	err = osutil.RenameOrCopy(method, srcFs, dstFs, srcPath, dst)
 
 	mtime := info.ModTime()
 	// If it's a trashcan versioner type thing, then it does not have version time in the name
@@ -300,7 +308,7 @@ func findAllVersions(fs fs.Filesystem, filePath string) []string {
 	return versions
 }
 
-func clean(ctx context.Context, versionsFs fs.Filesystem, toRemove func([]string, time.Time) []string) error {
+func clean(ctx context.Context, versionsFs fs.Filesystem, toRemove func([]string, time.Time) []string, retention retention) error {
 	l.Debugln("Versioner clean: Cleaning", versionsFs)
 
 	if _, err := versionsFs.Stat("."); fs.IsNotExist(err) {
@@ -309,6 +317,7 @@ func clean(ctx context.Context, versionsFs fs.Filesystem, toRemove func([]string
 	}
 
 	versionsPerFile := make(map[string][]string)
+	infos := make(map[string]fs.FileInfo)
 	dirTracker := make(emptyDirTracker)
 
 	walkFn := func(path string, f fs.FileInfo, err error) error {
@@ -335,6 +344,7 @@ func clean(ctx context.Context, versionsFs fs.Filesystem, toRemove func([]string
 		}
 
 		versionsPerFile[name] = append(versionsPerFile[name], path)
+		infos[path] = f
 
 		return nil
 	}
@@ -346,26 +356,38 @@ func clean(ctx context.Context, versionsFs fs.Filesystem, toRemove func([]string
 		return err
 	}
 
-	for _, versionList := range versionsPerFile {
+	var remaining []version
+	for name, versionList := range versionsPerFile {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 		}
-		cleanVersions(versionsFs, versionList, toRemove)
+		removed := cleanVersions(versionsFs, versionList, toRemove)
+		for _, path := range versionList {
+			if slices.Contains(removed, path) {
+				continue
+			}
+			remaining = append(remaining, newVersion(path, name, infos[path]))
+		}
 	}
 
+	retention.apply(versionsFs, remaining)
+
 	dirTracker.deleteEmptyDirs(versionsFs)
 
 	l.Debugln("Cleaner: Finished cleaning", versionsFs)
 	return nil
 }
 
-func cleanVersions(versionsFs fs.Filesystem, versions []string, toRemove func([]string, time.Time) []string) {
+// cleanVersions removes the versions to remove, returning them.
+func cleanVersions(versionsFs fs.Filesystem, versions []string, toRemove func([]string, time.Time) []string) []string {
 	l.Debugln("Versioner: Expiring versions", versions)
-	for _, file := range toRemove(versions, time.Now()) {
+	remove := toRemove(versions, time.Now())
+	for _, file := range remove {
 		if err := versionsFs.Remove(file); err != nil {
 			l.Warnf("Versioner: can't remove %q: %v", file, err)
 		}
 	}
+	return remove
 }
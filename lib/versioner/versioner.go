@@ -15,10 +15,12 @@ import (
 	"time"
 
 	"github.com/syncthing/syncthing/lib/config"
+	"github.com/syncthing/syncthing/lib/protocol"
 )
 
 type Versioner interface {
 	Archive(filePath string) error
+	ArchiveFrom(srcPath, filePath string) error
 	GetVersions() (map[string][]FileVersion, error)
 	Restore(filePath string, versionTime time.Time) error
 	Clean(context.Context) error
@@ -32,23 +34,54 @@ type FileVersion struct {
 
 type factory func(cfg config.FolderConfiguration) Versioner
 
+// A FileLookup returns the named file as currently recorded in the
+// database, for versioners passing its metadata on when archiving.
+type FileLookup func(name string) (protocol.FileInfo, bool)
+
+type fileLookupSetter interface {
+	setFileLookup(FileLookup)
+}
+
+// A stopper is a versioner holding on to resources, like a running
+// process, that should be released when the folder stops.
+type stopper interface {
+	stop()
+}
+
+// Stop releases what the versioner holds on to while its folder is
+// running. It may still be used afterwards.
+func Stop(v Versioner) {
+	if s, ok := v.(stopper); ok {
+		s.stop()
+	}
+}
+
 var factories = make(map[string]factory)
 
-var ErrRestorationNotSupported = errors.New("version restoration not supported with the current versioner")
+var (
+	ErrRestorationNotSupported     = errors.New("version restoration not supported with the current versioner")
+	ErrLocalVersioningNotSupported = errors.New("versioning local changes not supported with the current versioner")
+)
 
 const (
 	TimeFormat = "20060102-150405"
 	timeGlob   = "[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]-[0-9][0-9][0-9][0-9][0-9][0-9]" // glob pattern matching TimeFormat
 )
 
-func New(cfg config.FolderConfiguration) (Versioner, error) {
+// New returns the versioner for the folder. The lookup may be nil.
+func New(cfg config.FolderConfiguration, lookup FileLookup) (Versioner, error) {
 	fac, ok := factories[cfg.Versioning.Type]
 	if !ok {
 		return nil, fmt.Errorf("requested versioning type %q does not exist", cfg.Versioning.Type)
 	}
 
+	v := fac(cfg)
+	if setter, ok := v.(fileLookupSetter); ok && lookup != nil {
+		setter.setFileLookup(lookup)
+	}
+
 	return &versionerWithErrorContext{
-		Versioner: fac(cfg),
+		Versioner: v,
 		vtype:     cfg.Versioning.Type,
 	}, nil
 }
@@ -58,6 +91,10 @@ type versionerWithErrorContext struct {
 	vtype string
 }
 
+func (v *versionerWithErrorContext) stop() {
+	Stop(v.Versioner)
+}
+
 func (v *versionerWithErrorContext) wrapError(err error, op string) error {
 	if err != nil {
 		return fmt.Errorf("%s versioner: %v: %w", v.vtype, op, err)
@@ -69,6 +106,10 @@ func (v *versionerWithErrorContext) Archive(filePath string) error {
 	return v.wrapError(v.Versioner.Archive(filePath), "archive")
 }
 
+func (v *versionerWithErrorContext) ArchiveFrom(srcPath, filePath string) error {
+	return v.wrapError(v.Versioner.ArchiveFrom(srcPath, filePath), "archive")
+}
+
 func (v *versionerWithErrorContext) GetVersions() (map[string][]FileVersion, error) {
 	versions, err := v.Versioner.GetVersions()
 	return versions, v.wrapError(err, "get versions")